@@ -228,6 +228,11 @@ type StoreSpec struct {
 	EncryptionOptions []byte
 	// ProvisionedRateSpec is optional.
 	ProvisionedRateSpec ProvisionedRateSpec
+	// RaftLogPath, if set, is the directory of a separate storage engine which
+	// holds the raft state of the store's replicas, i.e. their raft logs,
+	// HardStates and RaftTruncatedStates. For an in memory store, the directory
+	// is created on the store's (possibly sticky) in memory file system.
+	RaftLogPath string
 }
 
 // String returns a fully parsable version of the store spec.
@@ -274,6 +279,9 @@ func (ss StoreSpec) String() string {
 		fmt.Fprintf(&buffer, "provisioned-rate=bandwidth=%s/s,",
 			humanizeutil.IBytes(ss.ProvisionedRateSpec.ProvisionedBandwidth))
 	}
+	if len(ss.RaftLogPath) != 0 {
+		fmt.Fprintf(&buffer, "raft-log-path=%s,", ss.RaftLogPath)
+	}
 	// Trim the extra comma from the end if it exists.
 	if l := buffer.Len(); l > 0 {
 		buffer.Truncate(l - 1)
@@ -319,6 +327,8 @@ var fractionRegex = regexp.MustCompile(`^([-]?([0-9]+\.[0-9]*|[0-9]*\.[0-9]+|[0-
 //   - provisioned-rate=bandwidth=<bandwidth-bytes/s> The provisioned-rate can be
 //     used for admission control for operations on the store and if unspecified,
 //     a cluster setting (kvadmission.store.provisioned_bandwidth) will be used.
+//   - raft-log-path=xxx The optional directory of a separate storage engine
+//     holding the raft logs of the store's replicas.
 //
 // Note that commas are forbidden within any field name or value.
 func NewStoreSpec(value string) (StoreSpec, error) {
@@ -448,6 +458,8 @@ func NewStoreSpec(value string) (StoreSpec, error) {
 				return StoreSpec{}, err
 			}
 			ss.ProvisionedRateSpec = rateSpec
+		case "raft-log-path":
+			ss.RaftLogPath = value
 
 		default:
 			return StoreSpec{}, fmt.Errorf("%s is not a valid store field", field)
@@ -467,6 +479,9 @@ func NewStoreSpec(value string) (StoreSpec, error) {
 	} else if ss.Path == "" {
 		return StoreSpec{}, fmt.Errorf("no path specified")
 	}
+	if ss.RaftLogPath != "" && ss.RaftLogPath == ss.Path {
+		return StoreSpec{}, fmt.Errorf("raft-log-path must differ from the store path")
+	}
 	return ss, nil
 }
 
@@ -157,6 +157,15 @@ target_file_size=2097152`
 		{"path=/mnt/hda1,provisioned-rate=200MiB/s", "provisioned-rate field has invalid value 200MiB/s", StoreSpec{}},
 		{"path=/mnt/hda1,provisioned-rate=bandwidth=0B/s", "provisioned-rate field is trying to set bandwidth to 0", StoreSpec{}},
 
+		// raft log path
+		{"path=/mnt/hda1,raft-log-path=/mnt/hdb1", "", StoreSpec{Path: "/mnt/hda1", RaftLogPath: "/mnt/hdb1"}},
+		{"type=mem,size=20GiB,raft-log-path=raft", "", StoreSpec{
+			Size:        SizeSpec{InBytes: 21474836480},
+			InMemory:    true,
+			RaftLogPath: "raft",
+		}},
+		{"path=/mnt/hda1,raft-log-path=/mnt/hda1", "raft-log-path must differ from the store path", StoreSpec{}},
+
 		// RocksDB
 		{"path=/,rocksdb=key1=val1;key2=val2", "", StoreSpec{Path: "/", RocksDBOptions: "key1=val1;key2=val2"}},
 
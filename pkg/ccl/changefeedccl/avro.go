@@ -613,6 +613,16 @@ func typeToAvroSchema(typ *types.T) (*avroSchemaField, error) {
 				return tree.ParseDTSQuery(x.(string))
 			},
 		)
+	case types.JSONPathFamily:
+		setNullable(
+			avroSchemaString,
+			func(d tree.Datum, _ interface{}) (interface{}, error) {
+				return d.(*tree.DJSONPath).Path.String(), nil
+			},
+			func(x interface{}) (tree.Datum, error) {
+				return tree.ParseDJSONPath(x.(string))
+			},
+		)
 	case types.TSVectorFamily:
 		setNullable(
 			avroSchemaString,
@@ -623,6 +633,34 @@ func typeToAvroSchema(typ *types.T) (*avroSchemaField, error) {
 				return tree.ParseDTSVector(x.(string))
 			},
 		)
+	case types.RangeFamily:
+		setNullable(
+			avroSchemaString,
+			func(d tree.Datum, _ interface{}) (interface{}, error) {
+				return tree.AsStringWithFlags(d, tree.FmtBareStrings), nil
+			},
+			func(x interface{}) (tree.Datum, error) {
+				d, _, err := tree.ParseDRangeFromString(nil /* ctx */, x.(string), typ)
+				if err != nil {
+					return nil, err
+				}
+				return d, nil
+			},
+		)
+	case types.MultirangeFamily:
+		setNullable(
+			avroSchemaString,
+			func(d tree.Datum, _ interface{}) (interface{}, error) {
+				return tree.AsStringWithFlags(d, tree.FmtBareStrings), nil
+			},
+			func(x interface{}) (tree.Datum, error) {
+				d, _, err := tree.ParseDMultirangeFromString(nil /* ctx */, x.(string), typ)
+				if err != nil {
+					return nil, err
+				}
+				return d, nil
+			},
+		)
 	case types.EnumFamily:
 		setNullable(
 			avroSchemaString,
@@ -0,0 +1,177 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package cdceval
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/lib/pq/oid"
+)
+
+// ValueFilterForExpression returns the filter which may be pushed down to the
+// rangefeeds of a changefeed with the given (normalized) select clause, so that
+// the rangefeed servers drop the events which the changefeed would discard.
+// Returns nil if nothing can be pushed down.
+//
+// The filter restricts events to the target column family, and contains the
+// top level conjuncts of the WHERE clause which compare a non primary key
+// column of the target family to constants, i.e. `col = const` and
+// `col IN (const, ...)`. Only columns of types whose value encoding is
+// canonical are considered. Everything else in the WHERE clause is left to the
+// changefeed to evaluate, which it does for every event regardless.
+func ValueFilterForExpression(
+	ctx context.Context,
+	desc catalog.TableDescriptor,
+	target jobspb.ChangefeedTargetSpecification,
+	sc *tree.SelectClause,
+) *kvpb.RangeFeedValueFilter {
+	var family *descpb.ColumnFamilyDescriptor
+	switch target.Type {
+	case jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY,
+		jobspb.ChangefeedTargetSpecification_COLUMN_FAMILY:
+		fd, err := getTargetFamilyDescriptor(desc, target)
+		if err != nil {
+			return nil
+		}
+		family = fd
+	default:
+		return nil
+	}
+
+	filter := &kvpb.RangeFeedValueFilter{ColumnFamilies: []uint32{uint32(family.ID)}}
+	if sc.Where == nil {
+		return filter
+	}
+
+	keyCols := desc.GetPrimaryIndex().CollectKeyColumnIDs()
+	famCols := catalog.MakeTableColSet(family.ColumnIDs...)
+	semaCtx := tree.MakeSemaContext()
+
+	// column returns the column referenced by the expression, if it is one
+	// whose values can be filtered on by the rangefeed server.
+	column := func(expr tree.Expr) catalog.Column {
+		name, ok := tree.StripParens(expr).(*tree.UnresolvedName)
+		if !ok || name.NumParts != 1 {
+			return nil
+		}
+		col := catalog.FindColumnByName(desc, name.Parts[0])
+		if col == nil || !col.Public() || col.IsVirtual() ||
+			keyCols.Contains(col.GetID()) || !famCols.Contains(col.GetID()) ||
+			!hasCanonicalValueEncoding(col.GetType()) {
+			return nil
+		}
+		return col
+	}
+	// encode returns the value encoding of the constant expression as a datum
+	// of the column's type.
+	encode := func(expr tree.Expr, col catalog.Column) ([]byte, bool) {
+		var d tree.Datum
+		switch t := tree.StripParens(expr).(type) {
+		case tree.Constant:
+			typed, err := t.ResolveAsType(ctx, &semaCtx, col.GetType())
+			if err != nil {
+				return nil, false
+			}
+			if d, _ = typed.(tree.Datum); d == nil {
+				return nil, false
+			}
+		case tree.Datum:
+			d = t
+		default:
+			return nil, false
+		}
+		if d == tree.DNull || !d.ResolvedType().Equivalent(col.GetType()) {
+			return nil, false
+		}
+		enc, err := valueside.Encode(nil, valueside.NoColumnID, d, nil /* scratch */)
+		if err != nil {
+			return nil, false
+		}
+		return enc, true
+	}
+	addPredicate := func(expr tree.Expr) {
+		cmp, ok := expr.(*tree.ComparisonExpr)
+		if !ok {
+			return
+		}
+		var pred kvpb.RangeFeedValueFilter_ColumnPredicate
+		switch cmp.Operator.Symbol {
+		case treecmp.EQ:
+			left, right := cmp.Left, cmp.Right
+			col := column(left)
+			if col == nil {
+				left, right = right, left
+				if col = column(left); col == nil {
+					return
+				}
+			}
+			enc, ok := encode(right, col)
+			if !ok {
+				return
+			}
+			pred = kvpb.RangeFeedValueFilter_ColumnPredicate{
+				ColumnID: uint32(col.GetID()), Values: [][]byte{enc},
+			}
+		case treecmp.In:
+			col := column(cmp.Left)
+			tuple, ok := tree.StripParens(cmp.Right).(*tree.Tuple)
+			if col == nil || !ok || len(tuple.Exprs) == 0 {
+				return
+			}
+			pred = kvpb.RangeFeedValueFilter_ColumnPredicate{ColumnID: uint32(col.GetID())}
+			for _, e := range tuple.Exprs {
+				enc, ok := encode(e, col)
+				if !ok {
+					return
+				}
+				pred.Values = append(pred.Values, enc)
+			}
+		default:
+			return
+		}
+		filter.Predicates = append(filter.Predicates, pred)
+	}
+
+	var visitConjuncts func(expr tree.Expr)
+	visitConjuncts = func(expr tree.Expr) {
+		expr = tree.StripParens(expr)
+		if and, ok := expr.(*tree.AndExpr); ok {
+			visitConjuncts(and.Left)
+			visitConjuncts(and.Right)
+			return
+		}
+		addPredicate(expr)
+	}
+	visitConjuncts(sc.Where.Expr)
+	return filter
+}
+
+// hasCanonicalValueEncoding returns whether equal datums of the type always
+// have the same value encoding, and values of the type compare equal only if
+// they are identical. This allows the rangefeed servers to compare the
+// encoded datums byte-wise.
+func hasCanonicalValueEncoding(typ *types.T) bool {
+	switch typ.Family() {
+	case types.IntFamily, types.BoolFamily, types.UuidFamily, types.BytesFamily:
+		return true
+	case types.StringFamily:
+		// CHAR(n) ignores trailing spaces when comparing.
+		return typ.Oid() == oid.T_text || typ.Oid() == oid.T_varchar
+	default:
+		return false
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package cdceval
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdctest"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueFilterForExpression(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(context.Background())
+	s := srv.ApplicationLayer()
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `CREATE TABLE foo (
+a INT PRIMARY KEY,
+b INT,
+s STRING,
+c CHAR(3),
+f FLOAT,
+e STRING,
+FAMILY main (a, b, s, c, f),
+FAMILY extra (e)
+)`)
+	fooDesc := cdctest.GetHydratedTableDescriptor(t, s.ExecutorConfig(), "foo")
+
+	encode := func(d tree.Datum) []byte {
+		enc, err := valueside.Encode(nil, valueside.NoColumnID, d, nil)
+		require.NoError(t, err)
+		return enc
+	}
+	pred := func(colID uint32, datums ...tree.Datum) kvpb.RangeFeedValueFilter_ColumnPredicate {
+		p := kvpb.RangeFeedValueFilter_ColumnPredicate{ColumnID: colID}
+		for _, d := range datums {
+			p.Values = append(p.Values, encode(d))
+		}
+		return p
+	}
+	mainTarget := jobspb.ChangefeedTargetSpecification{
+		Type:       jobspb.ChangefeedTargetSpecification_COLUMN_FAMILY,
+		TableID:    fooDesc.GetID(),
+		FamilyName: "main",
+	}
+	extraTarget := jobspb.ChangefeedTargetSpecification{
+		Type:       jobspb.ChangefeedTargetSpecification_COLUMN_FAMILY,
+		TableID:    fooDesc.GetID(),
+		FamilyName: "extra",
+	}
+
+	for _, tc := range []struct {
+		name   string
+		target jobspb.ChangefeedTargetSpecification
+		stmt   string
+		expect *kvpb.RangeFeedValueFilter
+	}{
+		{
+			name:   "no where clause",
+			target: mainTarget,
+			stmt:   "SELECT * FROM foo",
+			expect: &kvpb.RangeFeedValueFilter{ColumnFamilies: []uint32{0}},
+		},
+		{
+			name:   "each family",
+			target: jobspb.ChangefeedTargetSpecification{Type: jobspb.ChangefeedTargetSpecification_EACH_FAMILY},
+			stmt:   "SELECT * FROM foo WHERE b = 1",
+		},
+		{
+			name:   "equality and in",
+			target: mainTarget,
+			stmt:   "SELECT * FROM foo WHERE b = 1 AND (s IN ('x', 'y') AND 2 = b)",
+			expect: &kvpb.RangeFeedValueFilter{
+				ColumnFamilies: []uint32{0},
+				Predicates: []kvpb.RangeFeedValueFilter_ColumnPredicate{
+					pred(2, tree.NewDInt(1)),
+					pred(3, tree.NewDString("x"), tree.NewDString("y")),
+					pred(2, tree.NewDInt(2)),
+				},
+			},
+		},
+		{
+			name:   "unsupported conjuncts are skipped",
+			target: mainTarget,
+			stmt:   "SELECT * FROM foo WHERE a = 1 AND f = 1.5 AND c = 'x' AND b > 1 AND s = 'x' AND b = s::INT",
+			expect: &kvpb.RangeFeedValueFilter{
+				ColumnFamilies: []uint32{0},
+				Predicates: []kvpb.RangeFeedValueFilter_ColumnPredicate{
+					pred(3, tree.NewDString("x")),
+				},
+			},
+		},
+		{
+			name:   "disjunction",
+			target: mainTarget,
+			stmt:   "SELECT * FROM foo WHERE b = 1 OR s = 'x'",
+			expect: &kvpb.RangeFeedValueFilter{ColumnFamilies: []uint32{0}},
+		},
+		{
+			name:   "column of another family",
+			target: mainTarget,
+			stmt:   "SELECT * FROM foo WHERE e = 'x'",
+			expect: &kvpb.RangeFeedValueFilter{ColumnFamilies: []uint32{0}},
+		},
+		{
+			name:   "other family",
+			target: extraTarget,
+			stmt:   "SELECT * FROM foo WHERE e = 'x'",
+			expect: &kvpb.RangeFeedValueFilter{
+				ColumnFamilies: []uint32{1},
+				Predicates: []kvpb.RangeFeedValueFilter_ColumnPredicate{
+					pred(6, tree.NewDString("x")),
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sc, err := ParseChangefeedExpression(tc.stmt)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, ValueFilterForExpression(context.Background(), fooDesc, tc.target, sc))
+		})
+	}
+}
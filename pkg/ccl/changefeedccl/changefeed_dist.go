@@ -21,6 +21,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvcoord"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql"
@@ -222,6 +223,28 @@ func fetchSpansForTables(
 		sd, tableDescs[0], initialHighwater, target, sc)
 }
 
+// valueFilterForTables returns the filter that the rangefeeds of the
+// changefeed may push down to the rangefeed servers. It is nil unless the
+// changefeed has a select clause from which a filter can be derived.
+func valueFilterForTables(
+	ctx context.Context,
+	execCtx sql.JobExecContext,
+	tableDescs []catalog.TableDescriptor,
+	details jobspb.ChangefeedDetails,
+) (*kvpb.RangeFeedValueFilter, error) {
+	if details.Select == "" || len(tableDescs) != 1 ||
+		!changefeedbase.PushDownRangefeedValueFilter.Get(&execCtx.ExecCfg().Settings.SV) {
+		return nil, nil
+	}
+	sc, err := cdceval.ParseChangefeedExpression(details.Select)
+	if err != nil {
+		return nil, pgerror.Wrap(err, pgcode.InvalidParameterValue,
+			"could not parse changefeed expression")
+	}
+	return cdceval.ValueFilterForExpression(
+		ctx, tableDescs[0], details.TargetSpecifications[0], sc), nil
+}
+
 // startDistChangefeed starts distributed changefeed execution.
 func startDistChangefeed(
 	ctx context.Context,
@@ -246,6 +269,10 @@ func startDistChangefeed(
 	if err != nil {
 		return err
 	}
+	valueFilter, err := valueFilterForTables(ctx, execCtx, tableDescs, details)
+	if err != nil {
+		return err
+	}
 	if log.ExpensiveLogEnabled(ctx, 2) {
 		log.Infof(ctx, "tracked spans: %s", trackedSpans)
 	}
@@ -262,7 +289,7 @@ func startDistChangefeed(
 		checkpoint = progress.Checkpoint
 	}
 	p, planCtx, err := makePlan(execCtx, jobID, details, initialHighWater,
-		trackedSpans, checkpoint, localState.drainingNodes)(ctx, dsp)
+		trackedSpans, valueFilter, checkpoint, localState.drainingNodes)(ctx, dsp)
 	if err != nil {
 		return err
 	}
@@ -371,6 +398,7 @@ func makePlan(
 	details jobspb.ChangefeedDetails,
 	initialHighWater hlc.Timestamp,
 	trackedSpans []roachpb.Span,
+	valueFilter *kvpb.RangeFeedValueFilter,
 	checkpoint *jobspb.ChangefeedProgress_Checkpoint,
 	drainingNodes []roachpb.NodeID,
 ) func(context.Context, *sql.DistSQLPlanner) (*sql.PhysicalPlan, *sql.PlanningCtx, error) {
@@ -469,12 +497,13 @@ func makePlan(
 			}
 
 			aggregatorSpecs[i] = &execinfrapb.ChangeAggregatorSpec{
-				Watches:    watches,
-				Checkpoint: aggregatorCheckpoint,
-				Feed:       details,
-				UserProto:  execCtx.User().EncodeProto(),
-				JobID:      jobID,
-				Select:     execinfrapb.Expression{Expr: details.Select},
+				Watches:     watches,
+				Checkpoint:  aggregatorCheckpoint,
+				Feed:        details,
+				UserProto:   execCtx.User().EncodeProto(),
+				JobID:       jobID,
+				Select:      execinfrapb.Expression{Expr: details.Select},
+				ValueFilter: valueFilter,
 			}
 		}
 
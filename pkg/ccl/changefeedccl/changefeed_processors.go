@@ -487,6 +487,7 @@ func (ca *changeAggregator) makeKVFeedCfg(
 		EndTime:             config.EndTime,
 		WithDiff:            filters.WithDiff,
 		WithFiltering:       filters.WithFiltering,
+		ValueFilter:         ca.spec.ValueFilter,
 		NeedsInitialScan:    needsInitialScan,
 		SchemaChangeEvents:  schemaChange.EventClass,
 		SchemaChangePolicy:  schemaChange.Policy,
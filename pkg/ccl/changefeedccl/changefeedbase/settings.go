@@ -265,6 +265,16 @@ var PerEventElasticCPUControlEnabled = settings.RegisterBoolSetting(
 	true,
 )
 
+// PushDownRangefeedValueFilter determines whether changefeeds with a WHERE
+// clause ask the rangefeed servers to filter out the rows which can't match it.
+var PushDownRangefeedValueFilter = settings.RegisterBoolSetting(
+	settings.ApplicationLevel,
+	"changefeed.rangefeed_value_filter.enabled",
+	"if enabled, changefeeds push the column family and equality predicates of their "+
+		"WHERE clause down to the rangefeeds, filtering rows before they are sent to the aggregators",
+	true,
+)
+
 // RequireExternalConnectionSink is used to restrict non-admins with the CHANGEFEED privilege
 // to create changefeeds to external connections only.
 var RequireExternalConnectionSink = settings.RegisterBoolSetting(
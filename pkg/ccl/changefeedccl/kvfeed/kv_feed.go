@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvcoord"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
@@ -91,6 +92,12 @@ type Config struct {
 	// enables filtering out any transactional writes with that flag set to true.
 	WithFiltering bool
 
+	// ValueFilter, if set, is propagated via the RangefeedRequest to the
+	// rangefeed server, which then only emits the values matching it. Events
+	// are still filtered by the changefeed, the server-side filter only saves
+	// sending events that would be discarded.
+	ValueFilter *kvpb.RangeFeedValueFilter
+
 	// Knobs are kvfeed testing knobs.
 	Knobs TestingKnobs
 }
@@ -128,6 +135,7 @@ func Run(ctx context.Context, cfg Config) error {
 		cfg.SchemaFeed,
 		sc, pff, bf, cfg.Targets, cfg.Knobs)
 	f.onBackfillCallback = cfg.MonitoringCfg.OnBackfillCallback
+	f.valueFilter = cfg.ValueFilter
 	f.rangeObserver = startLaggingRangesObserver(g, cfg.MonitoringCfg.LaggingRangesCallback,
 		cfg.MonitoringCfg.LaggingRangesPollingInterval, cfg.MonitoringCfg.LaggingRangesThreshold)
 
@@ -252,6 +260,7 @@ type kvFeed struct {
 
 	onBackfillCallback func() func()
 	rangeObserver      func(fn kvcoord.ForEachRangeFn)
+	valueFilter        *kvpb.RangeFeedValueFilter
 	schemaChangeEvents changefeedbase.SchemaChangeEventClass
 	schemaChangePolicy changefeedbase.SchemaChangePolicy
 
@@ -563,6 +572,7 @@ func (f *kvFeed) runUntilTableEvent(ctx context.Context, resumeFrontier span.Fro
 		Frontier:      resumeFrontier.Frontier(),
 		WithDiff:      f.withDiff,
 		WithFiltering: f.withFiltering,
+		ValueFilter:   f.valueFilter,
 		Knobs:         f.knobs,
 		RangeObserver: f.rangeObserver,
 	}
@@ -0,0 +1,158 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+func init() {
+	sql.LogicalReplicationChanges = logicalReplicationChanges
+}
+
+// logicalReplicationChanges implements sql.LogicalReplicationChanges. The
+// changes to the tables are watched with a rangefeed and decoded like the
+// events of a changefeed.
+func logicalReplicationChanges(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	tables []catalog.TableDescriptor,
+	startTS hlc.Timestamp,
+	events chan<- sql.LogicalReplicationEvent,
+) error {
+	var targets changefeedbase.Targets
+	spans := make([]roachpb.Span, 0, len(tables))
+	for _, tbl := range tables {
+		targets.Add(changefeedbase.Target{
+			Type:              jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY,
+			TableID:           tbl.GetID(),
+			StatementTimeName: changefeedbase.StatementTimeName(tbl.GetName()),
+		})
+		spans = append(spans, tbl.PrimaryIndexSpan(execCfg.Codec))
+	}
+	decoder, err := cdcevent.NewEventDecoder(
+		ctx, execCfg, targets, false /* includeVirtual */, false /* keyOnly */)
+	if err != nil {
+		return err
+	}
+
+	// The rangefeed callbacks are called by a single goroutine. Once one of
+	// them fails, no more events are sent, so that no resolved timestamp is
+	// sent after a change which was not.
+	errCh := make(chan error, 1)
+	var failed atomic.Bool
+	setErr := func(err error) {
+		if failed.CompareAndSwap(false, true) {
+			errCh <- err
+		}
+	}
+	send := func(ctx context.Context, ev sql.LogicalReplicationEvent) {
+		if failed.Load() {
+			return
+		}
+		select {
+		case events <- ev:
+		case <-ctx.Done():
+		}
+	}
+	onValue := func(ctx context.Context, value *kvpb.RangeFeedValue) {
+		change, err := decodeLogicalReplicationChange(ctx, decoder, value)
+		if err != nil {
+			setErr(err)
+			return
+		}
+		if change != nil {
+			send(ctx, sql.LogicalReplicationEvent{Change: change})
+		}
+	}
+	rf, err := execCfg.RangeFeedFactory.RangeFeed(
+		ctx, "logical-replication", spans, startTS, onValue,
+		rangefeed.WithDiff(true),
+		rangefeed.WithOnFrontierAdvance(func(ctx context.Context, ts hlc.Timestamp) {
+			send(ctx, sql.LogicalReplicationEvent{Resolved: ts})
+		}),
+		rangefeed.WithOnDeleteRange(func(ctx context.Context, _ *kvpb.RangeFeedDeleteRange) {
+			// Range deletions only happen when tables or indexes are dropped or
+			// truncated, which are not published.
+		}),
+		rangefeed.WithOnInternalError(func(ctx context.Context, err error) {
+			setErr(err)
+		}),
+	)
+	if err != nil {
+		return err
+	}
+	defer rf.Close()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// decodeLogicalReplicationChange decodes the change to a row. It returns nil
+// if the change is not to a watched row.
+func decodeLogicalReplicationChange(
+	ctx context.Context, decoder cdcevent.Decoder, value *kvpb.RangeFeedValue,
+) (*sql.LogicalReplicationChange, error) {
+	kv := roachpb.KeyValue{Key: value.Key, Value: value.Value}
+	row, err := decoder.DecodeKV(ctx, kv, cdcevent.CurrentRow, value.Timestamp(), false /* keyOnly */)
+	if err != nil {
+		if errors.Is(err, cdcevent.ErrUnwatchedFamily) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	change := &sql.LogicalReplicationChange{
+		TableID:     row.TableID,
+		TableName:   row.TableName,
+		Version:     row.Version,
+		Timestamp:   value.Timestamp(),
+		Deleted:     row.IsDeleted(),
+		HadPrevious: value.PrevValue.IsPresent(),
+	}
+	keyCols := make(map[string]struct{})
+	if err := row.ForEachKeyColumn().Col(func(col cdcevent.ResultColumn) error {
+		keyCols[col.Name] = struct{}{}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if err := row.ForAllColumns().Datum(func(d tree.Datum, col cdcevent.ResultColumn) error {
+		_, isKey := keyCols[col.Name]
+		if change.Deleted && !isKey {
+			d = tree.DNull
+		}
+		change.Columns = append(change.Columns, sql.LogicalReplicationColumn{
+			Name: col.Name,
+			Type: col.Typ,
+			Key:  isKey,
+		})
+		change.Datums = append(change.Datums, d)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
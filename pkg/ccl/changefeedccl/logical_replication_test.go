@@ -0,0 +1,230 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgproto3"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLogicalReplication streams the changes of a publication through a
+// replication slot, and checks that a stream restarts from the position
+// confirmed by the client.
+func TestLogicalReplication(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	s := srv.ApplicationLayer()
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, `SET CLUSTER SETTING kv.rangefeed.enabled = true`)
+	sqlDB.Exec(t, `SET CLUSTER SETTING kv.closed_timestamp.target_duration = '100ms'`)
+
+	pgURL, cleanup := s.PGUrl(
+		t, serverutils.CertsDirPrefix("logical_replication_test"), serverutils.User(username.RootUser),
+	)
+	defer cleanup()
+	cfg, err := pgconn.ParseConfig(pgURL.String())
+	require.NoError(t, err)
+	cfg.RuntimeParams["replication"] = "database"
+	conn, err := pgconn.ConnectConfig(ctx, cfg)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close(ctx) }()
+
+	exec := func(query string) {
+		_, err := conn.Exec(ctx, query).ReadAll()
+		require.NoError(t, err, query)
+	}
+	exec(`CREATE TABLE t (k INT PRIMARY KEY, v STRING)`)
+	exec(`CREATE TABLE unpublished (k INT PRIMARY KEY)`)
+	exec(`CREATE PUBLICATION pub FOR TABLE t`)
+	exec(`CREATE_REPLICATION_SLOT slot LOGICAL pgoutput`)
+
+	exec(`INSERT INTO t VALUES (1, 'a'), (2, 'b')`)
+	exec(`INSERT INTO unpublished VALUES (1)`)
+	exec(`UPDATE t SET v = NULL WHERE k = 1`)
+	exec(`DELETE FROM t WHERE k = 2`)
+
+	fe := conn.Frontend()
+	startReplication := func() {
+		fe.Send(&pgproto3.Query{String: `START_REPLICATION SLOT slot LOGICAL 0/0 ` +
+			`(proto_version '1', publication_names 'pub')`})
+		require.NoError(t, fe.Flush())
+		msg, err := fe.Receive()
+		require.NoError(t, err)
+		require.IsType(t, &pgproto3.CopyBothResponse{}, msg)
+	}
+	// readTxns reads the messages of n transactions, and returns them along
+	// with the end LSN of the last one.
+	readTxns := func(n int) (msgs []string, endLSN uint64) {
+		for n > 0 {
+			msg, err := fe.Receive()
+			require.NoError(t, err)
+			data, ok := msg.(*pgproto3.CopyData)
+			require.True(t, ok, "unexpected message %T", msg)
+			if data.Data[0] == 'k' {
+				// Skip keepalives.
+				continue
+			}
+			require.Equal(t, byte('w'), data.Data[0])
+			decoded, commitEndLSN := decodeTestReplicationMessage(t, data.Data[25:])
+			msgs = append(msgs, decoded)
+			if commitEndLSN != 0 {
+				endLSN = commitEndLSN
+				n--
+			}
+		}
+		return msgs, endLSN
+	}
+	stopReplication := func() {
+		fe.Send(&pgproto3.CopyDone{})
+		require.NoError(t, fe.Flush())
+		for {
+			msg, err := fe.Receive()
+			require.NoError(t, err)
+			switch msg := msg.(type) {
+			case *pgproto3.CopyData:
+			case *pgproto3.CopyDone:
+			case *pgproto3.CommandComplete:
+				require.Equal(t, "START_REPLICATION", string(msg.CommandTag))
+			case *pgproto3.ReadyForQuery:
+				return
+			default:
+				t.Fatalf("unexpected message %T", msg)
+			}
+		}
+	}
+
+	startReplication()
+	msgs, endLSN := readTxns(3)
+	require.Equal(t, []string{
+		"BEGIN",
+		"RELATION public.t (k key, v)",
+		"INSERT (1, a)",
+		"INSERT (2, b)",
+		"COMMIT",
+		"BEGIN",
+		"UPDATE (1, NULL)",
+		"COMMIT",
+		"BEGIN",
+		"DELETE (2, NULL)",
+		"COMMIT",
+	}, msgs)
+
+	// Confirm the changes, and restart the stream, which only sends the
+	// changes after them.
+	status := make([]byte, 34)
+	status[0] = 'r'
+	binary.BigEndian.PutUint64(status[1:], endLSN)
+	binary.BigEndian.PutUint64(status[9:], endLSN)
+	binary.BigEndian.PutUint64(status[17:], endLSN)
+	fe.Send(&pgproto3.CopyData{Data: status})
+	stopReplication()
+	sqlDB.CheckQueryResults(t,
+		`SELECT confirmed_flush_lsn = $1 FROM system.replication_slots WHERE slot_name = 'slot'`,
+		[][]string{{"true"}}, endLSN)
+
+	exec(`INSERT INTO t VALUES (3, 'c')`)
+	startReplication()
+	msgs, _ = readTxns(1)
+	require.Equal(t, []string{
+		"BEGIN",
+		"RELATION public.t (k key, v)",
+		"INSERT (3, c)",
+		"COMMIT",
+	}, msgs)
+	stopReplication()
+
+	exec(`DROP_REPLICATION_SLOT slot`)
+}
+
+// decodeTestReplicationMessage returns a description of a pgoutput message,
+// and the end LSN of the transaction if it is a Commit message.
+func decodeTestReplicationMessage(t *testing.T, msg []byte) (_ string, commitEndLSN uint64) {
+	readString := func() string {
+		i := strings.IndexByte(string(msg), 0)
+		require.GreaterOrEqual(t, i, 0)
+		s := string(msg[:i])
+		msg = msg[i+1:]
+		return s
+	}
+	readTuple := func() string {
+		n := int(binary.BigEndian.Uint16(msg))
+		msg = msg[2:]
+		vals := make([]string, n)
+		for i := range vals {
+			kind := msg[0]
+			msg = msg[1:]
+			switch kind {
+			case 'n':
+				vals[i] = "NULL"
+			case 't':
+				l := int(binary.BigEndian.Uint32(msg))
+				vals[i] = string(msg[4 : 4+l])
+				msg = msg[4+l:]
+			default:
+				t.Fatalf("unexpected tuple value kind %q", kind)
+			}
+		}
+		return "(" + strings.Join(vals, ", ") + ")"
+	}
+	typ := msg[0]
+	msg = msg[1:]
+	switch typ {
+	case 'B':
+		return "BEGIN", 0
+	case 'C':
+		// Flags, commit LSN, end LSN, commit time.
+		return "COMMIT", binary.BigEndian.Uint64(msg[9:])
+	case 'R':
+		// Relation ID.
+		msg = msg[4:]
+		ns, name := readString(), readString()
+		// Replica identity.
+		msg = msg[1:]
+		n := int(binary.BigEndian.Uint16(msg))
+		msg = msg[2:]
+		cols := make([]string, n)
+		for i := range cols {
+			key := msg[0] == 1
+			msg = msg[1:]
+			cols[i] = readString()
+			if key {
+				cols[i] += " key"
+			}
+			// Type OID and modifier.
+			msg = msg[8:]
+		}
+		return fmt.Sprintf("RELATION %s.%s (%s)", ns, name, strings.Join(cols, ", ")), 0
+	case 'I', 'U', 'D':
+		// Relation ID, and the kind of tuple.
+		msg = msg[5:]
+		op := map[byte]string{'I': "INSERT", 'U': "UPDATE", 'D': "DELETE"}[typ]
+		return op + " " + readTuple(), 0
+	default:
+		t.Fatalf("unexpected message type %q", typ)
+		return "", 0
+	}
+}
@@ -175,6 +175,20 @@ func TestReadCommittedLogicCCL_plpgsql_cursor(
 	runCCLLogicTest(t, "plpgsql_cursor")
 }
 
+func TestReadCommittedLogicCCL_plpgsql_do(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "plpgsql_do")
+}
+
+func TestReadCommittedLogicCCL_plpgsql_for(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "plpgsql_for")
+}
+
 func TestReadCommittedLogicCCL_plpgsql_into(
 	t *testing.T,
 ) {
@@ -189,6 +203,13 @@ func TestReadCommittedLogicCCL_plpgsql_record(
 	runCCLLogicTest(t, "plpgsql_record")
 }
 
+func TestReadCommittedLogicCCL_plpgsql_setof(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "plpgsql_setof")
+}
+
 func TestReadCommittedLogicCCL_plpgsql_txn(
 	t *testing.T,
 ) {
@@ -266,6 +287,13 @@ func TestReadCommittedLogicCCL_subject(
 	runCCLLogicTest(t, "subject")
 }
 
+func TestReadCommittedLogicCCL_triggers(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "triggers")
+}
+
 func TestReadCommittedLogicCCL_udf_params(
 	t *testing.T,
 ) {
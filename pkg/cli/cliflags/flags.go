@@ -991,6 +991,16 @@ For example:
   --store=provisioned-rate=disk-name=nvme1n1
   --store=provisioned-rate=disk-name=sdb:bandwidth=250MiB/s
 
+</PRE>
+Optionally, the "raft-log-path" field places the Raft logs and Raft state of
+the store's replicas into a separate storage engine in the given directory,
+for example on a dedicated device. For an in-memory store, the separate engine
+is also kept in memory. Once a store was started with a separate Raft log
+engine, it must always be started with the same "raft-log-path". For example:
+<PRE>
+
+  --store=path=/mnt/ssd01,raft-log-path=/mnt/ssd02/raft
+
 </PRE>
 Commas are forbidden in all values, since they are used to separate fields.
 Also, if you use equal signs in the file path to a store, you must use the
@@ -1233,6 +1243,13 @@ If specified, print the system config contents. Beware that the output will be
 long and not particularly human-readable.`,
 	}
 
+	RaftLogDir = FlagInfo{
+		Name: "raft-log-dir",
+		Description: `
+The directory of the separate raft log engine of the store, if the store was
+started with a raft-log-path.`,
+	}
+
 	DecodeAsTable = FlagInfo{
 		Name: "decode-as-table",
 		Description: `
@@ -18,6 +18,7 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/cli/clierrorplus"
+	"github.com/cockroachdb/cockroach/pkg/cli/cliflags"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
@@ -47,6 +48,8 @@ Perform local consistency checks of a single store.
 Capable of detecting the following errors:
 * Raft logs that are inconsistent with their metadata
 * MVCC stats that are inconsistent with the data within the range
+* Raft state found in the wrong engine, if the store has a separate raft log
+  engine, see --raft-log-dir
 `,
 	Args: cobra.ExactArgs(1),
 	RunE: clierrorplus.MaybeDecorateError(runDebugCheckStoreCmd),
@@ -69,7 +72,7 @@ func runDebugCheckStoreCmd(cmd *cobra.Command, args []string) error {
 	}
 	// This is not optimized at all, but for the same data set as above, it
 	// returns instantly, so we won't need to optimize it for quite some time.
-	err = checkStoreRaftState(ctx, dir, func(format string, args ...interface{}) {
+	err = checkStoreRaftState(ctx, dir, debugCtx.raftLogDir, func(format string, args ...interface{}) {
 		_, _ = fmt.Printf(format, args...)
 	})
 	foundProblem = foundProblem || err != nil
@@ -212,6 +215,7 @@ func checkStoreRangeStats(
 func checkStoreRaftState(
 	ctx context.Context,
 	dir string, // the store directory
+	raftLogDir string, // the separate raft log engine directory, if any
 	printf func(string, ...interface{}), // fmt.Printf outside of tests
 ) error {
 	foundProblem := false
@@ -227,6 +231,32 @@ func checkStoreRaftState(
 	if err != nil {
 		return err
 	}
+	separated, err := kvstorage.IsRaftLogEngineSeparated(ctx, db)
+	if err != nil {
+		return err
+	}
+	logDB := db
+	if separated != (raftLogDir != "") {
+		if separated {
+			return errors.Errorf("store %s has a separate raft log engine, which must be passed via --%s",
+				dir, cliflags.RaftLogDir.Name)
+		}
+		return errors.Errorf("store %s doesn't have a separate raft log engine", dir)
+	}
+	if separated {
+		if logDB, err = OpenEngine(raftLogDir, stopper, fs.ReadOnly, storage.MustExist); err != nil {
+			return err
+		}
+		ident, err := kvstorage.ReadStoreIdent(ctx, db)
+		if err != nil {
+			return err
+		}
+		if logIdent, err := kvstorage.ReadStoreIdent(ctx, logDB); err != nil {
+			return err
+		} else if logIdent != ident {
+			return errors.Errorf("raft log engine %s belongs to store %s, not to %s", raftLogDir, logIdent, ident)
+		}
+	}
 
 	// MVCCIterate over the entire range-id-local space.
 	start := roachpb.Key(keys.LocalRangeIDPrefix)
@@ -241,55 +271,79 @@ func checkStoreRaftState(
 		return replicaInfo[rangeID]
 	}
 
-	if _, err := storage.MVCCIterate(ctx, db, start, end, hlc.MaxTimestamp,
-		storage.MVCCScanOptions{Inconsistent: true}, func(kv roachpb.KeyValue) error {
-			rangeID, _, suffix, detail, err := keys.DecodeRangeIDKey(kv.Key)
-			if err != nil {
-				return err
-			}
-
-			switch {
-			case bytes.Equal(suffix, keys.LocalRaftHardStateSuffix):
-				var hs raftpb.HardState
-				if err := kv.Value.GetProto(&hs); err != nil {
-					return err
-				}
-				getReplicaInfo(rangeID).committedIndex = kvpb.RaftIndex(hs.Commit)
-			case bytes.Equal(suffix, keys.LocalRaftTruncatedStateSuffix):
-				var trunc kvserverpb.RaftTruncatedState
-				if err := kv.Value.GetProto(&trunc); err != nil {
-					return err
-				}
-				getReplicaInfo(rangeID).truncatedIndex = trunc.Index
-			case bytes.Equal(suffix, keys.LocalRangeAppliedStateSuffix):
-				var state kvserverpb.RangeAppliedState
-				if err := kv.Value.GetProto(&state); err != nil {
-					return err
-				}
-				getReplicaInfo(rangeID).appliedIndex = state.RaftAppliedIndex
-			case bytes.Equal(suffix, keys.LocalRaftLogSuffix):
-				_, uIndex, err := encoding.DecodeUint64Ascending(detail)
-				index := kvpb.RaftIndex(uIndex)
+	visit := func(reader storage.Reader, isLogDB bool) error {
+		_, err := storage.MVCCIterate(ctx, reader, start, end, hlc.MaxTimestamp,
+			storage.MVCCScanOptions{Inconsistent: true}, func(kv roachpb.KeyValue) error {
+				rangeID, _, suffix, detail, err := keys.DecodeRangeIDKey(kv.Key)
 				if err != nil {
 					return err
 				}
-				ri := getReplicaInfo(rangeID)
-				if ri.firstIndex == 0 {
-					ri.firstIndex = index
-					ri.lastIndex = index
-				} else {
-					if index != ri.lastIndex+1 {
-						printf("range %s: log index anomaly: %v followed by %v\n",
-							rangeID, ri.lastIndex, index)
+
+				// With a separate raft log engine, the raft state of the replicas must
+				// be found only in it, and the rest only in the state engine.
+				if separated {
+					isRaftState := bytes.Equal(suffix, keys.LocalRaftHardStateSuffix) ||
+						bytes.Equal(suffix, keys.LocalRaftTruncatedStateSuffix) ||
+						bytes.Equal(suffix, keys.LocalRaftLogSuffix)
+					if isRaftState && !isLogDB {
+						printf("range %s: raft state key %s found in the state engine\n", rangeID, kv.Key)
+						return nil
+					} else if !isRaftState && isLogDB {
+						printf("range %s: key %s found in the raft log engine\n", rangeID, kv.Key)
+						return nil
 					}
-					ri.lastIndex = index
 				}
-			}
 
-			return nil
-		}); err != nil {
+				switch {
+				case bytes.Equal(suffix, keys.LocalRaftHardStateSuffix):
+					var hs raftpb.HardState
+					if err := kv.Value.GetProto(&hs); err != nil {
+						return err
+					}
+					getReplicaInfo(rangeID).committedIndex = kvpb.RaftIndex(hs.Commit)
+				case bytes.Equal(suffix, keys.LocalRaftTruncatedStateSuffix):
+					var trunc kvserverpb.RaftTruncatedState
+					if err := kv.Value.GetProto(&trunc); err != nil {
+						return err
+					}
+					getReplicaInfo(rangeID).truncatedIndex = trunc.Index
+				case bytes.Equal(suffix, keys.LocalRangeAppliedStateSuffix):
+					var state kvserverpb.RangeAppliedState
+					if err := kv.Value.GetProto(&state); err != nil {
+						return err
+					}
+					getReplicaInfo(rangeID).appliedIndex = state.RaftAppliedIndex
+				case bytes.Equal(suffix, keys.LocalRaftLogSuffix):
+					_, uIndex, err := encoding.DecodeUint64Ascending(detail)
+					index := kvpb.RaftIndex(uIndex)
+					if err != nil {
+						return err
+					}
+					ri := getReplicaInfo(rangeID)
+					if ri.firstIndex == 0 {
+						ri.firstIndex = index
+						ri.lastIndex = index
+					} else {
+						if index != ri.lastIndex+1 {
+							printf("range %s: log index anomaly: %v followed by %v\n",
+								rangeID, ri.lastIndex, index)
+						}
+						ri.lastIndex = index
+					}
+				}
+
+				return nil
+			})
 		return err
 	}
+	if err := visit(db, false /* isLogDB */); err != nil {
+		return err
+	}
+	if separated {
+		if err := visit(logDB, true /* isLogDB */); err != nil {
+			return err
+		}
+	}
 
 	for rangeID, info := range replicaInfo {
 		if info.truncatedIndex != 0 && info.truncatedIndex != info.firstIndex-1 {
@@ -907,6 +907,10 @@ func init() {
 		f := debugCheckLogConfigCmd.Flags()
 		cliflagcfg.VarFlag(f, &storeSpecs, cliflags.Store)
 	}
+	{
+		f := debugCheckStoreCmd.Flags()
+		cliflagcfg.StringFlag(f, &debugCtx.raftLogDir, cliflags.RaftLogDir)
+	}
 	{
 		f := debugRangeDataCmd.Flags()
 		cliflagcfg.BoolFlag(f, &debugCtx.replicated, cliflags.Replicated)
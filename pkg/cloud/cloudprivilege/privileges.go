@@ -69,3 +69,7 @@ func CheckDestinationPrivileges(ctx context.Context, p sql.PlanHookState, to []s
 
 	return nil
 }
+
+func init() {
+	sql.CheckDestinationPrivilegesHook = CheckDestinationPrivileges
+}
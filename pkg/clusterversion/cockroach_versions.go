@@ -309,6 +309,21 @@ const (
 	// to be pipelined.
 	V24_1_ReplicatedLockPipelining
 
+	// V24_1_AddSystemReplicationSlotsTable adds the system.replication_slots
+	// table, which stores the logical replication slots.
+	V24_1_AddSystemReplicationSlotsTable
+
+	// V24_1_GossipStoreWriteBytes is the version at which stores begin
+	// populating the store capacity field WriteBytesPerSecond. The field
+	// shouldn't be used for allocator decisions before then.
+	V24_1_GossipStoreWriteBytes
+
+	// V24_1_MVCCHistoryMutationIndex is the version at which replicas begin
+	// recording the index of the last command which removed or wrote MVCC
+	// versions below the closed timestamp in their applied state. Delta
+	// snapshots are not sent before then.
+	V24_1_MVCCHistoryMutationIndex
+
 	numKeys
 )
 
@@ -377,6 +392,9 @@ var versionTable = [numKeys]roachpb.Version{
 	V24_1_GossipMaximumIOOverload:              {Major: 23, Minor: 2, Internal: 20},
 	V24_1_EstimatedMVCCStatsInSplit:            {Major: 23, Minor: 2, Internal: 22},
 	V24_1_ReplicatedLockPipelining:             {Major: 23, Minor: 2, Internal: 24},
+	V24_1_AddSystemReplicationSlotsTable:       {Major: 23, Minor: 2, Internal: 26},
+	V24_1_GossipStoreWriteBytes:                {Major: 23, Minor: 2, Internal: 28},
+	V24_1_MVCCHistoryMutationIndex:             {Major: 23, Minor: 2, Internal: 30},
 }
 
 // Latest is always the highest version key. This is the maximum logical cluster
@@ -207,6 +207,9 @@ var (
 	// localStoreNodeTombstoneSuffix stores key value pairs that map
 	// nodeIDs to time of removal from cluster.
 	localStoreNodeTombstoneSuffix = []byte("ntmb")
+	// localStoreRaftLogEngineSuffix marks a store which keeps the raft state of
+	// its replicas in a separate raft log engine.
+	localStoreRaftLogEngineSuffix = []byte("rlog")
 	// localStoreCachedSettingsSuffix stores the cached settings for node.
 	localStoreCachedSettingsSuffix = []byte("stng")
 	// LocalStoreCachedSettingsKeyMin is the start of span of possible cached settings keys.
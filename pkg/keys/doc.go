@@ -218,6 +218,7 @@ var _ = [...]interface{}{
 	StoreIdentKey,                    // "iden"
 	StoreUnsafeReplicaRecoveryKey,    // "loqr"
 	StoreNodeTombstoneKey,            // "ntmb"
+	StoreRaftLogEngineKey,            // "rlog"
 	StoreCachedSettingsKey,           // "stng"
 	StoreLastUpKey,                   // "uptm"
 
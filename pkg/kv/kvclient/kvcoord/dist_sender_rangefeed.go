@@ -90,6 +90,7 @@ type rangeFeedConfig struct {
 	overSystemTable     bool
 	withDiff            bool
 	withFiltering       bool
+	valueFilter         *kvpb.RangeFeedValueFilter
 	rangeObserver       func(ForEachRangeFn)
 
 	knobs struct {
@@ -151,6 +152,15 @@ func WithFiltering() RangeFeedOption {
 	})
 }
 
+// WithValueFilter asks the rangefeed servers to only emit the values which
+// match the filter. The filter is conservative, so the caller must still apply
+// its own filtering to the events it receives.
+func WithValueFilter(filter *kvpb.RangeFeedValueFilter) RangeFeedOption {
+	return optionFunc(func(c *rangeFeedConfig) {
+		c.valueFilter = filter
+	})
+}
+
 // WithRangeObserver is called when the rangefeed starts with a function that
 // can be used to iterate over all the ranges.
 func WithRangeObserver(observer func(ForEachRangeFn)) RangeFeedOption {
@@ -760,8 +770,8 @@ func newTransportForRange(
 
 // makeRangeFeedRequest constructs kvpb.RangeFeedRequest for specified span and
 // rangeID. Request is constructed to watch event after specified timestamp, and
-// with optional diff.  If the request corresponds to a system range, request
-// receives higher admission priority.
+// with optional diff and value filter.  If the request corresponds to a system
+// range, request receives higher admission priority.
 func makeRangeFeedRequest(
 	span roachpb.Span,
 	rangeID roachpb.RangeID,
@@ -769,6 +779,7 @@ func makeRangeFeedRequest(
 	startAfter hlc.Timestamp,
 	withDiff bool,
 	withFiltering bool,
+	valueFilter *kvpb.RangeFeedValueFilter,
 ) kvpb.RangeFeedRequest {
 	admissionPri := admissionpb.BulkNormalPri
 	if isSystemRange {
@@ -782,6 +793,7 @@ func makeRangeFeedRequest(
 		},
 		WithDiff:      withDiff,
 		WithFiltering: withFiltering,
+		ValueFilter:   valueFilter,
 		AdmissionHeader: kvpb.AdmissionHeader{
 			// NB: AdmissionHeader is used only at the start of the range feed
 			// stream since the initial catch-up scan is expensive.
@@ -839,7 +851,8 @@ func (ds *DistSender) singleRangeFeed(
 		cancelFeed()
 	}()
 
-	args := makeRangeFeedRequest(span, desc.RangeID, cfg.overSystemTable, startAfter, cfg.withDiff, cfg.withFiltering)
+	args := makeRangeFeedRequest(
+		span, desc.RangeID, cfg.overSystemTable, startAfter, cfg.withDiff, cfg.withFiltering, cfg.valueFilter)
 	transport, err := newTransportForRange(ctx, desc, ds)
 	if err != nil {
 		return args.Timestamp, err
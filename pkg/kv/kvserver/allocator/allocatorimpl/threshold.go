@@ -29,6 +29,8 @@ func getLoadThreshold(dim load.Dimension, sv *settings.Values) float64 {
 		return allocator.QPSRebalanceThreshold.Get(sv)
 	case load.CPU:
 		return allocator.CPURebalanceThreshold.Get(sv)
+	case load.WriteBytes:
+		return allocator.WriteBytesRebalanceThreshold.Get(sv)
 	default:
 		panic(errors.AssertionFailedf("Unkown load dimension %d", dim))
 	}
@@ -55,6 +57,8 @@ func getLoadMinThreshold(dim load.Dimension) float64 {
 		return allocator.MinQPSThresholdDifference
 	case load.CPU:
 		return allocator.MinCPUThresholdDifference
+	case load.WriteBytes:
+		return allocator.MinWriteBytesThresholdDifference
 	default:
 		panic(errors.AssertionFailedf("Unkown load dimension %d", dim))
 	}
@@ -82,6 +86,8 @@ func getLoadRebalanceMinRequiredDiff(dim load.Dimension, sv *settings.Values) fl
 		return allocator.MinQPSDifferenceForTransfers.Get(sv)
 	case load.CPU:
 		return allocator.MinCPUDifferenceForTransfers
+	case load.WriteBytes:
+		return allocator.MinWriteBytesDifferenceForTransfers
 	default:
 		panic(errors.AssertionFailedf("Unkown load dimension %d", dim))
 	}
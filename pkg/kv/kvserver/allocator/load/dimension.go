@@ -26,6 +26,8 @@ const (
 	Queries Dimension = iota
 	// CPU refers to the cpu time (ns) used in processing.
 	CPU
+	// WriteBytes refers to the number of bytes written to storage.
+	WriteBytes
 
 	nDimensionsTyped
 	nDimensions = int(nDimensionsTyped)
@@ -38,6 +40,8 @@ func (d Dimension) String() string {
 		return "queries-per-second"
 	case CPU:
 		return "cpu-per-second"
+	case WriteBytes:
+		return "write-bytes-per-second"
 	default:
 		panic(fmt.Sprintf("cannot name: unknown dimension with ordinal %d", d))
 	}
@@ -53,6 +57,8 @@ func (d Dimension) format(value float64) redact.SafeString {
 		return redact.SafeString(fmt.Sprintf("%.1f", value))
 	case CPU:
 		return humanizeutil.Duration(time.Duration(int64(value)))
+	case WriteBytes:
+		return humanizeutil.IBytes(int64(value))
 	default:
 		panic(fmt.Sprintf("cannot format value: unknown dimension with ordinal %d", d))
 	}
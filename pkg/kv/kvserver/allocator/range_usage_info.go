@@ -30,7 +30,12 @@ type RangeUsageInfo struct {
 	RequestCPUNanosPerSecond float64
 	RequestsPerSecond        float64
 	RaftCPUNanosPerSecond    float64
-	RequestLocality          *RangeRequestLocalityInfo
+	// AppliedWriteBytesPerSecond is the bytes written per second by the
+	// replica when applying raft commands. Unlike WriteBytesPerSecond, it is
+	// also recorded on followers, however only the leaseholder's counts
+	// towards its store's write bytes.
+	AppliedWriteBytesPerSecond float64
+	RequestLocality            *RangeRequestLocalityInfo
 }
 
 // RangeRequestLocalityInfo is the same as PerLocalityCounts and is used for
@@ -46,6 +51,7 @@ func (r RangeUsageInfo) Load() load.Load {
 	dims := load.Vector{}
 	dims[load.Queries] = r.QueriesPerSecond
 	dims[load.CPU] = r.RequestCPUNanosPerSecond + r.RaftCPUNanosPerSecond
+	dims[load.WriteBytes] = r.AppliedWriteBytesPerSecond
 	return dims
 }
 
@@ -63,6 +69,9 @@ func (r RangeUsageInfo) TransferImpact() load.Load {
 	// TODO(kvoli): Look to separate out leaseholder vs replica cpu usage in
 	// accounting to account for follower reads if able.
 	dims[load.CPU] = r.RequestCPUNanosPerSecond
+	// The write bytes of a range are attributed to its leaseholder's store, so
+	// they move along with the lease.
+	dims[load.WriteBytes] = r.AppliedWriteBytesPerSecond
 	return dims
 }
 
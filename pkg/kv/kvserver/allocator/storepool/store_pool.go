@@ -615,6 +615,11 @@ func (sp *StorePool) UpdateLocalStoresAfterLeaseTransfer(
 		} else {
 			fromDetail.Desc.Capacity.QueriesPerSecond -= rangeUsageInfo.QueriesPerSecond
 		}
+		if fromDetail.Desc.Capacity.WriteBytesPerSecond < rangeUsageInfo.AppliedWriteBytesPerSecond {
+			fromDetail.Desc.Capacity.WriteBytesPerSecond = 0
+		} else {
+			fromDetail.Desc.Capacity.WriteBytesPerSecond -= rangeUsageInfo.AppliedWriteBytesPerSecond
+		}
 		// When CPU attribution is unsupported, the store will set the
 		// CPUPerSecond of its store capacity to be -1.
 		if fromDetail.Desc.Capacity.CPUPerSecond >= 0 {
@@ -636,6 +641,7 @@ func (sp *StorePool) UpdateLocalStoresAfterLeaseTransfer(
 	if toDetail.Desc != nil {
 		toDetail.Desc.Capacity.LeaseCount++
 		toDetail.Desc.Capacity.QueriesPerSecond += rangeUsageInfo.QueriesPerSecond
+		toDetail.Desc.Capacity.WriteBytesPerSecond += rangeUsageInfo.AppliedWriteBytesPerSecond
 		// When CPU attribution is unsupported, the store will set the
 		// CPUPerSecond of its store capacity to be -1.
 		if toDetail.Desc.Capacity.CPUPerSecond >= 0 {
@@ -958,6 +964,10 @@ type StoreList struct {
 	// eligible to be rebalance targets.
 	candidateWritesPerSecond Stat
 
+	// CandidateWriteBytesPerSecond tracks write-bytes-per-second stats for
+	// Stores that are eligible to be rebalance targets.
+	CandidateWriteBytesPerSecond Stat
+
 	// CandidateIOOverloadScores tracks the IO overload stats for Stores that are
 	// eligible to be rebalance candidates.
 	CandidateIOOverloadScores Stat
@@ -978,6 +988,7 @@ func MakeStoreList(descriptors []roachpb.StoreDescriptor) StoreList {
 		sl.CandidateQueriesPerSecond.update(desc.Capacity.QueriesPerSecond)
 		sl.candidateWritesPerSecond.update(desc.Capacity.WritesPerSecond)
 		sl.CandidateCPU.update(desc.Capacity.CPUPerSecond)
+		sl.CandidateWriteBytesPerSecond.update(desc.Capacity.WriteBytesPerSecond)
 		score, _ := desc.Capacity.IOThreshold.Score()
 		sl.CandidateIOOverloadScores.update(score)
 		maxScore, _ := desc.Capacity.IOThresholdMax.Score()
@@ -1048,6 +1059,7 @@ func (sl StoreList) LoadMeans() load.Load {
 	dims := load.Vector{}
 	dims[load.Queries] = sl.CandidateQueriesPerSecond.Mean
 	dims[load.CPU] = sl.CandidateCPU.Mean
+	dims[load.WriteBytes] = sl.CandidateWriteBytesPerSecond.Mean
 	return dims
 }
 
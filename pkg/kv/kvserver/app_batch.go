@@ -33,7 +33,10 @@ type appBatchStats struct {
 
 	// numMutations is the number of keys mutated, both via
 	// WriteBatch and AddSST.
-	numMutations               int
+	numMutations int
+	// numMutationBytes is the number of bytes written, both via
+	// WriteBatch and AddSST.
+	numMutationBytes           int64
 	numEntriesProcessed        int
 	numEntriesProcessedBytes   int64
 	numEmptyEntries            int
@@ -44,6 +47,7 @@ type appBatchStats struct {
 
 func (s *appBatchStats) merge(ss appBatchStats) {
 	s.numMutations += ss.numMutations
+	s.numMutationBytes += ss.numMutationBytes
 	s.numEntriesProcessed += ss.numEntriesProcessed
 	s.numEntriesProcessedBytes += ss.numEntriesProcessedBytes
 	ss.numEmptyEntries += ss.numEmptyEntries
@@ -143,6 +147,7 @@ func (b *appBatch) addWriteBatch(
 	} else {
 		b.numMutations += mutations
 	}
+	b.numMutationBytes += int64(len(wb.Data))
 	if err := batch.ApplyBatchRepr(wb.Data, false); err != nil {
 		return errors.Wrapf(err, "unable to apply WriteBatch")
 	}
@@ -183,6 +188,7 @@ func (b *appBatch) runPostAddTriggers(
 			*res.AddSSTable,
 		)
 		b.numAddSST++
+		b.numMutationBytes += int64(len(res.AddSSTable.Data))
 		if copied {
 			b.numAddSSTCopies++
 		}
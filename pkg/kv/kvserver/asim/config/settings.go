@@ -33,6 +33,11 @@ const (
 	defaultLBRebalanceQPSThreshold = 0.1
 	defaultLBMinRequiredQPSDiff    = 200
 	defaultLBRebalancingObjective  = 0 // QPS
+
+	defaultLBRebalanceWriteBytesThreshold = 0.1
+	defaultLBMinRequiredWriteBytesDiff    = 2 << 20 // 2 MiB/s
+	defaultLBRebalanceCPUThreshold        = 0.1
+	defaultLBMinRequiredCPUDiff           = float64(5 * time.Millisecond)
 )
 
 var (
@@ -115,6 +120,20 @@ type SimulationSettings struct {
 	// rebalancer would care to reconcile (via lease or replica rebalancing) between
 	// any two stores.
 	LBMinRequiredQPSDiff float64
+	// LBRebalanceWriteBytesThreshold is the fraction above or below the mean
+	// store write bytes per second, that a store is considered overfull or
+	// underfull.
+	LBRebalanceWriteBytesThreshold float64
+	// LBMinRequiredWriteBytesDiff is the minimum write bytes per second
+	// difference that the store rebalancer would care to reconcile (via replica
+	// rebalancing) between any two stores.
+	LBMinRequiredWriteBytesDiff float64
+	// LBRebalanceCPUThreshold is the fraction above or below the mean store
+	// cpu (ns) per second, that a store is considered overfull or underfull.
+	LBRebalanceCPUThreshold float64
+	// LBMinRequiredCPUDiff is the minimum cpu (ns) per second difference that
+	// the store rebalancer would care to reconcile between any two stores.
+	LBMinRequiredCPUDiff float64
 }
 
 // DefaultSimulationSettings returns a set of default settings for simulation.
@@ -141,6 +160,11 @@ func DefaultSimulationSettings() *SimulationSettings {
 		LBRebalancingInterval:   defaultLBRebalancingInterval,
 		LBRebalanceQPSThreshold: defaultLBRebalanceQPSThreshold,
 		LBMinRequiredQPSDiff:    defaultLBMinRequiredQPSDiff,
+
+		LBRebalanceWriteBytesThreshold: defaultLBRebalanceWriteBytesThreshold,
+		LBMinRequiredWriteBytesDiff:    defaultLBMinRequiredWriteBytesDiff,
+		LBRebalanceCPUThreshold:        defaultLBRebalanceCPUThreshold,
+		LBMinRequiredCPUDiff:           defaultLBMinRequiredCPUDiff,
 	}
 }
 
@@ -0,0 +1,76 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package gen
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/config"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/state"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/workload"
+)
+
+// DebugZipCluster implements the ClusterGen interface.
+type DebugZipCluster struct {
+	Info state.DebugZipInfo
+}
+
+func (dc DebugZipCluster) String() string {
+	return fmt.Sprintf("cluster from %v\n %v", dc.Info, dc.Info.ClusterInfo())
+}
+
+// Generate returns a new simulator state, where the cluster is loaded with the
+// nodes, localities and stores recorded in the debug zip. There is no
+// randomness in this cluster generation.
+func (dc DebugZipCluster) Generate(seed int64, settings *config.SimulationSettings) state.State {
+	return state.LoadDebugZipCluster(dc.Info, settings)
+}
+
+func (dc DebugZipCluster) Regions() []state.Region {
+	return dc.Info.ClusterInfo().Regions
+}
+
+// DebugZipRanges implements the RangeGen interface.
+type DebugZipRanges struct {
+	Info state.DebugZipInfo
+}
+
+func (dr DebugZipRanges) String() string {
+	return fmt.Sprintf("ranges from %v", dr.Info)
+}
+
+// Generate returns an updated simulator state, where the cluster is loaded
+// with the ranges, replica placement, leaseholders and span configs recorded
+// in the debug zip. The cluster must have been generated by DebugZipCluster
+// with the same debug zip. There is no randomness in this range generation.
+func (dr DebugZipRanges) Generate(
+	seed int64, settings *config.SimulationSettings, s state.State,
+) state.State {
+	state.LoadRangeInfo(s, dr.Info.RangesInfo()...)
+	return s
+}
+
+// DebugZipLoad implements the LoadGen interface.
+type DebugZipLoad struct {
+	Info state.DebugZipInfo
+}
+
+func (dl DebugZipLoad) String() string {
+	return fmt.Sprintf("load from %v", dl.Info)
+}
+
+// Generate returns a workload generator which replays the per-range load
+// recorded in the debug zip, over the ranges generated by DebugZipRanges.
+func (dl DebugZipLoad) Generate(seed int64, settings *config.SimulationSettings) []workload.Generator {
+	return []workload.Generator{
+		workload.NewReplayGenerator(settings.StartTime, seed, dl.Info.RecordedLoad()),
+	}
+}
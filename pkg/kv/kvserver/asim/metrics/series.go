@@ -38,6 +38,8 @@ func MakeTS(metrics [][]StoreMetrics) map[string][][]float64 {
 	ret["replica_b_sent"] = make([][]float64, stores)
 	ret["range_splits"] = make([][]float64, stores)
 	ret["disk_fraction_used"] = make([][]float64, stores)
+	ret["write_b_per_sec"] = make([][]float64, stores)
+	ret["cpu"] = make([][]float64, stores)
 
 	for _, sms := range metrics {
 		for i, sm := range sms {
@@ -54,6 +56,8 @@ func MakeTS(metrics [][]StoreMetrics) map[string][][]float64 {
 			ret["replica_b_sent"][i] = append(ret["replica_b_sent"][i], float64(sm.RebalanceSentBytes))
 			ret["range_splits"][i] = append(ret["range_splits"][i], float64(sm.RangeSplits))
 			ret["disk_fraction_used"][i] = append(ret["disk_fraction_used"][i], sm.DiskFractionUsed)
+			ret["write_b_per_sec"][i] = append(ret["write_b_per_sec"][i], float64(sm.WriteBytesPerSecond))
+			ret["cpu"][i] = append(ret["cpu"][i], float64(sm.CPUPerSecond))
 		}
 	}
 	return ret
@@ -42,6 +42,12 @@ type StoreMetrics struct {
 	RebalanceRcvdBytes int64
 	RangeSplits        int64
 	DiskFractionUsed   float64
+	// WriteBytesPerSecond tracks the store's write bytes per second, as
+	// published in the store capacity for load based rebalancing.
+	WriteBytesPerSecond int64
+	// CPUPerSecond tracks the store's request cpu (ns) per second, as
+	// published in the store capacity for load based rebalancing.
+	CPUPerSecond int64
 }
 
 // the MetricsTracker to report new store metrics for a tick.
@@ -120,6 +126,9 @@ func (mt *Tracker) Tick(ctx context.Context, tick time.Time, s state.State) {
 			RebalanceRcvdBytes: u.RebalanceRcvdBytes,
 			RangeSplits:        u.RangeSplits,
 			DiskFractionUsed:   desc.Capacity.FractionUsed(),
+
+			WriteBytesPerSecond: int64(desc.Capacity.WriteBytesPerSecond),
+			CPUPerSecond:        int64(desc.Capacity.CPUPerSecond),
 		}
 		sms = append(sms, sm)
 	}
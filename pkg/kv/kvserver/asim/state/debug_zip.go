@@ -0,0 +1,481 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package state
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/config"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/workload"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// debugZipDir is the directory within a debug zip which contains the
+	// cluster wide and per-node files.
+	debugZipDir = "debug"
+	// debugZipNodesFile contains the status of every node and its stores.
+	debugZipNodesFile = "nodes.json"
+	// debugZipRangesFile contains the ranges of a node, one per replica.
+	debugZipRangesFile = "ranges.json"
+	// debugZipSpanConfigsFile contains the system.span_configurations table.
+	debugZipSpanConfigsFile = "system.span_configurations.txt"
+	// debugZipDefaultCapacity is the store capacity used when a store didn't
+	// report its capacity, 1 TiB.
+	debugZipDefaultCapacity = 1 << 40
+)
+
+// DebugZipStore is a store recorded in a debug zip.
+type DebugZipStore struct {
+	StoreID  roachpb.StoreID
+	Capacity int64
+}
+
+// DebugZipNode is a node recorded in a debug zip.
+type DebugZipNode struct {
+	NodeID   roachpb.NodeID
+	Locality roachpb.Locality
+	Stores   []DebugZipStore
+}
+
+// DebugZipRange is a range recorded in a debug zip. The descriptor and
+// leaseholder refer to the stores of the recorded cluster, the load is the
+// load recorded by the leaseholder, per second.
+type DebugZipRange struct {
+	Descriptor  roachpb.RangeDescriptor
+	Leaseholder roachpb.StoreID
+	Config      *roachpb.SpanConfig
+	Size        int64
+	Load        workload.RecordedLoad
+}
+
+// DebugZipInfo contains the cluster topology, ranges and range load recorded
+// in a debug zip (cockroach debug zip), which is used to reproduce the cluster
+// in the simulator.
+type DebugZipInfo struct {
+	Nodes  []DebugZipNode
+	Ranges []DebugZipRange
+}
+
+// The types below mirror the subset of the JSON encoded status responses
+// written to a debug zip that the simulator requires. Decoding into the
+// complete response types would fail on any field which doesn't round-trip
+// through encoding/json, which we don't use.
+
+type debugZipNodesJSON struct {
+	Nodes []struct {
+		Desc struct {
+			NodeID   roachpb.NodeID   `json:"node_id"`
+			Locality roachpb.Locality `json:"locality"`
+		} `json:"desc"`
+		StoreStatuses []struct {
+			Desc struct {
+				StoreID  roachpb.StoreID `json:"store_id"`
+				Capacity struct {
+					Capacity int64 `json:"capacity"`
+				} `json:"capacity"`
+			} `json:"desc"`
+		} `json:"store_statuses"`
+	} `json:"nodes"`
+}
+
+type debugZipRangeJSON struct {
+	State struct {
+		State struct {
+			Desc  roachpb.RangeDescriptor `json:"desc"`
+			Lease struct {
+				Replica struct {
+					StoreID roachpb.StoreID `json:"store_id"`
+				} `json:"replica"`
+			} `json:"lease"`
+			Stats struct {
+				KeyBytes int64 `json:"key_bytes"`
+				ValBytes int64 `json:"val_bytes"`
+			} `json:"stats"`
+		} `json:"state"`
+	} `json:"state"`
+	SourceStoreID roachpb.StoreID `json:"source_store_id"`
+	IsLeaseholder bool            `json:"is_leaseholder"`
+	Stats         struct {
+		QueriesPerSecond    float64 `json:"queries_per_second"`
+		WritesPerSecond     float64 `json:"writes_per_second"`
+		ReadsPerSecond      float64 `json:"reads_per_second"`
+		WriteBytesPerSecond float64 `json:"write_bytes_per_second"`
+		ReadBytesPerSecond  float64 `json:"read_bytes_per_second"`
+		CPUTimePerSecond    float64 `json:"cpu_time_per_second"`
+	} `json:"stats"`
+}
+
+// OpenDebugZip reads the debug zip at the path given, which may either be the
+// zip file or the directory it was unzipped into.
+func OpenDebugZip(zipPath string) (DebugZipInfo, error) {
+	info, err := os.Stat(zipPath)
+	if err != nil {
+		return DebugZipInfo{}, err
+	}
+	if info.IsDir() {
+		return ReadDebugZip(os.DirFS(zipPath))
+	}
+	r, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return DebugZipInfo{}, errors.Wrapf(err, "opening debug zip %s", zipPath)
+	}
+	defer r.Close()
+	return ReadDebugZip(r)
+}
+
+// ReadDebugZip reads the nodes, ranges and span configs of a debug zip. Every
+// replica of a range reports the range, a single report is used for each
+// range.
+func ReadDebugZip(fsys fs.FS) (DebugZipInfo, error) {
+	var info DebugZipInfo
+	var nodes debugZipNodesJSON
+	if err := readDebugZipJSON(fsys, path.Join(debugZipDir, debugZipNodesFile), &nodes); err != nil {
+		return DebugZipInfo{}, err
+	}
+	for _, n := range nodes.Nodes {
+		node := DebugZipNode{NodeID: n.Desc.NodeID, Locality: n.Desc.Locality}
+		for _, ss := range n.StoreStatuses {
+			node.Stores = append(node.Stores, DebugZipStore{
+				StoreID:  ss.Desc.StoreID,
+				Capacity: ss.Desc.Capacity.Capacity,
+			})
+		}
+		sort.Slice(node.Stores, func(i, j int) bool {
+			return node.Stores[i].StoreID < node.Stores[j].StoreID
+		})
+		info.Nodes = append(info.Nodes, node)
+	}
+	sort.Slice(info.Nodes, func(i, j int) bool {
+		return info.Nodes[i].NodeID < info.Nodes[j].NodeID
+	})
+
+	rangeFiles, err := fs.Glob(fsys, path.Join(debugZipDir, "nodes", "*", debugZipRangesFile))
+	if err != nil {
+		return DebugZipInfo{}, err
+	}
+	if len(rangeFiles) == 0 {
+		return DebugZipInfo{}, errors.Newf(
+			"no %s found in debug zip, was it created with --include-range-info?", debugZipRangesFile)
+	}
+	// Replicas may lag behind and only the leaseholder serves the majority of
+	// requests. Use the report with the most recent descriptor, preferring the
+	// leaseholder's report of it.
+	ranges := map[roachpb.RangeID]*DebugZipRange{}
+	fromLeaseholder := map[roachpb.RangeID]bool{}
+	for _, file := range rangeFiles {
+		var reports []debugZipRangeJSON
+		if err := readDebugZipJSON(fsys, file, &reports); err != nil {
+			return DebugZipInfo{}, err
+		}
+		for _, report := range reports {
+			state := report.State.State
+			rangeID := state.Desc.RangeID
+			isLeaseholder := report.IsLeaseholder || report.SourceStoreID == state.Lease.Replica.StoreID
+			if r, ok := ranges[rangeID]; ok {
+				if gen := r.Descriptor.Generation; state.Desc.Generation < gen ||
+					(state.Desc.Generation == gen && (!isLeaseholder || fromLeaseholder[rangeID])) {
+					continue
+				}
+			}
+			stats := report.Stats
+			ranges[rangeID] = &DebugZipRange{
+				Descriptor:  state.Desc,
+				Leaseholder: state.Lease.Replica.StoreID,
+				Size:        state.Stats.KeyBytes + state.Stats.ValBytes,
+				Load: workload.RecordedLoad{
+					QueriesPerSecond:    stats.QueriesPerSecond,
+					WritesPerSecond:     stats.WritesPerSecond,
+					ReadsPerSecond:      stats.ReadsPerSecond,
+					WriteBytesPerSecond: stats.WriteBytesPerSecond,
+					ReadBytesPerSecond:  stats.ReadBytesPerSecond,
+					CPUTimePerSecond:    stats.CPUTimePerSecond,
+				},
+			}
+			fromLeaseholder[rangeID] = isLeaseholder
+		}
+	}
+	for _, r := range ranges {
+		info.Ranges = append(info.Ranges, *r)
+	}
+	sort.Slice(info.Ranges, func(i, j int) bool {
+		return info.Ranges[i].Descriptor.StartKey.Less(info.Ranges[j].Descriptor.StartKey)
+	})
+
+	spanConfigs, err := readDebugZipSpanConfigs(fsys)
+	if err != nil {
+		return DebugZipInfo{}, err
+	}
+	for i := range info.Ranges {
+		desc := &info.Ranges[i].Descriptor
+		for j := range spanConfigs {
+			if spanConfigs[j].span.ContainsKey(desc.StartKey.AsRawKey()) {
+				info.Ranges[i].Config = &spanConfigs[j].config
+				break
+			}
+		}
+	}
+	return info, nil
+}
+
+func readDebugZipJSON(fsys fs.FS, name string, v interface{}) error {
+	b, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return errors.Wrapf(err, "reading %s", name)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return errors.Wrapf(err, "decoding %s", name)
+	}
+	return nil
+}
+
+type debugZipSpanConfig struct {
+	span   roachpb.Span
+	config roachpb.SpanConfig
+}
+
+// readDebugZipSpanConfigs reads the span configs from the tab separated dump
+// of the system.span_configurations table. The table is optional, ranges use
+// the default span config when it is missing.
+func readDebugZipSpanConfigs(fsys fs.FS) ([]debugZipSpanConfig, error) {
+	name := path.Join(debugZipDir, debugZipSpanConfigsFile)
+	f, err := fsys.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	decodeBytes := func(s string) ([]byte, error) {
+		return hex.DecodeString(strings.TrimPrefix(s, `\x`))
+	}
+	var ret []debugZipSpanConfig
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Split(scanner.Text(), "\t")
+		// Skip the header.
+		if line == 1 && len(fields) > 0 && fields[0] == "start_key" {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, errors.Newf("%s:%d: expected 3 columns, found %d", name, line, len(fields))
+		}
+		var sc debugZipSpanConfig
+		if sc.span.Key, err = decodeBytes(fields[0]); err != nil {
+			return nil, errors.Wrapf(err, "%s:%d: decoding start_key", name, line)
+		}
+		if sc.span.EndKey, err = decodeBytes(fields[1]); err != nil {
+			return nil, errors.Wrapf(err, "%s:%d: decoding end_key", name, line)
+		}
+		config, err := decodeBytes(fields[2])
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s:%d: decoding config", name, line)
+		}
+		if err := protoutil.Unmarshal(config, &sc.config); err != nil {
+			return nil, errors.Wrapf(err, "%s:%d: unmarshaling config", name, line)
+		}
+		ret = append(ret, sc)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// simKeys returns the simulated start key of each range, the ranges are
+// evenly spread over the simulated keyspace in the order of their recorded
+// start keys. The end key of the last range is MaxKey.
+func (d DebugZipInfo) simKeys() []Key {
+	interval := int64(MaxKey-MinKey) / int64(len(d.Ranges))
+	keys := make([]Key, len(d.Ranges)+1)
+	for i := range d.Ranges {
+		keys[i] = MinKey + Key(int64(i)*interval)
+	}
+	keys[len(d.Ranges)] = MaxKey
+	return keys
+}
+
+// storeIDs returns a mapping from the recorded store IDs to the store IDs
+// they are assigned in the simulator, in the order they are added.
+func (d DebugZipInfo) storeIDs() map[roachpb.StoreID]StoreID {
+	ret := map[roachpb.StoreID]StoreID{}
+	for _, n := range d.Nodes {
+		for _, s := range n.Stores {
+			ret[s.StoreID] = StoreID(len(ret) + 1)
+		}
+	}
+	return ret
+}
+
+// ClusterInfo returns a summary of the recorded cluster's regions and zones.
+// It doesn't capture the localities nor store capacities exactly, use
+// LoadDebugZipCluster to load the cluster.
+func (d DebugZipInfo) ClusterInfo() ClusterInfo {
+	var regions []Region
+	regionIdx := map[string]int{}
+	for _, n := range d.Nodes {
+		regionName, _ := n.Locality.Find("region")
+		zoneName, _ := n.Locality.Find("zone")
+		idx, ok := regionIdx[regionName]
+		if !ok {
+			idx = len(regions)
+			regionIdx[regionName] = idx
+			regions = append(regions, Region{Name: regionName})
+		}
+		r := &regions[idx]
+		zoneIdx := -1
+		for i := range r.Zones {
+			if r.Zones[i].Name == zoneName {
+				zoneIdx = i
+			}
+		}
+		if zoneIdx == -1 {
+			zoneIdx = len(r.Zones)
+			r.Zones = append(r.Zones, NewZone(zoneName, 0, len(n.Stores)))
+		}
+		r.Zones[zoneIdx].NodeCount++
+	}
+	var diskCapacityGB int
+	for _, n := range d.Nodes {
+		for _, s := range n.Stores {
+			if gb := int(s.Capacity >> 30); gb > diskCapacityGB {
+				diskCapacityGB = gb
+			}
+		}
+	}
+	return ClusterInfo{DiskCapacityGB: diskCapacityGB, Regions: regions}
+}
+
+// RangesInfo returns the recorded ranges, with their keys mapped into the
+// simulated keyspace and their replicas mapped onto the simulated stores
+// created by LoadDebugZipCluster. Learner replicas are dropped and voters in
+// a joint configuration are loaded as full voters.
+func (d DebugZipInfo) RangesInfo() RangesInfo {
+	if len(d.Ranges) == 0 {
+		return nil
+	}
+	keys := d.simKeys()
+	storeIDs := d.storeIDs()
+	ret := make(RangesInfo, 0, len(d.Ranges))
+	for i, r := range d.Ranges {
+		desc := roachpb.RangeDescriptor{
+			StartKey: keys[i].ToRKey(),
+			EndKey:   keys[i+1].ToRKey(),
+		}
+		var leaseholder StoreID
+		for _, repl := range r.Descriptor.InternalReplicas {
+			storeID, ok := storeIDs[repl.StoreID]
+			if !ok {
+				continue
+			}
+			typ := roachpb.VOTER_FULL
+			if repl.IsNonVoter() {
+				typ = roachpb.NON_VOTER
+			} else if !repl.IsAnyVoter() {
+				continue
+			}
+			desc.InternalReplicas = append(desc.InternalReplicas, roachpb.ReplicaDescriptor{
+				StoreID: roachpb.StoreID(storeID),
+				Type:    typ,
+			})
+			if repl.StoreID == r.Leaseholder && typ == roachpb.VOTER_FULL {
+				leaseholder = storeID
+			}
+		}
+		// The recorded leaseholder may not have been a replica in the latest
+		// descriptor, fall back to the first voter.
+		if leaseholder == 0 {
+			for _, repl := range desc.InternalReplicas {
+				if repl.Type == roachpb.VOTER_FULL {
+					leaseholder = StoreID(repl.StoreID)
+					break
+				}
+			}
+		}
+		ret = append(ret, RangeInfo{
+			Descriptor:  desc,
+			Config:      r.Config,
+			Size:        r.Size,
+			Leaseholder: leaseholder,
+		})
+	}
+	return ret
+}
+
+// RecordedLoad returns the load recorded for each range, over the ranges'
+// simulated keys.
+func (d DebugZipInfo) RecordedLoad() []workload.RecordedLoad {
+	if len(d.Ranges) == 0 {
+		return nil
+	}
+	keys := d.simKeys()
+	ret := make([]workload.RecordedLoad, 0, len(d.Ranges))
+	for i, r := range d.Ranges {
+		rl := r.Load
+		rl.StartKey, rl.EndKey = int64(keys[i]), int64(keys[i+1])
+		ret = append(ret, rl)
+	}
+	return ret
+}
+
+func (d DebugZipInfo) String() string {
+	var stores int
+	for _, n := range d.Nodes {
+		stores += len(n.Stores)
+	}
+	return fmt.Sprintf("debug zip with nodes=%d, stores=%d, ranges=%d", len(d.Nodes), stores, len(d.Ranges))
+}
+
+// LoadDebugZipCluster creates a new state containing the nodes and stores
+// recorded in the debug zip, with their localities and store capacities.
+// Recorded nodes and stores are assigned sequential IDs in the simulator,
+// in the order of their recorded IDs.
+func LoadDebugZipCluster(d DebugZipInfo, settings *config.SimulationSettings) State {
+	s := newState(settings)
+	s.clusterinfo = d.ClusterInfo()
+	for _, n := range d.Nodes {
+		node := s.AddNode()
+		s.SetNodeLocality(node.NodeID(), n.Locality)
+		for _, store := range n.Stores {
+			newStore, ok := s.AddStore(node.NodeID())
+			if !ok {
+				panic(fmt.Sprintf("Unable to load debug zip: cannot add store %d", store.StoreID))
+			}
+			capacity := store.Capacity
+			if capacity <= 0 {
+				capacity = debugZipDefaultCapacity
+			}
+			s.SetStoreCapacity(newStore.StoreID(), capacity)
+		}
+	}
+	return s
+}
+
+// LoadDebugZipState creates a new state containing the cluster and ranges
+// recorded in the debug zip.
+func LoadDebugZipState(d DebugZipInfo, settings *config.SimulationSettings) State {
+	s := LoadDebugZipCluster(d, settings)
+	LoadRangeInfo(s, d.RangesInfo()...)
+	return s
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/config"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/workload"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/testutils/datapathutils"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDebugZip asserts that the ranges recorded in a debug zip are combined
+// from the reports of each replica and mapped onto the simulated cluster.
+func TestDebugZip(t *testing.T) {
+	info, err := OpenDebugZip(datapathutils.TestDataPath(t, "debug_zip"))
+	require.NoError(t, err)
+	require.Equal(t, "debug zip with nodes=3, stores=4, ranges=3", info.String())
+
+	require.Equal(t, ClusterInfo{
+		DiskCapacityGB: 512,
+		Regions: []Region{
+			{Name: "us-east", Zones: []Zone{NewZone("us-east-a", 1, 2), NewZone("us-east-b", 1, 1)}},
+			{Name: "us-west", Zones: []Zone{NewZone("us-west-a", 1, 1)}},
+		},
+	}, info.ClusterInfo())
+
+	voter := func(storeID roachpb.StoreID) roachpb.ReplicaDescriptor {
+		return roachpb.ReplicaDescriptor{StoreID: storeID, Type: roachpb.VOTER_FULL}
+	}
+	nonVoter := func(storeID roachpb.StoreID) roachpb.ReplicaDescriptor {
+		return roachpb.ReplicaDescriptor{StoreID: storeID, Type: roachpb.NON_VOTER}
+	}
+	rangesInfo := info.RangesInfo()
+	require.Len(t, rangesInfo, 3)
+	// The first range is reported by the leaseholder, and a follower.
+	require.Equal(t, MinKey.ToRKey(), rangesInfo[0].Descriptor.StartKey)
+	require.Equal(t, []roachpb.ReplicaDescriptor{voter(1), voter(3), voter(4)},
+		rangesInfo[0].Descriptor.InternalReplicas)
+	require.Equal(t, StoreID(1), rangesInfo[0].Leaseholder)
+	require.Equal(t, int64(1000), rangesInfo[0].Size)
+	require.Nil(t, rangesInfo[0].Config)
+	// The second range is reported by a replica with a stale descriptor, which
+	// held the lease prior, and the current leaseholder.
+	require.Equal(t, Key(3333333333).ToRKey(), rangesInfo[1].Descriptor.StartKey)
+	require.Equal(t, []roachpb.ReplicaDescriptor{voter(2), voter(3), nonVoter(4)},
+		rangesInfo[1].Descriptor.InternalReplicas)
+	require.Equal(t, StoreID(3), rangesInfo[1].Leaseholder)
+	require.Equal(t, int64(10000), rangesInfo[1].Size)
+	require.NotNil(t, rangesInfo[1].Config)
+	require.Equal(t, int32(5), rangesInfo[1].Config.NumReplicas)
+	// The third range has a learner replica, which isn't loaded.
+	require.Equal(t, Key(6666666666).ToRKey(), rangesInfo[2].Descriptor.StartKey)
+	require.Equal(t, MaxKey.ToRKey(), rangesInfo[2].Descriptor.EndKey)
+	require.Equal(t, []roachpb.ReplicaDescriptor{voter(1), voter(3), voter(4)},
+		rangesInfo[2].Descriptor.InternalReplicas)
+	require.Equal(t, StoreID(4), rangesInfo[2].Leaseholder)
+	require.Nil(t, rangesInfo[2].Config)
+
+	require.Equal(t, []workload.RecordedLoad{
+		{
+			StartKey:            0,
+			EndKey:              3333333333,
+			QueriesPerSecond:    100,
+			WritesPerSecond:     20,
+			ReadsPerSecond:      80,
+			WriteBytesPerSecond: 2000,
+			ReadBytesPerSecond:  8000,
+			CPUTimePerSecond:    5e6,
+		},
+		{
+			StartKey:            3333333333,
+			EndKey:              6666666666,
+			QueriesPerSecond:    50,
+			WritesPerSecond:     50,
+			WriteBytesPerSecond: 50000,
+			CPUTimePerSecond:    1e6,
+		},
+		{
+			StartKey:           6666666666,
+			EndKey:             9999999999,
+			QueriesPerSecond:   10,
+			ReadsPerSecond:     10,
+			ReadBytesPerSecond: 1000,
+			CPUTimePerSecond:   2e5,
+		},
+	}, info.RecordedLoad())
+
+	s := LoadDebugZipState(info, config.DefaultSimulationSettings())
+	require.Len(t, s.Nodes(), 3)
+	require.Len(t, s.Stores(), 4)
+	require.Len(t, s.Ranges(), 3)
+	for _, r := range rangesInfo {
+		rng := s.RangeFor(ToKey(r.Descriptor.StartKey.AsRawKey()))
+		require.Len(t, rng.Replicas(), len(r.Descriptor.InternalReplicas))
+		store, ok := s.LeaseholderStore(rng.RangeID())
+		require.True(t, ok)
+		require.Equal(t, r.Leaseholder, store.StoreID())
+	}
+	// A store which didn't report its capacity uses the default capacity.
+	store, _ := s.Store(4)
+	require.Equal(t, int64(debugZipDefaultCapacity), store.Descriptor().Capacity.Capacity)
+}
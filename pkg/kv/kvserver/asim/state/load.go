@@ -73,6 +73,12 @@ func (rl *ReplicaLoadCounter) ApplyLoad(le workload.LoadEvent) {
 	rl.WriteKeys += le.Writes
 
 	rl.loadStats.RecordBatchRequests(LoadEventQPS(le), 0)
+	if le.WriteSize > 0 {
+		rl.loadStats.RecordAppliedWriteBytes(float64(le.WriteSize))
+	}
+	if le.RequestCPU > 0 {
+		rl.loadStats.RecordReqCPUNanos(float64(le.RequestCPU))
+	}
 	// TODO(kvoli): Recording the load on every load counter is horribly
 	// inefficient at the moment. It multiplies the time taken per test almost
 	// linearly by the number of load stats counters we bump. The other load
@@ -86,8 +92,10 @@ func (rl *ReplicaLoadCounter) Load() allocator.RangeUsageInfo {
 	stats := rl.loadStats.Stats()
 
 	return allocator.RangeUsageInfo{
-		QueriesPerSecond: stats.QueriesPerSecond,
-		WritesPerSecond:  float64(rl.WriteKeys),
+		QueriesPerSecond:           stats.QueriesPerSecond,
+		WritesPerSecond:            float64(rl.WriteKeys),
+		AppliedWriteBytesPerSecond: stats.AppliedWriteBytesPerSecond,
+		RequestCPUNanosPerSecond:   stats.RequestCPUNanosPerSecond,
 	}
 }
 
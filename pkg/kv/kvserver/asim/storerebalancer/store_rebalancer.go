@@ -133,6 +133,27 @@ func (s simRebalanceObjectiveProvider) Objective() kvserver.LBRebalancingObjecti
 }
 
 func (src *storeRebalancerControl) scorerOptions() *allocatorimpl.LoadScorerOptions {
+	dim := kvserver.LBRebalancingObjective(src.settings.LBRebalancingObjective).ToDimension()
+	threshold, minDiff := load.Vector{}, load.Vector{}
+	switch dim {
+	case load.WriteBytes:
+		threshold[dim] = src.settings.LBRebalanceWriteBytesThreshold
+		minDiff[dim] = src.settings.LBMinRequiredWriteBytesDiff
+	case load.CPU:
+		threshold[dim] = src.settings.LBRebalanceCPUThreshold
+		minDiff[dim] = src.settings.LBMinRequiredCPUDiff
+	}
+	if dim != load.Queries {
+		return &allocatorimpl.LoadScorerOptions{
+			IOOverloadOptions:            src.allocator.IOOverloadOptions(),
+			DiskOptions:                  src.allocator.DiskOptions(),
+			Deterministic:                true,
+			LoadDims:                     []load.Dimension{dim},
+			LoadThreshold:                threshold,
+			MinLoadThreshold:             allocatorimpl.LoadMinThresholds(dim),
+			MinRequiredRebalanceLoadDiff: minDiff,
+		}
+	}
 	return &allocatorimpl.LoadScorerOptions{
 		IOOverloadOptions:            src.allocator.IOOverloadOptions(),
 		DiskOptions:                  src.allocator.DiskOptions(),
@@ -61,6 +61,16 @@ import (
 //     regions having 3 zones. complex: 28 nodes, 3 regions with a skewed
 //     number of nodes per region.
 //
+//   - "load_debug_zip" path=<string>
+//     Load the cluster, ranges and range load recorded in a debug zip (cockroach
+//     debug zip --include-range-info), either zipped or unzipped, at the path
+//     relative to testdata. This replaces the cluster, range and load
+//     generators: nodes, localities and store capacities are loaded as
+//     recorded, ranges are spread evenly over the simulated keyspace in their
+//     recorded order with their recorded replicas, leaseholders and span
+//     configs, and the per-range load recorded by each leaseholder is
+//     replayed.
+//
 //   - "gen_ranges" [ranges=<int>] [placement_skew=<bool>] [repl_factor=<int>]
 //     [keyspace=<int>] [range_bytes=<int>]
 //     Initialize the range generator parameters. On the next call to eval, the
@@ -127,12 +137,16 @@ import (
 //     constraints(violating) at the end of the evaluation.
 //
 //   - "setting" [rebalance_mode=<int>] [rebalance_interval=<duration>]
-//     [rebalance_qps_threshold=<float>] [split_qps_threshold=<float>]
+//     [rebalance_objective=<int>] [rebalance_qps_threshold=<float>]
+//     [rebalance_write_bytes_threshold=<float>]
+//     [rebalance_cpu_threshold=<float>] [split_qps_threshold=<float>]
 //     [rebalance_range_threshold=<float>] [gossip_delay=<duration>]
 //     Configure the simulation's various settings. The default values are:
 //     rebalance_mode=2 (leases and replicas) rebalance_interval=1m (1 minute)
-//     rebalance_qps_threshold=0.1 split_qps_threshold=2500
-//     rebalance_range_threshold=0.05 gossip_delay=500ms.
+//     rebalance_objective=0 (qps) rebalance_qps_threshold=0.1
+//     rebalance_write_bytes_threshold=0.1 rebalance_cpu_threshold=0.1
+//     split_qps_threshold=2500 rebalance_range_threshold=0.05
+//     gossip_delay=500ms. Only load replayed from a debug zip records cpu.
 //
 //   - "eval" [duration=<string>] [samples=<int>] [seed=<int>]
 //     Run samples (e.g. samples=5) number of simulations for duration (e.g.
@@ -163,7 +177,7 @@ func TestDataDriven(t *testing.T) {
 	dir := datapathutils.TestDataPath(t, "non_rand")
 	datadriven.Walk(t, dir, func(t *testing.T, path string) {
 		const defaultKeyspace = 10000
-		loadGen := gen.BasicLoad{}
+		var loadGen gen.LoadGen = gen.BasicLoad{}
 		var clusterGen gen.ClusterGen
 		var rangeGen gen.RangeGen = gen.BasicRanges{
 			BaseRanges: gen.BaseRanges{
@@ -192,13 +206,15 @@ func TestDataDriven(t *testing.T) {
 				scanIfExists(t, d, "min_key", &minKey)
 				scanIfExists(t, d, "max_key", &maxKey)
 
-				loadGen.SkewedAccess = accessSkew
-				loadGen.MinKey = minKey
-				loadGen.MaxKey = maxKey
-				loadGen.RWRatio = rwRatio
-				loadGen.Rate = rate
-				loadGen.MaxBlockSize = maxBlock
-				loadGen.MinBlockSize = minBlock
+				loadGen = gen.BasicLoad{
+					SkewedAccess: accessSkew,
+					MinKey:       minKey,
+					MaxKey:       maxKey,
+					RWRatio:      rwRatio,
+					Rate:         rate,
+					MaxBlockSize: maxBlock,
+					MinBlockSize: minBlock,
+				}
 				return ""
 			case "gen_ranges":
 				var ranges, replFactor, keyspace = 1, 3, defaultKeyspace
@@ -247,6 +263,15 @@ func TestDataDriven(t *testing.T) {
 				scanArg(t, d, "config", &config)
 				clusterGen = loadClusterInfo(config)
 				return ""
+			case "load_debug_zip":
+				var zipPath string
+				scanArg(t, d, "path", &zipPath)
+				info, err := state.OpenDebugZip(datapathutils.TestDataPath(t, zipPath))
+				require.NoError(t, err)
+				clusterGen = gen.DebugZipCluster{Info: info}
+				rangeGen = gen.DebugZipRanges{Info: info}
+				loadGen = gen.DebugZipLoad{Info: info}
+				return info.String()
 			case "add_node":
 				var delay time.Duration
 				var numStores = 1
@@ -438,7 +463,10 @@ func TestDataDriven(t *testing.T) {
 			case "setting":
 				scanIfExists(t, d, "rebalance_mode", &settingsGen.Settings.LBRebalancingMode)
 				scanIfExists(t, d, "rebalance_interval", &settingsGen.Settings.LBRebalancingInterval)
+				scanIfExists(t, d, "rebalance_objective", &settingsGen.Settings.LBRebalancingObjective)
 				scanIfExists(t, d, "rebalance_qps_threshold", &settingsGen.Settings.LBRebalanceQPSThreshold)
+				scanIfExists(t, d, "rebalance_write_bytes_threshold", &settingsGen.Settings.LBRebalanceWriteBytesThreshold)
+				scanIfExists(t, d, "rebalance_cpu_threshold", &settingsGen.Settings.LBRebalanceCPUThreshold)
 				scanIfExists(t, d, "split_qps_threshold", &settingsGen.Settings.SplitQPSThreshold)
 				scanIfExists(t, d, "rebalance_range_threshold", &settingsGen.Settings.RangeRebalanceThreshold)
 				scanIfExists(t, d, "gossip_delay", &settingsGen.Settings.StateExchangeDelay)
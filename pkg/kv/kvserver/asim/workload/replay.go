@@ -0,0 +1,127 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package workload
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// RecordedLoad is the load of a range that was recorded on a real cluster,
+// averaged per second. The load is replayed against the simulated keys
+// [StartKey, EndKey) of the range.
+type RecordedLoad struct {
+	StartKey, EndKey int64
+	// QueriesPerSecond is the number of batch requests per second.
+	QueriesPerSecond float64
+	// WritesPerSecond is the number of keys written per second.
+	WritesPerSecond float64
+	// ReadsPerSecond is the number of keys read per second.
+	ReadsPerSecond float64
+	// WriteBytesPerSecond is the number of bytes written per second.
+	WriteBytesPerSecond float64
+	// ReadBytesPerSecond is the number of bytes read per second.
+	ReadBytesPerSecond float64
+	// CPUTimePerSecond is the cpu time (ns) used per second.
+	CPUTimePerSecond float64
+}
+
+func (rl RecordedLoad) String() string {
+	return fmt.Sprintf("[%d,%d) qps=%.1f writes=%.1f reads=%.1f write_b=%.1f read_b=%.1f cpu=%.1f",
+		rl.StartKey, rl.EndKey, rl.QueriesPerSecond, rl.WritesPerSecond, rl.ReadsPerSecond,
+		rl.WriteBytesPerSecond, rl.ReadBytesPerSecond, rl.CPUTimePerSecond)
+}
+
+// replayedLoad tracks the load of a recorded range that is owed, but hasn't
+// been generated yet. Load is generated in whole units, the remainder is
+// carried over to the next tick.
+type replayedLoad struct {
+	RecordedLoad
+	queries, writes, writeBytes, readBytes, cpu float64
+}
+
+// ReplayGenerator generates the load that was recorded per-range on a real
+// cluster, e.g. from a debug zip. Every tick, the queries owed to each range
+// since the last tick are generated as a single load event on a random key
+// within the range. The queries are split into reads and writes following the
+// ratio of keys read and written by the range.
+type ReplayGenerator struct {
+	rand    *rand.Rand
+	lastRun time.Time
+	ranges  []replayedLoad
+}
+
+// NewReplayGenerator returns a generator that replays the recorded load of
+// the ranges given.
+func NewReplayGenerator(start time.Time, seed int64, recorded []RecordedLoad) Generator {
+	ranges := make([]replayedLoad, 0, len(recorded))
+	for _, rl := range recorded {
+		if rl.EndKey <= rl.StartKey {
+			panic(fmt.Sprintf("end key (%d) must be greater than start key (%d)", rl.EndKey, rl.StartKey))
+		}
+		ranges = append(ranges, replayedLoad{RecordedLoad: rl})
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].StartKey < ranges[j].StartKey
+	})
+	return &ReplayGenerator{
+		rand:    rand.New(rand.NewSource(seed)),
+		lastRun: start,
+		ranges:  ranges,
+	}
+}
+
+// Tick returns the load events up till time tick, from the last time the
+// workload generator was called.
+func (rg *ReplayGenerator) Tick(maxTime time.Time) LoadBatch {
+	elapsed := maxTime.Sub(rg.lastRun).Seconds()
+	if elapsed <= 0 {
+		return LoadBatch{}
+	}
+	rg.lastRun = maxTime
+
+	ret := LoadBatch{}
+	for i := range rg.ranges {
+		r := &rg.ranges[i]
+		r.queries += r.QueriesPerSecond * elapsed
+		r.writeBytes += r.WriteBytesPerSecond * elapsed
+		r.readBytes += r.ReadBytesPerSecond * elapsed
+		r.cpu += r.CPUTimePerSecond * elapsed
+
+		queries := int64(r.queries)
+		if queries < 1 {
+			continue
+		}
+		// Split the queries into writes and reads, generating only reads when
+		// no keys were recorded as either.
+		if keys := r.WritesPerSecond + r.ReadsPerSecond; keys > 0 {
+			r.writes += float64(queries) * r.WritesPerSecond / keys
+		}
+		writes := int64(r.writes)
+		event := LoadEvent{
+			Key:        r.StartKey + rg.rand.Int63n(r.EndKey-r.StartKey),
+			Writes:     writes,
+			Reads:      queries - writes,
+			WriteSize:  int64(r.writeBytes),
+			ReadSize:   int64(r.readBytes),
+			RequestCPU: int64(r.cpu),
+		}
+		r.queries -= float64(queries)
+		r.writes -= float64(writes)
+		r.writeBytes -= float64(event.WriteSize)
+		r.readBytes -= float64(event.ReadSize)
+		r.cpu -= float64(event.RequestCPU)
+		ret = append(ret, event)
+	}
+	return ret
+}
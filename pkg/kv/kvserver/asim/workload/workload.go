@@ -26,6 +26,9 @@ type LoadEvent struct {
 	WriteSize int64
 	Reads     int64
 	ReadSize  int64
+	// RequestCPU is the cpu time (ns) used serving the load event. It is only
+	// populated by generators which replay recorded load.
+	RequestCPU int64
 }
 
 // LoadBatch is a sorted list of load events.
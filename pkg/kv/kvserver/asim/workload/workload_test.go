@@ -162,3 +162,57 @@ func TestRandWorkloadGenerator(t *testing.T) {
 		require.Equal(t, math.Round(tc.readRatio*100), math.Round((float64(stats.reads)/float64(stats.reads+stats.writes))*100))
 	}
 }
+
+// TestReplayGenerator asserts that the recorded load of each range is replayed
+// in full on keys within the range, carrying over fractional load between
+// ticks.
+func TestReplayGenerator(t *testing.T) {
+	recorded := []RecordedLoad{
+		{
+			StartKey:            100,
+			EndKey:              200,
+			QueriesPerSecond:    10,
+			WritesPerSecond:     5,
+			ReadsPerSecond:      15,
+			WriteBytesPerSecond: 1000,
+			ReadBytesPerSecond:  3000,
+			CPUTimePerSecond:    float64(time.Millisecond),
+		},
+		{
+			StartKey:         0,
+			EndKey:           100,
+			QueriesPerSecond: 0.5,
+		},
+	}
+
+	start := time.Date(2022, 03, 21, 11, 0, 0, 0, time.UTC)
+	gen := NewReplayGenerator(start, testingSeed, recorded)
+
+	var writes, reads, writeSize, readSize, cpu [2]int64
+	for tick := start; tick.Before(start.Add(100 * time.Second)); {
+		tick = tick.Add(500 * time.Millisecond)
+		for _, event := range gen.Tick(tick) {
+			idx := 0
+			if event.Key >= 100 {
+				idx = 1
+			}
+			require.Less(t, event.Key, recorded[1-idx].EndKey)
+			require.GreaterOrEqual(t, event.Key, recorded[1-idx].StartKey)
+			writes[idx] += event.Writes
+			reads[idx] += event.Reads
+			writeSize[idx] += event.WriteSize
+			readSize[idx] += event.ReadSize
+			cpu[idx] += event.RequestCPU
+		}
+	}
+
+	require.Equal(t, int64(250), writes[1])
+	require.Equal(t, int64(750), reads[1])
+	require.Equal(t, int64(100*1000), writeSize[1])
+	require.Equal(t, int64(100*3000), readSize[1])
+	require.Equal(t, int64(100*time.Millisecond), cpu[1])
+	// A range without any recorded keys read or written has its queries
+	// generated as reads.
+	require.Equal(t, int64(0), writes[0])
+	require.Equal(t, int64(50), reads[0])
+}
@@ -273,6 +273,10 @@ func GC(
 	// We should only do that when we are doing actual cleanup as we want to have
 	// a hint when request is being handled.
 	if len(args.Keys) != 0 || len(args.RangeKeys) != 0 || args.ClearRange != nil {
+		// The removed versions are below the closed timestamp, so the removal is
+		// recorded to prevent delta snapshots across it.
+		res.Replicated.RewritesMVCCHistory = true
+
 		sl := MakeStateLoader(cArgs.EvalCtx)
 		hint, err := sl.LoadGCHint(ctx, readWriter)
 		if err != nil {
@@ -14,6 +14,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/batcheval/result"
@@ -27,6 +28,7 @@ import (
 
 func init() {
 	RegisterReadWriteCommand(kvpb.Migrate, declareKeysMigrate, Migrate)
+	registerMigration(clusterversion.V24_1_MVCCHistoryMutationIndex, mvccHistoryMutationIndexMigration)
 }
 
 func declareKeysMigrate(
@@ -55,6 +57,24 @@ var migrationRegistry = make(map[roachpb.Version]migration)
 
 type migration func(context.Context, storage.ReadWriter, CommandArgs) (result.Result, error)
 
+func registerMigration(key clusterversion.Key, migration migration) {
+	migrationRegistry[key.Version()] = migration
+}
+
+// mvccHistoryMutationIndexMigration starts recording the index of commands
+// which remove or write MVCC versions below the closed timestamp in the
+// RangeAppliedState. The migration itself is recorded as such a command, to
+// cover the commands applied before replicas started recording them.
+func mvccHistoryMutationIndexMigration(
+	_ context.Context, _ storage.ReadWriter, _ CommandArgs,
+) (result.Result, error) {
+	return result.Result{
+		Replicated: kvserverpb.ReplicatedEvalResult{
+			RewritesMVCCHistory: true,
+		},
+	}, nil
+}
+
 // Migrate executes the below-raft migration corresponding to the given version.
 // See kvpb.MigrateRequest for more details.
 func Migrate(
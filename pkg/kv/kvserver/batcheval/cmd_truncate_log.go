@@ -121,7 +121,11 @@ func TruncateLog(
 	// are not tracked in the raft log delta. The delta will be adjusted below
 	// raft.
 	// We can pass zero as nowNanos because we're only interested in SysBytes.
-	ms, err := storage.ComputeStats(ctx, readWriter, start, end, 0 /* nowNanos */)
+	var logReader storage.Reader = readWriter
+	if r := cArgs.EvalCtx.GetSeparatedRaftLogReader(); r != nil {
+		logReader = r
+	}
+	ms, err := storage.ComputeStats(ctx, logReader, start, end, 0 /* nowNanos */)
 	if err != nil {
 		return result.Result{}, errors.Wrap(err, "while computing stats of Raft log freed by truncation")
 	}
@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/readsummary/rspb"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/limit"
@@ -65,6 +66,10 @@ type EvalContext interface {
 	GetFirstIndex() kvpb.RaftIndex
 	GetTerm(index kvpb.RaftIndex) (kvpb.RaftTerm, error)
 	GetLeaseAppliedIndex() kvpb.LeaseAppliedIndex
+	// GetSeparatedRaftLogReader returns a reader of the raft log if the store
+	// keeps it in a separate engine, which the batch of the command doesn't
+	// read from. Returns nil otherwise.
+	GetSeparatedRaftLogReader() storage.Reader
 
 	Desc() *roachpb.RangeDescriptor
 	ContainsKey(key roachpb.Key) bool
@@ -239,6 +244,9 @@ func (m *mockEvalCtxImpl) GetFirstIndex() kvpb.RaftIndex {
 func (m *mockEvalCtxImpl) GetTerm(kvpb.RaftIndex) (kvpb.RaftTerm, error) {
 	return m.Term, nil
 }
+func (m *mockEvalCtxImpl) GetSeparatedRaftLogReader() storage.Reader {
+	return nil
+}
 func (m *mockEvalCtxImpl) GetLeaseAppliedIndex() kvpb.LeaseAppliedIndex {
 	panic("unimplemented")
 }
@@ -336,6 +336,9 @@ func (p *Result) MergeAndDestroy(q Result) error {
 	}
 	q.Replicated.MVCCHistoryMutation = nil
 
+	p.Replicated.RewritesMVCCHistory = p.Replicated.RewritesMVCCHistory || q.Replicated.RewritesMVCCHistory
+	q.Replicated.RewritesMVCCHistory = false
+
 	if p.Replicated.PrevLeaseProposal == nil {
 		p.Replicated.PrevLeaseProposal = q.Replicated.PrevLeaseProposal
 	} else if q.Replicated.PrevLeaseProposal != nil {
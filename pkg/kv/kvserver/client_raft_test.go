@@ -36,6 +36,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvflowcontrol/kvflowdispatch"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvstorage"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/liveness"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/liveness/livenesspb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/rditer"
@@ -197,6 +198,104 @@ func TestStoreRecoverFromEngine(t *testing.T) {
 	validate(store)
 }
 
+// TestStoreRaftLogEngineSeparated verifies that a store with a separate raft
+// log engine keeps the raft state of its replicas only in the log engine
+// across splits, log truncations and restarts.
+func TestStoreRaftLogEngineSeparated(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	lisReg := listenerutil.NewListenerRegistry()
+	defer lisReg.Close()
+
+	ctx := context.Background()
+	tc := testcluster.StartTestCluster(t, 1,
+		base.TestClusterArgs{
+			ReplicationMode:     base.ReplicationManual,
+			ReusableListenerReg: lisReg,
+			ServerArgs: base.TestServerArgs{
+				StoreSpecs: []base.StoreSpec{
+					{
+						InMemory:    true,
+						StickyVFSID: "1",
+						RaftLogPath: "raft-log",
+					},
+				},
+				Knobs: base.TestingKnobs{
+					Server: &server.TestingKnobs{
+						StickyVFSRegistry: fs.NewStickyRegistry(),
+					},
+				},
+			},
+		})
+	defer tc.Stopper().Stop(ctx)
+
+	splitKey := roachpb.Key("m")
+	key1 := roachpb.Key("a")
+	key2 := roachpb.Key("z")
+
+	increment := func(store *kvserver.Store, key roachpb.Key, value int64) int64 {
+		t.Helper()
+		resp, pErr := kv.SendWrapped(ctx, store.TestSender(), incrementArgs(key, value))
+		require.NoError(t, pErr.GoError())
+		return resp.(*kvpb.IncrementResponse).NewValue
+	}
+	// checkEngines verifies that the raft state of all replicas is in the log
+	// engine, and none of it is in the state engine.
+	checkEngines := func(store *kvserver.Store) {
+		t.Helper()
+		require.NotEqual(t, store.StateEngine(), store.LogEngine())
+		separated, err := kvstorage.IsRaftLogEngineSeparated(ctx, store.StateEngine())
+		require.NoError(t, err)
+		require.True(t, separated)
+		store.VisitReplicas(func(repl *kvserver.Replica) bool {
+			for _, span := range kvstorage.RaftStateSpans(repl.RangeID) {
+				empty, err := storage.MVCCIsSpanEmpty(ctx, store.StateEngine(), storage.MVCCIsSpanEmptyOptions{
+					StartKey: span.Key, EndKey: span.EndKey,
+				})
+				require.NoError(t, err)
+				require.True(t, empty, "r%d: raft state found in state engine", repl.RangeID)
+			}
+			hs, err := stateloader.Make(repl.RangeID).LoadHardState(ctx, store.LogEngine())
+			require.NoError(t, err)
+			require.NotZero(t, hs.Commit, "r%d", repl.RangeID)
+			return true
+		})
+	}
+
+	store := tc.GetFirstStoreFromServer(t, 0)
+	increment(store, key1, 2)
+	increment(store, key2, 5)
+	tc.SplitRangeOrFatal(t, splitKey)
+	increment(store, key1, 11)
+	increment(store, key2, 23)
+
+	// Truncate the log of the RHS. The truncation is enacted once the state
+	// engine is durable.
+	repl := store.LookupReplica(roachpb.RKey(key2))
+	index := repl.GetLastIndex()
+	_, pErr := kv.SendWrapped(ctx, store.TestSender(), truncateLogArgs(index+1, repl.RangeID))
+	require.NoError(t, pErr.GoError())
+	testutils.SucceedsSoon(t, func() error {
+		if err := store.StateEngine().Flush(); err != nil {
+			return err
+		}
+		if first := repl.GetFirstIndex(); first <= index {
+			return errors.Errorf("first index %d not truncated beyond %d", first, index)
+		}
+		return nil
+	})
+	checkEngines(store)
+
+	tc.StopServer(0)
+	require.NoError(t, tc.RestartServer(0))
+	store = tc.GetFirstStoreFromServer(t, 0)
+	checkEngines(store)
+	require.Equal(t, int64(13), increment(store, key1, 0))
+	require.Equal(t, int64(28), increment(store, key2, 0))
+	require.Less(t, index, store.LookupReplica(roachpb.RKey(key2)).GetFirstIndex())
+}
+
 // TestStoreRecoverWithErrors verifies that even commands that fail are marked as
 // applied so they are not retried after recovery.
 func TestStoreRecoverWithErrors(t *testing.T) {
@@ -843,6 +942,112 @@ func TestSnapshotAfterTruncation(t *testing.T) {
 	}
 }
 
+// TestDeltaSnapshotAfterTruncation tests that a replica which missed a few
+// writes and whose log was truncated in the meantime catches up via a delta
+// snapshot, and ends up with the same user data as the other replicas. If it
+// also missed a ClearRange request, which removes versions below the closed
+// timestamp, it must catch up via a full snapshot instead.
+func TestDeltaSnapshotAfterTruncation(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testutils.RunTrueAndFalse(t, "clearRange", func(t *testing.T, clearRange bool) {
+		lisReg := listenerutil.NewListenerRegistry()
+		defer lisReg.Close()
+
+		const numServers int = 3
+		stickyServerArgs := make(map[int]base.TestServerArgs)
+		for i := 0; i < numServers; i++ {
+			stickyServerArgs[i] = base.TestServerArgs{
+				StoreSpecs: []base.StoreSpec{
+					{
+						InMemory:    true,
+						StickyVFSID: strconv.FormatInt(int64(i), 10),
+					},
+				},
+				Knobs: base.TestingKnobs{
+					Server: &server.TestingKnobs{
+						StickyVFSRegistry: fs.NewStickyRegistry(),
+					},
+				},
+			}
+		}
+
+		ctx := context.Background()
+		tc := testcluster.StartTestCluster(t, numServers,
+			base.TestClusterArgs{
+				ReplicationMode:     base.ReplicationManual,
+				ReusableListenerReg: lisReg,
+				ServerArgsPerNode:   stickyServerArgs,
+			})
+		defer tc.Stopper().Stop(ctx)
+		sqlDB := sqlutils.MakeSQLRunner(tc.ServerConn(0))
+		sqlDB.Exec(t, `SET CLUSTER SETTING kv.snapshot.delta.enabled = true`)
+		sqlDB.Exec(t, `SET CLUSTER SETTING kv.closed_timestamp.target_duration = '10ms'`)
+
+		const stoppedStore = 1
+		store := tc.GetFirstStoreFromServer(t, 0)
+		key := tc.ScratchRange(t)
+		changedKey := append(key.Clone(), 'a')
+		unchangedKey := append(key.Clone(), 'b')
+		tc.AddVotersOrFatal(t, key, tc.Targets(1, 2)...)
+
+		for _, k := range []roachpb.Key{key, changedKey, unchangedKey} {
+			_, pErr := kv.SendWrapped(ctx, store.TestSender(), incrementArgs(k, 5))
+			require.NoError(t, pErr.GoError())
+		}
+		tc.WaitForValues(t, unchangedKey, []int64{5, 5, 5})
+
+		// Wait for the replica that will fall behind to have a closed timestamp,
+		// which the delta snapshot is computed against.
+		testutils.SucceedsSoon(t, func() error {
+			_, pErr := kv.SendWrapped(ctx, store.TestSender(), incrementArgs(key, 1))
+			require.NoError(t, pErr.GoError())
+			repl := tc.GetFirstStoreFromServer(t, stoppedStore).LookupReplica(roachpb.RKey(key))
+			if repl.State(ctx).RaftClosedTimestamp.IsEmpty() {
+				return errors.New("no closed timestamp yet")
+			}
+			return nil
+		})
+
+		tc.StopServer(stoppedStore)
+		_, pErr := kv.SendWrapped(ctx, store.TestSender(), incrementArgs(changedKey, 7))
+		require.NoError(t, pErr.GoError())
+		tc.WaitForValues(t, changedKey, []int64{12, 0 /* stopped server */, 12})
+		if clearRange {
+			_, pErr = kv.SendWrapped(ctx, store.DB().NonTransactionalSender(), &kvpb.ClearRangeRequest{
+				RequestHeader: kvpb.RequestHeader{Key: unchangedKey, EndKey: unchangedKey.Next()},
+			})
+			require.NoError(t, pErr.GoError())
+		}
+
+		repl0 := store.LookupReplica(roachpb.RKey(key))
+		index := repl0.GetLastIndex()
+		truncArgs := truncateLogArgs(index+1, repl0.GetRangeID())
+		_, pErr = kv.SendWrapped(ctx, store.TestSender(), truncArgs)
+		require.NoError(t, pErr.GoError())
+
+		require.NoError(t, tc.RestartServer(stoppedStore))
+		tc.WaitForValues(t, changedKey, []int64{12, 12, 12})
+		expDeltas := int64(1)
+		if clearRange {
+			expDeltas = 0
+		}
+		require.Equal(t, expDeltas,
+			tc.GetFirstStoreFromServer(t, stoppedStore).Metrics().RangeSnapshotsAppliedAsDelta.Count())
+
+		// The user data of the replicas must be identical, including all versions.
+		desc := repl0.Desc()
+		scan := func(i int) []storage.MVCCKeyValue {
+			kvs, err := storage.Scan(ctx, tc.GetFirstStoreFromServer(t, i).TODOEngine(),
+				desc.StartKey.AsRawKey(), desc.EndKey.AsRawKey(), 0 /* max */)
+			require.NoError(t, err)
+			return kvs
+		}
+		require.Equal(t, scan(0), scan(stoppedStore))
+	})
+}
+
 func waitForTruncationForTesting(t *testing.T, r *kvserver.Replica, newFirstIndex kvpb.RaftIndex) {
 	testutils.SucceedsSoon(t, func() error {
 		// Flush the engine to advance durability, which triggers truncation.
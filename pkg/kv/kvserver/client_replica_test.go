@@ -4907,16 +4907,18 @@ func TestRangeMigration(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
 
-	// We're going to be transitioning from startV to endV. Think a cluster of
-	// binaries running vX, but with active version vX-1.
+	// We're going to be transitioning from startV to endV, and then to latestV,
+	// which has a below-raft migration registered. Think a cluster of binaries
+	// running vX, but with active version vX-1.
 	startV := clusterversion.PreviousRelease.Version()
-	endV := clusterversion.Latest.Version()
+	endV := (clusterversion.V24_1_MVCCHistoryMutationIndex - 1).Version()
+	latestV := clusterversion.V24_1_MVCCHistoryMutationIndex.Version()
 
 	ctx := context.Background()
 	tc := testcluster.StartTestCluster(t, 1, base.TestClusterArgs{
 		ReplicationMode: base.ReplicationManual,
 		ServerArgs: base.TestServerArgs{
-			Settings: cluster.MakeTestingClusterSettingsWithVersions(endV, startV, false),
+			Settings: cluster.MakeTestingClusterSettingsWithVersions(latestV, startV, false),
 			Knobs: base.TestingKnobs{
 				Server: &server.TestingKnobs{
 					BinaryVersionOverride:          startV,
@@ -4970,6 +4972,20 @@ func TestRangeMigration(t *testing.T) {
 		t.Fatalf("expected migration interceptor to have been called")
 	}
 	assertVersion(endV)
+
+	// The migration to latestV records its own index as the last MVCC history
+	// mutation.
+	req = migrateArgs(desc.StartKey.AsRawKey(), desc.EndKey.AsRawKey(), latestV)
+	if _, pErr := kv.SendWrappedWith(ctx, kvDB.GetFactory().NonTransactionalSender(), kvpb.Header{RangeID: desc.RangeID}, req); pErr != nil {
+		t.Fatal(pErr)
+	}
+	assertVersion(latestV)
+	repl, err := store.GetReplica(rangeID)
+	require.NoError(t, err)
+	as, err := stateloader.Make(rangeID).LoadRangeAppliedState(ctx, store.TODOEngine())
+	require.NoError(t, err)
+	require.NotZero(t, as.MVCCHistoryMutationIndex)
+	require.Equal(t, as.MVCCHistoryMutationIndex, repl.State(ctx).MVCCHistoryMutationIndex)
 }
 
 func setupDBAndWriteAAndB(t *testing.T) (serverutils.TestServerInterface, *kv.DB) {
@@ -0,0 +1,40 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package kvstorage
+
+import "github.com/cockroachdb/cockroach/pkg/storage"
+
+// Engines contains the storage engines of a Store. The LogEngine holds the
+// raft state of the Store's replicas, i.e. their raft logs, HardStates and
+// RaftTruncatedStates. The StateEngine holds everything else, in particular
+// the replicated state machines. The two are the same Engine unless the Store
+// was configured with a separate raft log engine.
+type Engines struct {
+	StateEngine storage.Engine
+	LogEngine   storage.Engine
+}
+
+// MakeEngines returns the Engines of a Store which keeps all of its state in
+// the given engine.
+func MakeEngines(eng storage.Engine) Engines {
+	return Engines{StateEngine: eng, LogEngine: eng}
+}
+
+// MakeSeparatedEngines returns the Engines of a Store which keeps the raft
+// state of its replicas in a separate engine.
+func MakeSeparatedEngines(stateEngine, logEngine storage.Engine) Engines {
+	return Engines{StateEngine: stateEngine, LogEngine: logEngine}
+}
+
+// Separated returns whether the raft state is kept in a separate engine.
+func (e Engines) Separated() bool {
+	return e.LogEngine != e.StateEngine
+}
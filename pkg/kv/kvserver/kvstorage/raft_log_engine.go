@@ -0,0 +1,395 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package kvstorage
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/logstore"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/raftlog"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/stateloader"
+	"github.com/cockroachdb/cockroach/pkg/raft/raftpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// The raft state of a replica, i.e. its HardState, RaftTruncatedState and raft
+// log, lives in the log engine of the store. Everything else, including the
+// RaftReplicaID and the RangeTombstone, lives in the state engine. Updates
+// which touch both engines (snapshots, splits, replica removal, and log
+// truncations) write the state engine first, and the log engine second. The
+// state engine is thus the source of truth, and on startup, the raft state of
+// each replica in the log engine is reconciled with the state engine to
+// recover from a crash in between the two writes, see reconcileRaftState.
+//
+// A store which was started with a separate log engine once persists a marker
+// key in its state engine, and refuses to start without it, since the votes
+// in the HardStates must not be lost.
+
+// RaftStateSpans returns the spans of the range-ID local keys which make up the
+// raft state of the given range, i.e. its HardState, raft log and
+// RaftTruncatedState.
+func RaftStateSpans(rangeID roachpb.RangeID) []roachpb.Span {
+	// NB: the HardState and the raft log are adjacent.
+	truncStateKey := keys.RaftTruncatedStateKey(rangeID)
+	return []roachpb.Span{
+		{Key: keys.RaftHardStateKey(rangeID), EndKey: keys.RaftLogPrefix(rangeID).PrefixEnd()},
+		{Key: truncStateKey, EndKey: truncStateKey.Next()},
+	}
+}
+
+// IsRaftLogEngineSeparated returns whether the store with the given state
+// engine keeps the raft state of its replicas in a separate log engine.
+func IsRaftLogEngineSeparated(ctx context.Context, stateEngine storage.Reader) (bool, error) {
+	res, err := storage.MVCCGet(ctx, stateEngine, keys.StoreRaftLogEngineKey(), hlc.Timestamp{},
+		storage.MVCCGetOptions{})
+	if err != nil || res.Value == nil {
+		return false, err
+	}
+	return res.Value.GetBool()
+}
+
+// ClearRaftLogEngineState clears the raft state of the given replica from the
+// separate log engine. The reader must read from the log engine.
+func ClearRaftLogEngineState(
+	ctx context.Context, rangeID roachpb.RangeID, reader storage.Reader, writer storage.Writer,
+) error {
+	return ClearRangeData(ctx, rangeID, reader, writer, ClearRangeDataOptions{
+		ClearUnreplicatedByRangeID: true,
+	})
+}
+
+// prepareRaftLogEngine verifies that the Engines are compatible with the
+// persisted state of the store, and, if the log engine is separate, makes sure
+// that the raft state of all replicas is found in it and is consistent with the
+// state engine.
+func prepareRaftLogEngine(ctx context.Context, engs Engines, ident roachpb.StoreIdent) error {
+	separated, err := IsRaftLogEngineSeparated(ctx, engs.StateEngine)
+	if err != nil {
+		return err
+	}
+	if !engs.Separated() {
+		if separated {
+			return errors.Errorf(
+				"store %s keeps its raft state in a separate raft log engine, which was not provided; "+
+					"the store must be started with its raft-log-path", ident)
+		}
+		return nil
+	}
+	if err := initRaftLogEngine(ctx, engs, ident, separated); err != nil {
+		return err
+	}
+	if err := moveRaftStateToLogEngine(ctx, engs, separated); err != nil {
+		return errors.Wrap(err, "while moving raft state to the raft log engine")
+	}
+	return reconcileRaftLogEngine(ctx, engs)
+}
+
+// initRaftLogEngine verifies that the log engine belongs to the store with the
+// given ident, and writes the ident to it if it's used for the first time.
+func initRaftLogEngine(
+	ctx context.Context, engs Engines, ident roachpb.StoreIdent, separated bool,
+) error {
+	logIdent, err := ReadStoreIdent(ctx, engs.LogEngine)
+	if err == nil {
+		if logIdent != ident {
+			return errors.Errorf("raft log engine %s belongs to store %s, not to %s",
+				engs.LogEngine, logIdent, ident)
+		}
+		return nil
+	}
+	if !errors.HasType(err, (*NotBootstrappedError)(nil)) {
+		return err
+	}
+	if separated {
+		return errors.Errorf(
+			"store %s keeps its raft state in a separate raft log engine, but %s is not initialized",
+			ident, engs.LogEngine)
+	}
+	log.Infof(ctx, "initializing raft log engine %s", engs.LogEngine)
+	return errors.Wrap(InitEngine(ctx, engs.LogEngine, ident), "while initializing raft log engine")
+}
+
+// moveRaftStateToLogEngine moves any raft state found in the state engine to
+// the log engine, overwriting the raft state of the respective replicas in the
+// log engine. Such state is written by the initial bootstrap of a store, and
+// exists for all replicas when the log engine was just separated. It also marks
+// the store as separated in the state engine.
+//
+// The log engine is written and synced first, so this is idempotent.
+func moveRaftStateToLogEngine(ctx context.Context, engs Engines, separated bool) error {
+	var rangeIDs []roachpb.RangeID
+	if err := iterateRangeIDs(ctx, engs.StateEngine, func(rangeID roachpb.RangeID) error {
+		for _, span := range RaftStateSpans(rangeID) {
+			if empty, err := storage.MVCCIsSpanEmpty(ctx, engs.StateEngine, storage.MVCCIsSpanEmptyOptions{
+				StartKey: span.Key, EndKey: span.EndKey,
+			}); err != nil {
+				return err
+			} else if !empty {
+				rangeIDs = append(rangeIDs, rangeID)
+				return nil
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if len(rangeIDs) == 0 && separated {
+		return nil
+	}
+	log.Infof(ctx, "moving raft state of %d replicas to the raft log engine", len(rangeIDs))
+
+	logBatch := engs.LogEngine.NewBatch()
+	defer logBatch.Close()
+	stateBatch := engs.StateEngine.NewBatch()
+	defer stateBatch.Close()
+	for _, rangeID := range rangeIDs {
+		for _, span := range RaftStateSpans(rangeID) {
+			if err := logBatch.ClearRawRange(
+				span.Key, span.EndKey, true /* pointKeys */, false, /* rangeKeys */
+			); err != nil {
+				return err
+			}
+			if err := copySpan(ctx, engs.StateEngine, logBatch, span); err != nil {
+				return err
+			}
+			if err := stateBatch.ClearRawRange(
+				span.Key, span.EndKey, true /* pointKeys */, false, /* rangeKeys */
+			); err != nil {
+				return err
+			}
+		}
+	}
+	if err := logBatch.Commit(true /* sync */); err != nil {
+		return err
+	}
+
+	var v roachpb.Value
+	v.SetBool(true)
+	if _, err := storage.MVCCBlindPut(
+		ctx, stateBatch, keys.StoreRaftLogEngineKey(), hlc.Timestamp{}, v, storage.MVCCWriteOptions{},
+	); err != nil {
+		return err
+	}
+	return stateBatch.Commit(true /* sync */)
+}
+
+// copySpan copies all point keys in the span from the reader to the writer.
+func copySpan(
+	ctx context.Context, reader storage.Reader, writer storage.Writer, span roachpb.Span,
+) error {
+	iter, err := reader.NewEngineIterator(ctx, storage.IterOptions{
+		KeyTypes:   storage.IterKeyTypePointsOnly,
+		LowerBound: span.Key,
+		UpperBound: span.EndKey,
+	})
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+	ok, err := iter.SeekEngineKeyGE(storage.EngineKey{Key: span.Key})
+	for ; ok && err == nil; ok, err = iter.NextEngineKey() {
+		key, err := iter.UnsafeEngineKey()
+		if err != nil {
+			return err
+		}
+		value, err := iter.UnsafeValue()
+		if err != nil {
+			return err
+		}
+		if err := writer.PutEngineKey(key, value); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// iterateRangeIDs calls the given function for each range which has range-ID
+// local keys in the reader, in ascending order.
+func iterateRangeIDs(
+	ctx context.Context, reader storage.Reader, fn func(roachpb.RangeID) error,
+) error {
+	// NB: Range-ID local keys have no versions and no intents.
+	iter, err := reader.NewMVCCIterator(ctx, storage.MVCCKeyIterKind, storage.IterOptions{
+		LowerBound: keys.LocalRangeIDPrefix.AsRawKey(),
+		UpperBound: keys.LocalRangeIDPrefix.PrefixEnd().AsRawKey(),
+	})
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+	for iter.SeekGE(storage.MakeMVCCMetadataKey(keys.LocalRangeIDPrefix.AsRawKey())); ; {
+		if ok, err := iter.Valid(); !ok {
+			return err
+		}
+		rangeID, _, _, _, err := keys.DecodeRangeIDKey(iter.UnsafeKey().Key)
+		if err != nil {
+			return err
+		}
+		if err := fn(rangeID); err != nil {
+			return iterutil.Map(err)
+		}
+		iter.SeekGE(storage.MakeMVCCMetadataKey(keys.MakeRangeIDPrefix(rangeID + 1)))
+	}
+}
+
+// reconcileRaftLogEngine reconciles the raft state of all replicas in the log
+// engine with the state engine. The raft state of replicas which don't exist in
+// the state engine is removed.
+func reconcileRaftLogEngine(ctx context.Context, engs Engines) error {
+	replicas := map[roachpb.RangeID]struct{}{}
+	var msg kvserverpb.RaftReplicaID
+	if err := IterateIDPrefixKeys(ctx, engs.StateEngine, keys.RaftReplicaIDKey, &msg,
+		func(rangeID roachpb.RangeID) error {
+			replicas[rangeID] = struct{}{}
+			return nil
+		},
+	); err != nil {
+		return err
+	}
+
+	batch := engs.LogEngine.NewBatch()
+	defer batch.Close()
+	if err := iterateRangeIDs(ctx, engs.LogEngine, func(rangeID roachpb.RangeID) error {
+		if _, ok := replicas[rangeID]; ok {
+			return nil
+		}
+		log.Infof(ctx, "r%d: removing raft state of destroyed replica from raft log engine", rangeID)
+		return ClearRaftLogEngineState(ctx, rangeID, engs.LogEngine, batch)
+	}); err != nil {
+		return err
+	}
+
+	rangeIDs := make([]roachpb.RangeID, 0, len(replicas))
+	for rangeID := range replicas {
+		rangeIDs = append(rangeIDs, rangeID)
+	}
+	sort.Slice(rangeIDs, func(i, j int) bool { return rangeIDs[i] < rangeIDs[j] })
+	for _, rangeID := range rangeIDs {
+		if err := reconcileRaftState(ctx, engs, batch, rangeID); err != nil {
+			return errors.Wrapf(err, "r%d", rangeID)
+		}
+	}
+	if batch.Empty() {
+		return nil
+	}
+	return batch.Commit(true /* sync */)
+}
+
+// reconcileRaftState makes sure that the raft state of the replica in the log
+// engine is consistent with its applied state in the state engine. Crashes
+// after a write to the state engine but before the corresponding write to the
+// log engine leave the log engine behind:
+//
+//   - An uninitialized replica must have no raft log and HardState.Commit = 0.
+//     The raft state is left over from a destroyed replica in this case, of
+//     which only the term and vote are kept.
+//   - The raft log of an initialized replica must contain its applied index,
+//     with the applied term. Otherwise, the replica applied a snapshot or was
+//     created by a split, and its raft log is replaced with a truncated state
+//     at the applied index.
+func reconcileRaftState(
+	ctx context.Context, engs Engines, w storage.Writer, rangeID roachpb.RangeID,
+) error {
+	as, err := stateloader.Make(rangeID).LoadRangeAppliedState(ctx, engs.StateEngine)
+	if err != nil {
+		return err
+	}
+	sl := logstore.NewStateLoader(rangeID)
+	hs, err := sl.LoadHardState(ctx, engs.LogEngine)
+	if err != nil {
+		return err
+	}
+	ts, err := sl.LoadRaftTruncatedState(ctx, engs.LogEngine)
+	if err != nil {
+		return err
+	}
+	lastIndex, err := sl.LoadLastIndex(ctx, engs.LogEngine)
+	if err != nil {
+		return err
+	}
+	clearLog := func() error {
+		prefix := keys.RaftLogPrefix(rangeID)
+		if err := w.ClearRawRange(
+			prefix, prefix.PrefixEnd(), true /* pointKeys */, false, /* rangeKeys */
+		); err != nil {
+			return err
+		}
+		return w.ClearUnversioned(keys.RaftTruncatedStateKey(rangeID), storage.ClearOptions{})
+	}
+
+	applied := as.RaftAppliedIndex
+	if applied == 0 {
+		if lastIndex == 0 && hs.Commit == 0 {
+			return nil
+		}
+		log.Infof(ctx, "r%d: removing raft log of uninitialized replica, last index %d, %+v",
+			rangeID, lastIndex, hs)
+		if err := clearLog(); err != nil {
+			return err
+		}
+		hs.Commit = 0
+		return sl.SetHardState(ctx, w, hs)
+	}
+
+	if ts.Index > applied {
+		return errors.AssertionFailedf("raft log truncated at %d beyond applied index %d",
+			ts.Index, applied)
+	}
+	if lastIndex >= applied {
+		term := ts.Term
+		if applied > ts.Index {
+			if err := raftlog.Visit(ctx, engs.LogEngine, rangeID, applied, applied+1,
+				func(ent raftpb.Entry) error {
+					term = kvpb.RaftTerm(ent.Term)
+					return nil
+				},
+			); err != nil {
+				return err
+			}
+		}
+		if term == as.RaftAppliedIndexTerm {
+			if hs.Commit >= uint64(applied) {
+				return nil
+			}
+			// The HardState update of the commit index raced with the crash.
+			hs.Commit = uint64(applied)
+			return sl.SetHardState(ctx, w, hs)
+		}
+	}
+	if as.RaftAppliedIndexTerm == 0 {
+		return errors.AssertionFailedf("unknown term of applied index %d", applied)
+	}
+	log.Infof(ctx, "r%d: replacing raft log at %d, last index %d, by applied index %d",
+		rangeID, ts.Index, lastIndex, applied)
+	if err := clearLog(); err != nil {
+		return err
+	}
+	ts = kvserverpb.RaftTruncatedState{Index: applied, Term: as.RaftAppliedIndexTerm}
+	if err := sl.SetRaftTruncatedState(ctx, w, &ts); err != nil {
+		return err
+	}
+	if hs.Commit < uint64(applied) {
+		hs.Commit = uint64(applied)
+	}
+	if hs.Term < uint64(ts.Term) {
+		hs.Term, hs.Vote = uint64(ts.Term), 0
+	}
+	return sl.SetHardState(ctx, w, hs)
+}
@@ -0,0 +1,142 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package kvstorage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/stateloader"
+	"github.com/cockroachdb/cockroach/pkg/raft/raftpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRaftLogEngineSeparation verifies that the raft state is moved from the
+// state engine to a separate log engine on startup, and that the log engine is
+// reconciled with the state engine.
+func TestRaftLogEngineSeparation(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	stateEng := storage.NewDefaultInMemForTesting()
+	defer stateEng.Close()
+	logEng := storage.NewDefaultInMemForTesting()
+	defer logEng.Close()
+	engs := MakeSeparatedEngines(stateEng, logEng)
+	require.True(t, engs.Separated())
+	require.False(t, MakeEngines(stateEng).Separated())
+
+	ident := roachpb.StoreIdent{ClusterID: uuid.MakeV4(), NodeID: 1, StoreID: 1}
+	require.NoError(t, InitEngine(ctx, stateEng, ident))
+
+	const rangeID = roachpb.RangeID(1)
+	desc := roachpb.RangeDescriptor{
+		RangeID:  rangeID,
+		StartKey: roachpb.RKeyMin,
+		EndKey:   roachpb.RKeyMax,
+		InternalReplicas: []roachpb.ReplicaDescriptor{
+			{NodeID: 1, StoreID: 1, ReplicaID: 1},
+		},
+		NextReplicaID: 2,
+	}
+	require.NoError(t, stateloader.WriteInitialRangeState(
+		ctx, stateEng, desc, 1 /* replicaID */, clusterversion.Latest.Version()))
+	sl := stateloader.Make(rangeID)
+	hs, err := sl.LoadHardState(ctx, stateEng)
+	require.NoError(t, err)
+	ts, err := sl.LoadRaftTruncatedState(ctx, stateEng)
+	require.NoError(t, err)
+
+	// The raft state of a replica which doesn't exist in the state engine is
+	// removed from the log engine.
+	const orphanRangeID = roachpb.RangeID(2)
+	require.NoError(t, stateloader.Make(orphanRangeID).SetHardState(
+		ctx, logEng, raftpb.HardState{Term: 5, Vote: 1}))
+
+	requireRaftStateEmpty := func(eng storage.Reader, rangeID roachpb.RangeID, exp bool) {
+		t.Helper()
+		for _, span := range RaftStateSpans(rangeID) {
+			empty, err := storage.MVCCIsSpanEmpty(ctx, eng, storage.MVCCIsSpanEmptyOptions{
+				StartKey: span.Key, EndKey: span.EndKey,
+			})
+			require.NoError(t, err)
+			require.Equal(t, exp, empty, "%s", span)
+		}
+	}
+
+	// The first start moves the raft state to the log engine, and marks the
+	// store as separated.
+	repls, err := LoadAndReconcileReplicas(ctx, engs)
+	require.NoError(t, err)
+	require.Len(t, repls, 1)
+	separated, err := IsRaftLogEngineSeparated(ctx, stateEng)
+	require.NoError(t, err)
+	require.True(t, separated)
+	logIdent, err := ReadStoreIdent(ctx, logEng)
+	require.NoError(t, err)
+	require.Equal(t, ident, logIdent)
+	requireRaftStateEmpty(stateEng, rangeID, true)
+	requireRaftStateEmpty(logEng, rangeID, false)
+	requireRaftStateEmpty(logEng, orphanRangeID, true)
+
+	logHS, err := sl.LoadHardState(ctx, logEng)
+	require.NoError(t, err)
+	require.Equal(t, hs, logHS)
+	logTS, err := sl.LoadRaftTruncatedState(ctx, logEng)
+	require.NoError(t, err)
+	require.Equal(t, ts, logTS)
+
+	// The store refuses to start without its log engine.
+	_, err = LoadAndReconcileReplicas(ctx, MakeEngines(stateEng))
+	require.True(t, testutils.IsError(err, "must be started with its raft-log-path"), "%v", err)
+
+	// Simulate a crash after a snapshot was applied to the state engine, but
+	// before the raft state was written to the log engine.
+	as, err := sl.LoadRangeAppliedState(ctx, stateEng)
+	require.NoError(t, err)
+	ms := as.RangeStats.ToStats()
+	require.NoError(t, sl.SetRangeAppliedState(
+		ctx, stateEng, as.RaftAppliedIndex+10, as.LeaseAppliedIndex, as.RaftAppliedIndexTerm+1,
+		&ms, as.RaftClosedTimestamp, as.MVCCHistoryMutationIndex, nil, /* asAlloc */
+	))
+	_, err = LoadAndReconcileReplicas(ctx, engs)
+	require.NoError(t, err)
+	logTS, err = sl.LoadRaftTruncatedState(ctx, logEng)
+	require.NoError(t, err)
+	require.Equal(t, kvserverpb.RaftTruncatedState{
+		Index: as.RaftAppliedIndex + 10,
+		Term:  as.RaftAppliedIndexTerm + 1,
+	}, logTS)
+	logHS, err = sl.LoadHardState(ctx, logEng)
+	require.NoError(t, err)
+	require.Equal(t, uint64(logTS.Index), logHS.Commit)
+	require.Equal(t, uint64(logTS.Term), logHS.Term)
+	require.Zero(t, logHS.Vote)
+	requireRaftStateEmpty(stateEng, rangeID, true)
+
+	// A log engine of another store is rejected.
+	otherLogEng := storage.NewDefaultInMemForTesting()
+	defer otherLogEng.Close()
+	otherIdent := ident
+	otherIdent.StoreID++
+	require.NoError(t, InitEngine(ctx, otherLogEng, otherIdent))
+	_, err = LoadAndReconcileReplicas(ctx, MakeSeparatedEngines(stateEng, otherLogEng))
+	require.True(t, testutils.IsError(err, "belongs to store"), "%v", err)
+}
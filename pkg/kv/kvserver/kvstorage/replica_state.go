@@ -41,13 +41,13 @@ type LoadedReplicaState struct {
 // TODO(pavelkalinnikov): integrate with stateloader.
 func LoadReplicaState(
 	ctx context.Context,
-	eng storage.Reader,
+	engs Engines,
 	storeID roachpb.StoreID,
 	desc *roachpb.RangeDescriptor,
 	replicaID roachpb.ReplicaID,
 ) (LoadedReplicaState, error) {
 	sl := stateloader.Make(desc.RangeID)
-	id, err := sl.LoadRaftReplicaID(ctx, eng)
+	id, err := sl.LoadRaftReplicaID(ctx, engs.StateEngine)
 	if err != nil {
 		return LoadedReplicaState{}, err
 	}
@@ -57,13 +57,10 @@ func LoadReplicaState(
 	}
 
 	ls := LoadedReplicaState{ReplicaID: replicaID}
-	if ls.hardState, err = sl.LoadHardState(ctx, eng); err != nil {
+	if ls.hardState, err = sl.LoadHardState(ctx, engs.LogEngine); err != nil {
 		return LoadedReplicaState{}, err
 	}
-	if ls.LastIndex, err = sl.LoadLastIndex(ctx, eng); err != nil {
-		return LoadedReplicaState{}, err
-	}
-	if ls.ReplState, err = sl.Load(ctx, eng, desc); err != nil {
+	if err := ls.load(ctx, engs, desc); err != nil {
 		return LoadedReplicaState{}, err
 	}
 
@@ -73,6 +70,30 @@ func LoadReplicaState(
 	return ls, nil
 }
 
+// load loads the last index and the ReplicaState of the replica with the given
+// descriptor.
+func (r *LoadedReplicaState) load(
+	ctx context.Context, engs Engines, desc *roachpb.RangeDescriptor,
+) error {
+	sl := stateloader.Make(desc.RangeID)
+	var err error
+	if r.LastIndex, err = sl.LoadLastIndex(ctx, engs.LogEngine); err != nil {
+		return err
+	}
+	if r.ReplState, err = sl.Load(ctx, engs.StateEngine, desc); err != nil {
+		return err
+	}
+	if engs.Separated() {
+		// The RaftTruncatedState lives in the log engine.
+		ts, err := sl.LoadRaftTruncatedState(ctx, engs.LogEngine)
+		if err != nil {
+			return err
+		}
+		r.ReplState.TruncatedState = &ts
+	}
+	return nil
+}
+
 // check makes sure that the replica invariants hold for the loaded state.
 func (r LoadedReplicaState) check(storeID roachpb.StoreID) error {
 	desc := r.ReplState.Desc
@@ -108,11 +129,12 @@ func (r LoadedReplicaState) check(storeID roachpb.StoreID) error {
 // because it has been deleted.
 func CreateUninitializedReplica(
 	ctx context.Context,
-	eng storage.Engine,
+	engs Engines,
 	storeID roachpb.StoreID,
 	rangeID roachpb.RangeID,
 	replicaID roachpb.ReplicaID,
 ) error {
+	eng := engs.StateEngine
 	// Before creating the replica, see if there is a tombstone which would
 	// indicate that this replica has been removed.
 	tombstoneKey := keys.RangeTombstoneKey(rangeID)
@@ -141,9 +163,17 @@ func CreateUninitializedReplica(
 	if err := sl.SetRaftReplicaID(ctx, eng, replicaID); err != nil {
 		return err
 	}
+	// With a separate log engine, the RaftReplicaID must be durable before the
+	// replica writes its HardState to the log engine. Otherwise, the HardState
+	// could be removed on startup, losing a vote.
+	if engs.Separated() {
+		if err := storage.WriteSyncNoop(eng); err != nil {
+			return err
+		}
+	}
 
 	// Make sure that storage invariants for this uninitialized replica hold.
 	uninitDesc := roachpb.RangeDescriptor{RangeID: rangeID}
-	_, err := LoadReplicaState(ctx, eng, storeID, &uninitDesc, replicaID)
+	_, err := LoadReplicaState(ctx, engs, storeID, &uninitDesc, replicaID)
 	return err
 }
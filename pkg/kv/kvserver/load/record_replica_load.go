@@ -52,3 +52,8 @@ func (rl *ReplicaLoad) RecordRaftCPUNanos(val float64) {
 func (rl *ReplicaLoad) RecordReqCPUNanos(val float64) {
 	rl.record(ReqCPUNanos, val, 0 /* nodeID */)
 }
+
+// RecordAppliedWriteBytes records the value given for applied write bytes.
+func (rl *ReplicaLoad) RecordAppliedWriteBytes(val float64) {
+	rl.record(AppliedWriteBytes, val, 0 /* nodeID */)
+}
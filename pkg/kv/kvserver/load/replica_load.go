@@ -36,8 +36,9 @@ const (
 	ReadBytes
 	RaftCPUNanos
 	ReqCPUNanos
+	AppliedWriteBytes
 
-	numLoadStats = 8
+	numLoadStats = 9
 )
 
 // ReplicaLoadStats contains per-second average statistics for load upon a
@@ -81,6 +82,11 @@ type ReplicaLoadStats struct {
 	// RequestCPUNanos is the replica's time spent on-processor for requests
 	// averaged per second.
 	RequestCPUNanosPerSecond float64
+	// AppliedWriteBytesPerSecond is the replica's average bytes written to
+	// storage per second when applying raft commands. Unlike
+	// WriteBytesPerSecond, which is only recorded on the leaseholder, this is
+	// recorded on every replica of the range.
+	AppliedWriteBytesPerSecond float64
 }
 
 // ReplicaLoad tracks a sliding window of throughput on a replica.
@@ -193,14 +199,15 @@ func (rl *ReplicaLoad) Stats() ReplicaLoadStats {
 	defer rl.mu.Unlock()
 
 	return ReplicaLoadStats{
-		QueriesPerSecond:         rl.getLocked(Queries),
-		RequestsPerSecond:        rl.getLocked(Requests),
-		WriteKeysPerSecond:       rl.getLocked(WriteKeys),
-		ReadKeysPerSecond:        rl.getLocked(ReadKeys),
-		WriteBytesPerSecond:      rl.getLocked(WriteBytes),
-		ReadBytesPerSecond:       rl.getLocked(ReadBytes),
-		RequestCPUNanosPerSecond: rl.getLocked(ReqCPUNanos),
-		RaftCPUNanosPerSecond:    rl.getLocked(RaftCPUNanos),
+		QueriesPerSecond:           rl.getLocked(Queries),
+		RequestsPerSecond:          rl.getLocked(Requests),
+		WriteKeysPerSecond:         rl.getLocked(WriteKeys),
+		ReadKeysPerSecond:          rl.getLocked(ReadKeys),
+		WriteBytesPerSecond:        rl.getLocked(WriteBytes),
+		ReadBytesPerSecond:         rl.getLocked(ReadBytes),
+		RequestCPUNanosPerSecond:   rl.getLocked(ReqCPUNanos),
+		RaftCPUNanosPerSecond:      rl.getLocked(RaftCPUNanos),
+		AppliedWriteBytesPerSecond: rl.getLocked(AppliedWriteBytes),
 	}
 }
 
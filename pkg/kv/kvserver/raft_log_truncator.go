@@ -228,8 +228,11 @@ type storeForTruncator interface {
 	acquireReplicaForTruncator(rangeID roachpb.RangeID) replicaForTruncator
 	// releaseReplicaForTruncator releases the replica.
 	releaseReplicaForTruncator(r replicaForTruncator)
-	// Engine accessor.
-	getEngine() storage.Engine
+	// Engine accessors. The durable applied state is read from the state
+	// engine, and the raft log is truncated in the log engine. The two may be
+	// the same engine.
+	getStateEngine() storage.Engine
+	getLogEngine() storage.Engine
 }
 
 // replicaForTruncator abstracts the interface of Replica needed by the
@@ -266,7 +269,7 @@ type replicaForTruncator interface {
 	sideloadedBytesIfTruncatedFromTo(
 		_ context.Context, from, to kvpb.RaftIndex) (freed int64, _ error)
 	getStateLoader() stateloader.StateLoader
-	// NB: Setting the persistent raft state is via the Engine exposed by
+	// NB: Setting the persistent raft state is via the log Engine exposed by
 	// storeForTruncator.
 }
 
@@ -451,7 +454,7 @@ func (t *raftLogTruncator) durabilityAdvanced(ctx context.Context) {
 	// Sort it for deterministic testing output.
 	sort.Sort(rangesByRangeID(ranges))
 	// Create an engine Reader to provide a safe lower bound on what is durable.
-	reader := t.store.getEngine().NewReader(storage.GuaranteedDurability)
+	reader := t.store.getStateEngine().NewReader(storage.GuaranteedDurability)
 	defer reader.Close()
 	shouldQuiesce := t.stopper.ShouldQuiesce()
 	quiesced := false
@@ -555,7 +558,7 @@ func (t *raftLogTruncator) tryEnactTruncations(
 	}
 	// Do the truncation of persistent raft entries, specified by enactIndex
 	// (this subsumes all the preceding queued truncations).
-	batch := t.store.getEngine().NewUnindexedBatch()
+	batch := t.store.getLogEngine().NewUnindexedBatch()
 	defer batch.Close()
 	apply, err := handleTruncatedStateBelowRaftPreApply(ctx, &truncState,
 		&pendingTruncs.mu.truncs[enactIndex].RaftTruncatedState, stateLoader, batch)
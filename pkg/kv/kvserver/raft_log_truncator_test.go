@@ -189,7 +189,7 @@ func (r *replicaTruncatorTest) writeRaftAppliedIndex(
 	t *testing.T, eng storage.Engine, raftAppliedIndex kvpb.RaftIndex, flush bool,
 ) {
 	require.NoError(t, r.stateLoader.SetRangeAppliedState(context.Background(), eng,
-		raftAppliedIndex, 0, 0, &enginepb.MVCCStats{}, hlc.Timestamp{}, nil))
+		raftAppliedIndex, 0, 0, &enginepb.MVCCStats{}, hlc.Timestamp{}, 0, nil))
 	// Flush to make it satisfy the contract of OnlyReadGuaranteedDurable in
 	// Pebble.
 	if flush {
@@ -258,7 +258,11 @@ func makeStoreTT(eng storage.Engine, buf *strings.Builder) *storeTruncatorTest {
 	}
 }
 
-func (s *storeTruncatorTest) getEngine() storage.Engine {
+func (s *storeTruncatorTest) getStateEngine() storage.Engine {
+	return s.eng
+}
+
+func (s *storeTruncatorTest) getLogEngine() storage.Engine {
 	return s.eng
 }
 
@@ -111,7 +111,7 @@ func runBenchmarkRangefeed(b *testing.B, opts benchmarkRangefeedOpts) {
 		streams[i] = &noopStream{ctx: ctx}
 		futures[i] = &future.ErrorFuture{}
 		ok, _ := p.Register(span, hlc.MinTimestamp, nil,
-			withDiff, withFiltering, streams[i], nil, futures[i])
+			withDiff, withFiltering, nil /* valueFilter */, streams[i], nil, futures[i])
 		require.True(b, ok)
 	}
 
@@ -140,7 +140,11 @@ type outputEventFn func(e *kvpb.RangeFeedEvent) error
 // TODO(sumeer): ctx is not used for SeekGE and Next. Fix by adding a method
 // to SimpleMVCCIterator to replace the context.
 func (i *CatchUpIterator) CatchUpScan(
-	ctx context.Context, outputFn outputEventFn, withDiff bool, withFiltering bool,
+	ctx context.Context,
+	outputFn outputEventFn,
+	withDiff bool,
+	withFiltering bool,
+	valueFilter *ValueFilter,
 ) error {
 	var a bufalloc.ByteAllocator
 	// MVCCIterator will encounter historical values for each key in
@@ -274,6 +278,13 @@ func (i *CatchUpIterator) CatchUpScan(
 			continue
 		}
 
+		// Skip keys of column families that the value filter excludes. None of
+		// their versions will be output, nor used as previous values.
+		if !valueFilter.matchesKey(unsafeKey.Key) {
+			i.NextKey()
+			continue
+		}
+
 		mvccVal, err := storage.DecodeMVCCValue(unsafeValRaw)
 		if err != nil {
 			return errors.Wrapf(err, "decoding mvcc value: %v", unsafeKey)
@@ -346,7 +357,9 @@ func (i *CatchUpIterator) CatchUpScan(
 				continue
 			}
 
-			if !ignore {
+			// Values which don't match the value filter are not output, but may
+			// still have been used as the previous value of the last version.
+			if !ignore && valueFilter.matchesValue(val) {
 				// Add value to reorderBuf to be output.
 				var event kvpb.RangeFeedEvent
 				event.MustSetValue(&kvpb.RangeFeedValue{
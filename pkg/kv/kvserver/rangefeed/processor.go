@@ -207,6 +207,7 @@ type Processor interface {
 		catchUpIter *CatchUpIterator,
 		withDiff bool,
 		withFiltering bool,
+		valueFilter *ValueFilter,
 		stream Stream,
 		disconnectFn func(),
 		done *future.ErrorFuture,
@@ -585,6 +586,7 @@ func (p *LegacyProcessor) Register(
 	catchUpIter *CatchUpIterator,
 	withDiff bool,
 	withFiltering bool,
+	valueFilter *ValueFilter,
 	stream Stream,
 	disconnectFn func(),
 	done *future.ErrorFuture,
@@ -596,7 +598,7 @@ func (p *LegacyProcessor) Register(
 
 	blockWhenFull := p.Config.EventChanTimeout == 0 // for testing
 	r := newRegistration(
-		span.AsRawSpanWithNoLocals(), startTS, catchUpIter, withDiff, withFiltering,
+		span.AsRawSpanWithNoLocals(), startTS, catchUpIter, withDiff, withFiltering, valueFilter,
 		p.Config.EventChanCap, blockWhenFull, p.Metrics, stream, disconnectFn, done,
 	)
 	select {
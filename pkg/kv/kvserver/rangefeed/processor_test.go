@@ -473,6 +473,7 @@ func TestProcessorBasic(t *testing.T) {
 			nil,   /* catchUpIter */
 			false, /* withDiff */
 			false, /* withFiltering */
+			nil,   /* valueFilter */
 			r1Stream,
 			func() {},
 			&r1Done,
@@ -608,6 +609,7 @@ func TestProcessorBasic(t *testing.T) {
 			nil,  /* catchUpIter */
 			true, /* withDiff */
 			true, /* withFiltering */
+			nil,  /* valueFilter */
 			r2Stream,
 			func() {},
 			&r2Done,
@@ -723,6 +725,7 @@ func TestProcessorBasic(t *testing.T) {
 			nil,   /* catchUpIter */
 			false, /* withDiff */
 			false, /* withFiltering */
+			nil,   /* valueFilter */
 			r3Stream,
 			func() {},
 			&r3Done,
@@ -747,6 +750,7 @@ func TestProcessorSlowConsumer(t *testing.T) {
 			nil,   /* catchUpIter */
 			false, /* withDiff */
 			false, /* withFiltering */
+			nil,   /* valueFilter */
 			r1Stream,
 			func() {},
 			&r1Done,
@@ -759,6 +763,7 @@ func TestProcessorSlowConsumer(t *testing.T) {
 			nil,   /* catchUpIter */
 			false, /* withDiff */
 			false, /* withFiltering */
+			nil,   /* valueFilter */
 			r2Stream,
 			func() {},
 			&r2Done,
@@ -856,6 +861,7 @@ func TestProcessorMemoryBudgetExceeded(t *testing.T) {
 			nil,   /* catchUpIter */
 			false, /* withDiff */
 			false, /* withFiltering */
+			nil,   /* valueFilter */
 			r1Stream,
 			func() {},
 			&r1Done,
@@ -912,6 +918,7 @@ func TestProcessorMemoryBudgetReleased(t *testing.T) {
 			nil,   /* catchUpIter */
 			false, /* withDiff */
 			false, /* withFiltering */
+			nil,   /* valueFilter */
 			r1Stream,
 			func() {},
 			&r1Done,
@@ -994,6 +1001,7 @@ func TestProcessorInitializeResolvedTimestamp(t *testing.T) {
 			nil,   /* catchUpIter */
 			false, /* withDiff */
 			false, /* withFiltering */
+			nil,   /* valueFilter */
 			r1Stream,
 			func() {},
 			&r1Done,
@@ -1304,7 +1312,7 @@ func TestProcessorConcurrentStop(t *testing.T) {
 				s := newTestStream()
 				var done future.ErrorFuture
 				p.Register(h.span, hlc.Timestamp{}, nil, /* catchUpIter */
-					false /* withDiff */, false /* withFiltering */, s, func() {}, &done)
+					false /* withDiff */, false /* withFiltering */, nil /* valueFilter */, s, func() {}, &done)
 			}()
 			go func() {
 				defer wg.Done()
@@ -1377,7 +1385,7 @@ func TestProcessorRegistrationObservesOnlyNewEvents(t *testing.T) {
 				regs[s] = firstIdx
 				var done future.ErrorFuture
 				p.Register(h.span, hlc.Timestamp{}, nil, /* catchUpIter */
-					false /* withDiff */, false /* withFiltering */, s, func() {}, &done)
+					false /* withDiff */, false /* withFiltering */, nil /* valueFilter */, s, func() {}, &done)
 				regDone <- struct{}{}
 			}
 		}()
@@ -1444,6 +1452,7 @@ func TestBudgetReleaseOnProcessorStop(t *testing.T) {
 			nil,   /* catchUpIter */
 			false, /* withDiff */
 			false, /* withFiltering */
+			nil,   /* valueFilter */
 			rStream,
 			func() {},
 			&done,
@@ -1526,6 +1535,7 @@ func TestBudgetReleaseOnLastStreamError(t *testing.T) {
 			nil,   /* catchUpIter */
 			false, /* withDiff */
 			false, /* withFiltering */
+			nil,   /* valueFilter */
 			rStream,
 			func() {},
 			&done,
@@ -1598,6 +1608,7 @@ func TestBudgetReleaseOnOneStreamError(t *testing.T) {
 			nil,   /* catchUpIter */
 			false, /* withDiff */
 			false, /* withFiltering */
+			nil,   /* valueFilter */
 			r1Stream,
 			func() {},
 			&r1Done,
@@ -1613,6 +1624,7 @@ func TestBudgetReleaseOnOneStreamError(t *testing.T) {
 			nil,   /* catchUpIter */
 			false, /* withDiff */
 			false, /* withFiltering */
+			nil,   /* valueFilter */
 			r2Stream,
 			func() {},
 			&r2Done,
@@ -1764,7 +1776,7 @@ func TestProcessorBackpressure(t *testing.T) {
 	stream := newTestStream()
 	done := &future.ErrorFuture{}
 	ok, _ := p.Register(span, hlc.MinTimestamp, nil, /* catchUpIter */
-		false /* withDiff */, false /* withFiltering */, stream, nil, done)
+		false /* withDiff */, false /* withFiltering */, nil /* valueFilter */, stream, nil, done)
 	require.True(t, ok)
 
 	// Wait for the initial checkpoint.
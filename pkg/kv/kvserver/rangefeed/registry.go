@@ -80,6 +80,7 @@ type registration struct {
 	catchUpTimestamp hlc.Timestamp // exclusive
 	withDiff         bool
 	withFiltering    bool
+	valueFilter      *ValueFilter
 	metrics          *Metrics
 
 	// Output.
@@ -119,6 +120,7 @@ func newRegistration(
 	catchUpIter *CatchUpIterator,
 	withDiff bool,
 	withFiltering bool,
+	valueFilter *ValueFilter,
 	bufferSz int,
 	blockWhenFull bool,
 	metrics *Metrics,
@@ -131,6 +133,7 @@ func newRegistration(
 		catchUpTimestamp: startTS,
 		withDiff:         withDiff,
 		withFiltering:    withFiltering,
+		valueFilter:      valueFilter,
 		metrics:          metrics,
 		stream:           stream,
 		done:             done,
@@ -401,7 +404,7 @@ func (r *registration) maybeRunCatchUpScan(ctx context.Context) error {
 		r.metrics.RangeFeedCatchUpScanNanos.Inc(timeutil.Since(start).Nanoseconds())
 	}()
 
-	return catchUpIter.CatchUpScan(ctx, r.stream.Send, r.withDiff, r.withFiltering)
+	return catchUpIter.CatchUpScan(ctx, r.stream.Send, r.withDiff, r.withFiltering, r.valueFilter)
 }
 
 // ID implements interval.Interface.
@@ -471,9 +474,11 @@ func (reg *registry) PublishToOverlapping(
 	// Determine the earliest starting timestamp that a registration
 	// can have while still needing to hear about this event.
 	var minTS hlc.Timestamp
+	var val *kvpb.RangeFeedValue
 	switch t := event.GetValue().(type) {
 	case *kvpb.RangeFeedValue:
 		minTS = t.Value.Timestamp
+		val = t
 	case *kvpb.RangeFeedSSTable:
 		minTS = t.WriteTS
 	case *kvpb.RangeFeedDeleteRange:
@@ -491,9 +496,11 @@ func (reg *registry) PublishToOverlapping(
 
 	reg.forOverlappingRegs(ctx, span, func(r *registration) (bool, *kvpb.Error) {
 		// Don't publish events if they:
-		// 1. are equal to or less than the registration's starting timestamp, or
-		// 2. have OmitInRangefeeds = true and this registration has opted into filtering.
-		if r.catchUpTimestamp.Less(minTS) && !(r.withFiltering && omitInRangefeeds) {
+		// 1. are equal to or less than the registration's starting timestamp,
+		// 2. have OmitInRangefeeds = true and this registration has opted into filtering, or
+		// 3. are values which don't match the registration's value filter.
+		if r.catchUpTimestamp.Less(minTS) && !(r.withFiltering && omitInRangefeeds) &&
+			(val == nil || r.valueFilter.matches(val.Key, val.Value.RawBytes)) {
 			r.publish(ctx, event, alloc)
 		}
 		return false, nil
@@ -129,6 +129,7 @@ func newTestRegistration(
 		makeCatchUpIterator(catchup, span, ts),
 		withDiff,
 		withFiltering,
+		nil, /* valueFilter */
 		5,
 		false, /* blockWhenFull */
 		NewMetrics(),
@@ -0,0 +1,159 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rangefeed
+
+import (
+	"bytes"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// ValueFilter is the compiled form of a kvpb.RangeFeedValueFilter. It is
+// evaluated on the values of a registration before they are buffered, and
+// during its catch-up scan. A nil ValueFilter matches all events.
+//
+// The filter is conservative: keys which aren't SQL row keys and values which
+// can't be decoded as a tuple of columns match it.
+type ValueFilter struct {
+	families   []uint32
+	predicates []valuePredicate
+}
+
+// valuePredicate holds if the column is equal to any of the values.
+type valuePredicate struct {
+	colID  uint32
+	values []encodedDatum
+}
+
+// encodedDatum is a value encoded datum, split into its type and its payload
+// following the value tag.
+type encodedDatum struct {
+	typ  encoding.Type
+	data []byte
+}
+
+func (d encodedDatum) equal(o encodedDatum) bool {
+	return d.typ == o.typ && bytes.Equal(d.data, o.data)
+}
+
+// NewValueFilter compiles the RangeFeedValueFilter of a rangefeed request.
+// It returns nil if the filter is unset or empty.
+func NewValueFilter(f *kvpb.RangeFeedValueFilter) (*ValueFilter, error) {
+	if f == nil || (len(f.ColumnFamilies) == 0 && len(f.Predicates) == 0) {
+		return nil, nil
+	}
+	vf := &ValueFilter{
+		families:   f.ColumnFamilies,
+		predicates: make([]valuePredicate, 0, len(f.Predicates)),
+	}
+	for _, p := range f.Predicates {
+		if p.ColumnID == 0 {
+			return nil, errors.Errorf("value filter predicate with no column ID")
+		}
+		pred := valuePredicate{colID: p.ColumnID, values: make([]encodedDatum, 0, len(p.Values))}
+		for _, v := range p.Values {
+			colIDDelta, d, rest, err := decodeTupleColumn(v)
+			if err != nil {
+				return nil, errors.Wrapf(err, "decoding value of column %d", p.ColumnID)
+			}
+			if colIDDelta != 0 || len(rest) != 0 {
+				return nil, errors.Errorf(
+					"value of column %d must be a single datum encoded without a column ID", p.ColumnID)
+			}
+			pred.values = append(pred.values, d)
+		}
+		vf.predicates = append(vf.predicates, pred)
+	}
+	return vf, nil
+}
+
+// decodeTupleColumn decodes the first value encoded datum in b, returning
+// the column ID delta of its tag, the datum, and the remainder of b.
+func decodeTupleColumn(b []byte) (colIDDelta uint32, _ encodedDatum, rest []byte, _ error) {
+	_, dataOffset, colIDDelta, typ, err := encoding.DecodeValueTag(b)
+	if err != nil {
+		return 0, encodedDatum{}, nil, err
+	}
+	n, err := encoding.PeekValueLengthWithOffsetsAndType(b, dataOffset, typ)
+	if err != nil {
+		return 0, encodedDatum{}, nil, err
+	}
+	return colIDDelta, encodedDatum{typ: typ, data: b[dataOffset:n]}, b[n:], nil
+}
+
+// matches returns whether an event with the given key and value, which is
+// empty for deletions, matches the filter.
+func (f *ValueFilter) matches(key roachpb.Key, value []byte) bool {
+	return f.matchesKey(key) && f.matchesValue(value)
+}
+
+// matchesKey returns whether the column family of the key matches the filter.
+func (f *ValueFilter) matchesKey(key roachpb.Key) bool {
+	if f == nil || len(f.families) == 0 {
+		return true
+	}
+	famID, err := keys.DecodeFamilyKey(key)
+	if err != nil {
+		// Not a row key.
+		return true
+	}
+	for _, id := range f.families {
+		if id == famID {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesValue returns whether the value satisfies the predicates of the
+// filter. Deletions, and values which aren't encoded as tuples, always match.
+func (f *ValueFilter) matchesValue(value []byte) bool {
+	if f == nil || len(f.predicates) == 0 || len(value) == 0 {
+		return true
+	}
+	v := roachpb.Value{RawBytes: value}
+	if v.GetTag() != roachpb.ValueType_TUPLE {
+		return true
+	}
+	b, err := v.GetTuple()
+	if err != nil {
+		return true
+	}
+	// Columns are encoded in increasing column ID order, and each appears at
+	// most once, so each predicate is satisfied at most once.
+	var satisfied int
+	var colID uint32
+	for len(b) > 0 {
+		colIDDelta, d, rest, err := decodeTupleColumn(b)
+		if err != nil {
+			return true
+		}
+		colID += colIDDelta
+		b = rest
+		for i := range f.predicates {
+			p := &f.predicates[i]
+			if p.colID != colID {
+				continue
+			}
+			for _, pv := range p.values {
+				if d.equal(pv) {
+					satisfied++
+					break
+				}
+			}
+		}
+	}
+	return satisfied == len(f.predicates)
+}
@@ -0,0 +1,172 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package rangefeed
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueFilter(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	rowKey := func(pk int64, famID uint32) roachpb.Key {
+		k := keys.SystemSQLCodec.IndexPrefix(104, 1)
+		k = encoding.EncodeVarintAscending(k, pk)
+		return keys.MakeFamilyKey(k, famID)
+	}
+	// tuple encodes a row value storing an INT column 2 and a STRING column 3,
+	// omitting the columns which are NULL.
+	tuple := func(b *int64, c *string) []byte {
+		var data []byte
+		var lastColID uint32
+		if b != nil {
+			data = encoding.EncodeIntValue(data, 2-lastColID, *b)
+			lastColID = 2
+		}
+		if c != nil {
+			data = encoding.EncodeBytesValue(data, 3-lastColID, []byte(*c))
+		}
+		var v roachpb.Value
+		v.SetTuple(data)
+		return v.RawBytes
+	}
+	intPtr := func(i int64) *int64 { return &i }
+	strPtr := func(s string) *string { return &s }
+	intDatum := func(i int64) []byte { return encoding.EncodeIntValue(nil, encoding.NoColumnID, i) }
+	strDatum := func(s string) []byte {
+		return encoding.EncodeBytesValue(nil, encoding.NoColumnID, []byte(s))
+	}
+	var bytesValue roachpb.Value
+	bytesValue.SetBytes([]byte("not a tuple"))
+
+	eqB := kvpb.RangeFeedValueFilter_ColumnPredicate{ColumnID: 2, Values: [][]byte{intDatum(1)}}
+	inB := kvpb.RangeFeedValueFilter_ColumnPredicate{ColumnID: 2, Values: [][]byte{intDatum(1), intDatum(3)}}
+	eqC := kvpb.RangeFeedValueFilter_ColumnPredicate{ColumnID: 3, Values: [][]byte{strDatum("x")}}
+
+	for _, tc := range []struct {
+		name   string
+		filter *kvpb.RangeFeedValueFilter
+		key    roachpb.Key
+		value  []byte
+		exp    bool
+	}{
+		{
+			name:  "no filter",
+			key:   rowKey(1, 0),
+			value: tuple(intPtr(2), nil),
+			exp:   true,
+		},
+		{
+			name:   "family matches",
+			filter: &kvpb.RangeFeedValueFilter{ColumnFamilies: []uint32{0, 2}},
+			key:    rowKey(1, 2),
+			value:  tuple(intPtr(2), nil),
+			exp:    true,
+		},
+		{
+			name:   "family doesn't match",
+			filter: &kvpb.RangeFeedValueFilter{ColumnFamilies: []uint32{0, 2}},
+			key:    rowKey(1, 1),
+			value:  tuple(intPtr(2), nil),
+			exp:    false,
+		},
+		{
+			name:   "family of deletion doesn't match",
+			filter: &kvpb.RangeFeedValueFilter{ColumnFamilies: []uint32{0}},
+			key:    rowKey(1, 1),
+			exp:    false,
+		},
+		{
+			name:   "family of non-row key",
+			filter: &kvpb.RangeFeedValueFilter{ColumnFamilies: []uint32{0}},
+			key:    roachpb.Key("a"),
+			value:  tuple(intPtr(2), nil),
+			exp:    true,
+		},
+		{
+			name:   "predicate matches",
+			filter: &kvpb.RangeFeedValueFilter{Predicates: []kvpb.RangeFeedValueFilter_ColumnPredicate{eqB}},
+			key:    rowKey(1, 0),
+			value:  tuple(intPtr(1), strPtr("y")),
+			exp:    true,
+		},
+		{
+			name:   "predicate doesn't match",
+			filter: &kvpb.RangeFeedValueFilter{Predicates: []kvpb.RangeFeedValueFilter_ColumnPredicate{eqB}},
+			key:    rowKey(1, 0),
+			value:  tuple(intPtr(2), strPtr("y")),
+			exp:    false,
+		},
+		{
+			name:   "predicate on NULL column",
+			filter: &kvpb.RangeFeedValueFilter{Predicates: []kvpb.RangeFeedValueFilter_ColumnPredicate{eqB}},
+			key:    rowKey(1, 0),
+			value:  tuple(nil, strPtr("y")),
+			exp:    false,
+		},
+		{
+			name:   "predicate with multiple values",
+			filter: &kvpb.RangeFeedValueFilter{Predicates: []kvpb.RangeFeedValueFilter_ColumnPredicate{inB}},
+			key:    rowKey(1, 0),
+			value:  tuple(intPtr(3), nil),
+			exp:    true,
+		},
+		{
+			name:   "all predicates match",
+			filter: &kvpb.RangeFeedValueFilter{Predicates: []kvpb.RangeFeedValueFilter_ColumnPredicate{eqB, eqC}},
+			key:    rowKey(1, 0),
+			value:  tuple(intPtr(1), strPtr("x")),
+			exp:    true,
+		},
+		{
+			name:   "one predicate doesn't match",
+			filter: &kvpb.RangeFeedValueFilter{Predicates: []kvpb.RangeFeedValueFilter_ColumnPredicate{eqB, eqC}},
+			key:    rowKey(1, 0),
+			value:  tuple(intPtr(1), strPtr("y")),
+			exp:    false,
+		},
+		{
+			name:   "predicate on deletion",
+			filter: &kvpb.RangeFeedValueFilter{Predicates: []kvpb.RangeFeedValueFilter_ColumnPredicate{eqB}},
+			key:    rowKey(1, 0),
+			exp:    true,
+		},
+		{
+			name:   "predicate on non-tuple value",
+			filter: &kvpb.RangeFeedValueFilter{Predicates: []kvpb.RangeFeedValueFilter_ColumnPredicate{eqB}},
+			key:    rowKey(1, 1),
+			value:  bytesValue.RawBytes,
+			exp:    true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := NewValueFilter(tc.filter)
+			require.NoError(t, err)
+			require.Equal(t, tc.exp, f.matches(tc.key, tc.value))
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewValueFilter(&kvpb.RangeFeedValueFilter{
+			Predicates: []kvpb.RangeFeedValueFilter_ColumnPredicate{
+				{ColumnID: 2, Values: [][]byte{encoding.EncodeIntValue(nil, 2, 1)}},
+			},
+		})
+		require.Error(t, err)
+	})
+}
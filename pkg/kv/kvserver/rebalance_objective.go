@@ -27,12 +27,14 @@ import (
 // LBRebalancingObjective controls the objective of load based rebalancing.
 // This is used to both (1) define the types of load considered when
 // determining how balanced the cluster is, and (2) select actions that improve
-// balancing the given objective. Currently there are only two possible
+// balancing the given objective. Currently there are three possible
 // objectives:
 //   - qps which is the original default setting and looks at the number of batch
 //     requests on a range and store.
 //   - cpu which is added in 23.1 and looks at the cpu usage of a range and
 //     store.
+//   - write_bytes which is added in 24.1 and looks at the bytes written to
+//     storage by a range and store.
 type LBRebalancingObjective int64
 
 const (
@@ -90,13 +92,36 @@ const (
 	// process cpu approach. The sum of impact over available actions is equal
 	// to the store value being balanced, similar to LBRebalancingQueries.
 	LBRebalancingCPU
+
+	// LBRebalancingWriteBytes is a rebalance objective that aims to balance the
+	// bytes written to storage per second among stores. The store write bytes
+	// is calculated as the sum of the applied write bytes of the replicas on
+	// the store which hold the lease for their range. Every replica of a range
+	// writes the same bytes to its store's disk when applying raft commands,
+	// however like QPS, the write bytes are attributed only to the leaseholder.
+	// This is because the store rebalancer only acts on ranges it holds the
+	// lease for: were follower write bytes counted, a store could be overfull
+	// due to ranges it cannot move. Follower write bytes are instead spread by
+	// balancing replica counts. The write bytes value per-replica is
+	// calculated as the average bytes written per second over the last 30
+	// minutes, or replica lifetime, whichever is shorter.
+	//
+	// When searching for rebalance actions, this objective considers both
+	// lease and replica rebalancing, as the write bytes move with the lease.
+	// Stores with excessive L0 sublevels are already excluded as rebalance
+	// targets by the allocator, see kv.allocator.io_overload_threshold.
+	//
+	// This rebalancing objective tends to work well for write heavy workloads,
+	// where disk bandwidth rather than cpu is the bottleneck.
+	LBRebalancingWriteBytes
 )
 
 // LoadBasedRebalancingObjectiveMap maps the LoadBasedRebalancingObjective enum
 // value to a string.
 var LoadBasedRebalancingObjectiveMap map[int64]string = map[int64]string{
-	int64(LBRebalancingQueries): "qps",
-	int64(LBRebalancingCPU):     "cpu",
+	int64(LBRebalancingQueries):    "qps",
+	int64(LBRebalancingCPU):        "cpu",
+	int64(LBRebalancingWriteBytes): "write_bytes",
 }
 
 func (lbro LBRebalancingObjective) String() string {
@@ -110,7 +135,9 @@ var LoadBasedRebalancingObjective = settings.RegisterEnumSetting(
 	"kv.allocator.load_based_rebalancing.objective",
 	"what objective does the cluster use to rebalance; if set to `qps` "+
 		"the cluster will attempt to balance qps among stores, if set to "+
-		"`cpu` the cluster will attempt to balance cpu usage among stores",
+		"`cpu` the cluster will attempt to balance cpu usage among stores, if set "+
+		"to `write_bytes` the cluster will attempt to balance the bytes written "+
+		"per second among stores",
 	"cpu",
 	LoadBasedRebalancingObjectiveMap,
 	settings.WithPublic)
@@ -130,6 +157,8 @@ func (d LBRebalancingObjective) ToDimension() load.Dimension {
 		return load.Queries
 	case LBRebalancingCPU:
 		return load.CPU
+	case LBRebalancingWriteBytes:
+		return load.WriteBytes
 	default:
 		panic("unknown dimension")
 	}
@@ -266,6 +295,15 @@ func ResolveLBRebalancingObjective(
 	if set == int64(LBRebalancingQueries) {
 		return LBRebalancingQueries
 	}
+	if set == int64(LBRebalancingWriteBytes) {
+		// Stores only begin gossiping their write bytes once the cluster
+		// version is active, before then fall back to balancing cpu.
+		if st.Version.IsActive(ctx, clusterversion.V24_1_GossipStoreWriteBytes) {
+			return LBRebalancingWriteBytes
+		}
+		log.Infof(ctx, "write bytes balance objective unsupported by the cluster version, reverting to cpu balance objective")
+		set = int64(LBRebalancingCPU)
+	}
 	// When the cpu timekeeping utility is unsupported on this aarch, the cpu
 	// usage cannot be gathered. Fall back to QPS balancing.
 	if !grunning.Supported() {
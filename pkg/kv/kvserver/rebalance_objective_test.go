@@ -14,6 +14,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/allocator/storepool"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
@@ -120,6 +121,12 @@ func TestLoadBasedRebalancingObjective(t *testing.T) {
 			ResolveLBRebalancingObjective(ctx, st, gossipStoreDescProvider.GetStores()),
 		)
 
+		LoadBasedRebalancingObjective.Override(ctx, &st.SV, int64(LBRebalancingWriteBytes))
+		require.Equal(t,
+			LBRebalancingWriteBytes,
+			ResolveLBRebalancingObjective(ctx, st, gossipStoreDescProvider.GetStores()),
+		)
+
 		LoadBasedRebalancingObjective.Override(ctx, &st.SV, int64(LBRebalancingQueries))
 		require.Equal(t,
 			LBRebalancingQueries,
@@ -127,6 +134,20 @@ func TestLoadBasedRebalancingObjective(t *testing.T) {
 		)
 	})
 
+	t.Run("write bytes reverts to cpu before the cluster version", func(t *testing.T) {
+		st := cluster.MakeTestingClusterSettingsWithVersions(
+			(clusterversion.V24_1_GossipStoreWriteBytes - 1).Version(),
+			clusterversion.MinSupported.Version(),
+			true, /* initializeVersion */
+		)
+		gossipStoreDescProvider := testMakeProviderNotifier(allPositiveCPUMap)
+		LoadBasedRebalancingObjective.Override(ctx, &st.SV, int64(LBRebalancingWriteBytes))
+		require.Equal(t,
+			LBRebalancingCPU,
+			ResolveLBRebalancingObjective(ctx, st, gossipStoreDescProvider.GetStores()),
+		)
+	})
+
 	t.Run("remote node set cpu to -1, signalling no support", func(t *testing.T) {
 		st := cluster.MakeTestingClusterSettings()
 		// The store with StoreID 3 has a -1 CPUPerSecond value, this indicates
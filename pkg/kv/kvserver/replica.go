@@ -1700,6 +1700,14 @@ func (r *Replica) assertStateRaftMuLockedReplicaMuRLocked(
 	if err != nil {
 		log.Fatalf(ctx, "%v", err)
 	}
+	// With a separate log engine, the reader doesn't contain the raft state.
+	if r.store.raftLogSeparated() {
+		truncState, err := r.mu.stateLoader.LoadRaftTruncatedState(ctx, r.store.LogEngine())
+		if err != nil {
+			log.Fatalf(ctx, "%v", err)
+		}
+		diskState.TruncatedState = &truncState
+	}
 
 	// We don't care about this field; see comment on
 	// DeprecatedUsingAppliedStateKey for more details. This can be removed once
@@ -2488,15 +2496,16 @@ func (r *Replica) RangeUsageInfo() allocator.RangeUsageInfo {
 	loadStats := r.LoadStats()
 	localityInfo := r.loadStats.RequestLocalityInfo()
 	return allocator.RangeUsageInfo{
-		LogicalBytes:             r.GetMVCCStats().Total(),
-		QueriesPerSecond:         loadStats.QueriesPerSecond,
-		WritesPerSecond:          loadStats.WriteKeysPerSecond,
-		ReadsPerSecond:           loadStats.ReadKeysPerSecond,
-		WriteBytesPerSecond:      loadStats.WriteBytesPerSecond,
-		ReadBytesPerSecond:       loadStats.ReadBytesPerSecond,
-		RaftCPUNanosPerSecond:    loadStats.RaftCPUNanosPerSecond,
-		RequestCPUNanosPerSecond: loadStats.RequestCPUNanosPerSecond,
-		RequestsPerSecond:        loadStats.RequestsPerSecond,
+		LogicalBytes:               r.GetMVCCStats().Total(),
+		QueriesPerSecond:           loadStats.QueriesPerSecond,
+		WritesPerSecond:            loadStats.WriteKeysPerSecond,
+		ReadsPerSecond:             loadStats.ReadKeysPerSecond,
+		WriteBytesPerSecond:        loadStats.WriteBytesPerSecond,
+		ReadBytesPerSecond:         loadStats.ReadBytesPerSecond,
+		RaftCPUNanosPerSecond:      loadStats.RaftCPUNanosPerSecond,
+		RequestCPUNanosPerSecond:   loadStats.RequestCPUNanosPerSecond,
+		RequestsPerSecond:          loadStats.RequestsPerSecond,
+		AppliedWriteBytesPerSecond: loadStats.AppliedWriteBytesPerSecond,
 		RequestLocality: &allocator.RangeRequestLocalityInfo{
 			Counts:   localityInfo.LocalityCounts,
 			Duration: localityInfo.Duration,
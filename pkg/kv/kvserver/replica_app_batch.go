@@ -14,6 +14,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/apply"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvadmission"
@@ -44,6 +45,13 @@ type replicaAppBatch struct {
 
 	// batch accumulates writes implied by the raft entries in this batch.
 	batch storage.Batch
+	// logBatch accumulates writes to the raft state in the separate log engine
+	// implied by the raft entries in this batch, i.e. the raft state of the RHS
+	// of a split, or the removal of the raft state of a merged or removed
+	// replica. It is committed after the batch is durably committed, and is nil
+	// if the store doesn't have a separate log engine or there are no such
+	// writes.
+	logBatch storage.Batch
 	// state is this batch's view of the replica's state. It is copied from
 	// under the Replica.mu when the batch is initialized and is updated in
 	// stageTrivialReplicatedEvalResult.
@@ -305,7 +313,11 @@ func (b *replicaAppBatch) runPostAddTriggersReplicaOnly(
 		//
 		// Alternatively if we discover that the RHS has already been removed
 		// from this store, clean up its data.
-		splitPreApply(ctx, b.r, b.batch, res.Split.SplitTrigger, cmd.Cmd.ClosedTimestamp)
+		var logReadWriter storage.ReadWriter
+		if b.r.store.raftLogSeparated() {
+			logReadWriter = b.raftLogBatch()
+		}
+		splitPreApply(ctx, b.r, b.batch, logReadWriter, res.Split.SplitTrigger, cmd.Cmd.ClosedTimestamp)
 
 		// The rangefeed processor will no longer be provided logical ops for
 		// its entire range, so it needs to be shut down and all registrations
@@ -353,6 +365,13 @@ func (b *replicaAppBatch) runPostAddTriggersReplicaOnly(
 		}); err != nil {
 			return errors.Wrapf(err, "unable to destroy replica before merge")
 		}
+		if b.r.store.raftLogSeparated() {
+			if err := kvstorage.ClearRaftLogEngineState(
+				ctx, rhsRepl.RangeID, b.r.store.LogEngine(), b.raftLogBatch(),
+			); err != nil {
+				return errors.Wrapf(err, "unable to destroy replica raft state before merge")
+			}
+		}
 
 		// Shut down rangefeed processors on either side of the merge.
 		//
@@ -407,7 +426,13 @@ func (b *replicaAppBatch) runPostAddTriggersReplicaOnly(
 		// only for deciding how to truncate the raft log, which is not part of
 		// the state machine. Also, we will eventually eliminate this check by
 		// only supporting loosely coupled truncation.
-		looselyCoupledTruncation := isLooselyCoupledRaftLogTruncationEnabled(ctx, b.r.ClusterSettings())
+		//
+		// A separate raft log engine can only be truncated once the state engine
+		// durably applied the truncation, so truncations are always loosely
+		// coupled in that case.
+		separated := b.r.store.raftLogSeparated()
+		looselyCoupledTruncation := separated ||
+			isLooselyCoupledRaftLogTruncationEnabled(ctx, b.r.ClusterSettings())
 		// In addition to cluster version and cluster settings, we also apply
 		// immediately if RaftExpectedFirstIndex is not populated (see comment in
 		// that proto).
@@ -419,7 +444,7 @@ func (b *replicaAppBatch) runPostAddTriggersReplicaOnly(
 		// it, the loosely coupled code will mark the log size as untrusted and
 		// will recompute the size. This has no correctness impact, so we are not
 		// going to bother with a long-running migration.
-		apply := !looselyCoupledTruncation || res.RaftExpectedFirstIndex == 0
+		apply := !looselyCoupledTruncation || (res.RaftExpectedFirstIndex == 0 && !separated)
 		if apply {
 			if apply, err = handleTruncatedStateBelowRaftPreApply(
 				ctx, b.state.TruncatedState, res.State.TruncatedState, b.r.raftMu.stateLoader, b.batch,
@@ -513,6 +538,13 @@ func (b *replicaAppBatch) runPostAddTriggersReplicaOnly(
 		}); err != nil {
 			return errors.Wrapf(err, "unable to destroy replica before removal")
 		}
+		if b.r.store.raftLogSeparated() {
+			if err := kvstorage.ClearRaftLogEngineState(
+				ctx, b.r.RangeID, b.r.store.LogEngine(), b.raftLogBatch(),
+			); err != nil {
+				return errors.Wrapf(err, "unable to destroy replica raft state before removal")
+			}
+		}
 	}
 
 	// Provide the command's corresponding logical operations to the Replica's
@@ -555,6 +587,21 @@ func (b *replicaAppBatch) stageTrivialReplicatedEvalResult(
 
 	res := cmd.ReplicatedResult()
 
+	// Record the index of commands which may have removed or written versions
+	// below the closed timestamp, so that delta snapshots aren't sent across
+	// them. Replicas only start recording them with the below-raft migration to
+	// V24_1_MVCCHistoryMutationIndex, which is recorded itself, to keep the
+	// applied state identical to that of replicas on older binaries until then.
+	if res.RewritesMVCCHistory || res.MVCCHistoryMutation != nil {
+		version := b.state.Version
+		if res.State != nil && res.State.Version != nil {
+			version = res.State.Version
+		}
+		if version != nil && version.AtLeast(clusterversion.V24_1_MVCCHistoryMutationIndex.Version()) {
+			b.state.MVCCHistoryMutationIndex = cmd.Index()
+		}
+	}
+
 	// Special-cased MVCC stats handling to exploit commutativity of stats delta
 	// upgrades. Thanks to commutativity, the spanlatch manager does not have to
 	// serialize on the stats key.
@@ -600,18 +647,24 @@ func (b *replicaAppBatch) ApplyToStateMachine(ctx context.Context) error {
 	// cluster setting is the default, we will no longer need to sync here upon
 	// log truncations. The sync will happen by other means with a lag.
 	//
-	// TODO(sep-raft-log): when the log and state machine engines are completely
-	// separated, we must either sync here unconditionally upon log truncation
-	// (which would be expensive), or apply the side effects (remove the entries)
-	// asynchronously when sure that the state machine engine has synced the
-	// application of this command. I.e. the loosely coupled truncation migration
-	// mentioned above likely needs to be done first.
-	sync := b.changeRemovesReplica || b.changeTruncatesSideloadedFiles
+	// When the log engine is separate, log truncations are always loosely
+	// coupled and don't need a sync here. However, we sync the batch if the
+	// command also writes to the log engine (splits, merges, and replica
+	// removals), since the log engine write must not become durable before the
+	// batch does. See kvstorage.reconcileRaftState.
+	sync := b.changeRemovesReplica || b.changeTruncatesSideloadedFiles || b.logBatch != nil
 	if err := b.batch.Commit(sync); err != nil {
 		return errors.Wrapf(err, "unable to commit Raft entry batch")
 	}
 	b.batch.Close()
 	b.batch = nil
+	if b.logBatch != nil {
+		if err := b.logBatch.Commit(true /* sync */); err != nil {
+			return errors.Wrapf(err, "unable to commit raft log engine batch")
+		}
+		b.logBatch.Close()
+		b.logBatch = nil
+	}
 
 	// Update the replica's applied indexes, mvcc stats and closed timestamp.
 	r := b.r
@@ -619,6 +672,7 @@ func (b *replicaAppBatch) ApplyToStateMachine(ctx context.Context) error {
 	r.mu.state.RaftAppliedIndex = b.state.RaftAppliedIndex
 	r.mu.state.RaftAppliedIndexTerm = b.state.RaftAppliedIndexTerm
 	r.mu.state.LeaseAppliedIndex = b.state.LeaseAppliedIndex
+	r.mu.state.MVCCHistoryMutationIndex = b.state.MVCCHistoryMutationIndex
 
 	// Sanity check that the RaftClosedTimestamp doesn't go backwards.
 	existingClosed := r.mu.state.RaftClosedTimestamp
@@ -657,6 +711,8 @@ func (b *replicaAppBatch) ApplyToStateMachine(ctx context.Context) error {
 
 	// Record the number of keys written to the replica.
 	b.r.loadStats.RecordWriteKeys(float64(b.ab.numMutations))
+	// Record the number of bytes written to the replica.
+	b.r.loadStats.RecordAppliedWriteBytes(float64(b.ab.numMutationBytes))
 
 	now := timeutil.Now()
 	if needsSplitBySize && r.splitQueueThrottle.ShouldProcess(now) {
@@ -682,7 +738,7 @@ func (b *replicaAppBatch) addAppliedStateKeyToBatch(ctx context.Context) error {
 	loader := &b.r.raftMu.stateLoader
 	return loader.SetRangeAppliedState(
 		ctx, b.batch, b.state.RaftAppliedIndex, b.state.LeaseAppliedIndex, b.state.RaftAppliedIndexTerm,
-		b.state.Stats, b.state.RaftClosedTimestamp, &b.asAlloc,
+		b.state.Stats, b.state.RaftClosedTimestamp, b.state.MVCCHistoryMutationIndex, &b.asAlloc,
 	)
 }
 
@@ -707,9 +763,21 @@ func (b *replicaAppBatch) Close() {
 	if b.batch != nil {
 		b.batch.Close()
 	}
+	if b.logBatch != nil {
+		b.logBatch.Close()
+	}
 	*b = replicaAppBatch{}
 }
 
+// raftLogBatch returns the batch of writes to the separate log engine, creating
+// it if necessary. Must only be used if the store has a separate log engine.
+func (b *replicaAppBatch) raftLogBatch() storage.Batch {
+	if b.logBatch == nil {
+		b.logBatch = b.r.store.LogEngine().NewBatch()
+	}
+	return b.logBatch
+}
+
 // Assert that the current command is not writing under the closed timestamp.
 // This check only applies to certain write commands, mainly IsIntentWrite,
 // since others (for example, EndTxn) can operate below the closed timestamp.
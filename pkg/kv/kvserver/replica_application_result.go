@@ -63,6 +63,7 @@ func clearTrivialReplicatedEvalResultFields(r *kvserverpb.ReplicatedEvalResult)
 	r.Delta = enginepb.MVCCStatsDelta{}
 	// Rangefeeds have been disconnected prior to application.
 	r.MVCCHistoryMutation = nil
+	r.RewritesMVCCHistory = false
 }
 
 // prepareLocalResult is performed after the command has been committed to the
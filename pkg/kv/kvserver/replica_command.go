@@ -3292,6 +3292,12 @@ func (r *Replica) followerSendSnapshot(
 		externalReplicate = external > (total - external)
 	}
 
+	// Offer to send the snapshot as a delta if it is neither using shared nor
+	// external replication. The recipient decides whether it can accept one,
+	// see kvserverpb.SnapshotDeltaBase.
+	deltaReplicate := !sharedReplicate && !externalReplicate && nonSystemRange &&
+		snapshotDeltaEnabled.Get(&r.store.ClusterSettings().SV)
+
 	// Create new snapshot request header using the delegate snapshot request.
 	header := kvserverpb.SnapshotRequest_Header{
 		State: snap.State,
@@ -3312,6 +3318,7 @@ func (r *Replica) followerSendSnapshot(
 		SenderQueuePriority: req.SenderQueuePriority,
 		SharedReplicate:     sharedReplicate,
 		ExternalReplicate:   externalReplicate,
+		DeltaReplicate:      deltaReplicate,
 	}
 	newBatchFn := func() storage.WriteBatch {
 		return r.store.TODOEngine().NewWriteBatch()
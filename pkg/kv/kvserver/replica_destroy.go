@@ -130,7 +130,6 @@ func (r *Replica) destroyRaftMuLocked(ctx context.Context, nextReplicaID roachpb
 		ClearReplicatedByRangeID:   inited,
 		ClearUnreplicatedByRangeID: true,
 	}
-	// TODO(sep-raft-log): need both engines separately here.
 	if err := kvstorage.DestroyReplica(ctx, r.RangeID, r.store.TODOEngine(), batch, nextReplicaID, opts); err != nil {
 		return err
 	}
@@ -144,6 +143,20 @@ func (r *Replica) destroyRaftMuLocked(ctx context.Context, nextReplicaID roachpb
 	if err := batch.Commit(true); err != nil {
 		return err
 	}
+	// With a separate log engine, remove the raft state only now that the
+	// tombstone is durable, see kvstorage.reconcileRaftState.
+	if r.store.raftLogSeparated() {
+		logBatch := r.store.LogEngine().NewWriteBatch()
+		defer logBatch.Close()
+		if err := kvstorage.ClearRaftLogEngineState(
+			ctx, r.RangeID, r.store.LogEngine(), logBatch,
+		); err != nil {
+			return err
+		}
+		if err := logBatch.Commit(true /* sync */); err != nil {
+			return err
+		}
+	}
 	commitTime := timeutil.Now()
 
 	if err := r.postDestroyRaftMuLocked(ctx, ms); err != nil {
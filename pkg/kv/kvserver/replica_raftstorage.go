@@ -80,7 +80,7 @@ var _ raft.Storage = (*replicaRaftStorage)(nil)
 // exclusive access to r.mu.stateLoader.
 func (r *replicaRaftStorage) InitialState() (raftpb.HardState, raftpb.ConfState, error) {
 	ctx := r.AnnotateCtx(context.TODO())
-	hs, err := r.mu.stateLoader.LoadHardState(ctx, r.store.TODOEngine())
+	hs, err := r.mu.stateLoader.LoadHardState(ctx, r.store.LogEngine())
 	// For uninitialized ranges, membership is unknown at this point.
 	if raft.IsEmptyHardState(hs) || err != nil {
 		if err != nil {
@@ -114,7 +114,7 @@ func (r *replicaRaftStorage) TypedEntries(
 	if r.raftMu.sideloaded == nil {
 		return nil, errors.New("sideloaded storage is uninitialized")
 	}
-	ents, _, loadedSize, err := logstore.LoadEntries(ctx, r.mu.stateLoader.StateLoader, r.store.TODOEngine(), r.RangeID,
+	ents, _, loadedSize, err := logstore.LoadEntries(ctx, r.mu.stateLoader.StateLoader, r.store.LogEngine(), r.RangeID,
 		r.store.raftEntryCache, r.raftMu.sideloaded, lo, hi, maxBytes, &r.raftMu.bytesAccount)
 	r.store.metrics.RaftStorageReadBytes.Inc(int64(loadedSize))
 	return ents, err
@@ -150,7 +150,7 @@ func (r *replicaRaftStorage) TypedTerm(i kvpb.RaftIndex) (kvpb.RaftTerm, error)
 		return r.mu.lastTermNotDurable, nil
 	}
 	ctx := r.AnnotateCtx(context.TODO())
-	return logstore.LoadTerm(ctx, r.mu.stateLoader.StateLoader, r.store.TODOEngine(), r.RangeID,
+	return logstore.LoadTerm(ctx, r.mu.stateLoader.StateLoader, r.store.LogEngine(), r.RangeID,
 		r.store.raftEntryCache, i)
 }
 
@@ -220,6 +220,15 @@ func (r *Replica) GetFirstIndex() kvpb.RaftIndex {
 	return r.raftFirstIndexRLocked()
 }
 
+// GetSeparatedRaftLogReader returns the log engine if the store keeps the raft
+// log in a separate engine, or nil otherwise.
+func (r *Replica) GetSeparatedRaftLogReader() storage.Reader {
+	if !r.store.raftLogSeparated() {
+		return nil
+	}
+	return r.store.LogEngine()
+}
+
 // GetLeaseAppliedIndex returns the lease index of the last applied command.
 func (r *Replica) GetLeaseAppliedIndex() kvpb.LeaseAppliedIndex {
 	r.mu.RLock()
@@ -393,6 +402,9 @@ type IncomingSnapshot struct {
 	// cleared by doing the Ingest*. This is tracked so that we can convert the
 	// ssts into a WriteBatch if the total size of the ssts is small.
 	clearedSpans []roachpb.Span
+	// deltaBase is set if the snapshot is a delta against the state of the
+	// existing replica. See kvserverpb.SnapshotDeltaBase.
+	deltaBase *kvserverpb.SnapshotDeltaBase
 }
 
 func (s IncomingSnapshot) String() string {
@@ -548,6 +560,9 @@ func (r *Replica) applySnapshot(
 			if err != nil {
 				log.Fatalf(ctx, "could not fetch replica descriptor for range after applying snapshot: %v", err)
 			}
+			if inSnap.deltaBase != nil {
+				r.store.metrics.RangeSnapshotsAppliedAsDelta.Inc(1)
+			}
 			if isInitialSnap {
 				r.store.metrics.RangeSnapshotsAppliedForInitialUpreplication.Inc(1)
 			} else {
@@ -598,6 +613,9 @@ func (r *Replica) applySnapshot(
 		if inSnap.doExcise {
 			logDetails.Printf(" excise=true")
 		}
+		if inSnap.deltaBase != nil {
+			logDetails.Printf(" delta=true")
+		}
 		logDetails.Printf(" ingestion=%d@%0.0fms", len(inSnap.SSTStorageScratch.SSTs()),
 			stats.ingestion.Sub(stats.subsumedReplicas).Seconds()*1000)
 		var appliedAsWriteStr string
@@ -608,8 +626,10 @@ func (r *Replica) applySnapshot(
 	}(timeutil.Now())
 
 	clearedSpans := inSnap.clearedSpans
+	raftLogSeparated := r.store.raftLogSeparated()
 	unreplicatedSSTFile, clearedSpan, err := writeUnreplicatedSST(
 		ctx, r.ID(), r.ClusterSettings(), nonemptySnap.Metadata, hs, &r.raftMu.stateLoader.StateLoader,
+		!raftLogSeparated, /* withRaftState */
 	)
 	if err != nil {
 		return err
@@ -651,7 +671,8 @@ func (r *Replica) applySnapshot(
 	// of the removed range. In this case, however, it's copacetic, as subsumed
 	// ranges _can't_ have new replicas.
 	clearedSubsumedSpans, err := clearSubsumedReplicaDiskData(
-		// TODO(sep-raft-log): needs access to both engines.
+		// NB: with a separate log engine, the raft state of the subsumed replicas
+		// is cleared after ingestion, see writeSnapshotRaftStateToLogEngine.
 		ctx, r.store.ClusterSettings(), r.store.TODOEngine(), inSnap.SSTStorageScratch.WriteSST,
 		desc, subsumedDescs, mergedTombstoneReplicaID,
 	)
@@ -686,7 +707,10 @@ func (r *Replica) applySnapshot(
 				inSnap.SSTStorageScratch.SSTs(), exciseSpan.Key, exciseSpan.EndKey)
 		}
 	} else {
-		if inSnap.SSTSize > snapshotIngestAsWriteThreshold.Get(&r.ClusterSettings().SV) {
+		// Delta snapshots clear individual user keys with range deletions in
+		// their SSTs, which are not carried over when applying as a batch.
+		if inSnap.SSTSize > snapshotIngestAsWriteThreshold.Get(&r.ClusterSettings().SV) ||
+			inSnap.deltaBase != nil {
 			if ingestStats, err =
 				r.store.TODOEngine().IngestLocalFilesWithStats(ctx, inSnap.SSTStorageScratch.SSTs()); err != nil {
 				return errors.Wrapf(err, "while ingesting %s", inSnap.SSTStorageScratch.SSTs())
@@ -717,10 +741,25 @@ func (r *Replica) applySnapshot(
 	// has not yet been updated. Any errors past this point must therefore be
 	// treated as fatal.
 
+	if raftLogSeparated {
+		if err := r.writeSnapshotRaftStateToLogEngine(
+			ctx, appliedAsWrite, nonemptySnap.Metadata, hs, subsumedDescs,
+		); err != nil {
+			log.Fatalf(ctx, "unable to write raft state to raft log engine: %s", err)
+		}
+	}
+
 	state, err := stateloader.Make(desc.RangeID).Load(ctx, r.store.TODOEngine(), desc)
 	if err != nil {
 		log.Fatalf(ctx, "unable to load replica state: %s", err)
 	}
+	if raftLogSeparated {
+		truncState, err := r.raftMu.stateLoader.LoadRaftTruncatedState(ctx, r.store.LogEngine())
+		if err != nil {
+			log.Fatalf(ctx, "unable to load truncated state: %s", err)
+		}
+		state.TruncatedState = &truncState
+	}
 
 	if uint64(state.RaftAppliedIndex) != nonemptySnap.Metadata.Index {
 		log.Fatalf(ctx, "snapshot RaftAppliedIndex %d doesn't match its metadata index %d",
@@ -872,6 +911,7 @@ func writeUnreplicatedSST(
 	meta raftpb.SnapshotMetadata,
 	hs raftpb.HardState,
 	sl *logstore.StateLoader,
+	withRaftState bool,
 ) (_ *storage.MemObject, clearedSpan roachpb.Span, _ error) {
 	unreplicatedSSTFile := &storage.MemObject{}
 	unreplicatedSST := storage.MakeIngestionSSTWriter(
@@ -894,8 +934,10 @@ func writeUnreplicatedSST(
 	}
 
 	// Update HardState.
-	if err := sl.SetHardState(ctx, &unreplicatedSST, hs); err != nil {
-		return nil, roachpb.Span{}, errors.Wrapf(err, "unable to write HardState to unreplicated SST writer")
+	if withRaftState {
+		if err := sl.SetHardState(ctx, &unreplicatedSST, hs); err != nil {
+			return nil, roachpb.Span{}, errors.Wrapf(err, "unable to write HardState to unreplicated SST writer")
+		}
 	}
 	// We've cleared all the raft state above, so we are forced to write the
 	// RaftReplicaID again here.
@@ -904,14 +946,16 @@ func writeUnreplicatedSST(
 		return nil, roachpb.Span{}, errors.Wrapf(err, "unable to write RaftReplicaID to unreplicated SST writer")
 	}
 
-	if err := sl.SetRaftTruncatedState(
-		ctx, &unreplicatedSST,
-		&kvserverpb.RaftTruncatedState{
-			Index: kvpb.RaftIndex(meta.Index),
-			Term:  kvpb.RaftTerm(meta.Term),
-		},
-	); err != nil {
-		return nil, roachpb.Span{}, errors.Wrapf(err, "unable to write TruncatedState to unreplicated SST writer")
+	if withRaftState {
+		if err := sl.SetRaftTruncatedState(
+			ctx, &unreplicatedSST,
+			&kvserverpb.RaftTruncatedState{
+				Index: kvpb.RaftIndex(meta.Index),
+				Term:  kvpb.RaftTerm(meta.Term),
+			},
+		); err != nil {
+			return nil, roachpb.Span{}, errors.Wrapf(err, "unable to write TruncatedState to unreplicated SST writer")
+		}
 	}
 
 	if err := unreplicatedSST.Finish(); err != nil {
@@ -920,6 +964,48 @@ func writeUnreplicatedSST(
 	return unreplicatedSSTFile, clearedSpan, nil
 }
 
+// writeSnapshotRaftStateToLogEngine replaces the raft state of the replica in the
+// separate log engine with the HardState and the RaftTruncatedState at the
+// applied snapshot, and clears the raft state of the subsumed replicas. The
+// snapshot must already be applied to the state engine, which is made durable
+// first, see kvstorage.reconcileRaftState.
+func (r *Replica) writeSnapshotRaftStateToLogEngine(
+	ctx context.Context,
+	appliedAsWrite bool,
+	meta raftpb.SnapshotMetadata,
+	hs raftpb.HardState,
+	subsumedDescs []*roachpb.RangeDescriptor,
+) error {
+	// Ingested SSTs are durable, but a snapshot applied as a write is not.
+	if appliedAsWrite {
+		if err := storage.WriteSyncNoop(r.store.TODOEngine()); err != nil {
+			return err
+		}
+	}
+	logEng := r.store.LogEngine()
+	batch := logEng.NewWriteBatch()
+	defer batch.Close()
+	if err := kvstorage.ClearRaftLogEngineState(ctx, r.RangeID, logEng, batch); err != nil {
+		return err
+	}
+	for _, sd := range subsumedDescs {
+		if err := kvstorage.ClearRaftLogEngineState(ctx, sd.RangeID, logEng, batch); err != nil {
+			return err
+		}
+	}
+	sl := r.raftMu.stateLoader
+	if err := sl.SetHardState(ctx, batch, hs); err != nil {
+		return err
+	}
+	if err := sl.SetRaftTruncatedState(ctx, batch, &kvserverpb.RaftTruncatedState{
+		Index: kvpb.RaftIndex(meta.Index),
+		Term:  kvpb.RaftTerm(meta.Term),
+	}); err != nil {
+		return err
+	}
+	return batch.Commit(true /* sync */)
+}
+
 // clearSubsumedReplicaDiskData clears the on disk data of the subsumed
 // replicas by creating SSTs with range deletion tombstones. We have to be
 // careful here not to have overlapping ranges with the SSTs we have already
@@ -267,6 +267,11 @@ func (r *Replica) RangeFeed(
 		return future.MakeCompletedErrorFuture(err)
 	}
 
+	valueFilter, err := rangefeed.NewValueFilter(args.ValueFilter)
+	if err != nil {
+		return future.MakeCompletedErrorFuture(err)
+	}
+
 	if err := r.ensureClosedTimestampStarted(ctx); err != nil {
 		return future.MakeCompletedErrorFuture(err.GoError())
 	}
@@ -343,7 +348,8 @@ func (r *Replica) RangeFeed(
 	}
 	var done future.ErrorFuture
 	p := r.registerWithRangefeedRaftMuLocked(
-		ctx, rSpan, args.Timestamp, catchUpIter, args.WithDiff, args.WithFiltering, lockedStream, &done,
+		ctx, rSpan, args.Timestamp, catchUpIter, args.WithDiff, args.WithFiltering, valueFilter,
+		lockedStream, &done,
 	)
 	r.raftMu.Unlock()
 
@@ -440,6 +446,7 @@ func (r *Replica) registerWithRangefeedRaftMuLocked(
 	catchUpIter *rangefeed.CatchUpIterator,
 	withDiff bool,
 	withFiltering bool,
+	valueFilter *rangefeed.ValueFilter,
 	stream rangefeed.Stream,
 	done *future.ErrorFuture,
 ) rangefeed.Processor {
@@ -461,7 +468,7 @@ func (r *Replica) registerWithRangefeedRaftMuLocked(
 	p := r.rangefeedMu.proc
 
 	if p != nil {
-		reg, filter := p.Register(span, startTS, catchUpIter, withDiff, withFiltering,
+		reg, filter := p.Register(span, startTS, catchUpIter, withDiff, withFiltering, valueFilter,
 			stream, func() { r.maybeDisconnectEmptyRangefeed(p) }, done)
 		if reg {
 			// Registered successfully with an existing processor.
@@ -550,7 +557,7 @@ func (r *Replica) registerWithRangefeedRaftMuLocked(
 	// this ensures that the only time the registration fails is during
 	// server shutdown.
 	reg, filter := p.Register(span, startTS, catchUpIter, withDiff,
-		withFiltering, stream, func() { r.maybeDisconnectEmptyRangefeed(p) }, done)
+		withFiltering, valueFilter, stream, func() { r.maybeDisconnectEmptyRangefeed(p) }, done)
 	if !reg {
 		select {
 		case <-r.store.Stopper().ShouldQuiesce():
@@ -75,6 +75,10 @@ func (obj LBRebalancingObjective) ToSplitObjective() split.SplitObjective {
 		return split.SplitQPS
 	case LBRebalancingCPU:
 		return split.SplitCPU
+	case LBRebalancingWriteBytes:
+		// Load based splitting doesn't track the write bytes of a range, split
+		// on QPS instead, which is supported on every architecture.
+		return split.SplitQPS
 	default:
 		panic(fmt.Sprintf("unknown objective %d", obj))
 	}
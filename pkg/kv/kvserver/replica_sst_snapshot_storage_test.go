@@ -280,6 +280,7 @@ func TestMultiSSTWriterInitSST(t *testing.T) {
 	msstw, err := newMultiSSTWriter(
 		ctx, cluster.MakeTestingClusterSettings(), scratch, keySpans, 0,
 		false, /* skipRangeDelForLastSpan */
+		false, /* deltaForLastSpan */
 	)
 	require.NoError(t, err)
 	_, err = msstw.Finish(ctx)
@@ -345,7 +346,8 @@ func TestMultiSSTWriterAddLastSpan(t *testing.T) {
 
 			msstw, err := newMultiSSTWriter(
 				ctx, cluster.MakeTestingClusterSettings(), scratch, keySpans, 0,
-				true, /* skipRangeDelForLastSpan */
+				true,  /* skipRangeDelForLastSpan */
+				false, /* deltaForLastSpan */
 			)
 			require.NoError(t, err)
 			if addRangeDel {
@@ -394,6 +396,77 @@ func TestMultiSSTWriterAddLastSpan(t *testing.T) {
 	}
 }
 
+// TestMultiSSTWriterDeltaLastSpan tests that multiSSTWriter only clears the
+// range keys of the last span up front when receiving a delta snapshot, and
+// otherwise writes the range deletions of the delta.
+func TestMultiSSTWriterDeltaLastSpan(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	testRangeID := roachpb.RangeID(1)
+	testSnapUUID := uuid.Must(uuid.FromBytes([]byte("foobar1234567890")))
+	testLimiter := rate.NewLimiter(rate.Inf, 0)
+
+	cleanup, eng := newOnDiskEngine(ctx, t)
+	defer cleanup()
+	defer eng.Close()
+
+	sstSnapshotStorage := NewSSTSnapshotStorage(eng, testLimiter)
+	scratch := sstSnapshotStorage.NewScratchSpace(testRangeID, testSnapUUID)
+	desc := roachpb.RangeDescriptor{
+		StartKey: roachpb.RKey("d"),
+		EndKey:   roachpb.RKeyMax,
+	}
+	keySpans := rditer.MakeReplicatedKeySpans(&desc)
+
+	msstw, err := newMultiSSTWriter(
+		ctx, cluster.MakeTestingClusterSettings(), scratch, keySpans, 0,
+		false, /* skipRangeDelForLastSpan */
+		true,  /* deltaForLastSpan */
+	)
+	require.NoError(t, err)
+	testKey := roachpb.Key("d1")
+	testEngineKey, _ := storage.DecodeEngineKey(storage.EncodeMVCCKey(
+		storage.MVCCKey{Key: testKey, Timestamp: hlc.Timestamp{WallTime: 1}}))
+	require.NoError(t, msstw.PutInternalRangeDelete(ctx,
+		storage.EngineKey{Key: testKey}.Encode(), storage.EngineKey{Key: testKey.Next()}.Encode()))
+	require.NoError(t, msstw.Put(ctx, testEngineKey, []byte("foo")))
+	_, err = msstw.Finish(ctx)
+	require.NoError(t, err)
+
+	var actualSSTs [][]byte
+	fileNames := msstw.scratch.SSTs()
+	for _, file := range fileNames {
+		sst, err := fs.ReadFile(eng.Env(), file)
+		require.NoError(t, err)
+		actualSSTs = append(actualSSTs, sst)
+	}
+
+	var expectedSSTs [][]byte
+	for i, s := range keySpans {
+		func() {
+			sstFile := &storage.MemObject{}
+			sst := storage.MakeIngestionSSTWriter(ctx, cluster.MakeTestingClusterSettings(), sstFile)
+			defer sst.Close()
+			if i < len(keySpans)-1 {
+				require.NoError(t, sst.ClearRawRange(s.Key, s.EndKey, true, true))
+			} else {
+				require.NoError(t, sst.ClearRawRange(s.Key, s.EndKey, false, true))
+				require.NoError(t, sst.ClearRawRange(testKey, testKey.Next(), true, false))
+				require.NoError(t, sst.PutEngineKey(testEngineKey, []byte("foo")))
+			}
+			require.NoError(t, sst.Finish())
+			expectedSSTs = append(expectedSSTs, sstFile.Data())
+		}()
+	}
+
+	require.Equal(t, len(actualSSTs), len(expectedSSTs))
+	for i := range fileNames {
+		require.Equal(t, actualSSTs[i], expectedSSTs[i])
+	}
+}
+
 func newOnDiskEngine(ctx context.Context, t *testing.T) (func(), storage.Engine) {
 	dir, cleanup := testutils.TempDir(t)
 	eng, err := storage.Open(
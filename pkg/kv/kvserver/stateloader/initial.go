@@ -120,8 +120,8 @@ func WriteInitialRangeState(
 		return err
 	}
 
-	// TODO(sep-raft-log): when the log storage is separated, the below can't be
-	// written in the same batch. Figure out the ordering required here.
+	// NB: with a separate raft log engine, the raft state written below is moved
+	// to the log engine on startup, see kvstorage.moveRaftStateToLogEngine.
 	sl := Make(desc.RangeID)
 	if err := sl.SynthesizeRaftState(ctx, readWriter); err != nil {
 		return err
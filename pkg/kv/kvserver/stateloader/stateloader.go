@@ -83,6 +83,7 @@ func (rsl StateLoader) Load(
 	ms := as.RangeStats.ToStats()
 	s.Stats = &ms
 	s.RaftClosedTimestamp = as.RaftClosedTimestamp
+	s.MVCCHistoryMutationIndex = as.MVCCHistoryMutationIndex
 
 	// The truncated state should not be optional (i.e. the pointer is
 	// pointless), but it is and the migration is not worth it.
@@ -127,8 +128,9 @@ func (rsl StateLoader) Save(
 	if err := rsl.SetGCHint(ctx, readWriter, ms, state.GCHint); err != nil {
 		return enginepb.MVCCStats{}, err
 	}
-	// TODO(sep-raft-log): SetRaftTruncatedState will be in a separate batch when
-	// the Raft log engine is separated. Figure out the ordering required here.
+	// NB: with a separate raft log engine, the RaftTruncatedState is moved to the
+	// log engine by splitPreApply for the RHS of a split, and on startup when the
+	// store is bootstrapped, see kvstorage.moveRaftStateToLogEngine.
 	if err := rsl.SetRaftTruncatedState(ctx, readWriter, state.TruncatedState); err != nil {
 		return enginepb.MVCCStats{}, err
 	}
@@ -145,6 +147,7 @@ func (rsl StateLoader) Save(
 		state.RaftAppliedIndexTerm,
 		ms,
 		state.RaftClosedTimestamp,
+		state.MVCCHistoryMutationIndex,
 		nil,
 	); err != nil {
 		return enginepb.MVCCStats{}, err
@@ -237,6 +240,7 @@ func (rsl StateLoader) SetRangeAppliedState(
 	appliedIndexTerm kvpb.RaftTerm,
 	newMS *enginepb.MVCCStats,
 	raftClosedTimestamp hlc.Timestamp,
+	mvccHistoryMutationIndex kvpb.RaftIndex,
 	asAlloc *kvserverpb.RangeAppliedState, // optional
 ) error {
 	if asAlloc == nil {
@@ -244,11 +248,12 @@ func (rsl StateLoader) SetRangeAppliedState(
 	}
 	as := asAlloc
 	*as = kvserverpb.RangeAppliedState{
-		RaftAppliedIndex:     appliedIndex,
-		LeaseAppliedIndex:    leaseAppliedIndex,
-		RangeStats:           kvserverpb.MVCCPersistentStats(*newMS),
-		RaftClosedTimestamp:  raftClosedTimestamp,
-		RaftAppliedIndexTerm: appliedIndexTerm,
+		RaftAppliedIndex:         appliedIndex,
+		LeaseAppliedIndex:        leaseAppliedIndex,
+		RangeStats:               kvserverpb.MVCCPersistentStats(*newMS),
+		RaftClosedTimestamp:      raftClosedTimestamp,
+		RaftAppliedIndexTerm:     appliedIndexTerm,
+		MVCCHistoryMutationIndex: mvccHistoryMutationIndex,
 	}
 	// The RangeAppliedStateKey is not included in stats. This is also reflected
 	// in ComputeStats.
@@ -270,7 +275,7 @@ func (rsl StateLoader) SetMVCCStats(
 	alloc := as // reuse
 	return rsl.SetRangeAppliedState(
 		ctx, readWriter, as.RaftAppliedIndex, as.LeaseAppliedIndex, as.RaftAppliedIndexTerm, newMS,
-		as.RaftClosedTimestamp, alloc)
+		as.RaftClosedTimestamp, as.MVCCHistoryMutationIndex, alloc)
 }
 
 // SetClosedTimestamp overwrites the closed timestamp.
@@ -284,7 +289,7 @@ func (rsl StateLoader) SetClosedTimestamp(
 	alloc := as // reuse
 	return rsl.SetRangeAppliedState(
 		ctx, readWriter, as.RaftAppliedIndex, as.LeaseAppliedIndex, as.RaftAppliedIndexTerm,
-		as.RangeStats.ToStatsPtr(), closedTS, alloc)
+		as.RangeStats.ToStatsPtr(), closedTS, as.MVCCHistoryMutationIndex, alloc)
 }
 
 // LoadGCThreshold loads the GC threshold.
@@ -554,10 +554,11 @@ func (rs *storeReplicaVisitor) EstimatedCount() int {
 }
 
 // internalEngines contains the engines that support the operations of
-// this Store. At the time of writing, all three fields will be populated
-// with the same Engine. As work on CRDB-220 (separate raft log) proceeds,
-// we will be able to experimentally run with a separate log engine, and
-// ultimately allow doing so in production deployments.
+// this Store. All three fields are populated with the same Engine, unless
+// the Store was configured with a separate raft log engine, in which case
+// logEngine holds the raft state of the replicas (see kvstorage.Engines).
+// As work on CRDB-220 (separate raft log) proceeds, the uses of todoEngine
+// will be migrated to one of the other two.
 type internalEngines struct {
 	// stateEngine is the engine that materializes the raft logs on the system.
 	stateEngine storage.Engine
@@ -1413,6 +1414,14 @@ func (sc *StoreConfig) Tracer() *tracing.Tracer {
 // NewStore returns a new instance of a store.
 func NewStore(
 	ctx context.Context, cfg StoreConfig, eng storage.Engine, nodeDesc *roachpb.NodeDescriptor,
+) *Store {
+	return NewStoreWithEngines(ctx, cfg, kvstorage.MakeEngines(eng), nodeDesc)
+}
+
+// NewStoreWithEngines returns a new instance of a store which keeps its state
+// in the given engines. See kvstorage.Engines.
+func NewStoreWithEngines(
+	ctx context.Context, cfg StoreConfig, engs kvstorage.Engines, nodeDesc *roachpb.NodeDescriptor,
 ) *Store {
 	if !cfg.Valid() {
 		log.Fatalf(ctx, "invalid store configuration: %+v", &cfg)
@@ -1425,9 +1434,9 @@ func NewStore(
 		// This simplifies going through references to these
 		// engines.
 		internalEngines: internalEngines{
-			stateEngine: eng,
-			todoEngine:  eng,
-			logEngine:   eng,
+			stateEngine: engs.StateEngine,
+			todoEngine:  engs.StateEngine,
+			logEngine:   engs.LogEngine,
 		},
 		cfg:                               cfg,
 		db:                                cfg.DB, // TODO(tschottdorf): remove redundancy.
@@ -2033,8 +2042,10 @@ func (s *Store) Start(ctx context.Context, stopper *stop.Stopper) error {
 
 	// Populate the store ident. If not bootstrapped, ReadStoreIntent will
 	// return an error.
-	// TODO(sep-raft-log): which engine holds the ident?
-	ident, err := kvstorage.ReadStoreIdent(ctx, s.TODOEngine())
+	//
+	// NB: the ident is read from the state engine. A separate log engine holds
+	// a copy of it, which is verified in LoadAndReconcileReplicas.
+	ident, err := kvstorage.ReadStoreIdent(ctx, s.StateEngine())
 	if err != nil {
 		return err
 	}
@@ -2045,11 +2056,15 @@ func (s *Store) Start(ctx context.Context, stopper *stop.Stopper) error {
 	ctx = s.AnnotateCtx(ctx)
 	log.Event(ctx, "read store identity")
 
-	// Communicate store ID to engine.
-	// TODO(sep-raft-log): do for both engines.
-	if err := s.TODOEngine().SetStoreID(ctx, int32(s.StoreID())); err != nil {
+	// Communicate store ID to engines.
+	if err := s.StateEngine().SetStoreID(ctx, int32(s.StoreID())); err != nil {
 		return err
 	}
+	if s.raftLogSeparated() {
+		if err := s.LogEngine().SetStoreID(ctx, int32(s.StoreID())); err != nil {
+			return err
+		}
+	}
 
 	{
 		m := rangefeed.NewSchedulerMetrics(s.cfg.HistogramWindowInterval)
@@ -2126,7 +2141,7 @@ func (s *Store) Start(ctx context.Context, stopper *stop.Stopper) error {
 	{
 		truncator := s.raftTruncator
 		// When state machine has persisted new RaftAppliedIndex, fire callback.
-		s.TODOEngine().RegisterFlushCompletedCallback(func() {
+		s.StateEngine().RegisterFlushCompletedCallback(func() {
 			truncator.durabilityAdvancedCallback()
 		})
 	}
@@ -2152,10 +2167,7 @@ func (s *Store) Start(ctx context.Context, stopper *stop.Stopper) error {
 	// concurrently. Note that while we can perform this initialization
 	// concurrently, all initialization must be performed before we start
 	// listening for Raft messages and starting the process Raft loop.
-	//
-	// TODO(sep-raft-log): this will need to learn to stitch and reconcile data from
-	// both engines.
-	repls, err := kvstorage.LoadAndReconcileReplicas(ctx, s.TODOEngine())
+	repls, err := kvstorage.LoadAndReconcileReplicas(ctx, s.engines())
 	if err != nil {
 		return err
 	}
@@ -2172,7 +2184,7 @@ func (s *Store) Start(ctx context.Context, stopper *stop.Stopper) error {
 			continue
 		}
 		// TODO(pavelkalinnikov): integrate into kvstorage.LoadAndReconcileReplicas.
-		state, err := repl.Load(ctx, s.TODOEngine(), s.StoreID())
+		state, err := repl.Load(ctx, s.engines(), s.StoreID())
 		if err != nil {
 			return err
 		}
@@ -2931,6 +2943,20 @@ func (s *Store) LogEngine() storage.Engine {
 	return s.internalEngines.logEngine
 }
 
+// engines returns the state and log engines.
+func (s *Store) engines() kvstorage.Engines {
+	return kvstorage.Engines{
+		StateEngine: s.internalEngines.stateEngine,
+		LogEngine:   s.internalEngines.logEngine,
+	}
+}
+
+// raftLogSeparated returns whether the raft state of the replicas is kept in a
+// separate log engine.
+func (s *Store) raftLogSeparated() bool {
+	return s.engines().Separated()
+}
+
 // DB accessor.
 func (s *Store) DB() *kv.DB { return s.cfg.DB }
 
@@ -2989,21 +3015,23 @@ func (s *Store) Capacity(ctx context.Context, useCached bool) (roachpb.StoreCapa
 	var logicalBytes int64
 	var totalQueriesPerSecond float64
 	var totalWritesPerSecond float64
+	var totalWriteBytesPerSecond float64
 	var totalStoreCPUTimePerSecond float64
 	replicaCount := s.metrics.ReplicaCount.Value()
 	bytesPerReplica := make([]float64, 0, replicaCount)
 	writesPerReplica := make([]float64, 0, replicaCount)
 	// We wish to track both CPU and QPS, due to different usecases between UI
 	// and rebalancing. By default rebalancing uses CPU whilst the UI will use
-	// QPS.
-	rankingsAccumulator := NewReplicaAccumulator(load.CPU, load.Queries)
+	// QPS. Write bytes are tracked for the write bytes rebalancing objective.
+	rankingsAccumulator := NewReplicaAccumulator(load.CPU, load.Queries, load.WriteBytes)
 	// rankingsByTenantAccumulator collects top replicas by QPS only as far as it is
 	// used in Db Console only.
 	rankingsByTenantAccumulator := NewTenantReplicaAccumulator(load.Queries)
 
 	newStoreReplicaVisitor(s).Visit(func(r *Replica) bool {
 		rangeCount++
-		if r.OwnsValidLease(ctx, now) {
+		ownsLease := r.OwnsValidLease(ctx, now)
+		if ownsLease {
 			leaseCount++
 		}
 		usage := r.RangeUsageInfo()
@@ -3017,6 +3045,13 @@ func (s *Store) Capacity(ctx context.Context, useCached bool) (roachpb.StoreCapa
 		totalStoreCPUTimePerSecond += usage.RequestCPUNanosPerSecond + usage.RaftCPUNanosPerSecond
 		totalQueriesPerSecond += usage.QueriesPerSecond
 		totalWritesPerSecond += usage.WritesPerSecond
+		// Every replica applies the same writes, but the write bytes are only
+		// attributed to the leaseholder. The store rebalancer only moves ranges
+		// it holds the lease for, so it can act on all of the store's write
+		// bytes, similar to QPS.
+		if ownsLease {
+			totalWriteBytesPerSecond += usage.AppliedWriteBytesPerSecond
+		}
 		writesPerReplica = append(writesPerReplica, usage.WritesPerSecond)
 		cr := candidateReplica{
 			Replica: r,
@@ -3045,6 +3080,7 @@ func (s *Store) Capacity(ctx context.Context, useCached bool) (roachpb.StoreCapa
 	capacity.CPUPerSecond = totalStoreCPUTimePerSecond
 	capacity.QueriesPerSecond = totalQueriesPerSecond
 	capacity.WritesPerSecond = totalWritesPerSecond
+	capacity.WriteBytesPerSecond = totalWriteBytesPerSecond
 	goNow := now.ToTimestamp().GoTime()
 	{
 		s.ioThreshold.Lock()
@@ -3973,10 +4009,12 @@ func (s *storeForTruncatorImpl) releaseReplicaForTruncator(r replicaForTruncator
 	replica.raftMu.Unlock()
 }
 
-func (s *storeForTruncatorImpl) getEngine() storage.Engine {
-	// TODO(sep-raft-log): we'll need the log engine here but need
-	// to read code to see if more needs to be done.
-	return (*Store)(s).TODOEngine()
+func (s *storeForTruncatorImpl) getStateEngine() storage.Engine {
+	return (*Store)(s).StateEngine()
+}
+
+func (s *storeForTruncatorImpl) getLogEngine() storage.Engine {
+	return (*Store)(s).LogEngine()
 }
 
 func init() {
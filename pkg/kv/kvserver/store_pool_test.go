@@ -129,6 +129,7 @@ func TestStorePoolUpdateLocalStore(t *testing.T) {
 	for _, store := range stores {
 		replica.loadStats.RecordBatchRequests(1, store.Node.NodeID)
 		replica.loadStats.RecordWriteKeys(1)
+		replica.loadStats.RecordAppliedWriteBytes(1 << 10)
 	}
 	manual.Advance(replicastats.MinStatsDuration + time.Second)
 
@@ -136,6 +137,7 @@ func TestStorePoolUpdateLocalStore(t *testing.T) {
 	stats := replica.LoadStats()
 	QPS := stats.QueriesPerSecond
 	WPS := stats.WriteKeysPerSecond
+	WBPS := stats.AppliedWriteBytesPerSecond
 
 	sp.UpdateLocalStoreAfterRebalance(roachpb.StoreID(1), rangeUsageInfo, roachpb.ADD_VOTER)
 	desc, ok := sp.GetStoreDescriptor(roachpb.StoreID(1))
@@ -154,6 +156,10 @@ func TestStorePoolUpdateLocalStore(t *testing.T) {
 	if expectedWPS := 30 + WPS; desc.Capacity.WritesPerSecond != expectedWPS {
 		t.Errorf("expected WritesPerSecond %f, but got %f", expectedWPS, desc.Capacity.WritesPerSecond)
 	}
+	// The write bytes of a range are only counted on its leaseholder.
+	if expectedWBPS := float64(0); desc.Capacity.WriteBytesPerSecond != expectedWBPS {
+		t.Errorf("expected WriteBytesPerSecond %f, but got %f", expectedWBPS, desc.Capacity.WriteBytesPerSecond)
+	}
 	if expectedNumL0Sublevels := int64(5); desc.Capacity.IOThreshold.L0NumSubLevels != expectedNumL0Sublevels {
 		t.Errorf("expected L0 Sub-Levels %d, but got %d", expectedNumL0Sublevels, desc.Capacity.IOThreshold.L0NumSubLevels)
 	}
@@ -208,6 +214,9 @@ func TestStorePoolUpdateLocalStore(t *testing.T) {
 	if expectedQPS := 50 + QPS; desc.Capacity.QueriesPerSecond != expectedQPS {
 		t.Errorf("expected QueriesPerSecond %f, but got %f", expectedQPS, desc.Capacity.QueriesPerSecond)
 	}
+	if expectedWBPS := WBPS; desc.Capacity.WriteBytesPerSecond != expectedWBPS {
+		t.Errorf("expected WriteBytesPerSecond %f, but got %f", expectedWBPS, desc.Capacity.WriteBytesPerSecond)
+	}
 
 	sp.UpdateLocalStoreAfterRelocate(
 		[]roachpb.ReplicationTarget{{StoreID: roachpb.StoreID(1)}}, []roachpb.ReplicationTarget{},
@@ -217,12 +226,14 @@ func TestStorePoolUpdateLocalStore(t *testing.T) {
 	desc, ok = sp.GetStoreDescriptor(roachpb.StoreID(1))
 	require.True(t, ok)
 	require.Equal(t, 100.0, desc.Capacity.QueriesPerSecond)
+	require.Equal(t, WBPS, desc.Capacity.WriteBytesPerSecond)
 	require.Equal(t, int32(1), desc.Capacity.LeaseCount)
 	require.Equal(t, int32(7), desc.Capacity.RangeCount)
 
 	desc, ok = sp.GetStoreDescriptor(roachpb.StoreID(2))
 	require.True(t, ok)
 	require.Equal(t, 50.0, desc.Capacity.QueriesPerSecond)
+	require.Equal(t, 0.0, desc.Capacity.WriteBytesPerSecond)
 	require.Equal(t, int32(2), desc.Capacity.LeaseCount)
 	require.Equal(t, int32(2), desc.Capacity.RangeCount)
 }
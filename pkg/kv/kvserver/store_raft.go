@@ -475,6 +475,14 @@ func (s *Store) processRaftSnapshotRequest(
 			// that raft looks at just before handing the message off.
 			snapHeader.RaftMessageRequest.Message.From = 0
 		}
+		if inSnap.deltaBase != nil {
+			// The delta snapshot can only be applied to the replica state it was
+			// streamed against. Check this before handing it to raft, as errors
+			// during application are fatal.
+			if err := r.checkSnapshotDeltaBaseRaftMuLocked(inSnap); err != nil {
+				return kvpb.NewError(err)
+			}
+		}
 		// NB: we cannot get errRemoved here because we're promised by
 		// withReplicaForRequest that this replica is not currently being removed
 		// and we've been holding the raftMu the entire time.
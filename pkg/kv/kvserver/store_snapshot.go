@@ -119,6 +119,9 @@ type kvBatchSnapshotStrategy struct {
 	scratch   *SSTSnapshotStorageScratch
 	st        *cluster.Settings
 	clusterID uuid.UUID
+	// If set, the snapshot is streamed as a delta against the state of the
+	// recipient's replica. See kvserverpb.SnapshotDeltaBase.
+	deltaBase *kvserverpb.SnapshotDeltaBase
 }
 
 // multiSSTWriter is a wrapper around an SSTWriter and SSTSnapshotStorageScratch
@@ -141,6 +144,10 @@ type multiSSTWriter struct {
 	// same sstable. We rely on the caller to take care of clearing this span
 	// through a different process (eg. IngestAndExcise on pebble).
 	skipRangeDelForLastSpan bool
+	// if deltaForLastSpan is true, only the range keys of the last span are
+	// cleared up front. Its point keys are cleared key by key by the range
+	// deletions received in a delta snapshot.
+	deltaForLastSpan bool
 }
 
 func newMultiSSTWriter(
@@ -150,6 +157,7 @@ func newMultiSSTWriter(
 	keySpans []roachpb.Span,
 	sstChunkSize int64,
 	skipRangeDelForLastSpan bool,
+	deltaForLastSpan bool,
 ) (multiSSTWriter, error) {
 	msstw := multiSSTWriter{
 		st:                      st,
@@ -157,6 +165,7 @@ func newMultiSSTWriter(
 		keySpans:                keySpans,
 		sstChunkSize:            sstChunkSize,
 		skipRangeDelForLastSpan: skipRangeDelForLastSpan,
+		deltaForLastSpan:        deltaForLastSpan,
 	}
 	if err := msstw.initSST(ctx); err != nil {
 		return msstw, err
@@ -171,14 +180,15 @@ func (msstw *multiSSTWriter) initSST(ctx context.Context) error {
 	}
 	newSST := storage.MakeIngestionSSTWriter(ctx, msstw.st, newSSTFile)
 	msstw.currSST = newSST
-	if msstw.skipRangeDelForLastSpan && msstw.currSpan == len(msstw.keySpans)-1 {
+	lastSpan := msstw.currSpan == len(msstw.keySpans)-1
+	if msstw.skipRangeDelForLastSpan && lastSpan {
 		// Skip this ClearRange, as it will be excised at ingestion time in the
 		// engine instead.
 		return nil
 	}
 	if err := msstw.currSST.ClearRawRange(
 		msstw.keySpans[msstw.currSpan].Key, msstw.keySpans[msstw.currSpan].EndKey,
-		true /* pointKeys */, true, /* rangeKeys */
+		!(msstw.deltaForLastSpan && lastSpan) /* pointKeys */, true, /* rangeKeys */
 	); err != nil {
 		msstw.currSST.Close()
 		return errors.Wrap(err, "failed to clear range on sst file writer")
@@ -500,6 +510,15 @@ func (kvSS *kvBatchSnapshotStrategy) Receive(
 	if header.SharedReplicate && !s.cfg.SharedStorageEnabled {
 		return noSnap, sendSnapshotError(ctx, s, stream, errors.New("cannot accept shared sstables"))
 	}
+	// A delta snapshot is applied on top of the user keys of the existing
+	// replica, which must thus not be excised.
+	delta := kvSS.deltaBase != nil
+	if delta {
+		if header.SharedReplicate || header.ExternalReplicate {
+			return noSnap, errors.AssertionFailedf("delta snapshot cannot contain shared or external files")
+		}
+		doExcise = false
+	}
 
 	// We rely on the last keyRange passed into multiSSTWriter being the user key
 	// span. If the sender signals that it can no longer do shared replication
@@ -508,7 +527,7 @@ func (kvSS *kvBatchSnapshotStrategy) Receive(
 	// opaque slice of keyRanges, we just tell it to add a rangedel for the last
 	// span. To avoid bugs, assert on the last span in keyRanges actually being
 	// equal to the user key span.
-	if doExcise {
+	if doExcise || delta {
 		if !keyRanges[len(keyRanges)-1].Equal(header.State.Desc.KeySpan().AsRawSpanWithNoLocals()) {
 			return noSnap, errors.AssertionFailedf("last span in multiSSTWriter did not equal the user key span: %s", keyRanges[len(keyRanges)-1].String())
 		}
@@ -517,7 +536,7 @@ func (kvSS *kvBatchSnapshotStrategy) Receive(
 	// TODO(aaditya): Remove once we support flushableIngests for shared and
 	// external files in the engine.
 	skipRangeDelForLastSpan := doExcise && (header.SharedReplicate || header.ExternalReplicate)
-	msstw, err := newMultiSSTWriter(ctx, kvSS.st, kvSS.scratch, keyRanges, kvSS.sstChunkSize, skipRangeDelForLastSpan)
+	msstw, err := newMultiSSTWriter(ctx, kvSS.st, kvSS.scratch, keyRanges, kvSS.sstChunkSize, skipRangeDelForLastSpan, delta)
 	if err != nil {
 		return noSnap, err
 	}
@@ -565,8 +584,9 @@ func (kvSS *kvBatchSnapshotStrategy) Receive(
 				if err != nil {
 					return noSnap, err
 				}
-				// Verify value checksum to catch data corruption.
-				if verifyCheckSum {
+				// Verify value checksum to catch data corruption. Range deletions
+				// carry their end key in place of a value.
+				if verifyCheckSum && batchReader.KeyKind() != pebble.InternalKeyKindRangeDelete {
 					if err = ek.Verify(batchReader.Value()); err != nil {
 						return noSnap, errors.Wrap(err, "verifying value checksum")
 					}
@@ -584,7 +604,9 @@ func (kvSS *kvBatchSnapshotStrategy) Receive(
 						return noSnap, errors.Wrapf(err, "writing sst for raft snapshot")
 					}
 				case pebble.InternalKeyKindRangeDelete:
-					if !doExcise {
+					// Delta snapshots clear each changed user key before sending its
+					// versions.
+					if !doExcise && !delta {
 						return noSnap, errors.AssertionFailedf("unexpected batch entry key kind %d", batchReader.KeyKind())
 					}
 					start := batchReader.Key()
@@ -715,11 +737,16 @@ func (kvSS *kvBatchSnapshotStrategy) Receive(
 				doExcise:                    doExcise,
 				includesRangeDelForLastSpan: !skipRangeDelForLastSpan,
 				clearedSpans:                keyRanges,
+				deltaBase:                   kvSS.deltaBase,
+			}
+			if delta {
+				// The point keys of the user key span are only partially cleared.
+				inSnap.clearedSpans = keyRanges[:len(keyRanges)-1]
 			}
 
 			timingTag.stop("totalTime")
 
-			kvSS.status = redact.Sprintf("local ssts: %d, shared ssts: %d, external ssts: %d", len(kvSS.scratch.SSTs()), len(sharedSSTs), len(externalSSTs))
+			kvSS.status = redact.Sprintf("local ssts: %d, shared ssts: %d, external ssts: %d, delta: %t", len(kvSS.scratch.SSTs()), len(sharedSSTs), len(externalSSTs), delta)
 			return inSnap, nil
 		}
 	}
@@ -737,7 +764,7 @@ func (kvSS *kvBatchSnapshotStrategy) Send(
 	// not reflect the log entries sent (which are never sent in newer versions of
 	// CRDB, as of VersionUnreplicatedTruncatedState).
 	var bytesSent int64
-	var kvs, rangeKVs, sharedSSTCount, externalSSTCount int
+	var kvs, rangeKVs, sharedSSTCount, externalSSTCount, deltaKeys int
 
 	// These stopwatches allow us to time the various components of Send().
 	// - totalTimeStopwatch measures the total time spent within this function.
@@ -800,7 +827,7 @@ func (kvSS *kvBatchSnapshotStrategy) Send(
 	sharedReplicate := header.SharedReplicate && rditer.IterateReplicaKeySpansShared != nil
 	externalReplicate := header.ExternalReplicate && rditer.IterateReplicaKeySpansShared != nil
 	replicatedFilter := rditer.ReplicatedSpansAll
-	if sharedReplicate || externalReplicate {
+	if sharedReplicate || externalReplicate || kvSS.deltaBase != nil {
 		replicatedFilter = rditer.ReplicatedSpansExcludeUser
 	}
 
@@ -864,6 +891,67 @@ func (kvSS *kvBatchSnapshotStrategy) Send(
 		return 0, err
 	}
 
+	// If the recipient accepted a delta snapshot, send the full history of the
+	// user keys which changed since its base, each preceded by a deletion of
+	// the key, followed by all range keys of the user key span.
+	if base := kvSS.deltaBase; base != nil {
+		userSpan := snap.State.Desc.KeySpan().AsRawSpanWithNoLocals()
+		err := iterateSnapshotDeltaKeys(ctx, snap.EngineSnap, userSpan, base.ClosedTimestamp,
+			func(key roachpb.Key, iter storage.EngineIterator) error {
+				timingTag.start("iter")
+				defer timingTag.stop("iter")
+
+				deltaKeys++
+				if b == nil {
+					b = kvSS.newWriteBatch()
+				}
+				if err := b.ClearRawRange(key, key.Next(), true /* pointKeys */, false /* rangeKeys */); err != nil {
+					return err
+				}
+				for ok := true; ok; {
+					ek, err := iter.UnsafeEngineKey()
+					if err != nil {
+						return err
+					}
+					if !ek.Key.Equal(key) {
+						return nil
+					}
+					kvs++
+					if b == nil {
+						b = kvSS.newWriteBatch()
+					}
+					v, err := iter.UnsafeValue()
+					if err != nil {
+						return err
+					}
+					if err := b.PutEngineKey(ek, v); err != nil {
+						return err
+					}
+					if err := maybeFlushBatch(); err != nil {
+						return err
+					}
+					if ok, err = iter.NextEngineKey(); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		if err != nil {
+			return 0, err
+		}
+		err = rditer.IterateReplicaKeySpans(ctx, snap.State.Desc, snap.EngineSnap, true, /* replicatedOnly */
+			rditer.ReplicatedSpansUserOnly,
+			func(iter storage.EngineIterator, span roachpb.Span, keyType storage.IterKeyType) error {
+				if keyType != storage.IterKeyTypeRangesOnly {
+					return nil
+				}
+				return iterateRKSpansVisitor(iter, span, keyType)
+			})
+		if err != nil {
+			return 0, err
+		}
+	}
+
 	var valBuf []byte
 	if sharedReplicate || externalReplicate {
 		var sharedVisitor func(sst *pebble.SharedSSTMeta) error
@@ -977,7 +1065,7 @@ func (kvSS *kvBatchSnapshotStrategy) Send(
 	timingTag.stop("totalTime")
 	log.Eventf(ctx, "finished sending snapshot batches, sent a total of %d bytes", bytesSent)
 
-	kvSS.status = redact.Sprintf("kvs=%d rangeKVs=%d sharedSSTs=%d, externalSSTs=%d", kvs, rangeKVs, sharedSSTCount, externalSSTCount)
+	kvSS.status = redact.Sprintf("kvs=%d rangeKVs=%d sharedSSTs=%d, externalSSTs=%d, deltaKeys=%d", kvs, rangeKVs, sharedSSTCount, externalSSTCount, deltaKeys)
 	return bytesSent, nil
 }
 
@@ -1389,20 +1477,23 @@ func (s *Store) receiveSnapshot(
 	// ReplicaPlaceholder semantics. Please be familiar with them
 	// before making any changes.
 	var placeholder *ReplicaPlaceholder
+	var deltaBase *kvserverpb.SnapshotDeltaBase
 	if pErr := s.withReplicaForRequest(
 		ctx, &header.RaftMessageRequest, func(ctx context.Context, r *Replica,
 		) *kvpb.Error {
 			var err error
 			s.mu.Lock()
-			defer s.mu.Unlock()
 			placeholder, err = s.canAcceptSnapshotLocked(ctx, header)
+			if err == nil && placeholder != nil {
+				err = s.addPlaceholderLocked(placeholder)
+			}
+			s.mu.Unlock()
 			if err != nil {
 				return kvpb.NewError(err)
 			}
-			if placeholder != nil {
-				if err := s.addPlaceholderLocked(placeholder); err != nil {
-					return kvpb.NewError(err)
-				}
+			// Initialized replicas may receive the snapshot as a delta.
+			if placeholder == nil {
+				deltaBase = r.snapshotDeltaBaseRaftMuLocked(ctx, header)
 			}
 			return nil
 		}); pErr != nil {
@@ -1431,10 +1522,14 @@ func (s *Store) receiveSnapshot(
 		sstChunkSize: snapshotSSTWriteSyncRate.Get(&s.cfg.Settings.SV),
 		st:           s.ClusterSettings(),
 		clusterID:    s.ClusterID(),
+		deltaBase:    deltaBase,
 	}
 	defer ss.Close(ctx)
 
-	if err := stream.Send(&kvserverpb.SnapshotResponse{Status: kvserverpb.SnapshotResponse_ACCEPTED}); err != nil {
+	if err := stream.Send(&kvserverpb.SnapshotResponse{
+		Status:    kvserverpb.SnapshotResponse_ACCEPTED,
+		DeltaBase: deltaBase,
+	}); err != nil {
 		return err
 	}
 	if log.V(2) {
@@ -1899,6 +1994,7 @@ func sendSnapshot(
 		newWriteBatch: newWriteBatch,
 		st:            st,
 		clusterID:     clusterID,
+		deltaBase:     resp.DeltaBase,
 	}
 
 	// Record timings for snapshot send if kv.trace.snapshot.enable_threshold is enabled
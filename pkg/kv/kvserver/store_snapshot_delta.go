@@ -0,0 +1,198 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package kvserver
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/rditer"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// snapshotDeltaEnabled controls whether snapshots may be sent as deltas
+// against the state of the recipient's replica, see
+// kvserverpb.SnapshotDeltaBase. This allows a replica which fell behind the
+// raft log truncation only briefly to catch up without receiving the full
+// range.
+//
+// Delta snapshots rely on all commands writing above the closed timestamp.
+// Commands which don't, like ClearRange, RevertRange, non-MVCC AddSSTable and
+// GC requests, are recorded in the RangeAppliedState, and the snapshot is sent
+// in full if the recipient's replica hasn't applied the last of them.
+var snapshotDeltaEnabled = settings.RegisterBoolSetting(
+	settings.SystemOnly,
+	"kv.snapshot.delta.enabled",
+	"if enabled, raft snapshots to replicas which fell behind only slightly contain "+
+		"only the user keys which changed since the replica's state",
+	false,
+)
+
+// snapshotDeltaBaseRaftMuLocked returns the base which the snapshot with the given header
+// may be streamed against as a delta to this replica, or nil if the snapshot
+// must be streamed in full. The replica must be initialized.
+//
+// The base is only offered if the replica has the same descriptor and GC
+// threshold as the snapshot, already applied the last command which removed
+// or wrote MVCC versions below the closed timestamp, and holds no replicated
+// locks. With no locks, all intents which are resolved after the base were
+// written above the base's closed timestamp, so their resolution is reflected
+// in the versions above it. The base is verified again when the snapshot is
+// applied, see checkSnapshotDeltaBaseRaftMuLocked.
+func (r *Replica) snapshotDeltaBaseRaftMuLocked(
+	ctx context.Context, header *kvserverpb.SnapshotRequest_Header,
+) *kvserverpb.SnapshotDeltaBase {
+	if !header.DeltaReplicate || header.SharedReplicate || header.ExternalReplicate {
+		return nil
+	}
+	desc := header.State.Desc
+	if desc.StartKey.AsRawKey().Compare(keys.TableDataMin) < 0 {
+		// System ranges contain inline values, which aren't timestamped.
+		return nil
+	}
+
+	r.mu.RLock()
+	curDesc := r.mu.state.Desc
+	base := &kvserverpb.SnapshotDeltaBase{
+		AppliedIndex:    r.mu.state.RaftAppliedIndex,
+		ClosedTimestamp: r.mu.state.RaftClosedTimestamp,
+	}
+	sameGCThreshold := r.mu.state.GCThreshold.Equal(header.State.GCThreshold)
+	r.mu.RUnlock()
+
+	if !curDesc.IsInitialized() || curDesc.Generation != desc.Generation ||
+		!curDesc.StartKey.Equal(desc.StartKey) || !curDesc.EndKey.Equal(desc.EndKey) ||
+		!sameGCThreshold || base.ClosedTimestamp.IsEmpty() ||
+		base.AppliedIndex >= header.State.RaftAppliedIndex {
+		return nil
+	}
+	// The MVCC history mutation index is only recorded once the snapshot's
+	// state has been migrated, see kvserverpb.RangeAppliedState.
+	if v := header.State.Version; v == nil ||
+		!v.AtLeast(clusterversion.V24_1_MVCCHistoryMutationIndex.Version()) ||
+		base.AppliedIndex < header.State.MVCCHistoryMutationIndex {
+		return nil
+	}
+
+	// NB: raftMu is held, so the replica doesn't apply commands while the lock
+	// table is checked.
+	lockSpans := rditer.Select(desc.RangeID, rditer.SelectOpts{
+		ReplicatedBySpan:      desc.RSpan(),
+		ReplicatedSpansFilter: rditer.ReplicatedSpansLocksOnly,
+	})
+	reader := r.store.TODOEngine()
+	for _, span := range lockSpans {
+		empty, err := isEngineSpanEmpty(ctx, reader, span)
+		if err != nil {
+			log.Warningf(ctx, "unable to check lock table for delta snapshot: %v", err)
+			return nil
+		}
+		if !empty {
+			return nil
+		}
+	}
+	return base
+}
+
+// isEngineSpanEmpty returns whether the reader has no point keys in the span.
+func isEngineSpanEmpty(
+	ctx context.Context, reader storage.Reader, span roachpb.Span,
+) (bool, error) {
+	iter, err := reader.NewEngineIterator(ctx, storage.IterOptions{
+		KeyTypes:   storage.IterKeyTypePointsOnly,
+		LowerBound: span.Key,
+		UpperBound: span.EndKey,
+	})
+	if err != nil {
+		return false, err
+	}
+	defer iter.Close()
+	ok, err := iter.SeekEngineKeyGE(storage.EngineKey{Key: span.Key})
+	return !ok, err
+}
+
+// checkSnapshotDeltaBaseRaftMuLocked returns an error if the replica moved
+// past the base which the delta snapshot was streamed against. The snapshot
+// must then be discarded, and the sender will retry.
+func (r *Replica) checkSnapshotDeltaBaseRaftMuLocked(inSnap IncomingSnapshot) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if applied := r.mu.state.RaftAppliedIndex; applied != inSnap.deltaBase.AppliedIndex {
+		return errors.Errorf("replica moved to applied index %d past the base of delta snapshot at %d",
+			applied, inSnap.deltaBase.AppliedIndex)
+	}
+	if desc := r.mu.state.Desc; desc.Generation != inSnap.Desc.Generation {
+		return errors.Errorf("replica descriptor %s changed since the base of delta snapshot", desc)
+	}
+	return nil
+}
+
+// iterateSnapshotDeltaKeys calls the visitor for each key in the span which
+// has versions above the given timestamp, with an iterator positioned at the
+// first version of the key. The visitor may advance the iterator.
+//
+// Provisional values of intents are treated as any other version: the lock
+// table is streamed in full alongside.
+func iterateSnapshotDeltaKeys(
+	ctx context.Context,
+	reader storage.Reader,
+	span roachpb.Span,
+	since hlc.Timestamp,
+	visitor func(roachpb.Key, storage.EngineIterator) error,
+) error {
+	incIter, err := storage.NewMVCCIncrementalIterator(ctx, reader, storage.MVCCIncrementalIterOptions{
+		KeyTypes:     storage.IterKeyTypePointsOnly,
+		StartKey:     span.Key,
+		EndKey:       span.EndKey,
+		StartTime:    since,
+		IntentPolicy: storage.MVCCIncrementalIterIntentPolicyIgnore,
+		ReadCategory: storage.RangeSnapshotReadCategory,
+	})
+	if err != nil {
+		return err
+	}
+	defer incIter.Close()
+	iter, err := reader.NewEngineIterator(ctx, storage.IterOptions{
+		KeyTypes:     storage.IterKeyTypePointsOnly,
+		LowerBound:   span.Key,
+		UpperBound:   span.EndKey,
+		ReadCategory: storage.RangeSnapshotReadCategory,
+	})
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	var key roachpb.Key
+	for incIter.SeekGE(storage.MVCCKey{Key: span.Key}); ; incIter.NextKey() {
+		if ok, err := incIter.Valid(); err != nil || !ok {
+			return err
+		}
+		key = append(key[:0], incIter.UnsafeKey().Key...)
+		ok, err := iter.SeekEngineKeyGE(storage.EngineKey{Key: key})
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.AssertionFailedf("no versions found for changed key %s", key)
+		}
+		if err := visitor(key, iter); err != nil {
+			return err
+		}
+	}
+}
@@ -32,10 +32,15 @@ import (
 //
 // initClosedTS is the closed timestamp carried by the split command. It will be
 // used to initialize the new RHS range.
+//
+// logReadWriter is non-nil iff the store keeps the raft state in a separate log
+// engine. It receives the raft state of the RHS, and is committed after the
+// ReadWriter.
 func splitPreApply(
 	ctx context.Context,
 	r *Replica,
 	readWriter storage.ReadWriter,
+	logReadWriter storage.ReadWriter,
 	split roachpb.SplitTrigger,
 	initClosedTS *hlc.Timestamp,
 ) {
@@ -124,8 +129,13 @@ func splitPreApply(
 			// Cleared the HardState and RaftReplicaID, so rewrite them to the current
 			// values. NB: rightRepl.raftMu is still locked since HardState was read,
 			// so it can't have been rewritten in the meantime (fixed in #75918).
-			if err := rightRepl.raftMu.stateLoader.SetHardState(ctx, readWriter, hs); err != nil {
-				log.Fatalf(ctx, "failed to set hard state with 0 commit index for removed rhs: %v", err)
+			//
+			// With a separate log engine, the HardState isn't in the ReadWriter and
+			// remains untouched.
+			if logReadWriter == nil {
+				if err := rightRepl.raftMu.stateLoader.SetHardState(ctx, readWriter, hs); err != nil {
+					log.Fatalf(ctx, "failed to set hard state with 0 commit index for removed rhs: %v", err)
+				}
 			}
 			if err := rightRepl.raftMu.stateLoader.SetRaftReplicaID(
 				ctx, readWriter, rightRepl.ReplicaID()); err != nil {
@@ -139,7 +149,11 @@ func splitPreApply(
 	// replica is initialized (combining it with existing or default
 	// Term and Vote). This is the common case.
 	rsl := stateloader.Make(split.RightDesc.RangeID)
-	if err := rsl.SynthesizeRaftState(ctx, readWriter); err != nil {
+	if logReadWriter == nil {
+		if err := rsl.SynthesizeRaftState(ctx, readWriter); err != nil {
+			log.Fatalf(ctx, "%v", err)
+		}
+	} else if err := splitPreApplyRaftLogEngine(ctx, rsl, readWriter, logReadWriter); err != nil {
 		log.Fatalf(ctx, "%v", err)
 	}
 	// Write the RaftReplicaID for the RHS to maintain the invariant that any
@@ -167,6 +181,39 @@ func splitPreApply(
 	}
 }
 
+// splitPreApplyRaftLogEngine moves the RaftTruncatedState staged for the RHS by
+// the split trigger from the state engine batch to the log engine batch, and
+// synthesizes the RHS HardState in the log engine batch. The existing HardState
+// of an uninitialized RHS is read from the log engine.
+func splitPreApplyRaftLogEngine(
+	ctx context.Context,
+	rsl stateloader.StateLoader,
+	readWriter storage.ReadWriter,
+	logReadWriter storage.ReadWriter,
+) error {
+	truncState, err := rsl.LoadRaftTruncatedState(ctx, readWriter)
+	if err != nil {
+		return err
+	}
+	as, err := rsl.LoadRangeAppliedState(ctx, readWriter)
+	if err != nil {
+		return err
+	}
+	if err := readWriter.ClearUnversioned(
+		rsl.RaftTruncatedStateKey(), storage.ClearOptions{},
+	); err != nil {
+		return err
+	}
+	hs, err := rsl.LoadHardState(ctx, logReadWriter)
+	if err != nil {
+		return err
+	}
+	if err := rsl.SetRaftTruncatedState(ctx, logReadWriter, &truncState); err != nil {
+		return err
+	}
+	return rsl.SynthesizeHardState(ctx, logReadWriter, hs, truncState, as.RaftAppliedIndex)
+}
+
 // splitPostApply is the part of the split trigger which coordinates the actual
 // split with the Store. Requires that Replica.raftMu is held. The deltaMS are
 // the MVCC stats which apply to the RHS and have already been removed from the
@@ -245,7 +292,7 @@ func prepareRightReplicaForSplit(
 	// Finish initialization of the RHS replica.
 
 	state, err := kvstorage.LoadReplicaState(
-		ctx, r.store.TODOEngine(), r.StoreID(), &split.RightDesc, rightRepl.replicaID)
+		ctx, r.store.engines(), r.StoreID(), &split.RightDesc, rightRepl.replicaID)
 	if err != nil {
 		log.Fatalf(ctx, "%v", err)
 	}
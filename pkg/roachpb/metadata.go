@@ -570,6 +570,7 @@ func (sc StoreCapacity) Load() load.Load {
 	dims := load.Vector{}
 	dims[load.Queries] = sc.QueriesPerSecond
 	dims[load.CPU] = sc.CPUPerSecond
+	dims[load.WriteBytes] = sc.WriteBytesPerSecond
 	return dims
 
 }
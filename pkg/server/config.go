@@ -701,13 +701,29 @@ func (e *Engines) Close() {
 	*e = nil
 }
 
-// CreateEngines creates Engines based on the specs in cfg.Stores.
-func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
+// RaftLogEngines maps the engines of the stores which keep the raft state of
+// their replicas in a separate engine to these raft log engines, see
+// base.StoreSpec.RaftLogPath.
+type RaftLogEngines map[storage.Engine]storage.Engine
+
+// Close closes all the raft log engines.
+func (e *RaftLogEngines) Close() {
+	for _, eng := range *e {
+		eng.Close()
+	}
+	*e = nil
+}
+
+// CreateEngines creates Engines based on the specs in cfg.Stores, and the
+// separate raft log engines of the stores which have a raft log path.
+func (cfg *Config) CreateEngines(ctx context.Context) (Engines, RaftLogEngines, error) {
 	var engines Engines
 	defer engines.Close()
+	var raftLogEngines RaftLogEngines
+	defer raftLogEngines.Close()
 
 	if cfg.enginesCreated {
-		return Engines{}, errors.Errorf("engines already created")
+		return Engines{}, nil, errors.Errorf("engines already created")
 	}
 	cfg.enginesCreated = true
 
@@ -728,7 +744,7 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 			base.ExternalIODirConfig{}, cfg.Settings, nil, cfg.User, nil,
 			nil, cloud.NilMetrics)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
@@ -740,7 +756,7 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 	}
 	openFileLimitPerStore, err := setOpenFileLimit(physicalStores)
 	if err != nil {
-		return Engines{}, err
+		return Engines{}, nil, err
 	}
 
 	log.Event(ctx, "initializing engines")
@@ -765,7 +781,7 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 
 	storeEnvs, err := fs.InitEnvsFromStoreSpecs(ctx, cfg.Stores.Specs, fs.ReadWrite, stickyRegistry)
 	if err != nil {
-		return Engines{}, err
+		return Engines{}, nil, err
 	}
 	defer storeEnvs.CloseAll()
 
@@ -782,6 +798,9 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 		if len(storeKnobs.EngineKnobs) > 0 {
 			storageConfigOpts = append(storageConfigOpts, storeKnobs.EngineKnobs...)
 		}
+		// The raft log engine only shares the caches and the limits with the
+		// store's engine.
+		raftLogConfigOpts := append([]storage.ConfigOption(nil), storeKnobs.EngineKnobs...)
 		addCfgOpt := func(opt storage.ConfigOption) {
 			storageConfigOpts = append(storageConfigOpts, opt)
 		}
@@ -791,16 +810,17 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 			if spec.Size.Percent > 0 {
 				sysMem, err := status.GetTotalMemory(ctx)
 				if err != nil {
-					return Engines{}, errors.Errorf("could not retrieve system memory")
+					return Engines{}, nil, errors.Errorf("could not retrieve system memory")
 				}
 				sizeInBytes = int64(float64(sysMem) * spec.Size.Percent / 100)
 			}
 			if sizeInBytes != 0 && !storeKnobs.SkipMinSizeCheck && sizeInBytes < base.MinimumStoreSize {
-				return Engines{}, errors.Errorf("%f%% of memory is only %s bytes, which is below the minimum requirement of %s",
+				return Engines{}, nil, errors.Errorf("%f%% of memory is only %s bytes, which is below the minimum requirement of %s",
 					spec.Size.Percent, humanizeutil.IBytes(sizeInBytes), humanizeutil.IBytes(base.MinimumStoreSize))
 			}
 			addCfgOpt(storage.MaxSize(sizeInBytes))
 			addCfgOpt(storage.CacheSize(cfg.CacheSize))
+			raftLogConfigOpts = append(raftLogConfigOpts, storage.CacheSize(cfg.CacheSize))
 			addCfgOpt(storage.RemoteStorageFactory(cfg.EarlyBootExternalStorageAccessor))
 
 			detail(redact.Sprintf("store %d: in-memory, size %s", i, humanizeutil.IBytes(sizeInBytes)))
@@ -810,14 +830,14 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 			// data directory if it didn't already exist.
 			du, err := storeEnvs[i].UnencryptedFS.GetDiskUsage(spec.Path)
 			if err != nil {
-				return Engines{}, errors.Wrap(err, "retrieving disk usage")
+				return Engines{}, nil, errors.Wrap(err, "retrieving disk usage")
 			}
 			var sizeInBytes = spec.Size.InBytes
 			if spec.Size.Percent > 0 {
 				sizeInBytes = int64(float64(du.TotalBytes) * spec.Size.Percent / 100)
 			}
 			if sizeInBytes != 0 && !storeKnobs.SkipMinSizeCheck && sizeInBytes < base.MinimumStoreSize {
-				return Engines{}, errors.Errorf("%f%% of %s's total free space is only %s bytes, which is below the minimum requirement of %s",
+				return Engines{}, nil, errors.Errorf("%f%% of %s's total free space is only %s bytes, which is below the minimum requirement of %s",
 					spec.Size.Percent, spec.Path, humanizeutil.IBytes(sizeInBytes), humanizeutil.IBytes(base.MinimumStoreSize))
 			}
 
@@ -829,6 +849,11 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 			// TODO(radu): move up all remaining settings below so they apply to in-memory stores as well.
 			addCfgOpt(storage.MaxOpenFiles(int(openFileLimitPerStore)))
 			addCfgOpt(storage.MaxWriterConcurrency(2))
+			raftLogConfigOpts = append(raftLogConfigOpts,
+				storage.Caches(pebbleCache, tableCache),
+				storage.MaxOpenFiles(int(openFileLimitPerStore)),
+				storage.MaxWriterConcurrency(2),
+			)
 			addCfgOpt(storage.RemoteStorageFactory(cfg.EarlyBootExternalStorageAccessor))
 			if sharedStorage != nil {
 				addCfgOpt(storage.SharedStorage(sharedStorage))
@@ -849,12 +874,12 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 				}))
 			}
 			if len(spec.RocksDBOptions) > 0 {
-				return nil, errors.Errorf("store %d: using Pebble storage engine but StoreSpec provides RocksDB options", i)
+				return nil, nil, errors.Errorf("store %d: using Pebble storage engine but StoreSpec provides RocksDB options", i)
 			}
 		}
 		eng, err := storage.Open(ctx, storeEnvs[i], cfg.Settings, storageConfigOpts...)
 		if err != nil {
-			return Engines{}, err
+			return Engines{}, nil, err
 		}
 		// Nil out the store env; the engine has taken responsibility for Closing
 		// it.
@@ -863,12 +888,24 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 		storeEnvs[i] = nil
 		detail(redact.Sprintf("store %d: %s", i, eng.Properties()))
 		engines = append(engines, eng)
+
+		if spec.RaftLogPath != "" {
+			logEngine, err := openRaftLogEngine(ctx, spec, cfg.Settings, stickyRegistry, raftLogConfigOpts)
+			if err != nil {
+				return Engines{}, nil, errors.Wrapf(err, "store %d: opening raft log engine", i)
+			}
+			if raftLogEngines == nil {
+				raftLogEngines = RaftLogEngines{}
+			}
+			raftLogEngines[eng] = logEngine
+			detail(redact.Sprintf("store %d: raft log engine at %s", i, spec.RaftLogPath))
+		}
 	}
 
 	if tableCache != nil {
 		// Unref the table cache now that the engines hold references to it.
 		if err := tableCache.Unref(); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
@@ -879,9 +916,30 @@ func (cfg *Config) CreateEngines(ctx context.Context) (Engines, error) {
 	}
 
 	// Clear out engines because we have deferred engines.Close().
-	enginesCopy := engines
-	engines = nil
-	return enginesCopy, nil
+	enginesCopy, raftLogEnginesCopy := engines, raftLogEngines
+	engines, raftLogEngines = nil, nil
+	return enginesCopy, raftLogEnginesCopy, nil
+}
+
+// openRaftLogEngine opens the separate raft log engine of the store with the
+// given spec.
+func openRaftLogEngine(
+	ctx context.Context,
+	spec base.StoreSpec,
+	st *cluster.Settings,
+	stickyRegistry fs.StickyRegistry,
+	opts []storage.ConfigOption,
+) (storage.Engine, error) {
+	env, err := fs.InitRaftLogEnvFromStoreSpec(ctx, spec, fs.ReadWrite, stickyRegistry)
+	if err != nil {
+		return nil, err
+	}
+	eng, err := storage.Open(ctx, env, st, opts...)
+	if err != nil {
+		env.Close()
+		return nil, err
+	}
+	return eng, nil
 }
 
 // InitSQLServer finalizes the configuration of a SQL-only node.
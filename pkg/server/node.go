@@ -382,6 +382,9 @@ type Node struct {
 	initialStart bool // true if this is the first time this node has started
 	txnMetrics   kvcoord.TxnMetrics
 
+	// raftLogEngines are the separate raft log engines of the stores, if any.
+	raftLogEngines RaftLogEngines
+
 	// Used to signal when additional stores, if any, have been initialized.
 	additionalStoreInitCh chan struct{}
 
@@ -558,6 +561,7 @@ func NewNode(
 	stopper *stop.Stopper,
 	txnMetrics kvcoord.TxnMetrics,
 	stores *kvserver.Stores,
+	raftLogEngines RaftLogEngines,
 	clusterID *base.ClusterIDContainer,
 	kvAdmissionQ *admission.WorkQueue,
 	elasticCPUGrantCoord *admission.ElasticCPUGrantCoordinator,
@@ -575,6 +579,7 @@ func NewNode(
 		recorder:              recorder,
 		metrics:               makeNodeMetrics(reg, cfg.HistogramWindowInterval),
 		stores:                stores,
+		raftLogEngines:        raftLogEngines,
 		txnMetrics:            txnMetrics,
 		execCfg:               nil, // filled in later by InitLogger()
 		clusterID:             clusterID,
@@ -592,6 +597,15 @@ func NewNode(
 	return n
 }
 
+// storeEngines returns the engines of the store with the given engine, which
+// include its separate raft log engine, if any.
+func (n *Node) storeEngines(eng storage.Engine) kvstorage.Engines {
+	if logEngine, ok := n.raftLogEngines[eng]; ok {
+		return kvstorage.MakeSeparatedEngines(eng, logEngine)
+	}
+	return kvstorage.MakeEngines(eng)
+}
+
 // InitLogger connects the Node to the Executor to be used for event
 // logging.
 func (n *Node) InitLogger(execCfg *sql.ExecutorConfig) {
@@ -677,7 +691,7 @@ func (n *Node) start(
 			stop.TaskOpts{TaskName: "initialize-stores", SpanOpt: stop.FollowsFromSpan, Sem: sem, WaitForSem: true},
 			func(ctx context.Context) {
 				start := timeutil.Now()
-				s := kvserver.NewStore(ctx, n.storeCfg, engine, &n.Descriptor)
+				s := kvserver.NewStoreWithEngines(ctx, n.storeCfg, n.storeEngines(engine), &n.Descriptor)
 				if err := s.Start(workersCtx, n.stopper); err != nil {
 					engineErrC <- errors.Wrap(err, "failed to start store")
 					return
@@ -908,7 +922,7 @@ func (n *Node) initializeAdditionalStores(
 				return err
 			}
 
-			s := kvserver.NewStore(ctx, n.storeCfg, eng, &n.Descriptor)
+			s := kvserver.NewStoreWithEngines(ctx, n.storeCfg, n.storeEngines(eng), &n.Descriptor)
 			if err := s.Start(ctx, stopper); err != nil {
 				return err
 			}
@@ -88,6 +88,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/gcjob/gcjobnotifier"
 	"github.com/cockroachdb/cockroach/pkg/sql/idxusage"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/notify"
 	"github.com/cockroachdb/cockroach/pkg/sql/optionalnodeliveness"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire"
 	"github.com/cockroachdb/cockroach/pkg/sql/querycache"
@@ -919,6 +920,7 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 		cfg.sqlStatusServer.TxnIDResolution,
 		&contentionMetrics,
 	)
+	notificationRegistry := notify.NewRegistry(cfg.sqlStatusServer.NotifyListeners)
 
 	if !cfg.Insecure {
 		certMgr, err := cfg.rpcContext.SecurityContext.GetCertificateManager()
@@ -956,6 +958,7 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 		SessionRegistry:         cfg.sessionRegistry,
 		ClosedSessionCache:      cfg.closedSessionCache,
 		ContentionRegistry:      contentionRegistry,
+		NotificationRegistry:    notificationRegistry,
 		SQLLiveness:             cfg.sqlLivenessProvider,
 		JobRegistry:             jobRegistry,
 		VirtualSchemas:          virtualSchemas,
@@ -1477,6 +1480,7 @@ func (s *SQLServer) preStart(
 	s.leaseMgr.SetRegionPrefix(regionPhysicalRep)
 
 	s.execCfg.ContentionRegistry.Start(ctx, stopper)
+	s.execCfg.NotificationRegistry.Start(ctx, stopper)
 
 	// Start the sql liveness subsystem. We'll need it to get a session.
 	s.sqlLivenessProvider.Start(ctx, regionPhysicalRep)
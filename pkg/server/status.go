@@ -58,6 +58,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server/srverrors"
 	"github.com/cockroachdb/cockroach/pkg/server/status/statuspb"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/spanconfig"
 	"github.com/cockroachdb/cockroach/pkg/sql"
@@ -69,6 +70,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/contentionpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/flowinfra"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/notify"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
@@ -3584,6 +3586,84 @@ func (s *statusServer) ListExecutionInsights(
 	return &response, nil
 }
 
+// notifyListenersNodeTimeout bounds the time spent delivering notifications to
+// a single node. Notifications are broadcast in order by a single task on each
+// node, so a node which doesn't respond must not hold up the delivery of the
+// notifications which follow to the other nodes.
+var notifyListenersNodeTimeout = settings.RegisterDurationSetting(
+	settings.ApplicationLevel,
+	"server.notify_listeners.node.timeout",
+	"the duration allowed for a single node to receive the notifications sent"+
+		" with NOTIFY before they are dropped for that node; if set to 0, there is no timeout",
+	10*time.Second,
+	settings.NonNegativeDuration,
+)
+
+// NotifyListeners delivers the notifications sent with NOTIFY or pg_notify to
+// the sessions listening on their channels. If no node is specified, the
+// notifications are delivered to the listeners on all nodes.
+func (s *statusServer) NotifyListeners(
+	ctx context.Context, req *serverpb.NotifyListenersRequest,
+) (*serverpb.NotifyListenersResponse, error) {
+	ctx = s.AnnotateCtx(authserver.ForwardSQLIdentityThroughRPCCalls(ctx))
+	if err := s.privilegeChecker.RequireRepairClusterPermission(ctx); err != nil {
+		// NB: not using srverrors.ServerError() here since the priv checker
+		// already returns a proper gRPC error status.
+		return nil, err
+	}
+
+	response := &serverpb.NotifyListenersResponse{}
+	localReq := &serverpb.NotifyListenersRequest{
+		NodeID:        "local",
+		Notifications: req.Notifications,
+	}
+
+	if len(req.NodeID) > 0 {
+		requestedNodeID, local, err := s.parseNodeID(req.NodeID)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		if local {
+			notifications := make([]notify.Notification, len(req.Notifications))
+			for i, n := range req.Notifications {
+				notifications[i] = notify.Notification{
+					Database:  n.Database,
+					Channel:   n.Channel,
+					Payload:   n.Payload,
+					SenderPID: n.SenderPID,
+				}
+			}
+			s.sqlServer.execCfg.NotificationRegistry.Deliver(notifications)
+			return response, nil
+		}
+		statusClient, err := s.dialNode(ctx, requestedNodeID)
+		if err != nil {
+			return nil, err
+		}
+		return statusClient.NotifyListeners(ctx, localReq)
+	}
+
+	notifyListeners := func(ctx context.Context, status serverpb.StatusClient, _ roachpb.NodeID) (interface{}, error) {
+		return status.NotifyListeners(ctx, localReq)
+	}
+
+	var fanoutError error
+	if err := iterateNodes(ctx, s.serverIterator, s.stopper, "notify listeners",
+		notifyListenersNodeTimeout.Get(&s.st.SV),
+		s.dialNode,
+		notifyListeners,
+		func(nodeID roachpb.NodeID, resp interface{}) {
+			// Nothing to do here.
+		},
+		func(nodeID roachpb.NodeID, nodeFnError error) {
+			fanoutError = errors.CombineErrors(fanoutError, nodeFnError)
+		},
+	); err != nil {
+		return nil, err
+	}
+	return response, fanoutError
+}
+
 // SpanStats requests the total statistics stored on a node for a given key
 // span, which may include multiple ranges.
 func (s *statusServer) SpanStats(
@@ -95,6 +95,10 @@ func (p *planner) AlterTable(ctx context.Context, n *tree.AlterTable) (planNode,
 	if tableDesc == nil {
 		return newZeroNode(nil /* columns */), nil
 	}
+	if tableDesc.IsForeignTable() {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"ALTER TABLE is not supported on foreign table %q", tableDesc.GetName())
+	}
 
 	// This check for CREATE privilege is kept for backwards compatibility.
 	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
@@ -197,6 +201,9 @@ func (n *alterTableNode) startExec(params runParams) error {
 						"UNIQUE WITHOUT INDEX constraint on the column",
 				)
 			}
+			if t.ColumnDef.Unique.Deferrability != tree.ConstraintNotDeferrable {
+				return sqlerrors.NewAddColumnDeferrableUniqueError()
+			}
 			if t.ColumnDef.PrimaryKey.IsPrimaryKey {
 				return pgerror.Newf(pgcode.InvalidColumnDefinition,
 					"multiple primary keys for table %q are not allowed", tn.Object())
@@ -233,6 +240,10 @@ func (n *alterTableNode) startExec(params runParams) error {
 					continue
 				}
 
+				if d.PrimaryKey && d.Deferrability != tree.ConstraintNotDeferrable {
+					return sqlerrors.NewDeferrablePrimaryKeyError()
+				}
+
 				if d.PrimaryKey {
 					if t.ValidationBehavior == tree.ValidationSkip {
 						return sqlerrors.NewUnsupportedUnvalidatedConstraintError(catconstants.ConstraintTypePK)
@@ -302,7 +313,7 @@ func (n *alterTableNode) startExec(params runParams) error {
 				}
 				idx := descpb.IndexDescriptor{
 					Name:             string(d.Name),
-					Unique:           true,
+					Unique:           d.Deferrability == tree.ConstraintNotDeferrable,
 					NotVisible:       d.Invisibility.Value != 0.0,
 					Invisibility:     d.Invisibility.Value,
 					StoreColumnNames: d.Storing.ToStrings(),
@@ -337,6 +348,15 @@ func (n *alterTableNode) startExec(params runParams) error {
 					return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
 						"index %q being dropped, try again later", d.Name)
 				}
+				var deferrableConstraint deferrableUniqueIndexConstraint
+				if !idx.Unique {
+					deferrableConstraint, err = makeDeferrableUniqueIndexConstraint(
+						n.tableDesc, &idx, d.Columns, d.Deferrability, activeVersion,
+					)
+					if err != nil {
+						return err
+					}
+				}
 				if err := n.tableDesc.AddIndexMutationMaybeWithTempIndex(
 					&idx, descpb.DescriptorMutation_ADD,
 				); err != nil {
@@ -349,6 +369,11 @@ func (n *alterTableNode) startExec(params runParams) error {
 				if err := n.tableDesc.AllocateIDs(params.ctx, version); err != nil {
 					return err
 				}
+				if !idx.Unique {
+					if err := deferrableConstraint.add(params.ctx, n.tableDesc, NonEmptyTable); err != nil {
+						return err
+					}
+				}
 				if err := params.p.configureZoneConfigForNewIndexPartitioning(
 					params.ctx,
 					n.tableDesc,
@@ -389,6 +414,20 @@ func (n *alterTableNode) startExec(params runParams) error {
 					return err
 				}
 
+			case *tree.ExcludeConstraintTableDef:
+				if err := addExcludeConstraintTableDef(
+					params.ctx,
+					params.EvalContext(),
+					d,
+					n.tableDesc,
+					*tn,
+					NonEmptyTable,
+					t.ValidationBehavior,
+					params.p.SemaCtx(),
+				); err != nil {
+					return err
+				}
+
 			case *tree.ForeignKeyConstraintTableDef:
 				// We want to reject uses of FK ON UPDATE actions where there is already
 				// an ON UPDATE expression for the column.
@@ -530,12 +569,16 @@ func (n *alterTableNode) startExec(params runParams) error {
 				}
 				return sqlerrors.NewUndefinedConstraintError(string(t.Constraint), n.tableDesc.Name)
 			}
+			// The index of a DEFERRABLE unique constraint declared with an index
+			// is dropped along with the constraint.
+			var uniqueIndexID descpb.IndexID
 			if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
 				if err := params.p.tryRemoveFKBackReferences(
 					params.ctx, n.tableDesc, uwoi, t.DropBehavior, true,
 				); err != nil {
 					return err
 				}
+				uniqueIndexID = uwoi.UniqueWithoutIndexDesc().IndexID
 			}
 			if err := n.tableDesc.DropConstraint(
 				c,
@@ -552,6 +595,16 @@ func (n *alterTableNode) startExec(params runParams) error {
 			if err := validateDescriptor(params.ctx, params.p, n.tableDesc); err != nil {
 				return err
 			}
+			if uniqueIndexID != 0 {
+				if idx := catalog.FindIndexByID(n.tableDesc, uniqueIndexID); idx != nil {
+					if err := params.p.dropIndexByName(
+						params.ctx, tn, tree.UnrestrictedName(idx.GetName()), n.tableDesc, true, /* ifExists */
+						t.DropBehavior, ignoreIdxConstraint, tree.AsStringWithFQNames(n.n, params.Ann()),
+					); err != nil {
+						return err
+					}
+				}
+			}
 
 		case *tree.AlterTableValidateConstraint:
 			name := string(t.Constraint)
@@ -602,6 +655,39 @@ func (n *alterTableNode) startExec(params runParams) error {
 			}
 			descriptorChanged = true
 
+		case *tree.AlterTableAlterConstraint:
+			c := catalog.FindConstraintByName(n.tableDesc, string(t.Constraint))
+			if c == nil {
+				return sqlerrors.NewUndefinedConstraintError(string(t.Constraint), n.tableDesc.Name)
+			}
+			switch c.GetConstraintValidity() {
+			case descpb.ConstraintValidity_Validating:
+				return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+					"constraint %q in the middle of being added, try again later", t.Constraint)
+			case descpb.ConstraintValidity_Dropping:
+				return sqlerrors.NewUndefinedConstraintError(string(t.Constraint), n.tableDesc.Name)
+			}
+			deferrable := t.Deferrability != tree.ConstraintNotDeferrable
+			initiallyDeferred := t.Deferrability == tree.ConstraintInitiallyDeferred
+			if fk := c.AsForeignKey(); fk != nil {
+				fkDesc := fk.ForeignKeyDesc()
+				fkDesc.Deferrable = deferrable
+				fkDesc.InitiallyDeferred = initiallyDeferred
+				if err := params.p.updateFKBackReferenceDeferrability(
+					params.ctx, n.tableDesc, fkDesc,
+				); err != nil {
+					return err
+				}
+			} else if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+				uwoi.UniqueWithoutIndexDesc().Deferrable = deferrable
+				uwoi.UniqueWithoutIndexDesc().InitiallyDeferred = initiallyDeferred
+			} else {
+				return pgerror.Newf(pgcode.WrongObjectType,
+					"constraint %q of relation %q is not a foreign key or unique without index"+
+						" constraint", tree.ErrString(&t.Constraint), tree.ErrString(n.n.Table))
+			}
+			descriptorChanged = true
+
 		case tree.ColumnMutationCmd:
 			// Column mutations
 			tableDesc := n.tableDesc
@@ -1607,6 +1693,9 @@ func validateConstraintNameIsNotUsed(
 	case *tree.ForeignKeyConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
+	case *tree.ExcludeConstraintTableDef:
+		name = d.Name
+		hasIfNotExists = d.IfNotExists
 	case *tree.UniqueConstraintTableDef:
 		name = d.Name
 		hasIfNotExists = d.IfNotExists
@@ -1735,6 +1824,44 @@ func (p *planner) updateFKBackReferenceName(
 	return errors.Errorf("missing backreference for foreign key %s", ref.Name)
 }
 
+// updateFKBackReferenceDeferrability updates the deferrability of the inbound
+// reference to the given outbound foreign key on the referenced table.
+func (p *planner) updateFKBackReferenceDeferrability(
+	ctx context.Context, tableDesc *tabledesc.Mutable, ref *descpb.ForeignKeyConstraint,
+) error {
+	var referencedTableDesc *tabledesc.Mutable
+	// We don't want to lookup/edit a second copy of the same table.
+	if tableDesc.ID == ref.ReferencedTableID {
+		referencedTableDesc = tableDesc
+	} else {
+		lookup, err := p.Descriptors().MutableByID(p.txn).Table(ctx, ref.ReferencedTableID)
+		if err != nil {
+			return errors.Wrapf(err, "error resolving referenced table ID %d", ref.ReferencedTableID)
+		}
+		referencedTableDesc = lookup
+	}
+	if referencedTableDesc.Dropped() {
+		// The referenced table is being dropped. No need to modify it further.
+		return nil
+	}
+	for i := range referencedTableDesc.InboundFKs {
+		backref := &referencedTableDesc.InboundFKs[i]
+		if backref.Name == ref.Name && backref.OriginTableID == tableDesc.ID {
+			backref.Deferrable = ref.Deferrable
+			backref.InitiallyDeferred = ref.InitiallyDeferred
+			if referencedTableDesc == tableDesc {
+				return nil
+			}
+			return p.writeSchemaChange(
+				ctx, referencedTableDesc, descpb.InvalidMutationID,
+				fmt.Sprintf("updating referenced FK table %s(%d) for table %s(%d)",
+					referencedTableDesc.Name, referencedTableDesc.ID, tableDesc.Name, tableDesc.ID),
+			)
+		}
+	}
+	return errors.Errorf("missing backreference for foreign key %s", ref.Name)
+}
+
 func dropColumnImpl(
 	params runParams,
 	tn *tree.TableName,
@@ -1607,6 +1607,15 @@ func ValidateConstraint(
 			return txn.WithSyntheticDescriptors(
 				[]catalog.Descriptor{tableDesc},
 				func() error {
+					if ucDesc := uwi.UniqueWithoutIndexDesc(); ucDesc.IsExclusion() {
+						return validateExclusionConstraint(
+							ctx, tableDesc, ucDesc,
+							indexIDForValidation,
+							txn,
+							sessionData.User(),
+							false, /* preExisting */
+						)
+					}
 					return validateUniqueConstraint(
 						ctx, tableDesc, uwi.GetName(),
 						uwi.CollectKeyColumnIDs().Ordered(),
@@ -1889,6 +1898,11 @@ func ValidateForwardIndexes(
 
 	// Compute the size of each index.
 	for _, idx := range indexes {
+		// The entries of a BRIN index only record the rows which are not yet
+		// summarized, so their number does not match the number of rows.
+		if idx.GetAccessMethod() == descpb.IndexDescriptor_BRIN {
+			continue
+		}
 		// Shadow idx to prevent its value from changing within each gorountine.
 		idx := idx
 		grp.GoCtx(func(ctx context.Context) error {
@@ -2813,6 +2827,17 @@ func validateUniqueWithoutIndexConstraintInTxn(
 	return txn.WithSyntheticDescriptors(
 		syntheticDescs,
 		func() error {
+			if uc.IsExclusion() {
+				return validateExclusionConstraint(
+					ctx,
+					tableDesc,
+					uc,
+					0, /* indexIDForValidation */
+					txn,
+					user,
+					false, /* preExisting */
+				)
+			}
 			return validateUniqueConstraint(
 				ctx,
 				tableDesc,
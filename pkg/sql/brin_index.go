@@ -0,0 +1,650 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/fetchpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/constraint"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+)
+
+// BRIN indexes summarize the values of their columns in block ranges, which
+// are runs of consecutive rows of the primary index. The summary of a column
+// in a block range is the minimum and maximum of its non-NULL values, and
+// whether it has NULL values. Scans of the primary index skip the block ranges
+// whose summaries show that they cannot contain rows satisfying the filters of
+// the scan.
+//
+// A BRIN index is stored like a secondary index whose first key column is a
+// hidden virtual computed column with a constant value, brinUnsummarizedKey,
+// followed by the indexed columns. The index entries written by the usual
+// index maintenance therefore record the rows which were written since they
+// were last summarized. The summaries are stored after those entries in the
+// index span, keyed by brinSummaryKey and the primary key at which the block
+// range starts. The block ranges cover the whole primary index: the first one
+// starts at its beginning, and each one ends where the next one starts.
+//
+// The rows which are not yet summarized are summarized by
+// brin_summarize_new_values, which summarizes again the block ranges
+// containing them, splits these block ranges into block ranges of at most
+// sql.index.brin.rows_per_range rows, and removes the index entries of the
+// rows. Until then, scans read the rows which are not summarized in addition
+// to the block ranges which match their filters; if there are more than
+// sql.index.brin.max_unsummarized_rows such rows, scans read the whole table.
+//
+// The summaries are read when the table readers of a scan are planned, right
+// before the scan is executed, see constrainSpansWithBRINIndexes.
+//
+// Unlike in Postgres, the block ranges are not tied to the physical layout of
+// the table, and new rows are not summarized automatically: CREATE INDEX warns
+// about it, and brin_summarize_new_values must be run after writing to the
+// table.
+
+const (
+	// brinUnsummarizedKey is the value of the first key column of the index
+	// entries of a BRIN index, which record the rows not yet summarized.
+	brinUnsummarizedKey = 0
+	// brinSummaryKey prefixes the summaries of a BRIN index.
+	brinSummaryKey = 1
+)
+
+var brinRowsPerRange = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"sql.index.brin.rows_per_range",
+	"the maximum number of rows in the block ranges created when summarizing BRIN indexes",
+	1024,
+	settings.PositiveInt,
+)
+
+var brinMaxUnsummarizedRows = settings.RegisterIntSetting(
+	settings.ApplicationLevel,
+	"sql.index.brin.max_unsummarized_rows",
+	"the maximum number of rows not yet summarized by a BRIN index for scans to use it; "+
+		"rows are only summarized by brin_summarize_new_values",
+	10000,
+	settings.NonNegativeInt,
+)
+
+// brinBlockRange is a block range of a BRIN index, with the summaries of the
+// indexed columns.
+type brinBlockRange struct {
+	// start is the key of the primary index at which the block range starts,
+	// without the index prefix.
+	start roachpb.Key
+	cols  []brinColumnSummary
+}
+
+// brinColumnSummary is the summary of the values of a column in a block
+// range.
+type brinColumnSummary struct {
+	// min and max are the smallest and largest non-NULL values, or nil if
+	// there are none.
+	min, max tree.Datum
+	hasNulls bool
+}
+
+// add adds the given value to the summary.
+func (s *brinColumnSummary) add(evalCtx *eval.Context, d tree.Datum) error {
+	if d == tree.DNull {
+		s.hasNulls = true
+		return nil
+	}
+	if s.min == nil {
+		s.min, s.max = d, d
+		return nil
+	}
+	if cmp, err := d.CompareError(evalCtx, s.min); err != nil {
+		return err
+	} else if cmp < 0 {
+		s.min = d
+	}
+	if cmp, err := d.CompareError(evalCtx, s.max); err != nil {
+		return err
+	} else if cmp > 0 {
+		s.max = d
+	}
+	return nil
+}
+
+// mayMatch returns whether the column may have values satisfying the given
+// constraint in the block range.
+func (s *brinColumnSummary) mayMatch(evalCtx *eval.Context, c *constraint.Constraint) bool {
+	keyCtx := constraint.MakeKeyContext(&c.Columns, evalCtx)
+	intersects := func(min, max tree.Datum) bool {
+		var sp constraint.Span
+		sp.Init(constraint.MakeKey(min), constraint.IncludeBoundary, constraint.MakeKey(max), constraint.IncludeBoundary)
+		for i, n := 0, c.Spans.Count(); i < n; i++ {
+			if intersection := sp; intersection.TryIntersectWith(&keyCtx, c.Spans.Get(i)) {
+				return true
+			}
+		}
+		return false
+	}
+	return (s.hasNulls && intersects(tree.DNull, tree.DNull)) ||
+		(s.min != nil && intersects(s.min, s.max))
+}
+
+// brinIndexColumns returns the columns summarized by the given BRIN index.
+func brinIndexColumns(desc catalog.TableDescriptor, idx catalog.Index) ([]catalog.Column, error) {
+	cols := make([]catalog.Column, idx.NumKeyColumns()-1)
+	for i := range cols {
+		col, err := catalog.MustFindColumnByID(desc, idx.GetKeyColumnID(i+1))
+		if err != nil {
+			return nil, err
+		}
+		cols[i] = col
+	}
+	return cols, nil
+}
+
+// brinKeyPrefix returns the prefix of the keys of the given BRIN index with
+// the given value of the first key column.
+func brinKeyPrefix(
+	codec keys.SQLCodec, desc catalog.TableDescriptor, idx catalog.Index, key int64,
+) roachpb.Key {
+	prefix := rowenc.MakeIndexKeyPrefix(codec, desc.GetID(), idx.GetID())
+	return encoding.EncodeVarintAscending(prefix, key)
+}
+
+// appendKey returns a new key made of the given prefix and suffix.
+func appendKey(prefix, suffix []byte) roachpb.Key {
+	key := make(roachpb.Key, 0, len(prefix)+len(suffix))
+	return append(append(key, prefix...), suffix...)
+}
+
+// encodeBRINSummaries encodes the given column summaries as the value of the
+// summary of a block range.
+func encodeBRINSummaries(cols []brinColumnSummary) ([]byte, error) {
+	var b []byte
+	for i := range cols {
+		var err error
+		b, err = valueside.Encode(b, valueside.NoColumnID, tree.MakeDBool(tree.DBool(cols[i].hasNulls)), nil /* scratch */)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range []tree.Datum{cols[i].min, cols[i].max} {
+			if d == nil {
+				d = tree.DNull
+			}
+			if b, err = valueside.Encode(b, valueside.NoColumnID, d, nil /* scratch */); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return b, nil
+}
+
+// decodeBRINSummaries decodes the summaries of the given columns encoded by
+// encodeBRINSummaries.
+func decodeBRINSummaries(
+	a *tree.DatumAlloc, cols []catalog.Column, b []byte,
+) ([]brinColumnSummary, error) {
+	res := make([]brinColumnSummary, len(cols))
+	for i, col := range cols {
+		var hasNulls tree.Datum
+		var err error
+		if hasNulls, b, err = valueside.Decode(a, types.Bool, b); err != nil {
+			return nil, err
+		}
+		res[i].hasNulls = bool(tree.MustBeDBool(hasNulls))
+		for _, d := range []*tree.Datum{&res[i].min, &res[i].max} {
+			if *d, b, err = valueside.Decode(a, col.GetType(), b); err != nil {
+				return nil, err
+			}
+			if *d == tree.DNull {
+				*d = nil
+			}
+		}
+	}
+	return res, nil
+}
+
+// readBRINBlockRanges reads the block ranges of the given BRIN index, in
+// order.
+func readBRINBlockRanges(
+	ctx context.Context,
+	txn *kv.Txn,
+	codec keys.SQLCodec,
+	desc catalog.TableDescriptor,
+	idx catalog.Index,
+	cols []catalog.Column,
+) ([]brinBlockRange, error) {
+	prefix := brinKeyPrefix(codec, desc, idx, brinSummaryKey)
+	kvs, err := txn.Scan(ctx, prefix, prefix.PrefixEnd(), 0 /* maxRows */)
+	if err != nil {
+		return nil, err
+	}
+	var a tree.DatumAlloc
+	ranges := make([]brinBlockRange, len(kvs))
+	for i := range kvs {
+		b, err := kvs[i].Value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		summaries, err := decodeBRINSummaries(&a, cols, b)
+		if err != nil {
+			return nil, err
+		}
+		ranges[i] = brinBlockRange{start: kvs[i].Key[len(prefix):], cols: summaries}
+	}
+	return ranges, nil
+}
+
+// newPrimaryKeyFetcher returns a fetcher of the given columns of the given
+// index, and a map from the IDs of the primary key columns and of the given
+// columns to their ordinal in the fetched rows. The fetched columns are the
+// primary key columns followed by the given columns which are not part of the
+// primary key.
+func newPrimaryKeyFetcher(
+	ctx context.Context,
+	txn *kv.Txn,
+	codec keys.SQLCodec,
+	desc catalog.TableDescriptor,
+	idx catalog.Index,
+	cols []catalog.Column,
+) (*row.Fetcher, catalog.TableColMap, error) {
+	var colMap catalog.TableColMap
+	fetchColumnIDs := append([]descpb.ColumnID(nil), desc.GetPrimaryIndex().IndexDesc().KeyColumnIDs...)
+	for i, id := range fetchColumnIDs {
+		colMap.Set(id, i)
+	}
+	for _, col := range cols {
+		if _, ok := colMap.Get(col.GetID()); !ok {
+			colMap.Set(col.GetID(), len(fetchColumnIDs))
+			fetchColumnIDs = append(fetchColumnIDs, col.GetID())
+		}
+	}
+	var spec fetchpb.IndexFetchSpec
+	if err := rowenc.InitIndexFetchSpec(&spec, codec, desc, idx, fetchColumnIDs); err != nil {
+		return nil, catalog.TableColMap{}, err
+	}
+	var fetcher row.Fetcher
+	if err := fetcher.Init(ctx, row.FetcherInitArgs{
+		Txn:   txn,
+		Alloc: &tree.DatumAlloc{},
+		Spec:  &spec,
+	}); err != nil {
+		return nil, catalog.TableColMap{}, err
+	}
+	return &fetcher, colMap, nil
+}
+
+// readBRINUnsummarizedRows reads the keys of the rows which are not yet
+// summarized by the given BRIN index, in order and without the primary index
+// prefix. If limit is not negative and there are more than limit such rows,
+// it returns ok=false.
+func readBRINUnsummarizedRows(
+	ctx context.Context,
+	txn *kv.Txn,
+	codec keys.SQLCodec,
+	desc catalog.TableDescriptor,
+	idx catalog.Index,
+	limit int,
+) (_ []roachpb.Key, ok bool, _ error) {
+	fetcher, colMap, err := newPrimaryKeyFetcher(ctx, txn, codec, desc, idx, nil /* cols */)
+	if err != nil {
+		return nil, false, err
+	}
+	defer fetcher.Close(ctx)
+	prefix := brinKeyPrefix(codec, desc, idx, brinUnsummarizedKey)
+	if err := fetcher.StartScan(
+		ctx,
+		roachpb.Spans{{Key: prefix, EndKey: prefix.PrefixEnd()}},
+		nil, /* spanIDs */
+		rowinfra.GetDefaultBatchBytesLimit(false /* forceProductionValue */),
+		rowinfra.NoRowLimit,
+	); err != nil {
+		return nil, false, err
+	}
+	var rowKeys []roachpb.Key
+	for {
+		datums, err := fetcher.NextRowDecoded(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		if datums == nil {
+			break
+		}
+		if limit >= 0 && len(rowKeys) == limit {
+			return nil, false, nil
+		}
+		key, _, err := rowenc.EncodeIndexKey(desc, desc.GetPrimaryIndex(), colMap, datums, nil /* keyPrefix */)
+		if err != nil {
+			return nil, false, err
+		}
+		rowKeys = append(rowKeys, key)
+	}
+	// The index entries are ordered by the values of the indexed columns first.
+	sort.Slice(rowKeys, func(i, j int) bool { return rowKeys[i].Compare(rowKeys[j]) < 0 })
+	return rowKeys, true, nil
+}
+
+// summarizeBRINBlockRange summarizes the rows of the block range of the given
+// BRIN index which starts at the given key and covers the given span of the
+// primary index. The rows are split into block ranges of at most rowsPerRange
+// rows, the first of which starts at the same key as the original block range.
+func summarizeBRINBlockRange(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	txn *kv.Txn,
+	codec keys.SQLCodec,
+	desc catalog.TableDescriptor,
+	cols []catalog.Column,
+	start roachpb.Key,
+	span roachpb.Span,
+	rowsPerRange int64,
+) ([]brinBlockRange, error) {
+	primary := desc.GetPrimaryIndex()
+	fetcher, colMap, err := newPrimaryKeyFetcher(ctx, txn, codec, desc, primary, cols)
+	if err != nil {
+		return nil, err
+	}
+	defer fetcher.Close(ctx)
+	if err := fetcher.StartScan(
+		ctx,
+		roachpb.Spans{span},
+		nil, /* spanIDs */
+		rowinfra.GetDefaultBatchBytesLimit(false /* forceProductionValue */),
+		rowinfra.NoRowLimit,
+	); err != nil {
+		return nil, err
+	}
+	ranges := []brinBlockRange{{start: start, cols: make([]brinColumnSummary, len(cols))}}
+	var numRows int64
+	for {
+		datums, err := fetcher.NextRowDecoded(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if datums == nil {
+			break
+		}
+		if numRows == rowsPerRange {
+			key, _, err := rowenc.EncodeIndexKey(desc, primary, colMap, datums, nil /* keyPrefix */)
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, brinBlockRange{start: key, cols: make([]brinColumnSummary, len(cols))})
+			numRows = 0
+		}
+		numRows++
+		summaries := ranges[len(ranges)-1].cols
+		for i, col := range cols {
+			ord, _ := colMap.Get(col.GetID())
+			if err := summaries[i].add(evalCtx, datums[ord]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return ranges, nil
+}
+
+// summarizeBRINIndex summarizes again the block ranges of the given BRIN index
+// which contain rows not yet summarized, and returns the number of block
+// ranges it summarized.
+func summarizeBRINIndex(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	txn *kv.Txn,
+	codec keys.SQLCodec,
+	sv *settings.Values,
+	desc catalog.TableDescriptor,
+	idx catalog.Index,
+) (int, error) {
+	cols, err := brinIndexColumns(desc, idx)
+	if err != nil {
+		return 0, err
+	}
+	unsummarized, _, err := readBRINUnsummarizedRows(ctx, txn, codec, desc, idx, -1 /* limit */)
+	if err != nil || len(unsummarized) == 0 {
+		return 0, err
+	}
+	ranges, err := readBRINBlockRanges(ctx, txn, codec, desc, idx, cols)
+	if err != nil {
+		return 0, err
+	}
+	if len(ranges) == 0 {
+		// The first block range starts at the beginning of the primary index.
+		ranges = []brinBlockRange{{start: roachpb.Key{}}}
+	}
+
+	// Find the block ranges containing the rows not yet summarized. Both the
+	// block ranges and the rows are ordered.
+	var toSummarize []int
+	for _, key := range unsummarized {
+		i := sort.Search(len(ranges), func(i int) bool { return ranges[i].start.Compare(key) > 0 }) - 1
+		if n := len(toSummarize); n == 0 || toSummarize[n-1] != i {
+			toSummarize = append(toSummarize, i)
+		}
+	}
+
+	primaryPrefix := rowenc.MakeIndexKeyPrefix(codec, desc.GetID(), desc.GetPrimaryIndexID())
+	summaryPrefix := brinKeyPrefix(codec, desc, idx, brinSummaryKey)
+	rowsPerRange := brinRowsPerRange.Get(sv)
+	b := txn.NewBatch()
+	var numRanges int
+	for _, i := range toSummarize {
+		span := roachpb.Span{Key: appendKey(primaryPrefix, ranges[i].start)}
+		if i+1 < len(ranges) {
+			span.EndKey = appendKey(primaryPrefix, ranges[i+1].start)
+		} else {
+			span.EndKey = roachpb.Key(primaryPrefix).PrefixEnd()
+		}
+		summarized, err := summarizeBRINBlockRange(
+			ctx, evalCtx, txn, codec, desc, cols, ranges[i].start, span, rowsPerRange,
+		)
+		if err != nil {
+			return 0, err
+		}
+		for _, r := range summarized {
+			value, err := encodeBRINSummaries(r.cols)
+			if err != nil {
+				return 0, err
+			}
+			b.Put(appendKey(summaryPrefix, r.start), value)
+		}
+		numRanges += len(summarized)
+	}
+	unsummarizedPrefix := brinKeyPrefix(codec, desc, idx, brinUnsummarizedKey)
+	b.DelRange(unsummarizedPrefix, unsummarizedPrefix.PrefixEnd(), false /* returnKeys */)
+	if err := txn.Run(ctx, b); err != nil {
+		return 0, err
+	}
+	return numRanges, nil
+}
+
+// SummarizeBRINIndex is part of the eval.Planner interface.
+func (p *planner) SummarizeBRINIndex(ctx context.Context, indexName string) (int, error) {
+	un, err := parser.ParseTableName(indexName)
+	if err != nil {
+		return 0, err
+	}
+	tn := un.ToTableName()
+	tableIndexName := tree.TableIndexName{
+		Table: tree.MakeTableNameFromPrefix(tn.ObjectNamePrefix, "" /* object */),
+		Index: tree.UnrestrictedName(tn.ObjectName),
+	}
+	_, prefix, tableDesc, idx, err := resolver.ResolveIndex(
+		ctx, p, &tableIndexName, tree.IndexLookupFlags{Required: true},
+	)
+	if err != nil {
+		return 0, err
+	}
+	if err := p.canResolveDescUnderSchema(ctx, prefix.Schema, tableDesc); err != nil {
+		return 0, err
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return 0, err
+	}
+	if idx.GetAccessMethod() != descpb.IndexDescriptor_BRIN {
+		return 0, pgerror.Newf(pgcode.WrongObjectType, "%q is not a BRIN index", idx.GetName())
+	}
+	return summarizeBRINIndex(
+		ctx, p.EvalContext(), p.Txn(), p.ExecCfg().Codec, &p.ExecCfg().Settings.SV, tableDesc, idx,
+	)
+}
+
+// brinIndexSpans returns the spans of the primary index of the table which may
+// contain rows satisfying the given column constraints according to the given
+// BRIN index: the spans of the block ranges whose summaries match the
+// constraints, and the spans of the rows not yet summarized. It returns
+// ok=false if the index cannot be used, because none of its columns are
+// constrained or because too many rows are not yet summarized.
+func brinIndexSpans(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	txn *kv.Txn,
+	codec keys.SQLCodec,
+	sv *settings.Values,
+	desc catalog.TableDescriptor,
+	idx catalog.Index,
+	constraints []exec.ColumnConstraint,
+) (_ roachpb.Spans, ok bool, _ error) {
+	cols, err := brinIndexColumns(desc, idx)
+	if err != nil {
+		return nil, false, err
+	}
+	colConstraints := make([]*constraint.Constraint, len(cols))
+	for i, col := range cols {
+		for j := range constraints {
+			if int(constraints[j].Column) == col.Ordinal() && !constraints[j].Constraint.IsUnconstrained() {
+				colConstraints[i] = constraints[j].Constraint
+				ok = true
+			}
+		}
+	}
+	if !ok {
+		return nil, false, nil
+	}
+	unsummarized, ok, err := readBRINUnsummarizedRows(
+		ctx, txn, codec, desc, idx, int(brinMaxUnsummarizedRows.Get(sv)),
+	)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	ranges, err := readBRINBlockRanges(ctx, txn, codec, desc, idx, cols)
+	if err != nil {
+		return nil, false, err
+	}
+
+	primaryPrefix := rowenc.MakeIndexKeyPrefix(codec, desc.GetID(), desc.GetPrimaryIndexID())
+	spans := make(roachpb.Spans, 0, len(unsummarized))
+	for i := range ranges {
+		match := true
+		for j, c := range colConstraints {
+			if c != nil && !ranges[i].cols[j].mayMatch(evalCtx, c) {
+				match = false
+				break
+			}
+		}
+		if !match {
+			continue
+		}
+		span := roachpb.Span{Key: appendKey(primaryPrefix, ranges[i].start)}
+		if i+1 < len(ranges) {
+			span.EndKey = appendKey(primaryPrefix, ranges[i+1].start)
+		} else {
+			span.EndKey = roachpb.Key(primaryPrefix).PrefixEnd()
+		}
+		spans = append(spans, span)
+	}
+	for _, key := range unsummarized {
+		rowKey := appendKey(primaryPrefix, key)
+		spans = append(spans, roachpb.Span{Key: rowKey, EndKey: rowKey.PrefixEnd()})
+	}
+	spans, _ = roachpb.MergeSpans(&spans)
+	return spans, true, nil
+}
+
+// constrainSpansWithBRINIndexes restricts the given spans of the primary index
+// of the given table to the spans which may contain rows satisfying the given
+// column constraints according to the BRIN indexes of the table. The summaries
+// are read with the given transaction, which is the one executing the scan, so
+// this is done when the table readers of the scan are planned rather than when
+// the query is optimized.
+func constrainSpansWithBRINIndexes(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	txn *kv.Txn,
+	codec keys.SQLCodec,
+	sv *settings.Values,
+	desc catalog.TableDescriptor,
+	spans roachpb.Spans,
+	constraints []exec.ColumnConstraint,
+) (roachpb.Spans, error) {
+	if txn == nil {
+		return spans, nil
+	}
+	for _, idx := range desc.PublicNonPrimaryIndexes() {
+		if idx.GetAccessMethod() != descpb.IndexDescriptor_BRIN {
+			continue
+		}
+		brinSpans, ok, err := brinIndexSpans(ctx, evalCtx, txn, codec, sv, desc, idx, constraints)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			spans = intersectSpans(spans, brinSpans)
+		}
+	}
+	return spans, nil
+}
+
+// intersectSpans returns the parts of the given spans which are contained in
+// the given allowed spans, which must be ordered and non-overlapping. Spans
+// of single keys are kept as is if they are allowed.
+func intersectSpans(spans, allowed roachpb.Spans) roachpb.Spans {
+	var res roachpb.Spans
+	for _, sp := range spans {
+		end := sp.EndKey
+		if len(end) == 0 {
+			end = sp.Key.Next()
+		}
+		i := sort.Search(len(allowed), func(i int) bool { return allowed[i].EndKey.Compare(sp.Key) > 0 })
+		for ; i < len(allowed) && allowed[i].Key.Compare(end) < 0; i++ {
+			if len(sp.EndKey) == 0 {
+				res = append(res, sp)
+				break
+			}
+			intersection := sp
+			if allowed[i].Key.Compare(intersection.Key) > 0 {
+				intersection.Key = allowed[i].Key
+			}
+			if allowed[i].EndKey.Compare(intersection.EndKey) < 0 {
+				intersection.EndKey = allowed[i].EndKey
+			}
+			res = append(res, intersection)
+		}
+	}
+	return res
+}
@@ -116,9 +116,17 @@ func indexForDisplay(
 		f.WriteString(" USING")
 		if index.Type == descpb.IndexDescriptor_INVERTED {
 			f.WriteString(" gin")
+		} else if index.AccessMethod == descpb.IndexDescriptor_HASH {
+			f.WriteString(" hash")
+		} else if index.AccessMethod == descpb.IndexDescriptor_BRIN {
+			f.WriteString(" brin")
 		} else {
 			f.WriteString(" btree")
 		}
+	} else if index.AccessMethod == descpb.IndexDescriptor_HASH && *tableName != descpb.AnonymousTable {
+		f.WriteString(" USING hash")
+	} else if index.AccessMethod == descpb.IndexDescriptor_BRIN && *tableName != descpb.AnonymousTable {
+		f.WriteString(" USING brin")
 	}
 
 	f.WriteString(" (")
@@ -220,6 +228,11 @@ func FormatIndexElements(
 	}
 
 	startIdx := index.ExplicitColumnStartIdx()
+	if index.AccessMethod == descpb.IndexDescriptor_BRIN {
+		// The first key column of a BRIN index is an internal constant, which
+		// is not part of its definition.
+		startIdx++
+	}
 	for i, n := startIdx, len(index.KeyColumnIDs); i < n; i++ {
 		col, err := catalog.MustFindColumnByID(table, index.KeyColumnIDs[i])
 		if err != nil {
@@ -228,6 +241,15 @@ func FormatIndexElements(
 		if i > startIdx {
 			f.WriteString(", ")
 		}
+		if index.AccessMethod == descpb.IndexDescriptor_HASH && col.IsExpressionIndexColumn() {
+			// The key column of a hash index hashes the indexed columns or
+			// expressions, which are formatted instead. Hash indexes have no
+			// direction.
+			if err := formatHashIndexKey(ctx, table, col, f, semaCtx, sessionData, elemFmtFlag); err != nil {
+				return err
+			}
+			continue
+		}
 		if col.IsExpressionIndexColumn() {
 			expr, err := schemaexpr.FormatExprForExpressionIndexDisplay(
 				ctx, table, col.GetComputeExpr(), semaCtx, sessionData, elemFmtFlag,
@@ -248,8 +270,9 @@ func FormatIndexElements(
 		}
 		// The last column of an inverted index cannot have a DESC direction.
 		// Since the default direction is ASC, we omit the direction entirely
-		// for inverted index columns.
-		if i < n-1 || index.Type != descpb.IndexDescriptor_INVERTED {
+		// for inverted index columns. BRIN indexes have no direction.
+		if (i < n-1 || index.Type != descpb.IndexDescriptor_INVERTED) &&
+			index.AccessMethod != descpb.IndexDescriptor_BRIN {
 			f.WriteByte(' ')
 			f.WriteString(index.KeyColumnDirections[i].String())
 		}
@@ -257,6 +280,41 @@ func FormatIndexElements(
 	return nil
 }
 
+// formatHashIndexKey formats the columns or expressions whose hash is stored
+// in the given key column of a hash index.
+func formatHashIndexKey(
+	ctx context.Context,
+	table catalog.TableDescriptor,
+	col catalog.Column,
+	f *tree.FmtCtx,
+	semaCtx *tree.SemaContext,
+	sessionData *sessiondata.SessionData,
+	fmtFlags tree.FmtFlags,
+) error {
+	keys, err := schemaexpr.HashIndexKeyExprs(col.GetComputeExpr())
+	if err != nil {
+		return err
+	}
+	for i, key := range keys {
+		if i > 0 {
+			f.WriteString(", ")
+		}
+		if name, ok := key.(*tree.UnresolvedName); ok && name.NumParts == 1 {
+			colName := tree.Name(name.Parts[0])
+			f.FormatNode(&colName)
+			continue
+		}
+		expr, err := schemaexpr.FormatExprForExpressionIndexDisplay(
+			ctx, table, tree.Serialize(key), semaCtx, sessionData, fmtFlags,
+		)
+		if err != nil {
+			return err
+		}
+		f.WriteString(expr)
+	}
+	return nil
+}
+
 // formatStorageConfigs writes the index's storage configurations to the given
 // format context.
 func formatStorageConfigs(
@@ -64,6 +64,10 @@ func CanHaveCompositeKeyEncoding(typ *types.T) bool {
 			}
 		}
 		return false
+	case types.RangeFamily:
+		return CanHaveCompositeKeyEncoding(typ.RangeSubtype())
+	case types.MultirangeFamily:
+		return CanHaveCompositeKeyEncoding(types.RangeOfMultirange(typ).RangeSubtype())
 	case types.BoolFamily,
 		types.IntFamily,
 		types.DateFamily,
@@ -87,7 +91,9 @@ func CanHaveCompositeKeyEncoding(typ *types.T) bool {
 		types.VoidFamily,
 		types.EncodedKeyFamily,
 		types.TSQueryFamily,
-		types.TSVectorFamily:
+		types.TSVectorFamily,
+		types.JSONPathFamily,
+		types.TriggerFamily:
 		return false
 	case types.UnknownFamily,
 		types.AnyFamily:
@@ -255,9 +255,61 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 		desc.validateMultiRegion(vea)
 	}
 
+	desc.validatePublications(vea)
 	desc.maybeValidateSystemDatabaseSchemaVersion(vea)
 }
 
+// validatePublications checks that the publications of the database have
+// distinct, non-empty names and owners.
+func (desc *immutable) validatePublications(vea catalog.ValidationErrorAccumulator) {
+	names := make(map[string]struct{}, len(desc.Publications))
+	for i := range desc.Publications {
+		pub := &desc.Publications[i]
+		if pub.Name == "" {
+			vea.Report(errors.AssertionFailedf("publication has an empty name"))
+			continue
+		}
+		if _, ok := names[pub.Name]; ok {
+			vea.Report(errors.AssertionFailedf("duplicate publication name %q", pub.Name))
+		}
+		names[pub.Name] = struct{}{}
+		if pub.OwnerProto.Decode().Undefined() {
+			vea.Report(errors.AssertionFailedf("publication %q has no owner", pub.Name))
+		}
+		if pub.AllTables && len(pub.TableIDs) > 0 {
+			vea.Report(errors.AssertionFailedf(
+				"publication %q includes all tables but also has table IDs", pub.Name))
+		}
+	}
+}
+
+// GetPublication implements the DatabaseDescriptor interface.
+func (desc *immutable) GetPublication(name string) *descpb.DatabaseDescriptor_Publication {
+	for i := range desc.Publications {
+		if desc.Publications[i].Name == name {
+			return &desc.Publications[i]
+		}
+	}
+	return nil
+}
+
+// AddPublication adds a publication to the database.
+func (desc *Mutable) AddPublication(pub descpb.DatabaseDescriptor_Publication) {
+	desc.Publications = append(desc.Publications, pub)
+}
+
+// RemovePublication removes the publication with the given name from the
+// database, and returns whether it was found.
+func (desc *Mutable) RemovePublication(name string) bool {
+	for i := range desc.Publications {
+		if desc.Publications[i].Name == name {
+			desc.Publications = append(desc.Publications[:i], desc.Publications[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 // validateMultiRegion performs checks specific to multi-region DBs.
 func (desc *immutable) validateMultiRegion(vea catalog.ValidationErrorAccumulator) {
 	if desc.RegionConfig.PrimaryRegion == "" {
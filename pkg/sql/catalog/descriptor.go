@@ -295,6 +295,9 @@ type DatabaseDescriptor interface {
 	// HasPublicSchemaWithDescriptor returns true iff the database has a public
 	// schema which itself has a descriptor.
 	HasPublicSchemaWithDescriptor() bool
+	// GetPublication returns the publication of the database with the given
+	// name, or nil if there is none. The publication must not be modified.
+	GetPublication(name string) *descpb.DatabaseDescriptor_Publication
 }
 
 // TableDescriptor is an interface around the table descriptor types.
@@ -323,6 +326,10 @@ type TableDescriptor interface {
 	// virtual Table (like the information_schema tables) and thus doesn't
 	// need to be physically stored.
 	IsVirtualTable() bool
+	// IsForeignTable returns true if the TableDescriptor describes a foreign
+	// table, whose rows are read from files in external storage rather than
+	// stored in the kv layer.
+	IsForeignTable() bool
 	// IsPhysicalTable returns true if the TableDescriptor actually describes a
 	// physical Table that needs to be stored in the kv layer, as opposed to a
 	// different resource like a view or a virtual table. Physical tables have
@@ -547,6 +554,12 @@ type TableDescriptor interface {
 	// GetNextConstraintID returns the next unused constraint ID for this table.
 	// Constraint IDs are unique per table, but not unique globally.
 	GetNextConstraintID() descpb.ConstraintID
+	// GetTriggers returns the triggers defined on this table, in the order in
+	// which they were created.
+	GetTriggers() []descpb.TriggerDescriptor
+	// GetNextTriggerID returns the next unused trigger ID for this table.
+	// Trigger IDs are unique per table, but not unique globally.
+	GetNextTriggerID() descpb.TriggerID
 	// IsShardColumn returns true if col corresponds to a non-dropped hash sharded
 	// index. This method assumes that col is currently a member of desc.
 	IsShardColumn(col Column) bool
@@ -820,6 +833,10 @@ type TypeDescriptor interface {
 	// nil otherwise.
 	AsCompositeTypeDescriptor() CompositeTypeDescriptor
 
+	// AsDomainTypeDescriptor returns this instance cast to
+	// DomainTypeDescriptor if this type is a domain, nil otherwise.
+	AsDomainTypeDescriptor() DomainTypeDescriptor
+
 	// AsTableImplicitRecordTypeDescriptor returns this instance cast to
 	// TableImplicitRecordTypeDescriptor if this type is an implicit table record
 	// type, nil otherwise.
@@ -907,6 +924,29 @@ type CompositeTypeDescriptor interface {
 	GetElementType(ordinal int) *types.T
 }
 
+// DomainTypeDescriptor is the TypeDescriptor subtype for domains, which are
+// base types with an optional default value and constraints.
+type DomainTypeDescriptor interface {
+	NonAliasTypeDescriptor
+
+	// BaseType returns the type underlying the domain.
+	BaseType() *types.T
+
+	// GetDefaultExpr returns the serialized default expression of the domain,
+	// or nil if it has no default.
+	GetDefaultExpr() *string
+
+	// IsNotNull returns true if the domain does not allow NULL values.
+	IsNotNull() bool
+
+	// NumChecks returns the number of CHECK constraints of the domain.
+	NumChecks() int
+
+	// GetCheck returns the CHECK constraint of the domain at the given
+	// ordinal.
+	GetCheck(ordinal int) *descpb.TypeDescriptor_Domain_CheckConstraint
+}
+
 // TableImplicitRecordTypeDescriptor is the TypeDescriptor subtype for the
 // record type implicitly defined by a table.
 type TableImplicitRecordTypeDescriptor interface {
@@ -991,6 +1031,26 @@ type FunctionDescriptor interface {
 	// IsProcedure returns true if the descriptor represents a procedure. It
 	// returns false if the descriptor represents a user-defined function.
 	IsProcedure() bool
+
+	// IsAggregate returns true if the descriptor represents an aggregate
+	// created with CREATE AGGREGATE.
+	IsAggregate() bool
+
+	// GetSecurity returns whether the function executes with the privileges of
+	// its invoker or of its owner.
+	GetSecurity() catpb.Function_Security
+
+	// GetOperators returns the names of the operators created with CREATE
+	// OPERATOR which are implemented by the function.
+	GetOperators() []string
+
+	// GetCastContext returns the context of the cast created with CREATE CAST
+	// which is implemented by the function, or NOT_A_CAST.
+	GetCastContext() catpb.Function_CastContext
+
+	// GetSessionSettings returns the session variables which are set while the
+	// function executes.
+	GetSessionSettings() []descpb.FunctionDescriptor_SessionSetting
 }
 
 // FilterDroppedDescriptor returns an error if the descriptor state is DROP.
@@ -212,6 +212,33 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			vea.Report(errors.AssertionFailedf("type not set for arg %d", i))
 		}
 	}
+	if desc.Aggregate != nil {
+		if desc.Aggregate.StateType == nil {
+			vea.Report(errors.AssertionFailedf("state type not set for aggregate"))
+		}
+		if desc.Aggregate.StateFunc == 0 {
+			vea.Report(errors.AssertionFailedf("state function not set for aggregate"))
+		}
+		if desc.IsProcedure() {
+			vea.Report(errors.AssertionFailedf("aggregate cannot be a procedure"))
+		}
+	}
+	if len(desc.Operators) > 0 {
+		if n := len(desc.Params); n != 1 && n != 2 {
+			vea.Report(errors.AssertionFailedf("operator function has %d parameters", n))
+		}
+		if desc.IsProcedure() || desc.IsAggregate() {
+			vea.Report(errors.AssertionFailedf("operator function cannot be a procedure or aggregate"))
+		}
+	}
+	if desc.CastContext != catpb.Function_NOT_A_CAST {
+		if n := len(desc.Params); n != 1 {
+			vea.Report(errors.AssertionFailedf("cast function has %d parameters", n))
+		}
+		if desc.IsProcedure() || desc.IsAggregate() {
+			vea.Report(errors.AssertionFailedf("cast function cannot be a procedure or aggregate"))
+		}
+	}
 
 	vp := funcinfo.MakeVolatilityProperties(desc.Volatility, desc.LeakProof)
 	vea.Report(vp.Validate())
@@ -330,9 +357,9 @@ func (desc *immutable) validateInboundFunctionRef(
 			backrefFunctionDesc.GetName(), backrefFunctionDesc.GetID())
 	}
 	// Validate all other references are unset.
-	if ref.ColumnIDs != nil || ref.IndexIDs != nil || ref.ConstraintIDs != nil {
-		return errors.AssertionFailedf("function reference has invalid references (%v, %v %v)",
-			ref.ColumnIDs, ref.IndexIDs, ref.ConstraintIDs)
+	if ref.ColumnIDs != nil || ref.IndexIDs != nil || ref.ConstraintIDs != nil || ref.TriggerIDs != nil {
+		return errors.AssertionFailedf("function reference has invalid references (%v, %v, %v, %v)",
+			ref.ColumnIDs, ref.IndexIDs, ref.ConstraintIDs, ref.TriggerIDs)
 	}
 	// Validate a reference exists to this function.
 	for _, refID := range backrefFunctionDesc.GetDependsOnFunctions() {
@@ -409,6 +436,26 @@ func (desc *immutable) validateInboundTableRef(
 			cstID, backRefTbl.GetName(), backRefTbl.GetID(), desc.GetName(), desc.GetID(),
 		)
 	}
+
+	for _, trigID := range by.TriggerIDs {
+		trig := catalog.FindTriggerByID(backRefTbl, trigID)
+		if trig == nil {
+			return errors.AssertionFailedf("depended-on-by relation %q (%d) does not have a trigger with ID %d",
+				backRefTbl.GetName(), by.ID, trigID)
+		}
+		if trig.FuncID == desc.GetID() {
+			foundInTable = true
+			continue
+		}
+		if fnIDs := catalog.MakeDescriptorIDSet(trig.DependsOnRoutines...); fnIDs.Contains(desc.GetID()) {
+			foundInTable = true
+			continue
+		}
+		return errors.AssertionFailedf(
+			"trigger %d in depended-on-by relation %q (%d) does not have reference to function %q (%d)",
+			trigID, backRefTbl.GetName(), backRefTbl.GetID(), desc.GetName(), desc.GetID(),
+		)
+	}
 	if foundInTable {
 		return nil
 	}
@@ -463,6 +510,11 @@ func (desc *immutable) ForEachUDTDependentForHydration(fn func(t *types.T) error
 			return iterutil.Map(err)
 		}
 	}
+	if desc.Aggregate != nil && catid.IsOIDUserDefined(desc.Aggregate.StateType.Oid()) {
+		if err := fn(desc.Aggregate.StateType); err != nil {
+			return iterutil.Map(err)
+		}
+	}
 	if !catid.IsOIDUserDefined(desc.ReturnType.Type.Oid()) {
 		return nil
 	}
@@ -571,6 +623,43 @@ func (desc *Mutable) SetLang(v catpb.Function_Language) {
 	desc.Lang = v
 }
 
+// SetSecurity sets the security attribute.
+func (desc *Mutable) SetSecurity(v catpb.Function_Security) {
+	desc.Security = v
+}
+
+// SetSessionSetting sets the value of a session variable while the function
+// executes, replacing any previous value of the variable.
+func (desc *Mutable) SetSessionSetting(name, value string) {
+	for i := range desc.SessionSettings {
+		if desc.SessionSettings[i].Name == name {
+			desc.SessionSettings[i].Value = value
+			return
+		}
+	}
+	desc.SessionSettings = append(desc.SessionSettings, descpb.FunctionDescriptor_SessionSetting{
+		Name:  name,
+		Value: value,
+	})
+}
+
+// ResetSessionSetting removes the value of a session variable set while the
+// function executes.
+func (desc *Mutable) ResetSessionSetting(name string) {
+	for i := range desc.SessionSettings {
+		if desc.SessionSettings[i].Name == name {
+			desc.SessionSettings = append(desc.SessionSettings[:i], desc.SessionSettings[i+1:]...)
+			return
+		}
+	}
+}
+
+// ResetAllSessionSettings removes all the session variables set while the
+// function executes.
+func (desc *Mutable) ResetAllSessionSettings() {
+	desc.SessionSettings = nil
+}
+
 // SetFuncBody sets the function body.
 func (desc *Mutable) SetFuncBody(v string) {
 	desc.FunctionBody = v
@@ -630,6 +719,82 @@ func (desc *Mutable) RemoveConstraintReference(id descpb.ID, constraintID descpb
 	}
 }
 
+// AddTriggerReference adds back reference to a trigger to the function. Unlike
+// other references, the function is allowed to depend on the table of the
+// trigger, since the function is only invoked when rows of the table are
+// mutated.
+func (desc *Mutable) AddTriggerReference(id descpb.ID, triggerID descpb.TriggerID) error {
+	for i := range desc.DependedOnBy {
+		if desc.DependedOnBy[i].ID == id {
+			for _, existing := range desc.DependedOnBy[i].TriggerIDs {
+				if existing == triggerID {
+					return nil
+				}
+			}
+			ids := append(desc.DependedOnBy[i].TriggerIDs, triggerID)
+			sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+			desc.DependedOnBy[i].TriggerIDs = ids
+			return nil
+		}
+	}
+	desc.DependedOnBy = append(
+		desc.DependedOnBy,
+		descpb.FunctionDescriptor_Reference{
+			ID:         id,
+			TriggerIDs: []descpb.TriggerID{triggerID},
+		},
+	)
+	sort.Slice(desc.DependedOnBy, func(i, j int) bool {
+		return desc.DependedOnBy[i].ID < desc.DependedOnBy[j].ID
+	})
+	return nil
+}
+
+// RemoveTriggerReference removes back reference to a trigger from the
+// function.
+func (desc *Mutable) RemoveTriggerReference(id descpb.ID, triggerID descpb.TriggerID) {
+	for i := range desc.DependedOnBy {
+		if desc.DependedOnBy[i].ID == id {
+			ids := desc.DependedOnBy[i].TriggerIDs[:0]
+			for _, existing := range desc.DependedOnBy[i].TriggerIDs {
+				if existing != triggerID {
+					ids = append(ids, existing)
+				}
+			}
+			if len(ids) == 0 {
+				ids = nil
+			}
+			desc.DependedOnBy[i].TriggerIDs = ids
+			desc.maybeRemoveTableReference(id)
+			return
+		}
+	}
+}
+
+// AddOperator records that the function implements the operator with the
+// given name.
+func (desc *Mutable) AddOperator(name string) {
+	desc.Operators = append(desc.Operators, name)
+}
+
+// RemoveOperator removes the operator with the given name from the operators
+// implemented by the function. It returns false if the function does not
+// implement the operator.
+func (desc *Mutable) RemoveOperator(name string) bool {
+	for i, op := range desc.Operators {
+		if op == name {
+			desc.Operators = append(desc.Operators[:i], desc.Operators[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// SetCastContext sets the context of the cast implemented by the function.
+func (desc *Mutable) SetCastContext(v catpb.Function_CastContext) {
+	desc.CastContext = v
+}
+
 // AddFunctionReference adds back reference for a function invoking this function.
 func (desc *Mutable) AddFunctionReference(id descpb.ID) error {
 	for _, f := range desc.DependsOnFunctions {
@@ -705,13 +870,14 @@ func (desc *Mutable) RemoveColumnReference(id descpb.ID, colID descpb.ColumnID)
 }
 
 // maybeRemoveTableReference removes a table's references from the function if
-// the column, index and constraint references are all empty. This function is
-// only used internally when removing an individual column, index or constraint
-// reference.
+// the column, index, constraint and trigger references are all empty. This
+// function is only used internally when removing an individual column, index,
+// constraint or trigger reference.
 func (desc *Mutable) maybeRemoveTableReference(id descpb.ID) {
 	var ret []descpb.FunctionDescriptor_Reference
 	for _, ref := range desc.DependedOnBy {
-		if ref.ID == id && len(ref.ColumnIDs) == 0 && len(ref.IndexIDs) == 0 && len(ref.ConstraintIDs) == 0 {
+		if ref.ID == id && len(ref.ColumnIDs) == 0 && len(ref.IndexIDs) == 0 &&
+			len(ref.ConstraintIDs) == 0 && len(ref.TriggerIDs) == 0 {
 			continue
 		}
 		ret = append(ret, ref)
@@ -794,6 +960,7 @@ func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 		class := ToTreeRoutineParamClass(param.Class)
 		if tree.IsInParamClass(class) {
 			signatureTypes = append(signatureTypes, tree.ParamType{Name: param.Name, Typ: param.Type})
+			ret.Variadic = class == tree.RoutineParamVariadic
 		}
 		routineParam := tree.RoutineParam{
 			Name:  tree.Name(param.Name),
@@ -824,6 +991,31 @@ func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	if desc.ReturnType.ReturnSet {
 		ret.Class = tree.GeneratorClass
 	}
+	if agg := desc.Aggregate; agg != nil {
+		ret.Class = tree.AggregateClass
+		// Aggregates are always called, even if all of their inputs are NULL.
+		// NULL inputs are handled by the state transition function instead.
+		ret.CalledOnNullInput = true
+		ret.AggregateDef = &tree.AggregateDefinition{
+			StateFunc:   agg.StateFunc,
+			StateType:   agg.StateType,
+			FinalFunc:   agg.FinalFunc,
+			CombineFunc: agg.CombineFunc,
+			InitCond:    agg.InitCond,
+		}
+	}
+	if desc.Security == catpb.Function_DEFINER || len(desc.SessionSettings) > 0 {
+		ret.SessionOverride = &tree.RoutineSessionOverride{}
+		if desc.Security == catpb.Function_DEFINER {
+			ret.SessionOverride.User = desc.Privileges.Owner()
+		}
+		for _, setting := range desc.SessionSettings {
+			ret.SessionOverride.Settings = append(ret.SessionOverride.Settings, tree.RoutineSessionSetting{
+				Name:  setting.Name,
+				Value: setting.Value,
+			})
+		}
+	}
 
 	return ret, nil
 }
@@ -887,12 +1079,21 @@ func (desc *immutable) ToCreateExpr() (ret *tree.CreateRoutine, err error) {
 			}
 		}
 	}
-	// We only store 5 function attributes at the moment. We may extend the
-	// pre-allocated capacity in the future.
-	ret.Options = make(tree.RoutineOptions, 0, 5)
+	// We always store 5 function attributes, in addition to the security
+	// attribute and session settings if they are specified.
+	ret.Options = make(tree.RoutineOptions, 0, 6+len(desc.SessionSettings))
 	ret.Options = append(ret.Options, desc.getCreateExprVolatility())
 	ret.Options = append(ret.Options, tree.RoutineLeakproof(desc.LeakProof))
 	ret.Options = append(ret.Options, desc.getCreateExprNullInputBehavior())
+	if desc.Security == catpb.Function_DEFINER {
+		ret.Options = append(ret.Options, tree.RoutineDefiner)
+	}
+	for _, setting := range desc.SessionSettings {
+		ret.Options = append(ret.Options, &tree.SetVar{
+			Name:   setting.Name,
+			Values: tree.Exprs{tree.NewStrVal(setting.Value)},
+		})
+	}
 	ret.Options = append(ret.Options, tree.RoutineBodyStr(desc.FunctionBody))
 	ret.Options = append(ret.Options, desc.getCreateExprLang())
 	return ret, nil
@@ -903,6 +1104,11 @@ func (desc *immutable) IsProcedure() bool {
 	return desc.FunctionDescriptor.IsProcedure
 }
 
+// IsAggregate implements the FunctionDescriptor interface.
+func (desc *immutable) IsAggregate() bool {
+	return desc.FunctionDescriptor.Aggregate != nil
+}
+
 func (desc *immutable) getCreateExprLang() tree.RoutineLanguage {
 	switch desc.Lang {
 	case catpb.Function_SQL:
@@ -951,6 +1157,8 @@ func ToTreeRoutineParamClass(class catpb.Function_Param_Class) tree.RoutineParam
 		return tree.RoutineParamInOut
 	case catpb.Function_Param_VARIADIC:
 		return tree.RoutineParamVariadic
+	case catpb.Function_Param_TABLE:
+		return tree.RoutineParamTable
 	}
 	return 0
 }
@@ -997,6 +997,19 @@ func DatabaseDescs(
 			return err
 		}
 		db.Schemas = newSchemas
+
+		// Rewrite the IDs of the tables of publications. Tables which are not
+		// restored are removed from the publications.
+		for i := range db.Publications {
+			pub := &db.Publications[i]
+			tableIDs := pub.TableIDs[:0]
+			for _, id := range pub.TableIDs {
+				if rewrite, ok := descriptorRewrites[id]; ok {
+					tableIDs = append(tableIDs, rewrite.ID)
+				}
+			}
+			pub.TableIDs = tableIDs
+		}
 	}
 	return nil
 }
@@ -47,6 +47,16 @@ type SchemaDescriptor interface {
 	// ForEachFunctionSignature iterates through all function signatures within
 	// the schema and calls fn on each signature.
 	ForEachFunctionSignature(fn func(sig descpb.SchemaDescriptor_FunctionSignature) error) error
+
+	// GetTextSearchDictionary returns the text search dictionary of the schema
+	// with the given name, or nil if there is none. The dictionary must not be
+	// modified.
+	GetTextSearchDictionary(name string) *descpb.SchemaDescriptor_TextSearchDictionary
+
+	// GetTextSearchConfig returns the text search configuration of the schema
+	// with the given name, or nil if there is none. The configuration must not
+	// be modified.
+	GetTextSearchConfig(name string) *descpb.SchemaDescriptor_TextSearchConfig
 }
 
 // ResolvedSchemaKind is an enum that represents what kind of schema
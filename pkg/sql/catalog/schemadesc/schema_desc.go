@@ -16,6 +16,7 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
@@ -249,6 +250,45 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			}
 		}
 	}
+
+	desc.validateTextSearchObjects(vea)
+}
+
+// validateTextSearchObjects checks that the text search dictionaries and
+// configurations of the schema have distinct, non-empty names and owners, and
+// that the configurations only refer to dictionaries which exist.
+func (desc *immutable) validateTextSearchObjects(vea catalog.ValidationErrorAccumulator) {
+	validateName := func(kind, name string, owner username.SQLUsernameProto, names map[string]struct{}) {
+		if name == "" {
+			vea.Report(errors.AssertionFailedf("text search %s has an empty name", kind))
+			return
+		}
+		if _, ok := names[name]; ok {
+			vea.Report(errors.AssertionFailedf("duplicate text search %s name %q", kind, name))
+		}
+		names[name] = struct{}{}
+		if owner.Decode().Undefined() {
+			vea.Report(errors.AssertionFailedf("text search %s %q has no owner", kind, name))
+		}
+	}
+	dictNames := make(map[string]struct{}, len(desc.TextSearchDictionaries))
+	for i := range desc.TextSearchDictionaries {
+		dict := &desc.TextSearchDictionaries[i]
+		validateName("dictionary", dict.Name, dict.OwnerProto, dictNames)
+	}
+	configNames := make(map[string]struct{}, len(desc.TextSearchConfigs))
+	for i := range desc.TextSearchConfigs {
+		config := &desc.TextSearchConfigs[i]
+		validateName("configuration", config.Name, config.OwnerProto, configNames)
+		for _, m := range config.Mappings {
+			for _, d := range m.Dictionaries {
+				if _, ok := dictNames[d.Name]; !d.Builtin && !ok {
+					vea.Report(errors.AssertionFailedf(
+						"text search configuration %q refers to unknown dictionary %q", config.Name, d.Name))
+				}
+			}
+		}
+	}
 }
 
 // GetReferencedDescIDs returns the IDs of all descriptors referenced by
@@ -495,6 +535,62 @@ func (desc *Mutable) RemoveFunction(name string, id descpb.ID) {
 	}
 }
 
+// GetTextSearchDictionary implements the SchemaDescriptor interface.
+func (desc *immutable) GetTextSearchDictionary(
+	name string,
+) *descpb.SchemaDescriptor_TextSearchDictionary {
+	for i := range desc.TextSearchDictionaries {
+		if desc.TextSearchDictionaries[i].Name == name {
+			return &desc.TextSearchDictionaries[i]
+		}
+	}
+	return nil
+}
+
+// GetTextSearchConfig implements the SchemaDescriptor interface.
+func (desc *immutable) GetTextSearchConfig(name string) *descpb.SchemaDescriptor_TextSearchConfig {
+	for i := range desc.TextSearchConfigs {
+		if desc.TextSearchConfigs[i].Name == name {
+			return &desc.TextSearchConfigs[i]
+		}
+	}
+	return nil
+}
+
+// AddTextSearchDictionary adds a text search dictionary to the schema.
+func (desc *Mutable) AddTextSearchDictionary(dict descpb.SchemaDescriptor_TextSearchDictionary) {
+	desc.TextSearchDictionaries = append(desc.TextSearchDictionaries, dict)
+}
+
+// RemoveTextSearchDictionary removes the text search dictionary with the
+// given name from the schema, and returns whether it was found.
+func (desc *Mutable) RemoveTextSearchDictionary(name string) bool {
+	for i := range desc.TextSearchDictionaries {
+		if desc.TextSearchDictionaries[i].Name == name {
+			desc.TextSearchDictionaries = append(desc.TextSearchDictionaries[:i], desc.TextSearchDictionaries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// AddTextSearchConfig adds a text search configuration to the schema.
+func (desc *Mutable) AddTextSearchConfig(config descpb.SchemaDescriptor_TextSearchConfig) {
+	desc.TextSearchConfigs = append(desc.TextSearchConfigs, config)
+}
+
+// RemoveTextSearchConfig removes the text search configuration with the given
+// name from the schema, and returns whether it was found.
+func (desc *Mutable) RemoveTextSearchConfig(name string) bool {
+	for i := range desc.TextSearchConfigs {
+		if desc.TextSearchConfigs[i].Name == name {
+			desc.TextSearchConfigs = append(desc.TextSearchConfigs[:i], desc.TextSearchConfigs[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 // ReplaceOverload updates the function signature that matches the existing
 // overload with the new one. An error is returned if the function doesn't exist
 // or a match is not found.
@@ -578,10 +674,14 @@ func (desc *immutable) GetResolvedFuncDefinition(
 			Type:                     routineType,
 			UDFContainsOnlySignature: true,
 			OutParamOrdinals:         sig.OutParamOrdinals,
+			Variadic:                 sig.IsVariadic,
 		}
 		if funcDescPb.Signatures[i].ReturnSet {
 			overload.Class = tree.GeneratorClass
 		}
+		if sig.IsAggregate {
+			overload.Class = tree.AggregateClass
+		}
 		// There is no need to look at the parameter classes since ArgTypes
 		// already contains only parameters that are included into the
 		// signature of the overload.
@@ -142,6 +142,18 @@ func (p synthetic) GetFunction(name string) (descpb.SchemaDescriptor_Function, b
 	return descpb.SchemaDescriptor_Function{}, false
 }
 
+// GetTextSearchDictionary implements the SchemaDescriptor interface.
+func (p synthetic) GetTextSearchDictionary(
+	name string,
+) *descpb.SchemaDescriptor_TextSearchDictionary {
+	return nil
+}
+
+// GetTextSearchConfig implements the SchemaDescriptor interface.
+func (p synthetic) GetTextSearchConfig(name string) *descpb.SchemaDescriptor_TextSearchConfig {
+	return nil
+}
+
 // ForEachFunctionSignature implements the SchemaDescriptor interface.
 func (p synthetic) ForEachFunctionSignature(
 	fn func(sig descpb.SchemaDescriptor_FunctionSignature) error,
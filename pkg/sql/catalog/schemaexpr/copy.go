@@ -0,0 +1,70 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schemaexpr
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/transform"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// MakeCopyFromWhereExpr type-checks the WHERE condition of a COPY FROM
+// statement, which must be a boolean expression that only references the
+// columns being copied. Column references are replaced with IndexedVars whose
+// indexes are ordinals in cols, so that the returned expression can be
+// evaluated over each incoming row with a RowIndexedVarContainer.
+//
+// Subqueries, aggregates, window functions, set-returning functions, and
+// user-defined functions are not allowed, since the condition is evaluated
+// outside of the optimizer.
+func MakeCopyFromWhereExpr(
+	ctx context.Context,
+	expr tree.Expr,
+	tableDesc catalog.TableDescriptor,
+	cols []catalog.Column,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+) (tree.TypedExpr, error) {
+	tn := tree.NewUnqualifiedTableName(tree.Name(tableDesc.GetName()))
+	nr := newNameResolver(evalCtx, tableDesc.GetID(), tn, cols)
+	expr, err := nr.resolveNames(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	// We need to save and restore the previous values of the fields in
+	// semaCtx, since the planner's semaCtx is shared with later statements.
+	defer semaCtx.Properties.Restore(semaCtx.Properties)
+	defer func(ivarContainer tree.IndexedVarContainer) {
+		semaCtx.IVarContainer = ivarContainer
+	}(semaCtx.IVarContainer)
+	nr.addIVarContainerToSemaCtx(semaCtx)
+	semaCtx.Properties.Require(string(tree.CopyFromWhereExpr), tree.RejectSpecial|tree.RejectSubqueries)
+
+	typedExpr, err := tree.TypeCheck(ctx, expr, semaCtx, types.Bool)
+	if err != nil {
+		return nil, err
+	}
+	var v tree.UDFDisallowanceVisitor
+	tree.WalkExpr(&v, typedExpr)
+	if v.FoundUDF {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"user-defined functions are not allowed in %s", tree.CopyFromWhereExpr)
+	}
+	var txCtx transform.ExprTransformContext
+	return txCtx.NormalizeExpr(ctx, evalCtx, typedExpr)
+}
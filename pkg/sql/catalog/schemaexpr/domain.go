@@ -0,0 +1,91 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schemaexpr
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/lib/pq/oid"
+)
+
+// ValidateDomainBaseType returns an error if the given type cannot be used as
+// the base type of a domain. Domains over user-defined types and container
+// types are not supported, and neither are domains over the types which are
+// distinguished from other types of their family only by their OID, since the
+// OID of a domain replaces the OID of its base type.
+func ValidateDomainBaseType(typ *types.T) error {
+	if typ.UserDefined() {
+		return unimplemented.NewWithIssue(27796, "domains over user-defined types")
+	}
+	switch typ.Family() {
+	case types.ArrayFamily, types.TupleFamily, types.OidFamily, types.AnyFamily,
+		types.UnknownFamily, types.VoidFamily, types.TriggerFamily:
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"type %s cannot be used as the base type of a domain", typ.SQLString())
+	}
+	switch typ.Oid() {
+	case oid.T_bpchar, oid.T_char, oid.T_name, oid.T_int2vector, oid.T_oidvector:
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"type %s cannot be used as the base type of a domain", typ.SQLString())
+	}
+	return nil
+}
+
+// ValidateDomainDefaultExpr type-checks the DEFAULT expression of a domain
+// against the base type of the domain, and returns its serialized form.
+//
+// The expression is type-checked without a name resolver, since domains
+// do not track dependencies on other objects. This means that references to
+// user-defined types and functions are not allowed.
+func ValidateDomainDefaultExpr(
+	ctx context.Context, expr tree.Expr, baseType *types.T,
+) (string, error) {
+	semaCtx := tree.MakeSemaContext()
+	typedExpr, err := SanitizeVarFreeExpr(
+		ctx, expr, baseType, tree.DomainDefaultExpr, &semaCtx, volatility.Volatile, true, /* allowAssignmentCast */
+	)
+	if err != nil {
+		return "", pgerror.WithCandidateCode(err, pgcode.DatatypeMismatch)
+	}
+	return tree.Serialize(typedExpr), nil
+}
+
+// ValidateDomainCheckExpr type-checks the CHECK constraint expression of a
+// domain with the given base type, and returns its serialized form. The
+// expression must be a boolean expression which may refer to the value being
+// checked as VALUE, and which may not refer to any other variables.
+//
+// Like for defaults, the expression is type-checked without a name resolver.
+func ValidateDomainCheckExpr(
+	ctx context.Context, expr tree.Expr, baseType *types.T,
+) (string, error) {
+	// Type-check the expression with VALUE replaced by a NULL of the base type,
+	// which is how the expression is evaluated against each value.
+	replaced, err := tree.ReplaceDomainValue(expr, &tree.CastExpr{
+		Expr: tree.DNull, Type: baseType, SyntaxMode: tree.CastShort,
+	})
+	if err != nil {
+		return "", err
+	}
+	semaCtx := tree.MakeSemaContext()
+	if _, err := SanitizeVarFreeExpr(
+		ctx, replaced, types.Bool, tree.DomainCheckExpr, &semaCtx, volatility.Volatile, false, /* allowAssignmentCast */
+	); err != nil {
+		return "", pgerror.WithCandidateCode(err, pgcode.DatatypeMismatch)
+	}
+	return tree.Serialize(expr), nil
+}
@@ -447,6 +447,9 @@ func SanitizeVarFreeExpr(
 	if err != nil {
 		return nil, err
 	}
+	if err := validateTextSearchConfigUsage(typedExpr, context); err != nil {
+		return nil, err
+	}
 
 	actualType := typedExpr.ResolvedType()
 	if !expectedType.Equivalent(actualType) && typedExpr != tree.DNull {
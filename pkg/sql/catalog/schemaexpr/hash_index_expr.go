@@ -0,0 +1,57 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schemaexpr
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// MakeHashIndexExpr creates the expression of the key column of a hash index
+// on the given key expressions. The expression will be of the form:
+//
+//	fnv64(crdb_internal.datums_to_bytes(key1, key2, ...))
+func MakeHashIndexExpr(keys tree.Exprs) tree.Expr {
+	unresolvedFunc := func(funcName string) tree.ResolvableFunctionReference {
+		return tree.ResolvableFunctionReference{
+			FunctionReference: &tree.UnresolvedName{
+				NumParts: 1,
+				Parts:    tree.NameParts{funcName},
+			},
+		}
+	}
+	return &tree.FuncExpr{
+		Func: unresolvedFunc("fnv64"),
+		Exprs: tree.Exprs{
+			&tree.FuncExpr{
+				Func:  unresolvedFunc("crdb_internal.datums_to_bytes"),
+				Exprs: keys,
+			},
+		},
+	}
+}
+
+// HashIndexKeyExprs returns the key expressions of a hash index given the
+// serialized expression of its key column, which was created by
+// MakeHashIndexExpr.
+func HashIndexKeyExprs(computeExpr string) (tree.Exprs, error) {
+	expr, err := parser.ParseExpr(computeExpr)
+	if err != nil {
+		return nil, err
+	}
+	if hash, ok := expr.(*tree.FuncExpr); ok && len(hash.Exprs) == 1 {
+		if toBytes, ok := hash.Exprs[0].(*tree.FuncExpr); ok && len(toBytes.Exprs) > 0 {
+			return toBytes.Exprs, nil
+		}
+	}
+	return nil, errors.AssertionFailedf("unexpected hash index expression %q", computeExpr)
+}
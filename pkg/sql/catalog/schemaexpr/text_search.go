@@ -0,0 +1,89 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schemaexpr
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+)
+
+// textSearchConfigExprContexts are the contexts of the expressions which are
+// stored in descriptors, and whose results may be stored as well. User-defined
+// text search configurations have no back-references to the expressions which
+// use them, so they cannot be used in these expressions: dropping or altering
+// a configuration would break the expressions or silently change their
+// results.
+var textSearchConfigExprContexts = map[tree.SchemaExprContext]struct{}{
+	tree.StoredComputedColumnExpr:        {},
+	tree.VirtualComputedColumnExpr:       {},
+	tree.ColumnOnUpdateExpr:              {},
+	tree.ColumnDefaultExprInAddColumn:    {},
+	tree.ColumnDefaultExprInNewTable:     {},
+	tree.ColumnDefaultExprInNewView:      {},
+	tree.ColumnDefaultExprInSetDefault:   {},
+	tree.CheckConstraintExpr:             {},
+	tree.UniqueWithoutIndexPredicateExpr: {},
+	tree.IndexPredicateExpr:              {},
+	tree.ExpressionIndexElementExpr:      {},
+	tree.TTLExpirationExpr:               {},
+	tree.TTLDefaultExpr:                  {},
+	tree.TTLUpdateExpr:                   {},
+	tree.TriggerWhenExpr:                 {},
+	tree.DomainDefaultExpr:               {},
+	tree.DomainCheckExpr:                 {},
+}
+
+// validateTextSearchConfigUsage returns an error if an expression of the given
+// context calls a text search function whose configuration may be
+// user-defined. The configuration must be a constant which names a built-in
+// configuration.
+func validateTextSearchConfigUsage(expr tree.TypedExpr, context tree.SchemaExprContext) error {
+	if _, ok := textSearchConfigExprContexts[context]; !ok {
+		return nil
+	}
+	_, err := tree.SimpleVisit(expr, func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		f, ok := expr.(*tree.FuncExpr)
+		if !ok || !hasTextSearchConfigArg(f) {
+			return true, expr, nil
+		}
+		d, ok := tree.StripParens(f.Exprs[0]).(*tree.DString)
+		if !ok {
+			return false, expr, pgerror.Newf(pgcode.FeatureNotSupported,
+				"the text search configuration of %s must be a constant in %s", f.Func, context)
+		}
+		if _, err := tsearch.BuiltinConfig(tsearch.GetConfigKey(string(*d))); err != nil {
+			return false, expr, pgerror.Newf(pgcode.FeatureNotSupported,
+				"user-defined text search configuration %q cannot be used in %s", string(*d), context)
+		}
+		return true, expr, nil
+	})
+	return err
+}
+
+// hasTextSearchConfigArg returns whether the function expression is a text
+// search function whose first argument is the name of a configuration.
+func hasTextSearchConfigArg(f *tree.FuncExpr) bool {
+	if len(f.Exprs) != 2 || f.ResolvedOverload() == nil ||
+		f.ResolvedOverload().Type != tree.BuiltinRoutine {
+		return false
+	}
+	def, ok := f.Func.FunctionReference.(*tree.ResolvedFunctionDefinition)
+	if !ok {
+		return false
+	}
+	switch def.Name {
+	case "to_tsvector", "to_tsquery", "plainto_tsquery", "phraseto_tsquery":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,212 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schemaexpr
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// TriggerNewRecordName and TriggerOldRecordName are the names of the
+// pseudo-records through which a row-level trigger can reference the new and
+// old versions of the row that fired it.
+const (
+	TriggerNewRecordName = tree.Name("new")
+	TriggerOldRecordName = tree.Name("old")
+)
+
+// ValidateTriggerWhenExpr validates the WHEN condition of a trigger, which
+// must be a boolean expression. Row-level triggers may reference the columns
+// of the table through the NEW and OLD pseudo-records, either one column at a
+// time (NEW.a) or as a whole (NEW.*). INSERT triggers cannot reference OLD,
+// DELETE triggers cannot reference NEW, and statement-level triggers cannot
+// reference either.
+//
+// The type-checked expression is returned in serialized form, with UDF names
+// replaced by OID references. References to NEW and OLD are preserved.
+func ValidateTriggerWhenExpr(
+	ctx context.Context,
+	expr tree.Expr,
+	semaCtx *tree.SemaContext,
+	forEachRow bool,
+	events []*tree.TriggerEvent,
+	getAllNonDropColumnsFn func() colinfo.ResultColumns,
+	columnLookupByNameFn func(columnName tree.Name) (exists bool, accessible bool, id catid.ColumnID, typ *types.T),
+) (string, error) {
+	var rowType *types.T
+	getRowType := func() *types.T {
+		if rowType == nil {
+			cols := getAllNonDropColumnsFn()
+			contents := make([]*types.T, 0, len(cols))
+			labels := make([]string, 0, len(cols))
+			for i := range cols {
+				if cols[i].Hidden {
+					continue
+				}
+				contents = append(contents, cols[i].Typ)
+				labels = append(labels, cols[i].Name)
+			}
+			rowType = types.MakeLabeledTuple(contents, labels)
+		}
+		return rowType
+	}
+	checkRecord := func(record tree.Name) error {
+		if !forEachRow {
+			return pgerror.New(pgcode.InvalidObjectDefinition,
+				"statement trigger's WHEN condition cannot reference column values")
+		}
+		for _, ev := range events {
+			if ev.EventType == tree.TriggerEventInsert && record == TriggerOldRecordName {
+				return pgerror.New(pgcode.InvalidObjectDefinition,
+					"INSERT trigger's WHEN condition cannot reference OLD values")
+			}
+			if ev.EventType == tree.TriggerEventDelete && record == TriggerNewRecordName {
+				return pgerror.New(pgcode.InvalidObjectDefinition,
+					"DELETE trigger's WHEN condition cannot reference NEW values")
+			}
+		}
+		return nil
+	}
+
+	// Replace references to NEW and OLD with dummy columns, so that the
+	// expression can be type-checked.
+	replacedExpr, err := tree.SimpleVisit(expr, func(expr tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		vBase, ok := expr.(tree.VarName)
+		if !ok {
+			return true, expr, nil
+		}
+		v, err := vBase.NormalizeVarName()
+		if err != nil {
+			return false, nil, err
+		}
+		var record, colName tree.Name
+		var wholeRow bool
+		switch t := v.(type) {
+		case *tree.ColumnItem:
+			if t.TableName == nil {
+				if t.ColumnName != TriggerNewRecordName && t.ColumnName != TriggerOldRecordName {
+					return false, nil, pgerror.Newf(pgcode.UndefinedColumn,
+						"column %q does not exist", t.ColumnName)
+				}
+				record, wholeRow = t.ColumnName, true
+			} else {
+				if t.TableName.NumParts != 1 {
+					return false, nil, pgerror.Newf(pgcode.InvalidObjectDefinition,
+						"invalid reference to %q in trigger WHEN condition", tree.ErrString(t))
+				}
+				record, colName = tree.Name(t.TableName.Parts[0]), t.ColumnName
+			}
+		case *tree.AllColumnsSelector:
+			if t.TableName.NumParts != 1 {
+				return false, nil, pgerror.Newf(pgcode.InvalidObjectDefinition,
+					"invalid reference to %q in trigger WHEN condition", tree.ErrString(t))
+			}
+			record, wholeRow = tree.Name(t.TableName.Parts[0]), true
+		default:
+			return true, expr, nil
+		}
+		if record != TriggerNewRecordName && record != TriggerOldRecordName {
+			return false, nil, pgerror.Newf(pgcode.UndefinedTable,
+				"missing FROM-clause entry for table %q", record)
+		}
+		if err := checkRecord(record); err != nil {
+			return false, nil, err
+		}
+		if wholeRow {
+			return false, &dummyTriggerRecord{typ: getRowType(), record: record}, nil
+		}
+		colExists, colIsAccessible, _, colType := columnLookupByNameFn(colName)
+		if !colExists {
+			return false, nil, pgerror.Newf(pgcode.UndefinedColumn,
+				"record %q has no field %q", record, colName)
+		}
+		if !colIsAccessible {
+			return false, nil, pgerror.Newf(pgcode.UndefinedColumn,
+				"column %q is inaccessible and cannot be referenced", colName)
+		}
+		return false, &dummyTriggerRecord{typ: colType, record: record, column: colName}, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	typedExpr, err := SanitizeVarFreeExpr(
+		ctx,
+		replacedExpr,
+		types.Bool,
+		tree.TriggerWhenExpr,
+		semaCtx,
+		volatility.Volatile,
+		false, /* allowAssignmentCast */
+	)
+	if err != nil {
+		return "", err
+	}
+	typedExpr, err = MaybeReplaceUDFNameWithOIDReferenceInTypedExpr(typedExpr)
+	if err != nil {
+		return "", err
+	}
+	return tree.Serialize(typedExpr), nil
+}
+
+// dummyTriggerRecord represents a reference to the NEW or OLD pseudo-record of
+// a trigger, or to one of its fields, that can be type-checked. It formats as
+// the original reference, so that the expression can be serialized.
+type dummyTriggerRecord struct {
+	typ    *types.T
+	record tree.Name
+	// column is empty if the whole record is referenced.
+	column tree.Name
+}
+
+// String implements the Stringer interface.
+func (d *dummyTriggerRecord) String() string {
+	return tree.AsString(d)
+}
+
+// Format implements the NodeFormatter interface.
+func (d *dummyTriggerRecord) Format(ctx *tree.FmtCtx) {
+	if d.column == "" {
+		ctx.FormatNode(&d.record)
+		return
+	}
+	ctx.FormatNode(&d.record)
+	ctx.WriteByte('.')
+	ctx.FormatNode(&d.column)
+}
+
+// Walk implements the Expr interface.
+func (d *dummyTriggerRecord) Walk(_ tree.Visitor) tree.Expr {
+	return d
+}
+
+// TypeCheck implements the Expr interface.
+func (d *dummyTriggerRecord) TypeCheck(
+	_ context.Context, _ *tree.SemaContext, desired *types.T,
+) (tree.TypedExpr, error) {
+	return d, nil
+}
+
+func (*dummyTriggerRecord) Eval(ctx context.Context, v tree.ExprEvaluator) (tree.Datum, error) {
+	panic("dummyTriggerRecord.Eval() is undefined")
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *dummyTriggerRecord) ResolvedType() *types.T {
+	return d.typ
+}
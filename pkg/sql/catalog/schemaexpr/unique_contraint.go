@@ -15,9 +15,13 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
 )
 
 // ValidateUniqueWithoutIndexPredicate verifies that an expression is a valid
@@ -55,3 +59,55 @@ func ValidateUniqueWithoutIndexPredicate(
 	}
 	return expr, nil
 }
+
+// exclusionOperators are the operators that can be used in exclusion
+// constraints, keyed by the names stored in the descriptor. All of them are
+// commutative, so whether two rows conflict does not depend on which of them
+// is the new row. The optimizer only knows how to build the conflict
+// comparisons of these operators, so any operator added here must also be
+// handled by the uniqueness checks in optbuilder.
+var exclusionOperators = map[string]treecmp.ComparisonOperatorSymbol{
+	treecmp.EQ.String():       treecmp.EQ,
+	treecmp.NE.String():       treecmp.NE,
+	treecmp.Overlaps.String(): treecmp.Overlaps,
+}
+
+// ExclusionOperator returns the operator with the given name, as stored in
+// the descriptor of an exclusion constraint.
+func ExclusionOperator(name string) (treecmp.ComparisonOperatorSymbol, error) {
+	op, ok := exclusionOperators[name]
+	if !ok {
+		return 0, errors.AssertionFailedf("invalid exclusion constraint operator %q", name)
+	}
+	return op, nil
+}
+
+// NewUnsupportedExclusionOperatorError returns the error raised when an
+// operator which cannot be used in exclusion constraints is specified.
+func NewUnsupportedExclusionOperatorError(op treecmp.ComparisonOperatorSymbol) error {
+	return errors.WithHint(
+		pgerror.Newf(pgcode.WrongObjectType,
+			"operator %s is not supported in exclusion constraints", op),
+		"exclusion constraints support the =, <> and && operators",
+	)
+}
+
+// ValidateExclusionOperator verifies that op can be used to compare values of
+// the given type in an exclusion constraint. It must be called whenever an
+// exclusion constraint is created, so that only the operators supported by
+// the uniqueness checks of the optimizer are stored in descriptors.
+func ValidateExclusionOperator(op treecmp.ComparisonOperatorSymbol, typ *types.T) error {
+	if _, ok := exclusionOperators[op.String()]; !ok {
+		return NewUnsupportedExclusionOperatorError(op)
+	}
+	lookup := op
+	if lookup == treecmp.NE {
+		// Inequality is evaluated as the negation of equality.
+		lookup = treecmp.EQ
+	}
+	if _, ok := tree.CmpOps[lookup].LookupImpl(typ, typ); !ok {
+		return pgerror.Newf(pgcode.UndefinedFunction,
+			"operator does not exist: %s %s %s", typ.SQLString(), op, typ.SQLString())
+	}
+	return nil
+}
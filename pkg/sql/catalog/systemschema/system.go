@@ -900,6 +900,20 @@ CREATE TABLE system.external_connections (
 	FAMILY "primary" (connection_name, created, updated, connection_type, connection_details, owner, owner_id)
 );`
 
+	SystemReplicationSlotsTableSchema = `
+CREATE TABLE system.replication_slots (
+	slot_name           STRING NOT NULL,
+	database_id         INT8 NOT NULL,
+	plugin              STRING NOT NULL,
+	confirmed_flush_lsn INT8 NOT NULL,
+	confirmed_flush_ts  DECIMAL NOT NULL,
+	created             TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	owner               STRING NOT NULL,
+	owner_id            OID NOT NULL,
+	CONSTRAINT "primary" PRIMARY KEY (slot_name),
+	FAMILY "primary" (slot_name, database_id, plugin, confirmed_flush_lsn, confirmed_flush_ts, created, owner, owner_id)
+);`
+
 	SystemTenantTasksSchema = `
 CREATE TABLE system.tenant_tasks (
 	tenant_id    INT8 NOT NULL,
@@ -1208,7 +1222,7 @@ const SystemDatabaseName = catconstants.SystemDatabaseName
 // SystemDatabaseSchemaBootstrapVersion is the system database schema version
 // that should be used during bootstrap. It should be bumped up alongside any
 // upgrade that creates or modifies the schema of a system table.
-var SystemDatabaseSchemaBootstrapVersion = clusterversion.V24_1_SessionBasedLeasingUpgradeDescriptor.Version()
+var SystemDatabaseSchemaBootstrapVersion = clusterversion.V24_1_AddSystemReplicationSlotsTable.Version()
 
 // MakeSystemDatabaseDesc constructs a copy of the system database
 // descriptor.
@@ -1399,6 +1413,7 @@ func MakeSystemTables() []SystemTable {
 		SystemMVCCStatisticsTable,
 		StatementExecInsightsTable,
 		TransactionExecInsightsTable,
+		SystemReplicationSlotsTable,
 	}
 }
 
@@ -4041,6 +4056,42 @@ var (
 		),
 	)
 
+	// SystemReplicationSlotsTable is the descriptor for the replication slots
+	// table, which stores the position of each logical replication slot.
+	SystemReplicationSlotsTable = makeSystemTable(
+		SystemReplicationSlotsTableSchema,
+		systemTable(
+			catconstants.ReplicationSlotsTableName,
+			descpb.InvalidID, // dynamically assigned
+			[]descpb.ColumnDescriptor{
+				{Name: "slot_name", ID: 1, Type: types.String},
+				{Name: "database_id", ID: 2, Type: types.Int},
+				{Name: "plugin", ID: 3, Type: types.String},
+				{Name: "confirmed_flush_lsn", ID: 4, Type: types.Int},
+				{Name: "confirmed_flush_ts", ID: 5, Type: types.Decimal},
+				{Name: "created", ID: 6, Type: types.TimestampTZ, DefaultExpr: &nowTZString},
+				{Name: "owner", ID: 7, Type: types.String},
+				{Name: "owner_id", ID: 8, Type: types.Oid},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"slot_name", "database_id", "plugin", "confirmed_flush_lsn", "confirmed_flush_ts", "created", "owner", "owner_id"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5, 6, 7, 8},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:                "primary",
+				ID:                  1,
+				Unique:              true,
+				KeyColumnNames:      []string{"slot_name"},
+				KeyColumnDirections: singleASC,
+				KeyColumnIDs:        singleID1,
+			},
+		),
+	)
+
 	SystemTenantTasksTable = makeSystemTable(
 		SystemTenantTasksSchema,
 		systemTable(
@@ -177,6 +177,7 @@ type Index interface {
 	GetInvisibility() float64
 	GetPredicate() string
 	GetType() descpb.IndexDescriptor_Type
+	GetAccessMethod() descpb.IndexDescriptor_AccessMethod
 	GetGeoConfig() geopb.Config
 	GetVersion() descpb.IndexDescriptorVersion
 	GetEncodingType() catenumpb.IndexDescriptorEncodingType
@@ -1147,6 +1148,30 @@ func MustFindConstraintByID(tbl TableDescriptor, id descpb.ConstraintID) (Constr
 	return nil, pgerror.Newf(pgcode.UndefinedObject, "constraint-id \"%d\" does not exist", id)
 }
 
+// FindTriggerByID traverses the slice returned by the GetTriggers method on the
+// table descriptor and returns the first trigger that matches the desired ID,
+// or nil if none was found.
+func FindTriggerByID(tbl TableDescriptor, id descpb.TriggerID) *descpb.TriggerDescriptor {
+	triggers := tbl.GetTriggers()
+	for i := range triggers {
+		if triggers[i].ID == id {
+			return &triggers[i]
+		}
+	}
+	return nil
+}
+
+// FindTriggerByName is like FindTriggerByID but with names instead of IDs.
+func FindTriggerByName(tbl TableDescriptor, name string) *descpb.TriggerDescriptor {
+	triggers := tbl.GetTriggers()
+	for i := range triggers {
+		if triggers[i].Name == name {
+			return &triggers[i]
+		}
+	}
+	return nil
+}
+
 // FindConstraintByName is like FindConstraintByID but with names instead of
 // IDs.
 func FindConstraintByName(tbl TableDescriptor, name string) Constraint {
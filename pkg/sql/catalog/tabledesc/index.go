@@ -135,6 +135,11 @@ func (w index) GetType() descpb.IndexDescriptor_Type {
 	return w.desc.Type
 }
 
+// GetAccessMethod returns the access method of the index.
+func (w index) GetAccessMethod() descpb.IndexDescriptor_AccessMethod {
+	return w.desc.AccessMethod
+}
+
 // GetPartitioning returns the partitioning descriptor of the index.
 func (w index) GetPartitioning() catalog.Partitioning {
 	return &partitioning{desc: &w.desc.Partitioning}
@@ -364,6 +364,13 @@ func (desc *wrapper) GetAllReferencedTypeIDs(
 		ids.Add(id)
 	}
 
+	// Add type dependencies of triggers.
+	for i := range desc.Triggers {
+		for _, id := range desc.Triggers[i].DependsOnTypes {
+			ids.Add(id)
+		}
+	}
+
 	return ids.Ordered(), referencedInColumns, nil
 }
 
@@ -382,6 +389,12 @@ func (desc *wrapper) GetAllReferencedFunctionIDs() (catalog.DescriptorIDSet, err
 			ret.Add(id)
 		}
 	}
+	for i := range desc.Triggers {
+		ret.Add(desc.Triggers[i].FuncID)
+		for _, id := range desc.Triggers[i].DependsOnRoutines {
+			ret.Add(id)
+		}
+	}
 	// TODO(chengxiong): add logic to extract references from indexes when UDFs
 	// are allowed in them.
 	return ret.Union(catalog.MakeDescriptorIDSet(desc.DependsOnFunctions...)), nil
@@ -568,8 +581,9 @@ func (desc *Mutable) AllocateIDsWithoutValidation(
 		}
 	}
 
-	// Only tables and materialized views can have / need indexes and column families.
-	if desc.IsTable() || desc.MaterializedView() {
+	// Only tables and materialized views can have / need indexes and column
+	// families. Foreign tables, like views, have neither.
+	if (desc.IsTable() && !desc.IsForeignTable()) || desc.MaterializedView() {
 		if err := desc.allocateIndexIDs(columnNames); err != nil {
 			return err
 		}
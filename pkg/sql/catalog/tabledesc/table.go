@@ -29,6 +29,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/errors"
@@ -223,9 +224,17 @@ func MakeColumnDefDescs(
 	}
 
 	if d.PrimaryKey.IsPrimaryKey || (d.Unique.IsUnique && !d.Unique.WithoutIndex) {
+		if d.PrimaryKey.IsPrimaryKey && d.Unique.Deferrability != tree.ConstraintNotDeferrable {
+			return nil, sqlerrors.NewDeferrablePrimaryKeyError()
+		}
+		// A DEFERRABLE unique constraint may be violated until the end of the
+		// transaction, so its index cannot be unique. The constraint itself must
+		// be added by the caller, and it is enforced like a UNIQUE WITHOUT INDEX
+		// constraint.
+		unique := d.Unique.Deferrability == tree.ConstraintNotDeferrable
 		if !d.PrimaryKey.Sharded {
 			ret.PrimaryKeyOrUniqueIndexDescriptor = &descpb.IndexDescriptor{
-				Unique:              true,
+				Unique:              unique,
 				KeyColumnNames:      []string{string(d.Name)},
 				KeyColumnDirections: []catenumpb.IndexColumn_Direction{catenumpb.IndexColumn_ASC},
 			}
@@ -236,7 +245,7 @@ func MakeColumnDefDescs(
 			}
 			shardColName := GetShardColumnName([]string{string(d.Name)}, buckets)
 			ret.PrimaryKeyOrUniqueIndexDescriptor = &descpb.IndexDescriptor{
-				Unique:              true,
+				Unique:              unique,
 				KeyColumnNames:      []string{shardColName, string(d.Name)},
 				KeyColumnDirections: []catenumpb.IndexColumn_Direction{catenumpb.IndexColumn_ASC, catenumpb.IndexColumn_ASC},
 				Sharded: catpb.ShardedDescriptor{
@@ -118,6 +118,17 @@ func (desc *wrapper) GetReferencedDescIDs() (catalog.DescriptorIDSet, error) {
 	for _, ref := range desc.GetDependedOnBy() {
 		ids.Add(ref.ID)
 	}
+	// Add trigger dependencies.
+	for i := range desc.Triggers {
+		trigger := &desc.Triggers[i]
+		ids.Add(trigger.FuncID)
+		for _, id := range trigger.DependsOnTypes {
+			ids.Add(id)
+		}
+		for _, id := range trigger.DependsOnRoutines {
+			ids.Add(id)
+		}
+	}
 	// Add sequence dependencies
 	return ids, nil
 }
@@ -199,6 +210,18 @@ func (desc *wrapper) ValidateForwardReferences(
 		}
 	}
 
+	// Check all types and functions referenced by triggers exist.
+	for i := range desc.Triggers {
+		trigger := &desc.Triggers[i]
+		vea.Report(desc.validateOutboundFuncRef(trigger.FuncID, vdg))
+		for _, id := range trigger.DependsOnRoutines {
+			vea.Report(desc.validateOutboundFuncRef(id, vdg))
+		}
+		for _, id := range trigger.DependsOnTypes {
+			vea.Report(desc.validateOutboundTypeRef(id, vdg))
+		}
+	}
+
 	// Check enforced outbound foreign keys.
 	for _, fk := range desc.EnforcedOutboundForeignKeys() {
 		vea.Report(desc.validateOutboundFK(fk.ForeignKeyDesc(), vdg))
@@ -263,6 +286,21 @@ func (desc *wrapper) ValidateBackReferences(
 		}
 	}
 
+	// Check back-references in functions referenced by triggers.
+	for i := range desc.Triggers {
+		trigger := &desc.Triggers[i]
+		fnIDs := catalog.MakeDescriptorIDSet(trigger.DependsOnRoutines...)
+		fnIDs.Add(trigger.FuncID)
+		for _, fnID := range fnIDs.Ordered() {
+			fn, err := vdg.GetFunctionDescriptor(fnID)
+			if err != nil {
+				vea.Report(err)
+				continue
+			}
+			vea.Report(desc.validateOutboundFuncRefBackReferenceForTrigger(fn, trigger.ID))
+		}
+	}
+
 	// For views, check dependent relations.
 	if desc.IsView() {
 		for _, id := range desc.DependsOnTypes {
@@ -376,6 +414,23 @@ func (desc *wrapper) validateOutboundFuncRefBackReferenceForColumn(
 		ref.GetName(), ref.GetID())
 }
 
+func (desc *wrapper) validateOutboundFuncRefBackReferenceForTrigger(
+	ref catalog.FunctionDescriptor, triggerID descpb.TriggerID,
+) error {
+	for _, dep := range ref.GetDependedOnBy() {
+		if dep.ID != desc.GetID() {
+			continue
+		}
+		for _, id := range dep.TriggerIDs {
+			if id == triggerID {
+				return nil
+			}
+		}
+	}
+	return errors.AssertionFailedf("depends-on function %q (%d) has no corresponding depended-on-by back reference",
+		ref.GetName(), ref.GetID())
+}
+
 func (desc *wrapper) validateInboundFunctionRef(
 	by descpb.TableDescriptor_Reference, vdg catalog.ValidationDescGetter,
 ) error {
@@ -483,6 +538,10 @@ func (desc *wrapper) validateSelfFKs() error {
 			return errors.AssertionFailedf("invalid outbound foreign key %q: mismatched number of referenced and origin columns", fk.Name)
 		}
 
+		if fk.InitiallyDeferred && !fk.Deferrable {
+			return errors.AssertionFailedf("invalid outbound foreign key %q: initially deferred but not deferrable", fk.Name)
+		}
+
 		for _, colID := range fk.OriginColumnIDs {
 			if _, ok := colsByID[colID]; !ok {
 				return errors.AssertionFailedf(
@@ -726,6 +785,19 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			"CREATE TABLE ... AS but does not have a CreateAsOfTime set"))
 	}
 
+	// Foreign tables have no data in KV, and thus no indexes.
+	if desc.IsForeignTable() {
+		if !desc.IsTable() {
+			vea.Report(errors.AssertionFailedf("foreign table is a view or sequence"))
+		}
+		if desc.ForeignTable.Server == "" {
+			vea.Report(errors.AssertionFailedf("foreign table has no server"))
+		}
+		if len(desc.Indexes) > 0 || len(desc.PrimaryIndex.KeyColumnIDs) > 0 {
+			vea.Report(errors.AssertionFailedf("foreign table has indexes"))
+		}
+	}
+
 	// VirtualTables have their privileges stored in system.privileges which
 	// is validated outside of the descriptor.
 	if !desc.IsVirtualTable() {
@@ -865,6 +937,7 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	// actually a table, not if it's just a view.
 	if desc.IsPhysicalTable() {
 		desc.validateConstraintNamesAndIDs(vea)
+		desc.validateTriggers(vea)
 		newErrs := []error{
 			desc.validateColumnFamilies(columnsByID),
 			desc.validateCheckConstraints(columnsByID),
@@ -1101,6 +1174,56 @@ func (desc *wrapper) validateConstraintNamesAndIDs(vea catalog.ValidationErrorAc
 
 }
 
+// validateTriggers validates that the triggers on the table have unique names
+// and IDs, and that they are well-formed.
+func (desc *wrapper) validateTriggers(vea catalog.ValidationErrorAccumulator) {
+	names := make(map[string]descpb.TriggerID, len(desc.Triggers))
+	idToName := make(map[descpb.TriggerID]string, len(desc.Triggers))
+	for i := range desc.Triggers {
+		trigger := &desc.Triggers[i]
+		if trigger.ID == 0 {
+			vea.Report(errors.AssertionFailedf(
+				"trigger ID was missing for trigger %q", trigger.Name))
+		} else if trigger.ID >= desc.NextTriggerID {
+			vea.Report(errors.AssertionFailedf(
+				"trigger %q has ID %d not less than NextTriggerID value %d for table",
+				trigger.Name, trigger.ID, desc.NextTriggerID))
+		}
+		if trigger.Name == "" {
+			vea.Report(pgerror.Newf(pgcode.Syntax, "empty trigger name"))
+		}
+		if otherID, found := names[trigger.Name]; found && trigger.ID != otherID {
+			vea.Report(pgerror.Newf(pgcode.DuplicateObject,
+				"duplicate trigger name: %q", trigger.Name))
+		}
+		names[trigger.Name] = trigger.ID
+		if other, found := idToName[trigger.ID]; found {
+			vea.Report(pgerror.Newf(pgcode.DuplicateObject,
+				"trigger ID %d in trigger %q already in use by %q",
+				trigger.ID, trigger.Name, other))
+		}
+		idToName[trigger.ID] = trigger.Name
+		if trigger.ActionTime == semenumpb.TriggerActionTime_ACTION_UNKNOWN {
+			vea.Report(errors.AssertionFailedf(
+				"trigger %q has unknown action time", trigger.Name))
+		}
+		if len(trigger.Events) == 0 {
+			vea.Report(errors.AssertionFailedf(
+				"trigger %q has no events", trigger.Name))
+		}
+		for _, ev := range trigger.Events {
+			if ev.Type == semenumpb.TriggerEventType_EVENT_UNKNOWN {
+				vea.Report(errors.AssertionFailedf(
+					"trigger %q has unknown event type", trigger.Name))
+			}
+		}
+		if trigger.FuncID == descpb.InvalidID {
+			vea.Report(errors.AssertionFailedf(
+				"trigger %q has invalid function ID", trigger.Name))
+		}
+	}
+}
+
 func (desc *wrapper) validateColumns() error {
 	columnIDs := make(map[descpb.ColumnID]*descpb.ColumnDescriptor, len(desc.Columns))
 	columnNames := make(map[string]descpb.ColumnID, len(desc.Columns))
@@ -1362,6 +1485,26 @@ func (desc *wrapper) validateUniqueWithoutIndexConstraints(
 			seen.Add(int(colID))
 		}
 
+		if uwi := c.UniqueWithoutIndexDesc(); uwi.InitiallyDeferred && !uwi.Deferrable {
+			return errors.Newf(
+				"unique without index constraint %q is initially deferred but not deferrable", c.GetName(),
+			)
+		}
+
+		if uwi := c.UniqueWithoutIndexDesc(); uwi.IsExclusion() {
+			if len(uwi.ExclusionOperators) != len(uwi.ColumnIDs) {
+				return errors.Newf(
+					"exclusion constraint %q has %d operators for %d columns",
+					c.GetName(), len(uwi.ExclusionOperators), len(uwi.ColumnIDs),
+				)
+			}
+			for _, op := range uwi.ExclusionOperators {
+				if _, err := schemaexpr.ExclusionOperator(op); err != nil {
+					return err
+				}
+			}
+		}
+
 		if c.IsPartial() {
 			expr, err := parser.ParseExpr(c.GetPredicate())
 			if err != nil {
@@ -1461,6 +1604,23 @@ func (desc *wrapper) validateTableIndexes(
 			return errors.Newf("index %q must contain at least 1 column", idx.GetName())
 		}
 
+		if idx.GetAccessMethod() == descpb.IndexDescriptor_HASH {
+			if idx.Primary() || idx.IsUnique() || idx.GetType() != descpb.IndexDescriptor_FORWARD {
+				return errors.Newf("hash index %q must be a non-unique forward secondary index", idx.GetName())
+			}
+			if idx.NumKeyColumns()-idx.ExplicitColumnStartIdx() != 1 {
+				return errors.Newf("hash index %q must contain exactly 1 explicit key column", idx.GetName())
+			}
+		}
+		if idx.GetAccessMethod() == descpb.IndexDescriptor_BRIN {
+			if idx.Primary() || idx.IsUnique() || idx.GetType() != descpb.IndexDescriptor_FORWARD || idx.IsPartial() {
+				return errors.Newf("BRIN index %q must be a non-unique, non-partial forward secondary index", idx.GetName())
+			}
+			if idx.ExplicitColumnStartIdx() != 0 || idx.NumKeyColumns() < 2 {
+				return errors.Newf("BRIN index %q must contain at least 2 key columns and no implicit partitioning columns", idx.GetName())
+			}
+		}
+
 		var validateIndexDup catalog.TableColSet
 		for i, colID := range idx.IndexDesc().KeyColumnIDs {
 			inIndexColName := idx.IndexDesc().KeyColumnNames[i]
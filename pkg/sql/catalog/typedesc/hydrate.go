@@ -14,6 +14,7 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/errors"
@@ -103,11 +104,12 @@ func ensureTypeIsHydratedRecursive(
 			maybeName = &name
 		}
 	}
-	ensureTypeMetadataIsHydrated(&t.TypeMeta, maybeName, maybeDesc)
+	ensureTypeMetadataIsHydrated(ctx, &t.TypeMeta, maybeName, maybeDesc)
 	return nil
 }
 
 func ensureTypeMetadataIsHydrated(
+	ctx context.Context,
 	tm *types.UserDefinedTypeMetadata, maybeName *tree.TypeName, maybeDesc catalog.TypeDescriptor,
 ) {
 	var version uint32
@@ -159,4 +161,44 @@ func ensureTypeMetadataIsHydrated(
 			}
 		}
 	}
+	if d := maybeDesc.AsDomainTypeDescriptor(); d != nil {
+		n := d.NumChecks()
+		tm.DomainData = &types.DomainMetadata{
+			NotNull:     d.IsNotNull(),
+			DefaultExpr: d.GetDefaultExpr(),
+			CheckNames:  make([]string, n),
+			CheckExprs:  make([]string, n),
+		}
+		for i := 0; i < n; i++ {
+			c := d.GetCheck(i)
+			tm.DomainData.CheckNames[i] = c.Name
+			tm.DomainData.CheckExprs[i] = c.Expr
+		}
+		tm.DomainData.TypedCheckExprs = typeCheckDomainChecks(ctx, d.BaseType(), tm.DomainData.CheckExprs)
+	}
+}
+
+// typeCheckDomainChecks parses and type-checks the given CHECK constraint
+// expressions of a domain so that they do not need to be re-parsed for every
+// value being checked. It returns nil if any of the expressions cannot be
+// type-checked, in which case they are type-checked on every evaluation.
+func typeCheckDomainChecks(
+	ctx context.Context, baseType *types.T, exprs []string,
+) []interface{} {
+	if len(exprs) == 0 {
+		return nil
+	}
+	typed := make([]interface{}, len(exprs))
+	for i, exprStr := range exprs {
+		expr, err := parser.ParseExpr(exprStr)
+		if err != nil {
+			return nil
+		}
+		typedExpr, err := tree.TypeCheckDomainCheckExpr(ctx, expr, baseType)
+		if err != nil {
+			return nil
+		}
+		typed[i] = typedExpr
+	}
+	return typed
 }
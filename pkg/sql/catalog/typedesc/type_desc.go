@@ -508,6 +508,8 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 		if desc.Composite == nil {
 			vea.Report(errors.AssertionFailedf("COMPOSITE type desc has nil composite type"))
 		}
+	case descpb.TypeDescriptor_DOMAIN:
+		desc.validateDomain(vea)
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		vea.Report(errors.AssertionFailedf("invalid type descriptor: kind %s should never be serialized or validated", desc.Kind.String()))
 	default:
@@ -515,6 +517,35 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	}
 }
 
+// validateDomain performs checks on the definition of a domain.
+func (desc *immutable) validateDomain(vea catalog.ValidationErrorAccumulator) {
+	if desc.Domain == nil || desc.Domain.BaseType == nil {
+		vea.Report(errors.AssertionFailedf("DOMAIN type desc has nil base type"))
+		return
+	}
+	if desc.Domain.BaseType.UserDefined() {
+		vea.Report(errors.AssertionFailedf(
+			"DOMAIN type desc has user-defined base type %s", desc.Domain.BaseType.SQLString()))
+	}
+	names := make(map[string]struct{}, len(desc.Domain.Checks))
+	ids := make(map[descpb.ConstraintID]struct{}, len(desc.Domain.Checks))
+	for _, c := range desc.Domain.Checks {
+		if _, ok := names[c.Name]; ok {
+			vea.Report(errors.AssertionFailedf("duplicate domain constraint name %q", c.Name))
+		}
+		names[c.Name] = struct{}{}
+		if _, ok := ids[c.ConstraintID]; ok {
+			vea.Report(errors.AssertionFailedf("duplicate domain constraint ID %d", c.ConstraintID))
+		}
+		ids[c.ConstraintID] = struct{}{}
+		if c.ConstraintID >= desc.Domain.NextConstraintID {
+			vea.Report(errors.AssertionFailedf(
+				"domain constraint %q has ID %d, which is not less than the next constraint ID %d",
+				c.Name, c.ConstraintID, desc.Domain.NextConstraintID))
+		}
+	}
+}
+
 // validateEnumMembers performs enum member checks.
 // Returns true iff the enums are sorted.
 func (desc *immutable) validateEnumMembers(vea catalog.ValidationErrorAccumulator) (isSorted bool) {
@@ -633,13 +664,13 @@ func (desc *immutable) ValidateBackReferences(
 ) {
 
 	// Validate that the backward-referenced types exist.
-	if e := desc.AsEnumTypeDescriptor(); e != nil {
+	if desc.AsEnumTypeDescriptor() != nil || desc.AsDomainTypeDescriptor() != nil {
 		// Ensure that the array type exists.
 		// This is considered to be a backward reference, not a forward reference,
 		// as the element type doesn't need the array type to exist, but the
 		// converse is not true.
-		if typ, err := vdg.GetTypeDescriptor(e.GetArrayTypeID()); err != nil {
-			vea.Report(errors.Wrapf(err, "arrayTypeID %d does not exist for %q", e.GetArrayTypeID(), e.GetKind()))
+		if typ, err := vdg.GetTypeDescriptor(desc.GetArrayTypeID()); err != nil {
+			vea.Report(errors.Wrapf(err, "arrayTypeID %d does not exist for %q", desc.GetArrayTypeID(), desc.GetKind()))
 		} else if typ.Dropped() {
 			vea.Report(errors.AssertionFailedf("array type %q (%d) is dropped", typ.GetName(), typ.GetID()))
 		}
@@ -773,6 +804,12 @@ func (desc *immutable) AsTypesT() *types.T {
 			contents,
 			labels,
 		)
+	case descpb.TypeDescriptor_DOMAIN:
+		return types.MakeDomain(
+			desc.Domain.BaseType,
+			catid.TypeIDToOID(desc.GetID()),
+			catid.TypeIDToOID(desc.ArrayTypeID),
+		)
 	}
 	panic(errors.AssertionFailedf("unsupported descriptor kind %s", desc.Kind.String()))
 }
@@ -1011,6 +1048,14 @@ func (desc *immutable) AsCompositeTypeDescriptor() catalog.CompositeTypeDescript
 	return nil
 }
 
+// AsDomainTypeDescriptor implements the catalog.TypeDescriptor interface.
+func (desc *immutable) AsDomainTypeDescriptor() catalog.DomainTypeDescriptor {
+	if desc.Kind == descpb.TypeDescriptor_DOMAIN {
+		return desc
+	}
+	return nil
+}
+
 // AsTableImplicitRecordTypeDescriptor implements the catalog.TypeDescriptor
 // interface.
 func (desc *immutable) AsTableImplicitRecordTypeDescriptor() catalog.TableImplicitRecordTypeDescriptor {
@@ -1037,6 +1082,31 @@ func (desc *immutable) GetElementType(ordinal int) *types.T {
 	return desc.Composite.Elements[ordinal].ElementType
 }
 
+// BaseType implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) BaseType() *types.T {
+	return desc.Domain.BaseType
+}
+
+// GetDefaultExpr implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetDefaultExpr() *string {
+	return desc.Domain.DefaultExpr
+}
+
+// IsNotNull implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) IsNotNull() bool {
+	return desc.Domain.NotNull
+}
+
+// NumChecks implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) NumChecks() int {
+	return len(desc.Domain.Checks)
+}
+
+// GetCheck implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetCheck(ordinal int) *descpb.TypeDescriptor_Domain_CheckConstraint {
+	return &desc.Domain.Checks[ordinal]
+}
+
 // ForEachRegionInSuperRegion implements the catalog.RegionEnumTypeDescriptor
 // interface.
 func (desc *immutable) ForEachRegionInSuperRegion(
@@ -503,6 +503,17 @@ func (p *planner) RevalidateUniqueConstraint(
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for _, uc := range tableDesc.EnforcedUniqueConstraintsWithoutIndex() {
 		if uc.GetName() == constraintName {
+			if ucDesc := uc.UniqueWithoutIndexDesc(); ucDesc.IsExclusion() {
+				return validateExclusionConstraint(
+					ctx,
+					tableDesc,
+					ucDesc,
+					0, /* indexIDForValidation */
+					p.InternalSQLTxn(),
+					p.User(),
+					true, /* preExisting */
+				)
+			}
 			return validateUniqueConstraint(
 				ctx,
 				tableDesc,
@@ -583,17 +594,31 @@ func RevalidateUniqueConstraintsInTable(
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for _, uc := range tableDesc.EnforcedUniqueConstraintsWithoutIndex() {
 		if uc.IsConstraintValidated() {
-			if err := validateUniqueConstraint(
-				ctx,
-				tableDesc,
-				uc.GetName(),
-				uc.CollectKeyColumnIDs().Ordered(),
-				uc.GetPredicate(),
-				0, /* indexIDForValidation */
-				txn,
-				user,
-				true, /* preExisting */
-			); err != nil {
+			var err error
+			if ucDesc := uc.UniqueWithoutIndexDesc(); ucDesc.IsExclusion() {
+				err = validateExclusionConstraint(
+					ctx,
+					tableDesc,
+					ucDesc,
+					0, /* indexIDForValidation */
+					txn,
+					user,
+					true, /* preExisting */
+				)
+			} else {
+				err = validateUniqueConstraint(
+					ctx,
+					tableDesc,
+					uc.GetName(),
+					uc.CollectKeyColumnIDs().Ordered(),
+					uc.GetPredicate(),
+					0, /* indexIDForValidation */
+					txn,
+					user,
+					true, /* preExisting */
+				)
+			}
+			if err != nil {
 				log.Errorf(ctx, "validation of unique constraints failed for table %s: %s", tableDesc.GetName(), err)
 				return errors.Wrapf(err, "for table %s", tableDesc.GetName())
 			}
@@ -642,6 +667,41 @@ func validateUniqueConstraint(
 		query,
 	)
 
+	values, err := runValidationQuery(ctx, txn, user, "validate unique constraint", query)
+	if err != nil {
+		return err
+	}
+	if values.Len() > 0 {
+		valuesStr := make([]string, len(values))
+		for i := range values {
+			valuesStr[i] = values[i].String()
+		}
+		// Note: this error message mirrors the message produced by Postgres
+		// when it fails to add a unique index due to duplicated keys.
+		errMsg := "could not create unique constraint"
+		if preExisting {
+			errMsg = "failed to validate unique constraint"
+		}
+		return errors.WithDetail(
+			pgerror.WithConstraintName(
+				pgerror.Newf(
+					pgcode.UniqueViolation, "%s %q", errMsg, constraintName,
+				),
+				constraintName,
+			),
+			fmt.Sprintf(
+				"Key (%s)=(%s) is duplicated.", strings.Join(colNames, ","), strings.Join(valuesStr, ","),
+			),
+		)
+	}
+	return nil
+}
+
+// runValidationQuery runs the given constraint validation query, which returns
+// at most one row describing a violation, retrying on transient errors.
+func runValidationQuery(
+	ctx context.Context, txn isql.Txn, user username.SQLUsername, opName string, query string,
+) (tree.Datums, error) {
 	sessionDataOverride := sessiondata.NoSessionDataOverride
 	sessionDataOverride.User = user
 	// We are likely to have performed a lot of work before getting here (e.g.
@@ -655,13 +715,14 @@ func validateUniqueConstraint(
 	// retries in order to not waste (a lot of) work that was performed before
 	// we got here.
 	var values tree.Datums
+	var err error
 	retryOptions := retry.Options{
 		InitialBackoff: 20 * time.Millisecond,
 		Multiplier:     1.5,
 		MaxRetries:     5,
 	}
 	for r := retry.StartWithCtx(ctx, retryOptions); r.Next(); {
-		values, err = txn.QueryRowEx(ctx, "validate unique constraint", txn.KV(), sessionDataOverride, query)
+		values, err = txn.QueryRowEx(ctx, opName, txn.KV(), sessionDataOverride, query)
 		if err == nil {
 			break
 		}
@@ -672,6 +733,136 @@ func validateUniqueConstraint(
 			log.Infof(ctx, "retrying the validation query because of %v", err)
 			continue
 		}
+		return nil, err
+	}
+	return values, nil
+}
+
+// exclusionViolationQuery generates and returns a query for pairs of rows that
+// violate the specified exclusion constraint. Rows in the table with any null
+// values in the constrained columns are excluded from matching.
+//
+// For example, an exclusion constraint (a WITH =, b WITH &&) on the table "tbl"
+// with primary key k would require the following query:
+//
+// SELECT l.a, l.b, r.a, r.b
+// FROM (SELECT k, a, b FROM tbl WHERE a IS NOT NULL AND b IS NOT NULL) AS l
+// JOIN (SELECT k, a, b FROM tbl WHERE a IS NOT NULL AND b IS NOT NULL) AS r
+// ON l.a = r.a AND l.b && r.b AND (l.k) != (r.k)
+// LIMIT 1
+//
+// The pred argument is a partial constraint predicate, which filters the subset
+// of rows that are constrained. If the constraint is not partial, pred should
+// be empty.
+//
+// If leftFilter is non-empty, it contains additional conditions on the columns
+// of the left side of the join, which restrict the query to the conflicts of
+// specific rows.
+func exclusionViolationQuery(
+	srcTbl catalog.TableDescriptor,
+	uc *descpb.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+	leftFilter []string,
+) (sql string, colNames []string, _ error) {
+	colNames, err := catalog.ColumnNamesForIDs(srcTbl, uc.ColumnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+	pkColNames, err := catalog.ColumnNamesForIDs(
+		srcTbl, srcTbl.GetPrimaryIndex().IndexDesc().KeyColumnIDs,
+	)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var projCols []string
+	seen := make(map[string]struct{})
+	for _, names := range [][]string{pkColNames, colNames} {
+		for _, n := range names {
+			if _, ok := seen[n]; !ok {
+				seen[n] = struct{}{}
+				projCols = append(projCols, tree.NameString(n))
+			}
+		}
+	}
+
+	srcWhere := make([]string, 0, len(colNames)+1)
+	for _, n := range colNames {
+		srcWhere = append(srcWhere, fmt.Sprintf("%s IS NOT NULL", tree.NameString(n)))
+	}
+	if uc.Predicate != "" {
+		srcWhere = append(srcWhere, fmt.Sprintf("(%s)", uc.Predicate))
+	}
+	src := fmt.Sprintf("[%d AS tbl]", srcTbl.GetID())
+	if indexIDForValidation != 0 {
+		src = fmt.Sprintf("[%d AS tbl]@[%d]", srcTbl.GetID(), indexIDForValidation)
+	}
+	subquery := fmt.Sprintf(
+		"SELECT %s FROM %s WHERE %s",
+		strings.Join(projCols, ", "), src, strings.Join(srcWhere, " AND "),
+	)
+	leftSubquery := subquery
+	if len(leftFilter) > 0 {
+		leftSubquery = fmt.Sprintf("%s AND %s", subquery, strings.Join(leftFilter, " AND "))
+	}
+
+	on := make([]string, 0, len(colNames)+1)
+	leftCols := make([]string, len(colNames))
+	rightCols := make([]string, len(colNames))
+	for i, n := range colNames {
+		leftCols[i] = "l." + tree.NameString(n)
+		rightCols[i] = "r." + tree.NameString(n)
+		on = append(on, fmt.Sprintf("%s %s %s", leftCols[i], uc.ExclusionOperators[i], rightCols[i]))
+	}
+	leftPK := make([]string, len(pkColNames))
+	rightPK := make([]string, len(pkColNames))
+	for i, n := range pkColNames {
+		leftPK[i] = "l." + tree.NameString(n)
+		rightPK[i] = "r." + tree.NameString(n)
+	}
+	on = append(on, fmt.Sprintf(
+		"(%s) != (%s)", strings.Join(leftPK, ", "), strings.Join(rightPK, ", "),
+	))
+
+	query := fmt.Sprintf(
+		`SELECT %[1]s, %[2]s FROM (%[3]s) AS l JOIN (%[4]s) AS r ON %[5]s LIMIT 1`,
+		strings.Join(leftCols, ", "),  // 1
+		strings.Join(rightCols, ", "), // 2
+		leftSubquery,                  // 3
+		subquery,                      // 4
+		strings.Join(on, " AND "),     // 5
+	)
+	return query, colNames, nil
+}
+
+// validateExclusionConstraint verifies that no two rows in the srcTable
+// conflict according to the given exclusion constraint. The arguments have the
+// same meaning as in validateUniqueConstraint.
+func validateExclusionConstraint(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	uc *descpb.UniqueWithoutIndexConstraint,
+	indexIDForValidation descpb.IndexID,
+	txn isql.Txn,
+	user username.SQLUsername,
+	preExisting bool,
+) error {
+	query, colNames, err := exclusionViolationQuery(
+		srcTable, uc, indexIDForValidation, nil, /* leftFilter */
+	)
+	if err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "validating exclusion constraint %q (%q [%v]) with query %q",
+		uc.Name,
+		srcTable.GetName(),
+		colNames,
+		query,
+	)
+
+	values, err := runValidationQuery(ctx, txn, user, "validate exclusion constraint", query)
+	if err != nil {
 		return err
 	}
 	if values.Len() > 0 {
@@ -679,21 +870,24 @@ func validateUniqueConstraint(
 		for i := range values {
 			valuesStr[i] = values[i].String()
 		}
+		n := len(colNames)
 		// Note: this error message mirrors the message produced by Postgres
-		// when it fails to add a unique index due to duplicated keys.
-		errMsg := "could not create unique constraint"
+		// when it fails to add an exclusion constraint due to conflicting rows.
+		errMsg := "could not create exclusion constraint"
 		if preExisting {
-			errMsg = "failed to validate unique constraint"
+			errMsg = "failed to validate exclusion constraint"
 		}
+		cols := strings.Join(colNames, ", ")
 		return errors.WithDetail(
 			pgerror.WithConstraintName(
 				pgerror.Newf(
-					pgcode.UniqueViolation, "%s %q", errMsg, constraintName,
+					pgcode.ExclusionViolation, "%s %q", errMsg, uc.Name,
 				),
-				constraintName,
+				uc.Name,
 			),
 			fmt.Sprintf(
-				"Key (%s)=(%s) is duplicated.", strings.Join(colNames, ","), strings.Join(valuesStr, ","),
+				"Key (%s)=(%s) conflicts with key (%s)=(%s).",
+				cols, strings.Join(valuesStr[:n], ", "), cols, strings.Join(valuesStr[n:], ", "),
 			),
 		)
 	}
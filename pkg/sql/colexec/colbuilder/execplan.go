@@ -192,6 +192,9 @@ func supportedNatively(core *execinfrapb.ProcessorCoreUnion) error {
 			if agg.FilterColIdx != nil {
 				return errFilteringAggregation
 			}
+			if agg.UserDefined != nil {
+				return errUserDefinedAggregation
+			}
 		}
 		return nil
 
@@ -261,6 +264,7 @@ var (
 	errWrappedCast                    = errors.New("mismatched types in NewColOperator and unsupported casts")
 	errLookupJoinUnsupported          = errors.New("lookup join reader is unsupported in vectorized")
 	errFilteringAggregation           = errors.New("filtering aggregation not supported")
+	errUserDefinedAggregation         = errors.New("user-defined aggregates are not supported")
 	errNonInnerHashJoinWithOnExpr     = errors.New("can't plan vectorized non-inner hash joins with ON expressions")
 	errNonInnerMergeJoinWithOnExpr    = errors.New("can't plan vectorized non-inner merge joins with ON expressions")
 	errWindowFunctionFilterClause     = errors.New("window functions with FILTER clause are not supported")
@@ -2244,8 +2248,18 @@ func planCastOperator(
 	evalCtx *eval.Context,
 ) (op colexecop.Operator, resultIdx int, typs []*types.T, err error) {
 	outputIdx := len(columnTypes)
-	op, err = colexecbase.GetCastOperator(colmem.NewAllocator(ctx, acc, factory), input, inputIdx, outputIdx, fromType, toType, evalCtx)
+	castType := toType
+	if toType.IsDomain() {
+		// The cast operators don't check the constraints of domains, so cast to
+		// the base type of the domain, which has the same physical
+		// representation, and check the constraints afterwards.
+		castType = toType.DomainBaseType()
+	}
+	op, err = colexecbase.GetCastOperator(colmem.NewAllocator(ctx, acc, factory), input, inputIdx, outputIdx, fromType, castType, evalCtx)
 	typs = append(columnTypes, toType)
+	if err == nil && toType.IsDomain() {
+		op = colexec.NewDomainCheckOp(evalCtx, op, len(typs), outputIdx, toType)
+	}
 	return op, outputIdx, typs, err
 }
 
@@ -0,0 +1,65 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexec
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colconv"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// domainCheckOp is an operator that checks that the values in a column, which
+// have already been cast to the base type of a domain, satisfy the NOT NULL
+// and CHECK constraints of the domain. It doesn't modify the batch.
+type domainCheckOp struct {
+	colexecop.OneInputHelper
+	evalCtx   *eval.Context
+	colIdx    int
+	typ       *types.T
+	converter *colconv.VecToDatumConverter
+}
+
+var _ colexecop.Operator = &domainCheckOp{}
+
+// NewDomainCheckOp returns an operator that checks the values in the column
+// at index colIdx against the constraints of the domain type typ. batchWidth
+// is the number of columns in the batches coming from input.
+func NewDomainCheckOp(
+	evalCtx *eval.Context, input colexecop.Operator, batchWidth int, colIdx int, typ *types.T,
+) colexecop.Operator {
+	return &domainCheckOp{
+		OneInputHelper: colexecop.MakeOneInputHelper(input),
+		evalCtx:        evalCtx,
+		colIdx:         colIdx,
+		typ:            typ,
+		converter:      colconv.NewVecToDatumConverter(batchWidth, []int{colIdx}, false /* willRelease */),
+	}
+}
+
+func (d *domainCheckOp) Next() coldata.Batch {
+	batch := d.Input.Next()
+	n := batch.Length()
+	if n == 0 {
+		return coldata.ZeroBatch
+	}
+	d.converter.ConvertBatchAndDeselect(batch)
+	// Note that the datum column is "dense", so the selection vector doesn't
+	// need to be applied.
+	for _, datum := range d.converter.GetDatumColumn(d.colIdx)[:n] {
+		if err := eval.CheckDomainConstraints(d.Ctx, d.evalCtx, datum, d.typ); err != nil {
+			colexecerror.ExpectedError(err)
+		}
+	}
+	return batch
+}
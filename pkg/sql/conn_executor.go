@@ -1174,7 +1174,18 @@ func (s *Server) newConnExecutor(
 		mode:   ex.sessionData().NewSchemaChangerMode,
 		memAcc: ex.sessionMon.MakeBoundAccount(),
 	}
+	deferredConstraintsAcc := ex.sessionMon.MakeBoundAccount()
+	ex.extraTxnState.deferredConstraints.init(&deferredConstraintsAcc)
 	ex.queryCancelKey = pgwirecancel.MakeBackendKeyData(ex.rng, ex.server.cfg.NodeInfo.NodeID.SQLInstanceID())
+	ex.extraTxnState.notifications.init(
+		s.cfg.NotificationRegistry,
+		ex.queryCancelKey.GetPGBackendPID(),
+		func() {
+			// Wake up the connExecutor so that it can deliver the notifications
+			// if the connection is idle.
+			_ /* err */ = stmtBuf.Push(ctx, DeliverNotifications{})
+		},
+	)
 	ex.mu.ActiveQueries = make(map[clusterunique.ID]*queryMeta)
 	ex.machine = fsm.MakeMachine(TxnStateTransitions, stateNoTxn{}, &ex.state)
 
@@ -1289,6 +1300,10 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 	}
 
 	ex.resetExtraTxnState(ctx, txnEvent{eventType: txnEvType}, payloadErr)
+	// Release the memory of deferred constraint checks, which is not reset
+	// above when the transaction is owned by an outer executor.
+	ex.extraTxnState.deferredConstraints.reset(ctx)
+	ex.extraTxnState.notifications.close()
 	if ex.hasCreatedTemporarySchema && !ex.server.cfg.TestingKnobs.DisableTempObjectsCleanupOnSessionExit {
 		err := cleanupSessionTempObjects(
 			ctx,
@@ -1453,6 +1468,14 @@ type connExecutor struct {
 		// validateDbZoneConfig should the DB zone config on commit.
 		validateDbZoneConfig bool
 
+		// deferredConstraints tracks the checks of deferrable constraints that
+		// are deferred until the transaction commits.
+		deferredConstraints deferredConstraintState
+
+		// notifications tracks the channels the session listens on and the
+		// LISTEN, UNLISTEN and NOTIFY statements of the current transaction.
+		notifications notificationState
+
 		// txnCounter keeps track of how many SQL txns have been open since
 		// the start of the session. This is used for logging, to
 		// distinguish statements that belong to separate SQL transactions.
@@ -2050,6 +2073,8 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent, pay
 		ex.extraTxnState.descCollection.ReleaseAll(ctx)
 		ex.extraTxnState.jobs.reset()
 		ex.extraTxnState.validateDbZoneConfig = false
+		ex.extraTxnState.deferredConstraints.reset(ctx)
+		ex.extraTxnState.notifications.reset()
 		ex.extraTxnState.schemaChangerState.memAcc.Clear(ctx)
 		ex.extraTxnState.schemaChangerState = &SchemaChangerState{
 			mode:   ex.sessionData().NewSchemaChangerMode,
@@ -2488,6 +2513,15 @@ func (ex *connExecutor) execCmd() (retErr error) {
 		//   was created when the statement started executing (via the
 		//   reset() method).
 		ex.statsCollector.PhaseTimes().SetSessionPhaseTime(sessionphase.SessionQueryServiced, timeutil.Now())
+	case StartReplication:
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionQueryReceived, tcmd.TimeReceived)
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionStartParse, tcmd.ParseStart)
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionEndParse, tcmd.ParseEnd)
+		replRes := ex.clientComm.CreateStartReplicationResult(tcmd, pos)
+		res = replRes
+		stmtCtx := withStatement(ctx, tcmd.Stmt)
+		ev, payload = ex.execStartReplication(stmtCtx, tcmd, replRes)
+		ex.statsCollector.PhaseTimes().SetSessionPhaseTime(sessionphase.SessionQueryServiced, timeutil.Now())
 	case DrainRequest:
 		// We received a drain request. We terminate immediately if we're not in a
 		// transaction. If we are in a transaction, we'll finish as soon as a Sync
@@ -2498,6 +2532,11 @@ func (ex *connExecutor) execCmd() (retErr error) {
 		if ex.idleConn() {
 			return errDrainingComplete
 		}
+	case DeliverNotifications:
+		// The session received notifications. They are delivered below if the
+		// connection is idle; otherwise, they'll be delivered with the Sync
+		// which ends the current transaction.
+		res = ex.clientComm.CreateNotificationResult(pos)
 	case Flush:
 		// Closing the res will flush the connection's buffer.
 		res = ex.clientComm.CreateFlushResult(pos)
@@ -2590,6 +2629,15 @@ func (ex *connExecutor) execCmd() (retErr error) {
 				}
 			}
 		}
+		// Deliver the notifications received by the session if the connection
+		// is idle. Otherwise, they'll be delivered once the current transaction
+		// ends.
+		switch cmd.(type) {
+		case Sync, DeliverNotifications:
+			if ex.idleConn() {
+				ex.extraTxnState.notifications.drain(res.(NotificationBuffer))
+			}
+		}
 		res.Close(ctx, stateToTxnStatusIndicator(ex.machine.CurState()))
 	} else {
 		res.Discard()
@@ -2766,8 +2814,12 @@ func (ex *connExecutor) updateTxnRewindPosMaybe(
 				// Can't advance.
 			case CopyOut:
 				// Can't advance.
+			case StartReplication:
+				// Can't advance.
 			case DrainRequest:
 				canAdvance = true
+			case DeliverNotifications:
+				canAdvance = true
 			case Flush:
 				canAdvance = true
 			default:
@@ -3052,6 +3104,82 @@ func (ex *connExecutor) setCopyLoggingFields(stmt statements.Statement[tree.Stat
 	ex.planner.curPlan.init(&ex.planner.stmt, &ex.planner.instrumentation)
 }
 
+// execStartReplication handles the START_REPLICATION command by streaming the
+// changes of a replication slot to the client until the client ends the
+// stream. As with execCopyIn, the contract is that the pgwire.conn is not
+// reading from the network connection until control is handed back through
+// cmd.ReplicationDone.
+func (ex *connExecutor) execStartReplication(
+	ctx context.Context, cmd StartReplication, res StartReplicationResult,
+) (retEv fsm.Event, retPayload fsm.EventPayload) {
+	// First handle connExecutor state transitions.
+	switch ex.machine.CurState().(type) {
+	case stateNoTxn:
+		return ex.beginImplicitTxn(ctx, cmd.ParsedStmt.AST, ex.QualityOfService())
+	case stateOpen:
+		if !ex.implicitTxn() {
+			cmd.ReplicationDone.Once.Do(cmd.ReplicationDone.WaitGroup.Done)
+			return ex.makeErrEvent(pgerror.New(pgcode.ActiveSQLTransaction,
+				"START_REPLICATION cannot be executed inside a transaction block"), cmd.Stmt)
+		}
+	default:
+		cmd.ReplicationDone.Once.Do(cmd.ReplicationDone.WaitGroup.Done)
+		return ex.makeErrEvent(sqlerrors.NewTransactionAbortedError("" /* customMsg */), cmd.Stmt)
+	}
+
+	ex.incrementStartedStmtCounter(cmd.Stmt)
+	var cancelQuery context.CancelFunc
+	ctx, cancelQuery = ctxlog.WithCancel(ctx)
+	queryID := ex.server.cfg.GenerateID()
+	ex.addActiveQuery(cmd.ParsedStmt, nil /* placeholders */, queryID, cancelQuery)
+	ex.metrics.EngineMetrics.SQLActiveStatements.Inc(1)
+
+	defer func() {
+		ex.removeActiveQuery(queryID, cmd.Stmt)
+		cancelQuery()
+		ex.metrics.EngineMetrics.SQLActiveStatements.Dec(1)
+		if !payloadHasError(retPayload) {
+			ex.incrementExecutedStmtCounter(cmd.Stmt)
+		}
+		if p, ok := retPayload.(payloadWithError); ok {
+			log.SqlExec.Errorf(ctx, "error executing %s: %+v", cmd, p.errorCause())
+		}
+	}()
+
+	// When we're done, unblock the network connection.
+	defer cmd.ReplicationDone.Once.Do(cmd.ReplicationDone.WaitGroup.Done)
+
+	stmtTS := ex.server.cfg.Clock.PhysicalTime()
+	ex.statsCollector.Reset(ex.applicationStats, ex.phaseTimes)
+	ex.resetPlanner(ctx, &ex.planner, ex.state.mu.txn, stmtTS)
+	ex.setCopyLoggingFields(cmd.ParsedStmt)
+
+	if err := func() error {
+		ex.mu.Lock()
+		defer ex.mu.Unlock()
+		queryMeta, ok := ex.mu.ActiveQueries[queryID]
+		if !ok {
+			return errors.AssertionFailedf("query %d not in registry", queryID)
+		}
+		queryMeta.phase = executing
+		return nil
+	}(); err != nil {
+		return ex.makeErrEvent(err, cmd.Stmt)
+	}
+
+	if err := runStartReplication(ctx, &ex.planner, cmd, res); err != nil {
+		// Cancellation of the query is reported as such rather than as
+		// whatever error the stream ran into while being canceled.
+		if ctx.Err() != nil {
+			err = cancelchecker.QueryCanceledError
+		}
+		ev := eventNonRetriableErr{IsCommit: fsm.False}
+		payload := eventNonRetriableErrPayload{err: err}
+		return ev, payload
+	}
+	return nil, nil
+}
+
 // We handle the CopyFrom statement by creating a copyMachine and handing it
 // control over the connection until the copying is done. The contract is that,
 // when this is called, the pgwire.conn is not reading from the network
@@ -3740,6 +3868,18 @@ func (ex *connExecutor) initEvalCtx(ctx context.Context, evalCtx *extendedEvalCo
 		indexUsageStats:      ex.indexUsageStats,
 		statementPreparer:    ex,
 	}
+	// Constraint checks cannot be deferred in an outer txn, since it is not
+	// committed by this connExecutor.
+	if !ex.extraTxnState.fromOuterTxn {
+		evalCtx.deferredConstraints = &ex.extraTxnState.deferredConstraints
+		evalCtx.DeferredConstraints = evalCtx.deferredConstraints
+	}
+	// Likewise, notifications can only be sent and received by a connExecutor
+	// which commits its own transactions.
+	if !ex.extraTxnState.fromOuterTxn && ex.server.cfg.NotificationRegistry != nil {
+		evalCtx.notifications = &ex.extraTxnState.notifications
+		evalCtx.Notifications = evalCtx.notifications
+	}
 	rng, _ := randutil.NewPseudoRand()
 	evalCtx.RNG = rng
 	evalCtx.copyFromExecCfg(ex.server.cfg)
@@ -4016,6 +4156,9 @@ func (ex *connExecutor) txnStateTransitionsApplyWrapper(
 		if err := ex.waitOneVersionForNewVersionDescriptorsWithoutJobs(descIDsInJobs); err != nil {
 			return advanceInfo{}, err
 		}
+		if ex.planner.extendedEvalCtx.notifications != nil {
+			ex.extraTxnState.notifications.commit()
+		}
 
 		fallthrough
 	case txnRollback:
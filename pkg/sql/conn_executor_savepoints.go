@@ -105,6 +105,7 @@ func (ex *connExecutor) execSavepointInOpenState(
 		commitOnRelease: commitOnRelease,
 		kvToken:         token,
 		numDDL:          ex.extraTxnState.numDDL,
+		notifications:   ex.extraTxnState.notifications.mark(),
 	}
 	savepoints.push(sp)
 	ex.sessionDataStack.PushTopClone()
@@ -213,6 +214,7 @@ func (ex *connExecutor) execRollbackToSavepointInOpenState(
 	if err := ex.popSavepointsToIdx(s, idx); err != nil {
 		return ex.makeErrEvent(err, s)
 	}
+	ex.extraTxnState.notifications.rollbackTo(entry.notifications)
 
 	if entry.kvToken.Initial() {
 		return eventTxnRestart{}, nil
@@ -289,6 +291,7 @@ func (ex *connExecutor) execRollbackToSavepointInAbortedState(
 	if err := ex.popSavepointsToIdx(s, idx); err != nil {
 		return ex.makeErrEvent(err, s)
 	}
+	ex.extraTxnState.notifications.rollbackTo(entry.notifications)
 
 	if err := ex.state.mu.txn.RollbackToSavepoint(ctx, entry.kvToken); err != nil {
 		return ex.makeErrEvent(err, s)
@@ -357,6 +360,11 @@ type savepoint struct {
 	// more DDL statements were executed since the savepoint's creation.
 	// TODO(knz): support partial DDL cancellation in pending txns.
 	numDDL int
+
+	// notifications marks the LISTEN, UNLISTEN and NOTIFY statements that had
+	// been executed in the transaction at the time the savepoint was created.
+	// Those executed since then are discarded when rolling back the savepoint.
+	notifications notificationMark
 }
 
 type savepointStack []savepoint
@@ -19,7 +19,10 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/notify"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -429,6 +432,48 @@ func (c CopyOut) String() string {
 
 var _ Command = CopyOut{}
 
+// StartReplication is the command for execution of the START_REPLICATION
+// command of the streaming replication pgwire subprotocol.
+type StartReplication struct {
+	ParsedStmt statements.Statement[tree.Statement]
+	Stmt       *pgrepltree.StartReplication
+	// Conn is the network connection. Execution of START_REPLICATION takes
+	// control of the connection, since the client sends status updates while
+	// changes are streamed.
+	Conn pgwirebase.Conn
+	// ReplicationDone is used to signal that control of the connection is
+	// being handed back to the network routine.
+	ReplicationDone struct {
+		// WaitGroup is decremented once execution finishes.
+		*sync.WaitGroup
+		// Once is used to decrement the WaitGroup exactly once.
+		*sync.Once
+	}
+	// TimeReceived is the time at which the message was received
+	// from the client. Used to compute the service latency.
+	TimeReceived time.Time
+	// ParseStart/ParseEnd are the timing info for parsing of the query. Used for
+	// stats reporting.
+	ParseStart time.Time
+	ParseEnd   time.Time
+}
+
+// command implements the Command interface.
+func (StartReplication) command() string { return "start replication" }
+
+// isExtendedProtocolCmd implements the Command interface.
+func (StartReplication) isExtendedProtocolCmd() bool { return false }
+
+func (c StartReplication) String() string {
+	s := "(empty)"
+	if c.Stmt != nil {
+		s = c.Stmt.String()
+	}
+	return fmt.Sprintf("StartReplication: %s", s)
+}
+
+var _ Command = StartReplication{}
+
 // DrainRequest represents a notice that the server is draining and command
 // processing should stop soon.
 //
@@ -447,6 +492,27 @@ func (DrainRequest) String() string {
 
 var _ Command = DrainRequest{}
 
+// DeliverNotifications represents a notice that the session received
+// notifications on the channels it listens on. If the connection is idle, the
+// notifications are sent to the client right away; otherwise, they are sent
+// with the ReadyForQuery message that ends the current transaction.
+//
+// DeliverNotifications commands don't produce results other than the
+// notifications.
+type DeliverNotifications struct{}
+
+// command implements the Command interface.
+func (DeliverNotifications) command() string { return "deliver notifications" }
+
+// isExtendedProtocolCmd implements the Command interface.
+func (DeliverNotifications) isExtendedProtocolCmd() bool { return false }
+
+func (DeliverNotifications) String() string {
+	return "DeliverNotifications"
+}
+
+var _ Command = DeliverNotifications{}
+
 // SendError is a command that, upon execution, send a specific error to the
 // client. This is used by pgwire to schedule errors to be sent at an
 // appropriate time.
@@ -741,8 +807,14 @@ type ClientComm interface {
 	CreateCopyInResult(cmd CopyIn, pos CmdPos) CopyInResult
 	// CreateCopyOutResult creates a result for a Copy-out command.
 	CreateCopyOutResult(cmd CopyOut, pos CmdPos) CopyOutResult
+	// CreateStartReplicationResult creates a result for a StartReplication
+	// command.
+	CreateStartReplicationResult(cmd StartReplication, pos CmdPos) StartReplicationResult
 	// CreateDrainResult creates a result for a Drain command.
 	CreateDrainResult(pos CmdPos) DrainResult
+	// CreateNotificationResult creates a result for a DeliverNotifications
+	// command.
+	CreateNotificationResult(pos CmdPos) NotificationResult
 
 	// LockCommunication ensures that no further results are delivered to the
 	// client. The returned ClientLock can be queried to see what results have
@@ -939,6 +1011,7 @@ type DeleteResult interface {
 // flushed.
 type SyncResult interface {
 	ResultBase
+	NotificationBuffer
 }
 
 // FlushResult represents the result of a Flush command. When this result is
@@ -953,6 +1026,26 @@ type DrainResult interface {
 	ResultBase
 }
 
+// NotificationResult represents the result of a DeliverNotifications command.
+// When closed, the buffered notifications are flushed to the client.
+type NotificationResult interface {
+	ResultBase
+	NotificationBuffer
+}
+
+// NotificationBuffer is implemented by the results which can carry the
+// notifications received by the session on the channels it listens on.
+type NotificationBuffer interface {
+	// BufferNotification buffers a notification to be sent to the client when
+	// the result is closed.
+	BufferNotification(n notify.Notification)
+
+	// BufferNotice appends a notice to the result, which is used to warn the
+	// client about dropped notifications.
+	// This gets flushed only when the result is closed.
+	BufferNotice(notice pgnotice.Notice)
+}
+
 // EmptyQueryResult represents the result of an empty query (a query
 // representing a blank string).
 type EmptyQueryResult interface {
@@ -983,6 +1076,28 @@ type CopyOutResult interface {
 
 	// SendCopyDone sends the copy done response to the client.
 	SendCopyDone(ctx context.Context) error
+
+	// SetRowsAffected sets the number of rows affected by the COPY. It is only
+	// needed when the data is not sent to the client with SendCopyData.
+	SetRowsAffected(ctx context.Context, n int)
+}
+
+// StartReplicationResult represents the result of a StartReplication command.
+// Closing this result sends a CommandComplete message to the client.
+type StartReplicationResult interface {
+	ResultBase
+
+	// SendCopyBothResponse sends the copy both response to the client, which
+	// starts the stream of replication messages.
+	SendCopyBothResponse(ctx context.Context) error
+
+	// SendReplicationMessage sends a replication message to the client in a
+	// CopyData message, and flushes it.
+	SendReplicationMessage(ctx context.Context, msg pgoutput.Message) error
+
+	// SendCopyDone sends the copy done response to the client, which ends the
+	// stream of replication messages.
+	SendCopyDone(ctx context.Context) error
 }
 
 // ClientLock is an interface returned by ClientComm.lockCommunication(). It
@@ -1098,6 +1213,11 @@ func (r *streamingCommandResult) BufferNotice(notice pgnotice.Notice) {
 	// Unimplemented: the internal executor does not support notices.
 }
 
+// BufferNotification is part of the NotificationBuffer interface.
+func (r *streamingCommandResult) BufferNotification(notify.Notification) {
+	// Unimplemented: the internal executor does not support notifications.
+}
+
 // SendNotice is part of the RestrictedCommandResult interface.
 func (r *streamingCommandResult) SendNotice(ctx context.Context, notice pgnotice.Notice) error {
 	// Unimplemented: the internal executor does not support notices.
@@ -1241,6 +1361,18 @@ func (r *streamingCommandResult) SendCopyDone(ctx context.Context) error {
 	return errors.AssertionFailedf("streamingCommandResult does not implement SendCopyDone")
 }
 
+// SendCopyBothResponse is part of the sql.StartReplicationResult interface.
+func (r *streamingCommandResult) SendCopyBothResponse(ctx context.Context) error {
+	return errors.AssertionFailedf("streamingCommandResult does not implement SendCopyBothResponse")
+}
+
+// SendReplicationMessage is part of the sql.StartReplicationResult interface.
+func (r *streamingCommandResult) SendReplicationMessage(
+	ctx context.Context, msg pgoutput.Message,
+) error {
+	return errors.AssertionFailedf("streamingCommandResult does not implement SendReplicationMessage")
+}
+
 // BulkJobInfoKey are for keys stored in pgwire.commandResult.bulkJobInfo.
 type BulkJobInfoKey string
 
@@ -18,6 +18,8 @@ import (
 	"io"
 	"math/rand"
 	"net/url"
+	"os"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strconv"
@@ -112,6 +114,10 @@ func TestDataDriven(t *testing.T) {
 					expectedRows--
 				}
 			}
+			// Rows may be filtered out by a WHERE clause.
+			if d.HasArg("rows") {
+				d.ScanArgs(t, "rows", &expectedRows)
+			}
 
 			if kvtrace {
 				err := conn.Exec(ctx, "SET TRACING=on,kv")
@@ -475,6 +481,82 @@ func TestCopyFromTimeout(t *testing.T) {
 	})
 }
 
+// TestCopyFile verifies that COPY can write the contents of a table to a file
+// in external storage, and read them back.
+func TestCopyFile(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	ctx := context.Background()
+
+	dir, dirCleanup := testutils.TempDir(t)
+	defer dirCleanup()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{ExternalIODir: dir})
+	defer srv.Stopper().Stop(ctx)
+
+	sqlDB := sqlutils.MakeSQLRunner(db)
+	sqlDB.Exec(t, "CREATE TABLE t (k INT PRIMARY KEY, v STRING)")
+	sqlDB.Exec(t, "INSERT INTO t VALUES (1, 'a'), (2, NULL), (3, 'b,c')")
+
+	for _, tc := range []struct {
+		format   string
+		expected string
+	}{
+		{format: "TEXT", expected: "1\ta\n2\t\\N\n3\tb,c\n"},
+		{format: "CSV", expected: "1,a\n2,\n3,\"b,c\"\n"},
+	} {
+		t.Run(tc.format, func(t *testing.T) {
+			name := "t." + strings.ToLower(tc.format)
+			res := sqlDB.Exec(t, fmt.Sprintf(
+				"COPY t TO 'nodelocal://1/%s' WITH (FORMAT %s)", name, tc.format,
+			))
+			n, err := res.RowsAffected()
+			require.NoError(t, err)
+			require.Equal(t, int64(3), n)
+			contents, err := os.ReadFile(filepath.Join(dir, name))
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(contents))
+
+			sqlDB.Exec(t, "CREATE TABLE u (k INT PRIMARY KEY, v STRING)")
+			defer sqlDB.Exec(t, "DROP TABLE u")
+			res = sqlDB.Exec(t, fmt.Sprintf(
+				"COPY u FROM 'nodelocal://1/%s' WITH (FORMAT %s) WHERE k > 1", name, tc.format,
+			))
+			n, err = res.RowsAffected()
+			require.NoError(t, err)
+			require.Equal(t, int64(2), n)
+			sqlDB.CheckQueryResults(t, "SELECT k, COALESCE(v, 'NULL') FROM u ORDER BY k", [][]string{
+				{"2", "NULL"}, {"3", "b,c"},
+			})
+		})
+	}
+
+	t.Run("query", func(t *testing.T) {
+		sqlDB.Exec(t, "COPY (SELECT k * 10 FROM t ORDER BY k) TO 'nodelocal://1/query'")
+		contents, err := os.ReadFile(filepath.Join(dir, "query"))
+		require.NoError(t, err)
+		require.Equal(t, "10\n20\n30\n", string(contents))
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		sqlDB.ExpectErr(t, "file doesn't exist", "COPY t FROM 'nodelocal://1/missing'")
+	})
+
+	t.Run("privileges", func(t *testing.T) {
+		sqlDB.Exec(t, "CREATE USER testuser")
+		sqlDB.Exec(t, "GRANT SELECT, INSERT ON t TO testuser")
+		userDB := sqlutils.MakeSQLRunner(
+			srv.ApplicationLayer().SQLConn(t, serverutils.User(username.TestUser)),
+		)
+		const privErr = "only users with the admin role or the EXTERNALIOIMPLICITACCESS " +
+			"system privilege are allowed to access the specified nodelocal URI"
+		userDB.ExpectErr(t, privErr, "COPY t TO 'nodelocal://1/user'")
+		userDB.ExpectErr(t, privErr, "COPY t FROM 'nodelocal://1/t.text'")
+
+		sqlDB.Exec(t, "GRANT SYSTEM EXTERNALIOIMPLICITACCESS TO testuser")
+		userDB.Exec(t, "COPY t TO 'nodelocal://1/user'")
+	})
+}
+
 func TestShowQueriesIncludesCopy(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 	defer log.Scope(t).Close(t)
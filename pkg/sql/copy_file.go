@@ -0,0 +1,156 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"io"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// CheckDestinationPrivilegesHook is the hook point for checking that the user
+// is allowed to access each of the given external storage URIs. It is set by
+// the cloudprivilege package, which cannot be imported here because it depends
+// on this package.
+var CheckDestinationPrivilegesHook = func(
+	ctx context.Context, p PlanHookState, uris []string,
+) error {
+	return errors.AssertionFailedf("external storage privilege checks are not available")
+}
+
+// copyFileChunkSize is the size of the chunks in which the file of a COPY FROM
+// statement is read from external storage.
+const copyFileChunkSize = 64 << 10 // 64 KiB
+
+// resolveCopyFileURI evaluates the URI of the file of a COPY TO or COPY FROM
+// statement, and checks that the user is allowed to access it. The URI may
+// refer to an external connection.
+func resolveCopyFileURI(ctx context.Context, p *planner, file tree.Expr) (string, error) {
+	uri, err := p.ExprEvaluator("COPY").String(ctx, file)
+	if err != nil {
+		return "", err
+	}
+	if err := CheckDestinationPrivilegesHook(ctx, p, []string{uri}); err != nil {
+		return "", err
+	}
+	return uri, nil
+}
+
+// readFile consumes all the data from the file in external storage and inserts
+// it in the database.
+func (c *copyMachine) readFile(ctx context.Context) error {
+	store, err := c.p.execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, c.fileURI, c.p.User())
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	r, _, err := store.ReadFile(ctx, "", cloud.ReadOptions{NoFileSize: true})
+	if err != nil {
+		return err
+	}
+	defer r.Close(ctx)
+
+	buf := make([]byte, copyFileChunkSize)
+	for {
+		n, err := r.Read(ctx, buf)
+		if n > 0 {
+			if err := c.processCopyData(
+				ctx, unsafeUint8ToString(buf[:n]), false, /* final */
+			); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return c.processCopyData(ctx, "" /* data */, true /* final */)
+}
+
+// fileCopyOutResult is a CopyOutResult that writes the data of a COPY TO
+// statement to a file in external storage. Only the number of rows copied is
+// sent to the client.
+type fileCopyOutResult struct {
+	CopyOutResult
+	w       io.WriteCloser
+	numRows int
+}
+
+var _ CopyOutResult = &fileCopyOutResult{}
+
+// openFileCopyOutResult opens the file of the given COPY TO statement for
+// writing. The returned cleanup function must be called once the copy is done,
+// and only persists the file if no error occurred.
+func openFileCopyOutResult(
+	ctx context.Context, p *planner, n *tree.CopyTo, res CopyOutResult,
+) (_ *fileCopyOutResult, cleanup func(error) error, _ error) {
+	uri, err := resolveCopyFileURI(ctx, p, n.File)
+	if err != nil {
+		return nil, nil, err
+	}
+	store, err := p.execCfg.DistSQLSrv.ExternalStorageFromURI(ctx, uri, p.User())
+	if err != nil {
+		return nil, nil, err
+	}
+	f := &fileCopyOutResult{CopyOutResult: res}
+	// Canceling the context of the writer aborts the upload, so that a partial
+	// file is not left behind if the copy fails.
+	writeCtx, cancel := context.WithCancel(ctx)
+	if f.w, err = store.Writer(writeCtx, ""); err != nil {
+		cancel()
+		return nil, nil, errors.CombineErrors(err, store.Close())
+	}
+	cleanup = func(err error) error {
+		if err != nil {
+			cancel()
+			_ = f.w.Close()
+		} else {
+			err = f.w.Close()
+			cancel()
+		}
+		return errors.CombineErrors(err, store.Close())
+	}
+	return f, cleanup, nil
+}
+
+// SendCopyOut is part of the CopyOutResult interface.
+func (f *fileCopyOutResult) SendCopyOut(
+	ctx context.Context, cols colinfo.ResultColumns, format pgwirebase.FormatCode,
+) error {
+	return nil
+}
+
+// SendCopyData is part of the CopyOutResult interface.
+func (f *fileCopyOutResult) SendCopyData(
+	ctx context.Context, copyData []byte, isHeader bool,
+) error {
+	if _, err := f.w.Write(copyData); err != nil {
+		return err
+	}
+	if !isHeader {
+		f.numRows++
+	}
+	return nil
+}
+
+// SendCopyDone is part of the CopyOutResult interface.
+func (f *fileCopyOutResult) SendCopyDone(ctx context.Context) error {
+	f.CopyOutResult.SetRowsAffected(ctx, f.numRows)
+	return nil
+}
@@ -28,6 +28,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/colconv"
 	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -87,6 +89,13 @@ type copyMachineInterface interface {
 type copyOptions struct {
 	csvEscape       rune
 	csvExpectHeader bool
+	// csvForceQuote, csvForceNotNull, and csvForceNull are the columns named
+	// by the FORCE_QUOTE, FORCE_NOT_NULL, and FORCE_NULL options, which are
+	// resolved against the copied columns by COPY TO and COPY FROM.
+	csvForceQuote    tree.NameList
+	csvForceQuoteAll bool
+	csvForceNotNull  tree.NameList
+	csvForceNull     tree.NameList
 
 	delimiter byte
 	format    tree.CopyFormat
@@ -126,6 +135,26 @@ func processCopyOptions(
 		}
 	}
 
+	if opts.ForceQuote != nil || opts.ForceQuoteAll {
+		if c.format != tree.CopyFormatCSV {
+			return c, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_QUOTE only supported with CSV format")
+		}
+		c.csvForceQuote = opts.ForceQuote
+		c.csvForceQuoteAll = opts.ForceQuoteAll
+	}
+	if opts.ForceNotNull != nil {
+		if c.format != tree.CopyFormatCSV {
+			return c, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_NOT_NULL only supported with CSV format")
+		}
+		c.csvForceNotNull = opts.ForceNotNull
+	}
+	if opts.ForceNull != nil {
+		if c.format != tree.CopyFormatCSV {
+			return c, pgerror.Newf(pgcode.FeatureNotSupported, "FORCE_NULL only supported with CSV format")
+		}
+		c.csvForceNull = opts.ForceNull
+	}
+
 	exprEval := p.ExprEvaluator("COPY")
 	if opts.Delimiter != nil {
 		if c.format == tree.CopyFormatBinary {
@@ -199,6 +228,32 @@ func processCopyOptions(
 	return c, nil
 }
 
+// resolveCopyForceColumns resolves the columns named by one of the FORCE_*
+// options against the columns being copied. It returns a slice indexed by
+// column ordinal, or nil if no columns were named.
+func resolveCopyForceColumns(
+	option string, names tree.NameList, cols colinfo.ResultColumns,
+) ([]bool, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	res := make([]bool, len(cols))
+	for _, name := range names {
+		found := false
+		for i := range cols {
+			if cols[i].Name == string(name) {
+				res[i] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, pgerror.Newf(pgcode.InvalidColumnReference,
+				"%s column %q not referenced by COPY", option, name)
+		}
+	}
+	return res, nil
+}
+
 // copyMachine supports the Copy-in pgwire subprotocol (COPY...FROM STDIN). The
 // machine is created by the Executor when that statement is executed; from that
 // moment on, the machine takes control of the pgwire connection until
@@ -226,8 +281,13 @@ type copyMachine struct {
 	// NULL. The spec says this is only supported for CSV, and also must specify
 	// which columns it applies to.
 	forceNotNull bool
-	csvInput     bytes.Buffer
-	csvReader    *csv.Reader
+	// forceNotNullCols and forceNullCols are indexed by column ordinal, and
+	// are set if the FORCE_NOT_NULL and FORCE_NULL options were specified,
+	// respectively. See isCSVNull.
+	forceNotNullCols []bool
+	forceNullCols    []bool
+	csvInput         bytes.Buffer
+	csvReader        *csv.Reader
 	// buf is used to parse input data into rows. It also accumulates a partial
 	// row between protocol messages.
 	buf []byte
@@ -236,6 +296,16 @@ type copyMachine struct {
 	// insertedRows keeps track of the total number of rows inserted by the
 	// machine.
 	insertedRows int
+	// where, if set, is the WHERE condition that rows must satisfy in order to
+	// be inserted. Its column references are IndexedVars that are resolved by
+	// whereContainer.
+	where          tree.TypedExpr
+	whereContainer schemaexpr.RowIndexedVarContainer
+	// whereConverter, whereSel, and whereBatch are used to filter the batch of
+	// rows in the vectorized path. See filterBatch.
+	whereConverter *colconv.VecToDatumConverter
+	whereSel       []int
+	whereBatch     coldata.Batch
 	// copyMon tracks copy's memory usage.
 	copyMon *mon.BytesMonitor
 	// rowsMemAcc accounts for memory used by `rows`.
@@ -246,6 +316,9 @@ type copyMachine struct {
 
 	// conn is the pgwire connection from which data is to be read.
 	conn pgwirebase.Conn
+	// fileURI, if set, is the URI of the file in external storage from which
+	// data is to be read instead of conn.
+	fileURI string
 
 	// execInsertPlan is a function to be used to execute the plan (stored in the
 	// planner) which performs an INSERT.
@@ -266,6 +339,7 @@ type copyMachine struct {
 
 	scratchRow    []tree.Datum
 	batch         coldata.Batch
+	alloc         *colmem.Allocator
 	accHelper     colmem.SetAccountingHelper
 	typs          []*types.T
 	valueHandlers []tree.ValueHandler
@@ -294,6 +368,9 @@ func newCopyMachine(
 	if err != nil {
 		return nil, err
 	}
+	if cOpts.csvForceQuote != nil || cOpts.csvForceQuoteAll {
+		return nil, pgerror.New(pgcode.FeatureNotSupported, "FORCE_QUOTE only supported with COPY TO")
+	}
 	c := &copyMachine{
 		conn:        conn,
 		copyFromAST: n,
@@ -327,6 +404,11 @@ func newCopyMachine(
 	if err := c.p.CheckPrivilege(ctx, tableDesc, privilege.INSERT); err != nil {
 		return nil, err
 	}
+	if n.File != nil {
+		if c.fileURI, err = resolveCopyFileURI(ctx, c.p, n.File); err != nil {
+			return nil, err
+		}
+	}
 	cols, err := colinfo.ProcessTargetColumns(tableDesc, n.Columns,
 		true /* ensureColumns */, false /* allowMutations */)
 	if err != nil {
@@ -344,6 +426,28 @@ func newCopyMachine(
 		typs[i] = col.GetType()
 	}
 	c.typs = typs
+	if c.forceNotNullCols, err = resolveCopyForceColumns(
+		"FORCE_NOT_NULL", cOpts.csvForceNotNull, c.resultColumns,
+	); err != nil {
+		return nil, err
+	}
+	if c.forceNullCols, err = resolveCopyForceColumns(
+		"FORCE_NULL", cOpts.csvForceNull, c.resultColumns,
+	); err != nil {
+		return nil, err
+	}
+	if n.Where != nil {
+		c.where, err = schemaexpr.MakeCopyFromWhereExpr(
+			ctx, n.Where.Expr, tableDesc, cols, c.p.EvalContext(), &c.p.semaCtx,
+		)
+		if err != nil {
+			return nil, err
+		}
+		c.whereContainer = schemaexpr.RowIndexedVarContainer{Cols: cols}
+		for i, col := range cols {
+			c.whereContainer.Mapping.Set(col.GetID(), i)
+		}
+	}
 	// If there are no column specifiers and we expect non-visible columns
 	// to have field data then we have to populate the expectedHiddenColumnIdxs
 	// field with the columns indexes we expect to be hidden.
@@ -448,6 +552,7 @@ func (c *copyMachine) initVectorizedCopy(ctx context.Context, typs []*types.T) e
 	alloc := colmem.NewLimitedAllocator(ctx, &c.rowsMemAcc, nil /*optional unlimited memory account*/, factory)
 	alloc.SetMaxBatchSize(c.copyBatchRowSize)
 	// TODO(cucaroach): Avoid allocating selection vector.
+	c.alloc = alloc
 	c.accHelper.Init(alloc, c.maxRowMem, typs, false /*alwaysReallocate*/)
 	// Start with small number of rows, compromise between going too big and
 	// overallocating memory and avoiding some doubling growth batches.
@@ -476,6 +581,10 @@ func (c *copyMachine) initVectorizedCopy(ctx context.Context, typs []*types.T) e
 	for i := range typs {
 		c.valueHandlers[i] = coldataext.MakeVecHandler(c.batch.ColVec(i))
 	}
+	if c.where != nil {
+		c.whereConverter = colconv.NewAllVecToDatumConverter(len(typs))
+		c.scratchRow = make(tree.Datums, len(typs))
+	}
 	return nil
 }
 
@@ -525,23 +634,10 @@ func (c *copyMachine) Close(ctx context.Context) {
 	c.copyMon.Stop(ctx)
 }
 
-// run consumes all the copy-in data from the network connection and inserts it
-// in the database.
+// run consumes all the copy-in data from the network connection, or from the
+// file in external storage if one was specified, and inserts it in the
+// database.
 func (c *copyMachine) run(ctx context.Context) error {
-	format := pgwirebase.FormatText
-	if c.format == tree.CopyFormatBinary {
-		format = pgwirebase.FormatBinary
-	}
-	// Send the message describing the columns to the client.
-	if err := c.conn.BeginCopyIn(ctx, c.resultColumns, format); err != nil {
-		return err
-	}
-
-	// Read from the connection until we see an ClientMsgCopyDone.
-	readBuf := pgwirebase.MakeReadBuffer(
-		pgwirebase.ReadBufferOptionWithClusterSettings(&c.p.execCfg.Settings.SV),
-	)
-
 	switch c.format {
 	case tree.CopyFormatText:
 		c.textDelim = []byte{c.delimiter}
@@ -556,6 +652,24 @@ func (c *copyMachine) run(ctx context.Context) error {
 		}
 	}
 
+	if c.fileURI != "" {
+		return c.readFile(ctx)
+	}
+
+	format := pgwirebase.FormatText
+	if c.format == tree.CopyFormatBinary {
+		format = pgwirebase.FormatBinary
+	}
+	// Send the message describing the columns to the client.
+	if err := c.conn.BeginCopyIn(ctx, c.resultColumns, format); err != nil {
+		return err
+	}
+
+	// Read from the connection until we see an ClientMsgCopyDone.
+	readBuf := pgwirebase.MakeReadBuffer(
+		pgwirebase.ReadBufferOptionWithClusterSettings(&c.p.execCfg.Settings.SV),
+	)
+
 Loop:
 	for {
 		typ, _, err := readBuf.ReadTypedMsg(c.conn.Rd())
@@ -846,9 +960,7 @@ func (c *copyMachine) readCSVTuple(ctx context.Context, record []csv.Record) err
 	if c.vectorized {
 		vh := c.valueHandlers
 		for i, s := range record {
-			// NB: When we implement FORCE_NULL, then quoted values also are allowed
-			// to be treated as NULL.
-			if !s.Quoted && s.Val == c.null {
+			if c.isCSVNull(i, s) {
 				vh[i].Null()
 				continue
 			}
@@ -860,9 +972,7 @@ func (c *copyMachine) readCSVTuple(ctx context.Context, record []csv.Record) err
 	} else {
 		datums := c.scratchRow
 		for i, s := range record {
-			// NB: When we implement FORCE_NULL, then quoted values also are allowed
-			// to be treated as NULL.
-			if !s.Quoted && s.Val == c.null {
+			if c.isCSVNull(i, s) {
 				datums[i] = tree.DNull
 				continue
 			}
@@ -872,13 +982,27 @@ func (c *copyMachine) readCSVTuple(ctx context.Context, record []csv.Record) err
 			}
 			datums[i] = d
 		}
-		if _, err := c.rows.AddRow(ctx, datums); err != nil {
+		if err := c.addRow(ctx, datums); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// isCSVNull returns whether the CSV field for the column with the given
+// ordinal is NULL. Unquoted fields that match the null string are NULL, unless
+// FORCE_NOT_NULL was specified for the column. Quoted fields that match the
+// null string are only NULL if FORCE_NULL was specified for the column.
+func (c *copyMachine) isCSVNull(colIdx int, s csv.Record) bool {
+	if s.Val != c.null {
+		return false
+	}
+	if s.Quoted {
+		return c.forceNullCols != nil && c.forceNullCols[colIdx]
+	}
+	return c.forceNotNullCols == nil || !c.forceNotNullCols[colIdx]
+}
+
 func (c *copyMachine) readBinaryData(ctx context.Context, final bool) (brk bool, err error) {
 	if len(c.expectedHiddenColumnIdxs) > 0 {
 		return false, pgerror.Newf(
@@ -974,8 +1098,7 @@ func (c *copyMachine) readBinaryTuple(ctx context.Context) (bytesRead int, err e
 		}
 		datums[i] = d
 	}
-	_, err = c.rows.AddRow(ctx, datums)
-	if err != nil {
+	if err := c.addRow(ctx, datums); err != nil {
 		return bytesRead, err
 	}
 	return bytesRead, nil
@@ -1134,8 +1257,91 @@ func (c *copyMachine) insertRows(ctx context.Context, finalBatch bool) error {
 	return err
 }
 
+// addRow buffers a row that is to be inserted, unless it does not satisfy the
+// WHERE condition.
+func (c *copyMachine) addRow(ctx context.Context, datums tree.Datums) error {
+	if c.where != nil {
+		if ok, err := c.evalWhere(ctx, datums); err != nil || !ok {
+			return err
+		}
+	}
+	_, err := c.rows.AddRow(ctx, datums)
+	return err
+}
+
+// evalWhere returns whether the given row satisfies the WHERE condition.
+func (c *copyMachine) evalWhere(ctx context.Context, datums tree.Datums) (bool, error) {
+	c.whereContainer.CurSourceRow = datums
+	evalCtx := c.p.EvalContext()
+	evalCtx.PushIVarContainer(&c.whereContainer)
+	defer evalCtx.PopIVarContainer()
+	d, err := eval.Expr(ctx, evalCtx, c.where)
+	if err != nil {
+		return false, err
+	}
+	return d == tree.DBoolTrue, nil
+}
+
+// filterBatch removes the rows of the vectorized batch that do not satisfy the
+// WHERE condition. The remaining rows are compacted to the start of the batch,
+// since the vectorized insert does not support selection vectors.
+func (c *copyMachine) filterBatch(ctx context.Context) error {
+	n := c.batch.Length()
+	if n == 0 {
+		return nil
+	}
+	c.whereConverter.ConvertBatch(c.batch)
+	sel := c.whereSel[:0]
+	for i := 0; i < n; i++ {
+		for j := range c.scratchRow {
+			c.scratchRow[j] = c.whereConverter.GetDatumColumn(j)[i]
+		}
+		ok, err := c.evalWhere(ctx, c.scratchRow)
+		if err != nil {
+			return err
+		}
+		if ok {
+			sel = append(sel, i)
+		}
+	}
+	c.whereSel = sel
+	if len(sel) == n {
+		return nil
+	}
+	if len(sel) == 0 {
+		c.batch.SetLength(0)
+		return nil
+	}
+	return colexecerror.CatchVectorizedRuntimeError(func() {
+		c.whereBatch, _ = c.alloc.ResetMaybeReallocateNoMemLimit(c.typs, c.whereBatch, len(sel))
+		c.alloc.PerformOperation(c.whereBatch.ColVecs(), func() {
+			for i := range c.typs {
+				c.whereBatch.ColVec(i).Copy(coldata.SliceArgs{
+					Src:       c.batch.ColVec(i),
+					Sel:       sel,
+					SrcEndIdx: len(sel),
+				})
+			}
+		})
+		c.alloc.PerformOperation(c.batch.ColVecs(), func() {
+			for i := range c.typs {
+				c.batch.ColVec(i).Copy(coldata.SliceArgs{
+					Src:       c.whereBatch.ColVec(i),
+					SrcEndIdx: len(sel),
+				})
+			}
+		})
+		c.batch.SetLength(len(sel))
+	})
+}
+
 // insertRowsInternal transforms the buffered rows into an insertNode and executes it.
 func (c *copyMachine) insertRowsInternal(ctx context.Context, finalBatch bool) (retErr error) {
+	if c.vectorized && c.where != nil {
+		if err := c.filterBatch(ctx); err != nil {
+			return err
+		}
+	}
 	numRows := c.currentBatchSize()
 	if numRows == 0 {
 		return nil
@@ -1285,8 +1491,7 @@ func (c *copyMachine) readTextTupleDatum(ctx context.Context, parts [][]byte) er
 
 		datums[i] = d
 	}
-	_, err := c.rows.AddRow(ctx, datums)
-	return err
+	return c.addRow(ctx, datums)
 }
 
 func (c *copyMachine) readTextTupleVec(ctx context.Context, parts [][]byte) error {
@@ -17,6 +17,8 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
@@ -76,6 +78,9 @@ type csvCopyToTranslater struct {
 	b      bytes.Buffer
 	fmtCtx *tree.FmtCtx
 	w      *csv.Writer
+	// forceQuoteCols is indexed by column ordinal, and is set if the
+	// FORCE_QUOTE option named specific columns.
+	forceQuoteCols []bool
 }
 
 func (c *csvCopyToTranslater) translateRow(
@@ -83,7 +88,7 @@ func (c *csvCopyToTranslater) translateRow(
 ) ([]byte, error) {
 	c.b.Reset()
 	c.fmtCtx.Buffer.Reset()
-	for _, d := range datums {
+	for i, d := range datums {
 		if d == tree.DNull {
 			if err := c.w.WriteField(bytes.NewBufferString(c.null)); err != nil {
 				return nil, err
@@ -97,6 +102,10 @@ func (c *csvCopyToTranslater) translateRow(
 			if err := c.w.ForceEmptyField(); err != nil {
 				return nil, err
 			}
+		} else if c.csvForceQuoteAll || (c.forceQuoteCols != nil && c.forceQuoteCols[i]) {
+			if err := c.w.WriteQuotedField(bytes.NewBuffer(c.fmtCtx.Buffer.Bytes())); err != nil {
+				return nil, err
+			}
 		} else {
 			if err := c.w.WriteField(bytes.NewBuffer(c.fmtCtx.Buffer.Bytes())); err != nil {
 				return nil, err
@@ -135,6 +144,24 @@ func runCopyTo(
 	if err != nil {
 		return 0, err
 	}
+	if copyOptions.csvForceNotNull != nil {
+		return 0, pgerror.New(pgcode.FeatureNotSupported, "FORCE_NOT_NULL only supported with COPY FROM")
+	}
+	if copyOptions.csvForceNull != nil {
+		return 0, pgerror.New(pgcode.FeatureNotSupported, "FORCE_NULL only supported with COPY FROM")
+	}
+	if cmd.Stmt.File != nil {
+		// The data is written to a file in external storage instead of being
+		// sent to the client.
+		f, cleanup, err := openFileCopyOutResult(ctx, p, cmd.Stmt, res)
+		if err != nil {
+			return 0, err
+		}
+		defer func() {
+			retErr = cleanup(retErr)
+		}()
+		res = f
+	}
 
 	wireFormat := pgwirebase.FormatText
 	var t copyToTranslater
@@ -202,6 +229,14 @@ func runCopyTo(
 		}
 	}()
 
+	if csvTranslater, ok := t.(*csvCopyToTranslater); ok {
+		if csvTranslater.forceQuoteCols, err = resolveCopyForceColumns(
+			"FORCE_QUOTE", copyOptions.csvForceQuote, it.Types(),
+		); err != nil {
+			return 0, err
+		}
+	}
+
 	// Send the message describing the columns to the client.
 	if err := res.SendCopyOut(ctx, it.Types(), wireFormat); err != nil {
 		return 0, err
@@ -0,0 +1,511 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+type createAggregateNode struct {
+	n      *tree.CreateAggregate
+	dbDesc catalog.DatabaseDescriptor
+	scDesc catalog.SchemaDescriptor
+}
+
+// Use to satisfy the linter.
+var _ planNode = &createAggregateNode{n: nil}
+
+// CreateAggregate creates a user-defined aggregate function.
+// Privileges: CREATE on schema, EXECUTE on the support functions.
+func (p *planner) CreateAggregate(ctx context.Context, n *tree.CreateAggregate) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE AGGREGATE",
+	); err != nil {
+		return nil, err
+	}
+	if !p.execCfg.Settings.Version.IsActive(ctx, clusterversion.V24_1) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to create aggregates",
+			clusterversion.V24_1)
+	}
+
+	dbDesc, scDesc, _, err := p.ResolveTargetObject(ctx, n.Name.ToUnresolvedObjectName())
+	if err != nil {
+		return nil, err
+	}
+	return &createAggregateNode{n: n, dbDesc: dbDesc, scDesc: scDesc}, nil
+}
+
+func (n *createAggregateNode) ReadingOwnWrites() {}
+
+func (n *createAggregateNode) startExec(params runParams) error {
+	if err := params.p.canCreateOnSchema(
+		params.ctx, n.scDesc.GetID(), n.dbDesc.GetID(), params.p.User(), skipCheckPublicSchema,
+	); err != nil {
+		return err
+	}
+	if n.scDesc.SchemaKind() == catalog.SchemaTemporary {
+		return unimplemented.NewWithIssue(104687, "cannot create UDFs under a temporary schema")
+	}
+
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("aggregate"))
+
+	pbParams := make([]descpb.FunctionDescriptor_Parameter, len(n.n.Params))
+	argTypes := make([]*types.T, len(n.n.Params))
+	for i, param := range n.n.Params {
+		switch param.Class {
+		case tree.RoutineParamDefault, tree.RoutineParamIn:
+		case tree.RoutineParamVariadic:
+			return unimplemented.New("variadic aggregates", "VARIADIC aggregates are not yet supported")
+		default:
+			return pgerror.New(pgcode.InvalidFunctionDefinition, "aggregates cannot have output arguments")
+		}
+		if param.DefaultVal != nil {
+			return pgerror.New(pgcode.InvalidFunctionDefinition, "aggregates cannot have default arguments")
+		}
+		pbParam, err := makeFunctionParam(params.ctx, params.p.SemaCtx(), param, params.p)
+		if err != nil {
+			return err
+		}
+		pbParams[i] = pbParam
+		argTypes[i] = pbParam.Type
+	}
+
+	agg, returnType, vol, err := n.makeAggregateDefinition(params, argTypes)
+	if err != nil {
+		return err
+	}
+	funcVolatility := catpb.Function_IMMUTABLE
+	switch vol {
+	case volatility.Stable:
+		funcVolatility = catpb.Function_STABLE
+	case volatility.Volatile:
+		funcVolatility = catpb.Function_VOLATILE
+	}
+
+	mutScDesc, err := params.p.descCollection.MutableByName(params.p.Txn()).Schema(params.ctx, n.dbDesc, n.scDesc.GetName())
+	if err != nil {
+		return err
+	}
+
+	// Try to look up an existing aggregate or function with the same
+	// signature.
+	routineObj := tree.RoutineObj{
+		FuncName: n.n.Name,
+		Params:   n.n.Params,
+	}
+	if routineObj.Params == nil {
+		// A nil list of parameters would match any signature.
+		routineObj.Params = tree.RoutineParams{}
+	}
+	existing, err := params.p.matchRoutine(
+		params.ctx, &routineObj, false, /* required */
+		tree.UDFRoutine|tree.ProcedureRoutine, false, /* inDropContext */
+	)
+	if err != nil {
+		return err
+	}
+
+	fnName := tree.MakeQualifiedRoutineName(n.dbDesc.GetName(), n.scDesc.GetName(), n.n.Name.Object())
+	event := eventpb.CreateFunction{
+		FunctionName: fnName.FQString(),
+		IsReplace:    existing != nil,
+	}
+
+	var fnDesc *funcdesc.Mutable
+	if existing != nil {
+		if !n.n.Replace {
+			return pgerror.Newf(
+				pgcode.DuplicateFunction,
+				"function %q already exists with same argument types",
+				n.n.Name.Object(),
+			)
+		}
+		fnDesc, err = params.p.checkPrivilegesForDropFunction(
+			params.ctx, funcdesc.UserDefinedFunctionOIDToID(existing.Oid),
+		)
+		if err != nil {
+			return err
+		}
+		if !fnDesc.IsAggregate() {
+			formatStr := "%q is a function"
+			if fnDesc.IsProcedure() {
+				formatStr = "%q is a procedure"
+			}
+			return errors.WithDetailf(
+				pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind"),
+				formatStr,
+				fnDesc.GetName(),
+			)
+		}
+		if !returnType.Identical(fnDesc.ReturnType.Type) {
+			return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"cannot change return type of existing function")
+		}
+		if err := n.removeAggregateReferences(params, fnDesc); err != nil {
+			return err
+		}
+	} else {
+		id, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+		if err != nil {
+			return err
+		}
+		privileges, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+			n.dbDesc.GetDefaultPrivilegeDescriptor(),
+			mutScDesc.GetDefaultPrivilegeDescriptor(),
+			n.dbDesc.GetID(),
+			params.SessionData().User(),
+			privilege.Routines,
+		)
+		if err != nil {
+			return err
+		}
+		newDesc := funcdesc.NewMutableFunctionDescriptor(
+			id,
+			n.dbDesc.GetID(),
+			mutScDesc.GetID(),
+			n.n.Name.Object(),
+			pbParams,
+			returnType,
+			false, /* returnSet */
+			false, /* isProcedure */
+			privileges,
+		)
+		fnDesc = &newDesc
+	}
+	fnDesc.Aggregate = agg
+	fnDesc.SetVolatility(funcVolatility)
+	fnDesc.SetLeakProof(false)
+	if err := n.addAggregateReferences(params, fnDesc, argTypes); err != nil {
+		return err
+	}
+
+	if existing != nil {
+		if err := params.p.writeFuncSchemaChange(params.ctx, fnDesc); err != nil {
+			return err
+		}
+		return params.p.logEvent(params.ctx, fnDesc.GetID(), &event)
+	}
+
+	if err := params.p.createDescriptor(
+		params.ctx,
+		fnDesc,
+		tree.AsStringWithFQNames(&n.n.Name, params.Ann()),
+	); err != nil {
+		return err
+	}
+	mutScDesc.AddFunction(
+		fnDesc.GetName(),
+		descpb.SchemaDescriptor_FunctionSignature{
+			ID:          fnDesc.GetID(),
+			ArgTypes:    argTypes,
+			ReturnType:  returnType,
+			IsAggregate: true,
+		},
+	)
+	if err := params.p.writeSchemaDescChange(params.ctx, mutScDesc, "Create Aggregate"); err != nil {
+		return err
+	}
+	return params.p.logEvent(params.ctx, fnDesc.GetID(), &event)
+}
+
+func (*createAggregateNode) Next(params runParams) (bool, error) { return false, nil }
+func (*createAggregateNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*createAggregateNode) Close(ctx context.Context)           {}
+
+// makeAggregateDefinition validates the options of the CREATE AGGREGATE
+// statement and resolves the support functions they reference. It returns the
+// definition of the aggregate, its return type, and its volatility, which is
+// the volatility of the most volatile support function.
+func (n *createAggregateNode) makeAggregateDefinition(
+	params runParams, argTypes []*types.T,
+) (_ *descpb.FunctionDescriptor_Aggregate, returnType *types.T, _ volatility.V, _ error) {
+	var stateFunc, finalFunc, combineFunc, stateType *tree.AggregateOption
+	var initCond *string
+	for i := range n.n.Options {
+		o := &n.n.Options[i]
+		var target **tree.AggregateOption
+		switch strings.ToLower(o.Name) {
+		case tree.AggregateOptionStateFunc:
+			target = &stateFunc
+		case tree.AggregateOptionStateType:
+			target = &stateType
+		case tree.AggregateOptionFinalFunc:
+			target = &finalFunc
+		case tree.AggregateOptionCombineFunc:
+			target = &combineFunc
+		case tree.AggregateOptionInitCond:
+			if o.Value == nil {
+				return nil, nil, 0, pgerror.Newf(pgcode.Syntax,
+					"aggregate attribute %q must be a string or numeric constant", o.Name)
+			}
+			s := tree.AsStringWithFlags(o.Value, tree.FmtBareStrings)
+			initCond = &s
+			continue
+		default:
+			return nil, nil, 0, pgerror.Newf(pgcode.Syntax,
+				"aggregate attribute %q not recognized", o.Name)
+		}
+		if o.Type == nil {
+			return nil, nil, 0, pgerror.Newf(pgcode.Syntax,
+				"aggregate attribute %q must be a name", o.Name)
+		}
+		*target = o
+	}
+	if stateType == nil {
+		return nil, nil, 0, pgerror.New(pgcode.InvalidFunctionDefinition,
+			"aggregate stype must be specified")
+	}
+	if stateFunc == nil {
+		return nil, nil, 0, pgerror.New(pgcode.InvalidFunctionDefinition,
+			"aggregate sfunc must be specified")
+	}
+
+	typ, err := tree.ResolveType(params.ctx, stateType.Type, params.p)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if typ.Family() == types.AnyFamily || typ.IsWildcardType() {
+		return nil, nil, 0, unimplemented.Newf("polymorphic aggregates",
+			"aggregates with a polymorphic state type are not yet supported")
+	}
+	agg := &descpb.FunctionDescriptor_Aggregate{StateType: typ}
+
+	// The state transition function is called with the current state followed
+	// by the arguments of the aggregate, and returns the new state.
+	sfuncArgs := append([]*types.T{typ}, argTypes...)
+	sfunc, err := n.resolveSupportFunc(params, stateFunc, sfuncArgs)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if err := checkSupportFuncReturnType(params, sfunc, "transition", typ); err != nil {
+		return nil, nil, 0, err
+	}
+	agg.StateFunc = sfunc.Oid
+	vol := sfunc.Volatility
+	returnType = typ
+
+	if finalFunc != nil {
+		ffunc, err := n.resolveSupportFunc(params, finalFunc, []*types.T{typ})
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		agg.FinalFunc = ffunc.Oid
+		returnType = ffunc.ReturnType(nil /* args */)
+		if ffunc.Volatility > vol {
+			vol = ffunc.Volatility
+		}
+	}
+
+	if combineFunc != nil {
+		cfunc, err := n.resolveSupportFunc(params, combineFunc, []*types.T{typ, typ})
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		if err := checkSupportFuncReturnType(params, cfunc, "combine", typ); err != nil {
+			return nil, nil, 0, err
+		}
+		agg.CombineFunc = cfunc.Oid
+		if cfunc.Volatility > vol {
+			vol = cfunc.Volatility
+		}
+	}
+
+	if initCond != nil {
+		if _, err := rowenc.ParseDatumStringAs(
+			params.ctx, typ, *initCond, params.EvalContext(), params.p.SemaCtx(),
+		); err != nil {
+			return nil, nil, 0, err
+		}
+		agg.InitCond = initCond
+	} else if !sfunc.CalledOnNullInput && (len(argTypes) == 0 || !argTypes[0].Identical(typ)) {
+		// When the transition function is strict and there is no initial
+		// state, the first non-NULL input becomes the initial state, so its
+		// type must match the state type.
+		return nil, nil, 0, pgerror.New(pgcode.InvalidFunctionDefinition,
+			"must not omit initial value when transition function is strict and "+
+				"transition type is not compatible with input type")
+	}
+	return agg, returnType, vol, nil
+}
+
+// resolveSupportFunc resolves the function referenced by the given option of
+// the CREATE AGGREGATE statement, which must accept arguments of the given
+// types. Both builtin and user-defined functions may be used.
+func (n *createAggregateNode) resolveSupportFunc(
+	params runParams, opt *tree.AggregateOption, argTypes []*types.T,
+) (*tree.Overload, error) {
+	name, ok := opt.Type.(*tree.UnresolvedObjectName)
+	if !ok {
+		return nil, pgerror.Newf(pgcode.UndefinedFunction,
+			"function %s does not exist", opt.Type.SQLString())
+	}
+	routineParams := make(tree.RoutineParams, len(argTypes))
+	for i, typ := range argTypes {
+		routineParams[i] = tree.RoutineParam{Type: typ, Class: tree.RoutineParamIn}
+	}
+	routineObj := tree.RoutineObj{
+		FuncName: name.ToRoutineName(),
+		Params:   routineParams,
+	}
+	path := params.p.CurrentSearchPath()
+	fnDef, err := params.p.ResolveFunction(
+		params.ctx, tree.MakeUnresolvedFunctionName(name.ToUnresolvedName()), &path,
+	)
+	if err != nil {
+		return nil, err
+	}
+	ol, err := fnDef.MatchOverload(
+		params.ctx, params.p, &routineObj, &path, tree.BuiltinRoutine|tree.UDFRoutine,
+		false /* inDropContext */, false, /* tryDefaultExprs */
+	)
+	if err != nil {
+		return nil, err
+	}
+	// The overloads of user-defined functions resolved by name only contain
+	// the signature, so look up the full definition.
+	_, fn, err := params.p.ResolveFunctionByOID(params.ctx, ol.Oid)
+	if err != nil {
+		return nil, err
+	}
+	if fn.Class != tree.NormalClass || fn.Variadic || fn.Type == tree.ProcedureRoutine {
+		return nil, pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"function %s cannot be used as an aggregate %s",
+			fnDef.Name, opt.Name)
+	}
+	if fn.Type == tree.UDFRoutine {
+		fnDesc, err := params.p.Descriptors().ByIDWithLeased(params.p.Txn()).WithoutNonPublic().Get().Function(
+			params.ctx, funcdesc.UserDefinedFunctionOIDToID(fn.Oid),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := params.p.CheckPrivilege(params.ctx, fnDesc, privilege.EXECUTE); err != nil {
+			return nil, err
+		}
+	}
+	return fn, nil
+}
+
+// checkSupportFuncReturnType returns an error if the given support function
+// of an aggregate does not return the state type.
+func checkSupportFuncReturnType(
+	params runParams, fn *tree.Overload, kind string, stateType *types.T,
+) error {
+	if ret := fn.ReturnType(nil /* args */); ret == nil || !ret.Identical(stateType) {
+		return pgerror.Newf(pgcode.DatatypeMismatch,
+			"return type of %s function is not %s", kind, stateType.SQLStringForError())
+	}
+	return nil
+}
+
+// supportFuncIDs returns the IDs of the user-defined support functions of the
+// given aggregate.
+func supportFuncIDs(agg *descpb.FunctionDescriptor_Aggregate) catalog.DescriptorIDSet {
+	var ids catalog.DescriptorIDSet
+	for _, fnOid := range []oid.Oid{agg.StateFunc, agg.FinalFunc, agg.CombineFunc} {
+		if funcdesc.IsOIDUserDefinedFunc(fnOid) {
+			ids.Add(funcdesc.UserDefinedFunctionOIDToID(fnOid))
+		}
+	}
+	return ids
+}
+
+// addAggregateReferences adds references from the aggregate to the types and
+// user-defined support functions it depends on, along with the corresponding
+// back references.
+func (n *createAggregateNode) addAggregateReferences(
+	params runParams, fnDesc *funcdesc.Mutable, argTypes []*types.T,
+) error {
+	fnIDs := supportFuncIDs(fnDesc.Aggregate)
+	fnDesc.DependsOnFunctions = fnIDs.Ordered()
+	for _, id := range fnDesc.DependsOnFunctions {
+		backRefDesc, err := params.p.Descriptors().MutableByID(params.p.Txn()).Function(params.ctx, id)
+		if err != nil {
+			return err
+		}
+		if dbID := backRefDesc.GetParentID(); dbID != n.dbDesc.GetID() && dbID != keys.SystemDatabaseID {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"dependent function %s cannot be from another database", backRefDesc.GetName())
+		}
+		if err := backRefDesc.AddFunctionReference(fnDesc.GetID()); err != nil {
+			return err
+		}
+		if err := params.p.writeFuncSchemaChange(params.ctx, backRefDesc); err != nil {
+			return err
+		}
+	}
+
+	var typeIDs catalog.DescriptorIDSet
+	for _, typ := range append([]*types.T{fnDesc.Aggregate.StateType, fnDesc.ReturnType.Type}, argTypes...) {
+		typedesc.GetTypeDescriptorClosure(typ).ForEach(typeIDs.Add)
+	}
+	fnDesc.DependsOnTypes = typeIDs.Ordered()
+	for _, id := range fnDesc.DependsOnTypes {
+		jobDesc := fmt.Sprintf("updating type back reference %d for aggregate %d", id, fnDesc.GetID())
+		if err := params.p.addTypeBackReference(params.ctx, id, fnDesc.GetID(), jobDesc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeAggregateReferences removes the back references to an aggregate which
+// is being replaced from the types and functions it depends on.
+func (n *createAggregateNode) removeAggregateReferences(
+	params runParams, fnDesc *funcdesc.Mutable,
+) error {
+	jobDesc := fmt.Sprintf("updating type back reference %d for aggregate %d", fnDesc.DependsOnTypes, fnDesc.GetID())
+	if err := params.p.removeTypeBackReferences(params.ctx, fnDesc.DependsOnTypes, fnDesc.GetID(), jobDesc); err != nil {
+		return err
+	}
+	for _, id := range fnDesc.DependsOnFunctions {
+		backRefDesc, err := params.p.Descriptors().MutableByID(params.p.Txn()).Function(params.ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := backRefDesc.RemoveFunctionReference(fnDesc.GetID()); err != nil {
+			return err
+		}
+		if err := params.p.writeFuncSchemaChange(params.ctx, backRefDesc); err != nil {
+			return err
+		}
+	}
+	fnDesc.DependsOnTypes = nil
+	fnDesc.DependsOnFunctions = nil
+	return nil
+}
@@ -0,0 +1,284 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
+	"github.com/cockroachdb/errors"
+)
+
+type createCastNode struct {
+	n *tree.CreateCast
+}
+
+// CreateCast creates a cast between two types, which is performed by calling a
+// user-defined function. The cast is stored on the descriptor of the function.
+// Privileges: ownership of the function, and of the source or target type.
+func (p *planner) CreateCast(ctx context.Context, n *tree.CreateCast) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE CAST",
+	); err != nil {
+		return nil, err
+	}
+	if !p.execCfg.Settings.Version.IsActive(ctx, clusterversion.V24_1) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to create casts",
+			clusterversion.V24_1)
+	}
+	return &createCastNode{n: n}, nil
+}
+
+func (n *createCastNode) ReadingOwnWrites() {}
+
+func (n *createCastNode) startExec(params runParams) error {
+	src, tgt, err := params.p.resolveCastTypes(params.ctx, n.n.Source, n.n.Target)
+	if err != nil {
+		return err
+	}
+	if src.Oid() == tgt.Oid() {
+		return pgerror.New(pgcode.InvalidObjectDefinition,
+			"source data type and target data type are the same")
+	}
+	if !src.UserDefined() && !tgt.UserDefined() {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"casts between built-in types cannot be created")
+	}
+	if err := params.p.checkCastTypeOwnership(params.ctx, src, tgt); err != nil {
+		return err
+	}
+	if _, ok, err := params.p.LookupUserDefinedCast(params.ctx, src, tgt); err != nil {
+		return err
+	} else if ok {
+		return pgerror.Newf(pgcode.DuplicateObject, "cast from type %s to type %s already exists",
+			src, tgt)
+	}
+
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("cast"))
+
+	routineObj := n.n.Func
+	if routineObj.Params == nil {
+		// A nil list of parameters would match any signature.
+		routineObj.Params = tree.RoutineParams{{Type: src, Class: tree.RoutineParamIn}}
+	}
+	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &routineObj)
+	if err != nil {
+		return err
+	}
+	if fnDesc.IsProcedure() || fnDesc.IsAggregate() || fnDesc.ReturnType.ReturnSet {
+		return pgerror.Newf(pgcode.InvalidObjectDefinition,
+			"%s cannot be used as a cast function", fnDesc.GetName())
+	}
+	if len(fnDesc.Params) != 1 || fnDesc.Params[0].Type.Oid() != src.Oid() {
+		return pgerror.New(pgcode.InvalidObjectDefinition,
+			"cast function must take one argument of the source data type")
+	}
+	if fnDesc.ReturnType.Type.Oid() != tgt.Oid() {
+		return pgerror.New(pgcode.InvalidObjectDefinition,
+			"return data type of cast function must match target data type")
+	}
+	fnDesc.SetCastContext(castContextToProto(n.n.Context))
+	return params.p.writeFuncSignatureChange(params.ctx, fnDesc, "create cast")
+}
+
+func (*createCastNode) Next(params runParams) (bool, error) { return false, nil }
+func (*createCastNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*createCastNode) Close(ctx context.Context)           {}
+
+type dropCastNode struct {
+	n *tree.DropCast
+}
+
+// DropCast drops a user-defined cast.
+// Privileges: ownership of the function of the cast.
+func (p *planner) DropCast(ctx context.Context, n *tree.DropCast) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP CAST",
+	); err != nil {
+		return nil, err
+	}
+	return &dropCastNode{n: n}, nil
+}
+
+func (n *dropCastNode) ReadingOwnWrites() {}
+
+func (n *dropCastNode) startExec(params runParams) error {
+	src, tgt, err := params.p.resolveCastTypes(params.ctx, n.n.Source, n.n.Target)
+	if err != nil {
+		return err
+	}
+	c, ok, err := params.p.LookupUserDefinedCast(params.ctx, src, tgt)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if n.n.IfExists {
+			return nil
+		}
+		return pgerror.Newf(pgcode.UndefinedObject, "cast from type %s to type %s does not exist",
+			src, tgt)
+	}
+
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("cast"))
+
+	fnDesc, err := params.p.checkPrivilegesForDropFunction(
+		params.ctx, funcdesc.UserDefinedFunctionOIDToID(c.Func),
+	)
+	if err != nil {
+		return err
+	}
+	fnDesc.SetCastContext(catpb.Function_NOT_A_CAST)
+	return params.p.writeFuncSignatureChange(params.ctx, fnDesc, "drop cast")
+}
+
+func (*dropCastNode) Next(params runParams) (bool, error) { return false, nil }
+func (*dropCastNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*dropCastNode) Close(ctx context.Context)           {}
+
+// resolveCastTypes resolves the source and target types of a CREATE CAST or
+// DROP CAST statement.
+func (p *planner) resolveCastTypes(
+	ctx context.Context, source, target tree.ResolvableTypeReference,
+) (src, tgt *types.T, _ error) {
+	src, err := tree.ResolveType(ctx, source, p)
+	if err != nil {
+		return nil, nil, err
+	}
+	tgt, err = tree.ResolveType(ctx, target, p)
+	if err != nil {
+		return nil, nil, err
+	}
+	return src, tgt, nil
+}
+
+// checkCastTypeOwnership returns an error if the current user owns neither
+// the source nor the target type of a cast.
+func (p *planner) checkCastTypeOwnership(ctx context.Context, src, tgt *types.T) error {
+	for _, typ := range []*types.T{src, tgt} {
+		if !typ.UserDefined() {
+			continue
+		}
+		typDesc, err := p.Descriptors().ByIDWithLeased(p.txn).WithoutNonPublic().Get().Type(
+			ctx, typedesc.UserDefinedTypeOIDToID(typ.Oid()),
+		)
+		if err != nil {
+			return err
+		}
+		hasOwnership, err := p.HasOwnership(ctx, typDesc)
+		if err != nil {
+			return err
+		}
+		if hasOwnership {
+			return nil
+		}
+	}
+	return pgerror.Newf(pgcode.InsufficientPrivilege, "must be owner of type %s or type %s",
+		src, tgt)
+}
+
+// writeFuncSignatureChange writes the descriptor of a function whose casts or
+// operators have changed, and updates its signature in the schema descriptor
+// to match.
+func (p *planner) writeFuncSignatureChange(
+	ctx context.Context, fnDesc *funcdesc.Mutable, jobDesc string,
+) error {
+	scDesc, err := p.Descriptors().MutableByID(p.txn).Schema(ctx, fnDesc.GetParentSchemaID())
+	if err != nil {
+		return err
+	}
+	scDesc.RemoveFunction(fnDesc.GetName(), fnDesc.GetID())
+	scDesc.AddFunction(fnDesc.GetName(), toSchemaOverloadSignature(fnDesc))
+	if err := p.writeFuncSchemaChange(ctx, fnDesc); err != nil {
+		return err
+	}
+	return p.writeSchemaDescChange(ctx, scDesc, jobDesc)
+}
+
+// LookupUserDefinedCast implements the cast.UserDefinedResolver interface.
+// The casts are stored on the descriptors of their functions, so the function
+// signatures of every schema in the current database are searched.
+func (sr *schemaResolver) LookupUserDefinedCast(
+	ctx context.Context, src, tgt *types.T,
+) (_ cast.Cast, ok bool, _ error) {
+	if sr.txn == nil || sr.CurrentDatabase() == "" {
+		return cast.Cast{}, false, nil
+	}
+	db, err := sr.MustGetCurrentSessionDatabase(ctx)
+	if err != nil {
+		return cast.Cast{}, false, err
+	}
+	g := sr.byIDGetterBuilder().WithoutNonPublic().Get()
+	var found descpb.SchemaDescriptor_FunctionSignature
+	if err := db.ForEachSchema(func(id descpb.ID, _ string) error {
+		sc, err := g.Schema(ctx, id)
+		if err != nil {
+			return err
+		}
+		return sc.ForEachFunctionSignature(func(sig descpb.SchemaDescriptor_FunctionSignature) error {
+			if sig.CastContext != catpb.Function_NOT_A_CAST && len(sig.ArgTypes) == 1 &&
+				sig.ArgTypes[0].Oid() == src.Oid() && sig.ReturnType.Oid() == tgt.Oid() {
+				found, ok = sig, true
+				return iterutil.StopIteration()
+			}
+			return nil
+		})
+	}); err != nil || !ok {
+		return cast.Cast{}, false, err
+	}
+	fnDesc, err := g.Function(ctx, found.ID)
+	if err != nil {
+		return cast.Cast{}, false, err
+	}
+	ol, err := fnDesc.ToOverload()
+	if err != nil {
+		return cast.Cast{}, false, err
+	}
+	return cast.MakeUserDefinedCast(castContextFromProto(found.CastContext), ol.Volatility, ol.Oid), true, nil
+}
+
+func castContextToProto(c cast.Context) catpb.Function_CastContext {
+	switch c {
+	case cast.ContextAssignment:
+		return catpb.Function_ASSIGNMENT_CAST
+	case cast.ContextImplicit:
+		return catpb.Function_IMPLICIT_CAST
+	case cast.ContextExplicit:
+		return catpb.Function_EXPLICIT_CAST
+	}
+	panic(errors.AssertionFailedf("unexpected cast context %d", c))
+}
+
+func castContextFromProto(c catpb.Function_CastContext) cast.Context {
+	switch c {
+	case catpb.Function_ASSIGNMENT_CAST:
+		return cast.ContextAssignment
+	case catpb.Function_IMPLICIT_CAST:
+		return cast.ContextImplicit
+	}
+	return cast.ContextExplicit
+}
@@ -0,0 +1,38 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// CreateDomain creates a domain. It is only implemented in the declarative
+// schema changer.
+func (p *planner) CreateDomain(_ context.Context, n *tree.CreateDomain) (planNode, error) {
+	return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+		"%s is only implemented in the declarative schema changer", n.StatementTag())
+}
+
+// AlterDomain alters a domain. See CreateDomain.
+func (p *planner) AlterDomain(_ context.Context, n *tree.AlterDomain) (planNode, error) {
+	return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+		"%s is only implemented in the declarative schema changer", n.StatementTag())
+}
+
+// DropDomain drops a domain. See CreateDomain.
+func (p *planner) DropDomain(_ context.Context, n *tree.DropDomain) (planNode, error) {
+	return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+		"%s is only implemented in the declarative schema changer", n.StatementTag())
+}
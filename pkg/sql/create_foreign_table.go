@@ -0,0 +1,233 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud/externalconn"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type createForeignTableNode struct {
+	n      *tree.CreateForeignTable
+	dbDesc catalog.DatabaseDescriptor
+}
+
+// CreateForeignTable creates a foreign table, whose rows are read from files
+// in external storage.
+// Privileges: CREATE on schema, USAGE on the external connection.
+func (p *planner) CreateForeignTable(
+	ctx context.Context, n *tree.CreateForeignTable,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE FOREIGN TABLE",
+	); err != nil {
+		return nil, err
+	}
+	if !p.execCfg.Settings.Version.IsActive(ctx, clusterversion.V24_1) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to create foreign tables",
+			clusterversion.V24_1)
+	}
+
+	dbDesc, _, prefix, err := p.ResolveTargetObject(ctx, n.Table.ToUnresolvedObjectName())
+	if err != nil {
+		return nil, err
+	}
+	n.Table.ObjectNamePrefix = prefix
+
+	return &createForeignTableNode{n: n, dbDesc: dbDesc}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because CREATE FOREIGN TABLE performs multiple KV operations on
+// descriptors and expects to see its own writes.
+func (n *createForeignTableNode) ReadingOwnWrites() {}
+
+func (n *createForeignTableNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("foreign_table"))
+
+	schema, err := getSchemaForCreateTable(params, n.dbDesc, tree.PersistencePermanent, &n.n.Table,
+		tree.ResolveRequireTableDesc, n.n.IfNotExists)
+	if err != nil {
+		if sqlerrors.IsRelationAlreadyExistsError(err) && n.n.IfNotExists {
+			params.p.BufferClientNotice(
+				params.ctx,
+				pgnotice.Newf("relation %q already exists, skipping", n.n.Table.Table()),
+			)
+			return nil
+		}
+		return err
+	}
+
+	// The server of a foreign table is an external connection, which must exist
+	// and be usable by the creator of the table.
+	server := string(n.n.Server)
+	if _, err := externalconn.LoadExternalConnection(
+		params.ctx, server, params.p.InternalSQLTxn(),
+	); err != nil {
+		return err
+	}
+	if err := params.p.CheckPrivilege(
+		params.ctx, &syntheticprivilege.ExternalConnectionPrivilege{ConnectionName: server}, privilege.USAGE,
+	); err != nil {
+		return err
+	}
+
+	foreignTable := &descpb.TableDescriptor_ForeignTable{Server: server}
+	for _, o := range n.n.Options {
+		s, ok := o.Value.(*tree.StrVal)
+		if !ok {
+			return errors.AssertionFailedf("unexpected value of foreign table option %s: %T", o.Key, o.Value)
+		}
+		foreignTable.Options = append(foreignTable.Options, descpb.TableDescriptor_ForeignTable_Option{
+			Key:   strings.ToLower(string(o.Key)),
+			Value: s.RawString(),
+		})
+	}
+	// Validate the options now, so that a foreign table which cannot be read is
+	// not created.
+	if _, _, err := makeForeignTableFormat(foreignTable); err != nil {
+		return err
+	}
+
+	privs, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Tables,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return err
+	}
+	// creationTime is initialized to a zero value and populated at read time.
+	// See the comment in desc.MaybeIncrementVersion.
+	var creationTime hlc.Timestamp
+	desc := tabledesc.InitTableDescriptor(
+		id,
+		n.dbDesc.GetID(),
+		schema.GetID(),
+		n.n.Table.Table(),
+		creationTime,
+		privs,
+		tree.PersistencePermanent,
+	)
+	desc.ForeignTable = foreignTable
+	if n.dbDesc.IsMultiRegion() {
+		desc.SetTableLocalityGlobal()
+	}
+
+	for _, def := range n.n.Defs {
+		d, ok := def.(*tree.ColumnTableDef)
+		if !ok {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"foreign tables do not support constraints or indexes")
+		}
+		if err := checkForeignTableColumnDef(d); err != nil {
+			return err
+		}
+		cdd, err := tabledesc.MakeColumnDefDescs(
+			params.ctx, d, &params.p.semaCtx, params.EvalContext(), tree.ColumnDefaultExprInNewTable,
+		)
+		if err != nil {
+			return err
+		}
+		if cdd.ColumnDescriptor.Type.UserDefined() {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"column %q: foreign tables do not support user-defined types", d.Name)
+		}
+		desc.AddColumn(cdd.ColumnDescriptor)
+	}
+	version := params.ExecCfg().Settings.Version.ActiveVersion(params.ctx)
+	if err := desc.AllocateIDs(params.ctx, version); err != nil {
+		return err
+	}
+
+	if err := params.p.createDescriptor(
+		params.ctx,
+		&desc,
+		tree.AsStringWithFQNames(n.n, params.Ann()),
+	); err != nil {
+		return err
+	}
+	if err := validateDescriptor(params.ctx, params.p, &desc); err != nil {
+		return err
+	}
+
+	// Log Create Table event. This is an auditable log event and is recorded in
+	// the same transaction as the table descriptor update.
+	return params.p.logEvent(params.ctx,
+		desc.ID,
+		&eventpb.CreateTable{
+			TableName: n.n.Table.FQString(),
+		})
+}
+
+func (*createForeignTableNode) Next(runParams) (bool, error) { return false, nil }
+func (*createForeignTableNode) Values() tree.Datums          { return tree.Datums{} }
+func (*createForeignTableNode) Close(context.Context)        {}
+
+// checkForeignTableColumnDef returns an error if the column definition of a
+// foreign table has a qualification other than NULL or NOT NULL. Foreign
+// tables are read-only, so defaults and computed columns are meaningless, and
+// constraints other than NOT NULL cannot be enforced on data in external
+// storage.
+func checkForeignTableColumnDef(d *tree.ColumnTableDef) error {
+	var what string
+	switch {
+	case d.IsSerial:
+		what = "SERIAL columns"
+	case d.HasDefaultExpr():
+		what = "DEFAULT expressions"
+	case d.HasOnUpdateExpr():
+		what = "ON UPDATE expressions"
+	case d.IsComputed():
+		what = "computed columns"
+	case d.GeneratedIdentity.IsGeneratedAsIdentity:
+		what = "identity columns"
+	case d.Hidden:
+		what = "hidden columns"
+	case d.HasColumnFamily():
+		what = "column families"
+	case d.PrimaryKey.IsPrimaryKey, d.Unique.IsUnique, len(d.CheckExprs) > 0, d.HasFKConstraint():
+		what = "constraints or indexes"
+	default:
+		return nil
+	}
+	return pgerror.Newf(pgcode.FeatureNotSupported,
+		"column %q: foreign tables do not support %s", d.Name, what)
+}
@@ -13,7 +13,9 @@ package sql
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
@@ -24,10 +26,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
@@ -55,6 +59,18 @@ func (n *createFunctionNode) startExec(params runParams) error {
 	if n.cf.RoutineBody != nil {
 		return unimplemented.NewWithIssue(85144, "CREATE FUNCTION...sql_body unimplemented")
 	}
+	for _, p := range n.cf.Params {
+		switch p.Class {
+		case tree.RoutineParamVariadic:
+			if err := checkRoutineFeatureVersion(params, "VARIADIC parameters"); err != nil {
+				return err
+			}
+		case tree.RoutineParamTable:
+			if err := checkRoutineFeatureVersion(params, "RETURNS TABLE"); err != nil {
+				return err
+			}
+		}
+	}
 
 	if err := params.p.canCreateOnSchema(
 		params.ctx, n.scDesc.GetID(), n.dbDesc.GetID(), params.p.User(), skipCheckPublicSchema,
@@ -159,12 +175,14 @@ func (n *createFunctionNode) createNewFunction(
 	var outParamOrdinals []int32
 	var outParamTypes []*types.T
 	var defaultExprs []string
+	var isVariadic bool
 	for paramIdx, param := range udfDesc.Params {
 		class := funcdesc.ToTreeRoutineParamClass(param.Class)
 		if tree.IsInParamClass(class) {
 			signatureTypes = append(signatureTypes, param.Type)
+			isVariadic = class == tree.RoutineParamVariadic
 		}
-		if class == tree.RoutineParamOut {
+		if class == tree.RoutineParamOut || class == tree.RoutineParamTable {
 			outParamOrdinals = append(outParamOrdinals, int32(paramIdx))
 			outParamTypes = append(outParamTypes, param.Type)
 		}
@@ -183,6 +201,7 @@ func (n *createFunctionNode) createNewFunction(
 			OutParamOrdinals: outParamOrdinals,
 			OutParamTypes:    outParamTypes,
 			DefaultExprs:     defaultExprs,
+			IsVariadic:       isVariadic,
 		},
 	)
 	if err := params.p.writeSchemaDescChange(params.ctx, scDesc, "Create Function"); err != nil {
@@ -199,6 +218,13 @@ func (n *createFunctionNode) replaceFunction(
 	existing *tree.QualifiedOverload,
 ) error {
 
+	if udfDesc.IsAggregate() {
+		return errors.WithDetailf(
+			pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind"),
+			"%q is an aggregate function",
+			udfDesc.Name,
+		)
+	}
 	if n.cf.IsProcedure != udfDesc.IsProcedure() {
 		formatStr := "%q is a function"
 		if udfDesc.IsProcedure() {
@@ -246,12 +272,16 @@ func (n *createFunctionNode) replaceFunction(
 	var outParamOrdinals []int32
 	var outParamTypes []*types.T
 	var defaultExprs []string
+	var isVariadic bool
 	for i, p := range n.cf.Params {
 		udfDesc.Params[i], err = makeFunctionParam(params.ctx, params.p.SemaCtx(), p, params.p)
 		if err != nil {
 			return err
 		}
-		if p.Class == tree.RoutineParamOut {
+		if p.IsInParam() {
+			isVariadic = p.Class == tree.RoutineParamVariadic
+		}
+		if p.Class == tree.RoutineParamOut || p.Class == tree.RoutineParamTable {
 			outParamOrdinals = append(outParamOrdinals, int32(i))
 			outParamTypes = append(outParamTypes, udfDesc.Params[i].Type)
 		}
@@ -305,7 +335,7 @@ func (n *createFunctionNode) replaceFunction(
 	}
 
 	signatureChanged := len(existing.OutParamOrdinals) != len(outParamOrdinals) ||
-		len(existing.DefaultExprs) != len(defaultExprs)
+		len(existing.DefaultExprs) != len(defaultExprs) || existing.Variadic != isVariadic
 	for i := 0; !signatureChanged && i < len(outParamOrdinals); i++ {
 		signatureChanged = existing.OutParamOrdinals[i] != outParamOrdinals[i] ||
 			!existing.OutParamTypes.GetAt(i).Equivalent(outParamTypes[i])
@@ -333,6 +363,9 @@ func (n *createFunctionNode) replaceFunction(
 				OutParamOrdinals: outParamOrdinals,
 				OutParamTypes:    outParamTypes,
 				DefaultExprs:     defaultExprs,
+				IsVariadic:       isVariadic,
+				Operators:        udfDesc.Operators,
+				CastContext:      udfDesc.CastContext,
 			},
 		); err != nil {
 			return err
@@ -564,6 +597,22 @@ func setFuncOptions(
 			// Handle the body after the loop, since we don't yet know what language
 			// it is.
 			body = string(t)
+		case tree.RoutineSecurity:
+			if err := checkRoutineFeatureVersion(params, "SECURITY"); err != nil {
+				return err
+			}
+			sec, err := funcinfo.SecurityToProto(t)
+			if err != nil {
+				return err
+			}
+			udfDesc.SetSecurity(sec)
+		case *tree.SetVar:
+			if err := checkRoutineFeatureVersion(params, "SET"); err != nil {
+				return err
+			}
+			if err := setFuncSessionSetting(params, udfDesc, t); err != nil {
+				return err
+			}
 		default:
 			return pgerror.Newf(pgcode.InvalidParameterValue, "Unknown function option %q", t)
 		}
@@ -585,11 +634,86 @@ func setFuncOptions(
 	return nil
 }
 
+// checkRoutineFeatureVersion returns an error if the cluster version does not
+// yet allow the given routine feature, which older nodes do not know about.
+func checkRoutineFeatureVersion(params runParams, feature string) error {
+	if !params.p.execCfg.Settings.Version.IsActive(params.ctx, clusterversion.V24_1) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use %s in routines", clusterversion.V24_1, feature)
+	}
+	return nil
+}
+
+// setFuncSessionSetting applies a SET or RESET clause of a function to the
+// session variables which are set while the function executes.
+func setFuncSessionSetting(params runParams, udfDesc *funcdesc.Mutable, n *tree.SetVar) error {
+	if n.ResetAll {
+		udfDesc.ResetAllSessionSettings()
+		return nil
+	}
+	name := strings.ToLower(n.Name)
+	_, v, err := getSessionVar(name, false /* missingOk */)
+	if err != nil {
+		return err
+	}
+	if v.Set == nil {
+		if v.RuntimeSet == nil && v.SetWithPlanner == nil {
+			return newCannotChangeParameterError(name)
+		}
+		return unimplemented.Newf("routine set",
+			"setting %q for the duration of a function is not supported", name)
+	}
+	if len(n.Values) == 1 {
+		if _, ok := n.Values[0].(tree.DefaultVal); ok {
+			// "SET var = DEFAULT" and "RESET var" remove the setting.
+			udfDesc.ResetSessionSetting(name)
+			return nil
+		}
+	}
+
+	typedValues := make([]tree.TypedExpr, len(n.Values))
+	for i, expr := range n.Values {
+		expr = paramparse.UnresolvedNameToStrVal(expr)
+		var dummyHelper tree.IndexedVarHelper
+		typedValue, err := params.p.analyzeExpr(
+			params.ctx, expr, dummyHelper, types.String, false, "SET "+name)
+		if err != nil {
+			return wrapSetVarError(err, name, expr.String())
+		}
+		typedValues[i], err = eval.Expr(params.ctx, params.EvalContext(), typedValue)
+		if err != nil {
+			return err
+		}
+	}
+	var strVal string
+	if v.GetStringVal != nil {
+		strVal, err = v.GetStringVal(params.ctx, params.extendedEvalCtx, typedValues, params.p.Txn())
+	} else {
+		strVal, err = getStringVal(params.ctx, params.EvalContext(), name, typedValues)
+	}
+	if err != nil {
+		return err
+	}
+	// Validate the value by applying it to a copy of the session data, so that
+	// invalid values are rejected when the function is created rather than
+	// when it is called.
+	m := params.p.sessionDataMutatorIterator.mutator(
+		false /* applyCallbacks */, params.SessionData().Clone(),
+	)
+	if err := v.Set(params.ctx, m, strVal); err != nil {
+		return err
+	}
+	udfDesc.SetSessionSetting(name, strVal)
+	return nil
+}
+
 // resetFuncOption sets all function options to default values.
 func resetFuncOption(udfDesc *funcdesc.Mutable) {
 	udfDesc.SetVolatility(catpb.Function_VOLATILE)
 	udfDesc.SetNullInputBehavior(catpb.Function_CALLED_ON_NULL_INPUT)
 	udfDesc.SetLeakProof(false)
+	udfDesc.SetSecurity(catpb.Function_INVOKER)
+	udfDesc.ResetAllSessionSettings()
 }
 
 func makeFunctionParam(
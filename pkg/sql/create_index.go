@@ -165,9 +165,26 @@ func makeIndexDescriptor(
 		return nil, err
 	}
 
+	activeVersion := params.ExecCfg().Settings.Version.ActiveVersion(params.ctx)
+	if n.Hash {
+		// The key of a hash index is the hash of the indexed values, so the
+		// index elements are replaced with a single expression computing it.
+		hashElem, err := makeHashIndexElem(
+			params.ctx, tableDesc, tn, &n, params.p.SemaCtx(), activeVersion,
+		)
+		if err != nil {
+			return nil, err
+		}
+		columns = tree.IndexElemList{hashElem}
+	}
+	if n.BRIN {
+		if columns, err = makeBRINIndexElems(tableDesc, &n, activeVersion); err != nil {
+			return nil, err
+		}
+	}
+
 	// Replace expression index elements with hidden virtual computed columns.
 	// The virtual columns are added as mutation columns to tableDesc.
-	activeVersion := params.ExecCfg().Settings.Version.ActiveVersion(params.ctx)
 	if err := replaceExpressionElemsWithVirtualCols(
 		params.ctx,
 		tableDesc,
@@ -238,6 +255,13 @@ func makeIndexDescriptor(
 		}
 	}
 
+	if n.Hash {
+		indexDesc.AccessMethod = descpb.IndexDescriptor_HASH
+	}
+	if n.BRIN {
+		indexDesc.AccessMethod = descpb.IndexDescriptor_BRIN
+	}
+
 	if n.Sharded != nil {
 		if n.PartitionByIndex.ContainsPartitions() {
 			return nil, pgerror.New(pgcode.FeatureNotSupported, "sharded indexes don't support explicit partitioning")
@@ -311,6 +335,12 @@ func makeIndexDescriptor(
 	if indexDesc.IsSharded() {
 		telemetry.Inc(sqltelemetry.HashShardedIndexCounter)
 	}
+	if indexDesc.AccessMethod == descpb.IndexDescriptor_HASH {
+		telemetry.Inc(sqltelemetry.HashIndexCounter)
+	}
+	if indexDesc.AccessMethod == descpb.IndexDescriptor_BRIN {
+		telemetry.Inc(sqltelemetry.BRINIndexCounter)
+	}
 	if indexDesc.IsPartial() {
 		telemetry.Inc(sqltelemetry.PartialIndexCounter)
 	}
@@ -489,6 +519,143 @@ func validateIndexColumnsExist(desc *tabledesc.Mutable, columns tree.IndexElemLi
 	return nil
 }
 
+// makeHashIndexElem validates a CREATE INDEX ... USING hash statement and
+// returns the index element of the hash index's key column, an expression
+// hashing the values of the indexed columns or expressions.
+func makeHashIndexElem(
+	ctx context.Context,
+	desc *tabledesc.Mutable,
+	tn *tree.TableName,
+	n *tree.CreateIndex,
+	semaCtx *tree.SemaContext,
+	version clusterversion.ClusterVersion,
+) (tree.IndexElem, error) {
+	if !version.IsActive(clusterversion.V24_1) {
+		return tree.IndexElem{}, pgerror.New(pgcode.FeatureNotSupported,
+			"hash indexes are not supported until upgrade to version 24.1 is finalized")
+	}
+	if n.Unique {
+		// Distinct values can have the same hash, so a unique index on the hash
+		// would reject rows which do not violate the uniqueness of the values.
+		// This matches Postgres, whose hash indexes cannot be unique either.
+		return tree.IndexElem{}, errors.WithHint(
+			pgerror.New(pgcode.FeatureNotSupported, `access method "hash" does not support unique indexes`),
+			"hash indexes only store the hash of the indexed values, which may collide; "+
+				"use a btree index to enforce uniqueness",
+		)
+	}
+	if n.Sharded != nil {
+		return tree.IndexElem{}, pgerror.New(pgcode.FeatureNotSupported,
+			"hash indexes don't support hash sharding")
+	}
+	if n.PartitionByIndex.ContainsPartitions() {
+		return tree.IndexElem{}, pgerror.New(pgcode.FeatureNotSupported,
+			"hash indexes don't support explicit partitioning")
+	}
+
+	// Unlike in Postgres, hash indexes can have multiple columns, whose values
+	// are hashed together, and stored columns, which are stored like in any
+	// other secondary index.
+	keys := make(tree.Exprs, len(n.Columns))
+	for i, elem := range n.Columns {
+		if elem.Direction != tree.DefaultDirection || elem.NullsOrder != tree.DefaultNullsOrder {
+			return tree.IndexElem{}, pgerror.New(pgcode.FeatureNotSupported,
+				`access method "hash" does not support ASC/DESC options`)
+		}
+		if elem.OpClass != "" {
+			return tree.IndexElem{}, pgerror.Newf(pgcode.UndefinedObject,
+				`operator class %q does not exist for access method "hash"`, elem.OpClass)
+		}
+		key := elem.Expr
+		if key == nil {
+			key = &tree.ColumnItem{ColumnName: elem.Column}
+		}
+		// Validate the indexed value and resolve its type, which must be one
+		// that can be key-encoded to be hashed.
+		colDef := &tree.ColumnTableDef{Type: types.Any}
+		colDef.Computed.Computed = true
+		colDef.Computed.Expr = key
+		colDef.Computed.Virtual = true
+		_, typ, err := schemaexpr.ValidateComputedColumnExpression(
+			ctx, desc, colDef, tn, tree.ExpressionIndexElementExpr, semaCtx, version,
+		)
+		if err != nil {
+			return tree.IndexElem{}, err
+		}
+		if typ.IsAmbiguous() || !colinfo.ColumnTypeIsIndexable(typ) {
+			return tree.IndexElem{}, pgerror.Newf(pgcode.UndefinedObject,
+				`data type %s has no default operator class for access method "hash"`, typ.Name())
+		}
+		keys[i] = key
+	}
+	return tree.IndexElem{Expr: schemaexpr.MakeHashIndexExpr(keys)}, nil
+}
+
+// makeBRINIndexElems validates a CREATE INDEX ... USING brin statement and
+// returns the index elements of the BRIN index: a constant expression, whose
+// value marks the index entries of the rows not yet summarized, followed by
+// the summarized columns. See brin_index.go.
+func makeBRINIndexElems(
+	desc *tabledesc.Mutable, n *tree.CreateIndex, version clusterversion.ClusterVersion,
+) (tree.IndexElemList, error) {
+	if !version.IsActive(clusterversion.V24_1) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"BRIN indexes are not supported until upgrade to version 24.1 is finalized")
+	}
+	if n.Unique {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			`access method "brin" does not support unique indexes`)
+	}
+	if len(n.Storing) > 0 {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			`access method "brin" does not support included columns`)
+	}
+	if n.Sharded != nil {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"BRIN indexes don't support hash sharding")
+	}
+	if n.PartitionByIndex.ContainsPartitions() {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"BRIN indexes don't support explicit partitioning")
+	}
+	if desc.IsPartitionAllBy() || desc.IsLocalityRegionalByRow() {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			`BRIN indexes don't support implicit partitioning from "PARTITION ALL BY" or "LOCALITY REGIONAL BY ROW"`)
+	}
+	if n.Predicate != nil {
+		return nil, unimplemented.New("partial brin index", "partial BRIN indexes are not supported")
+	}
+	elems := tree.IndexElemList{{Expr: tree.NewDInt(brinUnsummarizedKey)}}
+	for _, elem := range n.Columns {
+		if elem.Expr != nil {
+			return nil, unimplemented.New("brin index expression", "BRIN indexes on expressions are not supported")
+		}
+		if elem.Direction != tree.DefaultDirection || elem.NullsOrder != tree.DefaultNullsOrder {
+			return nil, pgerror.New(pgcode.FeatureNotSupported,
+				`access method "brin" does not support ASC/DESC options`)
+		}
+		if elem.OpClass != "" {
+			return nil, pgerror.Newf(pgcode.UndefinedObject,
+				`operator class %q does not exist for access method "brin"`, elem.OpClass)
+		}
+		col, err := catalog.MustFindColumnByTreeName(desc, elem.Column)
+		if err != nil {
+			return nil, err
+		}
+		// The summaries are computed from the rows of the primary index, which
+		// does not store virtual columns.
+		if col.IsVirtual() {
+			return nil, unimplemented.New("brin index virtual column", "BRIN indexes on virtual columns are not supported")
+		}
+		if !colinfo.ColumnTypeIsIndexable(col.GetType()) {
+			return nil, pgerror.Newf(pgcode.UndefinedObject,
+				`data type %s has no default operator class for access method "brin"`, col.GetType().Name())
+		}
+		elems = append(elems, elem)
+	}
+	return elems, nil
+}
+
 // replaceExpressionElemsWithVirtualCols replaces each non-nil expression in
 // elems with an inaccessible virtual column with the same expression. If
 // isNewTable is true, the column is added directly to desc. Otherwise, the
@@ -768,6 +935,17 @@ func (n *createIndexNode) startExec(params runParams) error {
 		)
 	}
 
+	if n.n.BRIN {
+		params.p.BufferClientNotice(
+			params.ctx,
+			errors.WithHint(
+				pgnotice.Newf("BRIN indexes are not summarized automatically"),
+				"Run brin_summarize_new_values() after writing to the table, so that scans "+
+					"can skip the block ranges which do not match their filters.",
+			),
+		)
+	}
+
 	// Warn against creating a non-partitioned index on a partitioned table,
 	// which is undesirable in most cases.
 	// Avoid the warning if we have PARTITION ALL BY as all indexes will implicitly
@@ -0,0 +1,353 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+type createOperatorNode struct {
+	n *tree.CreateOperator
+}
+
+// CreateOperator creates an operator on user-defined types, which is
+// evaluated by calling a user-defined function. The operator is stored on the
+// descriptor of the function, so it is in the schema of the function.
+// Privileges: ownership of the function.
+func (p *planner) CreateOperator(ctx context.Context, n *tree.CreateOperator) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE OPERATOR",
+	); err != nil {
+		return nil, err
+	}
+	if !p.execCfg.Settings.Version.IsActive(ctx, clusterversion.V24_1) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to create operators",
+			clusterversion.V24_1)
+	}
+	return &createOperatorNode{n: n}, nil
+}
+
+func (n *createOperatorNode) ReadingOwnWrites() {}
+
+func (n *createOperatorNode) startExec(params runParams) error {
+	var fnOpt, leftOpt, rightOpt *tree.OperatorOption
+	for i := range n.n.Options {
+		o := &n.n.Options[i]
+		var target **tree.OperatorOption
+		switch o.Name {
+		case tree.OperatorOptionFunction, tree.OperatorOptionProcedure:
+			target = &fnOpt
+		case tree.OperatorOptionLeftArg:
+			target = &leftOpt
+		case tree.OperatorOptionRightArg:
+			target = &rightOpt
+		case tree.OperatorOptionCommutator, tree.OperatorOptionNegator,
+			tree.OperatorOptionRestrict, tree.OperatorOptionJoin,
+			tree.OperatorOptionHashes, tree.OperatorOptionMerges:
+			// These options are hints for the optimizer, which are accepted for
+			// compatibility and ignored.
+			continue
+		default:
+			return pgerror.Newf(pgcode.Syntax, "operator attribute %q not recognized", o.Name)
+		}
+		if *target != nil {
+			return pgerror.New(pgcode.Syntax, "conflicting or redundant options")
+		}
+		if o.Type == nil {
+			return pgerror.Newf(pgcode.Syntax, "operator attribute %q requires a value", o.Name)
+		}
+		*target = o
+	}
+	if fnOpt == nil {
+		return pgerror.New(pgcode.InvalidFunctionDefinition, "operator function must be specified")
+	}
+	if rightOpt == nil {
+		return pgerror.New(pgcode.InvalidFunctionDefinition,
+			"operator right argument type must be specified")
+	}
+
+	symbol := n.n.Name.Symbol
+	var argTypes []*types.T
+	if leftOpt != nil {
+		if !isBinaryOperatorSymbol(symbol) {
+			return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+				"operator %s cannot have a left argument", symbol)
+		}
+		left, err := tree.ResolveType(params.ctx, leftOpt.Type, params.p)
+		if err != nil {
+			return err
+		}
+		argTypes = append(argTypes, left)
+	} else if !isPrefixOperatorSymbol(symbol) {
+		return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"operator %s must have a left argument", symbol)
+	}
+	right, err := tree.ResolveType(params.ctx, rightOpt.Type, params.p)
+	if err != nil {
+		return err
+	}
+	argTypes = append(argTypes, right)
+
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("operator"))
+
+	fnName, ok := fnOpt.Type.(*tree.UnresolvedObjectName)
+	if !ok {
+		return pgerror.Newf(pgcode.UndefinedFunction,
+			"function %s does not exist", fnOpt.Type.SQLString())
+	}
+	routineObj := tree.RoutineObj{
+		FuncName: fnName.ToRoutineName(),
+		Params:   make(tree.RoutineParams, len(argTypes)),
+	}
+	for i, typ := range argTypes {
+		routineObj.Params[i] = tree.RoutineParam{Type: typ, Class: tree.RoutineParamIn}
+	}
+	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &routineObj)
+	if err != nil {
+		return err
+	}
+	if fnDesc.IsProcedure() || fnDesc.IsAggregate() || fnDesc.ReturnType.ReturnSet ||
+		len(fnDesc.Params) != len(argTypes) {
+		return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"%s cannot be used as an operator function", fnDesc.GetName())
+	}
+
+	scDesc, err := params.p.Descriptors().ByIDWithLeased(params.p.Txn()).WithoutNonPublic().Get().Schema(
+		params.ctx, fnDesc.GetParentSchemaID(),
+	)
+	if err != nil {
+		return err
+	}
+	if n.n.Name.Schema != "" && string(n.n.Name.Schema) != scDesc.GetName() {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"operator must be created in the schema of its function %q", scDesc.GetName())
+	}
+	for _, op := range schemaOperators(scDesc, symbol) {
+		if operatorArgsMatch(op, argTypes) {
+			return pgerror.Newf(pgcode.DuplicateFunction,
+				"operator %s already exists", formatOperator(symbol, argTypes))
+		}
+	}
+
+	fnDesc.AddOperator(symbol)
+	return params.p.writeFuncSignatureChange(params.ctx, fnDesc, "create operator")
+}
+
+func (*createOperatorNode) Next(params runParams) (bool, error) { return false, nil }
+func (*createOperatorNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*createOperatorNode) Close(ctx context.Context)           {}
+
+type dropOperatorNode struct {
+	n *tree.DropOperator
+}
+
+// DropOperator drops user-defined operators.
+// Privileges: ownership of the functions of the operators.
+func (p *planner) DropOperator(ctx context.Context, n *tree.DropOperator) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP OPERATOR",
+	); err != nil {
+		return nil, err
+	}
+	return &dropOperatorNode{n: n}, nil
+}
+
+func (n *dropOperatorNode) ReadingOwnWrites() {}
+
+func (n *dropOperatorNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("operator"))
+	for i := range n.n.Operators {
+		toDrop := &n.n.Operators[i]
+		var argTypes []*types.T
+		if toDrop.Left != nil {
+			left, err := tree.ResolveType(params.ctx, toDrop.Left, params.p)
+			if err != nil {
+				return err
+			}
+			argTypes = append(argTypes, left)
+		}
+		right, err := tree.ResolveType(params.ctx, toDrop.Right, params.p)
+		if err != nil {
+			return err
+		}
+		argTypes = append(argTypes, right)
+
+		op, ok, err := params.p.lookupOperator(params.ctx, &toDrop.Name, argTypes)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			if n.n.IfExists {
+				continue
+			}
+			return pgerror.Newf(pgcode.UndefinedFunction,
+				"operator does not exist: %s", formatOperator(toDrop.Name.Symbol, argTypes))
+		}
+		fnDesc, err := params.p.checkPrivilegesForDropFunction(
+			params.ctx, funcdesc.UserDefinedFunctionOIDToID(op.Func),
+		)
+		if err != nil {
+			return err
+		}
+		fnDesc.RemoveOperator(toDrop.Name.Symbol)
+		if err := params.p.writeFuncSignatureChange(params.ctx, fnDesc, "drop operator"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*dropOperatorNode) Next(params runParams) (bool, error) { return false, nil }
+func (*dropOperatorNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*dropOperatorNode) Close(ctx context.Context)           {}
+
+// lookupOperator returns the user-defined operator with the given name and
+// operand types. Unless the name is qualified, the operator is searched for in
+// the schemas of the search path.
+func (p *planner) lookupOperator(
+	ctx context.Context, name *tree.OperatorName, argTypes []*types.T,
+) (_ tree.UserDefinedOperator, ok bool, _ error) {
+	var ops []tree.UserDefinedOperator
+	if name.Schema != "" {
+		found, prefix, err := p.LookupSchema(ctx, p.CurrentDatabase(), string(name.Schema))
+		if err != nil {
+			return tree.UserDefinedOperator{}, false, err
+		}
+		if !found {
+			return tree.UserDefinedOperator{}, false, pgerror.Newf(pgcode.UndefinedSchema,
+				"schema %q does not exist", name.Schema)
+		}
+		ops = schemaOperators(prefix.Schema, name.Symbol)
+	} else {
+		var err error
+		ops, err = p.ResolveOperator(ctx, name.Symbol, p.CurrentSearchPath())
+		if err != nil {
+			return tree.UserDefinedOperator{}, false, err
+		}
+	}
+	for _, op := range ops {
+		if operatorArgsMatch(op, argTypes) {
+			return op, true, nil
+		}
+	}
+	return tree.UserDefinedOperator{}, false, nil
+}
+
+// ResolveOperator implements the tree.UserDefinedOperatorResolver interface.
+func (sr *schemaResolver) ResolveOperator(
+	ctx context.Context, symbol string, path tree.SearchPath,
+) ([]tree.UserDefinedOperator, error) {
+	if sr.txn == nil {
+		return nil, nil
+	}
+	var ops []tree.UserDefinedOperator
+	for i, n := 0, path.NumElements(); i < n; i++ {
+		found, prefix, err := sr.LookupSchema(ctx, sr.CurrentDatabase(), path.GetSchema(i))
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+		ops = append(ops, schemaOperators(prefix.Schema, symbol)...)
+	}
+	return ops, nil
+}
+
+// schemaOperators returns the user-defined operators in the given schema with
+// the given symbol, or with any symbol if it is empty. The operators are
+// ordered by the IDs of their functions.
+func schemaOperators(sc catalog.SchemaDescriptor, symbol string) []tree.UserDefinedOperator {
+	var sigs []descpb.SchemaDescriptor_FunctionSignature
+	_ = sc.ForEachFunctionSignature(func(sig descpb.SchemaDescriptor_FunctionSignature) error {
+		if len(sig.Operators) > 0 {
+			sigs = append(sigs, sig)
+		}
+		return nil
+	})
+	sort.Slice(sigs, func(i, j int) bool { return sigs[i].ID < sigs[j].ID })
+	var ops []tree.UserDefinedOperator
+	for i := range sigs {
+		sig := &sigs[i]
+		op := tree.UserDefinedOperator{
+			Right: sig.ArgTypes[len(sig.ArgTypes)-1],
+			Func:  catid.FuncIDToOID(sig.ID),
+		}
+		if len(sig.ArgTypes) == 2 {
+			op.Left = sig.ArgTypes[0]
+		}
+		for _, s := range sig.Operators {
+			if symbol == "" || s == symbol {
+				op.Symbol = s
+				ops = append(ops, op)
+			}
+		}
+	}
+	return ops
+}
+
+// operatorArgsMatch returns whether the operand types of the operator are the
+// given types.
+func operatorArgsMatch(op tree.UserDefinedOperator, argTypes []*types.T) bool {
+	if op.Left == nil {
+		return len(argTypes) == 1 && op.Right.Oid() == argTypes[0].Oid()
+	}
+	return len(argTypes) == 2 && op.Left.Oid() == argTypes[0].Oid() &&
+		op.Right.Oid() == argTypes[1].Oid()
+}
+
+// formatOperator formats an operator and its operand types for error
+// messages, e.g. "point2 + point2".
+func formatOperator(symbol string, argTypes []*types.T) string {
+	if len(argTypes) == 1 {
+		return symbol + " " + argTypes[0].String()
+	}
+	return argTypes[0].String() + " " + symbol + " " + argTypes[1].String()
+}
+
+// isPrefixOperatorSymbol returns whether an operator with the given symbol
+// can be applied to a single operand.
+func isPrefixOperatorSymbol(symbol string) bool {
+	switch symbol {
+	case tree.UnaryMinus.String(), tree.UnaryComplement.String(),
+		tree.UnarySqrt.String(), tree.UnaryCbrt.String():
+		return true
+	}
+	return false
+}
+
+// isBinaryOperatorSymbol returns whether an operator with the given symbol can
+// be applied to two operands.
+func isBinaryOperatorSymbol(symbol string) bool {
+	switch symbol {
+	case tree.UnarySqrt.String(), tree.UnaryCbrt.String():
+		return false
+	}
+	return true
+}
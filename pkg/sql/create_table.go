@@ -672,6 +672,7 @@ func addUniqueWithoutIndexColumnTableDef(
 		string(d.Unique.ConstraintName),
 		[]string{string(d.Name)},
 		"", /* predicate */
+		d.Unique.Deferrability,
 		ts,
 		validationBehavior,
 	); err != nil {
@@ -735,13 +736,75 @@ func addUniqueWithoutIndexTableDef(
 		colNames[i] = string(d.Columns[i].Column)
 	}
 	if err := ResolveUniqueWithoutIndexConstraint(
-		ctx, desc, string(d.Name), colNames, predicate, ts, validationBehavior,
+		ctx, desc, string(d.Name), colNames, predicate, d.Deferrability, ts, validationBehavior,
 	); err != nil {
 		return err
 	}
 	return nil
 }
 
+// addExcludeConstraintTableDef runs various checks on the given
+// ExcludeConstraintTableDef before adding it as an exclusion constraint to the
+// given table descriptor. Exclusion constraints are stored as UNIQUE WITHOUT
+// INDEX constraints annotated with the comparison operator of each column.
+func addExcludeConstraintTableDef(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	d *tree.ExcludeConstraintTableDef,
+	desc *tabledesc.Mutable,
+	tn tree.TableName,
+	ts TableState,
+	validationBehavior tree.ValidationBehavior,
+	semaCtx *tree.SemaContext,
+) error {
+	if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V24_1) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"exclusion constraints are not supported until upgrade to version 24.1 is finalized",
+		)
+	}
+
+	// If there is a predicate, validate it.
+	var predicate string
+	if d.Predicate != nil {
+		var err error
+		predicate, err = schemaexpr.ValidateUniqueWithoutIndexPredicate(
+			ctx, tn, desc, d.Predicate, semaCtx, evalCtx.Settings.Version.ActiveVersionOrEmpty(ctx),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	colNames := make([]string, len(d.Elems))
+	ops := make([]string, len(d.Elems))
+	for i := range d.Elems {
+		elem := &d.Elems[i]
+		if elem.Expr != nil {
+			return unimplemented.New("exclusion constraint expressions",
+				"expressions in exclusion constraints are not supported",
+			)
+		}
+		if elem.Direction != tree.DefaultDirection || elem.NullsOrder != tree.DefaultNullsOrder {
+			return pgerror.New(pgcode.FeatureNotSupported,
+				"ordering options are not supported in exclusion constraints",
+			)
+		}
+		col, err := desc.FindActiveOrNewColumnByName(elem.Column)
+		if err != nil {
+			return err
+		}
+		if err := schemaexpr.ValidateExclusionOperator(elem.Operator.Symbol, col.GetType()); err != nil {
+			return err
+		}
+		colNames[i] = string(elem.Column)
+		ops[i] = elem.Operator.String()
+	}
+	return resolveUniqueWithoutIndexConstraint(
+		ctx, desc, string(d.Name), colNames, predicate, d.Deferrability, ts, validationBehavior,
+		ops, string(d.Using), 0, /* indexID */
+	)
+}
+
 // ResolveUniqueWithoutIndexConstraint looks up the columns mentioned in a
 // UNIQUE WITHOUT INDEX constraint and adds metadata representing that
 // constraint to the descriptor.
@@ -755,8 +818,34 @@ func ResolveUniqueWithoutIndexConstraint(
 	constraintName string,
 	colNames []string,
 	predicate string,
+	deferrability tree.ConstraintDeferrability,
 	ts TableState,
 	validationBehavior tree.ValidationBehavior,
+) error {
+	return resolveUniqueWithoutIndexConstraint(
+		ctx, tbl, constraintName, colNames, predicate, deferrability, ts, validationBehavior,
+		nil /* exclusionOps */, "" /* exclusionMethod */, 0, /* indexID */
+	)
+}
+
+// resolveUniqueWithoutIndexConstraint is the implementation of
+// ResolveUniqueWithoutIndexConstraint. If exclusionOps is non-empty, it
+// contains one comparison operator per column and the resulting constraint is
+// an exclusion constraint. If indexID is not zero, it is the ID of the index
+// of a DEFERRABLE unique constraint that is declared with an index (see
+// deferrableUniqueIndexConstraint).
+func resolveUniqueWithoutIndexConstraint(
+	ctx context.Context,
+	tbl *tabledesc.Mutable,
+	constraintName string,
+	colNames []string,
+	predicate string,
+	deferrability tree.ConstraintDeferrability,
+	ts TableState,
+	validationBehavior tree.ValidationBehavior,
+	exclusionOps []string,
+	exclusionMethod string,
+	indexID descpb.IndexID,
 ) error {
 	var colSet catalog.TableColSet
 	cols := make([]catalog.Column, len(colNames))
@@ -776,8 +865,12 @@ func ResolveUniqueWithoutIndexConstraint(
 
 	// Verify we are not writing a constraint over the same name.
 	if constraintName == "" {
+		baseName := fmt.Sprintf("unique_%s", strings.Join(colNames, "_"))
+		if len(exclusionOps) > 0 {
+			baseName = fmt.Sprintf("%s_%s_excl", tbl.Name, strings.Join(colNames, "_"))
+		}
 		constraintName = tabledesc.GenerateUniqueName(
-			fmt.Sprintf("unique_%s", strings.Join(colNames, "_")),
+			baseName,
 			func(p string) bool {
 				return catalog.FindConstraintByName(tbl, p) != nil
 			},
@@ -809,6 +902,14 @@ func ResolveUniqueWithoutIndexConstraint(
 		Predicate:    predicate,
 		Validity:     validity,
 		ConstraintID: tbl.NextConstraintID,
+
+		Deferrable:        deferrability != tree.ConstraintNotDeferrable,
+		InitiallyDeferred: deferrability == tree.ConstraintInitiallyDeferred,
+
+		ExclusionOperators: exclusionOps,
+		ExclusionMethod:    exclusionMethod,
+
+		IndexID: indexID,
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -820,6 +921,75 @@ func ResolveUniqueWithoutIndexConstraint(
 	return nil
 }
 
+// deferrableUniqueIndexConstraint is a DEFERRABLE unique constraint that is
+// declared with an index. Since a unique index cannot contain duplicate keys
+// even temporarily, its index is not unique. The constraint is stored as a
+// deferrable UNIQUE WITHOUT INDEX constraint with the same name as the index,
+// which records the ID of the index, and the index is used to efficiently check
+// it.
+type deferrableUniqueIndexConstraint struct {
+	indexName     string
+	columnNames   []string
+	deferrability tree.ConstraintDeferrability
+}
+
+// makeDeferrableUniqueIndexConstraint returns the DEFERRABLE unique constraint
+// declared with the given non-unique index. If the index has no name, it is
+// given the default name of a unique constraint. The constraint must be added
+// with add once the IDs of the index and of its columns have been allocated.
+func makeDeferrableUniqueIndexConstraint(
+	desc *tabledesc.Mutable,
+	idx *descpb.IndexDescriptor,
+	columns tree.IndexElemList,
+	deferrability tree.ConstraintDeferrability,
+	version clusterversion.ClusterVersion,
+) (deferrableUniqueIndexConstraint, error) {
+	if !version.IsActive(clusterversion.V24_1) {
+		return deferrableUniqueIndexConstraint{}, pgerror.New(pgcode.FeatureNotSupported,
+			"DEFERRABLE unique constraints are not supported until upgrade to version 24.1 is finalized",
+		)
+	}
+	colNames := make([]string, len(columns))
+	for i := range columns {
+		if columns[i].Expr != nil {
+			return deferrableUniqueIndexConstraint{}, pgerror.New(pgcode.FeatureNotSupported,
+				"DEFERRABLE unique constraints cannot contain expressions")
+		}
+		colNames[i] = string(columns[i].Column)
+	}
+	if idx.Name == "" {
+		idx.Name = tabledesc.GenerateUniqueName(
+			fmt.Sprintf("%s_%s_key", desc.Name, strings.Join(colNames, "_")),
+			func(name string) bool {
+				return catalog.FindIndexByName(desc, name) != nil ||
+					catalog.FindConstraintByName(desc, name) != nil
+			},
+		)
+	}
+	return deferrableUniqueIndexConstraint{
+		indexName:     idx.Name,
+		columnNames:   colNames,
+		deferrability: deferrability,
+	}, nil
+}
+
+// add adds the constraint to the given table descriptor.
+func (c deferrableUniqueIndexConstraint) add(
+	ctx context.Context, desc *tabledesc.Mutable, ts TableState,
+) error {
+	idx, err := catalog.MustFindIndexByName(desc, c.indexName)
+	if err != nil {
+		return err
+	}
+	if idx.GetID() == 0 {
+		return errors.AssertionFailedf("index %q has no ID", c.indexName)
+	}
+	return resolveUniqueWithoutIndexConstraint(
+		ctx, desc, c.indexName, c.columnNames, idx.GetPredicate(), c.deferrability, ts,
+		tree.ValidationDefault, nil /* exclusionOps */, "" /* exclusionMethod */, idx.GetID(),
+	)
+}
+
 // ResolveFK looks up the tables and columns mentioned in a `REFERENCES`
 // constraint and adds metadata representing that constraint to the descriptor.
 // It may, in doing so, add to or alter descriptors in the passed in `backrefs`
@@ -1053,6 +1223,8 @@ func ResolveFK(
 		OnUpdate:            tree.ForeignKeyReferenceActionValue[d.Actions.Update],
 		Match:               tree.CompositeKeyMatchMethodValue[d.Match],
 		ConstraintID:        tbl.NextConstraintID,
+		Deferrable:          d.Deferrability != tree.ConstraintNotDeferrable,
+		InitiallyDeferred:   d.Deferrability == tree.ConstraintInitiallyDeferred,
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -1336,6 +1508,9 @@ func NewTableDesc(
 	// Used to delay establishing Column/Sequence dependency until ColumnIDs have
 	// been populated.
 	cdd := make([]*tabledesc.ColumnDefDescs, len(n.Defs))
+	// Used to delay adding the constraints of DEFERRABLE unique indexes until
+	// the IDs of the indexes and of their columns have been allocated.
+	var deferrableUniqueIndexConstraints []deferrableUniqueIndexConstraint
 
 	var opts newTableDescOptions
 	for _, o := range inOpts {
@@ -1688,6 +1863,16 @@ func NewTableDesc(
 				tabledesc.UpdateIndexPartitioning(implicitColumnDefIdx.idx, false /* isIndexPrimary */, newImplicitCols, newPartitioning)
 			}
 
+			if def := implicitColumnDefIdx.def; def.Unique.Deferrability != tree.ConstraintNotDeferrable {
+				c, err := makeDeferrableUniqueIndexConstraint(
+					&desc, implicitColumnDefIdx.idx, tree.IndexElemList{{Column: def.Name}},
+					def.Unique.Deferrability, version,
+				)
+				if err != nil {
+					return nil, err
+				}
+				deferrableUniqueIndexConstraints = append(deferrableUniqueIndexConstraints, c)
+			}
 			if err := desc.AddSecondaryIndex(*implicitColumnDefIdx.idx); err != nil {
 				return nil, err
 			}
@@ -1922,6 +2107,9 @@ func NewTableDesc(
 				// We will add the unique constraint below.
 				break
 			}
+			if d.PrimaryKey && d.Deferrability != tree.ConstraintNotDeferrable {
+				return nil, sqlerrors.NewDeferrablePrimaryKeyError()
+			}
 			// If the index is named, ensure that the name is unique. Unnamed
 			// indexes will be given a unique auto-generated name later on when
 			// AllocateIDs is called.
@@ -1954,7 +2142,7 @@ func NewTableDesc(
 			}
 			idx := descpb.IndexDescriptor{
 				Name:             string(d.Name),
-				Unique:           true,
+				Unique:           d.Deferrability == tree.ConstraintNotDeferrable,
 				StoreColumnNames: d.Storing.ToStrings(),
 				Version:          indexEncodingVersion,
 				NotVisible:       d.Invisibility.Value != 0.0,
@@ -2036,11 +2224,19 @@ func NewTableDesc(
 					primaryIndexColumnSet[string(c.Column)] = struct{}{}
 				}
 			} else {
+				if !idx.Unique {
+					c, err := makeDeferrableUniqueIndexConstraint(&desc, &idx, d.Columns, d.Deferrability, version)
+					if err != nil {
+						return nil, err
+					}
+					deferrableUniqueIndexConstraints = append(deferrableUniqueIndexConstraints, c)
+				}
 				if err := desc.AddSecondaryIndex(idx); err != nil {
 					return nil, err
 				}
 			}
-		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef:
+		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef,
+			*tree.ExcludeConstraintTableDef:
 			// pass, handled below.
 
 		default:
@@ -2187,6 +2383,12 @@ func NewTableDesc(
 		newTableName: &n.Table,
 	}
 
+	for _, c := range deferrableUniqueIndexConstraints {
+		if err := c.add(ctx, &desc, NewTable); err != nil {
+			return nil, err
+		}
+	}
+
 	ckBuilder := schemaexpr.MakeCheckConstraintBuilder(ctx, n.Table, &desc, semaCtx)
 	for _, def := range n.Defs {
 		switch d := def.(type) {
@@ -2226,6 +2428,13 @@ func NewTableDesc(
 				return nil, err
 			}
 
+		case *tree.ExcludeConstraintTableDef:
+			if err := addExcludeConstraintTableDef(
+				ctx, evalCtx, d, &desc, n.Table, NewTable, tree.ValidationDefault, semaCtx,
+			); err != nil {
+				return nil, err
+			}
+
 		default:
 			return nil, errors.Errorf("unsupported table def: %T", def)
 		}
@@ -2520,6 +2729,44 @@ func rowLevelTTLAutomaticColumnExpr(intervalExpr tree.Expr) tree.Expr {
 	}
 }
 
+// exclusionConstraintTableDef reconstructs the ExcludeConstraintTableDef for
+// the given exclusion constraint of the table.
+func exclusionConstraintTableDef(
+	td catalog.TableDescriptor, c *descpb.UniqueWithoutIndexConstraint,
+) (*tree.ExcludeConstraintTableDef, error) {
+	colNames, err := catalog.ColumnNamesForIDs(td, c.ColumnIDs)
+	if err != nil {
+		return nil, err
+	}
+	def := &tree.ExcludeConstraintTableDef{
+		Name:  tree.Name(c.Name),
+		Using: tree.Name(c.ExclusionMethod),
+		Elems: make(tree.ExcludeElemList, len(colNames)),
+	}
+	for i := range colNames {
+		sym, err := schemaexpr.ExclusionOperator(c.ExclusionOperators[i])
+		if err != nil {
+			return nil, err
+		}
+		def.Elems[i] = tree.ExcludeElem{
+			IndexElem: tree.IndexElem{Column: tree.Name(colNames[i])},
+			Operator:  treecmp.MakeComparisonOperator(sym),
+		}
+	}
+	if c.InitiallyDeferred {
+		def.Deferrability = tree.ConstraintInitiallyDeferred
+	} else if c.Deferrable {
+		def.Deferrability = tree.ConstraintInitiallyImmediate
+	}
+	if c.IsPartial() {
+		def.Predicate, err = parser.ParseExpr(c.Predicate)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return def, nil
+}
+
 // replaceLikeTableOps processes the TableDefs in the input CreateTableNode,
 // searching for LikeTableDefs. If any are found, each LikeTableDef will be
 // replaced in the output tree.TableDefs (which will be a copy of the input
@@ -2636,6 +2883,14 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 				defs = append(defs, &def)
 			}
 			for _, c := range td.UniqueWithoutIndexConstraints {
+				if c.IsExclusion() {
+					def, err := exclusionConstraintTableDef(td, &c)
+					if err != nil {
+						return nil, err
+					}
+					defs = append(defs, def)
+					continue
+				}
 				def := tree.UniqueConstraintTableDef{
 					IndexTableDef: tree.IndexTableDef{
 						Name:    tree.Name(c.Name),
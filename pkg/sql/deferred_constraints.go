@@ -0,0 +1,642 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/memsize"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+// constraintCheckMode is the mode of a deferrable constraint set with SET
+// CONSTRAINTS.
+type constraintCheckMode int8
+
+const (
+	// constraintCheckModeDefault indicates that the constraint is checked
+	// according to its INITIALLY DEFERRED or INITIALLY IMMEDIATE attribute.
+	constraintCheckModeDefault constraintCheckMode = iota
+	// constraintCheckModeImmediate indicates that the constraint is checked at
+	// the end of each statement.
+	constraintCheckModeImmediate
+	// constraintCheckModeDeferred indicates that the constraint is checked
+	// when the transaction commits.
+	constraintCheckModeDeferred
+)
+
+// constraintKey identifies a constraint of a table.
+type constraintKey struct {
+	tableID descpb.ID
+	name    string
+}
+
+// pendingConstraintCheck is the deferred check of a row that violated a
+// deferrable constraint when its statement executed.
+type pendingConstraintCheck struct {
+	constraintKey
+	// keyVals contains the values of the constraint columns of the row, in the
+	// order of the constraint columns. For a foreign key, these are the values
+	// of both the origin and the referenced columns.
+	keyVals tree.Datums
+	// referenced is true if the row was a referenced row of a foreign key that
+	// was updated or deleted.
+	referenced bool
+}
+
+const sizeOfPendingConstraintCheck = int64(unsafe.Sizeof(pendingConstraintCheck{}))
+
+// memUsage returns the estimated memory usage of the pending check.
+func (c *pendingConstraintCheck) memUsage() int64 {
+	size := sizeOfPendingConstraintCheck + int64(len(c.name))
+	for _, d := range c.keyVals {
+		size += memsize.DatumOverhead + int64(d.Size())
+	}
+	return size
+}
+
+// seenKeyMemUsage returns the estimated memory usage of the given key in the
+// seen map of deferredConstraintState.
+func seenKeyMemUsage(key string) int64 {
+	return memsize.MapEntryOverhead + memsize.String + int64(len(key))
+}
+
+// deferredConstraintState tracks the checks of deferrable constraints which
+// are deferred until the end of the current transaction. A deferred check is
+// recorded for each row that violated a constraint when its statement
+// executed. Only the constraint keys of these rows are checked again before
+// the transaction commits, since later statements may have fixed the
+// violations. Any violation introduced by a later statement is recorded by the
+// checks of that statement.
+type deferredConstraintState struct {
+	// allMode is the mode set with SET CONSTRAINTS ALL, if any.
+	allMode constraintCheckMode
+	// modes contains the modes set with SET CONSTRAINTS for individual
+	// constraints, which take precedence over allMode.
+	modes map[constraintKey]constraintCheckMode
+
+	mu struct {
+		syncutil.Mutex
+		// pending contains the rows which must be checked before the transaction
+		// commits. Checks may be run concurrently, so access must be
+		// synchronized.
+		pending []pendingConstraintCheck
+		// seen is used to avoid recording the same row multiple times.
+		seen map[string]struct{}
+		// seenBytes is the estimated memory usage of the keys in seen.
+		seenBytes int64
+		// acc accounts for the memory used by pending and seen.
+		acc *mon.BoundAccount
+	}
+}
+
+// init initializes the state with the account of the memory used by pending
+// checks, which must be bound to the session's monitor.
+func (s *deferredConstraintState) init(acc *mon.BoundAccount) {
+	s.mu.acc = acc
+}
+
+var _ eval.DeferredConstraints = &deferredConstraintState{}
+
+// IsDeferred is part of the eval.DeferredConstraints interface.
+func (s *deferredConstraintState) IsDeferred(
+	tableID catid.DescID, name string, initiallyDeferred bool,
+) bool {
+	mode, ok := s.modes[constraintKey{tableID: tableID, name: name}]
+	if !ok {
+		mode = s.allMode
+	}
+	switch mode {
+	case constraintCheckModeImmediate:
+		return false
+	case constraintCheckModeDeferred:
+		return true
+	default:
+		return initiallyDeferred
+	}
+}
+
+// AddPendingCheck is part of the eval.DeferredConstraints interface.
+func (s *deferredConstraintState) AddPendingCheck(
+	ctx context.Context, tableID catid.DescID, name string, keyVals tree.Datums, referenced bool,
+) error {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%d/%s/%t", tableID, name, referenced)
+	for _, d := range keyVals {
+		buf.WriteByte('/')
+		buf.WriteString(d.String())
+	}
+	key := buf.String()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.mu.seen[key]; ok {
+		return nil
+	}
+	c := pendingConstraintCheck{
+		constraintKey: constraintKey{tableID: tableID, name: name},
+		keyVals:       keyVals,
+		referenced:    referenced,
+	}
+	seenBytes := seenKeyMemUsage(key)
+	if s.mu.acc != nil {
+		if err := s.mu.acc.Grow(ctx, c.memUsage()+seenBytes); err != nil {
+			return errors.Wrap(err, "recording deferred constraint check")
+		}
+	}
+	if s.mu.seen == nil {
+		s.mu.seen = make(map[string]struct{})
+	}
+	s.mu.seen[key] = struct{}{}
+	s.mu.seenBytes += seenBytes
+	s.mu.pending = append(s.mu.pending, c)
+	return nil
+}
+
+// setMode sets the mode of the given constraints, or of all constraints if
+// keys is nil.
+func (s *deferredConstraintState) setMode(keys []constraintKey, mode constraintCheckMode) {
+	if keys == nil {
+		s.allMode = mode
+		s.modes = nil
+		return
+	}
+	if s.modes == nil {
+		s.modes = make(map[constraintKey]constraintCheckMode, len(keys))
+	}
+	for _, key := range keys {
+		s.modes[key] = mode
+	}
+}
+
+// reset clears all state at the end of a transaction.
+func (s *deferredConstraintState) reset(ctx context.Context) {
+	s.allMode = constraintCheckModeDefault
+	s.modes = nil
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mu.pending = nil
+	s.mu.seen = nil
+	s.mu.seenBytes = 0
+	if s.mu.acc != nil {
+		s.mu.acc.Clear(ctx)
+	}
+}
+
+// hasPending returns true if there are any pending checks.
+func (s *deferredConstraintState) hasPending() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.mu.pending) > 0
+}
+
+// validatePending runs the pending checks of the constraints for which include
+// returns true, and removes them from the list of pending checks. Constraints
+// that were dropped since their checks were deferred are ignored.
+func (s *deferredConstraintState) validatePending(
+	ctx context.Context, txn descs.Txn, user username.SQLUsername, include func(constraintKey) bool,
+) error {
+	var toValidate []pendingConstraintCheck
+	func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		released := s.mu.seenBytes
+		remaining := s.mu.pending[:0]
+		for _, c := range s.mu.pending {
+			if include(c.constraintKey) {
+				toValidate = append(toValidate, c)
+				released += c.memUsage()
+			} else {
+				remaining = append(remaining, c)
+			}
+		}
+		s.mu.pending = remaining
+		s.mu.seen = nil
+		s.mu.seenBytes = 0
+		if s.mu.acc != nil {
+			s.mu.acc.Shrink(ctx, released)
+		}
+	}()
+	for i := range toValidate {
+		if err := validateDeferredCheck(ctx, txn, user, &toValidate[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateDeferredCheck checks whether the row of the given pending check
+// still violates its constraint.
+func validateDeferredCheck(
+	ctx context.Context, txn descs.Txn, user username.SQLUsername, c *pendingConstraintCheck,
+) error {
+	tbl, err := txn.Descriptors().ByID(txn.KV()).Get().Table(ctx, c.tableID)
+	if err != nil {
+		return err
+	}
+	if tbl.Dropped() {
+		return nil
+	}
+	constraint := catalog.FindConstraintByName(tbl, c.name)
+	if constraint == nil {
+		return nil
+	}
+	if fk := constraint.AsForeignKey(); fk != nil {
+		referencedTable, err := txn.Descriptors().ByID(txn.KV()).Get().Table(
+			ctx, fk.GetReferencedTableID(),
+		)
+		if err != nil {
+			return err
+		}
+		return validateDeferredForeignKeyRow(ctx, txn, user, tbl, referencedTable, fk, c)
+	}
+	if uc := constraint.AsUniqueWithoutIndex(); uc != nil {
+		if uc.UniqueWithoutIndexDesc().IsExclusion() {
+			return validateDeferredExclusionRow(ctx, txn, user, tbl, uc, c)
+		}
+		return validateDeferredUniqueRow(ctx, txn, user, tbl, uc, c)
+	}
+	return errors.AssertionFailedf("constraint %q cannot be deferred", c.name)
+}
+
+// validateDeferredUniqueRow checks that no other row has the same key as the
+// row of the given pending check.
+func validateDeferredUniqueRow(
+	ctx context.Context,
+	txn descs.Txn,
+	user username.SQLUsername,
+	tbl catalog.TableDescriptor,
+	uc catalog.UniqueWithoutIndexConstraint,
+	c *pendingConstraintCheck,
+) error {
+	colNames, err := catalog.ColumnNamesForIDs(tbl, uc.UniqueWithoutIndexDesc().ColumnIDs)
+	if err != nil {
+		return err
+	}
+	where := keyFilter(colNames, "" /* alias */, "=")
+	if uc.IsPartial() {
+		where = append(where, fmt.Sprintf("(%s)", uc.GetPredicate()))
+	}
+	query := fmt.Sprintf(
+		"SELECT count(*) > 1 FROM [%d AS tbl] WHERE %s", tbl.GetID(), strings.Join(where, " AND "),
+	)
+	row, err := queryDeferredCheck(ctx, txn, user, query, c.keyVals)
+	if err != nil {
+		return err
+	}
+	if row == nil || !tree.MustBeDBool(row[0]) {
+		return nil
+	}
+	var msg bytes.Buffer
+	msg.WriteString("duplicate key value violates unique constraint ")
+	lexbase.EncodeEscapedSQLIdent(&msg, uc.GetName())
+	return errors.WithDetail(
+		pgerror.WithConstraintName(
+			pgerror.Newf(pgcode.UniqueViolation, "%s", msg.String()), uc.GetName(),
+		),
+		fmt.Sprintf("Key (%s)=(%s) already exists.",
+			strings.Join(colNames, ", "), formatKeyVals(c.keyVals)),
+	)
+}
+
+// validateDeferredExclusionRow checks that no other row conflicts with the row
+// of the given pending check.
+func validateDeferredExclusionRow(
+	ctx context.Context,
+	txn descs.Txn,
+	user username.SQLUsername,
+	tbl catalog.TableDescriptor,
+	uc catalog.UniqueWithoutIndexConstraint,
+	c *pendingConstraintCheck,
+) error {
+	colNames, err := catalog.ColumnNamesForIDs(tbl, uc.UniqueWithoutIndexDesc().ColumnIDs)
+	if err != nil {
+		return err
+	}
+	query, _, err := exclusionViolationQuery(
+		tbl, uc.UniqueWithoutIndexDesc(), 0, /* indexIDForValidation */
+		keyFilter(colNames, "" /* alias */, "="),
+	)
+	if err != nil {
+		return err
+	}
+	row, err := queryDeferredCheck(ctx, txn, user, query, c.keyVals)
+	if err != nil {
+		return err
+	}
+	if row == nil {
+		return nil
+	}
+	var msg bytes.Buffer
+	msg.WriteString("conflicting key value violates exclusion constraint ")
+	lexbase.EncodeEscapedSQLIdent(&msg, uc.GetName())
+	return errors.WithDetail(
+		pgerror.WithConstraintName(
+			pgerror.Newf(pgcode.ExclusionViolation, "%s", msg.String()), uc.GetName(),
+		),
+		fmt.Sprintf("Key (%s)=(%s) conflicts with key (%s)=(%s).",
+			strings.Join(colNames, ", "), formatKeyVals(row[:len(colNames)]),
+			strings.Join(colNames, ", "), formatKeyVals(row[len(colNames):])),
+	)
+}
+
+// validateDeferredForeignKeyRow checks that a row of the origin table with the
+// key of the given pending check does not exist, or that it references an
+// existing row of the referenced table.
+func validateDeferredForeignKeyRow(
+	ctx context.Context,
+	txn descs.Txn,
+	user username.SQLUsername,
+	originTable, referencedTable catalog.TableDescriptor,
+	fk catalog.ForeignKeyConstraint,
+	c *pendingConstraintCheck,
+) error {
+	fkDesc := fk.ForeignKeyDesc()
+	originColNames, err := catalog.ColumnNamesForIDs(originTable, fkDesc.OriginColumnIDs)
+	if err != nil {
+		return err
+	}
+	referencedColNames, err := catalog.ColumnNamesForIDs(referencedTable, fkDesc.ReferencedColumnIDs)
+	if err != nil {
+		return err
+	}
+	// The key contains NULL values if it violated a MATCH FULL foreign key, in
+	// which case a matching referenced row cannot exist.
+	query := fmt.Sprintf(
+		"SELECT 1 FROM [%d AS o] WHERE %s AND NOT EXISTS (SELECT 1 FROM [%d AS r] WHERE %s) LIMIT 1",
+		originTable.GetID(),
+		strings.Join(keyFilter(originColNames, "o", "IS NOT DISTINCT FROM"), " AND "),
+		referencedTable.GetID(),
+		strings.Join(keyFilter(referencedColNames, "r", "="), " AND "),
+	)
+	row, err := queryDeferredCheck(ctx, txn, user, query, c.keyVals)
+	if err != nil {
+		return err
+	}
+	if row == nil {
+		return nil
+	}
+
+	// Generate the same errors as the immediate checks of the constraint.
+	var msg bytes.Buffer
+	var details string
+	if c.referenced {
+		msg.WriteString("update or delete on table ")
+		lexbase.EncodeEscapedSQLIdent(&msg, referencedTable.GetName())
+		msg.WriteString(" violates foreign key constraint ")
+		lexbase.EncodeEscapedSQLIdent(&msg, fk.GetName())
+		msg.WriteString(" on table ")
+		lexbase.EncodeEscapedSQLIdent(&msg, originTable.GetName())
+		details = fmt.Sprintf("Key (%s)=(%s) is still referenced from table %s.",
+			strings.Join(referencedColNames, ", "), formatKeyVals(c.keyVals),
+			lexbase.EscapeSQLIdent(originTable.GetName()))
+	} else {
+		msg.WriteString("insert or update on table ")
+		lexbase.EncodeEscapedSQLIdent(&msg, originTable.GetName())
+		msg.WriteString(" violates foreign key constraint ")
+		lexbase.EncodeEscapedSQLIdent(&msg, fk.GetName())
+		details = fmt.Sprintf("Key (%s)=(%s) is not present in table %s.",
+			strings.Join(originColNames, ", "), formatKeyVals(c.keyVals),
+			lexbase.EscapeSQLIdent(referencedTable.GetName()))
+		for _, d := range c.keyVals {
+			if d == tree.DNull {
+				details = "MATCH FULL does not allow mixing of null and nonnull key values."
+				break
+			}
+		}
+	}
+	return errors.WithDetail(
+		pgerror.WithConstraintName(
+			pgerror.Newf(pgcode.ForeignKeyViolation, "%s", msg.String()), fk.GetName(),
+		),
+		details,
+	)
+}
+
+// keyFilter returns a condition for each of the given columns which compares
+// it to the placeholder of the same ordinal with the given operator.
+func keyFilter(colNames []string, alias string, op string) []string {
+	filter := make([]string, len(colNames))
+	for i, name := range colNames {
+		col := tree.NameString(name)
+		if alias != "" {
+			col = alias + "." + col
+		}
+		filter[i] = fmt.Sprintf("%s %s $%d", col, op, i+1)
+	}
+	return filter
+}
+
+// formatKeyVals formats the given key values for an error message.
+func formatKeyVals(keyVals tree.Datums) string {
+	strs := make([]string, len(keyVals))
+	for i, d := range keyVals {
+		strs[i] = d.String()
+	}
+	return strings.Join(strs, ", ")
+}
+
+// queryDeferredCheck runs the given query of a deferred check with the given
+// key values as placeholders, and returns the first row, if any.
+func queryDeferredCheck(
+	ctx context.Context, txn descs.Txn, user username.SQLUsername, query string, keyVals tree.Datums,
+) (tree.Datums, error) {
+	sessionDataOverride := sessiondata.NoSessionDataOverride
+	sessionDataOverride.User = user
+	args := make([]interface{}, len(keyVals))
+	for i, d := range keyVals {
+		args[i] = d
+	}
+	return txn.QueryRowEx(ctx, "validate deferred constraint", txn.KV(), sessionDataOverride, query, args...)
+}
+
+// constraintDeferrability returns whether the given constraint is deferrable,
+// and whether its checks are deferred by default.
+func constraintDeferrability(c catalog.Constraint) (deferrable, initiallyDeferred bool) {
+	if fk := c.AsForeignKey(); fk != nil {
+		return fk.ForeignKeyDesc().Deferrable, fk.ForeignKeyDesc().InitiallyDeferred
+	}
+	if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+		return uwoi.UniqueWithoutIndexDesc().Deferrable, uwoi.UniqueWithoutIndexDesc().InitiallyDeferred
+	}
+	return false, false
+}
+
+// deferrableUniqueIndex returns the index of the given constraint if it is a
+// DEFERRABLE unique constraint that was declared with an index (see
+// deferrableUniqueIndexConstraint), or nil otherwise. Indexes of partial
+// constraints are not considered, since their predicate is not part of the
+// UNIQUE constraint syntax.
+func deferrableUniqueIndex(
+	desc catalog.TableDescriptor, uc catalog.UniqueWithoutIndexConstraint,
+) catalog.Index {
+	ucDesc := uc.UniqueWithoutIndexDesc()
+	if !ucDesc.Deferrable || ucDesc.IndexID == 0 || uc.IsPartial() {
+		return nil
+	}
+	idx := catalog.FindIndexByID(desc, ucDesc.IndexID)
+	if idx == nil || idx.Dropped() {
+		return nil
+	}
+	return idx
+}
+
+// isDeferrableUniqueIndex returns true if the given index is the index of a
+// DEFERRABLE unique constraint (see deferrableUniqueIndex).
+func isDeferrableUniqueIndex(desc catalog.TableDescriptor, idx catalog.Index) bool {
+	for _, uc := range desc.UniqueConstraintsWithoutIndex() {
+		if uc.UniqueWithoutIndexDesc().IndexID == idx.GetID() {
+			return deferrableUniqueIndex(desc, uc) != nil
+		}
+	}
+	return false
+}
+
+// validateDeferredConstraints runs all the deferred constraint checks of the
+// current transaction before it commits.
+func (ex *connExecutor) validateDeferredConstraints(ctx context.Context) error {
+	s := &ex.extraTxnState.deferredConstraints
+	if !s.hasPending() {
+		return nil
+	}
+	return s.validatePending(
+		ctx, ex.planner.InternalSQLTxn(), ex.planner.User(),
+		func(constraintKey) bool { return true },
+	)
+}
+
+// SetConstraints sets the check mode of deferrable constraints for the
+// current transaction. Switching constraints to IMMEDIATE runs any of their
+// checks that were deferred.
+func (p *planner) SetConstraints(ctx context.Context, n *tree.SetConstraints) (planNode, error) {
+	var keys []constraintKey
+	for i := range n.Names {
+		resolved, err := p.resolveConstraintName(ctx, &n.Names[i])
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, resolved...)
+	}
+
+	if p.extendedEvalCtx.TxnImplicit {
+		// Postgres no-ops outside of a transaction block with a warning, so copy
+		// accordingly.
+		p.BufferClientNotice(
+			ctx,
+			pgnotice.NewWithSeverityf(
+				"WARNING",
+				"SET CONSTRAINTS can only be used in transaction blocks",
+			),
+		)
+		return newZeroNode(nil /* columns */), nil
+	}
+	s := p.extendedEvalCtx.deferredConstraints
+	if s == nil {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"SET CONSTRAINTS is not supported in this context")
+	}
+
+	if n.Deferred {
+		s.setMode(keys, constraintCheckModeDeferred)
+		return newZeroNode(nil /* columns */), nil
+	}
+	s.setMode(keys, constraintCheckModeImmediate)
+	include := func(constraintKey) bool { return true }
+	if n.Names != nil {
+		keySet := make(map[constraintKey]struct{}, len(keys))
+		for _, key := range keys {
+			keySet[key] = struct{}{}
+		}
+		include = func(key constraintKey) bool {
+			_, ok := keySet[key]
+			return ok
+		}
+	}
+	if err := s.validatePending(ctx, p.InternalSQLTxn(), p.User(), include); err != nil {
+		return nil, err
+	}
+	return newZeroNode(nil /* columns */), nil
+}
+
+// resolveConstraintName returns the constraints with the given name in a SET
+// CONSTRAINTS statement. As in Postgres, a name that is not schema-qualified
+// refers to the matching constraints of the first schema in the search path
+// that has any. All the matching constraints must be deferrable.
+func (p *planner) resolveConstraintName(
+	ctx context.Context, name *tree.TableName,
+) ([]constraintKey, error) {
+	if name.ExplicitCatalog && string(name.CatalogName) != p.SessionData().Database {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"cross-database references are not implemented: %s", tree.ErrString(name))
+	}
+	rows, err := p.InternalSQLTxn().QueryBufferedEx(
+		ctx,
+		"set-constraints",
+		p.txn,
+		sessiondata.NoSessionDataOverride,
+		`SELECT n.nspname, c.conrelid::INT8, c.condeferrable `+
+			`FROM pg_catalog.pg_constraint AS c `+
+			`JOIN pg_catalog.pg_namespace AS n ON c.connamespace = n.oid `+
+			`WHERE c.conname = $1 AND c.conrelid != 0`,
+		string(name.ObjectName),
+	)
+	if err != nil {
+		return nil, err
+	}
+	bySchema := make(map[string][]tree.Datums)
+	for _, row := range rows {
+		schema := string(tree.MustBeDString(row[0]))
+		bySchema[schema] = append(bySchema[schema], row)
+	}
+	var matches []tree.Datums
+	if name.ExplicitSchema {
+		matches = bySchema[string(name.SchemaName)]
+	} else {
+		iter := p.SessionData().SearchPath.Iter()
+		for schema, ok := iter.Next(); ok && matches == nil; schema, ok = iter.Next() {
+			matches = bySchema[schema]
+		}
+	}
+	if len(matches) == 0 {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"constraint %q does not exist", tree.ErrString(name))
+	}
+	keys := make([]constraintKey, len(matches))
+	for i, row := range matches {
+		if !tree.MustBeDBool(row[2]) {
+			return nil, pgerror.Newf(pgcode.WrongObjectType,
+				"constraint %q is not deferrable", tree.ErrString(name))
+		}
+		keys[i] = constraintKey{
+			tableID: descpb.ID(tree.MustBeDInt(row[1])),
+			name:    string(name.ObjectName),
+		}
+	}
+	return keys, nil
+}
@@ -67,6 +67,11 @@ func (n *discardNode) startExec(params runParams) error {
 			return err
 		}
 
+		// UNLISTEN *
+		if s := params.p.extendedEvalCtx.notifications; s != nil {
+			s.actions = append(s.actions, listenAction{unlisten: true, all: true})
+		}
+
 	case tree.DiscardModeSequences:
 		params.p.sessionDataMutatorIterator.applyOnEachMutator(func(m sessionDataMutator) {
 			m.data.SequenceState = sessiondata.NewSequenceState()
@@ -539,6 +539,9 @@ func checkSupportForPlanNode(node planNode) (distRecommendation, error) {
 			if agg.distsqlBlocklist {
 				return cannotDistribute, newQueryNotSupportedErrorf("aggregate %q cannot be executed with distsql", agg.funcName)
 			}
+			if err := checkUserDefinedAggForDistSQL(agg.userDefined); err != nil {
+				return cannotDistribute, err
+			}
 		}
 		// Distribute aggregations if possible.
 		return rec.compose(shouldDistribute), nil
@@ -731,6 +734,11 @@ func checkSupportForPlanNode(node planNode) (distRecommendation, error) {
 		if err != nil {
 			return cannotDistribute, err
 		}
+		for _, f := range n.funcs {
+			if err := checkUserDefinedAggForDistSQL(f.userDefined); err != nil {
+				return cannotDistribute, err
+			}
+		}
 		for _, f := range n.funcs {
 			if len(f.partitionIdxs) > 0 {
 				// If at least one function has PARTITION BY clause, then we
@@ -1947,6 +1955,7 @@ func (dsp *DistSQLPlanner) createTableReaders(
 			post:              post,
 			desc:              n.desc,
 			spans:             n.spans,
+			brinConstraints:   n.brinConstraints,
 			reverse:           n.reverse,
 			parallelize:       n.parallelize,
 			estimatedRowCount: n.estimatedRowCount,
@@ -1964,6 +1973,7 @@ type tableReaderPlanningInfo struct {
 	post              execinfrapb.PostProcessSpec
 	desc              catalog.TableDescriptor
 	spans             []roachpb.Span
+	brinConstraints   []exec.ColumnConstraint
 	reverse           bool
 	parallelize       bool
 	estimatedRowCount uint64
@@ -2096,6 +2106,23 @@ func (dsp *DistSQLPlanner) planTableReaders(
 		ignoreMisplannedRanges bool
 		err                    error
 	)
+	if len(info.brinConstraints) > 0 {
+		// Skip the parts of the table which cannot match the filters of the
+		// scan according to its BRIN indexes. This reads their summaries, so
+		// it is only done once the scan is about to be executed.
+		evalCtx := &planCtx.ExtendedEvalCtx.Context
+		info.spans, err = constrainSpansWithBRINIndexes(
+			ctx, evalCtx, evalCtx.Txn, planCtx.ExtendedEvalCtx.Codec, &dsp.st.SV,
+			info.desc, info.spans, info.brinConstraints,
+		)
+		if err != nil {
+			return err
+		}
+		if len(info.spans) == 0 {
+			dsp.planEmptyTableReaders(planCtx, p, info)
+			return nil
+		}
+	}
 	if planCtx.isLocal {
 		spanPartitions, parallelizeLocal = dsp.maybeParallelizeLocalScans(ctx, planCtx, info)
 	} else if info.post.Limit == 0 {
@@ -2195,6 +2222,25 @@ func (dsp *DistSQLPlanner) planTableReaders(
 	return nil
 }
 
+// planEmptyTableReaders plans a Values processor with no rows in place of the
+// table readers of a scan which cannot return any rows.
+func (dsp *DistSQLPlanner) planEmptyTableReaders(
+	planCtx *PlanningCtx, p *PhysicalPlan, info *tableReaderPlanningInfo,
+) {
+	typs := make([]*types.T, len(info.spec.FetchSpec.FetchedColumns))
+	for i := range typs {
+		typs[i] = info.spec.FetchSpec.FetchedColumns[i].Type
+	}
+	corePlacement := []physicalplan.ProcessorCorePlacement{{
+		SQLInstanceID: dsp.gatewaySQLInstanceID,
+		Core: execinfrapb.ProcessorCoreUnion{
+			Values: dsp.createValuesSpec(planCtx, typs, 0 /* numRows */, nil /* rawBytes */),
+		},
+	}}
+	p.AddNoInputStage(corePlacement, info.post, typs, execinfrapb.Ordering{})
+	p.PlanToStreamColMap = identityMap(make([]int, len(typs)), len(typs))
+}
+
 // createPlanForRender takes a PhysicalPlan and updates it to produce results
 // corresponding to the render node. An evaluator stage is added if the render
 // node has any expressions which are not just simple column references.
@@ -2296,11 +2342,22 @@ func (dsp *DistSQLPlanner) addAggregators(
 	aggregations := make([]execinfrapb.AggregatorSpec_Aggregation, len(n.funcs))
 	argumentsColumnTypes := make([][]*types.T, len(n.funcs))
 	for i, fholder := range n.funcs {
-		funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
-		if err != nil {
-			return err
+		if fholder.userDefined != nil {
+			aggregations[i].Func = execinfrapb.UserDefined
+			var err error
+			aggregations[i].UserDefined, err = makeUserDefinedAggSpec(
+				ctx, planCtx, fholder.userDefined, n.columns[i].Typ,
+			)
+			if err != nil {
+				return err
+			}
+		} else {
+			funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
+			if err != nil {
+				return err
+			}
+			aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		}
-		aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		aggregations[i].Distinct = fholder.isDistinct
 		for _, renderIdx := range fholder.argRenderIdxs {
 			aggregations[i].ColIdx = append(aggregations[i].ColIdx, uint32(p.PlanToStreamColMap[renderIdx]))
@@ -2335,6 +2392,82 @@ func (dsp *DistSQLPlanner) addAggregators(
 	})
 }
 
+// makeUserDefinedAggSpec creates the specification of the support functions of
+// an aggregate created with CREATE AGGREGATE.
+func makeUserDefinedAggSpec(
+	ctx context.Context, planCtx *PlanningCtx, agg *exec.UserDefinedAgg, resultType *types.T,
+) (*execinfrapb.AggregatorSpec_UserDefinedAggregate, error) {
+	var ef physicalplan.ExprFactory
+	ef.Init(ctx, planCtx, nil /* indexVarMap */)
+	spec := &execinfrapb.AggregatorSpec_UserDefinedAggregate{
+		StateType:       agg.StateType,
+		ResultType:      resultType,
+		StrictStateFunc: agg.StrictStateFunc,
+	}
+	var err error
+	if spec.StateFunc, err = ef.Make(agg.StateFunc); err != nil {
+		return nil, err
+	}
+	if spec.FinalFunc, err = ef.Make(agg.FinalFunc); err != nil {
+		return nil, err
+	}
+	if spec.CombineFunc, err = ef.Make(agg.CombineFunc); err != nil {
+		return nil, err
+	}
+	if spec.InitState, err = ef.Make(agg.InitState); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// splitUserDefinedAgg splits a user-defined aggregate which has a combine
+// function into a local aggregation, which computes a partial state, and a
+// final aggregation, which combines the partial states that are produced by
+// the local aggregation with the given index.
+func splitUserDefinedAgg(
+	agg execinfrapb.AggregatorSpec_Aggregation, localIdx uint32,
+) (local, final execinfrapb.AggregatorSpec_Aggregation) {
+	ud := agg.UserDefined
+	localUD := *ud
+	localUD.FinalFunc = execinfrapb.Expression{}
+	localUD.CombineFunc = execinfrapb.Expression{}
+	localUD.ResultType = ud.StateType
+	local = execinfrapb.AggregatorSpec_Aggregation{
+		Func:         execinfrapb.UserDefined,
+		ColIdx:       agg.ColIdx,
+		FilterColIdx: agg.FilterColIdx,
+		UserDefined:  &localUD,
+	}
+	// The final stage starts with a NULL state, so that the first non-NULL
+	// partial state becomes the state, and combines the following partial
+	// states with the combine function. Partial states which are NULL are
+	// skipped.
+	finalUD := *ud
+	finalUD.StateFunc = ud.CombineFunc
+	finalUD.StrictStateFunc = true
+	finalUD.InitState = execinfrapb.Expression{Expr: "NULL", LocalExpr: tree.DNull}
+	final = execinfrapb.AggregatorSpec_Aggregation{
+		Func:        execinfrapb.UserDefined,
+		ColIdx:      []uint32{localIdx},
+		UserDefined: &finalUD,
+	}
+	return local, final
+}
+
+// checkUserDefinedAggForDistSQL verifies that the support functions of an
+// aggregate created with CREATE AGGREGATE can be executed with distSQL.
+func checkUserDefinedAggForDistSQL(agg *exec.UserDefinedAgg) error {
+	if agg == nil {
+		return nil
+	}
+	for _, fn := range []tree.TypedExpr{agg.StateFunc, agg.FinalFunc, agg.CombineFunc} {
+		if err := checkExprForDistSQL(fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // planAggregators plans the aggregator processors. An evaluator stage is added
 // if necessary.
 // Invariants assumed:
@@ -2390,9 +2523,15 @@ func (dsp *DistSQLPlanner) planAggregators(
 	//      is the same (i.e. both either local or distributed).
 	//      TODO(yuzefovich): we could consider lifting the condition 5. by
 	//      changing the distribution of the hash joiner stager.
+	//   6. none of the aggregations are user-defined.
 	planHashGroupJoin := planCtx.ExtendedEvalCtx.SessionData().ExperimentalHashGroupJoinEnabled
 	if planHashGroupJoin { // condition 1.
 		planHashGroupJoin = func() bool {
+			for _, e := range info.aggregations {
+				if e.UserDefined != nil {
+					return false // condition 6.
+				}
+			}
 			prevStageProc := p.Processors[p.ResultRouters[0]].Spec
 			hjSpec := prevStageProc.Core.HashJoiner
 			if hjSpec == nil {
@@ -2524,6 +2663,11 @@ func (dsp *DistSQLPlanner) planAggregators(
 	//  - no function is performing distinct aggregation.
 	//  TODO(radu): we could relax this by splitting the aggregation into two
 	//  different paths and joining on the results.
+	//
+	// User-defined aggregates support a local stage if they have a combine
+	// function. The local stage computes partial states with the state
+	// transition function, and the final stage merges them with the combine
+	// function before applying the final function.
 	multiStage := prevStageNode == 0
 	if multiStage {
 		for _, e := range info.aggregations {
@@ -2531,6 +2675,13 @@ func (dsp *DistSQLPlanner) planAggregators(
 				multiStage = false
 				break
 			}
+			if e.UserDefined != nil {
+				if e.UserDefined.CombineFunc.Empty() {
+					multiStage = false
+					break
+				}
+				continue
+			}
 			// Check that the function supports a local stage.
 			if _, ok := physicalplan.DistAggregationTable[e.Func]; !ok {
 				multiStage = false
@@ -2572,6 +2723,11 @@ func (dsp *DistSQLPlanner) planAggregators(
 		nFinalAgg := 0
 		needRender := false
 		for _, e := range info.aggregations {
+			if e.UserDefined != nil {
+				nLocalAgg++
+				nFinalAgg++
+				continue
+			}
 			info := physicalplan.DistAggregationTable[e.Func]
 			nLocalAgg += len(info.LocalStage)
 			nFinalAgg += len(info.FinalStage)
@@ -2607,6 +2763,18 @@ func (dsp *DistSQLPlanner) planAggregators(
 		// to all final aggregations.
 		finalIdx := 0
 		for _, e := range info.aggregations {
+			if e.UserDefined != nil {
+				localAgg, finalAgg := splitUserDefinedAgg(e, uint32(len(localAggs)))
+				localAggs = append(localAggs, localAgg)
+				intermediateTypes = append(intermediateTypes, e.UserDefined.StateType)
+				finalIdxMap[finalIdx] = uint32(len(finalAggs))
+				finalAggs = append(finalAggs, finalAgg)
+				if needRender {
+					finalPreRenderTypes = append(finalPreRenderTypes, e.UserDefined.ResultType)
+				}
+				finalIdx++
+				continue
+			}
 			info := physicalplan.DistAggregationTable[e.Func]
 
 			// relToAbsLocalIdx maps each local stage for the given
@@ -2827,6 +2995,15 @@ func (dsp *DistSQLPlanner) planAggregators(
 			var ef physicalplan.ExprFactory
 			ef.Init(ctx, planCtx, nil /* indexVarMap */)
 			for i, e := range info.aggregations {
+				if e.UserDefined != nil {
+					var err error
+					renderExprs[i], err = ef.Make(h.IndexedVar(int(finalIdxMap[finalIdx])))
+					if err != nil {
+						return err
+					}
+					finalIdx++
+					continue
+				}
 				info := physicalplan.DistAggregationTable[e.Func]
 				if info.FinalRendering == nil {
 					// mappedIdx corresponds to the index
@@ -2875,6 +3052,10 @@ func (dsp *DistSQLPlanner) planAggregators(
 
 	finalOutTypes := make([]*types.T, len(info.aggregations))
 	for i, agg := range info.aggregations {
+		if agg.UserDefined != nil {
+			finalOutTypes[i] = agg.UserDefined.ResultType
+			continue
+		}
 		argTypes := make([]*types.T, len(agg.ColIdx)+len(agg.Arguments))
 		for j, c := range agg.ColIdx {
 			argTypes[j] = inputTypes[c]
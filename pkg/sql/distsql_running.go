@@ -1114,6 +1114,23 @@ func (w *errOnlyResultWriter) SetRowsAffected(ctx context.Context, n int) {
 	panic("SetRowsAffected not supported by errOnlyResultWriter")
 }
 
+// discardRowsResultWriter is a rowResultWriter and batchResultWriter that
+// receives an error and ignores all rows.
+type discardRowsResultWriter struct {
+	errOnlyResultWriter
+}
+
+var _ rowResultWriter = &discardRowsResultWriter{}
+var _ batchResultWriter = &discardRowsResultWriter{}
+
+func (w *discardRowsResultWriter) AddRow(ctx context.Context, row tree.Datums) error {
+	return nil
+}
+
+func (w *discardRowsResultWriter) AddBatch(ctx context.Context, batch coldata.Batch) error {
+	return nil
+}
+
 // RowResultWriter is a thin wrapper around a RowContainer.
 type RowResultWriter struct {
 	rowContainer *rowContainerHelper
@@ -2092,7 +2109,11 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 			}
 		}
 
-		log.VEventf(ctx, 2, "executing cascade for constraint %s", plan.cascades[i].FKConstraint.Name())
+		if fk := plan.cascades[i].FKConstraint; fk != nil {
+			log.VEventf(ctx, 2, "executing cascade for constraint %s", fk.Name())
+		} else {
+			log.VEvent(ctx, 2, "executing AFTER triggers")
+		}
 
 		// We place a sequence point before every cascade, so that each subsequent
 		// cascade can observe the writes by the previous step. However, The
@@ -2163,6 +2184,9 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 			evalCtx,
 			recv,
 			false, /* parallelCheck */
+			// The rows produced by the queries that fire AFTER triggers are
+			// discarded.
+			plan.cascades[i].FKConstraint == nil, /* discardRows */
 			defaultGetSaveFlowsFunc,
 			planner.instrumentation.getAssociateNodeWithComponentsFn(),
 			recv.stats.add,
@@ -2222,6 +2246,7 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 				evalCtxFactory(false /* usedConcurrently */),
 				recv,
 				false, /* parallelCheck */
+				false, /* discardRows */
 				defaultGetSaveFlowsFunc,
 				planner.instrumentation.getAssociateNodeWithComponentsFn(),
 				recv.stats.add,
@@ -2263,6 +2288,8 @@ var parallelChecksConcurrencyLimit = settings.RegisterIntSetting(
 // with other check queries. If parallelCheck is true, then getSaveFlowsFunc,
 // associateNodeWithComponents, and addTopLevelQueryStats must be
 // concurrency-safe (if non-nil).
+// - discardRows indicates whether the rows produced by the query should be
+// ignored. Otherwise, the query must not produce any rows.
 // - getSaveFlowsFunc will only be called if
 // planner.instrumentation.ShouldSaveFlows() returns true.
 func (dsp *DistSQLPlanner) planAndRunPostquery(
@@ -2272,6 +2299,7 @@ func (dsp *DistSQLPlanner) planAndRunPostquery(
 	evalCtx *extendedEvalContext,
 	recv *DistSQLReceiver,
 	parallelCheck bool,
+	discardRows bool,
 	getSaveFlowsFunc func() func(map[base.SQLInstanceID]*execinfrapb.FlowSpec, execopnode.OpChains, []execinfra.LocalProcessor, bool) error,
 	associateNodeWithComponents func(exec.Node, execComponents),
 	addTopLevelQueryStats func(stats *topLevelQueryStats),
@@ -2307,9 +2335,15 @@ func (dsp *DistSQLPlanner) planAndRunPostquery(
 	postqueryRecv := recv.clone()
 	defer postqueryRecv.Release()
 	defer addTopLevelQueryStats(&postqueryRecv.stats)
-	postqueryResultWriter := &errOnlyResultWriter{}
-	postqueryRecv.resultWriter = postqueryResultWriter
-	postqueryRecv.batchWriter = postqueryResultWriter
+	if discardRows {
+		postqueryResultWriter := &discardRowsResultWriter{}
+		postqueryRecv.resultWriter = postqueryResultWriter
+		postqueryRecv.batchWriter = postqueryResultWriter
+	} else {
+		postqueryResultWriter := &errOnlyResultWriter{}
+		postqueryRecv.resultWriter = postqueryResultWriter
+		postqueryRecv.batchWriter = postqueryResultWriter
+	}
 	finishedSetupFn, cleanup := getFinishedSetupFn(planner)
 	defer cleanup()
 	dsp.Run(ctx, postqueryPlanCtx, planner.txn, postqueryPhysPlan, postqueryRecv, evalCtx, finishedSetupFn)
@@ -2402,7 +2436,8 @@ func (dsp *DistSQLPlanner) planAndRunChecksInParallel(
 			planner,
 			evalCtxFactory(true /* usedConcurrently */),
 			recv,
-			true, /* parallelCheck */
+			true,  /* parallelCheck */
+			false, /* discardRows */
 			getSaveFlowsFunc,
 			associateNodeWithComponents,
 			addTopLevelQueryStats,
@@ -164,6 +164,9 @@ func (e *distSQLSpecExecFactory) ConstructValues(
 func (e *distSQLSpecExecFactory) ConstructScan(
 	table cat.Table, index cat.Index, params exec.ScanParams, reqOrdering exec.OutputOrdering,
 ) (exec.Node, error) {
+	if table.IsForeignTable() {
+		return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: foreign table scan")
+	}
 	if table.IsVirtualTable() {
 		return constructVirtualScan(
 			e, e.planner, table, index, params, reqOrdering,
@@ -232,6 +235,11 @@ func (e *distSQLSpecExecFactory) ConstructScan(
 		return nil, err
 	}
 
+	var brinConstraints []exec.ColumnConstraint
+	if idx.Primary() {
+		brinConstraints = params.ColumnConstraints
+	}
+
 	isFullTableOrIndexScan := len(spans) == 1 && spans[0].EqualValue(
 		tabDesc.IndexSpan(e.planner.ExecCfg().Codec, idx.GetID()),
 	)
@@ -284,6 +292,7 @@ func (e *distSQLSpecExecFactory) ConstructScan(
 			post:              post,
 			desc:              tabDesc,
 			spans:             spans,
+			brinConstraints:   brinConstraints,
 			reverse:           params.Reverse,
 			parallelize:       params.Parallelize,
 			estimatedRowCount: params.EstimatedRowCount,
@@ -529,6 +538,10 @@ func (e *distSQLSpecExecFactory) constructAggregators(
 		i := len(groupCols) + j
 		spec := &aggregationSpecs[i]
 		agg := &aggregations[j]
+		if agg.UserDefined != nil {
+			return nil, unimplemented.NewWithIssue(
+				47473, "experimental opt-driven distsql planning: user-defined aggregates")
+		}
 		argumentsColumnTypes[i], err = populateAggFuncSpec(
 			e.ctx, spec, agg.FuncName, agg.Distinct, agg.ArgCols,
 			agg.ConstArgs, agg.Filter, planCtx, physPlan,
@@ -23,6 +23,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -70,6 +72,14 @@ func (p *planner) DropTable(ctx context.Context, n *tree.DropTable) (planNode, e
 		if droppedDesc == nil {
 			continue
 		}
+		if n.Foreign && !droppedDesc.IsForeignTable() {
+			return nil, pgerror.Newf(pgcode.WrongObjectType, "%q is not a foreign table", tn.Table())
+		}
+		if !n.Foreign && droppedDesc.IsForeignTable() {
+			return nil, errors.WithHint(
+				pgerror.Newf(pgcode.WrongObjectType, "%q is a foreign table", tn.Table()),
+				"Use DROP FOREIGN TABLE to remove a foreign table.")
+		}
 
 		td[droppedDesc.ID] = toDelete{tn, droppedDesc}
 	}
@@ -0,0 +1,157 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package evalcatalog
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+	"github.com/cockroachdb/errors"
+)
+
+// ResolveTextSearchConfig is part of the eval.CatalogBuiltins interface.
+//
+// The configuration is looked up with leased descriptors, so that it can be
+// resolved on remote nodes as well as on the gateway.
+func (b *Builtins) ResolveTextSearchConfig(
+	ctx context.Context, name string, currentDatabase string, searchPath sessiondata.SearchPath,
+) (*tsearch.Config, error) {
+	notFound := pgerror.Newf(pgcode.UndefinedObject,
+		"text search configuration %q does not exist", name)
+	un, err := parser.ParseTableName(name)
+	if err != nil {
+		return nil, notFound
+	}
+	dbName := currentDatabase
+	if un.HasExplicitCatalog() {
+		dbName = un.Catalog()
+	}
+	if dbName == "" {
+		return nil, notFound
+	}
+	g := b.dc.ByNameWithLeased(b.txn).MaybeGet()
+	db, err := g.Database(ctx, dbName)
+	if err != nil {
+		return nil, err
+	}
+	if db == nil {
+		return nil, notFound
+	}
+	var scNames []string
+	if un.HasExplicitSchema() {
+		scNames = []string{un.Schema()}
+	} else {
+		iter := searchPath.IterWithoutImplicitPGSchemas()
+		for scName, ok := iter.Next(); ok; scName, ok = iter.Next() {
+			scNames = append(scNames, scName)
+		}
+	}
+	for _, scName := range scNames {
+		sc, err := g.Schema(ctx, db, scName)
+		if err != nil {
+			return nil, err
+		}
+		if sc == nil || sc.SchemaKind() == catalog.SchemaVirtual {
+			continue
+		}
+		if desc := sc.GetTextSearchConfig(un.Object()); desc != nil {
+			return MakeTextSearchConfig(sc, desc)
+		}
+	}
+	return nil, notFound
+}
+
+// MakeTextSearchConfig returns the text search configuration of the given
+// schema which is described by the descriptor.
+func MakeTextSearchConfig(
+	sc catalog.SchemaDescriptor, desc *descpb.SchemaDescriptor_TextSearchConfig,
+) (*tsearch.Config, error) {
+	config := tsearch.NewConfig()
+	for _, m := range desc.Mappings {
+		typ, err := tsearch.ParseTokenType(m.TokenType)
+		if err != nil {
+			return nil, err
+		}
+		dicts := make([]*tsearch.Dictionary, len(m.Dictionaries))
+		for i, ref := range m.Dictionaries {
+			var ok bool
+			if ref.Builtin {
+				dicts[i], ok = tsearch.BuiltinDictionary(ref.Name)
+			} else if d := sc.GetTextSearchDictionary(ref.Name); d != nil {
+				dicts[i], err = MakeTextSearchDictionary(d)
+				if err != nil {
+					return nil, err
+				}
+				ok = true
+			}
+			if !ok {
+				return nil, errors.AssertionFailedf(
+					"text search dictionary %q of configuration %q not found", ref.Name, desc.Name)
+			}
+		}
+		config.AddMapping(typ, dicts...)
+		if m.Weight != "" {
+			if err := config.SetWeight(typ, m.Weight); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return config, nil
+}
+
+// MakeTextSearchDictionary returns the text search dictionary which is
+// described by the descriptor.
+func MakeTextSearchDictionary(
+	desc *descpb.SchemaDescriptor_TextSearchDictionary,
+) (*tsearch.Dictionary, error) {
+	template, err := tsearch.ParseDictTemplate(desc.Template)
+	if err != nil {
+		return nil, err
+	}
+	dict := &tsearch.Dictionary{
+		Template: template,
+		Language: desc.Language,
+		Reject:   desc.Reject,
+	}
+	if template == tsearch.DictTemplateSnowball {
+		if err := tsearch.ValidLanguage(desc.Language); err != nil {
+			return nil, err
+		}
+	}
+	if desc.StopWords != "" || len(desc.StopWordList) > 0 {
+		dict.StopWords = make(map[string]struct{})
+	}
+	if desc.StopWords != "" {
+		stopWords, err := tsearch.BuiltinStopWords(desc.StopWords)
+		if err != nil {
+			return nil, err
+		}
+		for w := range stopWords {
+			dict.StopWords[w] = struct{}{}
+		}
+	}
+	for _, w := range desc.StopWordList {
+		dict.StopWords[w] = struct{}{}
+	}
+	if len(desc.Synonyms) > 0 {
+		dict.Synonyms = make(map[string]string, len(desc.Synonyms))
+		for _, s := range desc.Synonyms {
+			dict.Synonyms[s.Word] = s.Synonym
+		}
+	}
+	return dict, nil
+}
@@ -75,6 +75,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/idxusage"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
+	"github.com/cockroachdb/cockroach/pkg/sql/notify"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/optionalnodeliveness"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
@@ -1362,6 +1363,10 @@ type ExecutorConfig struct {
 	// contention observability.
 	ContentionRegistry *contention.Registry
 
+	// NotificationRegistry is a node-level registry of the sessions listening
+	// on notification channels with LISTEN.
+	NotificationRegistry *notify.Registry
+
 	// RootMemoryMonitor is the root memory monitor of the entire server. Do not
 	// use this for normal purposes. It is to be used to establish any new
 	// root-level memory accounts that are not related to a user session.
@@ -2027,6 +2032,9 @@ func checkResultType(typ *types.T, fmtCode pgwirebase.FormatCode) error {
 	case types.TSQueryFamily:
 	case types.TSVectorFamily:
 	case types.IntervalFamily:
+	case types.JSONPathFamily:
+	case types.RangeFamily:
+	case types.MultirangeFamily:
 	case types.JsonFamily:
 	case types.UuidFamily:
 	case types.INetFamily:
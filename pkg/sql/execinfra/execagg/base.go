@@ -106,6 +106,12 @@ func GetAggregateConstructor(
 		argTypes[len(aggInfo.ColIdx)+j] = d.ResolvedType()
 		arguments[j] = d
 	}
+	if aggInfo.Func == execinfrapb.UserDefined {
+		constructor, outputType, err = GetUserDefinedAggregateInfo(
+			ctx, evalCtx, semaCtx, aggInfo.UserDefined, argTypes,
+		)
+		return
+	}
 	constructor, outputType, err = GetAggregateInfo(aggInfo.Func, argTypes...)
 	return
 }
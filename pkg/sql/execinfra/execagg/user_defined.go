@@ -0,0 +1,160 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package execagg
+
+import (
+	"context"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// GetUserDefinedAggregateInfo returns the aggregate constructor and the return
+// type for an aggregate created with CREATE AGGREGATE when applied on the
+// given types.
+//
+// evalCtx will not be mutated.
+func GetUserDefinedAggregateInfo(
+	ctx context.Context,
+	evalCtx *eval.Context,
+	semaCtx *tree.SemaContext,
+	spec *execinfrapb.AggregatorSpec_UserDefinedAggregate,
+	inputTypes []*types.T,
+) (aggregateConstructor AggregateConstructor, returnType *types.T, err error) {
+	if spec == nil {
+		return nil, nil, errors.AssertionFailedf("user-defined aggregate is missing its definition")
+	}
+	def := &userDefinedAggregateDef{
+		strict: spec.StrictStateFunc,
+	}
+	def.stateTypes = make([]*types.T, len(inputTypes)+1)
+	def.stateTypes[0] = spec.StateType
+	copy(def.stateTypes[1:], inputTypes)
+	def.stateRow = make(rowenc.EncDatumRow, len(def.stateTypes))
+	if err := def.stateFunc.Init(ctx, spec.StateFunc, def.stateTypes, semaCtx, evalCtx); err != nil {
+		return nil, nil, err
+	}
+	if !spec.FinalFunc.Empty() {
+		def.finalFunc = &execinfrapb.ExprHelper{}
+		if err := def.finalFunc.Init(ctx, spec.FinalFunc, def.stateTypes[:1], semaCtx, evalCtx); err != nil {
+			return nil, nil, err
+		}
+	}
+	var initState execinfrapb.ExprHelper
+	if err := initState.Init(ctx, spec.InitState, nil /* types */, semaCtx, evalCtx); err != nil {
+		return nil, nil, err
+	}
+	if def.initState, err = initState.Eval(ctx, nil /* row */); err != nil {
+		return nil, nil, err
+	}
+	constructAgg := func(_ *eval.Context, _ tree.Datums) eval.AggregateFunc {
+		return &userDefinedAggregate{
+			ctx:   ctx,
+			def:   def,
+			state: def.initState,
+		}
+	}
+	return constructAgg, spec.ResultType, nil
+}
+
+// userDefinedAggregateDef contains the state which is shared by all instances
+// of a user-defined aggregate in a processor.
+type userDefinedAggregateDef struct {
+	// stateTypes contains the type of the state followed by the types of the
+	// arguments.
+	stateTypes []*types.T
+	// stateRow is scratch space for passing the state and the arguments to
+	// stateFunc.
+	stateRow  rowenc.EncDatumRow
+	stateFunc execinfrapb.ExprHelper
+	finalFunc *execinfrapb.ExprHelper
+	initState tree.Datum
+	strict    bool
+}
+
+// userDefinedAggregate computes an aggregate created with CREATE AGGREGATE by
+// evaluating its state transition function for each input row, and its final
+// function on the last state.
+type userDefinedAggregate struct {
+	// ctx is used to evaluate the final function, since Result does not take
+	// a context.
+	ctx   context.Context
+	def   *userDefinedAggregateDef
+	state tree.Datum
+}
+
+var _ eval.AggregateFunc = &userDefinedAggregate{}
+
+// Add implements the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Add(
+	ctx context.Context, firstArg tree.Datum, otherArgs ...tree.Datum,
+) error {
+	def := a.def
+	numArgs := len(def.stateTypes) - 1
+	if numArgs > 0 {
+		def.stateRow[1] = rowenc.DatumToEncDatum(def.stateTypes[1], firstArg)
+		for i := range otherArgs {
+			def.stateRow[i+2] = rowenc.DatumToEncDatum(def.stateTypes[i+2], otherArgs[i])
+		}
+	}
+	if def.strict {
+		// A strict state transition function is not called if any of the
+		// arguments are NULL. If the state is NULL, the first argument becomes
+		// the state.
+		for i := 1; i <= numArgs; i++ {
+			if def.stateRow[i].IsNull() {
+				return nil
+			}
+		}
+		if a.state == tree.DNull {
+			if numArgs > 0 {
+				a.state = firstArg
+			}
+			return nil
+		}
+	}
+	def.stateRow[0] = rowenc.DatumToEncDatum(def.stateTypes[0], a.state)
+	state, err := def.stateFunc.Eval(ctx, def.stateRow)
+	if err != nil {
+		return err
+	}
+	a.state = state
+	return nil
+}
+
+// Result implements the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Result() (tree.Datum, error) {
+	if a.def.finalFunc == nil {
+		return a.state, nil
+	}
+	row := rowenc.EncDatumRow{rowenc.DatumToEncDatum(a.def.stateTypes[0], a.state)}
+	return a.def.finalFunc.Eval(a.ctx, row)
+}
+
+// Reset implements the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Reset(context.Context) {
+	a.state = a.def.initState
+}
+
+// Close implements the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Close(context.Context) {}
+
+// Size implements the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Size() int64 {
+	return sizeOfUserDefinedAggregate
+}
+
+var sizeOfUserDefinedAggregate = int64(unsafe.Sizeof(userDefinedAggregate{}))
@@ -303,6 +303,11 @@ func (*DummyEvalPlanner) RevalidateUniqueConstraint(
 	return errors.WithStack(errEvalPlanner)
 }
 
+// SummarizeBRINIndex is part of the Planner interface.
+func (*DummyEvalPlanner) SummarizeBRINIndex(ctx context.Context, indexName string) (int, error) {
+	return 0, errors.WithStack(errEvalPlanner)
+}
+
 // IsConstraintActive is part of the EvalPlanner interface.
 func (*DummyEvalPlanner) IsConstraintActive(
 	ctx context.Context, tableID int, constraintName string,
@@ -0,0 +1,258 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/errors"
+)
+
+// The options of a foreign table, given in the OPTIONS clause of CREATE
+// FOREIGN TABLE.
+const (
+	// foreignTableOptionFilename is the path of the file, or a glob pattern
+	// matching the files, to read relative to the external connection of the
+	// foreign table.
+	foreignTableOptionFilename = "filename"
+	// foreignTableOptionFormat is the format of the files, either csv (the
+	// default), avro or parquet.
+	foreignTableOptionFormat = "format"
+	// foreignTableOptionDelimiter is the field delimiter of csv files.
+	foreignTableOptionDelimiter = "delimiter"
+	// foreignTableOptionHeader indicates whether csv files start with a header
+	// row, which is skipped.
+	foreignTableOptionHeader = "header"
+	// foreignTableOptionNull is the string that represents NULL in csv files.
+	foreignTableOptionNull = "null"
+)
+
+// makeForeignTableFormat validates the options of a foreign table and returns
+// the URI of the files of the table and their format.
+func makeForeignTableFormat(
+	ft *descpb.TableDescriptor_ForeignTable,
+) (uri string, format roachpb.IOFileFormat, _ error) {
+	opts := make(map[string]string, len(ft.Options))
+	for _, o := range ft.Options {
+		if _, ok := opts[o.Key]; ok {
+			return "", format, pgerror.Newf(pgcode.InvalidParameterValue,
+				"option %q provided more than once", o.Key)
+		}
+		opts[o.Key] = o.Value
+	}
+
+	filename, ok := opts[foreignTableOptionFilename]
+	if !ok || filename == "" {
+		return "", format, pgerror.Newf(pgcode.InvalidParameterValue,
+			"option %q is required", foreignTableOptionFilename)
+	}
+	uri = (&url.URL{
+		Scheme: "external",
+		Host:   ft.Server,
+		Path:   "/" + strings.TrimPrefix(filename, "/"),
+	}).String()
+
+	switch f := strings.ToLower(opts[foreignTableOptionFormat]); f {
+	case "", "csv":
+		format.Format = roachpb.IOFileFormat_CSV
+	case "avro":
+		format.Format = roachpb.IOFileFormat_Avro
+		format.Avro.Format = roachpb.AvroOptions_OCF
+	case "parquet":
+		format.Format = roachpb.IOFileFormat_Parquet
+	default:
+		return "", format, pgerror.Newf(pgcode.InvalidParameterValue,
+			"unsupported foreign table format %q", f)
+	}
+
+	for _, o := range ft.Options {
+		k, v := o.Key, o.Value
+		switch k {
+		case foreignTableOptionFilename, foreignTableOptionFormat:
+			continue
+		case foreignTableOptionDelimiter, foreignTableOptionHeader, foreignTableOptionNull:
+			if format.Format != roachpb.IOFileFormat_CSV {
+				return "", format, pgerror.Newf(pgcode.InvalidParameterValue,
+					"option %q is only supported for the csv format", k)
+			}
+		default:
+			return "", format, pgerror.Newf(pgcode.InvalidParameterValue, "invalid option %q", k)
+		}
+		switch k {
+		case foreignTableOptionDelimiter:
+			r, size := utf8.DecodeRuneInString(v)
+			if size == 0 || size != len(v) {
+				return "", format, pgerror.Newf(pgcode.InvalidParameterValue,
+					"option %q must be a single character", k)
+			}
+			format.Csv.Comma = r
+		case foreignTableOptionHeader:
+			header, err := strconv.ParseBool(v)
+			if err != nil {
+				return "", format, pgerror.Newf(pgcode.InvalidParameterValue,
+					"option %q must be a boolean", k)
+			}
+			if header {
+				format.Csv.Skip = 1
+			}
+		case foreignTableOptionNull:
+			null := v
+			format.Csv.NullEncoding = &null
+		}
+	}
+	return uri, format, nil
+}
+
+// ForeignTableReadSpec describes the files of a foreign table to read.
+type ForeignTableReadSpec struct {
+	Table catalog.TableDescriptor
+	// URI is the URI of the files to read, which may contain a glob pattern.
+	URI    string
+	Format roachpb.IOFileFormat
+	// NeededCols is the set of ordinals of the public columns of the table
+	// which are read. The other columns are NULL in the emitted rows.
+	NeededCols intsets.Fast
+
+	EvalCtx                    *eval.Context
+	SemaCtx                    *tree.SemaContext
+	User                       username.SQLUsername
+	MakeExternalStorage        cloud.ExternalStorageFactory
+	MakeExternalStorageFromURI cloud.ExternalStorageFromURIFactory
+}
+
+// ReadForeignTable reads the rows of a foreign table from external storage,
+// calling emit with each row. Each row contains a datum for every public
+// column of the table, and emit may not retain it.
+//
+// This function is implemented in pkg/sql/importer, which reuses the readers
+// of IMPORT, and injected here via runtime initialization.
+var ReadForeignTable func(
+	ctx context.Context, spec ForeignTableReadSpec, emit func(tree.Datums) error,
+) error
+
+// foreignTableScanNode is a planNode that reads the rows of a foreign table
+// from external storage. It projects the needed columns of the table and
+// evaluates a filter that was pushed down into it, if any.
+type foreignTableScanNode struct {
+	desc catalog.TableDescriptor
+	// neededCols are the ordinals of the public columns of desc which are
+	// output, in ascending order.
+	neededCols []int
+	columns    colinfo.ResultColumns
+
+	// filter is evaluated against each output row, and rows for which it is not
+	// true are skipped.
+	filter tree.TypedExpr
+
+	run struct {
+		next    virtualTableGenerator
+		cleanup cleanupFunc
+		row     tree.Datums
+	}
+}
+
+var _ eval.IndexedVarContainer = &foreignTableScanNode{}
+
+func (n *foreignTableScanNode) startExec(params runParams) error {
+	if ReadForeignTable == nil {
+		return errors.AssertionFailedf("foreign tables are not supported in this binary")
+	}
+	uri, format, err := makeForeignTableFormat(n.desc.TableDesc().ForeignTable)
+	if err != nil {
+		return err
+	}
+	var neededCols intsets.Fast
+	for _, ord := range n.neededCols {
+		neededCols.Add(ord)
+	}
+	execCfg := params.ExecCfg()
+	spec := ForeignTableReadSpec{
+		Table:                      n.desc,
+		URI:                        uri,
+		Format:                     format,
+		NeededCols:                 neededCols,
+		EvalCtx:                    params.EvalContext().Copy(),
+		SemaCtx:                    params.p.SemaCtx(),
+		User:                       params.p.User(),
+		MakeExternalStorage:        execCfg.DistSQLSrv.ExternalStorage,
+		MakeExternalStorageFromURI: execCfg.DistSQLSrv.ExternalStorageFromURI,
+	}
+	worker := func(ctx context.Context, pusher rowPusher) error {
+		row := make(tree.Datums, len(n.neededCols))
+		return ReadForeignTable(ctx, spec, func(datums tree.Datums) error {
+			for i, ord := range n.neededCols {
+				row[i] = datums[ord]
+			}
+			return pusher.pushRow(row...)
+		})
+	}
+	n.run.next, n.run.cleanup, err = setupGenerator(params.ctx, worker, execCfg.Stopper)
+	return err
+}
+
+func (n *foreignTableScanNode) Next(params runParams) (bool, error) {
+	for {
+		row, err := n.run.next()
+		if err != nil || row == nil {
+			return false, err
+		}
+		n.run.row = row
+		if n.filter == nil {
+			return true, nil
+		}
+		params.EvalContext().PushIVarContainer(n)
+		passesFilter, err := execinfrapb.RunFilter(params.ctx, n.filter, params.EvalContext())
+		params.EvalContext().PopIVarContainer()
+		if err != nil {
+			return false, err
+		}
+		if passesFilter {
+			return true, nil
+		}
+	}
+}
+
+func (n *foreignTableScanNode) Values() tree.Datums {
+	return n.run.row
+}
+
+func (n *foreignTableScanNode) Close(ctx context.Context) {
+	if n.run.cleanup != nil {
+		n.run.cleanup(ctx)
+	}
+}
+
+// IndexedVarEval implements the eval.IndexedVarContainer interface.
+func (n *foreignTableScanNode) IndexedVarEval(idx int) (tree.Datum, error) {
+	return n.run.row[idx], nil
+}
+
+// IndexedVarResolvedType implements the tree.IndexedVarContainer interface.
+func (n *foreignTableScanNode) IndexedVarResolvedType(idx int) *types.T {
+	return n.columns[idx].Typ
+}
@@ -5957,7 +5957,7 @@ func TestImportPgDumpIgnoredStmts(t *testing.T) {
 
 	data := `
 				-- Statements that CRDB cannot parse.
-				CREATE TRIGGER conditions_set_updated_at BEFORE UPDATE ON conditions FOR EACH ROW EXECUTE PROCEDURE set_updated_at();
+				CREATE RULE conditions_notify AS ON UPDATE TO conditions DO ALSO NOTIFY conditions;
 
 				REVOKE ALL ON SEQUENCE knex_migrations_id_seq FROM PUBLIC;
 				REVOKE ALL ON SEQUENCE knex_migrations_id_seq FROM database;
@@ -5969,6 +5969,7 @@ func TestImportPgDumpIgnoredStmts(t *testing.T) {
 				COMMENT ON FUNCTION f() is 'f';
 				CREATE EXTENSION IF NOT EXISTS plpgsql WITH SCHEMA pg_catalog;
 
+				-- Statements that CRDB can parse, but IMPORT does not support.
 				ALTER AGGREGATE myavg(integer) RENAME TO my_average;
 				ALTER DOMAIN zipcode SET NOT NULL;
 
@@ -6077,7 +6078,7 @@ func TestImportPgDumpIgnoredStmts(t *testing.T) {
 		}
 
 		schemaFileContents := []string{
-			`create trigger: could not be parsed
+			`create rule: could not be parsed
 revoke privileges on sequence: could not be parsed
 revoke privileges on sequence: could not be parsed
 grant privileges on sequence: could not be parsed
@@ -6085,13 +6086,13 @@ grant privileges on sequence: could not be parsed
 comment on extension: could not be parsed
 comment on function: could not be parsed
 create extension if not exists with: could not be parsed
-alter aggregate: could not be parsed
-alter domain: could not be parsed
+ALTER AGGREGATE myavg(INT8) RENAME TO my_average: unsupported by IMPORT
+ALTER DOMAIN zipcode SET NOT NULL: unsupported by IMPORT
 `,
 			`create function: could not be parsed
 alter function: could not be parsed
 alter table alter column add: could not be parsed
-copy from unsupported format: could not be parsed
+COPY db."table" (col1, col2, col3, col4) FROM '$$PATH$$/3057.dat': unsupported by IMPORT
 grant privileges on schema with: could not be parsed
 COMMENT ON TABLE t IS 'This should be skipped': unsupported by IMPORT
 COMMENT ON DATABASE t IS 'This should be skipped': unsupported by IMPORT
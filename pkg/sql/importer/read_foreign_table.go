@@ -0,0 +1,187 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+func init() {
+	sql.ReadForeignTable = readForeignTable
+}
+
+// readForeignTable reads the rows of a foreign table from the files matching
+// spec.URI, using the same row producers and consumers as IMPORT. Unlike
+// IMPORT, the rows are read serially and emitted as datums instead of being
+// converted into KVs. Parquet files, which IMPORT does not support, are read
+// by readForeignTableParquet.
+func readForeignTable(
+	ctx context.Context, spec sql.ForeignTableReadSpec, emit func(tree.Datums) error,
+) error {
+	files, err := expandForeignTableURI(ctx, spec)
+	if err != nil {
+		return err
+	}
+	if spec.Format.Format == roachpb.IOFileFormat_Parquet {
+		return readForeignTableParquet(ctx, spec, files, emit)
+	}
+	dataFiles := make(map[int32]string, len(files))
+	for i, f := range files {
+		dataFiles[int32(i)] = f
+	}
+
+	importCtx := &parallelImportContext{
+		semaCtx:   spec.SemaCtx,
+		evalCtx:   spec.EvalCtx,
+		tableDesc: spec.Table,
+	}
+	visibleCols := spec.Table.VisibleColumns()
+	conv := &row.DatumRowConverter{
+		EvalCtx:         spec.EvalCtx,
+		SemaCtx:         spec.SemaCtx,
+		VisibleCols:     visibleCols,
+		VisibleColTypes: make([]*types.T, len(visibleCols)),
+	}
+	for i, col := range visibleCols {
+		conv.VisibleColTypes[i] = col.GetType()
+	}
+	// The csv consumer only parses the fields of the target columns, so only the
+	// needed columns are targeted. The avro consumer fills in every field of a
+	// record regardless of the target columns.
+	switch spec.Format.Format {
+	case roachpb.IOFileFormat_CSV:
+		conv.TargetColOrds = spec.NeededCols
+	default:
+		for i := range visibleCols {
+			conv.TargetColOrds.Add(i)
+		}
+	}
+	datums := make(tree.Datums, len(visibleCols))
+
+	readFile := func(
+		ctx context.Context, input *fileReader, _ int32, _ int64, _ chan string,
+	) error {
+		var producer importRowProducer
+		var consumer importRowConsumer
+		var skip int64
+		switch spec.Format.Format {
+		case roachpb.IOFileFormat_CSV:
+			producer, consumer = newCSVPipeline(&csvInputReader{
+				importCtx:           importCtx,
+				numExpectedDataCols: len(visibleCols),
+				opts:                spec.Format.Csv,
+			}, input)
+			skip = int64(spec.Format.Csv.Skip)
+		case roachpb.IOFileFormat_Avro:
+			var err error
+			producer, consumer, err = newImportAvroPipeline(&avroInputReader{
+				importContext: importCtx,
+				opts:          spec.Format.Avro,
+			}, input)
+			if err != nil {
+				return err
+			}
+		default:
+			return errors.AssertionFailedf("unsupported foreign table format %s", spec.Format.Format)
+		}
+
+		for rowNum := int64(1); producer.Scan(); rowNum++ {
+			if rowNum <= skip {
+				if err := producer.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			r, err := producer.Row()
+			if err != nil {
+				return err
+			}
+			conv.Datums = make(tree.Datums, len(visibleCols))
+			if err := consumer.FillDatums(ctx, r, rowNum, conv); err != nil {
+				return err
+			}
+			if spec.Format.Format == roachpb.IOFileFormat_CSV {
+				// The csv consumer compacts the datums of the target columns, so
+				// they are spread out to the ordinals of their columns.
+				i := 0
+				for ord := range datums {
+					if conv.TargetColOrds.Contains(ord) {
+						datums[ord] = conv.Datums[i]
+						i++
+					} else {
+						datums[ord] = tree.DNull
+					}
+				}
+			} else {
+				for ord, d := range conv.Datums {
+					if d == nil {
+						d = tree.DNull
+					}
+					datums[ord] = d
+				}
+			}
+			if err := emit(datums); err != nil {
+				return err
+			}
+		}
+		return producer.Err()
+	}
+	return readInputFiles(
+		ctx, dataFiles, nil /* resumePos */, spec.Format, readFile, spec.MakeExternalStorage, spec.User,
+	)
+}
+
+// expandForeignTableURI returns the URIs of the files matching the URI of a
+// foreign table, which may contain a glob pattern.
+func expandForeignTableURI(ctx context.Context, spec sql.ForeignTableReadSpec) ([]string, error) {
+	uri, err := url.Parse(spec.URI)
+	if err != nil {
+		return nil, err
+	}
+	prefix := cloud.GetPrefixBeforeWildcard(uri.Path)
+	if len(prefix) == len(uri.Path) {
+		return []string{spec.URI}, nil
+	}
+	pattern := uri.Path[len(prefix):]
+	uri.Path = prefix
+	s, err := spec.MakeExternalStorageFromURI(ctx, uri.String(), spec.User)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+	var files []string
+	if err := s.List(ctx, "", "", func(s string) error {
+		ok, err := path.Match(pattern, s)
+		if ok {
+			uri.Path = prefix + s
+			files = append(files, uri.String())
+		}
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	if len(files) < 1 {
+		return nil, errors.Errorf(`no files matched %q in prefix %q in uri provided: %q`, pattern, prefix, spec.URI)
+	}
+	sort.Strings(files)
+	return files, nil
+}
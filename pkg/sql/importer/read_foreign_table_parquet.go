@@ -0,0 +1,468 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v11/parquet"
+	"github.com/apache/arrow/go/v11/parquet/file"
+	"github.com/apache/arrow/go/v11/parquet/schema"
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
+	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
+)
+
+// parquetBatchSize is the number of values read at a time from a column chunk
+// of a parquet file.
+const parquetBatchSize = 1024
+
+// readForeignTableParquet reads the rows of a foreign table from parquet files.
+// Unlike csv and avro files, parquet files are not read as a stream: the
+// footer of a file is read first, and then only the column chunks of the
+// needed columns are read, one row group at a time.
+//
+// The columns of a parquet file are matched to the columns of the table by
+// name. Only flat schemas are supported, i.e. the matched columns must not be
+// repeated. Values are converted to the types of the table columns by the
+// logical type of the parquet column when it has one, by parsing them when
+// they are strings, and by an assignment cast otherwise.
+func readForeignTableParquet(
+	ctx context.Context, spec sql.ForeignTableReadSpec, files []string, emit func(tree.Datums) error,
+) error {
+	cols := spec.Table.PublicColumns()
+	datums := make(tree.Datums, len(cols))
+	for _, f := range files {
+		if err := readParquetFile(ctx, spec, f, func(colDatums []tree.Datums, numRows int) error {
+			for i := 0; i < numRows; i++ {
+				for ord := range datums {
+					if colDatums[ord] == nil {
+						datums[ord] = tree.DNull
+					} else {
+						datums[ord] = colDatums[ord][i]
+					}
+				}
+				if err := emit(datums); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readParquetFile reads a parquet file one row group at a time. For each row
+// group, emitRowGroup is called with the datums of the needed columns of the
+// table, indexed by column ordinal. The datums of the other columns are nil.
+func readParquetFile(
+	ctx context.Context,
+	spec sql.ForeignTableReadSpec,
+	uri string,
+	emitRowGroup func(colDatums []tree.Datums, numRows int) error,
+) (err error) {
+	conf, err := cloud.ExternalStorageConfFromURI(uri, spec.User)
+	if err != nil {
+		return err
+	}
+	es, err := spec.MakeExternalStorage(ctx, conf)
+	if err != nil {
+		return err
+	}
+	defer es.Close()
+	size, err := es.Size(ctx, "")
+	if err != nil {
+		return err
+	}
+	reader, err := file.NewParquetReader(&parquetFileReader{ctx: ctx, es: es, size: size})
+	if err != nil {
+		return errors.Wrap(err, "reading parquet file")
+	}
+	defer func() {
+		err = errors.CombineErrors(err, reader.Close())
+	}()
+
+	cols := spec.Table.PublicColumns()
+	colIdxs, err := matchParquetColumns(reader.MetaData().Schema, cols, spec.NeededCols.Ordered())
+	if err != nil {
+		return err
+	}
+	colDatums := make([]tree.Datums, len(cols))
+	for rg := 0; rg < reader.NumRowGroups(); rg++ {
+		rgr := reader.RowGroup(rg)
+		numRows := int(rgr.NumRows())
+		for ord, colIdx := range colIdxs {
+			if colIdx < 0 {
+				continue
+			}
+			colReader, err := rgr.Column(colIdx)
+			if err != nil {
+				return err
+			}
+			if colDatums[ord], err = readParquetColumnChunk(
+				ctx, spec, colReader, cols[ord], numRows,
+			); err != nil {
+				return err
+			}
+		}
+		if err := emitRowGroup(colDatums, numRows); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchParquetColumns returns, for each public column of the table, the index
+// of the parquet column that it is read from, or -1 for the columns that are
+// not needed. A parquet column matches a table column if its name is equal to
+// the name of the table column, ignoring case if there is no exact match.
+func matchParquetColumns(
+	sch *schema.Schema, cols []catalog.Column, neededCols []int,
+) ([]int, error) {
+	colIdxs := make([]int, len(cols))
+	for i := range colIdxs {
+		colIdxs[i] = -1
+	}
+	for _, ord := range neededCols {
+		name := cols[ord].GetName()
+		for i := 0; i < sch.NumColumns(); i++ {
+			c := sch.Column(i)
+			if c.Path() == name {
+				colIdxs[ord] = i
+				break
+			}
+			if colIdxs[ord] < 0 && strings.EqualFold(c.Path(), name) {
+				colIdxs[ord] = i
+			}
+		}
+		if colIdxs[ord] < 0 {
+			return nil, pgerror.Newf(pgcode.UndefinedColumn,
+				"column %q does not exist in parquet file", name)
+		}
+		if sch.Column(colIdxs[ord]).MaxRepetitionLevel() > 0 {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"column %q: repeated parquet columns are not supported", name)
+		}
+	}
+	return colIdxs, nil
+}
+
+// readParquetColumnChunk reads the values of a column chunk and converts them
+// into datums of the type of col.
+func readParquetColumnChunk(
+	ctx context.Context,
+	spec sql.ForeignTableReadSpec,
+	r file.ColumnChunkReader,
+	col catalog.Column,
+	numRows int,
+) (tree.Datums, error) {
+	var values []tree.Datum
+	var err error
+	switch r.Type() {
+	case parquet.Types.Boolean:
+		values, err = readParquetValues(r, numRows, func(v bool) (tree.Datum, error) {
+			return tree.MakeDBool(tree.DBool(v)), nil
+		})
+	case parquet.Types.Int32:
+		values, err = readParquetValues(r, numRows, func(v int32) (tree.Datum, error) {
+			return convertParquetInt(r.Descriptor().LogicalType(), int64(v))
+		})
+	case parquet.Types.Int64:
+		values, err = readParquetValues(r, numRows, func(v int64) (tree.Datum, error) {
+			return convertParquetInt(r.Descriptor().LogicalType(), v)
+		})
+	case parquet.Types.Int96:
+		values, err = readParquetValues(r, numRows, func(v parquet.Int96) (tree.Datum, error) {
+			return tree.MakeDTimestampTZ(parquetInt96ToTime(v), time.Microsecond)
+		})
+	case parquet.Types.Float:
+		values, err = readParquetValues(r, numRows, func(v float32) (tree.Datum, error) {
+			return tree.NewDFloat(tree.DFloat(v)), nil
+		})
+	case parquet.Types.Double:
+		values, err = readParquetValues(r, numRows, func(v float64) (tree.Datum, error) {
+			return tree.NewDFloat(tree.DFloat(v)), nil
+		})
+	case parquet.Types.ByteArray:
+		values, err = readParquetValues(r, numRows, func(v parquet.ByteArray) (tree.Datum, error) {
+			return convertParquetBytes(ctx, spec, r.Descriptor().LogicalType(), false /* fixedLen */, col.GetType(), v)
+		})
+	case parquet.Types.FixedLenByteArray:
+		values, err = readParquetValues(r, numRows, func(v parquet.FixedLenByteArray) (tree.Datum, error) {
+			return convertParquetBytes(ctx, spec, r.Descriptor().LogicalType(), true /* fixedLen */, col.GetType(), v)
+		})
+	default:
+		return nil, errors.AssertionFailedf("unexpected parquet type %s", r.Type())
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading column %q", col.GetName())
+	}
+
+	typ := col.GetType()
+	for i, d := range values {
+		if d == tree.DNull {
+			if !col.IsNullable() {
+				return nil, sqlerrors.NewNonNullViolationError(col.GetName())
+			}
+			continue
+		}
+		if !d.ResolvedType().Identical(typ) {
+			if d, err = eval.PerformAssignmentCast(ctx, spec.EvalCtx, d, typ); err != nil {
+				return nil, errors.Wrapf(err, "reading column %q", col.GetName())
+			}
+		}
+		if values[i], err = tree.AdjustValueToType(typ, d); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// parquetBatchReader is implemented by the typed column chunk readers of the
+// parquet library.
+type parquetBatchReader[T any] interface {
+	ReadBatch(batchSize int64, values []T, defLvls, repLvls []int16) (total int64, valuesRead int, err error)
+}
+
+// readParquetValues reads the numRows values of a column chunk which is not
+// repeated, converting the non-NULL values with conv.
+func readParquetValues[T any](
+	r file.ColumnChunkReader, numRows int, conv func(T) (tree.Datum, error),
+) (tree.Datums, error) {
+	br, ok := r.(parquetBatchReader[T])
+	if !ok {
+		var t T
+		return nil, errors.AssertionFailedf("expected batch reader for type %T, found %T", t, r)
+	}
+	// The values of the non-NULL rows are packed at the front of values. A row
+	// is NULL if its definition level is lower than the maximum one; columns
+	// with a maximum definition level of 0 are required.
+	maxDef := r.Descriptor().MaxDefinitionLevel()
+	values := make([]T, parquetBatchSize)
+	defLvls := make([]int16, parquetBatchSize)
+	result := make(tree.Datums, 0, numRows)
+	for len(result) < numRows {
+		total, _, err := br.ReadBatch(parquetBatchSize, values, defLvls, nil /* repLvls */)
+		if err != nil {
+			return nil, err
+		}
+		if total == 0 {
+			break
+		}
+		v := 0
+		for i := 0; i < int(total); i++ {
+			if maxDef > 0 && defLvls[i] < maxDef {
+				result = append(result, tree.DNull)
+				continue
+			}
+			d, err := conv(values[v])
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, d)
+			v++
+		}
+	}
+	if len(result) != numRows {
+		return nil, errors.Newf("expected %d values in row group, found %d", numRows, len(result))
+	}
+	return result, nil
+}
+
+// convertParquetInt converts an int32 or int64 parquet value into a datum
+// according to the logical type of its column.
+func convertParquetInt(lt schema.LogicalType, v int64) (tree.Datum, error) {
+	switch t := lt.(type) {
+	case schema.DateLogicalType:
+		d, err := pgdate.MakeDateFromUnixEpoch(v)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDDate(d), nil
+	case *schema.TimestampLogicalType:
+		var ts time.Time
+		switch t.TimeUnit() {
+		case schema.TimeUnitMillis:
+			ts = time.UnixMilli(v)
+		case schema.TimeUnitMicros:
+			ts = time.UnixMicro(v)
+		default:
+			ts = time.Unix(0, v)
+		}
+		if t.IsAdjustedToUTC() {
+			return tree.MakeDTimestampTZ(ts.UTC(), time.Microsecond)
+		}
+		return tree.MakeDTimestamp(ts.UTC(), time.Microsecond)
+	case *schema.DecimalLogicalType:
+		return &tree.DDecimal{Decimal: *apd.New(v, -t.Scale())}, nil
+	}
+	return tree.NewDInt(tree.DInt(v)), nil
+}
+
+// convertParquetBytes converts a byte array parquet value into a datum of type
+// typ. Decimals are stored as big-endian two's complement unscaled integers,
+// except in the variable length byte arrays written by EXPORT and changefeeds
+// (see parquet.writeDecimal), which store them as text. Other values are used
+// as is by BYTES and UUID columns, and parsed as strings by other columns.
+func convertParquetBytes(
+	ctx context.Context,
+	spec sql.ForeignTableReadSpec,
+	lt schema.LogicalType,
+	fixedLen bool,
+	typ *types.T,
+	v []byte,
+) (tree.Datum, error) {
+	if t, ok := lt.(*schema.DecimalLogicalType); ok {
+		if !fixedLen && isDecimalText(v) {
+			if d, err := tree.ParseDDecimal(string(v)); err == nil {
+				return d, nil
+			}
+		}
+		var b big.Int
+		b.SetBytes(v)
+		if len(v) > 0 && v[0]&0x80 != 0 {
+			// The value is negative.
+			b.Sub(&b, new(big.Int).Lsh(big.NewInt(1), uint(len(v))*8))
+		}
+		var coeff apd.BigInt
+		coeff.SetMathBigInt(&b)
+		return &tree.DDecimal{Decimal: *apd.NewWithBigInt(&coeff, -t.Scale())}, nil
+	}
+	switch typ.Family() {
+	case types.BytesFamily:
+		return tree.NewDBytes(tree.DBytes(v)), nil
+	case types.UuidFamily:
+		if len(v) == uuid.Size {
+			u, err := uuid.FromBytes(v)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDUuid(tree.DUuid{UUID: u}), nil
+		}
+	}
+	return rowenc.ParseDatumStringAs(ctx, typ, string(v), spec.EvalCtx, spec.SemaCtx)
+}
+
+// isDecimalText returns whether v only contains the characters of a decimal
+// formatted as text. A binary decimal only does so if each of its bytes happens
+// to be one of these characters, which is unlikely for the values of a column
+// in practice.
+func isDecimalText(v []byte) bool {
+	switch string(v) {
+	case "":
+		return false
+	case "NaN", "Infinity", "-Infinity":
+		return true
+	}
+	for _, c := range v {
+		switch {
+		case c >= '0' && c <= '9':
+		case c == '-', c == '+', c == '.', c == 'e', c == 'E':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// julianDayOfUnixEpoch is the julian day of 1970-01-01.
+const julianDayOfUnixEpoch = 2440588
+
+// parquetInt96ToTime converts a legacy int96 parquet timestamp, made of the
+// nanoseconds within the day followed by the julian day, into a time.
+func parquetInt96ToTime(v parquet.Int96) time.Time {
+	nanos := int64(binary.LittleEndian.Uint64(v[:8]))
+	days := int64(binary.LittleEndian.Uint32(v[8:])) - julianDayOfUnixEpoch
+	return time.Unix(days*24*60*60, nanos).UTC()
+}
+
+// parquetFileReader implements io.ReaderAt and io.Seeker on top of a file in
+// external storage, as needed by the parquet reader.
+type parquetFileReader struct {
+	ctx    context.Context
+	es     cloud.ExternalStorage
+	size   int64
+	offset int64
+}
+
+var _ io.ReaderAt = (*parquetFileReader)(nil)
+var _ io.Seeker = (*parquetFileReader)(nil)
+
+// ReadAt implements io.ReaderAt.
+func (r *parquetFileReader) ReadAt(p []byte, off int64) (int, error) {
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	want := len(p)
+	if rem := r.size - off; int64(want) > rem {
+		want = int(rem)
+	}
+	reader, _, err := r.es.ReadFile(r.ctx, "", cloud.ReadOptions{
+		Offset:     off,
+		LengthHint: int64(want),
+		NoFileSize: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close(r.ctx)
+	n := 0
+	for n < want {
+		nn, err := reader.Read(r.ctx, p[n:want])
+		n += nn
+		if err != nil {
+			if errors.Is(err, io.EOF) && n == want {
+				break
+			}
+			return n, err
+		}
+	}
+	if want < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Seek implements io.Seeker.
+func (r *parquetFileReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, errors.Newf("invalid whence %d", whence)
+	}
+	if offset < 0 {
+		return 0, errors.Newf("negative offset %d", offset)
+	}
+	r.offset = offset
+	return offset, nil
+}
@@ -899,17 +899,30 @@ func readPostgresStmt(
 		}
 	case *tree.BeginTransaction, *tree.CommitTransaction:
 		// Ignore transaction statements as they have no meaning during an IMPORT.
-	case *tree.Insert, *tree.CopyFrom, *tree.Delete, copyData:
+	case *tree.CopyFrom:
+		// COPY FROM STDIN is handled during the data ingestion pass. COPY from
+		// a file or any other source cannot be processed by IMPORT.
+		if !stmt.Stdin {
+			if ignoreUnsupportedStmts {
+				return unsupportedStmtLogger.log(fmt.Sprintf("%s", stmt), false /* isParseError */)
+			}
+			return wrapErrorWithUnsupportedHint(errors.Errorf("unsupported %T statement: %s", stmt, stmt))
+		}
+	case *tree.Insert, *tree.Delete, copyData:
 		// handled during the data ingestion pass.
 	case *tree.CreateExtension, *tree.CommentOnDatabase, *tree.CommentOnTable,
 		*tree.CommentOnIndex, *tree.CommentOnConstraint, *tree.CommentOnColumn, *tree.SetVar, *tree.Analyze,
-		*tree.CommentOnSchema:
+		*tree.CommentOnSchema, *tree.CreateDomain, *tree.AlterDomain, *tree.DropDomain,
+		*tree.CreateAggregate, *tree.AlterRoutineRename, *tree.AlterRoutineSetSchema,
+		*tree.AlterRoutineSetOwner, *tree.DropRoutine:
 		// These are the statements that can be parsed by CRDB but are not
 		// supported, or are not required to be processed, during an IMPORT.
 		// - ignore txns.
 		// - ignore SETs and DMLs.
 		// - ANALYZE is syntactic sugar for CreateStatistics. It can be ignored
 		// because the auto stats stuff will pick up the changes and run if needed.
+		// - domains and user-defined routines, including aggregates, are not
+		// supported by IMPORT.
 		if ignoreUnsupportedStmts {
 			return unsupportedStmtLogger.log(fmt.Sprintf("%s", stmt), false /* isParseError */)
 		}
@@ -1187,7 +1200,8 @@ func (m *pgDumpReader) readFile(
 			}
 		case *tree.CopyFrom:
 			if !i.Stdin {
-				return errors.New("expected STDIN option on COPY FROM")
+				// Logged or rejected during schema extraction.
+				continue
 			}
 			name, err := getSchemaAndTableName(&i.Table)
 			if err != nil {
@@ -1418,7 +1432,9 @@ func (m *pgDumpReader) readFile(
 			}
 		case *tree.CreateExtension, *tree.CommentOnDatabase, *tree.CommentOnTable,
 			*tree.CommentOnIndex, *tree.CommentOnConstraint, *tree.CommentOnColumn, *tree.AlterSequence,
-			*tree.CommentOnSchema:
+			*tree.CommentOnSchema, *tree.CreateDomain, *tree.AlterDomain, *tree.DropDomain,
+			*tree.CreateAggregate, *tree.AlterRoutineRename, *tree.AlterRoutineSetSchema,
+			*tree.AlterRoutineSetOwner, *tree.DropRoutine:
 			// handled during schema extraction.
 		case *tree.SetVar, *tree.BeginTransaction, *tree.CommitTransaction, *tree.Analyze:
 			// handled during schema extraction.
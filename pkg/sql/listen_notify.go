@@ -0,0 +1,309 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/notify"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// listenAction is a LISTEN or UNLISTEN statement, which takes effect when its
+// transaction commits.
+type listenAction struct {
+	// database is the current database of the session when the statement was
+	// executed, which scopes the channel.
+	database string
+	channel  string
+	// unlisten is set for UNLISTEN statements.
+	unlisten bool
+	// all is set for UNLISTEN *.
+	all bool
+}
+
+// notificationKey identifies the notifications which are collapsed when they
+// are sent more than once in the same transaction.
+type notificationKey struct {
+	database, channel, payload string
+}
+
+// notificationMark identifies a point in the current transaction to which
+// its LISTEN, UNLISTEN and NOTIFY statements can be rolled back.
+type notificationMark struct {
+	numActions, numPending int
+}
+
+// notificationState tracks the channels a session listens on, along with the
+// LISTEN, UNLISTEN and NOTIFY statements of the current transaction, which
+// take effect when it commits.
+type notificationState struct {
+	registry *notify.Registry
+	// pid is the backend PID of the session, which is reported as the sender
+	// of its notifications.
+	pid uint32
+	// onNotify is called when the session's listener receives notifications.
+	onNotify func()
+	// listener is created when the session first listens on a channel.
+	listener *notify.Listener
+
+	// actions contains the LISTEN and UNLISTEN statements of the current
+	// transaction, in order.
+	actions []listenAction
+
+	mu struct {
+		syncutil.Mutex
+		// pending contains the notifications sent in the current transaction.
+		// pg_notify may be evaluated concurrently, so access must be
+		// synchronized.
+		pending []notify.Notification
+		// pendingSet contains the keys of the pending notifications, and is
+		// used to collapse identical notifications.
+		pendingSet map[notificationKey]struct{}
+	}
+}
+
+var _ eval.SessionNotifications = &notificationState{}
+
+// init initializes the notification state of a session.
+func (s *notificationState) init(registry *notify.Registry, pid uint32, onNotify func()) {
+	s.registry = registry
+	s.pid = pid
+	s.onNotify = onNotify
+}
+
+// checkChannelName returns an error if the given name cannot be used for a
+// notification channel.
+func checkChannelName(channel string) error {
+	if channel == "" {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name cannot be empty")
+	}
+	if len(channel) > notify.MaxChannelLength {
+		return pgerror.New(pgcode.InvalidParameterValue, "channel name too long")
+	}
+	return nil
+}
+
+// QueueNotification is part of the eval.SessionNotifications interface.
+func (s *notificationState) QueueNotification(database, channel, payload string) error {
+	if err := checkChannelName(channel); err != nil {
+		return err
+	}
+	if len(payload) > notify.MaxPayloadLength {
+		return pgerror.New(pgcode.InvalidParameterValue, "payload string too long")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Like Postgres, collapse identical notifications sent in the same
+	// transaction.
+	key := notificationKey{database: database, channel: channel, payload: payload}
+	if _, ok := s.mu.pendingSet[key]; ok {
+		return nil
+	}
+	if s.mu.pendingSet == nil {
+		s.mu.pendingSet = make(map[notificationKey]struct{})
+	}
+	s.mu.pendingSet[key] = struct{}{}
+	s.mu.pending = append(s.mu.pending, notify.Notification{
+		Database:  database,
+		Channel:   channel,
+		Payload:   payload,
+		SenderPID: s.pid,
+	})
+	return nil
+}
+
+// ListeningChannels is part of the eval.SessionNotifications interface.
+func (s *notificationState) ListeningChannels(database string) []string {
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Channels(database)
+}
+
+// mark returns the current point in the transaction, to which rollbackTo can
+// later roll back.
+func (s *notificationState) mark() notificationMark {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return notificationMark{numActions: len(s.actions), numPending: len(s.mu.pending)}
+}
+
+// rollbackTo discards the LISTEN, UNLISTEN and NOTIFY statements executed
+// since the given mark was taken.
+func (s *notificationState) rollbackTo(m notificationMark) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if m.numActions < len(s.actions) {
+		s.actions = s.actions[:m.numActions]
+	}
+	if m.numPending < len(s.mu.pending) {
+		for _, n := range s.mu.pending[m.numPending:] {
+			delete(s.mu.pendingSet, notificationKey{
+				database: n.Database, channel: n.Channel, payload: n.Payload,
+			})
+		}
+		s.mu.pending = s.mu.pending[:m.numPending]
+	}
+}
+
+// commit applies the LISTEN and UNLISTEN statements of the transaction which
+// just committed, and sends its notifications.
+func (s *notificationState) commit() {
+	for _, a := range s.actions {
+		switch {
+		case a.all:
+			if s.listener != nil {
+				s.listener.UnlistenAll()
+			}
+		case a.unlisten:
+			if s.listener != nil {
+				s.listener.Unlisten(a.database, a.channel)
+			}
+		default:
+			if s.listener == nil {
+				s.listener = s.registry.NewListener(s.onNotify)
+			}
+			s.listener.Listen(a.database, a.channel)
+		}
+	}
+	s.mu.Lock()
+	pending := s.mu.pending
+	s.mu.pending, s.mu.pendingSet = nil, nil
+	s.mu.Unlock()
+	s.registry.Notify(pending)
+}
+
+// reset clears all state at the end of a transaction.
+func (s *notificationState) reset() {
+	s.actions = nil
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mu.pending, s.mu.pendingSet = nil, nil
+}
+
+// close stops listening on all channels at the end of the session.
+func (s *notificationState) close() {
+	if s.listener != nil {
+		s.listener.Close()
+		s.listener = nil
+	}
+}
+
+// drain buffers the notifications received by the session into the given
+// result. If some notifications were dropped because the session didn't
+// consume them in time, a warning is buffered as well.
+func (s *notificationState) drain(res NotificationBuffer) {
+	if s.listener == nil {
+		return
+	}
+	pending, dropped := s.listener.Drain()
+	if dropped > 0 {
+		res.BufferNotice(pgnotice.NewWithSeverityf("WARNING",
+			"%d notifications were dropped because too many notifications were pending", dropped))
+	}
+	for _, n := range pending {
+		res.BufferNotification(n)
+	}
+}
+
+// sessionNotifications returns the notification state of the session, or an
+// error if the given statement is not supported in the current context.
+func (p *planner) sessionNotifications(stmt tree.Statement) (*notificationState, error) {
+	s := p.extendedEvalCtx.notifications
+	if s == nil || p.SessionData().Internal {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is not supported in this context", stmt.StatementTag())
+	}
+	return s, nil
+}
+
+// Listen implements the LISTEN statement.
+// See https://www.postgresql.org/docs/current/sql-listen.html for details.
+func (p *planner) Listen(ctx context.Context, n *tree.Listen) (planNode, error) {
+	if _, err := p.sessionNotifications(n); err != nil {
+		return nil, err
+	}
+	if err := checkChannelName(string(n.ChannelName)); err != nil {
+		return nil, err
+	}
+	return &listenNode{action: listenAction{
+		database: p.CurrentDatabase(),
+		channel:  string(n.ChannelName),
+	}}, nil
+}
+
+// Unlisten implements the UNLISTEN statement.
+// See https://www.postgresql.org/docs/current/sql-unlisten.html for details.
+func (p *planner) Unlisten(ctx context.Context, n *tree.Unlisten) (planNode, error) {
+	if _, err := p.sessionNotifications(n); err != nil {
+		return nil, err
+	}
+	if n.Star {
+		return &listenNode{action: listenAction{unlisten: true, all: true}}, nil
+	}
+	if err := checkChannelName(string(n.ChannelName)); err != nil {
+		return nil, err
+	}
+	return &listenNode{action: listenAction{
+		database: p.CurrentDatabase(),
+		channel:  string(n.ChannelName),
+		unlisten: true,
+	}}, nil
+}
+
+// Notify implements the NOTIFY statement.
+// See https://www.postgresql.org/docs/current/sql-notify.html for details.
+func (p *planner) Notify(ctx context.Context, n *tree.Notify) (planNode, error) {
+	if _, err := p.sessionNotifications(n); err != nil {
+		return nil, err
+	}
+	return &notifyNode{
+		database: p.CurrentDatabase(),
+		channel:  string(n.ChannelName),
+		payload:  n.Payload,
+	}, nil
+}
+
+type listenNode struct {
+	action listenAction
+}
+
+func (n *listenNode) startExec(params runParams) error {
+	s := params.p.extendedEvalCtx.notifications
+	s.actions = append(s.actions, n.action)
+	return nil
+}
+
+func (n *listenNode) Next(_ runParams) (bool, error) { return false, nil }
+func (n *listenNode) Values() tree.Datums            { return nil }
+func (n *listenNode) Close(_ context.Context)        {}
+
+type notifyNode struct {
+	database string
+	channel  string
+	payload  string
+}
+
+func (n *notifyNode) startExec(params runParams) error {
+	return params.p.extendedEvalCtx.notifications.QueueNotification(
+		n.database, n.channel, n.payload,
+	)
+}
+
+func (n *notifyNode) Next(_ runParams) (bool, error) { return false, nil }
+func (n *notifyNode) Values() tree.Datums            { return nil }
+func (n *notifyNode) Close(_ context.Context)        {}
@@ -0,0 +1,614 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsnutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// LogicalReplicationColumn describes a column of a LogicalReplicationChange.
+type LogicalReplicationColumn struct {
+	Name string
+	Type *types.T
+	// Key is set if the column is part of the primary key of the table.
+	Key bool
+}
+
+// LogicalReplicationChange is a change to a row of a published table.
+type LogicalReplicationChange struct {
+	TableID descpb.ID
+	// TableName and Version identify the version of the table descriptor which
+	// was used to decode the row. A Relation message is sent to the client
+	// whenever the version changes.
+	TableName string
+	Version   descpb.DescriptorVersion
+	Timestamp hlc.Timestamp
+	// Columns are the public columns of the table, and Datums the values of
+	// the row in the same order. If the row was deleted, only the values of
+	// the key columns are set.
+	Columns []LogicalReplicationColumn
+	Datums  tree.Datums
+	Deleted bool
+	// HadPrevious is set if the row existed before the change, which
+	// distinguishes updates from inserts.
+	HadPrevious bool
+}
+
+// LogicalReplicationEvent is either a change or a resolved timestamp, which
+// promises that every change at or below it has been sent already.
+type LogicalReplicationEvent struct {
+	Change   *LogicalReplicationChange
+	Resolved hlc.Timestamp
+}
+
+// LogicalReplicationChanges sends the changes to the given tables after
+// startTS on events, until the context is canceled or an error occurs. It is
+// set by changefeedccl, which decodes the changes from rangefeeds.
+var LogicalReplicationChanges func(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	tables []catalog.TableDescriptor,
+	startTS hlc.Timestamp,
+	events chan<- LogicalReplicationEvent,
+) error
+
+// logicalReplicationKeepaliveInterval is the interval at which keepalive
+// messages are sent to the client when there are no changes to send.
+var logicalReplicationKeepaliveInterval = 10 * time.Second
+
+// publishedTable is a table of the publications of a stream.
+type publishedTable struct {
+	schemaName string
+	// The operations published for the table, which are the union of those of
+	// the publications that include it.
+	insert, update, delete bool
+}
+
+// logicalReplicationStream is the state of the stream of changes of a
+// replication slot to a client.
+type logicalReplicationStream struct {
+	res  StartReplicationResult
+	slot replicationSlot
+	// startLSN is the position requested by the client. Transactions which
+	// end at or before it are not sent.
+	startLSN lsn.LSN
+	tables   map[descpb.ID]publishedTable
+
+	// pending are the changes which have not been sent yet, since their
+	// timestamp is not resolved.
+	pending []*LogicalReplicationChange
+	// lastLSN is the position of the last message sent to the client.
+	lastLSN lsn.LSN
+	// resolved is the timestamp up to which every change has been sent.
+	resolved hlc.Timestamp
+	// positions are the positions sent to the client which the client has not
+	// confirmed yet, with the timestamps up to which they include the changes.
+	// The client confirms positions rather than timestamps, which need to be
+	// persisted in the slot so that the stream can restart from them.
+	positions []streamPosition
+	// sentRelations has the version of the descriptor of each table whose
+	// Relation message has been sent.
+	sentRelations map[descpb.ID]descpb.DescriptorVersion
+	xid           uint32
+}
+
+type streamPosition struct {
+	lsn lsn.LSN
+	ts  hlc.Timestamp
+}
+
+// replicationClientMsg is a message received from the client while changes are
+// streamed.
+type replicationClientMsg struct {
+	typ  pgwirebase.ClientMessageType
+	data []byte
+	err  error
+}
+
+// runStartReplication streams the changes of the replication slot to the
+// client, in the pgoutput format, until the client ends the stream with a
+// CopyDone message.
+func runStartReplication(
+	ctx context.Context, p *planner, cmd StartReplication, res StartReplicationResult,
+) error {
+	n := cmd.Stmt
+	if err := p.checkReplicationSlotsEnabled(ctx); err != nil {
+		return err
+	}
+	if n.Kind != pgrepltree.LogicalReplication {
+		return pgerror.New(pgcode.FeatureNotSupported, "physical replication is not supported")
+	}
+	if p.SessionData().ReplicationMode != sessiondatapb.ReplicationMode_REPLICATION_MODE_DATABASE {
+		return pgerror.New(pgcode.ObjectNotInPrerequisiteState,
+			"logical decoding requires a database connection")
+	}
+	pubNames, err := parseLogicalReplicationOptions(n.Options)
+	if err != nil {
+		return err
+	}
+
+	s := &logicalReplicationStream{
+		res:           res,
+		startLSN:      n.LSN,
+		sentRelations: make(map[descpb.ID]descpb.DescriptorVersion),
+	}
+	var tables []catalog.TableDescriptor
+	// The slot and the publications are read in their own transaction, so
+	// that no descriptor leases are held while changes are streamed.
+	if err := p.ExecCfg().InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		tables, s.tables = nil, make(map[descpb.ID]publishedTable)
+		slot, found, err := getReplicationSlot(ctx, txn, string(n.Slot))
+		if err != nil {
+			return err
+		}
+		if !found {
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"replication slot %q does not exist", string(n.Slot))
+		}
+		s.slot = slot
+		db, err := txn.Descriptors().ByID(txn.KV()).Get().Database(ctx, slot.databaseID)
+		if err != nil {
+			return err
+		}
+		if db.GetName() != p.CurrentDatabase() {
+			return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"replication slot %q was not created in this database", slot.name)
+		}
+		for _, name := range pubNames {
+			pub := db.GetPublication(name)
+			if pub == nil {
+				return pgerror.Newf(pgcode.UndefinedObject, "publication %q does not exist", name)
+			}
+			pubTables, err := publicationTables(ctx, txn.Descriptors(), txn.KV(), db, pub)
+			if err != nil {
+				return err
+			}
+			for _, tbl := range pubTables {
+				t, ok := s.tables[tbl.GetID()]
+				if !ok {
+					if tbl.NumFamilies() > 1 {
+						return pgerror.Newf(pgcode.FeatureNotSupported,
+							"cannot publish changes of table %q with multiple column families", tbl.GetName())
+					}
+					sc, err := txn.Descriptors().ByID(txn.KV()).Get().Schema(ctx, tbl.GetParentSchemaID())
+					if err != nil {
+						return err
+					}
+					t.schemaName = sc.GetName()
+					tables = append(tables, tbl)
+				}
+				t.insert = t.insert || pub.PublishInsert
+				t.update = t.update || pub.PublishUpdate
+				t.delete = t.delete || pub.PublishDelete
+				s.tables[tbl.GetID()] = t
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if LogicalReplicationChanges == nil {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"logical replication is not supported in this binary")
+	}
+	s.lastLSN = s.slot.confirmedFlushLSN
+	s.resolved = s.slot.confirmedFlushTS
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The client sends status updates while changes are streamed, so its
+	// messages are read concurrently. Since the connection cannot be handed
+	// back to the network routine while it is being read, reading stops when
+	// the client ends the stream, or once one more message is read after the
+	// stream fails.
+	clientMsgs := make(chan replicationClientMsg)
+	stopReading := make(chan struct{})
+	readerDone := make(chan struct{})
+	go func() {
+		defer close(readerDone)
+		var readBuf pgwirebase.ReadBuffer
+		for {
+			typ, _, err := readBuf.ReadTypedMsg(cmd.Conn.Rd())
+			msg := replicationClientMsg{typ: typ, err: err}
+			if err == nil {
+				msg.data = append([]byte(nil), readBuf.Msg...)
+			}
+			select {
+			case clientMsgs <- msg:
+			case <-stopReading:
+				return
+			}
+			if err != nil || typ == pgwirebase.ClientMsgCopyDone || typ == pgwirebase.ClientMsgCopyFail {
+				return
+			}
+		}
+	}()
+
+	events := make(chan LogicalReplicationEvent)
+	changesErr := make(chan error, 1)
+	var wg sync.WaitGroup
+	if len(tables) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			changesErr <- LogicalReplicationChanges(ctx, p.ExecCfg(), tables, s.resolved, events)
+		}()
+	}
+
+	err = s.run(ctx, p.ExecCfg().InternalDB, clientMsgs, events, changesErr)
+	cancel()
+	wg.Wait()
+	select {
+	case <-readerDone:
+	default:
+		// Ask the client for a status update, so that the reader can stop
+		// without waiting for the next periodic update of the client.
+		_ = res.SendReplicationMessage(ctx, pgoutput.PrimaryKeepalive{
+			WALEnd:         s.lastLSN,
+			SendTime:       timeutil.Now(),
+			ReplyRequested: true,
+		})
+		close(stopReading)
+		<-readerDone
+	}
+	return err
+}
+
+// run sends the changes to the client until the client ends the stream.
+func (s *logicalReplicationStream) run(
+	ctx context.Context,
+	db *InternalDB,
+	clientMsgs <-chan replicationClientMsg,
+	events <-chan LogicalReplicationEvent,
+	changesErr <-chan error,
+) error {
+	if err := s.res.SendCopyBothResponse(ctx); err != nil {
+		return err
+	}
+	if err := s.sendKeepalive(ctx, false /* replyRequested */); err != nil {
+		return err
+	}
+	ticker := time.NewTicker(logicalReplicationKeepaliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-changesErr:
+			if err == nil {
+				err = errors.AssertionFailedf("stream of changes ended unexpectedly")
+			}
+			return err
+		case ev := <-events:
+			if ev.Change != nil {
+				s.pending = append(s.pending, ev.Change)
+				continue
+			}
+			if err := s.resolve(ctx, ev.Resolved); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			if err := s.sendKeepalive(ctx, false /* replyRequested */); err != nil {
+				return err
+			}
+		case msg := <-clientMsgs:
+			if msg.err != nil {
+				return msg.err
+			}
+			switch msg.typ {
+			case pgwirebase.ClientMsgCopyData:
+				if err := s.handleClientData(ctx, db, msg.data); err != nil {
+					return err
+				}
+			case pgwirebase.ClientMsgCopyDone:
+				return s.res.SendCopyDone(ctx)
+			case pgwirebase.ClientMsgCopyFail:
+				return pgerror.Newf(pgcode.QueryCanceled, "replication stream failed: %s",
+					strings.TrimSuffix(string(msg.data), "\x00"))
+			default:
+				return pgwirebase.NewUnrecognizedMsgTypeErr(msg.typ)
+			}
+		}
+	}
+}
+
+// handleClientData handles a CopyData message from the client.
+func (s *logicalReplicationStream) handleClientData(
+	ctx context.Context, db *InternalDB, data []byte,
+) error {
+	if len(data) == 0 {
+		return pgwirebase.NewProtocolViolationErrorf("unexpected empty message")
+	}
+	switch data[0] {
+	case pgoutput.StandbyStatusUpdateType:
+		update, err := pgoutput.ParseStandbyStatusUpdate(data)
+		if err != nil {
+			return err
+		}
+		if err := s.confirm(ctx, db, update.WALFlushed); err != nil {
+			return err
+		}
+		if update.ReplyRequested {
+			return s.sendKeepalive(ctx, false /* replyRequested */)
+		}
+		return nil
+	case pgoutput.HotStandbyFeedbackType:
+		// Hot standby feedback is only meaningful for physical replication.
+		return nil
+	default:
+		return pgwirebase.NewProtocolViolationErrorf(
+			"unexpected message type %q in replication stream", data[0])
+	}
+}
+
+// confirm records that the client has durably received the stream up to the
+// given position, so that the stream of the slot restarts from it.
+func (s *logicalReplicationStream) confirm(
+	ctx context.Context, db *InternalDB, flushed lsn.LSN,
+) error {
+	i := sort.Search(len(s.positions), func(i int) bool {
+		return s.positions[i].lsn > flushed
+	})
+	if i == 0 {
+		return nil
+	}
+	pos := s.positions[i-1]
+	s.positions = s.positions[i:]
+	if pos.lsn <= s.slot.confirmedFlushLSN {
+		return nil
+	}
+	if err := confirmReplicationSlot(ctx, db, s.slot.name, pos.lsn, pos.ts); err != nil {
+		return err
+	}
+	s.slot.confirmedFlushLSN, s.slot.confirmedFlushTS = pos.lsn, pos.ts
+	return nil
+}
+
+// sendKeepalive sends a keepalive message with the current position of the
+// stream.
+func (s *logicalReplicationStream) sendKeepalive(ctx context.Context, replyRequested bool) error {
+	if resolvedLSN := lsnutil.HLCToLSN(s.resolved); resolvedLSN > s.lastLSN {
+		s.lastLSN = resolvedLSN
+	}
+	s.addPosition(s.lastLSN, s.resolved)
+	return s.res.SendReplicationMessage(ctx, pgoutput.PrimaryKeepalive{
+		WALEnd:         s.lastLSN,
+		SendTime:       timeutil.Now(),
+		ReplyRequested: replyRequested,
+	})
+}
+
+func (s *logicalReplicationStream) addPosition(l lsn.LSN, ts hlc.Timestamp) {
+	if n := len(s.positions); n > 0 && s.positions[n-1].lsn == l {
+		s.positions[n-1].ts = ts
+		return
+	}
+	s.positions = append(s.positions, streamPosition{lsn: l, ts: ts})
+}
+
+// resolve sends the pending changes at or below the resolved timestamp. The
+// changes which share a timestamp are sent as one transaction, since they were
+// committed by the same transaction or by transactions which may have been
+// committed in any order.
+func (s *logicalReplicationStream) resolve(ctx context.Context, resolved hlc.Timestamp) error {
+	if resolved.LessEq(s.resolved) {
+		return nil
+	}
+	sort.SliceStable(s.pending, func(i, j int) bool {
+		return s.pending[i].Timestamp.Less(s.pending[j].Timestamp)
+	})
+	i := sort.Search(len(s.pending), func(i int) bool {
+		return resolved.Less(s.pending[i].Timestamp)
+	})
+	ready := s.pending[:i]
+	for len(ready) > 0 {
+		j := 1
+		for j < len(ready) && ready[j].Timestamp == ready[0].Timestamp {
+			j++
+		}
+		if err := s.sendTxn(ctx, ready[:j]); err != nil {
+			return err
+		}
+		ready = ready[j:]
+	}
+	s.pending = append(s.pending[:0], s.pending[i:]...)
+	s.resolved = resolved
+	return nil
+}
+
+// sendTxn sends the changes of a transaction. Its commit LSN is derived from
+// its timestamp, and made larger than that of the previous transaction.
+func (s *logicalReplicationStream) sendTxn(
+	ctx context.Context, changes []*LogicalReplicationChange,
+) error {
+	ts := changes[0].Timestamp
+	commitLSN := lsnutil.HLCToLSN(ts)
+	if commitLSN <= s.lastLSN {
+		commitLSN = s.lastLSN + 1
+	}
+	endLSN := commitLSN + 1
+	s.lastLSN = endLSN
+	s.addPosition(endLSN, ts)
+	if endLSN <= s.startLSN {
+		// The client has already received this transaction.
+		return nil
+	}
+
+	var msgs []pgoutput.Message
+	for _, c := range changes {
+		t := s.tables[c.TableID]
+		var msg pgoutput.Message
+		tuple := pgoutput.Tuple{Datums: c.Datums, Types: make([]*types.T, len(c.Columns))}
+		for i := range c.Columns {
+			tuple.Types[i] = c.Columns[i].Type
+		}
+		switch {
+		case c.Deleted:
+			if !t.delete || !c.HadPrevious {
+				continue
+			}
+			msg = pgoutput.Delete{RelationID: oid.Oid(c.TableID), Key: tuple}
+		case c.HadPrevious:
+			if !t.update {
+				continue
+			}
+			msg = pgoutput.Update{RelationID: oid.Oid(c.TableID), New: tuple}
+		default:
+			if !t.insert {
+				continue
+			}
+			msg = pgoutput.Insert{RelationID: oid.Oid(c.TableID), New: tuple}
+		}
+		if v, ok := s.sentRelations[c.TableID]; !ok || v != c.Version {
+			msgs = append(msgs, makeRelationMessage(c, t.schemaName))
+			s.sentRelations[c.TableID] = c.Version
+		}
+		msgs = append(msgs, msg)
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	s.xid++
+	commitTime := timeutil.Unix(0, ts.WallTime)
+	if err := s.send(ctx, commitLSN, pgoutput.Begin{
+		FinalLSN:   commitLSN,
+		CommitTime: commitTime,
+		XID:        s.xid,
+	}); err != nil {
+		return err
+	}
+	for _, msg := range msgs {
+		if err := s.send(ctx, commitLSN, msg); err != nil {
+			return err
+		}
+	}
+	if log.V(2) {
+		log.Infof(ctx, "sent transaction at %s with %d messages", ts, len(msgs))
+	}
+	return s.send(ctx, commitLSN, pgoutput.Commit{
+		CommitLSN:  commitLSN,
+		EndLSN:     endLSN,
+		CommitTime: commitTime,
+	})
+}
+
+func (s *logicalReplicationStream) send(
+	ctx context.Context, walStart lsn.LSN, msg pgoutput.Message,
+) error {
+	return s.res.SendReplicationMessage(ctx, pgoutput.XLogData{
+		WALStart: walStart,
+		WALEnd:   s.lastLSN,
+		SendTime: timeutil.Now(),
+		Message:  msg,
+	})
+}
+
+func makeRelationMessage(c *LogicalReplicationChange, schemaName string) pgoutput.Relation {
+	rel := pgoutput.Relation{
+		RelationID:      oid.Oid(c.TableID),
+		Namespace:       schemaName,
+		Name:            c.TableName,
+		ReplicaIdentity: pgoutput.ReplicaIdentityDefault,
+		Columns:         make([]pgoutput.RelationColumn, len(c.Columns)),
+	}
+	for i, col := range c.Columns {
+		rel.Columns[i] = pgoutput.RelationColumn{
+			Key:          col.Key,
+			Name:         col.Name,
+			TypeOID:      col.Type.Oid(),
+			TypeModifier: col.Type.TypeModifier(),
+		}
+	}
+	return rel
+}
+
+// parseLogicalReplicationOptions validates the options of START_REPLICATION,
+// which are the options of the pgoutput plugin, and returns the names of the
+// publications to stream.
+func parseLogicalReplicationOptions(opts pgrepltree.Options) ([]string, error) {
+	var pubNames []string
+	var hasProtoVersion bool
+	for _, o := range opts {
+		val := replicationOptionValue(o)
+		switch key := string(o.Key); key {
+		case "proto_version":
+			v, err := strconv.Atoi(val)
+			if err != nil {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"invalid proto_version %q", val)
+			}
+			if v != pgoutput.ProtoVersion {
+				return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+					"client sent proto_version=%d but server only supports protocol %d",
+					v, pgoutput.ProtoVersion)
+			}
+			hasProtoVersion = true
+		case "publication_names":
+			for _, name := range strings.Split(val, ",") {
+				name = strings.TrimSpace(name)
+				if len(name) > 1 && name[0] == '"' && name[len(name)-1] == '"' {
+					name = strings.ReplaceAll(name[1:len(name)-1], `""`, `"`)
+				} else {
+					name = strings.ToLower(name)
+				}
+				if name == "" {
+					return nil, pgerror.New(pgcode.InvalidName, "invalid publication_names syntax")
+				}
+				pubNames = append(pubNames, name)
+			}
+		case "binary", "messages", "streaming", "two_phase":
+			switch strings.ToLower(val) {
+			case "false", "off", "0":
+			default:
+				return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+					"pgoutput option %s is not supported", key)
+			}
+		case "origin":
+			// Every change originates from this cluster.
+		default:
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"unrecognized pgoutput option: %s", key)
+		}
+	}
+	if !hasProtoVersion {
+		return nil, pgerror.New(pgcode.InvalidParameterValue, "proto_version option missing")
+	}
+	if len(pubNames) == 0 {
+		return nil, pgerror.New(pgcode.InvalidParameterValue, "publication_names parameter missing")
+	}
+	return pubNames, nil
+}
@@ -0,0 +1,343 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package notify implements the node-level delivery of the notifications sent
+// with NOTIFY and pg_notify to the sessions which LISTEN on their channels.
+package notify
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// Notification is a message sent on a channel with NOTIFY or pg_notify.
+type Notification struct {
+	// Database is the name of the current database of the session which sent
+	// the notification. Like in Postgres, channels are scoped to a database,
+	// so the notification is only delivered to the sessions which listen on
+	// its channel in the same database.
+	Database string
+	// Channel is the name of the channel the notification was sent on.
+	Channel string
+	// Payload is the (possibly empty) payload of the notification.
+	Payload string
+	// SenderPID is the backend PID of the session which sent the notification,
+	// as returned by pg_backend_pid().
+	SenderPID uint32
+}
+
+// MaxPayloadLength is the maximum length of the payload of a notification,
+// which is the same as in Postgres.
+const MaxPayloadLength = 8000 - 1
+
+// MaxChannelLength is the maximum length of the name of a channel. Postgres
+// limits channel names to NAMEDATALEN-1 bytes.
+const MaxChannelLength = 63
+
+// maxPendingNotifications is the maximum number of notifications that are
+// buffered for a listener that hasn't consumed them yet, for example because
+// its session is in the middle of a long-running transaction. Older
+// notifications are dropped once this limit is reached, and the number of
+// dropped notifications is reported to the session by Drain.
+const maxPendingNotifications = 1 << 16
+
+// maxOutboxNotifications is the maximum number of notifications which are
+// buffered in the outbox of a registry while they are being broadcast, for
+// example because some nodes are slow to respond. Older notifications are
+// dropped once this limit is reached, and the number of dropped notifications
+// is logged by the broadcast task.
+const maxOutboxNotifications = 1 << 16
+
+// channelKey identifies a channel, which is scoped to a database.
+type channelKey struct {
+	database, channel string
+}
+
+// BroadcastEndpoint is the RPC endpoint used to deliver notifications to the
+// listeners on all the nodes of the cluster.
+type BroadcastEndpoint func(
+	context.Context, *serverpb.NotifyListenersRequest,
+) (*serverpb.NotifyListenersResponse, error)
+
+// Registry tracks the sessions on this node which listen on channels, and
+// delivers notifications to them.
+//
+// Notifications sent by committed transactions are queued in an outbox, which
+// is broadcast to the registries on all the nodes of the cluster (including
+// this one) by a background task. The outbox is sent in order, so that the
+// notifications sent from this node are received by each listener in the
+// order in which their transactions committed.
+type Registry struct {
+	endpoint BroadcastEndpoint
+
+	// outboxReady is signaled when notifications are added to the outbox.
+	outboxReady chan struct{}
+
+	mu struct {
+		syncutil.Mutex
+		// listeners maps each channel to the listeners on it.
+		listeners map[channelKey]map[*Listener]struct{}
+		// outbox contains the notifications which have yet to be broadcast.
+		outbox []Notification
+		// droppedFromOutbox is the number of notifications which were dropped
+		// from the outbox since it was last flushed, because it was full.
+		droppedFromOutbox int
+		// started is set once the broadcast task has been started. Until then,
+		// notifications are only delivered to the listeners on this node.
+		started bool
+	}
+}
+
+// NewRegistry creates a new Registry which uses the given endpoint to
+// broadcast notifications to all the nodes of the cluster.
+func NewRegistry(endpoint BroadcastEndpoint) *Registry {
+	r := &Registry{
+		endpoint:    endpoint,
+		outboxReady: make(chan struct{}, 1),
+	}
+	r.mu.listeners = make(map[channelKey]map[*Listener]struct{})
+	return r
+}
+
+// Start starts the background task which broadcasts notifications.
+func (r *Registry) Start(ctx context.Context, stopper *stop.Stopper) {
+	if r.endpoint == nil {
+		return
+	}
+	if err := stopper.RunAsyncTask(ctx, "notification-broadcast", func(ctx context.Context) {
+		ctx, cancel := stopper.WithCancelOnQuiesce(ctx)
+		defer cancel()
+		for {
+			select {
+			case <-r.outboxReady:
+				r.flushOutbox(ctx)
+			case <-stopper.ShouldQuiesce():
+				return
+			}
+		}
+	}); err != nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mu.started = true
+}
+
+// flushOutbox broadcasts all the notifications in the outbox.
+func (r *Registry) flushOutbox(ctx context.Context) {
+	r.mu.Lock()
+	outbox := r.mu.outbox
+	dropped := r.mu.droppedFromOutbox
+	r.mu.outbox = nil
+	r.mu.droppedFromOutbox = 0
+	r.mu.Unlock()
+	if dropped > 0 {
+		log.Warningf(ctx, "dropped %d notifications which could not be broadcast in time", dropped)
+	}
+	if len(outbox) == 0 {
+		return
+	}
+	req := &serverpb.NotifyListenersRequest{
+		Notifications: make([]serverpb.NotifyListenersRequest_Notification, len(outbox)),
+	}
+	for i, n := range outbox {
+		req.Notifications[i] = serverpb.NotifyListenersRequest_Notification{
+			Database:  n.Database,
+			Channel:   n.Channel,
+			Payload:   n.Payload,
+			SenderPID: n.SenderPID,
+		}
+	}
+	if _, err := r.endpoint(ctx, req); err != nil {
+		// Notifications are delivered on a best-effort basis to nodes that are
+		// unavailable.
+		log.Warningf(ctx, "failed to deliver %d notifications to all nodes: %v", len(outbox), err)
+	}
+}
+
+// Notify sends notifications to the listeners on their channels across the
+// cluster. It is called once the transaction which sent the notifications has
+// committed.
+func (r *Registry) Notify(notifications []Notification) {
+	if len(notifications) == 0 {
+		return
+	}
+	r.mu.Lock()
+	if !r.mu.started {
+		r.mu.Unlock()
+		r.Deliver(notifications)
+		return
+	}
+	r.mu.outbox = append(r.mu.outbox, notifications...)
+	if excess := len(r.mu.outbox) - maxOutboxNotifications; excess > 0 {
+		r.mu.outbox = append(r.mu.outbox[:0], r.mu.outbox[excess:]...)
+		r.mu.droppedFromOutbox += excess
+	}
+	r.mu.Unlock()
+	select {
+	case r.outboxReady <- struct{}{}:
+	default:
+	}
+}
+
+// Deliver delivers notifications to the listeners on this node.
+func (r *Registry) Deliver(notifications []Notification) {
+	var toSignal []*Listener
+	func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		for i := range notifications {
+			key := channelKey{database: notifications[i].Database, channel: notifications[i].Channel}
+			for l := range r.mu.listeners[key] {
+				if l.enqueue(notifications[i]) {
+					toSignal = append(toSignal, l)
+				}
+			}
+		}
+	}()
+	for _, l := range toSignal {
+		l.onNotify()
+	}
+}
+
+// NewListener creates a new Listener, which is not yet listening on any
+// channel. onNotify is called whenever the listener receives a notification
+// while it didn't have any pending notifications. The listener must be closed
+// with Close.
+func (r *Registry) NewListener(onNotify func()) *Listener {
+	l := &Listener{
+		registry: r,
+		onNotify: onNotify,
+		channels: make(map[channelKey]struct{}),
+	}
+	return l
+}
+
+// Listener receives the notifications sent on the channels a session listens
+// on.
+type Listener struct {
+	registry *Registry
+	onNotify func()
+
+	// channels is the set of channels the listener listens on. It is protected
+	// by registry.mu.
+	channels map[channelKey]struct{}
+
+	mu struct {
+		syncutil.Mutex
+		// pending contains the notifications received by the listener which
+		// haven't been consumed by its session yet.
+		pending []Notification
+		// dropped is the number of notifications which were dropped since the
+		// last call to Drain, because too many notifications were pending.
+		dropped int
+	}
+}
+
+// Listen starts listening on the given channel of the given database.
+func (l *Listener) Listen(database, channel string) {
+	r := l.registry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := channelKey{database: database, channel: channel}
+	if _, ok := l.channels[key]; ok {
+		return
+	}
+	l.channels[key] = struct{}{}
+	listeners, ok := r.mu.listeners[key]
+	if !ok {
+		listeners = make(map[*Listener]struct{})
+		r.mu.listeners[key] = listeners
+	}
+	listeners[l] = struct{}{}
+}
+
+// Unlisten stops listening on the given channel of the given database.
+func (l *Listener) Unlisten(database, channel string) {
+	r := l.registry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	l.unlistenLocked(channelKey{database: database, channel: channel})
+}
+
+// UnlistenAll stops listening on all channels, in all databases.
+func (l *Listener) UnlistenAll() {
+	r := l.registry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key := range l.channels {
+		l.unlistenLocked(key)
+	}
+}
+
+func (l *Listener) unlistenLocked(key channelKey) {
+	r := l.registry
+	if _, ok := l.channels[key]; !ok {
+		return
+	}
+	delete(l.channels, key)
+	listeners := r.mu.listeners[key]
+	delete(listeners, l)
+	if len(listeners) == 0 {
+		delete(r.mu.listeners, key)
+	}
+}
+
+// Channels returns the sorted names of the channels the listener listens on
+// in the given database.
+func (l *Listener) Channels(database string) []string {
+	r := l.registry
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var channels []string
+	for key := range l.channels {
+		if key.database == database {
+			channels = append(channels, key.channel)
+		}
+	}
+	sort.Strings(channels)
+	return channels
+}
+
+// Drain returns the notifications received by the listener since the last
+// call to Drain, along with the number of notifications which were dropped in
+// the meantime because the listener had too many pending notifications.
+func (l *Listener) Drain() (pending []Notification, dropped int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	pending, dropped = l.mu.pending, l.mu.dropped
+	l.mu.pending, l.mu.dropped = nil, 0
+	return pending, dropped
+}
+
+// Close stops listening on all channels and discards pending notifications.
+func (l *Listener) Close() {
+	l.UnlistenAll()
+	l.Drain()
+}
+
+// enqueue adds a notification to the listener's pending notifications. It
+// returns true if the listener didn't have any pending notifications before.
+func (l *Listener) enqueue(n Notification) (wasEmpty bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	wasEmpty = len(l.mu.pending) == 0
+	if len(l.mu.pending) >= maxPendingNotifications {
+		l.mu.pending = l.mu.pending[1:]
+		l.mu.dropped++
+	}
+	l.mu.pending = append(l.mu.pending, n)
+	return wasEmpty
+}
@@ -0,0 +1,174 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package notify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/stretchr/testify/require"
+)
+
+// drain drains the listener, which must not have dropped any notifications.
+func drain(t *testing.T, l *Listener) []Notification {
+	pending, dropped := l.Drain()
+	require.Zero(t, dropped)
+	return pending
+}
+
+func TestListener(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	r := NewRegistry(nil /* endpoint */)
+	var signals int
+	l1 := r.NewListener(func() { signals++ })
+	l2 := r.NewListener(func() {})
+	defer l1.Close()
+	defer l2.Close()
+
+	l1.Listen("db", "a")
+	l1.Listen("db", "b")
+	l1.Listen("db", "a")
+	l2.Listen("db", "b")
+	require.Equal(t, []string{"a", "b"}, l1.Channels("db"))
+	require.Equal(t, []string{"b"}, l2.Channels("db"))
+	require.Empty(t, l1.Channels("other"))
+
+	a1 := Notification{Database: "db", Channel: "a", Payload: "1", SenderPID: 1}
+	b2 := Notification{Database: "db", Channel: "b", Payload: "2", SenderPID: 2}
+	c3 := Notification{Database: "db", Channel: "c", Payload: "3", SenderPID: 3}
+	r.Notify([]Notification{a1, b2})
+	r.Notify([]Notification{c3})
+	// The listener is only signaled when it goes from having no pending
+	// notifications to having some.
+	require.Equal(t, 1, signals)
+	require.Equal(t, []Notification{a1, b2}, drain(t, l1))
+	require.Equal(t, []Notification{b2}, drain(t, l2))
+	require.Empty(t, drain(t, l1))
+
+	l1.Unlisten("db", "b")
+	r.Notify([]Notification{a1, b2})
+	require.Equal(t, 2, signals)
+	require.Equal(t, []Notification{a1}, drain(t, l1))
+	require.Equal(t, []Notification{b2}, drain(t, l2))
+
+	l1.UnlistenAll()
+	r.Notify([]Notification{a1})
+	require.Empty(t, l1.Channels("db"))
+	require.Empty(t, drain(t, l1))
+	require.Equal(t, 2, signals)
+}
+
+func TestListenerDatabases(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	r := NewRegistry(nil /* endpoint */)
+	l1 := r.NewListener(func() {})
+	l2 := r.NewListener(func() {})
+	defer l1.Close()
+	defer l2.Close()
+
+	// Channels with the same name in different databases are distinct.
+	l1.Listen("db1", "a")
+	l2.Listen("db2", "a")
+	require.Equal(t, []string{"a"}, l1.Channels("db1"))
+	require.Empty(t, l1.Channels("db2"))
+
+	a1 := Notification{Database: "db1", Channel: "a", Payload: "1", SenderPID: 1}
+	a2 := Notification{Database: "db2", Channel: "a", Payload: "2", SenderPID: 2}
+	r.Notify([]Notification{a1, a2})
+	require.Equal(t, []Notification{a1}, drain(t, l1))
+	require.Equal(t, []Notification{a2}, drain(t, l2))
+
+	// Unlistening in one database leaves the channel of the same name in
+	// another database alone.
+	l1.Listen("db2", "a")
+	l1.Unlisten("db1", "a")
+	r.Notify([]Notification{a1, a2})
+	require.Equal(t, []Notification{a2}, drain(t, l1))
+	require.Equal(t, []Notification{a2}, drain(t, l2))
+}
+
+func TestListenerDropsNotifications(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	r := NewRegistry(nil /* endpoint */)
+	l := r.NewListener(func() {})
+	defer l.Close()
+	l.Listen("db", "a")
+
+	// Once the listener has too many pending notifications, the oldest ones
+	// are dropped, and reported by Drain.
+	notifications := make([]Notification, maxPendingNotifications+2)
+	for i := range notifications {
+		notifications[i] = Notification{Database: "db", Channel: "a", SenderPID: uint32(i)}
+	}
+	r.Notify(notifications)
+	pending, dropped := l.Drain()
+	require.Equal(t, notifications[2:], pending)
+	require.Equal(t, 2, dropped)
+
+	// The count is reset by Drain.
+	r.Notify(notifications[:1])
+	require.Equal(t, notifications[:1], drain(t, l))
+}
+
+func TestRegistryBroadcast(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ctx := context.Background()
+	stopper := stop.NewStopper()
+	defer stopper.Stop(ctx)
+
+	// Simulate a cluster of two nodes, whose registries forward the
+	// notifications to each other.
+	var registries []*Registry
+	var mu syncutil.Mutex
+	var broadcasts int
+	endpoint := func(
+		_ context.Context, req *serverpb.NotifyListenersRequest,
+	) (*serverpb.NotifyListenersResponse, error) {
+		mu.Lock()
+		broadcasts++
+		mu.Unlock()
+		notifications := make([]Notification, len(req.Notifications))
+		for i, n := range req.Notifications {
+			notifications[i] = Notification{
+				Database: n.Database, Channel: n.Channel, Payload: n.Payload, SenderPID: n.SenderPID,
+			}
+		}
+		for _, r := range registries {
+			r.Deliver(notifications)
+		}
+		return &serverpb.NotifyListenersResponse{}, nil
+	}
+	registries = append(registries, NewRegistry(endpoint), NewRegistry(endpoint))
+	for _, r := range registries {
+		r.Start(ctx, stopper)
+	}
+
+	signaled := make(chan struct{}, 2)
+	l := registries[1].NewListener(func() { signaled <- struct{}{} })
+	defer l.Close()
+	l.Listen("db", "a")
+
+	n := Notification{Database: "db", Channel: "a", Payload: "hello", SenderPID: 42}
+	registries[0].Notify([]Notification{n})
+	<-signaled
+	require.Equal(t, []Notification{n}, drain(t, l))
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, 1, broadcasts)
+}
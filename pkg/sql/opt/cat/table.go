@@ -15,6 +15,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
@@ -43,6 +44,11 @@ type Table interface {
 	// information_schema tables.
 	IsVirtualTable() bool
 
+	// IsForeignTable returns true if this table is a foreign table, whose rows
+	// are read from files in external storage when it's queried. Foreign tables
+	// are also virtual tables.
+	IsForeignTable() bool
+
 	// IsSystemTable returns true if this table is a special system table.
 	IsSystemTable() bool
 
@@ -180,6 +186,13 @@ type Table interface {
 	// IsHypothetical returns true if this is a hypothetical table (used when
 	// searching for index recommendations).
 	IsHypothetical() bool
+
+	// TriggerCount returns the number of triggers defined on this table.
+	TriggerCount() int
+
+	// Trigger returns the ith trigger defined on this table, where
+	// i < TriggerCount.
+	Trigger(i int) Trigger
 }
 
 // CheckConstraint represents a check constraint on a table. Check constraints
@@ -328,6 +341,15 @@ type ForeignKeyConstraint interface {
 	// UpdateReferenceAction returns the action to be performed if the foreign key
 	// constraint would be violated by an update.
 	UpdateReferenceAction() tree.ReferenceAction
+
+	// Deferrable is true if checking of the constraint can be deferred until
+	// the end of the transaction with SET CONSTRAINTS.
+	Deferrable() bool
+
+	// InitiallyDeferred is true if checking of the constraint is deferred
+	// until the end of the transaction by default. It is only true if the
+	// constraint is Deferrable.
+	InitiallyDeferred() bool
 }
 
 // UniqueConstraint represents a uniqueness constraint. UniqueConstraints may
@@ -375,6 +397,28 @@ type UniqueConstraint interface {
 	// satisfied when building functional dependencies for the table. This enables
 	// additional optimizations, such as omission of uniqueness checks.
 	UniquenessGuaranteedByAnotherIndex() bool
+
+	// Deferrable is true if checking of the constraint can be deferred until
+	// the end of the transaction with SET CONSTRAINTS. Only constraints that
+	// are not enforced by an index can be deferrable.
+	Deferrable() bool
+
+	// InitiallyDeferred is true if checking of the constraint is deferred
+	// until the end of the transaction by default. It is only true if the
+	// constraint is Deferrable.
+	InitiallyDeferred() bool
+
+	// IsExclusion is true if this is an exclusion constraint (EXCLUDE). Two
+	// rows violate an exclusion constraint if all of its columns compare true
+	// with the operators returned by ExclusionOperator. Unlike a unique
+	// constraint, an exclusion constraint does not imply that its columns form
+	// a key.
+	IsExclusion() bool
+
+	// ExclusionOperator returns the operator used to compare the ith column of
+	// the constraint between two rows. It is treecmp.EQ for all columns of a
+	// unique constraint.
+	ExclusionOperator(i int) treecmp.ComparisonOperatorSymbol
 }
 
 // UniqueOrdinal identifies a unique constraint (in the context of a Table).
@@ -383,3 +427,53 @@ type UniqueOrdinal = int
 // UniqueOrdinals identifies a list of unique constraints (in the context of
 // a Table).
 type UniqueOrdinals = []UniqueOrdinal
+
+// Trigger represents a trigger defined on a table. A trigger executes a
+// function each time a mutation statement affects the table, either once for
+// the statement or once for each modified row. For example:
+//
+//	CREATE TRIGGER tr BEFORE INSERT ON t FOR EACH ROW EXECUTE FUNCTION f()
+type Trigger interface {
+	// Name returns the name of the trigger.
+	Name() tree.Name
+
+	// ActionTime returns whether the trigger fires before or after the
+	// mutation.
+	ActionTime() tree.TriggerActionTime
+
+	// EventCount returns the number of events that fire the trigger.
+	EventCount() int
+
+	// Event returns the ith event that fires the trigger, where
+	// i < EventCount.
+	Event(i int) TriggerEvent
+
+	// ForEachRow is true if the trigger fires once for each modified row, and
+	// false if it fires once per statement.
+	ForEachRow() bool
+
+	// WhenExpr returns the serialized WHEN condition of the trigger, or the
+	// empty string if the trigger has no WHEN condition. The expression may
+	// reference the NEW and OLD records.
+	WhenExpr() string
+
+	// FuncID returns the stable identifier of the trigger function.
+	FuncID() StableID
+
+	// FuncArgs returns the arguments that are passed to the trigger function
+	// through TG_ARGV.
+	FuncArgs() []string
+
+	// Enabled is true if the trigger is enabled. Disabled triggers never fire.
+	Enabled() bool
+}
+
+// TriggerEvent describes an event that fires a trigger.
+type TriggerEvent struct {
+	// EventType is the kind of mutation that fires the trigger.
+	EventType tree.TriggerEventType
+
+	// Columns is the list of columns that an UPDATE must target in order to
+	// fire the trigger. If it is empty, any UPDATE fires the trigger.
+	Columns tree.NameList
+}
@@ -105,11 +105,16 @@ func FormatTable(
 		if uniq.WithoutIndex() {
 			withoutIndexStr = "WITHOUT INDEX "
 		}
-		c := child.Childf(
-			"UNIQUE %s%s",
-			withoutIndexStr,
-			formatCols(tab, tab.Unique(i).ColumnCount(), tab.Unique(i).ColumnOrdinal),
-		)
+		var c treeprinter.Node
+		if uniq.IsExclusion() {
+			c = child.Childf("EXCLUDE %s", formatExclusionCols(tab, uniq))
+		} else {
+			c = child.Childf(
+				"UNIQUE %s%s",
+				withoutIndexStr,
+				formatCols(tab, tab.Unique(i).ColumnCount(), tab.Unique(i).ColumnOrdinal),
+			)
+		}
 		if pred, isPartial := uniq.Predicate(); isPartial {
 			c.Childf("WHERE %s", MaybeMarkRedactable(pred, redactableValues))
 		}
@@ -209,6 +214,25 @@ func formatCols(tab Table, numCols int, colOrdinal func(tab Table, i int) int) s
 	return buf.String()
 }
 
+// formatExclusionCols formats the columns of an exclusion constraint along
+// with their operators, e.g. "(a WITH =, b WITH &&)".
+func formatExclusionCols(tab Table, uniq UniqueConstraint) string {
+	var buf bytes.Buffer
+	buf.WriteByte('(')
+	for i, n := 0, uniq.ColumnCount(); i < n; i++ {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		colName := tab.Column(uniq.ColumnOrdinal(tab, i)).ColName()
+		buf.WriteString(colName.String())
+		buf.WriteString(" WITH ")
+		buf.WriteString(uniq.ExclusionOperator(i).String())
+	}
+	buf.WriteByte(')')
+
+	return buf.String()
+}
+
 // formatCatalogFKRef nicely formats a catalog foreign key reference using a
 // treeprinter for debugging and testing.
 func formatCatalogFKRef(
@@ -167,6 +167,12 @@ type Builder struct {
 	// processes to the builtScans slice.
 	doScanExprCollection bool
 
+	// filteredScan and scanFilters are set while building the input of a
+	// Select expression which is a Scan expression, to the Scan and the filters
+	// of the Select. See scanColumnConstraints.
+	filteredScan *memo.ScanExpr
+	scanFilters  memo.FiltersExpr
+
 	// IsANSIDML is true if the AST the execbuilder is working on is one of the
 	// 4 DML statements, SELECT, UPDATE, INSERT, DELETE, or an EXPLAIN of one of
 	// these statements.
@@ -152,9 +152,15 @@ func makeCascadeBuilder(b *Builder, mutationWithID opt.WithID) (*cascadeBuilder,
 
 // setupCascade fills in an exec.Cascade struct for the given cascade.
 func (cb *cascadeBuilder) setupCascade(cascade *memo.FKCascade) exec.Cascade {
+	buffer := cb.mutationBuffer
+	if cascade.FKConstraint == nil && cascade.WithID == 0 {
+		// Statement-level triggers do not require the buffered input, and must
+		// fire even if no rows were modified.
+		buffer = nil
+	}
 	return exec.Cascade{
 		FKConstraint: cascade.FKConstraint,
-		Buffer:       cb.mutationBuffer,
+		Buffer:       buffer,
 		PlanFn: func(
 			ctx context.Context,
 			semaCtx *tree.SemaContext,
@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/errors"
@@ -135,6 +136,10 @@ func (b *Builder) buildInsert(ins *memo.InsertExpr) (_ execPlan, outputCols colO
 		return execPlan{}, colOrdMap{}, err
 	}
 
+	if err := b.buildFKCascades(ins.WithID, ins.FKCascades); err != nil {
+		return execPlan{}, colOrdMap{}, err
+	}
+
 	return ep, outputCols, nil
 }
 
@@ -158,6 +163,16 @@ func (b *Builder) tryBuildFastPathInsert(
 	if len(ins.UniqueChecks) != len(ins.FastPathUniqueChecks) {
 		return execPlan{}, colOrdMap{}, false, nil
 	}
+	// The fast path does not support cascades, which are used to fire AFTER
+	// triggers.
+	if len(ins.FKCascades) != 0 {
+		return execPlan{}, colOrdMap{}, false, nil
+	}
+	// The fast path does not support deferring the checks of deferrable
+	// constraints.
+	if hasDeferrableChecks(b.mem.Metadata(), ins) {
+		return execPlan{}, colOrdMap{}, false, nil
+	}
 
 	insInput := ins.Input
 	values, ok := insInput.(*memo.ValuesExpr)
@@ -338,6 +353,24 @@ func (b *Builder) tryBuildFastPathInsert(
 	return ep, outputCols, true, nil
 }
 
+// hasDeferrableChecks returns true if any of the unique or foreign key checks
+// of the given insert are for deferrable constraints.
+func hasDeferrableChecks(md *opt.Metadata, ins *memo.InsertExpr) bool {
+	for i := range ins.UniqueChecks {
+		c := &ins.UniqueChecks[i]
+		if md.Table(c.Table).Unique(c.CheckOrdinal).Deferrable() {
+			return true
+		}
+	}
+	for i := range ins.FKChecks {
+		c := &ins.FKChecks[i]
+		if c.FKOutbound && md.Table(c.OriginTable).OutboundForeignKey(c.FKOrdinal).Deferrable() {
+			return true
+		}
+	}
+	return false
+}
+
 // rearrangeColumns rearranges the columns in a matrix of TypedExpr values.
 //
 // Each column in inRows corresponds to a column in inCols. The values in the
@@ -788,6 +821,8 @@ func (b *Builder) buildUniqueChecks(checks memo.UniqueChecksExpr) error {
 			return err
 		}
 		// Wrap the query in an error node.
+		tab := md.Table(c.Table)
+		uc := tab.Unique(c.CheckOrdinal)
 		mkErr := func(row tree.Datums) error {
 			keyVals := make(tree.Datums, len(c.KeyCols))
 			for i, col := range c.KeyCols {
@@ -797,6 +832,11 @@ func (b *Builder) buildUniqueChecks(checks memo.UniqueChecksExpr) error {
 				}
 				keyVals[i] = row[ord]
 			}
+			if deferred, err := b.maybeDeferCheck(
+				tab, uc.Name(), uc.Deferrable(), uc.InitiallyDeferred(), keyVals, false, /* referenced */
+			); err != nil || deferred {
+				return err
+			}
 			return mkUniqueCheckErr(md, c, keyVals)
 		}
 		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr)
@@ -820,6 +860,13 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 			return err
 		}
 		// Wrap the query in an error node.
+		origin := md.Table(c.OriginTable)
+		var fk cat.ForeignKeyConstraint
+		if c.FKOutbound {
+			fk = origin.OutboundForeignKey(c.FKOrdinal)
+		} else {
+			fk = md.Table(c.ReferencedTable).InboundForeignKey(c.FKOrdinal)
+		}
 		mkErr := func(row tree.Datums) error {
 			keyVals := make(tree.Datums, len(c.KeyCols))
 			for i, col := range c.KeyCols {
@@ -829,6 +876,11 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 				}
 				keyVals[i] = row[ord]
 			}
+			if deferred, err := b.maybeDeferCheck(
+				origin, fk.Name(), fk.Deferrable(), fk.InitiallyDeferred(), keyVals, !c.FKOutbound,
+			); err != nil || deferred {
+				return err
+			}
 			return mkFKCheckErr(md, c, keyVals)
 		}
 		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr)
@@ -840,6 +892,32 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 	return nil
 }
 
+// maybeDeferCheck is called when the check of a constraint on the given table
+// finds a row that violates it. If the constraint is deferrable and its checks
+// are currently deferred, the key of the row is recorded so that it is checked
+// again before the transaction commits, and maybeDeferCheck returns true.
+// Otherwise, it returns false and the violation must be reported immediately.
+func (b *Builder) maybeDeferCheck(
+	tab cat.Table,
+	name string,
+	deferrable, initiallyDeferred bool,
+	keyVals tree.Datums,
+	referenced bool,
+) (deferred bool, _ error) {
+	if !deferrable || b.evalCtx == nil || b.evalCtx.DeferredConstraints == nil {
+		return false, nil
+	}
+	dc := b.evalCtx.DeferredConstraints
+	tableID := catid.DescID(tab.ID())
+	if !dc.IsDeferred(tableID, name, initiallyDeferred) {
+		return false, nil
+	}
+	if err := dc.AddPendingCheck(b.ctx, tableID, name, keyVals, referenced); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // mkUniqueCheckErr generates a user-friendly error describing a uniqueness
 // violation. The keyVals are the values that correspond to the
 // cat.UniqueConstraint columns.
@@ -852,7 +930,17 @@ func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.D
 	// Generate an error of the form:
 	//   ERROR:  duplicate key value violates unique constraint "foo"
 	//   DETAIL: Key (k)=(2) already exists.
-	msg.WriteString("duplicate key value violates unique constraint ")
+	//
+	// Or, for exclusion constraints:
+	//   ERROR:  conflicting key value violates exclusion constraint "foo"
+	//   DETAIL: Key (k)=(2) conflicts with an existing key.
+	code := pgcode.UniqueViolation
+	if uc.IsExclusion() {
+		code = pgcode.ExclusionViolation
+		msg.WriteString("conflicting key value violates exclusion constraint ")
+	} else {
+		msg.WriteString("duplicate key value violates unique constraint ")
+	}
 	lexbase.EncodeEscapedSQLIdent(&msg, constraintName)
 
 	details.WriteString("Key (")
@@ -871,11 +959,15 @@ func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.D
 		details.WriteString(d.String())
 	}
 
-	details.WriteString(") already exists.")
+	if uc.IsExclusion() {
+		details.WriteString(") conflicts with an existing key.")
+	} else {
+		details.WriteString(") already exists.")
+	}
 
 	return errors.WithDetail(
 		pgerror.WithConstraintName(
-			pgerror.Newf(pgcode.UniqueViolation, "%s", msg.String()),
+			pgerror.Newf(code, "%s", msg.String()),
 			constraintName,
 		),
 		details.String(),
@@ -831,6 +831,9 @@ func (b *Builder) buildScan(scan *memo.ScanExpr) (_ execPlan, outputCols colOrdM
 	if err != nil {
 		return execPlan{}, colOrdMap{}, err
 	}
+	if b.filteredScan == scan {
+		params.ColumnConstraints = b.scanColumnConstraints(scan, b.scanFilters)
+	}
 	reqOrdering, err := reqOrdering(scan, outputCols)
 	if err != nil {
 		return execPlan{}, colOrdMap{}, err
@@ -931,7 +934,59 @@ func (b *Builder) buildPlaceholderScan(
 	return res, outputCols, nil
 }
 
+// scanColumnConstraints returns the constraints on single columns of the
+// scanned table which are implied by the given filters, applied to the output
+// of the given scan. The scan must be of the primary index, and must not have
+// a hard limit, which would be applied before the filters.
+func (b *Builder) scanColumnConstraints(
+	scan *memo.ScanExpr, filters memo.FiltersExpr,
+) []exec.ColumnConstraint {
+	if scan.Index != cat.PrimaryIndex || scan.HardLimit != 0 || scan.IsVirtualTable(b.mem.Metadata()) {
+		return nil
+	}
+	var res []exec.ColumnConstraint
+	for i := range filters {
+		cs := filters[i].ScalarProps().Constraints
+		if cs == nil {
+			continue
+		}
+		for j, n := 0, cs.Length(); j < n; j++ {
+			c := cs.Constraint(j)
+			if c.Columns.Count() != 1 || c.Columns.Get(0).Descending() {
+				continue
+			}
+			col := c.Columns.Get(0).ID()
+			if !scan.Cols.Contains(col) {
+				continue
+			}
+			ord := exec.TableColumnOrdinal(scan.Table.ColumnOrdinal(col))
+			merged := false
+			for k := range res {
+				if res[k].Column == ord {
+					// Intersect the constraints of the same column, which are
+					// implied by distinct filters.
+					intersected := *res[k].Constraint
+					intersected.IntersectWith(b.evalCtx, c)
+					res[k].Constraint = &intersected
+					merged = true
+					break
+				}
+			}
+			if !merged {
+				res = append(res, exec.ColumnConstraint{Column: ord, Constraint: c})
+			}
+		}
+	}
+	return res
+}
+
 func (b *Builder) buildSelect(sel *memo.SelectExpr) (_ execPlan, outputCols colOrdMap, err error) {
+	if scan, ok := sel.Input.(*memo.ScanExpr); ok {
+		// The filters can allow the scan to skip parts of the table. See
+		// scanColumnConstraints.
+		b.filteredScan, b.scanFilters = scan, sel.Filters
+		defer func() { b.filteredScan, b.scanFilters = nil, nil }()
+	}
 	input, inputCols, err := b.buildRelational(sel.Input)
 	if err != nil {
 		return execPlan{}, colOrdMap{}, err
@@ -1645,6 +1700,27 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (_ execPlan, outputCols col
 			agg = aggDistinct.Input
 		}
 
+		if uda, ok := agg.(*memo.UserDefinedAggExpr); ok {
+			argCols, err := b.userDefinedAggArgCols(uda, inputCols)
+			if err != nil {
+				return execPlan{}, colOrdMap{}, err
+			}
+			udAgg, err := b.buildUserDefinedAgg(uda)
+			if err != nil {
+				return execPlan{}, colOrdMap{}, err
+			}
+			aggInfos[i] = exec.AggInfo{
+				FuncName:    uda.Def.Name,
+				Distinct:    distinct,
+				ResultType:  item.Agg.DataType(),
+				ArgCols:     argCols,
+				Filter:      filterOrd,
+				UserDefined: udAgg,
+			}
+			outputCols.Set(item.Col, len(groupingColIdx)+i)
+			continue
+		}
+
 		name, overload := memo.FindAggregateOverload(agg)
 
 		// Accumulate variable arguments in argCols and constant arguments in
@@ -1775,6 +1851,64 @@ func (b *Builder) buildDistinct(
 	)
 }
 
+// userDefinedAggArgCols returns the ordinals of the input columns which are
+// the arguments of the given user-defined aggregate.
+func (b *Builder) userDefinedAggArgCols(
+	agg *memo.UserDefinedAggExpr, inputCols colOrdMap,
+) ([]exec.NodeColumnOrdinal, error) {
+	argCols := make([]exec.NodeColumnOrdinal, len(agg.Args))
+	for i, arg := range agg.Args {
+		variable, ok := arg.(*memo.VariableExpr)
+		if !ok {
+			return nil, errors.AssertionFailedf("only VariableOp args supported")
+		}
+		ord, err := getNodeColumnOrdinal(inputCols, variable.Col)
+		if err != nil {
+			return nil, err
+		}
+		argCols[i] = ord
+	}
+	return argCols, nil
+}
+
+// buildUserDefinedAgg builds the support functions of a user-defined
+// aggregate. The parameters of each support function are mapped to
+// IndexedVars in the order in which they are listed in the definition.
+func (b *Builder) buildUserDefinedAgg(
+	agg *memo.UserDefinedAggExpr,
+) (_ *exec.UserDefinedAgg, err error) {
+	def := agg.Def
+	buildSupportFunc := func(params opt.ColList, fn opt.ScalarExpr) (tree.TypedExpr, error) {
+		if fn == nil {
+			return nil, nil
+		}
+		paramCols := b.colOrdsAlloc.Alloc()
+		for i, col := range params {
+			paramCols.Set(col, i)
+		}
+		ctx := buildScalarCtx{
+			ivh:     tree.MakeIndexedVarHelper(nil /* container */, len(params)),
+			ivarMap: paramCols,
+		}
+		return b.buildScalar(&ctx, fn)
+	}
+	res := &exec.UserDefinedAgg{
+		StrictStateFunc: def.StrictStateFunc,
+		InitState:       def.InitState,
+		StateType:       def.StateType,
+	}
+	if res.StateFunc, err = buildSupportFunc(def.StateParams, def.StateFunc); err != nil {
+		return nil, err
+	}
+	if res.FinalFunc, err = buildSupportFunc(def.StateParams[:1], def.FinalFunc); err != nil {
+		return nil, err
+	}
+	if res.CombineFunc, err = buildSupportFunc(def.CombineParams, def.CombineFunc); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 func (b *Builder) buildGroupByInput(
 	groupBy memo.RelExpr,
 ) (_ execPlan, outputCols colOrdMap, err error) {
@@ -3395,6 +3529,7 @@ func (b *Builder) buildCall(c *memo.CallExpr) (_ execPlan, outputCols colOrdMap,
 		nil,   /* blockState */
 		nil,   /* cursorDeclaration */
 	)
+	r.SessionOverride = udf.Def.SessionOverride
 
 	var ep execPlan
 	ep.root, err = b.factory.ConstructCall(r)
@@ -3578,20 +3713,54 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (_ execPlan, outputCols colOrd
 	filterIdxs := make([]int, len(w.Windows))
 	exprs := make([]*tree.FuncExpr, len(w.Windows))
 	windowVals := make([]tree.WindowDef, len(w.Windows))
+	var userDefinedAggs []*exec.UserDefinedAgg
 
 	for i := range w.Windows {
 		item := &w.Windows[i]
 		fn := b.extractWindowFunction(item.Function)
-		name, overload := memo.FindWindowOverload(fn)
-		if !b.disableTelemetry {
-			telemetry.Inc(sqltelemetry.WindowFunctionCounter(name))
+		var fnRef tree.ResolvableFunctionReference
+		var typ *types.T
+		var props *tree.FunctionProperties
+		var overload *tree.Overload
+		var argVars []opt.ScalarExpr
+		if uda, ok := fn.(*memo.UserDefinedAggExpr); ok {
+			// A user-defined aggregate is computed from the support functions in
+			// its definition, so it is only referenced by name.
+			udAgg, err := b.buildUserDefinedAgg(uda)
+			if err != nil {
+				return execPlan{}, colOrdMap{}, err
+			}
+			if userDefinedAggs == nil {
+				userDefinedAggs = make([]*exec.UserDefinedAgg, len(w.Windows))
+			}
+			userDefinedAggs[i] = udAgg
+			fnRef = tree.ResolvableFunctionReference{
+				FunctionReference: &tree.UnresolvedName{NumParts: 1, Parts: tree.NameParts{uda.Def.Name}},
+			}
+			typ = uda.Def.Typ
+			argVars = uda.Args
+		} else {
+			var name string
+			name, overload = memo.FindWindowOverload(fn)
+			if !b.disableTelemetry {
+				telemetry.Inc(sqltelemetry.WindowFunctionCounter(name))
+			}
+			props, _ = builtinsregistry.GetBuiltinProperties(name)
+			fnRef, err = b.wrapFunction(name)
+			if err != nil {
+				return execPlan{}, colOrdMap{}, err
+			}
+			typ = overload.FixedReturnType()
+			argVars = make([]opt.ScalarExpr, fn.ChildCount())
+			for j := range argVars {
+				argVars[j] = fn.Child(j).(opt.ScalarExpr)
+			}
 		}
-		props, _ := builtinsregistry.GetBuiltinProperties(name)
 
-		args := make([]tree.TypedExpr, fn.ChildCount())
-		argIdxs[i] = make([]exec.NodeColumnOrdinal, fn.ChildCount())
-		for j, n := 0, fn.ChildCount(); j < n; j++ {
-			col := fn.Child(j).(*memo.VariableExpr).Col
+		args := make([]tree.TypedExpr, len(argVars))
+		argIdxs[i] = make([]exec.NodeColumnOrdinal, len(argVars))
+		for j := range argVars {
+			col := argVars[j].(*memo.VariableExpr).Col
 			indexedVar, err := b.indexedVar(&ctx, b.mem.Metadata(), col)
 			if err != nil {
 				return execPlan{}, colOrdMap{}, err
@@ -3629,17 +3798,13 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (_ execPlan, outputCols colOrd
 			OrderBy:    orderingExprs,
 			Frame:      frame,
 		}
-		wrappedFn, err := b.wrapFunction(name)
-		if err != nil {
-			return execPlan{}, colOrdMap{}, err
-		}
 		exprs[i] = tree.NewTypedFuncExpr(
-			wrappedFn,
+			fnRef,
 			0,
 			args,
 			builtFilter,
 			&windowVals[i],
-			overload.FixedReturnType(),
+			typ,
 			props,
 			overload,
 		)
@@ -3678,13 +3843,14 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (_ execPlan, outputCols colOrd
 	}
 	var ep execPlan
 	ep.root, err = b.factory.ConstructWindow(input.root, exec.WindowInfo{
-		Cols:       resultCols,
-		Exprs:      exprs,
-		OutputIdxs: outputIdxs,
-		ArgIdxs:    argIdxs,
-		FilterIdxs: filterIdxs,
-		Partition:  partitionIdxs,
-		Ordering:   sqlOrdering,
+		Cols:            resultCols,
+		Exprs:           exprs,
+		OutputIdxs:      outputIdxs,
+		ArgIdxs:         argIdxs,
+		UserDefinedAggs: userDefinedAggs,
+		FilterIdxs:      filterIdxs,
+		Partition:       partitionIdxs,
+		Ordering:        sqlOrdering,
 	})
 	if err != nil {
 		return execPlan{}, colOrdMap{}, err
@@ -960,6 +960,11 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 			"expected more than one body statement for a routine that opens a cursor",
 		))
 	}
+	if udf.Def.ReturnNextBuffer != nil && len(udf.Def.Body) <= 1 {
+		panic(errors.AssertionFailedf(
+			"expected more than one body statement for a RETURN NEXT or RETURN QUERY routine",
+		))
+	}
 
 	// Create a tree.RoutinePlanFn that can plan the statements in the UDF body.
 	// TODO(mgartner): Add support for WITH expressions inside UDF bodies.
@@ -981,7 +986,7 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 	// routine is in tail-call position.
 	_, tailCall := b.tailCalls[udf]
 
-	return tree.NewTypedRoutineExpr(
+	routine := tree.NewTypedRoutineExpr(
 		udf.Def.Name,
 		args,
 		planGen,
@@ -994,7 +999,11 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 		false, /* procedure */
 		blockState,
 		udf.Def.CursorDeclaration,
-	), nil
+	)
+	routine.ResultBuffer = udf.Def.ResultBuffer
+	routine.ReturnNextBuffer = udf.Def.ReturnNextBuffer
+	routine.SessionOverride = udf.Def.SessionOverride
+	return routine, nil
 }
 
 func (b *Builder) buildRoutineArgs(
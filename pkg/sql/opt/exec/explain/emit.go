@@ -121,11 +121,22 @@ func emitInternal(
 	}
 
 	for _, cascade := range plan.Cascades {
-		ob.EnterMetaNode("fk-cascade")
-		ob.Attr("fk", cascade.FKConstraint.Name())
 		// Here we do want to allow creation of the plans for the cascades to be
 		// able to include them into the EXPLAIN output.
 		const createPlanIfMissing = true
+		if cascade.FKConstraint == nil {
+			// The cascade fires AFTER triggers.
+			ob.EnterMetaNode("after-triggers")
+			if cascadePlan, err := cascade.GetExplainPlan(ctx, createPlanIfMissing); err != nil {
+				return err
+			} else if err = emitInternal(ctx, cascadePlan.(*Plan), ob, spanFormatFn, visitedFKsByCascades); err != nil {
+				return err
+			}
+			ob.LeaveNode()
+			continue
+		}
+		ob.EnterMetaNode("fk-cascade")
+		ob.Attr("fk", cascade.FKConstraint.Name())
 		if cascadePlan, err := cascade.GetExplainPlan(ctx, createPlanIfMissing); err != nil {
 			return err
 		} else {
@@ -501,6 +501,10 @@ func (u *unknownTable) IsVirtualTable() bool {
 	return false
 }
 
+func (u *unknownTable) IsForeignTable() bool {
+	return false
+}
+
 func (u *unknownTable) IsSystemTable() bool {
 	return false
 }
@@ -628,6 +632,14 @@ func (u *unknownTable) IsHypothetical() bool {
 	return false
 }
 
+func (u *unknownTable) TriggerCount() int {
+	return 0
+}
+
+func (u *unknownTable) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
 var _ cat.Table = &unknownTable{}
 
 // unknownTable implements the cat.Index interface and is used to represent
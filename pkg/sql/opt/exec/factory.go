@@ -128,6 +128,20 @@ type ScanParams struct {
 	// to work correctly, the execution engine must create a local DistSQL plan
 	// for the main query (subqueries and postqueries need not be local).
 	LocalityOptimized bool
+
+	// ColumnConstraints contains constraints on single columns of the table
+	// which are implied by filters applied to the output of the scan. They are
+	// not needed for correctness: the execution engine can use them to skip
+	// parts of the table using its BRIN indexes, which the optimizer does not
+	// scan.
+	ColumnConstraints []ColumnConstraint
+}
+
+// ColumnConstraint is a constraint on the values of a single column of a
+// table.
+type ColumnConstraint struct {
+	Column     TableColumnOrdinal
+	Constraint *constraint.Constraint
 }
 
 // OutputOrdering indicates the required output ordering on a Node that is being
@@ -214,6 +228,37 @@ type AggInfo struct {
 	// DistsqlBlocklist is set to true when this aggregate function cannot be
 	// evaluated in distributed fashion.
 	DistsqlBlocklist bool
+
+	// UserDefined is set if this is an aggregate created with CREATE
+	// AGGREGATE.
+	UserDefined *UserDefinedAgg
+}
+
+// UserDefinedAgg contains the support functions of an aggregate created with
+// CREATE AGGREGATE.
+type UserDefinedAgg struct {
+	// StateFunc computes the next state. The IndexedVar with ordinal 0 refers
+	// to the current state, and the following IndexedVars refer to the
+	// arguments of the aggregate.
+	StateFunc tree.TypedExpr
+
+	// StrictStateFunc is true if StateFunc is not called when any of the
+	// arguments are NULL.
+	StrictStateFunc bool
+
+	// FinalFunc, if set, computes the result from the state, which is referred
+	// to by the IndexedVar with ordinal 0.
+	FinalFunc tree.TypedExpr
+
+	// CombineFunc, if set, combines the two partial states which are referred
+	// to by the IndexedVars with ordinals 0 and 1.
+	CombineFunc tree.TypedExpr
+
+	// InitState is the initial state, or DNull.
+	InitState tree.Datum
+
+	// StateType is the type of the state.
+	StateType *types.T
 }
 
 // WindowInfo represents the information about a window function that must be
@@ -235,6 +280,11 @@ type WindowInfo struct {
 	// in the same order as Exprs.
 	ArgIdxs [][]NodeColumnOrdinal
 
+	// UserDefinedAggs contains the definition of each function which is an
+	// aggregate created with CREATE AGGREGATE, and nil for other functions, in
+	// the same order as Exprs.
+	UserDefinedAggs []*UserDefinedAgg
+
 	// FilterIdxs is the list of column indices to use as filters.
 	FilterIdxs []int
 
@@ -275,6 +325,9 @@ type ApplyJoinPlanRightSideFn func(ctx context.Context, ef Factory, leftRow tree
 // ConstructBuffer as an input; it should only be triggered if this buffer is
 // not empty.
 type Cascade struct {
+	// FKConstraint is the foreign key constraint that the cascade enforces. It
+	// is nil if the cascade fires AFTER triggers, in which case the rows
+	// produced by the query should be discarded.
 	FKConstraint cat.ForeignKeyConstraint
 
 	// Buffer is the Node returned by ConstructBuffer which stores the input to
@@ -352,7 +405,7 @@ type InsertFastPathCheck struct {
 }
 
 // MkErrFn is a function that generates an error which includes values from a
-// relevant row.
+// relevant row. Returning nil ignores the row.
 type MkErrFn func(tree.Datums) error
 
 // ExplainFactory is an extension of Factory used when constructing a plan that
@@ -342,11 +342,66 @@ func (c *indexConstraintCtx) makeSpansForSingleColumnDatum(
 				return complete
 			}
 		}
+
+	case opt.OverlapsOp, opt.ContainsOp:
+		if r, ok := datum.(*tree.DRange); ok {
+			return c.makeSpansForRangeDatum(offset, op, r, out)
+		}
 	}
 	c.unconstrained(offset, out)
 	return false
 }
 
+// makeSpansForRangeDatum creates spans for a range index column from an
+// expression of the form (col && r) or (col @> r). Ranges are ordered by their
+// lower bounds first, so a range that overlaps r must have a lower bound that
+// is not greater than the upper bound of r, and a range that contains r must
+// have a lower bound that is not greater than the lower bound of r. The
+// <tight> return value indicates if the spans are exactly equivalent to the
+// expression (and not weaker).
+func (c *indexConstraintCtx) makeSpansForRangeDatum(
+	offset int, op opt.Operator, r *tree.DRange, out *constraint.Constraint,
+) (tight bool) {
+	typ := r.ResolvedType()
+	if r.Empty {
+		if op == opt.OverlapsOp {
+			// No range overlaps the empty range.
+			c.contradiction(offset, out)
+			return true
+		}
+		// Every range contains the empty range.
+		c.makeNotNullSpan(offset, out)
+		return true
+	}
+	// The empty range sorts before all other ranges, and it neither overlaps
+	// nor contains a non-empty range.
+	startKey, startBoundary := constraint.MakeKey(tree.NewDEmptyRange(typ)), excludeBoundary
+	endKey, endBoundary := emptyKey, includeBoundary
+	switch op {
+	case opt.OverlapsOp:
+		if r.Upper != nil {
+			// The last range with a lower bound not greater than the upper bound
+			// of r is (upper,).
+			endKey = constraint.MakeKey(tree.NewDRangeFromFlags(typ, tree.RangeUpperInf, r.Upper, nil))
+		}
+	case opt.ContainsOp:
+		// The last range with the same lower bound as r is unbounded above.
+		flags := tree.RangeUpperInf
+		if r.Lower == nil {
+			flags |= tree.RangeLowerInf
+		} else if r.LowerInc {
+			flags |= tree.RangeLowerInc
+		}
+		endKey = constraint.MakeKey(tree.NewDRangeFromFlags(typ, flags, r.Lower, nil))
+	}
+	c.singleSpan(
+		offset, startKey, startBoundary, endKey, endBoundary,
+		c.columns[offset].Descending(),
+		out,
+	)
+	return false
+}
+
 // makeSpansForTupleInequality creates spans for index columns starting at
 // <offset> from a tuple inequality.
 // Assumes that ev.Operator() is an inequality and both sides are tuples.
@@ -754,6 +754,22 @@ type UDFDefinition struct {
 	// result of the routine. This invariant is enforced when the PLpgSQL routine
 	// is built. CursorDeclaration may be unset.
 	CursorDeclaration *tree.RoutineOpenCursor
+
+	// ResultBuffer is set for a set-returning PLpgSQL routine. It collects the
+	// rows produced by RETURN NEXT and RETURN QUERY statements, which form the
+	// result of the routine.
+	ResultBuffer *tree.RoutineResultBuffer
+
+	// ReturnNextBuffer is set for a routine that implements a PLpgSQL RETURN
+	// NEXT or RETURN QUERY statement. The rows produced by the *first* body
+	// statement are added to the buffer, which is shared with the set-returning
+	// routine. If it is set, there will be at least two body statements.
+	ReturnNextBuffer *tree.RoutineResultBuffer
+
+	// SessionOverride, if set, describes the changes made to the session while
+	// the routine executes. It is set for routines declared with SECURITY
+	// DEFINER or SET clauses.
+	SessionOverride *tree.RoutineSessionOverride
 }
 
 // ExceptionBlock contains the information needed to match and handle errors in
@@ -770,6 +786,55 @@ type ExceptionBlock struct {
 	Actions []*UDFDefinition
 }
 
+// UserDefinedAggDefinition stores details about an aggregate created with
+// CREATE AGGREGATE. The support functions of the aggregate are built as scalar
+// expressions over parameter columns, which are replaced with the current
+// state and the input values of the aggregate during execution.
+type UserDefinedAggDefinition struct {
+	// Name is the name of the aggregate.
+	Name string
+
+	// Typ is the result type of the aggregate.
+	Typ *types.T
+
+	// StateType is the type of the state of the aggregate.
+	StateType *types.T
+
+	// InitState is the initial state of the aggregate. It is DNull if the
+	// aggregate has no initial condition.
+	InitState tree.Datum
+
+	// StateParams is the list of parameter columns of the state transition
+	// function. The first column represents the current state, and the rest
+	// represent the arguments of the aggregate.
+	StateParams opt.ColList
+
+	// StateFunc computes the next state from the columns in StateParams.
+	StateFunc opt.ScalarExpr
+
+	// StrictStateFunc is true if the state transition function is not called
+	// when any of the arguments are NULL. In this case, the first non-NULL
+	// argument becomes the state if the initial state is NULL.
+	StrictStateFunc bool
+
+	// FinalFunc computes the result of the aggregate from the state, which is
+	// represented by the first column in StateParams. It is nil if the result
+	// is the final state.
+	FinalFunc opt.ScalarExpr
+
+	// CombineParams is the list of parameter columns of the combine function,
+	// which represent two partial states.
+	CombineParams opt.ColList
+
+	// CombineFunc combines the two partial states represented by the columns in
+	// CombineParams. It is nil if the aggregate has no combine function.
+	CombineFunc opt.ScalarExpr
+
+	// Volatility is the volatility of the aggregate, which is the volatility of
+	// its most volatile support function.
+	Volatility volatility.V
+}
+
 // WindowFrame denotes the definition of a window frame for an individual
 // window function, excluding the OFFSET expressions, if present.
 type WindowFrame struct {
@@ -1329,7 +1394,12 @@ type FKCascades []FKCascade
 
 // FKCascade stores metadata necessary for building a cascading query.
 // Cascading queries are built as needed, after the original query is executed.
+//
+// Cascades are also used to fire AFTER triggers once the original query has
+// modified the table; see optbuilder.afterTriggersBuilder.
 type FKCascade struct {
+	// FKConstraint is the foreign key constraint that the cascade enforces. It
+	// is nil if the cascade fires AFTER triggers.
 	FKConstraint cat.ForeignKeyConstraint
 
 	// Builder is an object that can be used as the "optbuilder" for the cascading
@@ -965,6 +965,10 @@ func (f *ExprFmtCtx) formatScalarWithLabel(
 				if i == 0 && def.CursorDeclaration != nil {
 					// The first statement is opening a cursor.
 					stmtNode = n.Child("open-cursor")
+				} else if i == 0 && def.ReturnNextBuffer != nil {
+					// The first statement adds rows to the result of a set-returning
+					// routine.
+					stmtNode = n.Child("return-next")
 				}
 				prevTailCalls := f.tailCalls
 				if i == len(def.Body)-1 {
@@ -1077,6 +1081,16 @@ func (f *ExprFmtCtx) formatScalarWithLabel(
 		formatUDFInputAndBody(udf, tp)
 		return
 
+	case opt.UserDefinedAggOp:
+		agg := scalar.(*UserDefinedAggExpr)
+		fmt.Fprintf(f.Buffer, "user-defined-agg: %s", agg.Def.Name)
+		f.FormatScalarProps(scalar)
+		tp = tp.Child(f.Buffer.String())
+		for i := range agg.Args {
+			f.formatExpr(agg.Args[i], tp)
+		}
+		return
+
 	case opt.TxnControlOp:
 		controlExpr := scalar.(*TxnControlExpr)
 		fmt.Fprintf(f.Buffer, "%s; CALL %s", controlExpr.TxnOp, controlExpr.Def.Name)
@@ -1346,7 +1360,7 @@ func (f *ExprFmtCtx) formatScalarPrivate(scalar opt.ScalarExpr) {
 		}
 		f.Buffer.WriteByte(')')
 
-	case *UDFCallExpr:
+	case *UDFCallExpr, *UserDefinedAggExpr:
 		private = nil
 
 	default:
@@ -1539,7 +1553,11 @@ func (f *ExprFmtCtx) formatMutationCommon(tp treeprinter.Node, p *MutationPrivat
 	if len(p.FKCascades) > 0 {
 		c := tp.Childf("cascades")
 		for i := range p.FKCascades {
-			c.Child(p.FKCascades[i].FKConstraint.Name())
+			if fk := p.FKCascades[i].FKConstraint; fk != nil {
+				c.Child(fk.Name())
+			} else {
+				c.Child("after-triggers")
+			}
 		}
 	}
 }
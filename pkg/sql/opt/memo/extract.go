@@ -134,8 +134,16 @@ func ExtractAggInputColumns(e opt.ScalarExpr) opt.ColSet {
 	}
 
 	for i, n := 0, e.ChildCount(); i < n; i++ {
-		if variable, ok := e.Child(i).(*VariableExpr); ok {
-			res.Add(variable.Col)
+		switch t := e.Child(i).(type) {
+		case *VariableExpr:
+			res.Add(t.Col)
+		case *ScalarListExpr:
+			// The arguments of a user-defined aggregate are stored in a list.
+			for _, arg := range *t {
+				if variable, ok := arg.(*VariableExpr); ok {
+					res.Add(variable.Col)
+				}
+			}
 		}
 	}
 
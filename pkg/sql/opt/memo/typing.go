@@ -213,6 +213,7 @@ func init() {
 	typingFuncMap[opt.MergeStatsMetadataOp] = typeAsFirstArg
 	typingFuncMap[opt.MergeStatementStatsOp] = typeAsFirstArg
 	typingFuncMap[opt.MergeTransactionStatsOp] = typeAsFirstArg
+	typingFuncMap[opt.UserDefinedAggOp] = typeUserDefinedAgg
 
 	// Modifiers for aggregations pass through their argument.
 	typingFuncMap[opt.AggDistinctOp] = typeAsFirstArg
@@ -387,6 +388,11 @@ func typeCast(e opt.ScalarExpr) *types.T {
 	return e.(*CastExpr).Typ
 }
 
+// typeUserDefinedAgg returns the type of a user-defined aggregate operator.
+func typeUserDefinedAgg(e opt.ScalarExpr) *types.T {
+	return e.(*UserDefinedAggExpr).Def.Typ
+}
+
 // typeUDFCall returns the type of a UDF call operator
 func typeUDFCall(e opt.ScalarExpr) *types.T {
 	return e.(*UDFCallExpr).Def.Typ
@@ -78,8 +78,13 @@ func (c *CustomFuncs) CanRemoveAggDistinctForKeys(
 	if agg.ChildCount() == 0 {
 		return false
 	}
+	// The arguments of a user-defined aggregate are stored in a list, so the
+	// first child is not necessarily a Variable.
+	variable, ok := agg.Child(0).(*memo.VariableExpr)
+	if !ok {
+		return false
+	}
 	inputFDs := &input.Relational().FuncDeps
-	variable := agg.Child(0).(*memo.VariableExpr)
 	cols := c.AddColToSet(private.GroupingCols, variable.Col)
 	return inputFDs.ColsAreStrictKey(cols)
 }
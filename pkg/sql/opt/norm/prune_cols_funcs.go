@@ -88,6 +88,12 @@ func (c *CustomFuncs) neededMutationCols(
 			withUses := memo.WithUses(fkChecks[i].Check)
 			cols.UnionWith(withUses[private.WithID].UsedCols)
 		}
+		// Cascades are built after the mutation runs, so their input columns
+		// must be retained.
+		for i := range private.FKCascades {
+			cols.UnionWith(private.FKCascades[i].OldValues.ToSet())
+			cols.UnionWith(private.FKCascades[i].NewValues.ToSet())
+		}
 	}
 
 	return cols
@@ -146,6 +152,13 @@ func (c *CustomFuncs) NeededMutationFetchCols(
 		}
 	}
 
+	// Retain any FetchCols that are passed to cascades, such as the OLD values
+	// of the rows passed to AFTER triggers.
+	for i := range private.FKCascades {
+		cols.UnionWith(private.FKCascades[i].OldValues.ToSet())
+		cols.UnionWith(private.FKCascades[i].NewValues.ToSet())
+	}
+
 	switch op {
 	case opt.UpdateOp, opt.UpsertOp:
 		// Determine set of target table columns that need to be updated.
@@ -341,7 +341,7 @@ func (b *Builder) buildStmt(
 			if !activeVersion.IsActive(clusterversion.V23_2) {
 				panic(unimplemented.Newf("user-defined functions", "%s usage inside a function definition is not supported until version 23.2", stmt.StatementTag()))
 			}
-		case *tree.Call:
+		case *tree.Call, *tree.DoBlock:
 			activeVersion := b.evalCtx.Settings.Version.ActiveVersion(b.ctx)
 			if !activeVersion.IsActive(clusterversion.V24_1) {
 				panic(unimplemented.Newf("stored procedures", "%s usage inside a routine definition is not supported until version 24.1", stmt.StatementTag()))
@@ -385,6 +385,9 @@ func (b *Builder) buildStmt(
 	case *tree.Call:
 		return b.buildProcedure(stmt, inScope)
 
+	case *tree.DoBlock:
+		return b.buildDoBlock(stmt, inScope)
+
 	case *tree.Explain:
 		return b.buildExplain(stmt, inScope)
 
@@ -13,6 +13,7 @@ package optbuilder
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
@@ -27,6 +28,7 @@ import (
 	plpgsqlparser "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
@@ -99,11 +101,16 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 	languageFound := false
 	var funcBodyStr string
 	var language tree.RoutineLanguage
+	var searchPath []string
 	for _, option := range cf.Options {
 		switch opt := option.(type) {
 		case tree.RoutineBodyStr:
 			funcBodyFound = true
 			funcBodyStr = string(opt)
+		case *tree.SetVar:
+			if strings.ToLower(opt.Name) == "search_path" {
+				searchPath = routineSearchPath(opt)
+			}
 		case tree.RoutineLanguage:
 			languageFound = true
 			language = opt
@@ -129,6 +136,17 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 		}
 	}
 
+	// Names in the body are resolved with the search_path which is set while
+	// the routine executes, if any.
+	if searchPath != nil {
+		defer b.pushRoutineSessionOverride(&tree.RoutineSessionOverride{
+			Settings: []tree.RoutineSessionSetting{{
+				Name:  "search_path",
+				Value: sessiondata.FormatSearchPaths(searchPath),
+			}},
+		})()
+	}
+
 	// Track the dependencies in the arguments, return type, and statements in
 	// the function body.
 	var deps opt.SchemaDeps
@@ -182,7 +200,7 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 	// When multiple OUT parameters are present, parameter names become the
 	// labels in the output RECORD type.
 	var outParamNames []string
-	var sawDefaultExpr bool
+	var sawDefaultExpr, sawVariadic bool
 	for i := range cf.Params {
 		param := &cf.Params[i]
 		typ, err := tree.ResolveType(b.ctx, param.Type, b.semaCtx.TypeResolver)
@@ -192,6 +210,23 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 		if param.Class == tree.RoutineParamInOut && param.Name == "" {
 			panic(unimplemented.NewWithIssue(121251, "unnamed INOUT parameters are not yet supported"))
 		}
+		if sawVariadic && param.IsInParam() {
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+				"VARIADIC parameter must be the last input parameter"))
+		}
+		if param.Class == tree.RoutineParamVariadic {
+			if cf.IsProcedure {
+				panic(unimplemented.NewWithIssue(88947, "variadic procedures are not yet supported"))
+			}
+			if typ.Family() != types.ArrayFamily {
+				panic(pgerror.New(pgcode.InvalidFunctionDefinition, "VARIADIC parameter must be an array"))
+			}
+			if param.DefaultVal != nil {
+				panic(unimplemented.NewWithIssue(88947,
+					"VARIADIC parameters with DEFAULT values are not yet supported"))
+			}
+			sawVariadic = true
+		}
 		if param.IsOutParam() {
 			outParamTypes = append(outParamTypes, typ)
 			paramName := string(param.Name)
@@ -293,7 +328,8 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 		// CREATE correctly.
 		funcReturnType = outParamType
 		cf.ReturnType = &tree.RoutineReturnType{
-			Type: outParamType,
+			Type:  outParamType,
+			SetOf: cf.ReturnType != nil && cf.ReturnType.SetOf,
 		}
 	} else if funcReturnType == nil {
 		if cf.IsProcedure {
@@ -315,6 +351,20 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 			panic(pgerror.New(pgcode.InvalidFunctionDefinition, "PL/pgSQL functions cannot return type unknown"))
 		}
 	}
+	// Trigger functions are invoked with implicit arguments describing the
+	// triggering event, and cannot be written in SQL.
+	isTriggerFunc := funcReturnType.Family() == types.TriggerFamily
+	if isTriggerFunc {
+		if language == tree.RoutineLangSQL {
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition, "SQL functions cannot return type trigger"))
+		}
+		if len(cf.Params) > 0 {
+			panic(errors.WithHint(
+				pgerror.New(pgcode.InvalidFunctionDefinition, "trigger functions cannot have declared arguments"),
+				"The arguments of the trigger can be accessed through TG_NARGS and TG_ARGV instead.",
+			))
+		}
+	}
 	// Collect the user defined type dependency of the return type.
 	typedesc.GetTypeDescriptorClosure(funcReturnType).ForEach(func(id descpb.ID) {
 		typeDeps.Add(int(id))
@@ -358,10 +408,11 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 			}
 		})
 	case tree.RoutineLangPLpgSQL:
-		if cf.ReturnType != nil && cf.ReturnType.SetOf {
+		isSetReturning := cf.ReturnType != nil && cf.ReturnType.SetOf
+		if isSetReturning && types.IsWildcardTupleType(funcReturnType) {
 			panic(unimplemented.NewWithIssueDetail(105240,
-				"set-returning PL/pgSQL functions",
-				"set-returning PL/pgSQL functions are not yet supported",
+				"set-returning PL/pgSQL functions returning RECORD",
+				"set-returning PL/pgSQL functions returning RECORD are not yet supported",
 			))
 		}
 
@@ -371,17 +422,22 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateRoutine, inScope *scope) (o
 			panic(err)
 		}
 
-		// We need to disable stable function folding because we want to catch the
-		// volatility of stable functions. If folded, we only get a scalar and lose
-		// the volatility.
-		b.factory.FoldingControl().TemporarilyDisallowStableFolds(func() {
-			plBuilder := newPLpgSQLBuilder(
-				b, cf.Name.Object(), stmt.AST.Label, nil, /* colRefs */
-				routineParams, funcReturnType, cf.IsProcedure, nil, /* outScope */
-			)
-			stmtScope = plBuilder.buildRootBlock(stmt.AST, bodyScope, routineParams)
-		})
-		checkStmtVolatility(targetVolatility, stmtScope, stmt)
+		// The body of a trigger function can only be built once the table it is
+		// attached to is known, since the types of the NEW and OLD records depend
+		// on it. It is built each time the trigger is planned instead.
+		if !isTriggerFunc {
+			// We need to disable stable function folding because we want to catch
+			// the volatility of stable functions. If folded, we only get a scalar
+			// and lose the volatility.
+			b.factory.FoldingControl().TemporarilyDisallowStableFolds(func() {
+				plBuilder := newPLpgSQLBuilder(
+					b, cf.Name.Object(), stmt.AST.Label, nil, /* colRefs */
+					routineParams, funcReturnType, cf.IsProcedure, isSetReturning, nil, /* outScope */
+				)
+				stmtScope = plBuilder.buildRootBlock(stmt.AST, bodyScope, routineParams)
+			})
+			checkStmtVolatility(targetVolatility, stmtScope, stmt)
+		}
 
 		// Format the statements with qualified datasource names.
 		formatFuncBodyStmt(fmtCtx, stmt.AST, language, false /* newLine */)
@@ -588,3 +644,23 @@ func checkDuplicateParamName(param tree.RoutineParam, seen map[tree.Name]struct{
 	}
 	seen[param.Name] = struct{}{}
 }
+
+// routineSearchPath returns the schemas of a SET search_path clause of a
+// routine, or nil if the clause does not specify them as constants.
+func routineSearchPath(n *tree.SetVar) []string {
+	paths := make([]string, 0, len(n.Values))
+	for _, expr := range n.Values {
+		switch t := expr.(type) {
+		case *tree.UnresolvedName:
+			paths = append(paths, tree.AsStringWithFlags(t, tree.FmtBareIdentifiers))
+		case *tree.StrVal:
+			paths = append(paths, t.RawString())
+		default:
+			return nil
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+	return paths
+}
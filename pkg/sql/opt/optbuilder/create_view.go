@@ -71,7 +71,11 @@ func (b *Builder) buildCreateView(cv *tree.CreateView, inScope *scope) (outScope
 		}
 	}()
 
-	defScope := b.buildStmtAtRoot(cv.AsSource, nil /* desiredTypes */)
+	asSource := cv.AsSource
+	if cv.Recursive {
+		asSource = desugarRecursiveView(cv)
+	}
+	defScope := b.buildStmtAtRoot(asSource, nil /* desiredTypes */)
 
 	p := defScope.makePhysicalProps().Presentation
 	if len(cv.ColumnNames) != 0 {
@@ -111,7 +115,7 @@ func (b *Builder) buildCreateView(cv *tree.CreateView, inScope *scope) (outScope
 		&memo.CreateViewPrivate{
 			Syntax:    cv,
 			Schema:    schID,
-			ViewQuery: tree.AsStringWithFlags(cv.AsSource, tree.FmtParsable),
+			ViewQuery: tree.AsStringWithFlags(asSource, tree.FmtParsable),
 			Columns:   p,
 			Deps:      b.schemaDeps,
 			TypeDeps:  b.schemaTypeDeps,
@@ -119,3 +123,42 @@ func (b *Builder) buildCreateView(cv *tree.CreateView, inScope *scope) (outScope
 	)
 	return outScope
 }
+
+// desugarRecursiveView returns the query of a view created with CREATE
+// RECURSIVE VIEW. Like in Postgres, the statement
+//
+//	CREATE RECURSIVE VIEW v (cols) AS <query>
+//
+// is equivalent to
+//
+//	CREATE VIEW v (cols) AS WITH RECURSIVE v (cols) AS (<query>) SELECT cols FROM v
+//
+// so the view is stored (and shown by SHOW CREATE) in the latter form.
+func desugarRecursiveView(cv *tree.CreateView) *tree.Select {
+	if len(cv.ColumnNames) == 0 {
+		panic(sqlerrors.NewSyntaxErrorf("CREATE RECURSIVE VIEW requires a column list"))
+	}
+	cols := make(tree.ColumnDefList, len(cv.ColumnNames))
+	exprs := make(tree.SelectExprs, len(cv.ColumnNames))
+	for i, name := range cv.ColumnNames {
+		cols[i] = tree.ColumnDef{Name: name}
+		exprs[i] = tree.SelectExpr{Expr: &tree.UnresolvedName{
+			NumParts: 1,
+			Parts:    tree.NameParts{string(name)},
+		}}
+	}
+	cteName := tree.MakeUnqualifiedTableName(cv.Name.ObjectName)
+	return &tree.Select{
+		With: &tree.With{
+			Recursive: true,
+			CTEList: []*tree.CTE{{
+				Name: tree.AliasClause{Alias: cv.Name.ObjectName, Cols: cols},
+				Stmt: cv.AsSource,
+			}},
+		},
+		Select: &tree.SelectClause{
+			Exprs: exprs,
+			From:  tree.From{Tables: tree.TableExprs{&cteName}},
+		},
+	}
+}
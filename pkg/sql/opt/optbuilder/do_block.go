@@ -0,0 +1,108 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/plpgsql"
+	plpgsqlparser "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// doBlockRoutineName is the name of the ephemeral routine that executes the
+// body of a DO statement. It matches the name used by Postgres.
+const doBlockRoutineName = "inline_code_block"
+
+// buildDoBlock builds a set of memo groups that represents the execution of an
+// anonymous code block. The code block is built as an ephemeral routine with
+// no parameters that returns VOID, and is invoked in the current transaction
+// in the same way as a procedure without OUT parameters.
+func (b *Builder) buildDoBlock(do *tree.DoBlock, inScope *scope) *scope {
+	switch do.Language {
+	case tree.RoutineLangPLpgSQL:
+	case tree.RoutineLangSQL, tree.RoutineLangC:
+		panic(pgerror.Newf(pgcode.FeatureNotSupported,
+			"language %q does not support inline code execution", strings.ToLower(string(do.Language))))
+	default:
+		panic(pgerror.Newf(pgcode.UndefinedObject, "language %q does not exist", do.Language))
+	}
+
+	// Disable memo reuse, since the body of the code block is not tracked in
+	// the metadata. See buildProcedure.
+	b.DisableMemoReuse = true
+	outScope := inScope.push()
+
+	if err := plpgsql.CheckClusterSupportsPLpgSQL(b.evalCtx.Settings); err != nil {
+		panic(err)
+	}
+	stmt, err := plpgsqlparser.Parse(do.Code)
+	if err != nil {
+		panic(err)
+	}
+
+	// Build the body of the code block. Start with an empty scope, since the
+	// code block cannot refer to anything from the outer context. See
+	// buildRoutine.
+	bodyScope := b.allocScope()
+	defer func(trackSchemaDeps, insideUDF, insideDataSource bool) {
+		b.trackSchemaDeps = trackSchemaDeps
+		b.insideUDF = insideUDF
+		b.insideDataSource = insideDataSource
+	}(b.trackSchemaDeps, b.insideUDF, b.insideDataSource)
+	b.insideDataSource = false
+	b.trackSchemaDeps = false
+	b.insideUDF = true
+
+	// Transaction control statements are not allowed, since the code block is
+	// executed within the current transaction.
+	plBuilder := newPLpgSQLBuilder(
+		b, doBlockRoutineName, stmt.AST.Label, nil /* colRefs */, nil /* routineParams */, types.Void,
+		false /* isProcedure */, false /* isSetReturning */, nil, /* outScope */
+	)
+	stmtScope := plBuilder.buildRootBlock(stmt.AST, bodyScope, nil /* routineParams */)
+	expr, physProps, _ := b.finishBuildLastStmt(
+		stmtScope, bodyScope, false /* isSetReturning */, false /* insideDataSource */, types.Void,
+	)
+	var bodyStmts []string
+	if b.verboseTracing {
+		bodyStmts = []string{stmt.String()}
+	}
+	routine := b.factory.ConstructUDFCall(
+		nil, /* args */
+		&memo.UDFCallPrivate{
+			Def: &memo.UDFDefinition{
+				Name:              doBlockRoutineName,
+				Typ:               types.Void,
+				Volatility:        volatility.Volatile,
+				CalledOnNullInput: true,
+				RoutineType:       tree.ProcedureRoutine,
+				RoutineLang:       tree.RoutineLangPLpgSQL,
+				Body:              []memo.RelExpr{expr},
+				BodyProps:         []*physical.Required{physProps},
+				BodyStmts:         bodyStmts,
+			},
+		},
+	)
+	routine = b.finishBuildScalar(nil /* texpr */, routine, inScope,
+		nil /* outScope */, nil /* outCol */)
+
+	// Build a call expression with no output columns.
+	callPrivate := &memo.CallPrivate{Columns: outScope.colList()}
+	outScope.expr = b.factory.ConstructCall(routine, callPrivate)
+	return outScope
+}
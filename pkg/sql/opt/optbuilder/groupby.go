@@ -43,9 +43,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // groupby information stored in scopes.
@@ -100,6 +103,33 @@ type groupby struct {
 	// It is used to ensure that the builder does not throw a grouping error
 	// prematurely.
 	buildingGroupingCols bool
+
+	// groupingSets contains the grouping sets of a GROUP BY clause with GROUPING
+	// SETS, ROLLUP or CUBE items. Each grouping set is the set of grouping
+	// columns in aggInScope that it contains. groupingSets is nil if there is
+	// only one grouping set, which is equivalent to a regular GROUP BY.
+	//
+	// When there are several grouping sets, the grouping columns that are not
+	// part of the grouping set of a row are NULL in the output, so each grouping
+	// column in aggInScope has a corresponding column with a different ID in
+	// aggOutScope. In that case, groupStrs maps to the aggOutScope columns.
+	groupingSets []opt.ColSet
+
+	// groupingFuncs contains information about the GROUPING function calls
+	// that reference the grouping sets.
+	groupingFuncs []groupingFuncInfo
+}
+
+// groupingFuncInfo stores information about a GROUPING function call that
+// references grouping sets. The result of the call only depends on the
+// grouping set of each row, so it is computed along with the grouping sets.
+type groupingFuncInfo struct {
+	// col is the output column of the call in aggOutScope.
+	col opt.ColumnID
+
+	// args contains the grouping columns in aggInScope that are passed to the
+	// call.
+	args opt.ColList
 }
 
 // groupByStrSet is a set of stringified GROUP BY expressions that map to the
@@ -133,6 +163,15 @@ func (g *groupby) groupingCols() []scopeColumn {
 	return g.aggInScope.cols[len(g.aggInScope.cols)-len(g.groupStrs):]
 }
 
+// groupingSetCols returns the columns in the aggOutScope corresponding to
+// grouping columns when there are several grouping sets, in the same order as
+// groupingCols.
+func (g *groupby) groupingSetCols() []scopeColumn {
+	// The grouping set cols are synthesized right after the grouping cols are
+	// built, so they always follow the aggregates.
+	return g.aggOutScope.cols[len(g.aggs) : len(g.aggs)+len(g.groupStrs)]
+}
+
 // getAggregateArgCols returns the columns in the aggInScope corresponding to
 // arguments to aggregate functions. If the aggregate has a filter, the column
 // corresponding to the filter's input will immediately follow the arguments.
@@ -250,6 +289,11 @@ func (a aggregateInfo) isOrderingSensitive() bool {
 	if a.isOrderedSetAggregate() {
 		return true
 	}
+	if a.def.Overload != nil && a.def.Overload.AggregateDef != nil {
+		// The state transition function of a user-defined aggregate may be
+		// sensitive to the order of its inputs.
+		return true
+	}
 	switch a.def.Name {
 	case "array_agg", "array_cat_agg", "concat_agg", "string_agg", "json_agg",
 		"jsonb_agg", "json_object_agg", "jsonb_object_agg", "st_makeline",
@@ -279,6 +323,57 @@ func (a *aggregateInfo) ResolvedType() *types.T {
 var _ tree.Expr = &aggregateInfo{}
 var _ tree.TypedExpr = &aggregateInfo{}
 
+// groupingInfo stores information about a GROUPING function call that has
+// been analyzed. It is replaced by a reference to a grouping set column or by
+// a constant when the call is built, since the grouping columns are not known
+// during analysis.
+type groupingInfo struct {
+	*tree.GroupingExpr
+
+	// args contains the type-checked arguments of the call.
+	args []tree.TypedExpr
+}
+
+// Walk is part of the tree.Expr interface.
+func (g *groupingInfo) Walk(v tree.Visitor) tree.Expr {
+	return g
+}
+
+// TypeCheck is part of the tree.Expr interface.
+func (g *groupingInfo) TypeCheck(
+	ctx context.Context, semaCtx *tree.SemaContext, desired *types.T,
+) (tree.TypedExpr, error) {
+	return g, nil
+}
+
+// Eval is part of the tree.TypedExpr interface.
+func (g *groupingInfo) Eval(_ context.Context, _ tree.ExprEvaluator) (tree.Datum, error) {
+	panic(errors.AssertionFailedf("groupingInfo must be replaced before evaluation"))
+}
+
+// ResolvedType is part of the tree.TypedExpr interface.
+func (g *groupingInfo) ResolvedType() *types.T {
+	return types.Int
+}
+
+var _ tree.Expr = &groupingInfo{}
+var _ tree.TypedExpr = &groupingInfo{}
+
+// result returns the result of the GROUPING function call for the rows of the
+// given grouping set. Each argument corresponds to a bit of the result, which
+// is set if the argument is not part of the grouping set. The last argument
+// corresponds to the least significant bit.
+func (f *groupingFuncInfo) result(set opt.ColSet) int64 {
+	var res int64
+	for _, col := range f.args {
+		res <<= 1
+		if !set.Contains(col) {
+			res |= 1
+		}
+	}
+	return res
+}
+
 func (b *Builder) needsAggregation(sel *tree.SelectClause, scope *scope) bool {
 	// We have an aggregation if:
 	//  - we have a GROUP BY, or
@@ -334,8 +429,29 @@ func (b *Builder) buildGroupingColumns(sel *tree.SelectClause, projectionsScope,
 	// The "from" columns are visible to any grouping expressions.
 	b.buildGroupingList(sel.GroupBy, sel.Exprs, projectionsScope, fromScope)
 
-	// Copy the grouping columns to the aggOutScope.
-	g.aggOutScope.appendColumns(g.groupingCols())
+	if g.groupingSets == nil {
+		// Copy the grouping columns to the aggOutScope.
+		g.aggOutScope.appendColumns(g.groupingCols())
+		return
+	}
+
+	// Synthesize the output columns of the grouping sets, which are computed by
+	// buildGroupingSetsInput. Grouping expressions in the SELECT list, HAVING
+	// and ORDER BY are resolved to these columns.
+	groupingCols := g.groupingCols()
+	for i := range groupingCols {
+		col := &groupingCols[i]
+		b.synthesizeColumn(g.aggOutScope, col.name, col.typ, col.expr, nil /* scalar */)
+	}
+	groupingSetCols := g.groupingSetCols()
+	for exprStr, col := range g.groupStrs {
+		for i := range groupingCols {
+			if col == &groupingCols[i] {
+				g.groupStrs[exprStr] = &groupingSetCols[i]
+				break
+			}
+		}
+	}
 }
 
 // buildAggregation builds the aggregation operators and constructs the
@@ -354,6 +470,10 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 	// If there are any aggregates that are ordering sensitive, build the
 	// aggregations as window functions over each group.
 	if g.hasNonCommutativeAggregates() {
+		if g.groupingSets != nil {
+			panic(unimplemented.New("grouping sets with ordered aggregates",
+				"ordering-sensitive aggregates are not supported with grouping sets"))
+		}
 		return b.buildAggregationAsWindow(groupingColSet, having, fromScope)
 	}
 
@@ -385,7 +505,7 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 
 		// Construct the aggregate function from its name and arguments and store
 		// it in the corresponding scope column.
-		aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		aggCols[i].scalar = b.constructAggregate(&agg.def, args)
 
 		// Wrap the aggregate function with an AggDistinct operator if DISTINCT
 		// was specified in the query.
@@ -430,8 +550,13 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 	// aggregate arguments, as well as any additional order by columns.
 	b.constructProjectForScope(fromScope, g.aggInScope)
 
+	input := g.aggInScope.expr
+	if g.groupingSets != nil {
+		input, groupingColSet = b.buildGroupingSetsInput(g, aggCols)
+	}
+
 	g.aggOutScope.expr = b.constructGroupBy(
-		g.aggInScope.expr,
+		input,
 		groupingColSet,
 		aggCols,
 		g.aggInScope.ordering,
@@ -447,6 +572,243 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 	return g.aggOutScope
 }
 
+// buildGroupingSetsInput builds the input of the GroupBy operator when there
+// are several grouping sets. Each row of the pre-projection is repeated for
+// every grouping set using a cross join with a Values operator, and the
+// grouping columns that are not part of the grouping set of the row are
+// replaced with NULL. For example:
+//
+//	SELECT a, b, count(*) FROM t GROUP BY ROLLUP (a, b)
+//
+//	pre-projection:  a, b
+//	grouping sets:   VALUES (0, true, true), (1, true, false), (2, false, false)
+//	                 AS (set, in_a, in_b)
+//	projection:      CASE WHEN in_a THEN a END (as col1),
+//	                 CASE WHEN in_b THEN b END (as col2)
+//	aggregation:     group by set, col1, col2, calculate count(*)
+//
+// The grouping set of each row is also a grouping column, so that rows from
+// different grouping sets are never aggregated together. The Values operator
+// also produces the result of each GROUPING function call, which only depends
+// on the grouping set.
+//
+// An empty grouping set must produce a single row even if the input is empty,
+// like a GROUP BY without grouping columns. If there is an empty grouping set,
+// the Values operator is left joined with the pre-projection instead, which
+// produces a NULL-extended row for every grouping set when the input is empty.
+// These rows are then filtered out for the non-empty grouping sets, and they are
+// excluded from the aggregations by a filter on a non-NULL marker column from
+// the pre-projection side. For example:
+//
+//	SELECT count(*) FROM t GROUP BY ROLLUP (a)
+//
+//	pre-projection:  a, true AS marker
+//	grouping sets:   VALUES (0, true, false), (1, false, true)
+//	                 AS (set, in_a, empty_set)
+//	join:            grouping sets LEFT JOIN pre-projection ON true
+//	filter:          marker IS NOT NULL OR empty_set
+//	aggregation:     group by set, col1, calculate count(*) FILTER (WHERE marker)
+//
+// Returns the input expression and the set of grouping columns for the GroupBy
+// operator. The aggregations in aggCols are updated to ignore the
+// NULL-extended rows if necessary.
+func (b *Builder) buildGroupingSetsInput(
+	g *groupby, aggCols []scopeColumn,
+) (memo.RelExpr, opt.ColSet) {
+	groupingCols := g.groupingCols()
+	groupingSetCols := g.groupingSetCols()
+
+	// Synthesize the columns of the Values operator: the ordinal of the grouping
+	// set, a boolean for each grouping column that indicates whether it is part
+	// of the grouping set, and the result of each GROUPING function call.
+	valuesScope := b.allocScope()
+	setCol := b.synthesizeColumn(
+		valuesScope, scopeColName("grouping_set"), types.Int, nil /* expr */, nil, /* scalar */
+	).id
+	inSetCols := make(opt.ColList, len(groupingCols))
+	for i := range groupingCols {
+		inSetCols[i] = b.synthesizeColumn(
+			valuesScope, scopeColName(""), types.Bool, nil /* expr */, nil, /* scalar */
+		).id
+	}
+	hasEmptySet := false
+	for _, set := range g.groupingSets {
+		if set.Empty() {
+			hasEmptySet = true
+			break
+		}
+	}
+	var emptySetCol opt.ColumnID
+	if hasEmptySet {
+		emptySetCol = b.synthesizeColumn(
+			valuesScope, scopeColName("empty_grouping_set"), types.Bool, nil /* expr */, nil, /* scalar */
+		).id
+	}
+	valuesCols := valuesScope.colList()
+	colTypes := make([]*types.T, 0, len(valuesCols)+len(g.groupingFuncs))
+	for i := range valuesScope.cols {
+		colTypes = append(colTypes, valuesScope.cols[i].typ)
+	}
+	for i := range g.groupingFuncs {
+		valuesCols = append(valuesCols, g.groupingFuncs[i].col)
+		colTypes = append(colTypes, types.Int)
+	}
+
+	tupleTyp := types.MakeTuple(colTypes)
+	rows := make(memo.ScalarListExpr, len(g.groupingSets))
+	for i, set := range g.groupingSets {
+		elems := make(memo.ScalarListExpr, 0, len(valuesCols))
+		elems = append(elems, b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(i)), types.Int))
+		for j := range groupingCols {
+			inSet := tree.MakeDBool(tree.DBool(set.Contains(groupingCols[j].id)))
+			elems = append(elems, b.factory.ConstructConstVal(inSet, types.Bool))
+		}
+		if hasEmptySet {
+			isEmpty := tree.MakeDBool(tree.DBool(set.Empty()))
+			elems = append(elems, b.factory.ConstructConstVal(isEmpty, types.Bool))
+		}
+		for j := range g.groupingFuncs {
+			res := tree.NewDInt(tree.DInt(g.groupingFuncs[j].result(set)))
+			elems = append(elems, b.factory.ConstructConstVal(res, types.Int))
+		}
+		rows[i] = b.factory.ConstructTuple(elems, tupleTyp)
+	}
+	values := b.factory.ConstructValues(rows, &memo.ValuesPrivate{
+		Cols: valuesCols,
+		ID:   b.factory.Metadata().NextUniqueID(),
+	})
+
+	var input memo.RelExpr
+	var markerCol opt.ColumnID
+	if hasEmptySet {
+		input, markerCol = b.buildEmptyGroupingSetsJoin(g, values, emptySetCol)
+	} else {
+		input = b.factory.ConstructInnerJoin(
+			g.aggInScope.expr, values, memo.TrueFilter, memo.EmptyJoinPrivate,
+		)
+	}
+
+	// Replace the grouping columns that are not part of the grouping set of each
+	// row with NULL. The aggregate arguments are passed through unchanged.
+	groupingColSet := opt.MakeColSet(setCol)
+	projections := make(memo.ProjectionsExpr, len(groupingCols), len(groupingCols)+len(aggCols))
+	for i := range groupingCols {
+		col := &groupingCols[i]
+		when := b.factory.ConstructWhen(
+			b.factory.ConstructVariable(inSetCols[i]), b.factory.ConstructVariable(col.id),
+		)
+		masked := b.factory.ConstructCase(
+			memo.TrueSingleton, memo.ScalarListExpr{when}, b.factory.ConstructNull(col.typ),
+		)
+		projections[i] = b.factory.ConstructProjectionsItem(masked, groupingSetCols[i].id)
+		groupingColSet.Add(groupingSetCols[i].id)
+	}
+	passthrough := g.aggInScope.colSet()
+	passthrough.Add(setCol)
+	for i := range g.groupingFuncs {
+		passthrough.Add(g.groupingFuncs[i].col)
+		groupingColSet.Add(g.groupingFuncs[i].col)
+	}
+
+	// Exclude the NULL-extended rows of the empty grouping sets from the
+	// aggregations. An existing filter is combined with the marker column in a
+	// new column.
+	if markerCol != 0 {
+		passthrough.Add(markerCol)
+		marker := b.factory.ConstructVariable(markerCol)
+		for i := range aggCols {
+			col := &aggCols[i]
+			if filter, ok := col.scalar.(*memo.AggFilterExpr); ok {
+				cond := b.factory.ConstructAnd(filter.Filter, marker)
+				filterCol := b.factory.Metadata().AddColumn("agg_filter", types.Bool)
+				projections = append(projections, b.factory.ConstructProjectionsItem(cond, filterCol))
+				col.scalar = b.factory.ConstructAggFilter(filter.Input, b.factory.ConstructVariable(filterCol))
+			} else {
+				col.scalar = b.factory.ConstructAggFilter(col.scalar, marker)
+			}
+		}
+	}
+	return b.factory.ConstructProject(input, projections, passthrough), groupingColSet
+}
+
+// buildEmptyGroupingSetsJoin left joins the given Values operator of grouping
+// sets with the pre-projection of the aggregation, so that the empty grouping
+// sets produce a row even if the input is empty. The NULL-extended rows of the
+// non-empty grouping sets are filtered out. Returns the join and a marker
+// column, which is NULL for the NULL-extended rows and true otherwise.
+func (b *Builder) buildEmptyGroupingSetsJoin(
+	g *groupby, values memo.RelExpr, emptySetCol opt.ColumnID,
+) (memo.RelExpr, opt.ColumnID) {
+	markerCol := b.factory.Metadata().AddColumn("grouping_input_row", types.Bool)
+	right := b.factory.ConstructProject(
+		g.aggInScope.expr,
+		memo.ProjectionsExpr{b.factory.ConstructProjectionsItem(memo.TrueSingleton, markerCol)},
+		g.aggInScope.colSet(),
+	)
+	join := b.factory.ConstructLeftJoin(values, right, memo.TrueFilter, memo.EmptyJoinPrivate)
+	filter := b.factory.ConstructOr(
+		b.factory.ConstructIsNot(b.factory.ConstructVariable(markerCol), memo.NullSingleton),
+		b.factory.ConstructVariable(emptySetCol),
+	)
+	return b.factory.ConstructSelect(
+		join, memo.FiltersExpr{b.factory.ConstructFiltersItem(filter)},
+	), markerCol
+}
+
+// buildGroupingFunc builds a GROUPING function call. Each argument must match
+// a GROUP BY expression. If there are several grouping sets, the call is built
+// as a reference to a new column that is produced along with the grouping sets
+// (see buildGroupingSetsInput). Otherwise, all the arguments are part of the
+// only grouping set, so the result is always zero.
+//
+// See Builder.buildStmt for a description of the remaining input and return
+// values.
+func (b *Builder) buildGroupingFunc(
+	f *groupingInfo, inScope, outScope *scope, outCol *scopeColumn, colRefs *opt.ColSet,
+) opt.ScalarExpr {
+	g := inScope.groupby
+	if g == nil || inScope.inAgg || g.buildingGroupingCols {
+		panic(errInvalidGroupingArgs)
+	}
+	cols := make([]*scopeColumn, len(f.args))
+	for i, arg := range f.args {
+		col, ok := g.groupStrs[symbolicExprStr(arg)]
+		if !ok {
+			panic(errInvalidGroupingArgs)
+		}
+		cols[i] = col
+	}
+
+	if g.groupingSets == nil {
+		zero := b.factory.ConstructConstVal(tree.NewDInt(0), types.Int)
+		return b.finishBuildScalar(f, zero, inScope, outScope, outCol)
+	}
+
+	// Translate the arguments to the grouping columns in aggInScope, which are
+	// the ones contained in the grouping sets.
+	info := groupingFuncInfo{args: make(opt.ColList, len(cols))}
+	groupingCols := g.groupingCols()
+	groupingSetCols := g.groupingSetCols()
+	for i, col := range cols {
+		for j := range groupingSetCols {
+			if groupingSetCols[j].id == col.id {
+				info.args[i] = groupingCols[j].id
+				break
+			}
+		}
+	}
+	info.col = b.synthesizeColumn(
+		g.aggOutScope, scopeColName("grouping"), types.Int, f, nil, /* scalar */
+	).id
+	g.groupingFuncs = append(g.groupingFuncs, info)
+	return b.finishBuildScalarRef(
+		&g.aggOutScope.cols[len(g.aggOutScope.cols)-1], g.aggOutScope, outScope, outCol, colRefs,
+	)
+}
+
+var errInvalidGroupingArgs = pgerror.New(pgcode.Grouping,
+	"arguments to GROUPING must be grouping expressions of the associated query level")
+
 // analyzeHaving analyzes the having clause and returns it as a typed
 // expression. fromScope contains the name bindings that are visible for this
 // HAVING clause (e.g., passed in from an enclosing statement).
@@ -510,15 +872,113 @@ func (b *Builder) buildGroupingList(
 	// used in an aggregate function`. The builder cannot know whether there is
 	// a grouping error until the grouping columns are fully built.
 	g.buildingGroupingCols = true
+	// Each GROUP BY expression produces a list of grouping sets, and the grouping
+	// sets of the GROUP BY clause are the cross product of these lists. Regular
+	// GROUP BY expressions produce a single grouping set.
+	sets := []opt.ColSet{{}}
 	for _, e := range groupBy {
-		b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+		exprSets := b.buildGroupingSets(e, selects, projectionsScope, fromScope, g.aggInScope)
+		if len(sets)*len(exprSets) > maxGroupingSets {
+			panic(errTooManyGroupingSets)
+		}
+		product := make([]opt.ColSet, 0, len(sets)*len(exprSets))
+		for _, set := range sets {
+			for _, exprSet := range exprSets {
+				product = append(product, set.Union(exprSet))
+			}
+		}
+		sets = product
 	}
 	g.buildingGroupingCols = false
+
+	if len(sets) > 1 {
+		g.groupingSets = sets
+	}
+}
+
+// maxGroupingSets is the maximum number of grouping sets that a GROUP BY clause
+// can produce. It matches the limit in Postgres.
+const maxGroupingSets = 4096
+
+// maxCubeElements is the maximum number of elements of a CUBE. It matches the
+// limit in Postgres.
+const maxCubeElements = 12
+
+var errTooManyGroupingSets = pgerror.Newf(pgcode.StatementTooComplex,
+	"too many grouping sets present (maximum %d)", maxGroupingSets)
+
+// buildGroupingSets builds the grouping columns of a GROUP BY expression, and
+// returns the grouping sets that it produces. Each grouping set is the set of
+// grouping columns in aggInScope that it contains. See buildGrouping for a
+// description of the parameters.
+//
+// A regular GROUP BY expression produces one grouping set, which contains the
+// columns of the expression. GROUPING SETS, ROLLUP and CUBE produce several
+// grouping sets, in the same order as Postgres. For example:
+//
+//	GROUPING SETS (a, (b, c), ()) => (a), (b, c), ()
+//	ROLLUP (a, b, c)              => (a, b, c), (a, b), (a), ()
+//	CUBE (a, b)                   => (a, b), (a), (b), ()
+func (b *Builder) buildGroupingSets(
+	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope, aggInScope *scope,
+) []opt.ColSet {
+	gs, ok := groupBy.(*tree.GroupingSet)
+	if !ok {
+		return []opt.ColSet{b.buildGrouping(groupBy, selects, projectionsScope, fromScope, aggInScope)}
+	}
+
+	switch gs.Type {
+	case tree.GroupingSets:
+		var sets []opt.ColSet
+		for _, e := range gs.Exprs {
+			sets = append(sets, b.buildGroupingSets(e, selects, projectionsScope, fromScope, aggInScope)...)
+			if len(sets) > maxGroupingSets {
+				panic(errTooManyGroupingSets)
+			}
+		}
+		return sets
+
+	case tree.Rollup:
+		sets := make([]opt.ColSet, len(gs.Exprs)+1)
+		for i, e := range gs.Exprs {
+			cols := b.buildGrouping(e, selects, projectionsScope, fromScope, aggInScope)
+			// The columns of the i-th expression are part of the grouping sets for
+			// all the prefixes that include it.
+			for j := 0; j < len(gs.Exprs)-i; j++ {
+				sets[j].UnionWith(cols)
+			}
+		}
+		return sets
+
+	case tree.Cube:
+		if len(gs.Exprs) > maxCubeElements {
+			panic(pgerror.Newf(pgcode.TooManyColumns,
+				"CUBE is limited to %d elements", maxCubeElements))
+		}
+		sets := make([]opt.ColSet, 1<<len(gs.Exprs))
+		for i, e := range gs.Exprs {
+			cols := b.buildGrouping(e, selects, projectionsScope, fromScope, aggInScope)
+			// Each grouping set corresponds to a subset of the expressions. The
+			// first expression corresponds to the most significant bit of the
+			// (inverted) index of the set, so that the larger sets come first.
+			bit := 1 << (len(gs.Exprs) - 1 - i)
+			for j := range sets {
+				if (len(sets)-1-j)&bit != 0 {
+					sets[j].UnionWith(cols)
+				}
+			}
+		}
+		return sets
+
+	default:
+		panic(errors.AssertionFailedf("unexpected grouping set type %s", gs.Type))
+	}
 }
 
 // buildGrouping builds a set of memo groups that represent a GROUP BY
 // expression. The expression (or expressions, if we have a star) is added to
-// groupStrs and to the aggInScope.
+// groupStrs and to the aggInScope. Returns the set of grouping columns in
+// aggInScope that correspond to the expression.
 //
 // groupBy          The given GROUP BY expression.
 // selects          The select expressions are needed in case the GROUP BY
@@ -538,7 +998,7 @@ func (b *Builder) buildGroupingList(
 //	as the aggregate function arguments.
 func (b *Builder) buildGrouping(
 	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope, aggInScope *scope,
-) {
+) (cols opt.ColSet) {
 	// Unwrap parenthesized expressions like "((a))" to "a".
 	groupBy = tree.StripParens(groupBy)
 	alias := ""
@@ -636,7 +1096,8 @@ func (b *Builder) buildGrouping(
 		// If a grouping column has already been added, don't add it again.
 		// GROUP BY a, a is semantically equivalent to GROUP BY a.
 		exprStr := symbolicExprStr(e)
-		if _, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+		if col, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+			cols.Add(col.id)
 			continue
 		}
 
@@ -646,7 +1107,9 @@ func (b *Builder) buildGrouping(
 		col := aggInScope.addColumn(scopeColName(tree.Name(alias)), e)
 		b.buildScalar(e, fromScope, aggInScope, col, nil)
 		fromScope.groupby.groupStrs[exprStr] = col
+		cols.Add(col.id)
 	}
+	return cols
 }
 
 // buildAggArg builds a scalar expression which is used as an input in some form
@@ -775,8 +1238,10 @@ func (b *Builder) buildAggregateFunction(
 	return &info
 }
 
-func (b *Builder) constructWindowFn(name string, args []opt.ScalarExpr) opt.ScalarExpr {
-	switch name {
+func (b *Builder) constructWindowFn(
+	def *memo.FunctionPrivate, args []opt.ScalarExpr,
+) opt.ScalarExpr {
+	switch def.Name {
 	case "rank":
 		return b.factory.ConstructRank()
 	case "row_number":
@@ -800,12 +1265,17 @@ func (b *Builder) constructWindowFn(name string, args []opt.ScalarExpr) opt.Scal
 	case "nth_value":
 		return b.factory.ConstructNthValue(args[0], args[1])
 	default:
-		return b.constructAggregate(name, args)
+		return b.constructAggregate(def, args)
 	}
 }
 
-func (b *Builder) constructAggregate(name string, args []opt.ScalarExpr) opt.ScalarExpr {
-	switch name {
+func (b *Builder) constructAggregate(
+	def *memo.FunctionPrivate, args []opt.ScalarExpr,
+) opt.ScalarExpr {
+	if def.Overload != nil && def.Overload.AggregateDef != nil {
+		return b.constructUserDefinedAgg(def, args)
+	}
+	switch def.Name {
 	case "array_agg":
 		return b.factory.ConstructArrayAgg(args[0])
 	case "array_cat_agg":
@@ -902,7 +1372,96 @@ func (b *Builder) constructAggregate(name string, args []opt.ScalarExpr) opt.Sca
 		return b.factory.ConstructMergeAggregatedStmtMetadata(args[0])
 	}
 
-	panic(errors.AssertionFailedf("unhandled aggregate: %s", name))
+	panic(errors.AssertionFailedf("unhandled aggregate: %s", def.Name))
+}
+
+// constructUserDefinedAgg constructs an aggregate created with CREATE
+// AGGREGATE. The support functions of the aggregate are built as calls over
+// synthesized parameter columns, which stand in for the state and the input
+// values during execution.
+func (b *Builder) constructUserDefinedAgg(
+	def *memo.FunctionPrivate, args []opt.ScalarExpr,
+) opt.ScalarExpr {
+	o := def.Overload
+	aggDef := o.AggregateDef
+	if err := b.catalog.CheckExecutionPrivilege(b.ctx, o.Oid); err != nil {
+		panic(err)
+	}
+	invocationTypes := make([]*types.T, len(args))
+	for i := range args {
+		invocationTypes[i] = args[i].DataType()
+	}
+	b.factory.Metadata().AddUserDefinedFunction(o, invocationTypes, nil /* name */)
+	if b.trackSchemaDeps {
+		b.schemaFunctionDeps.Add(int(o.Oid))
+	}
+
+	initState := tree.DNull
+	if aggDef.InitCond != nil {
+		var err error
+		initState, err = eval.PerformCast(
+			b.ctx, b.evalCtx, tree.NewDString(*aggDef.InitCond), aggDef.StateType,
+		)
+		if err != nil {
+			panic(err)
+		}
+	}
+	_, stateFunc, err := b.catalog.ResolveFunctionByOID(b.ctx, aggDef.StateFunc)
+	if err != nil {
+		panic(err)
+	}
+
+	udaDef := &memo.UserDefinedAggDefinition{
+		Name:            def.Name,
+		Typ:             o.FixedReturnType(),
+		StateType:       aggDef.StateType,
+		InitState:       initState,
+		StrictStateFunc: !stateFunc.CalledOnNullInput,
+		Volatility:      o.Volatility,
+	}
+
+	// Build the state transition function over the state column followed by
+	// one column for each argument.
+	stateScope := b.allocScope()
+	b.synthesizeColumn(stateScope, scopeColName("state"), aggDef.StateType, nil /* expr */, nil /* scalar */)
+	for i := range args {
+		b.synthesizeColumn(stateScope, scopeColName(""), invocationTypes[i], nil /* expr */, nil /* scalar */)
+	}
+	udaDef.StateParams = stateScope.colList()
+	udaDef.StateFunc = b.buildAggSupportFunc(aggDef.StateFunc, stateScope, stateScope.cols)
+	if aggDef.FinalFunc != 0 {
+		udaDef.FinalFunc = b.buildAggSupportFunc(aggDef.FinalFunc, stateScope, stateScope.cols[:1])
+	}
+
+	// Build the combine function over two partial states.
+	if aggDef.CombineFunc != 0 {
+		combineScope := b.allocScope()
+		b.synthesizeColumn(combineScope, scopeColName("state1"), aggDef.StateType, nil /* expr */, nil /* scalar */)
+		b.synthesizeColumn(combineScope, scopeColName("state2"), aggDef.StateType, nil /* expr */, nil /* scalar */)
+		udaDef.CombineParams = combineScope.colList()
+		udaDef.CombineFunc = b.buildAggSupportFunc(aggDef.CombineFunc, combineScope, combineScope.cols)
+	}
+
+	return b.factory.ConstructUserDefinedAgg(
+		memo.ScalarListExpr(args), &memo.UserDefinedAggPrivate{Def: udaDef},
+	)
+}
+
+// buildAggSupportFunc builds a call to the support function of a user-defined
+// aggregate with the given OID, passing the given columns as arguments.
+func (b *Builder) buildAggSupportFunc(
+	fnOID oid.Oid, inScope *scope, cols []scopeColumn,
+) opt.ScalarExpr {
+	exprs := make(tree.Exprs, len(cols))
+	for i := range cols {
+		exprs[i] = &cols[i]
+	}
+	call := &tree.FuncExpr{
+		Func:  tree.ResolvableFunctionReference{FunctionReference: &tree.FunctionOID{OID: fnOID}},
+		Exprs: exprs,
+	}
+	texpr := inScope.resolveType(call, types.Any)
+	return b.buildScalar(texpr, inScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
 }
 
 func isAggregate(def *tree.ResolvedFunctionDefinition) bool {
@@ -940,6 +1499,11 @@ func newGroupingError(name tree.Name) error {
 // In the unique index or unique without index cases, all key columns must be
 // marked as NOT NULL to allow the implicit grouping.
 func (b *Builder) allowImplicitGroupingColumn(colID opt.ColumnID, g *groupby) bool {
+	if g.groupingSets != nil {
+		// The grouping columns that are not part of a grouping set are NULL in the
+		// rows of that set, so they cannot determine any other column.
+		return false
+	}
 	md := b.factory.Metadata()
 	colMeta := md.ColumnMeta(colID)
 	if colMeta.Table == 0 {
@@ -968,6 +1532,10 @@ func (b *Builder) allowImplicitGroupingColumn(colID opt.ColumnID, g *groupby) bo
 	// Check UNIQUE WITHOUT INDEX constraints.
 	for i := 0; i < tab.UniqueCount(); i++ {
 		uniqueConstraint := tab.Unique(i)
+		if uniqueConstraint.IsExclusion() {
+			// Exclusion constraints do not imply uniqueness.
+			continue
+		}
 		var uniqueCols opt.ColSet
 		nullable := false
 		for j := 0; j < uniqueConstraint.ColumnCount(); j++ {
@@ -326,6 +326,16 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 			// derived from the primary index as the join condition.
 			mb.buildInputForUpsert(inScope, ins.Table, nil /* onConflict */, nil /* whereClause */)
 
+			// Invoke the row-level BEFORE UPDATE triggers for the rows that conflict
+			// with existing rows. The columns that are updated are the targets of
+			// the triggers.
+			for i := range mb.updateColIDs {
+				if mb.updateColIDs[i] != 0 {
+					mb.targetColSet.Add(mb.tabID.ColumnID(i))
+				}
+			}
+			mb.buildRowLevelBeforeTriggers(tree.TriggerEventUpdate)
+
 			// Add additional columns for computed expressions that may depend on any
 			// updated columns, as well as mutation columns with default values.
 			mb.addSynthesizedColsForUpdate()
@@ -376,6 +386,8 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 //     values specified for them.
 //  4. Each update value is the same as the corresponding insert value.
 //  5. There are no inbound foreign keys containing non-key columns.
+//  6. There are no triggers. Existing values are needed to determine whether
+//     the INSERT or the UPDATE triggers fire, and to provide the OLD row.
 //
 // TODO(andyk): The fast path is currently only enabled when the UPSERT alias
 // is explicitly selected by the user. It's possible to fast path some queries
@@ -387,6 +399,11 @@ func (mb *mutationBuilder) needExistingRows() bool {
 		return true
 	}
 
+	// #6: Triggers require the existing rows.
+	if tableHasTriggers(mb.tab) {
+		return true
+	}
+
 	// If there are any implicit partitioning columns in the primary index,
 	// these columns will need to be fetched.
 	primaryIndex := mb.tab.Index(cat.PrimaryIndex)
@@ -680,6 +697,11 @@ func (mb *mutationBuilder) addSynthesizedColsForInsert() {
 	// Add assignment casts for default column values.
 	mb.addAssignmentCasts(mb.insertColIDs)
 
+	// Invoke the row-level BEFORE INSERT triggers, which may modify the values
+	// of any column. Computed columns are added afterward, so that they reflect
+	// the modified values.
+	mb.buildRowLevelBeforeTriggers(tree.TriggerEventInsert)
+
 	// Now add all computed columns.
 	mb.addSynthesizedComputedCols(mb.insertColIDs, false /* restrict */)
 
@@ -704,11 +726,15 @@ func (mb *mutationBuilder) buildInsert(returning *tree.ReturningExprs) {
 
 	mb.buildFKChecksForInsert()
 
+	mb.buildAfterTriggers(tree.TriggerEventInsert)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructInsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fastPathUniqueChecks, mb.fkChecks, private,
 	)
 
+	mb.buildStatementLevelBeforeTriggers(tree.TriggerEventInsert)
+
 	mb.buildReturning(returning)
 }
 
@@ -935,11 +961,15 @@ func (mb *mutationBuilder) buildUpsert(returning *tree.ReturningExprs) {
 
 	mb.buildFKChecksForUpsert()
 
+	mb.buildAfterTriggersForUpsert()
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructUpsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
 	)
 
+	mb.buildStatementLevelBeforeTriggers(tree.TriggerEventInsert, tree.TriggerEventUpdate)
+
 	mb.buildReturning(returning)
 }
 
@@ -1214,6 +1214,14 @@ func (mb *mutationBuilder) parseDefaultExpr(colID opt.ColumnID) tree.Expr {
 	col := mb.tab.Column(ord)
 	exprStr := col.DefaultExprStr()
 
+	// Columns of a domain type without their own default use the default of
+	// the domain, if any.
+	if typ := col.DatumType(); exprStr == "" && typ.IsDomain() {
+		if d := typ.TypeMeta.DomainData; d != nil && d.DefaultExpr != nil {
+			exprStr = *d.DefaultExpr
+		}
+	}
+
 	// If no default expression, return NULL or a default value.
 	if exprStr == "" {
 		if col.IsMutation() && !col.IsNullable() {
@@ -1418,15 +1426,32 @@ func (mb *mutationBuilder) addAssignmentCasts(srcCols opt.OptionalColList) {
 			continue
 		}
 
-		// Check if an assignment cast is available from the inScope column
-		// type to the out type.
-		if !cast.ValidCast(srcType, targetType, cast.ContextAssignment) {
-			panic(sqlerrors.NewInvalidAssignmentCastError(srcType, targetType, string(targetCol.ColName())))
-		}
+		// A cast created with CREATE CAST ... AS ASSIGNMENT or AS IMPLICIT is
+		// performed by calling its function on the column.
+		var castExpr opt.ScalarExpr
+		if udc, ok := mb.lookupUserDefinedAssignmentCast(srcType, targetType); ok {
+			fn := &tree.FuncExpr{
+				Func: tree.ResolvableFunctionReference{
+					FunctionReference: &tree.FunctionOID{OID: udc.Func},
+				},
+				Exprs: tree.Exprs{mb.outScope.getColumn(colID)},
+			}
+			texpr := mb.outScope.resolveAndRequireType(fn, targetType)
+			castExpr = mb.b.buildScalar(texpr, mb.outScope, nil, nil, nil)
+			if !texpr.ResolvedType().Identical(targetType) {
+				castExpr = mb.b.factory.ConstructAssignmentCast(castExpr, targetType)
+			}
+		} else {
+			// Check if an assignment cast is available from the inScope column
+			// type to the out type.
+			if !cast.ValidCast(srcType, targetType, cast.ContextAssignment) {
+				panic(sqlerrors.NewInvalidAssignmentCastError(srcType, targetType, string(targetCol.ColName())))
+			}
 
-		// Create the cast expression.
-		variable := mb.b.factory.ConstructVariable(colID)
-		cast := mb.b.factory.ConstructAssignmentCast(variable, targetType)
+			// Create the cast expression.
+			variable := mb.b.factory.ConstructVariable(colID)
+			castExpr = mb.b.factory.ConstructAssignmentCast(variable, targetType)
+		}
 
 		// Lazily create the new scope.
 		if projectionScope == nil {
@@ -1441,7 +1466,7 @@ func (mb *mutationBuilder) addAssignmentCasts(srcCols opt.OptionalColList) {
 		// column, we perform a lookup with the ID and the name. See #61520.
 		scopeCol := projectionScope.getColumnWithIDAndReferenceName(colID, targetCol.ColName())
 		scopeCol.name = scopeCol.name.WithMetadataName(fmt.Sprintf("%s_cast", targetCol.ColName()))
-		mb.b.populateSynthesizedColumn(scopeCol, cast)
+		mb.b.populateSynthesizedColumn(scopeCol, castExpr)
 
 		// Replace old source column with the new one.
 		srcCols[ord] = scopeCol.id
@@ -1453,6 +1478,21 @@ func (mb *mutationBuilder) addAssignmentCasts(srcCols opt.OptionalColList) {
 	}
 }
 
+// lookupUserDefinedAssignmentCast returns the user-defined cast from the source
+// type to the target type, if one exists and it can be performed in an
+// assignment context.
+func (mb *mutationBuilder) lookupUserDefinedAssignmentCast(
+	srcType, targetType *types.T,
+) (_ cast.Cast, ok bool) {
+	c, ok, err := cast.LookupUserDefinedCast(
+		mb.b.ctx, mb.b.semaCtx.UserDefinedCastResolver(), srcType, targetType,
+	)
+	if err != nil {
+		panic(err)
+	}
+	return c, ok && c.MaxContext >= cast.ContextAssignment
+}
+
 // partialIndexCount returns the number of public, write-only, and delete-only
 // partial indexes defined on the table.
 func partialIndexCount(tab cat.Table) int {
@@ -14,10 +14,12 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
@@ -211,9 +213,14 @@ type uniqueCheckHelper struct {
 	uniqueOrdinals intsets.Fast
 
 	// primaryKeyOrdinals includes the ordinals from any primary key columns
-	// that are not included in uniqueOrdinals.
+	// that are not compared with equality by the constraint.
 	primaryKeyOrdinals intsets.Fast
 
+	// exclusionOps maps the table ordinals of the columns of an exclusion
+	// constraint that are not compared with equality to their operators. It is
+	// nil for unique constraints.
+	exclusionOps map[int]treecmp.ComparisonOperatorSymbol
+
 	// The scope and column ordinals of the scan that will serve as the right
 	// side of the semi join for the uniqueness checks.
 	scanScope    *scope
@@ -233,20 +240,33 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 		uniqueOrdinal: uniqueOrdinal,
 	}
 
-	var uniqueOrds intsets.Fast
+	// eqOrds are the ordinals of the columns compared with equality. For unique
+	// constraints, this includes all of the columns. Only these columns can be
+	// used to determine that a check is not needed below.
+	var uniqueOrds, eqOrds intsets.Fast
 	for i, n := 0, h.unique.ColumnCount(); i < n; i++ {
-		uniqueOrds.Add(h.unique.ColumnOrdinal(mb.tab, i))
+		ord := h.unique.ColumnOrdinal(mb.tab, i)
+		uniqueOrds.Add(ord)
+		if op := h.unique.ExclusionOperator(i); op != treecmp.EQ {
+			if h.exclusionOps == nil {
+				h.exclusionOps = make(map[int]treecmp.ComparisonOperatorSymbol)
+			}
+			h.exclusionOps[ord] = op
+		} else {
+			eqOrds.Add(ord)
+		}
 	}
 
-	// Find the primary key columns that are not part of the unique constraint.
-	// If there aren't any, we don't need a check.
+	// Find the primary key columns that are not part of the unique constraint
+	// (or not compared with equality by an exclusion constraint). If there
+	// aren't any, we don't need a check.
 	// TODO(mgartner): We also don't need a check if there exists a unique index
 	// with columns that are a subset of the unique constraint columns.
 	// Similarly, we don't need a check for a partial unique constraint if there
 	// exists a non-partial unique constraint with columns that are a subset of
 	// the partial unique constraint columns.
 	primaryOrds := getIndexLaxKeyOrdinals(mb.tab.Index(cat.PrimaryIndex))
-	primaryOrds.DifferenceWith(uniqueOrds)
+	primaryOrds.DifferenceWith(eqOrds)
 	if primaryOrds.Empty() {
 		// The primary key columns are a subset of the unique columns; unique check
 		// not needed.
@@ -270,7 +290,7 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 		// gen_random_uuid(), unique check not needed.
 		switch mb.md.ColumnMeta(colID).Type.Family() {
 		case types.UuidFamily, types.StringFamily, types.BytesFamily:
-			if columnIsGenRandomUUID(mb.outScope.expr, colID) {
+			if eqOrds.Contains(tabOrd) && columnIsGenRandomUUID(mb.outScope.expr, colID) {
 				requireCheck := UniquenessChecksForGenRandomUUIDClusterMode.Get(&mb.b.evalCtx.Settings.SV)
 				if !requireCheck {
 					return false
@@ -307,7 +327,7 @@ func (h *uniqueCheckHelper) init(mb *mutationBuilder, uniqueOrdinal int) bool {
 	// presence of the unique index on (region, k) (i.e., the primary index) is
 	// sufficient to guarantee the uniqueness of k.
 	var uniqueCols opt.ColSet
-	h.uniqueOrdinals.ForEach(func(ord int) {
+	eqOrds.ForEach(func(ord int) {
 		colID := h.scanScope.cols[ord].id
 		uniqueCols.Add(colID)
 	})
@@ -445,6 +465,11 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 	// Build the join filters:
 	//   (new_a = existing_a) AND (new_b = existing_b) AND ...
 	//
+	// For exclusion constraints, the columns are compared with the operators
+	// of the constraint instead, e.g. (new_a = existing_a) AND
+	// (new_b && existing_b). Filters with these operators can still be
+	// evaluated with an index, such as an inverted join on a spatial index.
+	//
 	// Set the capacity to h.uniqueOrdinals.Len()+1 since we'll have an equality
 	// condition for each column in the unique constraint, plus one additional
 	// condition to prevent rows from matching themselves (see below). If the
@@ -458,7 +483,8 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 	semiJoinFilters := make(memo.FiltersExpr, 0, numFilters)
 	for i, ok := h.uniqueOrdinals.Next(0); ok; i, ok = h.uniqueOrdinals.Next(i + 1) {
 		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(
-			f.ConstructEq(
+			h.constructConflictComparison(
+				i,
 				f.ConstructVariable(uniqueCheckScope.cols[i].id),
 				f.ConstructVariable(h.scanScope.cols[i].id),
 			),
@@ -481,8 +507,8 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 		scanExpr, foundScan = possibleScan.(*memo.ScanExpr)
 
 		// Fast path is disabled if this check is for a UNIQUE WITHOUT INDEX with a
-		// partial index predicate.
-		if foundScan && !isPartial {
+		// partial index predicate, or for an exclusion constraint.
+		if foundScan && !isPartial && !h.unique.IsExclusion() {
 			scanFilters = h.buildFiltersForFastPathCheck(uniqueCheckExpr, uniqueCheckCols, scanExpr)
 		}
 	}
@@ -585,6 +611,34 @@ func (h *uniqueCheckHelper) buildInsertionCheck(
 	return uniqueChecks, &fastPathChecks
 }
 
+// constructConflictComparison builds the comparison of the column with the
+// given table ordinal between a new row and an existing row. The rows conflict
+// if the comparisons of all of the columns in the constraint are true. Only the
+// operators accepted by schemaexpr.ValidateExclusionOperator, which is checked
+// when exclusion constraints are created, are supported.
+func (h *uniqueCheckHelper) constructConflictComparison(
+	ord int, left, right opt.ScalarExpr,
+) opt.ScalarExpr {
+	f := h.mb.b.factory
+	op, ok := h.exclusionOps[ord]
+	if !ok {
+		return f.ConstructEq(left, right)
+	}
+	switch op {
+	case treecmp.NE:
+		return f.ConstructNe(left, right)
+	case treecmp.Overlaps:
+		switch left.DataType().Family() {
+		case types.GeometryFamily, types.Box2DFamily:
+			// The && operator means "intersects" when used with geometry or bounding
+			// box operands.
+			return f.ConstructBBoxIntersects(left, right)
+		}
+		return f.ConstructOverlaps(left, right)
+	}
+	panic(schemaexpr.NewUnsupportedExclusionOperatorError(op))
+}
+
 // buildTableScan builds a Scan of the table. The ordinals of the columns
 // scanned are also returned.
 func (h *uniqueCheckHelper) buildTableScan() (outScope *scope, ordinals []int) {
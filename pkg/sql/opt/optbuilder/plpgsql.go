@@ -13,6 +13,7 @@ package optbuilder
 import (
 	"context"
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
@@ -25,6 +26,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
 	ast "github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treebin"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
@@ -174,6 +177,16 @@ type plpgsqlBuilder struct {
 	// building their body statements.
 	outScope *scope
 
+	// resultBuffer is set if the routine is set-returning. It is shared between
+	// the routine and the continuations that implement its RETURN NEXT and
+	// RETURN QUERY statements, which add rows to the routine's result.
+	resultBuffer *tree.RoutineResultBuffer
+
+	// loopVars is the set of declarations of integer FOR loop variables, which
+	// are implicitly declared by rewriteIntForLoop. Unlike explicit
+	// declarations, they may shadow a variable of an ancestor block.
+	loopVars map[*ast.Declaration]struct{}
+
 	routineName  string
 	isProcedure  bool
 	identCounter int
@@ -192,7 +205,7 @@ func newPLpgSQLBuilder(
 	colRefs *opt.ColSet,
 	routineParams []routineParam,
 	returnType *types.T,
-	isProcedure bool,
+	isProcedure, isSetReturning bool,
 	outScope *scope,
 ) *plpgsqlBuilder {
 	const initialBlocksCap = 2
@@ -205,6 +218,9 @@ func newPLpgSQLBuilder(
 		isProcedure: isProcedure,
 		outScope:    outScope,
 	}
+	if isSetReturning {
+		b.resultBuffer = &tree.RoutineResultBuffer{}
+	}
 	// Build the initial block for the routine parameters, which are considered
 	// PL/pgSQL variables.
 	b.pushBlock(plBlock{
@@ -216,7 +232,7 @@ func newPLpgSQLBuilder(
 		if param.name != "" {
 			// TODO(119502): unnamed parameters can only be accessed via $i
 			// notation.
-			b.addVariable(param.name, param.typ)
+			b.addVariable(param.name, param.typ, false /* allowShadowing */)
 		}
 		if tree.IsOutParamClass(param.class) {
 			b.outParams = append(b.outParams, param.name)
@@ -276,10 +292,10 @@ func (b *plpgsqlBuilder) buildRootBlock(
 	// Initialize OUT parameters to NULL. Note that the initial block for
 	// parameters was already created in newPLpgSQLBuilder().
 	for _, param := range routineParams {
-		if param.class != tree.RoutineParamOut || param.name == "" {
+		if (param.class != tree.RoutineParamOut && param.class != tree.RoutineParamTable) || param.name == "" {
 			continue
 		}
-		s = b.addPLpgSQLAssign(s, param.name, &tree.CastExpr{Expr: tree.DNull, Type: param.typ})
+		s = b.addPLpgSQLAssign(s, param.name, "" /* indirection */, &tree.CastExpr{Expr: tree.DNull, Type: param.typ})
 	}
 	if b.isProcedure {
 		var tc transactionControlVisitor
@@ -340,13 +356,17 @@ func (b *plpgsqlBuilder) buildBlock(astBlock *ast.Block, s *scope) *scope {
 			if types.IsRecordType(typ) {
 				panic(recordVarErr)
 			}
-			b.addVariable(dec.Var, typ)
+			_, allowShadowing := b.loopVars[dec]
+			if allowShadowing {
+				s = b.shadowVariable(s, dec.Var)
+			}
+			b.addVariable(dec.Var, typ, allowShadowing)
 			if dec.Expr != nil {
 				// Some variable declarations initialize the variable.
-				s = b.addPLpgSQLAssign(s, dec.Var, dec.Expr)
+				s = b.addPLpgSQLAssign(s, dec.Var, "" /* indirection */, dec.Expr)
 			} else {
 				// Uninitialized variables are null.
-				s = b.addPLpgSQLAssign(s, dec.Var, &tree.CastExpr{Expr: tree.DNull, Type: typ})
+				s = b.addPLpgSQLAssign(s, dec.Var, "" /* indirection */, &tree.CastExpr{Expr: tree.DNull, Type: typ})
 			}
 			if dec.Constant {
 				// Add to the constants map after initializing the variable, since
@@ -355,8 +375,8 @@ func (b *plpgsqlBuilder) buildBlock(astBlock *ast.Block, s *scope) *scope {
 			}
 		case *ast.CursorDeclaration:
 			// Declaration of a bound cursor declares a variable of type refcursor.
-			b.addVariable(dec.Name, types.RefCursor)
-			s = b.addPLpgSQLAssign(s, dec.Name, &tree.CastExpr{Expr: tree.DNull, Type: types.RefCursor})
+			b.addVariable(dec.Name, types.RefCursor, false /* allowShadowing */)
+			s = b.addPLpgSQLAssign(s, dec.Name, "" /* indirection */, &tree.CastExpr{Expr: tree.DNull, Type: types.RefCursor})
 			block.cursors[dec.Name] = *dec
 		}
 	}
@@ -417,7 +437,14 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			// statement must have no expression. Otherwise, the RETURN statement must
 			// have a non-empty expression.
 			expr := t.Expr
-			if b.hasOutParam() {
+			if b.isSetReturning() {
+				// The result of a set-returning routine is built by RETURN NEXT and
+				// RETURN QUERY statements, so RETURN only ends execution.
+				if expr != nil {
+					panic(returnWithSetOfParameterErr)
+				}
+				expr = tree.DNull
+			} else if b.hasOutParam() {
 				if expr != nil {
 					panic(returnWithOUTParameterErr)
 				}
@@ -448,7 +475,7 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 		case *ast.Assignment:
 			// Assignment (:=) is handled by projecting a new column with the same
 			// name as the variable being assigned.
-			s = b.addPLpgSQLAssign(s, t.Var, t.Value)
+			s = b.addPLpgSQLAssign(s, t.Var, t.Indirection, t.Value)
 			if b.hasExceptionHandler() {
 				// If exception handling is required, we have to start a new
 				// continuation after each variable assignment. This ensures that in the
@@ -573,6 +600,32 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			}
 			return b.buildPLpgSQLStatements(b.prependStmt(loop, stmts[i+1:]), s)
 
+		case *ast.ForLoop:
+			// A FOR loop is rewritten into a LOOP within a nested block, which
+			// declares the variables that track the state of the loop. See the
+			// rewriteIntForLoop and rewriteQueryForLoop comments for details.
+			var block *ast.Block
+			switch c := t.Control.(type) {
+			case *ast.IntForLoopControl:
+				if len(t.Target) != 1 {
+					panic(pgerror.New(pgcode.Syntax,
+						"integer FOR loop must have only one target variable",
+					))
+				}
+				block = b.rewriteIntForLoop(t.Label, t.Target[0], c, t.Body)
+			case *ast.QueryForLoopControl:
+				block = b.rewriteQueryForLoop(t, c, s)
+			default:
+				panic(errors.AssertionFailedf("unexpected FOR loop control: %T", c))
+			}
+			return b.buildPLpgSQLStatements(b.prependStmt(block, stmts[i+1:]), s)
+
+		case *ast.ForEachArray:
+			// A FOREACH loop is rewritten into an integer FOR loop over the indexes
+			// of the array. See the rewriteForEachArray comment for details.
+			block := b.rewriteForEachArray(t, s)
+			return b.buildPLpgSQLStatements(b.prependStmt(block, stmts[i+1:]), s)
+
 		case *ast.Exit:
 			if t.Condition != nil {
 				// EXIT with a condition is syntactic sugar for EXIT inside an IF stmt.
@@ -657,6 +710,53 @@ func (b *plpgsqlBuilder) buildPLpgSQLStatements(stmts []ast.Statement, s *scope)
 			b.appendPlpgSQLStmts(&con, stmts[i+1:])
 			return b.callContinuation(&con, s)
 
+		case *ast.ReturnNext:
+			// RETURN NEXT adds a row to the result of a set-returning routine, and
+			// then continues execution with the following statements. Similar to
+			// RAISE, this is handled by building a separate body statement for the
+			// row. During execution, the result of this body statement is added to
+			// the output of the set-returning routine, rather than discarded.
+			if !b.isSetReturning() {
+				panic(returnNextNonSetOfErr)
+			}
+			expr := t.Expr
+			if b.hasOutParam() {
+				if expr != nil {
+					panic(returnNextWithOUTParameterErr)
+				}
+				expr = b.makeReturnForOutParams()
+			} else if expr == nil {
+				panic(emptyReturnNextErr)
+			}
+			con := b.makeContinuation("_stmt_return_next")
+			con.def.Volatility = volatility.Volatile
+			con.def.ReturnNextBuffer = b.resultBuffer
+			returnScalar := b.buildPLpgSQLExpr(expr, b.returnType, con.s)
+			b.appendBodyStmt(&con, b.buildReturnNextScope(con.s, returnScalar))
+			b.appendPlpgSQLStmts(&con, stmts[i+1:])
+			return b.callContinuation(&con, s)
+
+		case *ast.ReturnQuery:
+			// RETURN QUERY adds the rows returned by a query to the result of a
+			// set-returning routine. It is handled in the same way as RETURN NEXT,
+			// except that the body statement may produce any number of rows.
+			if !b.isSetReturning() {
+				panic(returnQueryNonSetOfErr)
+			}
+			con := b.makeContinuation("_stmt_return_query")
+			con.def.Volatility = volatility.Volatile
+			con.def.ReturnNextBuffer = b.resultBuffer
+			stmtScope := b.ob.buildStmtAtRootWithScope(t.SqlStmt, nil /* desiredTypes */, con.s)
+			b.appendBodyStmt(&con, b.buildReturnQueryScope(stmtScope))
+			b.appendPlpgSQLStmts(&con, stmts[i+1:])
+			return b.callContinuation(&con, s)
+
+		case *ast.Perform:
+			// PERFORM executes a query and discards its result, so it is handled in
+			// the same way as a SQL statement with no INTO clause.
+			execStmt := &ast.Execute{SqlStmt: t.SqlStmt}
+			return b.buildPLpgSQLStatements(b.prependStmt(execStmt, stmts[i+1:]), s)
+
 		case *ast.Execute:
 			if _, ok := t.SqlStmt.(*tree.SetTransaction); ok {
 				// SET TRANSACTION must happen immediately after a COMMIT or ROLLBACK
@@ -1056,7 +1156,12 @@ func (b *plpgsqlBuilder) buildCursorNameGen(nameCon *continuation, nameVar ast.V
 // new column with the variable name that projects the assigned expression.
 // If there is a column with the same name in the previous scope, it will be
 // replaced. This allows the plpgsqlBuilder to model variable mutations.
-func (b *plpgsqlBuilder) addPLpgSQLAssign(inScope *scope, ident ast.Variable, val ast.Expr) *scope {
+//
+// If indirection is set, only the field of the record variable with that name
+// is assigned; see buildPLpgSQLFieldAssign.
+func (b *plpgsqlBuilder) addPLpgSQLAssign(
+	inScope *scope, ident ast.Variable, indirection tree.Name, val ast.Expr,
+) *scope {
 	typ := b.resolveVariableForAssign(ident)
 	assignScope := inScope.push()
 	for i := range inScope.cols {
@@ -1071,13 +1176,50 @@ func (b *plpgsqlBuilder) addPLpgSQLAssign(inScope *scope, ident ast.Variable, va
 	}
 	// Project the assignment as a new column.
 	colName := scopeColName(ident)
-	scalar := b.buildPLpgSQLExpr(val, typ, inScope)
+	var scalar opt.ScalarExpr
+	if indirection != "" {
+		scalar = b.buildPLpgSQLFieldAssign(ident, typ, indirection, val, inScope)
+	} else {
+		scalar = b.buildPLpgSQLExpr(val, typ, inScope)
+	}
 	b.addBarrierIfVolatile(inScope, scalar)
 	b.ob.synthesizeColumn(assignScope, colName, typ, nil, scalar)
 	b.ob.constructProjectForScope(inScope, assignScope)
 	return assignScope
 }
 
+// buildPLpgSQLFieldAssign builds an expression that produces a copy of the
+// given record variable in which the named field has been replaced by the
+// given value. It is used for assignments like "NEW.a := 1".
+func (b *plpgsqlBuilder) buildPLpgSQLFieldAssign(
+	ident ast.Variable, typ *types.T, field tree.Name, val ast.Expr, inScope *scope,
+) opt.ScalarExpr {
+	if typ.Family() != types.TupleFamily || types.IsWildcardTupleType(typ) {
+		panic(pgerror.Newf(pgcode.Syntax, "\"%s.%s\" is not a known variable", ident, field))
+	}
+	fieldIdx := -1
+	for i, label := range typ.TupleLabels() {
+		if label == string(field) {
+			fieldIdx = i
+			break
+		}
+	}
+	if fieldIdx == -1 {
+		panic(pgerror.Newf(pgcode.UndefinedColumn,
+			"record \"%s\" has no field \"%s\"", ident, field))
+	}
+	record := b.buildPLpgSQLExpr(tree.NewUnresolvedName(string(ident)), typ, inScope)
+	elems := make(memo.ScalarListExpr, len(typ.TupleContents()))
+	for i, fieldTyp := range typ.TupleContents() {
+		if i == fieldIdx {
+			elems[i] = b.buildPLpgSQLExpr(val, fieldTyp, inScope)
+			continue
+		}
+		elems[i] = b.ob.factory.ConstructColumnAccess(record, memo.TupleOrdinal(i))
+	}
+	return b.ob.factory.ConstructTuple(elems, typ)
+}
+
 // buildInto handles the mapping from the columns of a SQL statement to the
 // variables in an INTO target.
 func (b *plpgsqlBuilder) buildInto(stmtScope *scope, target []ast.Variable) *scope {
@@ -1433,11 +1575,12 @@ func (b *plpgsqlBuilder) buildExceptions(block *ast.Block) *memo.ExceptionBlock
 // handleEndOfFunction handles the case when control flow reaches the end of a
 // PL/pgSQL routine without reaching a RETURN statement.
 func (b *plpgsqlBuilder) handleEndOfFunction(inScope *scope) *scope {
-	if b.hasOutParam() || b.returnType.Family() == types.VoidFamily {
+	if b.hasOutParam() || b.returnType.Family() == types.VoidFamily || b.isSetReturning() {
 		// Routines with OUT-parameters and VOID return types need not explicitly
-		// specify a RETURN statement.
+		// specify a RETURN statement. Neither do set-returning routines, since
+		// their result is built by RETURN NEXT and RETURN QUERY statements.
 		var returnExpr tree.Expr = tree.DNull
-		if b.hasOutParam() {
+		if b.hasOutParam() && !b.isSetReturning() {
 			returnExpr = b.makeReturnForOutParams()
 		}
 		returnScope := inScope.push()
@@ -1637,6 +1780,379 @@ func (b *plpgsqlBuilder) projectRecordVar(s *scope, name ast.Variable) *scope {
 	return recordScope
 }
 
+// rewriteIntForLoop rewrites an integer FOR loop into a LOOP within a nested
+// block. The block declares variables for the loop bounds, which are only
+// evaluated once:
+//
+//	FOR i IN [REVERSE] [lower]..[upper] BY [step] LOOP
+//	  [body];
+//	END LOOP;
+//	=>
+//	DECLARE
+//	  i INT;
+//	  _for_counter INT := [lower];
+//	  _for_upper INT := [upper];
+//	  _for_step INT := [step];
+//	BEGIN
+//	  IF _for_counter IS NULL THEN
+//	    RAISE EXCEPTION 'lower bound of FOR loop cannot be null';
+//	  ELSIF ...
+//	  END IF;
+//	  LOOP
+//	    EXIT WHEN _for_counter IS NULL OR _for_counter > _for_upper;
+//	    i := _for_counter;
+//	    IF _for_counter > 9223372036854775807 - _for_step THEN
+//	      _for_counter := NULL;
+//	    ELSE
+//	      _for_counter := _for_counter + _for_step;
+//	    END IF;
+//	    [body];
+//	  END LOOP;
+//	END;
+//
+// For a REVERSE loop, the counter is decremented instead, and the loop exits
+// once the counter is less than the upper bound. Advancing the counter before
+// executing the body ensures that neither CONTINUE statements nor assignments
+// to the loop variable affect the iteration. If advancing the counter would
+// overflow, the current iteration is the last one; as in Postgres, the loop
+// then ends cleanly instead of raising an error.
+//
+// As in Postgres, the loop variable is a new variable of the block, which
+// shadows any existing variable with the same name.
+func (b *plpgsqlBuilder) rewriteIntForLoop(
+	label string, loopVar ast.Variable, control *ast.IntForLoopControl, body []ast.Statement,
+) *ast.Block {
+	counter := ast.Variable(b.makeIdentifier("_for_counter"))
+	upper := ast.Variable(b.makeIdentifier("_for_upper"))
+	step := ast.Variable(b.makeIdentifier("_for_step"))
+	var stepExpr ast.Expr = tree.NewDInt(1)
+	if control.Step != nil {
+		stepExpr = control.Step
+	}
+	loopVarDecl := &ast.Declaration{Var: loopVar, Typ: types.Int}
+	if b.loopVars == nil {
+		b.loopVars = make(map[*ast.Declaration]struct{})
+	}
+	b.loopVars[loopVarDecl] = struct{}{}
+	decls := []ast.Statement{
+		loopVarDecl,
+		&ast.Declaration{Var: counter, Typ: types.Int, Expr: control.Lower},
+		&ast.Declaration{Var: upper, Typ: types.Int, Expr: control.Upper},
+		&ast.Declaration{Var: step, Typ: types.Int, Expr: stepExpr},
+	}
+
+	// Check that the loop bounds are valid.
+	checks := &ast.If{
+		Condition: &tree.IsNullExpr{Expr: makeVarRef(counter)},
+		ThenBody: []ast.Statement{makeInternalRaise(
+			pgcode.NullValueNotAllowed, "lower bound of FOR loop cannot be null",
+		)},
+		ElseIfList: []ast.ElseIf{{
+			Condition: &tree.IsNullExpr{Expr: makeVarRef(upper)},
+			Stmts: []ast.Statement{makeInternalRaise(
+				pgcode.NullValueNotAllowed, "upper bound of FOR loop cannot be null",
+			)},
+		}},
+	}
+	if control.Step != nil {
+		checks.ElseIfList = append(checks.ElseIfList,
+			ast.ElseIf{
+				Condition: &tree.IsNullExpr{Expr: makeVarRef(step)},
+				Stmts: []ast.Statement{makeInternalRaise(
+					pgcode.NullValueNotAllowed, "BY value of FOR loop cannot be null",
+				)},
+			},
+			ast.ElseIf{
+				Condition: &tree.ComparisonExpr{
+					Operator: treecmp.MakeComparisonOperator(treecmp.LE),
+					Left:     makeVarRef(step),
+					Right:    tree.NewDInt(0),
+				},
+				Stmts: []ast.Statement{makeInternalRaise(
+					pgcode.InvalidParameterValue, "BY value of FOR loop must be greater than zero",
+				)},
+			},
+		)
+	}
+
+	// Build the loop, which advances the counter by the step on each iteration.
+	// The counter is set to NULL instead if advancing it would overflow. The
+	// step is known to be positive, so computing the limit cannot overflow.
+	exitCmp, advanceOp, limitOp := treecmp.GT, treebin.Plus, treebin.Minus
+	limit := tree.NewDInt(math.MaxInt64)
+	if control.Reverse {
+		exitCmp, advanceOp, limitOp = treecmp.LT, treebin.Minus, treebin.Plus
+		limit = tree.NewDInt(math.MinInt64)
+	}
+	loopBody := make([]ast.Statement, 0, len(body)+3)
+	loopBody = append(loopBody,
+		&ast.Exit{Condition: &tree.OrExpr{
+			Left: &tree.IsNullExpr{Expr: makeVarRef(counter)},
+			Right: &tree.ComparisonExpr{
+				Operator: treecmp.MakeComparisonOperator(exitCmp),
+				Left:     makeVarRef(counter),
+				Right:    makeVarRef(upper),
+			},
+		}},
+		&ast.Assignment{Var: loopVar, Value: makeVarRef(counter)},
+		&ast.If{
+			Condition: &tree.ComparisonExpr{
+				Operator: treecmp.MakeComparisonOperator(exitCmp),
+				Left:     makeVarRef(counter),
+				Right: &tree.BinaryExpr{
+					Operator: treebin.MakeBinaryOperator(limitOp),
+					Left:     limit,
+					Right:    makeVarRef(step),
+				},
+			},
+			ThenBody: []ast.Statement{&ast.Assignment{Var: counter, Value: tree.DNull}},
+			ElseBody: []ast.Statement{&ast.Assignment{Var: counter, Value: &tree.BinaryExpr{
+				Operator: treebin.MakeBinaryOperator(advanceOp),
+				Left:     makeVarRef(counter),
+				Right:    makeVarRef(step),
+			}}},
+		},
+	)
+	loopBody = append(loopBody, body...)
+	return &ast.Block{
+		Decls: decls,
+		Body:  []ast.Statement{checks, &ast.Loop{Label: label, Body: loopBody}},
+	}
+}
+
+// rewriteQueryForLoop rewrites a FOR loop over the rows of a query into a LOOP
+// within a nested block, which fetches each row from a cursor:
+//
+//	FOR [targets] IN [query] LOOP
+//	  [body];
+//	END LOOP;
+//	=>
+//	DECLARE
+//	  _for_cursor REFCURSOR;
+//	  _for_found BOOL;
+//	BEGIN
+//	  OPEN _for_cursor FOR
+//	    SELECT true, _for_query._c1, ..., _for_query._cN
+//	    FROM ([query]) WITH ORDINALITY AS _for_query (_c1, ..., _cN)
+//	    ORDER BY _for_query.ordinality;
+//	  LOOP
+//	    FETCH _for_cursor INTO _for_found, [targets];
+//	    EXIT WHEN _for_found IS NULL;
+//	    [body];
+//	  END LOOP;
+//	  CLOSE _for_cursor;
+//	END;
+//
+// The leading column of the cursor query is used to distinguish the end of the
+// cursor from a row of NULL values, and the ordinality column preserves the
+// ordering of the original query. If the target is a single composite-typed
+// variable, the columns of each row are assigned to its elements.
+//
+// Note that if control leaves the loop through a RETURN statement or an EXIT
+// statement for an enclosing loop or block, the cursor is not closed until the
+// end of the transaction.
+func (b *plpgsqlBuilder) rewriteQueryForLoop(
+	forLoop *ast.ForLoop, control *ast.QueryForLoopControl, s *scope,
+) *ast.Block {
+	query, ok := control.Query.(*tree.Select)
+	if !ok {
+		panic(nonSelectForLoopErr)
+	}
+	b.checkDuplicateTargets(forLoop.Target, "FOR")
+	cursor := ast.Variable(b.makeIdentifier("_for_cursor"))
+	found := ast.Variable(b.makeIdentifier("_for_found"))
+	alias := tree.Name(b.makeIdentifier("_for_query"))
+
+	// Determine the number of columns returned by the query, so that each can be
+	// referenced by name within the cursor query.
+	numCols := len(b.ob.buildStmtAtRootWithScope(
+		query, nil /* desiredTypes */, s.push(),
+	).makePhysicalProps().Presentation)
+	colDefs := make(tree.ColumnDefList, numCols)
+	cols := make(tree.Exprs, numCols)
+	for i := range colDefs {
+		colDefs[i].Name = tree.Name(fmt.Sprintf("_c%d", i+1))
+		cols[i] = tree.NewUnresolvedName(string(alias), string(colDefs[i].Name))
+	}
+	selectExprs := tree.SelectExprs{{Expr: tree.DBoolTrue}}
+	if b.targetIsRecordVar(forLoop.Target) {
+		selectExprs = append(selectExprs, tree.SelectExpr{Expr: &tree.Tuple{Exprs: cols}})
+	} else {
+		for i := range cols {
+			selectExprs = append(selectExprs, tree.SelectExpr{Expr: cols[i]})
+		}
+	}
+	cursorQuery := &tree.Select{
+		Select: &tree.SelectClause{
+			Exprs: selectExprs,
+			From: tree.From{Tables: tree.TableExprs{&tree.AliasedTableExpr{
+				Expr:       &tree.Subquery{Select: &tree.ParenSelect{Select: query}},
+				Ordinality: true,
+				As:         tree.AliasClause{Alias: alias, Cols: colDefs},
+			}}},
+		},
+		OrderBy: tree.OrderBy{{Expr: tree.NewUnresolvedName(string(alias), "ordinality")}},
+	}
+
+	loopBody := make([]ast.Statement, 0, len(forLoop.Body)+2)
+	loopBody = append(loopBody,
+		&ast.Fetch{
+			Cursor: tree.CursorStmt{Name: cursor, FetchType: tree.FetchNormal, Count: 1},
+			Target: append([]ast.Variable{found}, forLoop.Target...),
+		},
+		&ast.Exit{Condition: &tree.IsNullExpr{Expr: makeVarRef(found)}},
+	)
+	loopBody = append(loopBody, forLoop.Body...)
+	return &ast.Block{
+		Decls: []ast.Statement{
+			&ast.Declaration{Var: cursor, Typ: types.RefCursor},
+			&ast.Declaration{Var: found, Typ: types.Bool},
+		},
+		Body: []ast.Statement{
+			&ast.Open{CurVar: cursor, Query: cursorQuery},
+			&ast.Loop{Label: forLoop.Label, Body: loopBody},
+			&ast.Close{CurVar: cursor},
+		},
+	}
+}
+
+// rewriteForEachArray rewrites a FOREACH loop into an integer FOR loop over
+// the indexes of the array, within a nested block that declares a variable for
+// the array:
+//
+//	FOREACH x IN ARRAY [expr] LOOP
+//	  [body];
+//	END LOOP;
+//	=>
+//	DECLARE
+//	  _foreach_array [array type] := [expr];
+//	BEGIN
+//	  IF _foreach_array IS NULL THEN
+//	    RAISE EXCEPTION 'FOREACH expression must not be null';
+//	  END IF;
+//	  FOR _foreach_idx IN 1..COALESCE(array_length(_foreach_array, 1), 0) LOOP
+//	    x := _foreach_array[_foreach_idx];
+//	    [body];
+//	  END LOOP;
+//	END;
+func (b *plpgsqlBuilder) rewriteForEachArray(forEach *ast.ForEachArray, s *scope) *ast.Block {
+	if forEach.Slice != 0 {
+		panic(foreachSliceErr)
+	}
+	// Resolve the type of the array expression, which determines the type of the
+	// array variable.
+	expr, _ := tree.WalkExpr(s, forEach.Expr)
+	typedExpr, err := expr.TypeCheck(b.ob.ctx, b.ob.semaCtx, types.Any)
+	if err != nil {
+		panic(err)
+	}
+	arrayTyp := typedExpr.ResolvedType()
+	if arrayTyp.Family() != types.ArrayFamily {
+		panic(pgerror.Newf(pgcode.DatatypeMismatch,
+			"FOREACH expression must yield an array, not type %s", arrayTyp.SQLStringForError(),
+		))
+	}
+	array := ast.Variable(b.makeIdentifier("_foreach_array"))
+	idx := ast.Variable(b.makeIdentifier("_foreach_idx"))
+	arrayLen := &tree.CoalesceExpr{
+		Name: "COALESCE",
+		Exprs: tree.Exprs{
+			&tree.FuncExpr{
+				Func:  tree.WrapFunction("array_length"),
+				Exprs: tree.Exprs{makeVarRef(array), tree.NewDInt(1)},
+			},
+			tree.NewDInt(0),
+		},
+	}
+	loopBody := make([]ast.Statement, 0, len(forEach.Body)+1)
+	loopBody = append(loopBody, &ast.Assignment{
+		Var: forEach.Var,
+		Value: &tree.IndirectionExpr{
+			Expr:        makeVarRef(array),
+			Indirection: tree.ArraySubscripts{{Begin: makeVarRef(idx)}},
+		},
+	})
+	loopBody = append(loopBody, forEach.Body...)
+	return &ast.Block{
+		Decls: []ast.Statement{
+			&ast.Declaration{Var: array, Typ: arrayTyp, Expr: forEach.Expr},
+		},
+		Body: []ast.Statement{
+			&ast.If{
+				Condition: &tree.IsNullExpr{Expr: makeVarRef(array)},
+				ThenBody: []ast.Statement{makeInternalRaise(
+					pgcode.NullValueNotAllowed, "FOREACH expression must not be null",
+				)},
+			},
+			&ast.ForLoop{
+				Label:  forEach.Label,
+				Target: []ast.Variable{idx},
+				Control: &ast.IntForLoopControl{
+					Lower: tree.NewDInt(1),
+					Upper: arrayLen,
+				},
+				Body: loopBody,
+			},
+		},
+	}
+}
+
+// buildReturnNextScope projects the given scalar expression as a row to be
+// added to the result of a set-returning routine by RETURN NEXT.
+func (b *plpgsqlBuilder) buildReturnNextScope(inScope *scope, scalar opt.ScalarExpr) *scope {
+	b.addBarrierIfVolatile(inScope, scalar)
+	colName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_return_next"))
+	returnScope := inScope.push()
+	b.ob.synthesizeColumn(returnScope, colName, b.returnType, nil /* expr */, scalar)
+	b.ob.constructProjectForScope(inScope, returnScope)
+	return returnScope
+}
+
+// buildReturnQueryScope projects the columns of a RETURN QUERY statement as
+// rows to be added to the result of a set-returning routine. The columns are
+// combined into a tuple if the routine returns a composite type. The ordering
+// of the query, if any, is preserved.
+func (b *plpgsqlBuilder) buildReturnQueryScope(stmtScope *scope) *scope {
+	cols := stmtScope.makePhysicalProps().Presentation
+	colTypes := []*types.T{b.returnType}
+	if b.returnType.Family() == types.TupleFamily {
+		colTypes = b.returnType.TupleContents()
+	}
+	if len(cols) != len(colTypes) {
+		panic(errors.WithDetailf(returnQueryStructureErr,
+			"Number of returned columns (%d) does not match expected column count (%d).",
+			len(cols), len(colTypes),
+		))
+	}
+	elems := make(memo.ScalarListExpr, len(cols))
+	for i := range cols {
+		elems[i] = b.coerceType(b.ob.factory.ConstructVariable(cols[i].ID), colTypes[i])
+	}
+	scalar := elems[0]
+	if b.returnType.Family() == types.TupleFamily {
+		scalar = b.ob.factory.ConstructTuple(elems, b.returnType)
+	}
+	colName := scopeColName("").WithMetadataName(b.makeIdentifier("stmt_return_query"))
+	returnScope := stmtScope.push()
+	b.ob.synthesizeColumn(returnScope, colName, b.returnType, nil /* expr */, scalar)
+	returnScope.copyOrdering(stmtScope)
+	b.ob.constructProjectForScope(stmtScope, returnScope)
+	return returnScope
+}
+
+// makeVarRef returns a reference to the given PL/pgSQL variable that can be
+// used within a SQL expression.
+func makeVarRef(name ast.Variable) *tree.UnresolvedName {
+	return tree.NewUnresolvedName(string(name))
+}
+
+// makeInternalRaise returns a RAISE statement that throws an error with the
+// given code and message. It is used for runtime checks in statements that
+// are rewritten into simpler statements.
+func makeInternalRaise(code pgcode.Code, message string) *ast.Raise {
+	return &ast.Raise{LogLevel: "EXCEPTION", Code: code.String(), Message: message}
+}
+
 // makeContinuation allocates a new continuation routine with an uninitialized
 // definition. Note that the parameters of the continuation will be determined
 // by the current block; if a child block declares new variables, its
@@ -1659,7 +2175,7 @@ func (b *plpgsqlBuilder) makeContinuation(conName string) continuation {
 	for i := range b.blocks {
 		block := &b.blocks[i]
 		for _, name := range block.vars {
-			addParam(name, block.varTypes[name])
+			addParam(b.variableColName(i, name), block.varTypes[name])
 		}
 	}
 	b.ensureScopeHasExpr(s)
@@ -1790,7 +2306,7 @@ func (b *plpgsqlBuilder) makeContinuationArgs(con *continuation, s *scope) memo.
 		}
 		block := &b.blocks[i]
 		for _, name := range block.vars {
-			addArg(name)
+			addArg(b.variableColName(i, name))
 		}
 	}
 	return args
@@ -1939,6 +2455,10 @@ func (b *plpgsqlBuilder) hasOutParam() bool {
 	return len(b.outParams) > 0
 }
 
+func (b *plpgsqlBuilder) isSetReturning() bool {
+	return b.resultBuffer != nil
+}
+
 // makeReturnForOutParams builds the implicit RETURN expression for a routine
 // with OUT-parameters.
 func (b *plpgsqlBuilder) makeReturnForOutParams() tree.Expr {
@@ -1948,7 +2468,9 @@ func (b *plpgsqlBuilder) makeReturnForOutParams() tree.Expr {
 	exprs := make(tree.Exprs, len(b.outParams))
 	for i, param := range b.outParams {
 		if param != "" {
-			exprs[i] = tree.NewUnresolvedName(string(param))
+			// The OUT parameters are variables of the root block, which may be
+			// shadowed by a loop variable.
+			exprs[i] = tree.NewUnresolvedName(string(b.variableColName(0, param)))
 		} else {
 			// TODO(121251): if the unnamed parameter of INOUT type, then we
 			// should be using the argument expression here (assuming this
@@ -2042,25 +2564,83 @@ func (b *plpgsqlBuilder) getContinuation(
 }
 
 // addVariable adds a variable with the given name and type to the current
-// PL/pgSQL block scope.
-func (b *plpgsqlBuilder) addVariable(name ast.Variable, typ *types.T) {
+// PL/pgSQL block scope. If allowShadowing is false, it is an error for a
+// variable with the same name to exist in an ancestor block.
+func (b *plpgsqlBuilder) addVariable(name ast.Variable, typ *types.T, allowShadowing bool) {
 	curBlock := b.block()
 	if _, ok := curBlock.varTypes[name]; ok {
 		panic(pgerror.Newf(pgcode.Syntax, "duplicate declaration at or near \"%s\"", name))
 	}
-	for i := range b.blocks {
-		block := &b.blocks[i]
-		if _, ok := block.varTypes[name]; ok {
-			panic(errors.WithHintf(
-				unimplemented.NewWithIssue(117508, "variable shadowing is not yet implemented"),
-				"variable \"%s\" shadows a previously defined variable", name,
-			))
+	if !allowShadowing {
+		for i := range b.blocks {
+			block := &b.blocks[i]
+			if _, ok := block.varTypes[name]; ok {
+				panic(errors.WithHintf(
+					unimplemented.NewWithIssue(117508, "variable shadowing is not yet implemented"),
+					"variable \"%s\" shadows a previously defined variable", name,
+				))
+			}
 		}
 	}
 	curBlock.vars = append(curBlock.vars, name)
 	curBlock.varTypes[name] = typ
 }
 
+// shadowVariable is called before a variable with the given name is declared
+// in the current block. If a variable with the same name exists in an ancestor
+// block, its current value is projected as a column with an internal name, so
+// that references to the name resolve to the new variable while the value of
+// the shadowed variable is preserved until control returns to the ancestor
+// block. See variableColName.
+func (b *plpgsqlBuilder) shadowVariable(inScope *scope, name ast.Variable) *scope {
+	blockIdx := -1
+	for i := len(b.blocks) - 2; i >= 0; i-- {
+		if _, ok := b.blocks[i].varTypes[name]; ok {
+			blockIdx = i
+			break
+		}
+	}
+	if blockIdx == -1 {
+		return inScope
+	}
+	_, source, _, err := inScope.FindSourceProvidingColumn(b.ob.ctx, name)
+	if err != nil {
+		panic(err)
+	}
+	shadowed := source.(*scopeColumn)
+	shadowScope := inScope.push()
+	for i := range inScope.cols {
+		if col := &inScope.cols[i]; col.id != shadowed.id {
+			shadowScope.appendColumn(col)
+		}
+	}
+	b.ob.synthesizeColumn(
+		shadowScope, scopeColName(shadowedVariableName(blockIdx, name)), shadowed.typ,
+		nil /* expr */, b.ob.factory.ConstructVariable(shadowed.id),
+	)
+	b.ob.constructProjectForScope(inScope, shadowScope)
+	return shadowScope
+}
+
+// variableColName returns the name of the column that holds the value of the
+// variable with the given name that was declared in the block with the given
+// index. It is the name of the variable, unless the variable is shadowed by a
+// variable of a descendant block that is in scope.
+func (b *plpgsqlBuilder) variableColName(blockIdx int, name ast.Variable) ast.Variable {
+	for i := blockIdx + 1; i < len(b.blocks); i++ {
+		if _, ok := b.blocks[i].varTypes[name]; ok {
+			return shadowedVariableName(blockIdx, name)
+		}
+	}
+	return name
+}
+
+// shadowedVariableName returns the internal name of the column that holds the
+// value of a shadowed variable.
+func shadowedVariableName(blockIdx int, name ast.Variable) ast.Variable {
+	return ast.Variable(fmt.Sprintf("_shadowed_%d_%s", blockIdx, name))
+}
+
 // block returns the block for the current PL/pgSQL block.
 func (b *plpgsqlBuilder) block() *plBlock {
 	return &b.blocks[len(b.blocks)-1]
@@ -2242,6 +2822,33 @@ var (
 	)
 	returnWithVoidParameterProcedureErr = pgerror.New(pgcode.Syntax,
 		"RETURN cannot have a parameter in a procedure")
+	returnWithSetOfParameterErr = errors.WithHint(
+		pgerror.New(pgcode.DatatypeMismatch,
+			"RETURN cannot have a parameter in function returning set",
+		),
+		"Use RETURN NEXT or RETURN QUERY.",
+	)
+	returnNextNonSetOfErr = pgerror.New(pgcode.Syntax,
+		"cannot use RETURN NEXT in a non-SETOF function",
+	)
+	returnQueryNonSetOfErr = pgerror.New(pgcode.Syntax,
+		"cannot use RETURN QUERY in a non-SETOF function",
+	)
+	returnNextWithOUTParameterErr = pgerror.New(pgcode.Syntax,
+		"RETURN NEXT cannot have a parameter in function with OUT parameters",
+	)
+	emptyReturnNextErr = pgerror.New(pgcode.Syntax,
+		"RETURN NEXT must have a parameter",
+	)
+	returnQueryStructureErr = pgerror.New(pgcode.DatatypeMismatch,
+		"structure of query does not match function result type",
+	)
+	nonSelectForLoopErr = unimplemented.New("FOR loop over non-SELECT query",
+		"FOR loops over queries other than SELECT are not yet supported",
+	)
+	foreachSliceErr = unimplemented.New("FOREACH with SLICE",
+		"FOREACH loops with SLICE are not yet supported",
+	)
 	emptyReturnErr = pgerror.New(pgcode.Syntax,
 		"missing expression at or near \"RETURN;\"",
 	)
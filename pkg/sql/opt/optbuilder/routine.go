@@ -24,6 +24,7 @@ import (
 	plpgsql "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
@@ -52,6 +53,10 @@ func (b *Builder) buildUDF(
 			"To call a procedure, use CALL.",
 		))
 	}
+	if f.ResolvedType().Family() == types.TriggerFamily {
+		panic(pgerror.New(pgcode.FeatureNotSupported,
+			"trigger functions can only be called as triggers"))
+	}
 
 	// Check for execution privileges for user-defined overloads. Built-in
 	// overloads do not need to be checked.
@@ -283,6 +288,19 @@ func (b *Builder) buildRoutine(
 			))
 		}
 	}
+	if o.Variadic {
+		// Pack the trailing arguments of the VARIADIC parameter into an array.
+		numFixed := o.Types.Length() - 1
+		arrayTyp := o.Types.GetAt(numFixed)
+		elems := make(memo.ScalarListExpr, len(args)-numFixed)
+		for i, arg := range args[numFixed:] {
+			if !arg.DataType().Identical(arrayTyp.ArrayContents()) {
+				arg = b.factory.ConstructCast(arg, arrayTyp.ArrayContents())
+			}
+			elems[i] = arg
+		}
+		args = append(args[:numFixed], b.factory.ConstructArray(elems, arrayTyp))
+	}
 	// Create a new scope for building the statements in the function body. We
 	// start with an empty scope because a statement in the function body cannot
 	// refer to anything from the outer expression. If there are function
@@ -380,12 +398,22 @@ func (b *Builder) buildRoutine(
 	b.trackSchemaDeps = false
 	b.insideUDF = true
 	isSetReturning := o.Class == tree.GeneratorClass
+	if o.SessionOverride != nil {
+		// The body of the routine is built with the session changes which are
+		// applied while it executes, so that privileges are checked for the
+		// owner of a SECURITY DEFINER routine and names are resolved with the
+		// search_path of the routine. The plan depends on these changes, so the
+		// memo cannot be reused.
+		b.DisableMemoReuse = true
+		defer b.pushRoutineSessionOverride(o.SessionOverride)()
+	}
 	isMultiColDataSource = false
 
 	// Build an expression for each statement in the function body.
 	var body []memo.RelExpr
 	var bodyProps []*physical.Required
 	var bodyStmts []string
+	var resultBuffer *tree.RoutineResultBuffer
 	switch o.Language {
 	case tree.RoutineLangSQL:
 		// Parse the function body.
@@ -420,7 +448,7 @@ func (b *Builder) buildRoutine(
 				if i == len(stmts)-1 {
 					finishResolveType(stmtScope)
 					expr, physProps, isMultiColDataSource =
-						b.finishBuildLastStmt(stmtScope, bodyScope, isSetReturning, oldInsideDataSource, f)
+						b.finishBuildLastStmt(stmtScope, bodyScope, isSetReturning, oldInsideDataSource, f.ResolvedType())
 				}
 				body[i] = expr
 				bodyProps[i] = physProps
@@ -455,12 +483,13 @@ func (b *Builder) buildRoutine(
 		var expr memo.RelExpr
 		var physProps *physical.Required
 		plBuilder := newPLpgSQLBuilder(
-			b, def.Name, stmt.AST.Label, colRefs, routineParams, rtyp, isProc, outScope,
+			b, def.Name, stmt.AST.Label, colRefs, routineParams, rtyp, isProc, isSetReturning, outScope,
 		)
 		stmtScope := plBuilder.buildRootBlock(stmt.AST, bodyScope, routineParams)
+		resultBuffer = plBuilder.resultBuffer
 		finishResolveType(stmtScope)
 		expr, physProps, isMultiColDataSource =
-			b.finishBuildLastStmt(stmtScope, bodyScope, isSetReturning, oldInsideDataSource, f)
+			b.finishBuildLastStmt(stmtScope, bodyScope, isSetReturning, oldInsideDataSource, f.ResolvedType())
 		body = []memo.RelExpr{expr}
 		bodyProps = []*physical.Required{physProps}
 		if b.verboseTracing {
@@ -486,12 +515,47 @@ func (b *Builder) buildRoutine(
 				BodyProps:          bodyProps,
 				BodyStmts:          bodyStmts,
 				Params:             params,
+				ResultBuffer:       resultBuffer,
+				SessionOverride:    o.SessionOverride,
 			},
 		},
 	)
 	return routine, isMultiColDataSource
 }
 
+// pushRoutineSessionOverride pushes a copy of the session data with the user
+// and search_path of the given override applied, and returns a function which
+// restores the previous session data.
+func (b *Builder) pushRoutineSessionOverride(
+	override *tree.RoutineSessionOverride,
+) (restore func()) {
+	b.evalCtx.SessionDataStack.PushTopClone()
+	sd := b.evalCtx.SessionData()
+	if !override.User.Undefined() {
+		if sd.SessionUserProto == "" {
+			sd.SessionUserProto = sd.UserProto
+		}
+		sd.UserProto = override.User.EncodeProto()
+	}
+	for _, setting := range override.Settings {
+		if setting.Name == "search_path" {
+			paths, err := sessiondata.ParseSearchPath(setting.Value)
+			if err != nil {
+				panic(err)
+			}
+			sd.SearchPath = sd.SearchPath.UpdatePaths(paths)
+		}
+	}
+	oldSearchPath := b.semaCtx.SearchPath
+	b.semaCtx.SearchPath = &sd.SearchPath
+	return func() {
+		b.semaCtx.SearchPath = oldSearchPath
+		if err := b.evalCtx.SessionDataStack.Pop(); err != nil {
+			panic(err)
+		}
+	}
+}
+
 // finishBuildLastStmt manages the columns returned by the last statement of a
 // UDF. Depending on the context and return type of the UDF, this may mean
 // expanding a tuple into multiple columns, or combining multiple columns into
@@ -501,10 +565,9 @@ func (b *Builder) buildRoutine(
 // is passed in rather than using b.insideDataSource because b.insideDataSource
 // is reset while building the body of the routine.
 func (b *Builder) finishBuildLastStmt(
-	stmtScope *scope, bodyScope *scope, isSetReturning, insideDataSource bool, f *tree.FuncExpr,
+	stmtScope *scope, bodyScope *scope, isSetReturning, insideDataSource bool, rtyp *types.T,
 ) (expr memo.RelExpr, physProps *physical.Required, isMultiColDataSource bool) {
 	expr, physProps = stmtScope.expr, stmtScope.makePhysicalProps()
-	rtyp := f.ResolvedType()
 
 	// Add a LIMIT 1 to the last statement if the UDF is not
 	// set-returning. This is valid because any other rows after the
@@ -117,6 +117,9 @@ func (b *Builder) buildScalar(
 		}
 		return b.finishBuildScalarRef(t.col, aggOutScope, outScope, outCol, colRefs)
 
+	case *groupingInfo:
+		return b.buildGroupingFunc(t, inScope, outScope, outCol, colRefs)
+
 	case *windowInfo:
 		return b.finishBuildScalarRef(t.col, inScope, outScope, outCol, colRefs)
 
@@ -782,7 +785,16 @@ func (b *Builder) constructComparison(
 		}
 		return b.factory.ConstructOverlaps(left, right)
 	case treecmp.TSMatches:
+		if cmp.Op.LeftType.Family() == types.JsonFamily {
+			// The @@ operator returns the result of a jsonpath predicate when used
+			// with jsonb and jsonpath operands.
+			return b.factory.ConstructJsonPathMatch(left, right)
+		}
 		return b.factory.ConstructTSMatches(left, right)
+	case treecmp.JSONPathExists:
+		return b.factory.ConstructJsonPathExists(left, right)
+	case treecmp.Adjacent:
+		return b.factory.ConstructAdjacent(left, right)
 	}
 	panic(errors.AssertionFailedf("unhandled comparison operator: %s", redact.Safe(cmp.Operator)))
 }
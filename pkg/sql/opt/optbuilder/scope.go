@@ -996,6 +996,34 @@ func makeUntypedTuple(labels []string, texprs []tree.TypedExpr) *tree.Tuple {
 	return &tree.Tuple{Exprs: exprs, Labels: labels}
 }
 
+// resolveRecordFieldAccess attempts to resolve a column reference of the form
+// "rec.field" as an access to a field of a tuple-typed column named "rec".
+// This allows PL/pgSQL routines to reference the fields of record variables,
+// such as NEW and OLD in trigger functions. It returns nil if there is no such
+// column in scope.
+func (s *scope) resolveRecordFieldAccess(c *tree.ColumnItem) tree.Expr {
+	if c.TableName == nil || c.TableName.NumParts != 1 {
+		return nil
+	}
+	recordName := tree.Name(c.TableName.Parts[0])
+	for curr := s; curr != nil; curr = curr.parent {
+		for i := len(curr.cols) - 1; i >= 0; i-- {
+			col := &curr.cols[i]
+			if col.name.ReferenceName() != recordName || col.table.ObjectName != "" ||
+				col.visibility == inaccessible || col.typ.Family() != types.TupleFamily {
+				continue
+			}
+			for _, label := range col.typ.TupleLabels() {
+				if label == string(c.ColumnName) {
+					return &tree.ColumnAccessExpr{Expr: col, ColName: c.ColumnName}
+				}
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
 // VisitPre is part of the Visitor interface.
 //
 // NB: This code is adapted from sql/select_name_resolution.go and
@@ -1031,6 +1059,11 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 	case *tree.ColumnItem:
 		colI, resolveErr := colinfo.ResolveColumnItem(s.builder.ctx, s, t)
 		if resolveErr != nil {
+			// It may be a reference to a field of a record variable, e.g. NEW.a
+			// in a trigger function.
+			if access := s.resolveRecordFieldAccess(t); access != nil {
+				return false, access
+			}
 			// It may be a reference to a table, e.g. SELECT tbl FROM tbl.
 			// Attempt to resolve as a TupleStar.
 			if sqlerrors.IsUndefinedColumnError(resolveErr) {
@@ -1092,6 +1125,9 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 			break
 		}
 
+	case *tree.GroupingExpr:
+		expr = s.replaceGrouping(t)
+
 	case *tree.ArrayFlatten:
 		if sub, ok := t.Subquery.(*tree.Subquery); ok {
 			// Copy the ArrayFlatten expression so that the tree isn't mutated.
@@ -1300,6 +1336,25 @@ func (s *scope) replaceAggregate(f *tree.FuncExpr, def *tree.ResolvedFunctionDef
 	return s.builder.buildAggregateFunction(f, &private, tempScope, s)
 }
 
+// replaceGrouping type-checks the arguments of a GROUPING function call and
+// replaces the call with a groupingInfo, which is resolved against the
+// grouping columns when it is built.
+func (s *scope) replaceGrouping(f *tree.GroupingExpr) tree.Expr {
+	semaCtx := s.builder.semaCtx
+	if semaCtx.Properties.IsSet(tree.RejectAggregates) {
+		panic(pgerror.Newf(pgcode.Grouping,
+			"grouping operations are not allowed in %s", semaCtx.Properties.Context()))
+	}
+	if len(f.Exprs) > 31 {
+		panic(pgerror.New(pgcode.TooManyArguments, "GROUPING must have fewer than 32 arguments"))
+	}
+	info := &groupingInfo{GroupingExpr: f, args: make([]tree.TypedExpr, len(f.Exprs))}
+	for i, e := range f.Exprs {
+		info.args[i] = s.resolveType(e, types.Any)
+	}
+	return info
+}
+
 func (s *scope) lookupWindowDef(name tree.Name) *tree.WindowDef {
 	for i := range s.windowDefs {
 		if s.windowDefs[i].Name == name {
@@ -1543,9 +1598,9 @@ func (s *scope) replaceCount(
 	}
 	f.Exprs[0] = vn
 
-	// It is ok to use string equality here, even if there is a UDF named
-	// "count" because UDFs cannot be aggregate functions. This code path is
-	// only executed for aggregate functions.
+	// It is ok to use string equality here, even if there is a user-defined
+	// aggregate named "count", because count(*) always refers to the builtin.
+	// This code path is only executed for aggregate functions.
 	if strings.EqualFold(def.Name, "count") && f.Type == 0 {
 		if _, ok := vn.(tree.UnqualifiedStar); ok {
 			if f.Filter != nil {
@@ -1599,9 +1654,28 @@ func (s *scope) replaceCount(
 		// Similar to the work done in PR #17833.
 	}
 
+	if _, ok := vn.(tree.UnqualifiedStar); ok && hasUserDefinedOverload(def) {
+		// A user-defined aggregate which takes no arguments is invoked as
+		// agg(*), in which case * does not refer to a set of columns.
+		cpy := *f
+		cpy.Exprs = nil
+		return &cpy, def
+	}
+
 	return f, def
 }
 
+// hasUserDefinedOverload returns true if any of the overloads of the given
+// function are user-defined.
+func hasUserDefinedOverload(def *tree.ResolvedFunctionDefinition) bool {
+	for i := range def.Overloads {
+		if def.Overloads[i].Type == tree.UDFRoutine {
+			return true
+		}
+	}
+	return false
+}
+
 const (
 	extraColsAllowed   = true
 	noExtraColsAllowed = false
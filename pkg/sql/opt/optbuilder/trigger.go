@@ -0,0 +1,725 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	plpgsql "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// This file contains methods that build the invocations of row-level and
+// statement-level triggers for mutations.
+//
+// Row-level BEFORE triggers are built inline, as part of the mutation input.
+// Each trigger function is invoked with the NEW and OLD versions of the row,
+// and its result replaces the NEW row. If the result is NULL, the row is
+// skipped.
+//
+// Statement-level BEFORE triggers are built as the materialized binding of a
+// With expression that wraps the mutation. The binding is executed once, ahead
+// of the mutation, regardless of the number of modified rows.
+//
+// AFTER triggers are built as "cascades" of the mutation: postqueries that are
+// planned and executed after the mutation (and any FK cascades) has run. The
+// postquery for row-level triggers scans the buffered mutation input to obtain
+// the NEW and OLD rows. The postquery for statement-level triggers does not
+// require any input.
+//
+// An INSERT ... ON CONFLICT DO UPDATE or UPSERT fires both the INSERT and the
+// UPDATE triggers, as in Postgres. The row-level BEFORE INSERT triggers fire
+// for every proposed row, before conflicts are detected. The row-level BEFORE
+// UPDATE triggers only fire for the rows that conflict with an existing row,
+// and the AFTER triggers fire for the rows that were actually inserted or
+// updated. The canary column of the upsert distinguishes between the two.
+//
+// The trigger functions are PL/pgSQL routines that take no declared arguments.
+// Instead, the function body can reference the following implicit parameters:
+//
+//	NEW, OLD:          the new and old versions of the row, for row-level
+//	                   triggers; NULL otherwise.
+//	TG_NAME:           the name of the trigger.
+//	TG_WHEN:           BEFORE or AFTER.
+//	TG_LEVEL:          ROW or STATEMENT.
+//	TG_OP:             INSERT, UPDATE or DELETE.
+//	TG_RELID:          the OID of the table that fired the trigger.
+//	TG_TABLE_NAME:     the name of the table that fired the trigger.
+//	TG_TABLE_SCHEMA:   the schema of the table that fired the trigger.
+//	TG_NARGS:          the number of arguments given in CREATE TRIGGER.
+//	TG_ARGV:           the arguments given in CREATE TRIGGER.
+
+// tableHasTriggers returns true if the given table has any enabled triggers.
+func tableHasTriggers(tab cat.Table) bool {
+	for i, n := 0, tab.TriggerCount(); i < n; i++ {
+		if tab.Trigger(i).Enabled() {
+			return true
+		}
+	}
+	return false
+}
+
+// getTriggers returns the enabled triggers of the target table that fire with
+// the given timing and level for the given event, sorted by name. For UPDATE
+// triggers with a column list, the trigger is only returned if at least one of
+// the listed columns is a target of the mutation.
+func (mb *mutationBuilder) getTriggers(
+	actionTime tree.TriggerActionTime, forEachRow bool, eventType tree.TriggerEventType,
+) []cat.Trigger {
+	var triggers []cat.Trigger
+	for i, n := 0, mb.tab.TriggerCount(); i < n; i++ {
+		trigger := mb.tab.Trigger(i)
+		if !trigger.Enabled() || trigger.ActionTime() != actionTime ||
+			trigger.ForEachRow() != forEachRow {
+			continue
+		}
+		for j, m := 0, trigger.EventCount(); j < m; j++ {
+			event := trigger.Event(j)
+			if event.EventType != eventType {
+				continue
+			}
+			if len(event.Columns) > 0 && !mb.targetsAnyColumn(event.Columns) {
+				continue
+			}
+			triggers = append(triggers, trigger)
+			break
+		}
+	}
+	// Triggers fire in alphabetical order by name.
+	sort.Slice(triggers, func(i, j int) bool {
+		return triggers[i].Name() < triggers[j].Name()
+	})
+	return triggers
+}
+
+// targetsAnyColumn returns true if any of the given columns is a target of the
+// mutation.
+func (mb *mutationBuilder) targetsAnyColumn(cols tree.NameList) bool {
+	for i, n := 0, mb.tab.ColumnCount(); i < n; i++ {
+		if !mb.targetColSet.Contains(mb.tabID.ColumnID(i)) {
+			continue
+		}
+		for _, col := range cols {
+			if mb.tab.Column(i).ColName() == col {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// triggerRowType returns the type of the NEW and OLD records passed to the
+// triggers of the given table, which is a labeled tuple with an element for
+// each visible column. It also returns the ordinals of those columns.
+func triggerRowType(tab cat.Table) (*types.T, []int) {
+	var contents []*types.T
+	var labels []string
+	var ords []int
+	for i, n := 0, tab.ColumnCount(); i < n; i++ {
+		col := tab.Column(i)
+		if col.Kind() != cat.Ordinary || col.Visibility() != cat.Visible {
+			continue
+		}
+		contents = append(contents, col.DatumType())
+		labels = append(labels, string(col.ColName()))
+		ords = append(ords, i)
+	}
+	return types.MakeLabeledTuple(contents, labels), ords
+}
+
+// buildTriggerRecord constructs a tuple of the given type from the given
+// columns.
+func (b *Builder) buildTriggerRecord(rowType *types.T, cols opt.ColList) opt.ScalarExpr {
+	elems := make(memo.ScalarListExpr, len(cols))
+	for i := range cols {
+		elems[i] = b.factory.ConstructVariable(cols[i])
+	}
+	return b.factory.ConstructTuple(elems, rowType)
+}
+
+// buildRowLevelBeforeTriggers wraps the mutation input with the invocations of
+// the row-level BEFORE triggers for the given event. For INSERT and UPDATE,
+// the values returned by the triggers replace the values to be inserted or
+// updated. For all events, rows for which a trigger returns NULL are filtered
+// out of the input.
+func (mb *mutationBuilder) buildRowLevelBeforeTriggers(eventType tree.TriggerEventType) {
+	triggers := mb.getTriggers(tree.TriggerActionTimeBefore, true /* forEachRow */, eventType)
+	if len(triggers) == 0 {
+		return
+	}
+	f := mb.b.factory
+	rowType, ords := triggerRowType(mb.tab)
+
+	// Project the NEW and OLD records.
+	var newCol, oldCol opt.ColumnID
+	projectionsScope := mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	if eventType != tree.TriggerEventDelete {
+		cols := make(opt.ColList, len(ords))
+		for i, ord := range ords {
+			cols[i] = mb.mapToReturnColID(ord)
+		}
+		newCol = mb.b.synthesizeColumn(
+			projectionsScope, scopeColName("").WithMetadataName("new"), rowType,
+			nil /* expr */, mb.b.buildTriggerRecord(rowType, cols),
+		).id
+	}
+	if eventType != tree.TriggerEventInsert {
+		cols := make(opt.ColList, len(ords))
+		for i, ord := range ords {
+			cols[i] = mb.fetchColIDs[ord]
+		}
+		oldCol = mb.b.synthesizeColumn(
+			projectionsScope, scopeColName("").WithMetadataName("old"), rowType,
+			nil /* expr */, mb.b.buildTriggerRecord(rowType, cols),
+		).id
+	}
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+
+	for _, trigger := range triggers {
+		newRecord, oldRecord := f.ConstructNull(rowType), f.ConstructNull(rowType)
+		if newCol != 0 {
+			newRecord = f.ConstructVariable(newCol)
+		}
+		if oldCol != 0 {
+			oldRecord = f.ConstructVariable(oldCol)
+		}
+		call := mb.b.buildTriggerFunctionCall(
+			mb.tab, trigger, eventType, rowType, newRecord, oldRecord,
+		)
+
+		// If the WHEN condition does not hold, the trigger is skipped and the row
+		// is passed through unchanged. The value returned by a DELETE trigger is
+		// only used to determine whether the row should be skipped.
+		passthrough := newRecord
+		if eventType == tree.TriggerEventDelete {
+			passthrough = oldRecord
+		}
+		if trigger.WhenExpr() != "" {
+			cond := mb.b.buildTriggerWhen(trigger, rowType, newCol, oldCol)
+			call = f.ConstructCase(
+				memo.TrueSingleton,
+				memo.ScalarListExpr{f.ConstructWhen(cond, call)},
+				passthrough,
+			)
+		}
+
+		// The UPDATE triggers of an upsert only fire for the rows that conflict
+		// with an existing row, i.e. the rows for which the canary column is not
+		// NULL. The other rows will be inserted, and are passed through.
+		if eventType == tree.TriggerEventUpdate && mb.canaryColID != 0 {
+			call = f.ConstructCase(
+				memo.TrueSingleton,
+				memo.ScalarListExpr{f.ConstructWhen(
+					f.ConstructIs(f.ConstructVariable(mb.canaryColID), memo.NullSingleton),
+					passthrough,
+				)},
+				call,
+			)
+		}
+		projectionsScope = mb.outScope.replace()
+		projectionsScope.appendColumnsFromScope(mb.outScope)
+		resultCol := mb.b.synthesizeColumn(
+			projectionsScope,
+			scopeColName("").WithMetadataName(fmt.Sprintf("%s_result", trigger.Name())),
+			rowType, nil /* expr */, call,
+		).id
+		mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+		mb.outScope = projectionsScope
+
+		// Skip the rows for which the trigger returned NULL.
+		mb.outScope.expr = f.ConstructSelect(mb.outScope.expr, memo.FiltersExpr{
+			f.ConstructFiltersItem(f.ConstructIsNot(f.ConstructVariable(resultCol), f.ConstructNull(rowType))),
+		})
+		if eventType != tree.TriggerEventDelete {
+			newCol = resultCol
+		}
+	}
+	if eventType == tree.TriggerEventDelete {
+		return
+	}
+
+	// Extract the (possibly modified) column values from the NEW record. The
+	// names of the replaced columns are cleared, so that later references to
+	// the table columns, such as in computed column expressions or in the
+	// excluded data source of an upsert, resolve to the new values.
+	colIDs := mb.insertColIDs
+	if eventType == tree.TriggerEventUpdate {
+		colIDs = mb.updateColIDs
+	}
+	projectionsScope = mb.outScope.replace()
+	projectionsScope.appendColumnsFromScope(mb.outScope)
+	for i, ord := range ords {
+		if prevCol := projectionsScope.getColumn(colIDs[ord]); prevCol != nil {
+			prevCol.clearName()
+		}
+		tabCol := mb.tab.Column(ord)
+		colName := scopeColName(tabCol.ColName()).WithMetadataName(
+			string(tabCol.ColName()) + "_trigger",
+		)
+		access := f.ConstructColumnAccess(f.ConstructVariable(newCol), memo.TupleOrdinal(i))
+		colIDs[ord] = mb.b.synthesizeColumn(
+			projectionsScope, colName, tabCol.DatumType(), nil /* expr */, access,
+		).id
+	}
+	mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+	mb.outScope = projectionsScope
+}
+
+// buildStatementLevelBeforeTriggers wraps the mutation expression with the
+// invocations of the statement-level BEFORE triggers for the given events. The
+// triggers are invoked in the materialized binding of a With expression, which
+// is executed once before the mutation, even if no rows are modified.
+func (mb *mutationBuilder) buildStatementLevelBeforeTriggers(
+	eventTypes ...tree.TriggerEventType,
+) {
+	f := mb.b.factory
+	rowType, _ := triggerRowType(mb.tab)
+	bindingScope := mb.b.allocScope()
+	bindingScope.expr = f.ConstructNoColsRow()
+	projectionsScope := bindingScope.replace()
+	for _, eventType := range eventTypes {
+		for _, trigger := range mb.getTriggers(
+			tree.TriggerActionTimeBefore, false /* forEachRow */, eventType,
+		) {
+			// The result of a statement-level trigger is ignored.
+			call := mb.b.buildTriggerFunctionCall(
+				mb.tab, trigger, eventType, rowType, f.ConstructNull(rowType), f.ConstructNull(rowType),
+			)
+			mb.b.synthesizeColumn(
+				projectionsScope, scopeColName("").WithMetadataName(string(trigger.Name())),
+				rowType, nil /* expr */, call,
+			)
+		}
+	}
+	if len(projectionsScope.cols) == 0 {
+		return
+	}
+	mb.b.constructProjectForScope(bindingScope, projectionsScope)
+
+	// The binding is never referenced, so it must be materialized to prevent it
+	// from being inlined and eliminated.
+	id := f.Memo().NextWithID()
+	mb.md.AddWithBinding(id, projectionsScope.expr)
+	mb.outScope.expr = f.ConstructWith(projectionsScope.expr, mb.outScope.expr, &memo.WithPrivate{
+		ID:   id,
+		Name: "before-triggers",
+		Mtr:  tree.CTEMaterializeAlways,
+	})
+}
+
+// buildAfterTriggers adds the postqueries that invoke the AFTER triggers for
+// the given event. The row-level triggers are invoked for each row in the
+// buffered mutation input, and the statement-level triggers are invoked once.
+// Row-level triggers fire before statement-level triggers.
+func (mb *mutationBuilder) buildAfterTriggers(eventType tree.TriggerEventType) {
+	mb.buildRowLevelAfterTriggers(eventType)
+	mb.buildStatementLevelAfterTriggers(eventType)
+}
+
+// buildAfterTriggersForUpsert adds the postqueries that invoke the AFTER
+// triggers of an INSERT ... ON CONFLICT DO UPDATE or UPSERT. The row-level
+// INSERT triggers are invoked for each inserted row, and the row-level UPDATE
+// triggers for each updated row. The statement-level triggers of both events
+// are invoked once.
+func (mb *mutationBuilder) buildAfterTriggersForUpsert() {
+	mb.buildRowLevelAfterTriggers(tree.TriggerEventInsert)
+	mb.buildRowLevelAfterTriggers(tree.TriggerEventUpdate)
+	mb.buildStatementLevelAfterTriggers(tree.TriggerEventInsert)
+	mb.buildStatementLevelAfterTriggers(tree.TriggerEventUpdate)
+}
+
+// buildRowLevelAfterTriggers adds the postquery that invokes the row-level
+// AFTER triggers for the given event, if there are any.
+func (mb *mutationBuilder) buildRowLevelAfterTriggers(eventType tree.TriggerEventType) {
+	triggers := mb.getTriggers(tree.TriggerActionTimeAfter, true /* forEachRow */, eventType)
+	if len(triggers) == 0 {
+		return
+	}
+	_, ords := triggerRowType(mb.tab)
+	upsert := mb.canaryColID != 0
+	var oldValues, newValues opt.ColList
+	if eventType != tree.TriggerEventInsert || upsert {
+		oldValues = make(opt.ColList, len(ords), len(ords)+1)
+		for i, ord := range ords {
+			oldValues[i] = mb.fetchColIDs[ord]
+		}
+		if upsert {
+			// The canary column is passed as an additional old value. See
+			// afterTriggersBuilder.upsert.
+			oldValues = append(oldValues, mb.canaryColID)
+		}
+	}
+	if eventType != tree.TriggerEventDelete {
+		newValues = make(opt.ColList, len(ords))
+		for i, ord := range ords {
+			newValues[i] = mb.mapToReturnColID(ord)
+		}
+	}
+	mb.ensureWithID()
+	mb.cascades = append(mb.cascades, memo.FKCascade{
+		Builder:   newAfterTriggersBuilder(mb.tab, triggers, eventType, true /* forEachRow */, upsert),
+		WithID:    mb.withID,
+		OldValues: oldValues,
+		NewValues: newValues,
+	})
+}
+
+// buildStatementLevelAfterTriggers adds the postquery that invokes the
+// statement-level AFTER triggers for the given event, if there are any.
+func (mb *mutationBuilder) buildStatementLevelAfterTriggers(eventType tree.TriggerEventType) {
+	triggers := mb.getTriggers(tree.TriggerActionTimeAfter, false /* forEachRow */, eventType)
+	if len(triggers) == 0 {
+		return
+	}
+	mb.cascades = append(mb.cascades, memo.FKCascade{
+		Builder: newAfterTriggersBuilder(
+			mb.tab, triggers, eventType, false /* forEachRow */, false, /* upsert */
+		),
+	})
+}
+
+// afterTriggersBuilder is a memo.CascadeBuilder implementation for AFTER
+// triggers. It builds a query that invokes each trigger function, equivalent
+// to:
+//
+//	SELECT trigger_fn1(new, old, ...), trigger_fn2(new, old, ...)
+//	FROM (SELECT new, old FROM original_mutation_input)
+//
+// For statement-level triggers there is no input, and the functions are
+// invoked once with NULL NEW and OLD records.
+type afterTriggersBuilder struct {
+	mutatedTable cat.Table
+	triggers     []cat.Trigger
+	eventType    tree.TriggerEventType
+	forEachRow   bool
+
+	// upsert is true if the row-level triggers were fired by an INSERT ... ON
+	// CONFLICT DO UPDATE or UPSERT. In that case, the last of the old values is
+	// the canary column of the upsert, which is NULL for the inserted rows and
+	// not NULL for the updated rows. Only the rows that match the event of the
+	// triggers are passed to them.
+	upsert bool
+}
+
+var _ memo.CascadeBuilder = &afterTriggersBuilder{}
+
+func newAfterTriggersBuilder(
+	mutatedTable cat.Table,
+	triggers []cat.Trigger,
+	eventType tree.TriggerEventType,
+	forEachRow bool,
+	upsert bool,
+) *afterTriggersBuilder {
+	return &afterTriggersBuilder{
+		mutatedTable: mutatedTable,
+		triggers:     triggers,
+		eventType:    eventType,
+		forEachRow:   forEachRow,
+		upsert:       upsert,
+	}
+}
+
+// Build is part of the memo.CascadeBuilder interface.
+func (tb *afterTriggersBuilder) Build(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *eval.Context,
+	catalog cat.Catalog,
+	factoryI interface{},
+	binding opt.WithID,
+	bindingProps *props.Relational,
+	oldValues, newValues opt.ColList,
+) (_ memo.RelExpr, err error) {
+	return buildCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI, func(b *Builder) memo.RelExpr {
+		opt.MaybeInjectOptimizerTestingPanic(ctx, evalCtx)
+
+		f := b.factory
+		md := f.Metadata()
+		rowType, _ := triggerRowType(tb.mutatedTable)
+		outScope := b.allocScope()
+		var newCol, oldCol opt.ColumnID
+		if !tb.forEachRow {
+			outScope.expr = f.ConstructNoColsRow()
+		} else {
+			if binding == 0 {
+				panic(errors.AssertionFailedf("row-level AFTER triggers require an input binding"))
+			}
+			inCols := make(opt.ColList, 0, len(oldValues)+len(newValues))
+			inCols = append(inCols, oldValues...)
+			inCols = append(inCols, newValues...)
+			outCols := make(opt.ColList, len(inCols))
+			for i := range inCols {
+				c := md.ColumnMeta(inCols[i])
+				outCols[i] = md.AddColumn(c.Alias, c.Type)
+			}
+
+			// Construct a dummy operator as the binding.
+			md.AddWithBinding(binding, f.ConstructFakeRel(&memo.FakeRelPrivate{
+				Props: bindingProps,
+			}))
+			outScope.expr = f.ConstructWithScan(&memo.WithScanPrivate{
+				With:    binding,
+				InCols:  inCols,
+				OutCols: outCols,
+				ID:      md.NextUniqueID(),
+			})
+
+			oldCols, newCols := outCols[:len(oldValues)], outCols[len(oldValues):]
+			if tb.upsert {
+				// Only pass the inserted rows to INSERT triggers, and the updated rows
+				// to UPDATE triggers. The old values of the inserted rows are NULL.
+				canaryCol := oldCols[len(oldCols)-1]
+				oldCols = oldCols[:len(oldCols)-1]
+				var cond opt.ScalarExpr
+				if tb.eventType == tree.TriggerEventInsert {
+					cond = f.ConstructIs(f.ConstructVariable(canaryCol), memo.NullSingleton)
+					oldCols = nil
+				} else {
+					cond = f.ConstructIsNot(f.ConstructVariable(canaryCol), memo.NullSingleton)
+				}
+				outScope.expr = f.ConstructSelect(outScope.expr, memo.FiltersExpr{
+					f.ConstructFiltersItem(cond),
+				})
+			}
+
+			// Project the NEW and OLD records.
+			projectionsScope := outScope.replace()
+			if len(oldCols) > 0 {
+				oldCol = b.synthesizeColumn(
+					projectionsScope, scopeColName("").WithMetadataName("old"), rowType,
+					nil /* expr */, b.buildTriggerRecord(rowType, oldCols),
+				).id
+			}
+			if len(newCols) > 0 {
+				newCol = b.synthesizeColumn(
+					projectionsScope, scopeColName("").WithMetadataName("new"), rowType,
+					nil /* expr */, b.buildTriggerRecord(rowType, newCols),
+				).id
+			}
+			b.constructProjectForScope(outScope, projectionsScope)
+			outScope = projectionsScope
+		}
+
+		// Invoke each trigger function. The results are discarded.
+		projectionsScope := outScope.replace()
+		for _, trigger := range tb.triggers {
+			newRecord, oldRecord := f.ConstructNull(rowType), f.ConstructNull(rowType)
+			if newCol != 0 {
+				newRecord = f.ConstructVariable(newCol)
+			}
+			if oldCol != 0 {
+				oldRecord = f.ConstructVariable(oldCol)
+			}
+			call := b.buildTriggerFunctionCall(
+				tb.mutatedTable, trigger, tb.eventType, rowType, newRecord, oldRecord,
+			)
+			if tb.forEachRow && trigger.WhenExpr() != "" {
+				cond := b.buildTriggerWhen(trigger, rowType, newCol, oldCol)
+				call = f.ConstructCase(
+					memo.TrueSingleton,
+					memo.ScalarListExpr{f.ConstructWhen(cond, call)},
+					f.ConstructNull(rowType),
+				)
+			}
+			b.synthesizeColumn(
+				projectionsScope, scopeColName("").WithMetadataName(string(trigger.Name())),
+				rowType, nil /* expr */, call,
+			)
+		}
+		b.constructProjectForScope(outScope, projectionsScope)
+		return projectionsScope.expr
+	})
+}
+
+// buildTriggerWhen builds the WHEN condition of a row-level trigger. The
+// condition may reference the NEW and OLD records, which are provided by the
+// given columns. A zero column indicates that the record is not available for
+// the event that fired the trigger.
+func (b *Builder) buildTriggerWhen(
+	trigger cat.Trigger, rowType *types.T, newCol, oldCol opt.ColumnID,
+) opt.ScalarExpr {
+	expr, err := parser.ParseExpr(trigger.WhenExpr())
+	if err != nil {
+		panic(err)
+	}
+	// The records are the only columns that can be referenced by the WHEN
+	// condition. References to their fields (e.g. NEW.a) are resolved as field
+	// accesses; see scope.resolveRecordFieldAccess.
+	whenScope := b.allocScope()
+	addRecord := func(name tree.Name, col opt.ColumnID) {
+		if col != 0 {
+			whenScope.cols = append(whenScope.cols, scopeColumn{
+				name: scopeColName(name),
+				typ:  rowType,
+				id:   col,
+			})
+		}
+	}
+	addRecord(triggerNewParamName, newCol)
+	addRecord(triggerOldParamName, oldCol)
+	texpr := whenScope.resolveAndRequireType(expr, types.Bool)
+	return b.buildScalar(texpr, whenScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+}
+
+// Names of the implicit parameters of a trigger function.
+const (
+	triggerNewParamName         = tree.Name("new")
+	triggerOldParamName         = tree.Name("old")
+	triggerNameParamName        = tree.Name("tg_name")
+	triggerWhenParamName        = tree.Name("tg_when")
+	triggerLevelParamName       = tree.Name("tg_level")
+	triggerOpParamName          = tree.Name("tg_op")
+	triggerRelIDParamName       = tree.Name("tg_relid")
+	triggerTableNameParamName   = tree.Name("tg_table_name")
+	triggerTableSchemaParamName = tree.Name("tg_table_schema")
+	triggerNArgsParamName       = tree.Name("tg_nargs")
+	triggerArgVParamName        = tree.Name("tg_argv")
+)
+
+// buildTriggerFunctionCall builds an invocation of the function of the given
+// trigger, which was fired by the given event on the given table. newRecord
+// and oldRecord provide the NEW and OLD versions of the row, and should be
+// NULL if not applicable. The invocation returns a value of type rowType.
+func (b *Builder) buildTriggerFunctionCall(
+	tab cat.Table,
+	trigger cat.Trigger,
+	eventType tree.TriggerEventType,
+	rowType *types.T,
+	newRecord, oldRecord opt.ScalarExpr,
+) opt.ScalarExpr {
+	f := b.factory
+	funcName, o, err := b.catalog.ResolveFunctionByOID(
+		b.ctx, catid.FuncIDToOID(catid.DescID(trigger.FuncID())),
+	)
+	if err != nil {
+		panic(err)
+	}
+	if o.Language != tree.RoutineLangPLpgSQL {
+		panic(pgerror.Newf(pgcode.InvalidObjectDefinition,
+			"trigger function %s must be written in PL/pgSQL", funcName.Object()))
+	}
+	f.Metadata().AddUserDefinedFunction(o, nil /* invocationTypes */, nil /* name */)
+
+	tabName, err := b.catalog.FullyQualifiedName(b.ctx, tab)
+	if err != nil {
+		panic(err)
+	}
+	level := "STATEMENT"
+	if trigger.ForEachRow() {
+		level = "ROW"
+	}
+	funcArgs := trigger.FuncArgs()
+	argv := tree.NewDArray(types.String)
+	for _, arg := range funcArgs {
+		if err := argv.Append(tree.NewDString(arg)); err != nil {
+			panic(err)
+		}
+	}
+
+	// Build the arguments for the implicit parameters.
+	params := []routineParam{
+		{name: triggerNewParamName, typ: rowType, class: tree.RoutineParamIn},
+		{name: triggerOldParamName, typ: rowType, class: tree.RoutineParamIn},
+		{name: triggerNameParamName, typ: types.Name, class: tree.RoutineParamIn},
+		{name: triggerWhenParamName, typ: types.String, class: tree.RoutineParamIn},
+		{name: triggerLevelParamName, typ: types.String, class: tree.RoutineParamIn},
+		{name: triggerOpParamName, typ: types.String, class: tree.RoutineParamIn},
+		{name: triggerRelIDParamName, typ: types.Oid, class: tree.RoutineParamIn},
+		{name: triggerTableNameParamName, typ: types.Name, class: tree.RoutineParamIn},
+		{name: triggerTableSchemaParamName, typ: types.Name, class: tree.RoutineParamIn},
+		{name: triggerNArgsParamName, typ: types.Int, class: tree.RoutineParamIn},
+		{name: triggerArgVParamName, typ: types.StringArray, class: tree.RoutineParamIn},
+	}
+	args := memo.ScalarListExpr{
+		newRecord,
+		oldRecord,
+		f.ConstructConstVal(tree.NewDName(string(trigger.Name())), types.Name),
+		f.ConstructConstVal(tree.NewDString(trigger.ActionTime().String()), types.String),
+		f.ConstructConstVal(tree.NewDString(level), types.String),
+		f.ConstructConstVal(tree.NewDString(eventType.String()), types.String),
+		f.ConstructConstVal(tree.NewDOid(oid.Oid(tab.ID())), types.Oid),
+		f.ConstructConstVal(tree.NewDName(string(tab.Name())), types.Name),
+		f.ConstructConstVal(tree.NewDName(tabName.Schema()), types.Name),
+		f.ConstructConstVal(tree.NewDInt(tree.DInt(len(funcArgs))), types.Int),
+		f.ConstructConstVal(argv, types.StringArray),
+	}
+
+	// Build the body of the trigger function, with the implicit parameters in
+	// scope. See buildRoutine.
+	bodyScope := b.allocScope()
+	paramCols := make(opt.ColList, len(params))
+	for i := range params {
+		col := b.synthesizeColumn(
+			bodyScope, funcParamColName(params[i].name, i), params[i].typ, nil /* expr */, nil, /* scalar */
+		)
+		col.setParamOrd(i)
+		paramCols[i] = col.id
+	}
+	defer func(trackSchemaDeps, insideUDF, insideDataSource bool) {
+		b.trackSchemaDeps = trackSchemaDeps
+		b.insideUDF = insideUDF
+		b.insideDataSource = insideDataSource
+	}(b.trackSchemaDeps, b.insideUDF, b.insideDataSource)
+	b.insideDataSource = false
+	b.trackSchemaDeps = false
+	b.insideUDF = true
+
+	stmt, err := plpgsql.Parse(o.Body)
+	if err != nil {
+		panic(err)
+	}
+	plBuilder := newPLpgSQLBuilder(
+		b, funcName.Object(), stmt.AST.Label, nil /* colRefs */, params, rowType,
+		false /* isProcedure */, false /* isSetReturning */, nil, /* outScope */
+	)
+	stmtScope := plBuilder.buildRootBlock(stmt.AST, bodyScope, params)
+	expr, physProps, _ := b.finishBuildLastStmt(
+		stmtScope, bodyScope, false /* isSetReturning */, false /* insideDataSource */, rowType,
+	)
+	var bodyStmts []string
+	if b.verboseTracing {
+		bodyStmts = []string{stmt.String()}
+	}
+	return f.ConstructUDFCall(
+		args,
+		&memo.UDFCallPrivate{
+			Def: &memo.UDFDefinition{
+				Name:              funcName.Object(),
+				Typ:               rowType,
+				Volatility:        o.Volatility,
+				CalledOnNullInput: true,
+				RoutineType:       tree.UDFRoutine,
+				RoutineLang:       tree.RoutineLangPLpgSQL,
+				Body:              []memo.RelExpr{expr},
+				BodyProps:         []*physical.Required{physProps},
+				BodyStmts:         bodyStmts,
+				Params:            paramCols,
+			},
+		},
+	)
+}
@@ -358,11 +358,16 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 		switch def := def.(type) {
 		case *tree.UniqueConstraintTableDef:
 			if def.WithoutIndex {
-				tab.addUniqueConstraint(def.Name, def.Columns, def.Predicate, def.WithoutIndex)
+				tab.addUniqueConstraint(
+					def.Name, def.Columns, def.Predicate, def.WithoutIndex, def.Deferrability,
+				)
 			} else if !def.PrimaryKey {
 				tab.addIndex(&def.IndexTableDef, uniqueIndex)
 			}
 
+		case *tree.ExcludeConstraintTableDef:
+			tab.addExclusionConstraint(def)
+
 		case *tree.IndexTableDef:
 			tab.addIndex(def, nonUniqueIndex)
 
@@ -377,6 +382,7 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 						tree.IndexElemList{{Column: def.Name}},
 						nil, /* predicate */
 						def.Unique.WithoutIndex,
+						def.Unique.Deferrability,
 					)
 				} else {
 					tab.addIndex(
@@ -678,6 +684,8 @@ func (tc *Catalog) resolveFK(tab *Table, d *tree.ForeignKeyConstraintTableDef) {
 		matchMethod:              d.Match,
 		deleteAction:             d.Actions.Delete,
 		updateAction:             d.Actions.Update,
+		deferrable:               d.Deferrability != tree.ConstraintNotDeferrable,
+		initiallyDeferred:        d.Deferrability == tree.ConstraintInitiallyDeferred,
 	}
 	tab.outboundFKs = append(tab.outboundFKs, fk)
 	targetTable.inboundFKs = append(targetTable.inboundFKs, fk)
@@ -713,7 +721,11 @@ func (tt *Table) addCheckConstraint(check *tree.CheckConstraintTableDef) {
 }
 
 func (tt *Table) addUniqueConstraint(
-	name tree.Name, columns tree.IndexElemList, predicate tree.Expr, withoutIndex bool,
+	name tree.Name,
+	columns tree.IndexElemList,
+	predicate tree.Expr,
+	withoutIndex bool,
+	deferrability tree.ConstraintDeferrability,
 ) {
 	// We don't currently use unique constraints with an index (those are already
 	// tracked with unique indexes), so don't bother adding them.
@@ -734,6 +746,9 @@ func (tt *Table) addUniqueConstraint(
 		columnOrdinals: cols,
 		withoutIndex:   withoutIndex,
 		validated:      true,
+
+		deferrable:        deferrability != tree.ConstraintNotDeferrable,
+		initiallyDeferred: deferrability == tree.ConstraintInitiallyDeferred,
 	}
 	// Add partial unique constraint predicate.
 	if predicate != nil {
@@ -874,7 +889,9 @@ func (tt *Table) addIndexWithVersion(
 ) *Index {
 	// Add a unique constraint if this is a primary or unique index.
 	if typ != nonUniqueIndex {
-		tt.addUniqueConstraint(def.Name, def.Columns, def.Predicate, false /* withoutIndex */)
+		tt.addUniqueConstraint(
+			def.Name, def.Columns, def.Predicate, false /* withoutIndex */, tree.ConstraintNotDeferrable,
+		)
 	}
 
 	// The test catalog does not support the hash-sharded index syntactic sugar.
@@ -1171,6 +1188,38 @@ func (tt *Table) makeIndexName(defName tree.Name, cols tree.IndexElemList, typ i
 	return name
 }
 
+// addExclusionConstraint adds an exclusion constraint to the table. Unlike
+// the columns of unique constraints, the columns are not sorted so that they
+// stay aligned with their operators.
+func (tt *Table) addExclusionConstraint(def *tree.ExcludeConstraintTableDef) {
+	cols := make([]int, len(def.Elems))
+	ops := make([]treecmp.ComparisonOperatorSymbol, len(def.Elems))
+	for i := range def.Elems {
+		cols[i] = tt.FindOrdinal(string(def.Elems[i].Column))
+		ops[i] = def.Elems[i].Operator.Symbol
+	}
+	name := string(def.Name)
+	if name == "" {
+		name = fmt.Sprintf("%s_excl", tt.TabName.Table())
+	}
+	u := UniqueConstraint{
+		name:           name,
+		tabID:          tt.TabID,
+		columnOrdinals: cols,
+		withoutIndex:   true,
+		validated:      true,
+
+		deferrable:        def.Deferrability != tree.ConstraintNotDeferrable,
+		initiallyDeferred: def.Deferrability == tree.ConstraintInitiallyDeferred,
+
+		exclusionOps: ops,
+	}
+	if def.Predicate != nil {
+		u.predicate = tree.Serialize(def.Predicate)
+	}
+	tt.uniqueConstraints = append(tt.uniqueConstraints, u)
+}
+
 func (tt *Table) makeUniqueConstraintName(defName tree.Name, columns tree.IndexElemList) string {
 	name := string(defName)
 	if name == "" {
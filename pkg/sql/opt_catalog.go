@@ -27,6 +27,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/indexrec"
@@ -39,6 +40,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
@@ -316,6 +318,15 @@ func (oc *optCatalog) ResolveIndex(
 		)
 	}
 
+	if ot, ok := table.(*optTable); ok {
+		// The ordinals of the indexes of optTable may differ from their
+		// ordinals in the descriptor; see newOptTable.
+		for i := range ot.indexes {
+			if ot.indexes[i].idx.GetID() == idx.GetID() {
+				return &ot.indexes[i], oc.tn, nil
+			}
+		}
+	}
 	return table.Index(idx.Ordinal()), oc.tn, nil
 }
 
@@ -553,6 +564,11 @@ func (oc *optCatalog) dataSourceForTable(
 		// optVirtualTable.id for more information).
 		return newOptVirtualTable(ctx, oc, desc, name)
 	}
+	if desc.IsForeignTable() {
+		// Foreign tables have no indexes or stats, and are scanned in the same
+		// way as virtual tables.
+		return newOptVirtualTable(ctx, oc, desc, name)
+	}
 
 	// Even if we have a cached data source, we still have to cross-check that
 	// statistics and the zone config haven't changed.
@@ -741,6 +757,10 @@ type optTable struct {
 	// indexes.
 	indexes []optIndex
 
+	// publicIndexCount is the number of indexes which can be read by the
+	// optimizer, which are the first indexes of indexes. See newOptTable.
+	publicIndexCount int
+
 	// codec is capable of encoding sql table keys.
 	codec keys.SQLCodec
 
@@ -774,6 +794,9 @@ type optTable struct {
 	// constraints for user defined types.
 	checkConstraints []optCheckConstraint
 
+	// triggers is the set of triggers for this table.
+	triggers []optTrigger
+
 	// colMap is a mapping from unique ColumnID to column ordinal within the
 	// table. This is a common lookup that needs to be fast.
 	colMap catalog.TableColMap
@@ -800,8 +823,27 @@ func newOptTable(
 	// Determine how many columns we will potentially need.
 	cols := ot.desc.DeletableColumns()
 	numCols := len(ot.desc.AllColumns())
-	// Add one for each inverted index column.
 	secondaryIndexes := ot.desc.DeletableNonPrimaryIndexes()
+	// BRIN indexes are exposed to the optimizer like write-only indexes: they
+	// are maintained by mutations but never read, since their entries are not
+	// a complete index of the table. They are ordered after the other public
+	// indexes, and before the mutation indexes.
+	var public, brin, mutations []catalog.Index
+	for _, index := range secondaryIndexes {
+		switch {
+		case !index.Public():
+			mutations = append(mutations, index)
+		case index.GetAccessMethod() == descpb.IndexDescriptor_BRIN:
+			brin = append(brin, index)
+		default:
+			public = append(public, index)
+		}
+	}
+	ot.publicIndexCount = 1 + len(public)
+	if len(brin) > 0 {
+		secondaryIndexes = append(append(public, brin...), mutations...)
+	}
+	// Add one for each inverted index column.
 	for _, index := range secondaryIndexes {
 		if index.GetType() == descpb.IndexDescriptor_INVERTED {
 			numCols++
@@ -913,6 +955,23 @@ func newOptTable(
 			predicate:    u.GetPredicate(),
 			withoutIndex: true,
 			validity:     u.GetConstraintValidity(),
+
+			deferrable:        u.UniqueWithoutIndexDesc().Deferrable,
+			initiallyDeferred: u.UniqueWithoutIndexDesc().InitiallyDeferred,
+		}
+		if uwi := u.UniqueWithoutIndexDesc(); uwi.IsExclusion() {
+			// The operators of an exclusion constraint correspond to its columns
+			// in the order they were defined.
+			ops := make([]treecmp.ComparisonOperatorSymbol, len(uwi.ExclusionOperators))
+			for j, name := range uwi.ExclusionOperators {
+				op, err := schemaexpr.ExclusionOperator(name)
+				if err != nil {
+					return nil, err
+				}
+				ops[j] = op
+			}
+			ot.uniqueConstraints[i].columns = uwi.ColumnIDs
+			ot.uniqueConstraints[i].exclusionOps = ops
 		}
 	}
 
@@ -1017,6 +1076,8 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrable:        fk.ForeignKeyDesc().Deferrable,
+			initiallyDeferred: fk.ForeignKeyDesc().InitiallyDeferred,
 		})
 	}
 	for _, fk := range ot.desc.InboundForeignKeys() {
@@ -1030,6 +1091,8 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrable:        fk.ForeignKeyDesc().Deferrable,
+			initiallyDeferred: fk.ForeignKeyDesc().InitiallyDeferred,
 		})
 	}
 
@@ -1084,6 +1147,13 @@ func newOptTable(
 	}
 	ot.checkConstraints = append(ot.checkConstraints, synthesizedChecks...)
 
+	// Add triggers.
+	triggers := desc.GetTriggers()
+	ot.triggers = make([]optTrigger, len(triggers))
+	for i := range triggers {
+		ot.triggers[i].init(&triggers[i])
+	}
+
 	// Add stats last, now that other metadata is initialized.
 	if stats != nil {
 		ot.stats = make([]optTableStat, len(stats))
@@ -1195,6 +1265,11 @@ func (ot *optTable) IsVirtualTable() bool {
 	return false
 }
 
+// IsForeignTable is part of the cat.Table interface.
+func (ot *optTable) IsForeignTable() bool {
+	return false
+}
+
 // IsSystemTable is part of the cat.Table interface.
 func (ot *optTable) IsSystemTable() bool {
 	return catalog.IsSystemDescriptor(ot.desc)
@@ -1226,7 +1301,7 @@ func (ot *optTable) getCol(i int) catalog.Column {
 // IndexCount is part of the cat.Table interface.
 func (ot *optTable) IndexCount() int {
 	// Primary index is always present, so count is always >= 1.
-	return len(ot.desc.ActiveIndexes())
+	return ot.publicIndexCount
 }
 
 // WritableIndexCount is part of the cat.Table interface.
@@ -1394,6 +1469,16 @@ func (ot *optTable) IsHypothetical() bool {
 	return false
 }
 
+// TriggerCount is part of the cat.Table interface.
+func (ot *optTable) TriggerCount() int {
+	return len(ot.triggers)
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optTable) Trigger(i int) cat.Trigger {
+	return &ot.triggers[i]
+}
+
 // lookupColumnOrdinal returns the ordinal of the column with the given ID. A
 // cache makes the lookup O(1).
 func (ot *optTable) lookupColumnOrdinal(colID descpb.ColumnID) (int, error) {
@@ -1783,6 +1868,101 @@ func (oc *optCheckConstraint) ColumnOrdinal(i int) int {
 	return ord
 }
 
+// optTrigger implements cat.Trigger. See that interface for more information
+// on the fields.
+type optTrigger struct {
+	name       tree.Name
+	actionTime tree.TriggerActionTime
+	events     []cat.TriggerEvent
+	forEachRow bool
+	whenExpr   string
+	funcID     cat.StableID
+	funcArgs   []string
+	enabled    bool
+}
+
+var _ cat.Trigger = &optTrigger{}
+
+// init initializes the optTrigger from the given trigger descriptor.
+func (ot *optTrigger) init(desc *descpb.TriggerDescriptor) {
+	ot.name = tree.Name(desc.Name)
+	switch desc.ActionTime {
+	case semenumpb.TriggerActionTime_BEFORE:
+		ot.actionTime = tree.TriggerActionTimeBefore
+	case semenumpb.TriggerActionTime_AFTER:
+		ot.actionTime = tree.TriggerActionTimeAfter
+	case semenumpb.TriggerActionTime_INSTEAD_OF:
+		ot.actionTime = tree.TriggerActionTimeInsteadOf
+	}
+	ot.events = make([]cat.TriggerEvent, len(desc.Events))
+	for i, event := range desc.Events {
+		switch event.Type {
+		case semenumpb.TriggerEventType_INSERT:
+			ot.events[i].EventType = tree.TriggerEventInsert
+		case semenumpb.TriggerEventType_UPDATE:
+			ot.events[i].EventType = tree.TriggerEventUpdate
+		case semenumpb.TriggerEventType_DELETE:
+			ot.events[i].EventType = tree.TriggerEventDelete
+		case semenumpb.TriggerEventType_TRUNCATE:
+			ot.events[i].EventType = tree.TriggerEventTruncate
+		}
+		ot.events[i].Columns = make(tree.NameList, len(event.ColumnNames))
+		for j, colName := range event.ColumnNames {
+			ot.events[i].Columns[j] = tree.Name(colName)
+		}
+	}
+	ot.forEachRow = desc.ForEachRow
+	ot.whenExpr = desc.WhenExpr
+	ot.funcID = cat.StableID(desc.FuncID)
+	ot.funcArgs = desc.FuncArgs
+	ot.enabled = desc.Enabled
+}
+
+// Name is part of the cat.Trigger interface.
+func (ot *optTrigger) Name() tree.Name {
+	return ot.name
+}
+
+// ActionTime is part of the cat.Trigger interface.
+func (ot *optTrigger) ActionTime() tree.TriggerActionTime {
+	return ot.actionTime
+}
+
+// EventCount is part of the cat.Trigger interface.
+func (ot *optTrigger) EventCount() int {
+	return len(ot.events)
+}
+
+// Event is part of the cat.Trigger interface.
+func (ot *optTrigger) Event(i int) cat.TriggerEvent {
+	return ot.events[i]
+}
+
+// ForEachRow is part of the cat.Trigger interface.
+func (ot *optTrigger) ForEachRow() bool {
+	return ot.forEachRow
+}
+
+// WhenExpr is part of the cat.Trigger interface.
+func (ot *optTrigger) WhenExpr() string {
+	return ot.whenExpr
+}
+
+// FuncID is part of the cat.Trigger interface.
+func (ot *optTrigger) FuncID() cat.StableID {
+	return ot.funcID
+}
+
+// FuncArgs is part of the cat.Trigger interface.
+func (ot *optTrigger) FuncArgs() []string {
+	return ot.funcArgs
+}
+
+// Enabled is part of the cat.Trigger interface.
+func (ot *optTrigger) Enabled() bool {
+	return ot.enabled
+}
+
 type optTableStat struct {
 	stat           *stats.TableStatistic
 	columnOrdinals []int
@@ -1939,6 +2119,13 @@ type optUniqueConstraint struct {
 	withoutIndex bool
 	validity     descpb.ConstraintValidity
 
+	deferrable        bool
+	initiallyDeferred bool
+
+	// exclusionOps is set for exclusion constraints, and contains the operator
+	// of each column.
+	exclusionOps []treecmp.ComparisonOperatorSymbol
+
 	uniquenessGuaranteedByAnotherIndex bool
 }
 
@@ -1982,9 +2169,11 @@ func (u *optUniqueConstraint) WithoutIndex() bool {
 	return u.withoutIndex
 }
 
-// Validated is part of the cat.UniqueConstraint interface.
+// Validated is part of the cat.UniqueConstraint interface. Deferrable
+// constraints are never considered validated, since they may be violated
+// until the end of a transaction.
 func (u *optUniqueConstraint) Validated() bool {
-	return u.validity == descpb.ConstraintValidity_Validated
+	return u.validity == descpb.ConstraintValidity_Validated && !u.deferrable
 }
 
 // UniquenessGuaranteedByAnotherIndex is part of the cat.UniqueConstraint
@@ -1995,6 +2184,29 @@ func (u *optUniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return u.uniquenessGuaranteedByAnotherIndex
 }
 
+// Deferrable is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) Deferrable() bool {
+	return u.deferrable
+}
+
+// InitiallyDeferred is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) InitiallyDeferred() bool {
+	return u.initiallyDeferred
+}
+
+// IsExclusion is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) IsExclusion() bool {
+	return u.exclusionOps != nil
+}
+
+// ExclusionOperator is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) ExclusionOperator(i int) treecmp.ComparisonOperatorSymbol {
+	if u.exclusionOps == nil {
+		return treecmp.EQ
+	}
+	return u.exclusionOps[i]
+}
+
 // optForeignKeyConstraint implements cat.ForeignKeyConstraint and represents a
 // foreign key relationship. Both the origin and the referenced table store the
 // same optForeignKeyConstraint (as an outbound and inbound reference,
@@ -2012,6 +2224,9 @@ type optForeignKeyConstraint struct {
 	match        tree.CompositeKeyMatchMethod
 	deleteAction tree.ReferenceAction
 	updateAction tree.ReferenceAction
+
+	deferrable        bool
+	initiallyDeferred bool
 }
 
 var _ cat.ForeignKeyConstraint = &optForeignKeyConstraint{}
@@ -2063,9 +2278,11 @@ func (fk *optForeignKeyConstraint) ReferencedColumnOrdinal(referencedTable cat.T
 	return ord
 }
 
-// Validated is part of the cat.ForeignKeyConstraint interface.
+// Validated is part of the cat.ForeignKeyConstraint interface. Deferrable
+// constraints are never considered validated, since they may be violated
+// until the end of a transaction.
 func (fk *optForeignKeyConstraint) Validated() bool {
-	return fk.validity == descpb.ConstraintValidity_Validated
+	return fk.validity == descpb.ConstraintValidity_Validated && !fk.deferrable
 }
 
 // MatchMethod is part of the cat.ForeignKeyConstraint interface.
@@ -2083,6 +2300,16 @@ func (fk *optForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction
 	return fk.updateAction
 }
 
+// Deferrable is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) Deferrable() bool {
+	return fk.deferrable
+}
+
+// InitiallyDeferred is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) InitiallyDeferred() bool {
+	return fk.initiallyDeferred
+}
+
 // optVirtualTable is similar to optTable but is used with virtual tables.
 type optVirtualTable struct {
 	desc catalog.TableDescriptor
@@ -2128,7 +2355,7 @@ func newOptVirtualTable(
 ) (*optVirtualTable, error) {
 	// Calculate the stable ID (see the comment for optVirtualTable.id).
 	id := cat.StableID(desc.GetID())
-	if name.Catalog() != "" {
+	if name.Catalog() != "" && !desc.IsForeignTable() {
 		// TODO(radu): it's unfortunate that we have to lookup the schema again.
 		found, prefix, err := oc.planner.LookupSchema(ctx, name.Catalog(), name.Schema())
 		if err != nil {
@@ -2266,6 +2493,11 @@ func (ot *optVirtualTable) IsVirtualTable() bool {
 	return true
 }
 
+// IsForeignTable is part of the cat.Table interface.
+func (ot *optVirtualTable) IsForeignTable() bool {
+	return ot.desc.IsForeignTable()
+}
+
 // IsSystemTable is part of the cat.Table interface.
 func (ot *optVirtualTable) IsSystemTable() bool {
 	return false
@@ -2430,6 +2662,16 @@ func (ot *optVirtualTable) IsHypothetical() bool {
 	return false
 }
 
+// TriggerCount is part of the cat.Table interface.
+func (ot *optVirtualTable) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optVirtualTable) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("no triggers"))
+}
+
 // CollectTypes is part of the cat.DataSource interface.
 func (ot *optVirtualTable) CollectTypes(ord int) (descpb.IDs, error) {
 	col := ot.desc.AllColumns()[ord]
@@ -132,6 +132,9 @@ func (ef *execFactory) ConstructLiteralValues(
 func (ef *execFactory) ConstructScan(
 	table cat.Table, index cat.Index, params exec.ScanParams, reqOrdering exec.OutputOrdering,
 ) (exec.Node, error) {
+	if table.IsForeignTable() {
+		return ef.constructForeignTableScan(table, params, reqOrdering)
+	}
 	if table.IsVirtualTable() {
 		return ef.constructVirtualScan(table, index, params, reqOrdering)
 	}
@@ -161,6 +164,9 @@ func (ef *execFactory) ConstructScan(
 	if err != nil {
 		return nil, err
 	}
+	if idx.Primary() {
+		scan.brinConstraints = params.ColumnConstraints
+	}
 
 	scan.isFull = len(scan.spans) == 1 && scan.spans[0].EqualValue(
 		scan.desc.IndexSpan(ef.planner.ExecCfg().Codec, scan.index.GetID()),
@@ -210,6 +216,49 @@ func (ef *execFactory) constructVirtualScan(
 	)
 }
 
+// constructForeignTableScan constructs a scan of a foreign table, which is
+// cataloged like a virtual table: column 0 is the dummy primary key column, and
+// column i+1 is the i-th public column of the table.
+func (ef *execFactory) constructForeignTableScan(
+	table cat.Table, params exec.ScanParams, reqOrdering exec.OutputOrdering,
+) (exec.Node, error) {
+	// Check for explicit use of the dummy column.
+	if params.NeededCols.Contains(0) {
+		return nil, errors.Errorf("use of %s column not allowed.", table.Column(0).ColName())
+	}
+	if params.Locking.IsLocking() {
+		// We shouldn't have allowed SELECT FOR UPDATE for a foreign table.
+		return nil, errors.AssertionFailedf("locking cannot be used with foreign table")
+	}
+	desc := table.(*optVirtualTable).desc
+	publicCols := desc.PublicColumns()
+	scan := &foreignTableScanNode{desc: desc}
+	cols := make([]catalog.Column, 0, params.NeededCols.Len())
+	for ord, ok := params.NeededCols.Next(1); ok; ord, ok = params.NeededCols.Next(ord + 1) {
+		scan.neededCols = append(scan.neededCols, ord-1)
+		cols = append(cols, publicCols[ord-1])
+	}
+	scan.columns = colinfo.ResultColumnsFromColumns(desc.GetID(), cols)
+
+	var n exec.Node = scan
+	var err error
+	if params.HardLimit != 0 {
+		n, err = ef.ConstructLimit(n, tree.NewDInt(tree.DInt(params.HardLimit)), nil /* offset */)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Files in external storage are not ordered, so we have to sort if we have
+	// a required ordering.
+	if len(reqOrdering) != 0 {
+		n, err = ef.ConstructSort(n, reqOrdering, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
 func asDataSource(n exec.Node) planDataSource {
 	plan := n.(planNode)
 	return planDataSource{
@@ -230,6 +279,13 @@ func (ef *execFactory) ConstructFilter(
 	f.filter = filter
 	f.reqOrdering = ReqOrdering(reqOrdering)
 
+	// If the input is a scan of a foreign table, push the filter down into it so
+	// that rows are filtered as they are read.
+	if scan, ok := f.source.plan.(*foreignTableScanNode); ok && scan.filter == nil && len(reqOrdering) == 0 {
+		scan.filter = filter
+		return scan, nil
+	}
+
 	// If there's a spool, pull it up.
 	if spool, ok := f.source.plan.(*spoolNode); ok {
 		f.source.plan = spool.source
@@ -544,6 +600,7 @@ func (ef *execFactory) addAggregations(n *groupNode, aggregations []exec.AggInfo
 			agg.DistsqlBlocklist,
 		)
 		f.filterRenderIdx = int(agg.Filter)
+		f.userDefined = agg.UserDefined
 
 		n.funcs = append(n.funcs, f)
 	}
@@ -1147,6 +1204,9 @@ func (ef *execFactory) ConstructWindow(root exec.Node, wi exec.WindowInfo) (exec
 			columnOrdering: wi.Ordering,
 			frame:          wi.Exprs[i].WindowDef.Frame,
 		}
+		if wi.UserDefinedAggs != nil {
+			p.funcs[i].userDefined = wi.UserDefinedAggs[i]
+		}
 		if len(wi.Ordering) == 0 {
 			frame := p.funcs[i].frame
 			if frame.Mode == treewindow.RANGE && frame.Bounds.HasOffset() {
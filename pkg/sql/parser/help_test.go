@@ -98,6 +98,9 @@ func TestContextualHelp(t *testing.T) {
 		{`ALTER VIRTUAL CLUSTER ??`, `ALTER VIRTUAL CLUSTER`},
 		{`ALTER TENANT ??`, `ALTER VIRTUAL CLUSTER`},
 
+		{`ALTER DOMAIN ??`, `ALTER DOMAIN`},
+		{`ALTER DOMAIN d ??`, `ALTER DOMAIN`},
+
 		{`ALTER TYPE ??`, `ALTER TYPE`},
 		{`ALTER TYPE t ??`, `ALTER TYPE`},
 		{`ALTER TYPE t ADD VALUE ??`, `ALTER TYPE`},
@@ -207,6 +210,8 @@ func TestContextualHelp(t *testing.T) {
 
 		{`CREATE TYPE blah AS ENUM ??`, `CREATE TYPE`},
 		{`DROP TYPE ??`, `DROP TYPE`},
+		{`CREATE DOMAIN ??`, `CREATE DOMAIN`},
+		{`DROP DOMAIN ??`, `DROP DOMAIN`},
 
 		{`CREATE SCHEMA IF ??`, `CREATE SCHEMA`},
 		{`CREATE SCHEMA IF NOT ??`, `CREATE SCHEMA`},
@@ -220,6 +225,9 @@ func TestContextualHelp(t *testing.T) {
 		{`DISCARD ALL ??`, `DISCARD`},
 		{`DISCARD ??`, `DISCARD`},
 
+		{`DO ??`, `DO`},
+		{`DO LANGUAGE ??`, `DO`},
+
 		{`DROP ??`, `DROP`},
 
 		{`DROP DATABASE IF ??`, `DROP DATABASE`},
@@ -297,6 +305,13 @@ func TestContextualHelp(t *testing.T) {
 		{`MOVE ??`, `MOVE`},
 		{`MOVE 1 ??`, `MOVE`},
 
+		{`LISTEN ??`, `LISTEN`},
+
+		{`NOTIFY ??`, `NOTIFY`},
+		{`NOTIFY foo, ??`, `NOTIFY`},
+
+		{`UNLISTEN ??`, `UNLISTEN`},
+
 		{`INSERT INTO ??`, `INSERT`},
 		{`INSERT INTO blah (??`, `<SELECTCLAUSE>`},
 		{`INSERT INTO blah VALUES (1) RETURNING ??`, `INSERT`},
@@ -523,6 +538,7 @@ func TestContextualHelp(t *testing.T) {
 		{`SET LOCAL TIME ??`, `SET LOCAL`},
 		{`SET LOCAL TIME ZONE 'UTC' ??`, `SET LOCAL`},
 
+		{`SET CONSTRAINTS ??`, `SET CONSTRAINTS`},
 		{`SET TRANSACTION ??`, `SET TRANSACTION`},
 		{`SET TRANSACTION ISOLATION LEVEL SNAPSHOT ??`, `SET TRANSACTION`},
 		{`SET TIME ??`, `SET SESSION`},
@@ -588,6 +604,36 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE PROCEDURE ??`, `CREATE PROCEDURE`},
 		{`ALTER PROCEDURE ??`, `ALTER PROCEDURE`},
 		{`DROP PROCEDURE ??`, `DROP PROCEDURE`},
+
+		{`CREATE AGGREGATE ??`, `CREATE AGGREGATE`},
+		{`CREATE OR REPLACE AGGREGATE ??`, `CREATE AGGREGATE`},
+		{`ALTER AGGREGATE ??`, `ALTER AGGREGATE`},
+		{`DROP AGGREGATE ??`, `DROP AGGREGATE`},
+
+		{`CREATE CAST ??`, `CREATE CAST`},
+		{`CREATE CAST (a AS b) ??`, `CREATE CAST`},
+		{`DROP CAST ??`, `DROP CAST`},
+		{`CREATE OPERATOR ??`, `CREATE OPERATOR`},
+		{`CREATE OPERATOR + (FUNCTION = f, ??`, `CREATE OPERATOR`},
+		{`DROP OPERATOR ??`, `DROP OPERATOR`},
+
+		{`CREATE FOREIGN TABLE ??`, `CREATE FOREIGN TABLE`},
+		{`CREATE FOREIGN TABLE foo (a INT) SERVER bar OPTIONS ??`, `CREATE FOREIGN TABLE`},
+		{`DROP FOREIGN TABLE ??`, `DROP TABLE`},
+
+		{`CREATE PUBLICATION ??`, `CREATE PUBLICATION`},
+		{`CREATE PUBLICATION foo FOR TABLE ??`, `CREATE PUBLICATION`},
+		{`DROP PUBLICATION ??`, `DROP PUBLICATION`},
+
+		{`CREATE TEXT SEARCH ??`, `CREATE TEXT SEARCH`},
+		{`CREATE TEXT SEARCH DICTIONARY d (TEMPLATE = ??`, `CREATE TEXT SEARCH`},
+		{`ALTER TEXT SEARCH CONFIGURATION ??`, `ALTER TEXT SEARCH CONFIGURATION`},
+		{`ALTER TEXT SEARCH CONFIGURATION c ADD MAPPING ??`, `ALTER TEXT SEARCH CONFIGURATION`},
+		{`DROP TEXT SEARCH ??`, `DROP TEXT SEARCH`},
+
+		{`CREATE TRIGGER ??`, `CREATE TRIGGER`},
+		{`CREATE TRIGGER foo BEFORE ??`, `CREATE TRIGGER`},
+		{`DROP TRIGGER ??`, `DROP TRIGGER`},
 	}
 
 	// The following checks that the test definition above exercises all
@@ -36,6 +36,7 @@ import (
     "github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
     "github.com/cockroachdb/cockroach/pkg/sql/privilege"
     "github.com/cockroachdb/cockroach/pkg/sql/scanner"
+    "github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
     "github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
     "github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treebin"
     "github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
@@ -639,6 +640,12 @@ func (u *sqlSymUnion) idxElem() tree.IndexElem {
 func (u *sqlSymUnion) idxElems() tree.IndexElemList {
     return u.val.(tree.IndexElemList)
 }
+func (u *sqlSymUnion) excludeElem() tree.ExcludeElem {
+    return u.val.(tree.ExcludeElem)
+}
+func (u *sqlSymUnion) excludeElems() tree.ExcludeElemList {
+    return u.val.(tree.ExcludeElemList)
+}
 func (u *sqlSymUnion) indexInvisibility() tree.IndexInvisibility {
     return u.val.(tree.IndexInvisibility)
 }
@@ -732,6 +739,9 @@ func (u *sqlSymUnion) transactionModes() tree.TransactionModes {
 func (u *sqlSymUnion) compositeKeyMatchMethod() tree.CompositeKeyMatchMethod {
   return u.val.(tree.CompositeKeyMatchMethod)
 }
+func (u *sqlSymUnion) constraintDeferrability() tree.ConstraintDeferrability {
+  return u.val.(tree.ConstraintDeferrability)
+}
 func (u *sqlSymUnion) referenceAction() tree.ReferenceAction {
     return u.val.(tree.ReferenceAction)
 }
@@ -889,6 +899,69 @@ func (u *sqlSymUnion) beginTransaction() *tree.BeginTransaction {
 func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
     return u.val.(*tree.ShowFingerprintOptions)
 }
+func (u *sqlSymUnion) triggerActionTime() tree.TriggerActionTime {
+    return u.val.(tree.TriggerActionTime)
+}
+func (u *sqlSymUnion) triggerEvents() []*tree.TriggerEvent {
+    return u.val.([]*tree.TriggerEvent)
+}
+func (u *sqlSymUnion) triggerEvent() *tree.TriggerEvent {
+    return u.val.(*tree.TriggerEvent)
+}
+func (u *sqlSymUnion) triggerTransitions() []*tree.TriggerTransition {
+    return u.val.([]*tree.TriggerTransition)
+}
+func (u *sqlSymUnion) triggerTransition() *tree.TriggerTransition {
+    return u.val.(*tree.TriggerTransition)
+}
+func (u *sqlSymUnion) triggerForEach() tree.TriggerForEach {
+    return u.val.(tree.TriggerForEach)
+}
+func (u *sqlSymUnion) domainConstraint() tree.DomainConstraint {
+    return u.val.(tree.DomainConstraint)
+}
+func (u *sqlSymUnion) domainConstraints() []tree.DomainConstraint {
+    return u.val.([]tree.DomainConstraint)
+}
+func (u *sqlSymUnion) alterDomainCmd() tree.AlterDomainCmd {
+    return u.val.(tree.AlterDomainCmd)
+}
+func (u *sqlSymUnion) aggregateOption() tree.AggregateOption {
+    return u.val.(tree.AggregateOption)
+}
+func (u *sqlSymUnion) aggregateOptions() tree.AggregateOptions {
+    return u.val.(tree.AggregateOptions)
+}
+func (u *sqlSymUnion) castContext() cast.Context {
+    return u.val.(cast.Context)
+}
+func (u *sqlSymUnion) operatorName() tree.OperatorName {
+    return u.val.(tree.OperatorName)
+}
+func (u *sqlSymUnion) operatorOption() tree.OperatorOption {
+    return u.val.(tree.OperatorOption)
+}
+func (u *sqlSymUnion) operatorOptions() tree.OperatorOptions {
+    return u.val.(tree.OperatorOptions)
+}
+func (u *sqlSymUnion) operatorWithArgs() tree.OperatorWithArgs {
+    return u.val.(tree.OperatorWithArgs)
+}
+func (u *sqlSymUnion) operatorWithArgsList() []tree.OperatorWithArgs {
+    return u.val.([]tree.OperatorWithArgs)
+}
+func (u *sqlSymUnion) createPublication() *tree.CreatePublication {
+    return u.val.(*tree.CreatePublication)
+}
+func (u *sqlSymUnion) textSearchOption() tree.TextSearchOption {
+    return u.val.(tree.TextSearchOption)
+}
+func (u *sqlSymUnion) textSearchOptions() tree.TextSearchOptions {
+    return u.val.(tree.TextSearchOptions)
+}
+func (u *sqlSymUnion) alterTextSearchConfigCmd() tree.AlterTextSearchConfigCmd {
+    return u.val.(tree.AlterTextSearchConfigCmd)
+}
 %}
 
 // NB: the %token definitions must come before the %type definitions in this
@@ -908,9 +981,9 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 // below; search this file for "Keyword category lists".
 
 // Ordinary key words in alphabetical order.
-%token <str> ABORT ABSOLUTE ACCESS ACTION ADD ADMIN AFTER AGGREGATE
-%token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC AS_JSON AT_AT
-%token <str> ASENSITIVE ASYMMETRIC AT ATOMIC ATTRIBUTE AUTHORIZATION AUTOMATIC AVAILABILITY
+%token <str> ABORT ABSOLUTE ACCESS ACTION ADD ADJACENT ADMIN AFTER AGGREGATE
+%token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC AS_JSON AT_AT AT_QUESTION
+%token <str> ASENSITIVE ASSIGNMENT ASYMMETRIC AT ATOMIC ATTRIBUTE AUTHORIZATION AUTOMATIC AVAILABILITY
 
 %token <str> BACKUP BACKUPS BACKWARD BATCH BEFORE BEGIN BETWEEN BIGINT BIGSERIAL BINARY BIT
 %token <str> BUCKET_COUNT
@@ -927,10 +1000,10 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> CURRENT_USER CURSOR CYCLE
 
 %token <str> DATA DATABASE DATABASES DATE DAY DEBUG_IDS DEBUG_PAUSE_ON DEC DEBUG_DUMP_METADATA_SST DECIMAL DEFAULT DEFAULTS DEFINER
-%token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED DETAILS
+%token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED DETAILS DICTIONARY
 %token <str> DISCARD DISTINCT DO DOMAIN DOUBLE DROP
 
-%token <str> ELSE ENCODING ENCRYPTED ENCRYPTION_INFO_DIR ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
+%token <str> EACH ELSE ENCODING ENCRYPTED ENCRYPTION_INFO_DIR ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
 %token <str> EXISTS EXECUTE EXECUTION EXPERIMENTAL
 %token <str> EXPERIMENTAL_FINGERPRINTS EXPERIMENTAL_REPLICA
 %token <str> EXPERIMENTAL_AUDIT EXPERIMENTAL_RELOCATE
@@ -949,12 +1022,12 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> HAVING HASH HEADER HIGH HISTOGRAM HOLD HOUR
 
 %token <str> IDENTITY
-%token <str> IF IFERROR IFNULL IGNORE_FOREIGN_KEYS ILIKE IMMEDIATE IMMEDIATELY IMMUTABLE IMPORT IN INCLUDE
+%token <str> IF IFERROR IFNULL IGNORE_FOREIGN_KEYS ILIKE IMMEDIATE IMMEDIATELY IMMUTABLE IMPLICIT IMPORT IN INCLUDE
 %token <str> INCLUDING INCLUDE_ALL_SECONDARY_TENANTS INCLUDE_ALL_VIRTUAL_CLUSTERS INCREMENT INCREMENTAL INCREMENTAL_LOCATION
 %token <str> INET INET_CONTAINED_BY_OR_EQUALS
 %token <str> INET_CONTAINS_OR_EQUALS INDEX INDEXES INHERITS INJECT INITIALLY
 %token <str> INDEX_BEFORE_PAREN INDEX_BEFORE_NAME_THEN_PAREN INDEX_AFTER_ORDER_BY_BEFORE_AT
-%token <str> INNER INOUT INPUT INSENSITIVE INSERT INT INTEGER
+%token <str> INNER INOUT INPUT INSENSITIVE INSERT INSTEAD INT INTEGER
 %token <str> INTERSECT INTERVAL INTO INTO_DB INVERTED INVOKER IS ISERROR ISNULL ISOLATION
 
 %token <str> JOB JOBS JOIN JSON JSONB JSON_SOME_EXISTS JSON_ALL_EXISTS
@@ -964,21 +1037,21 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> LABEL LANGUAGE LAST LATERAL LATEST LC_CTYPE LC_COLLATE
 %token <str> LEADING LEASE LEAST LEAKPROOF LEFT LESS LEVEL LIKE LIMIT
 %token <str> LINESTRING LINESTRINGM LINESTRINGZ LINESTRINGZM
-%token <str> LIST LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOOKUP LOW LSHIFT
+%token <str> LIST LISTEN LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOOKUP LOW LSHIFT
 
-%token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MONTH MOVE
+%token <str> MAPPING MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MONTH MOVE
 %token <str> MULTILINESTRING MULTILINESTRINGM MULTILINESTRINGZ MULTILINESTRINGZM
 %token <str> MULTIPOINT MULTIPOINTM MULTIPOINTZ MULTIPOINTZM
 %token <str> MULTIPOLYGON MULTIPOLYGONM MULTIPOLYGONZ MULTIPOLYGONZM
 
-%token <str> NAN NAME NAMES NATURAL NEVER NEW_DB_NAME NEW_KMS NEXT NO NOCANCELQUERY NOCONTROLCHANGEFEED
+%token <str> NAN NAME NAMES NATURAL NEVER NEW NEW_DB_NAME NEW_KMS NEXT NO NOCANCELQUERY NOCONTROLCHANGEFEED
 %token <str> NOCONTROLJOB NOCREATEDB NOCREATELOGIN NOCREATEROLE NODE NOLOGIN NOMODIFYCLUSTERSETTING NOREPLICATION
 %token <str> NOSQLLOGIN NO_INDEX_JOIN NO_ZIGZAG_JOIN NO_FULL_SCAN NONE NONVOTERS NORMAL NOT
-%token <str> NOTHING NOTHING_AFTER_RETURNING
+%token <str> NOTHING NOTHING_AFTER_RETURNING NOTIFY
 %token <str> NOTNULL
 %token <str> NOVIEWACTIVITY NOVIEWACTIVITYREDACTED NOVIEWCLUSTERSETTING NOWAIT NULL NULLIF NULLS NUMERIC
 
-%token <str> OF OFF OFFSET OID OIDS OIDVECTOR OLD_KMS ON ONLY OPT OPTION OPTIONS OR
+%token <str> OF OFF OFFSET OID OIDS OIDVECTOR OLD OLD_KMS ON ONLY OPT OPTION OPTIONS OR
 %token <str> ORDER ORDINALITY OTHERS OUT OUTER OVER OVERLAPS OVERLAY OWNED OWNER OPERATOR
 
 %token <str> PARALLEL PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PER PHYSICAL PLACEMENT PLACING
@@ -988,7 +1061,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 
 %token <str> QUERIES QUERY QUOTE
 
-%token <str> RANGE RANGES READ REAL REASON REASSIGN RECURSIVE RECURRING REDACT REF REFERENCES REFRESH
+%token <str> RANGE RANGES READ REAL REASON REASSIGN RECURSIVE RECURRING REDACT REF REFERENCES REFERENCING REFRESH
 %token <str> REGCLASS REGION REGIONAL REGIONS REGNAMESPACE REGPROC REGPROCEDURE REGROLE REGTYPE REINDEX
 %token <str> RELATIVE RELOCATE REMOVE_PATH REMOVE_REGIONS RENAME REPEATABLE REPLACE REPLICATION
 %token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESUME RETENTION RETURNING RETURN RETURNS RETRY REVISION_HISTORY
@@ -1000,7 +1073,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> SHARE SHARED SHOW SIMILAR SIMPLE SIZE SKIP SKIP_LOCALITIES_CHECK SKIP_MISSING_FOREIGN_KEYS
 %token <str> SKIP_MISSING_SEQUENCES SKIP_MISSING_SEQUENCE_OWNERS SKIP_MISSING_VIEWS SKIP_MISSING_UDFS SMALLINT SMALLSERIAL
 %token <str> SNAPSHOT SOME SPLIT SQL SQLLOGIN
-%token <str> STABLE START STATE STATISTICS STATUS STDIN STDOUT STOP STRAIGHT STREAM STRICT STRING STORAGE STORE STORED STORING SUBJECT SUBSTRING SUPER
+%token <str> STABLE START STATE STATEMENT STATISTICS STATUS STDIN STDOUT STOP STRAIGHT STREAM STRICT STRING STORAGE STORE STORED STORING SUBJECT SUBSTRING SUPER
 %token <str> SUPPORT SURVIVE SURVIVAL SYMMETRIC SYNTAX SYSTEM SQRT SUBSCRIPTION STATEMENTS
 
 %token <str> TABLE TABLES TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANT_NAME TENANTS TESTING_RELOCATE TEXT THEN
@@ -1016,7 +1089,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %token <str> VIEWCLUSTERMETADATA VIEWCLUSTERSETTING VIRTUAL VISIBLE INVISIBLE VISIBILITY VOLATILE VOTERS
 %token <str> VIRTUAL_CLUSTER_NAME VIRTUAL_CLUSTER
 
-%token <str> WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRITE
+%token <str> WEIGHT WHEN WHERE WINDOW WITH WITHIN WITHOUT WORK WRITE
 
 %token <str> YEAR
 
@@ -1069,10 +1142,13 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.Statement> alter_role_stmt
 %type <*tree.SetVar> set_or_reset_clause
 %type <tree.Statement> alter_type_stmt
+%type <tree.Statement> alter_domain_stmt
 %type <tree.Statement> alter_schema_stmt
-%type <tree.Statement> alter_unsupported_stmt
 %type <tree.Statement> alter_func_stmt
 %type <tree.Statement> alter_proc_stmt
+%type <tree.Statement> alter_aggregate_stmt
+%type <tree.Statement> alter_text_search_stmt
+%type <tree.AlterTextSearchConfigCmd> alter_text_search_cmd
 
 // ALTER RANGE
 %type <tree.Statement> alter_zone_range_stmt
@@ -1207,6 +1283,15 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.Statement> create_sequence_stmt
 %type <tree.Statement> create_func_stmt
 %type <tree.Statement> create_proc_stmt
+%type <tree.Statement> create_aggregate_stmt
+%type <tree.Statement> create_cast_stmt
+%type <tree.Statement> create_operator_stmt
+%type <tree.Statement> create_foreign_table_stmt
+%type <tree.Statement> create_publication_stmt
+%type <tree.Statement> create_text_search_stmt
+%type <tree.TextSearchOption> text_search_option
+%type <tree.TextSearchOptions> text_search_option_list
+%type <tree.Statement> create_trigger_stmt
 
 %type <*tree.LikeTenantSpec> opt_like_virtual_cluster
 
@@ -1216,8 +1301,10 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <*tree.CreateStatsOptions> create_stats_option
 
 %type <tree.Statement> create_type_stmt
+%type <tree.Statement> create_domain_stmt
 %type <tree.Statement> delete_stmt
 %type <tree.Statement> discard_stmt
+%type <tree.Statement> do_stmt
 
 %type <tree.Statement> drop_stmt
 %type <tree.Statement> drop_ddl_stmt
@@ -1228,10 +1315,17 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.Statement> drop_schema_stmt
 %type <tree.Statement> drop_table_stmt
 %type <tree.Statement> drop_type_stmt
+%type <tree.Statement> drop_domain_stmt
 %type <tree.Statement> drop_view_stmt
 %type <tree.Statement> drop_sequence_stmt
 %type <tree.Statement> drop_func_stmt
 %type <tree.Statement> drop_proc_stmt
+%type <tree.Statement> drop_aggregate_stmt
+%type <tree.Statement> drop_cast_stmt
+%type <tree.Statement> drop_operator_stmt
+%type <tree.Statement> drop_trigger_stmt
+%type <tree.Statement> drop_publication_stmt
+%type <tree.Statement> drop_text_search_stmt
 %type <tree.Statement> drop_virtual_cluster_stmt
 %type <bool>           opt_immediate
 
@@ -1271,6 +1365,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.Statement> set_session_stmt
 %type <tree.Statement> set_csetting_stmt set_or_reset_csetting_stmt
 %type <tree.Statement> set_transaction_stmt
+%type <tree.Statement> set_constraints_stmt
 %type <tree.Statement> set_exprs_internal
 %type <tree.Statement> generic_set
 %type <tree.Statement> set_rest_more
@@ -1334,6 +1429,8 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 
 %type <tree.Statement> transaction_stmt legacy_transaction_stmt legacy_begin_stmt legacy_end_stmt
 %type <tree.Statement> truncate_stmt
+%type <tree.Statement> listen_stmt
+%type <tree.Statement> notify_stmt
 %type <tree.Statement> unlisten_stmt
 %type <tree.Statement> update_stmt
 %type <tree.Statement> upsert_stmt
@@ -1355,6 +1452,10 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <[]string> opt_incremental
 %type <tree.KVOption> kv_option
 %type <[]tree.KVOption> kv_option_list opt_with_options var_set_list opt_with_schedule_options
+%type <tree.KVOption> foreign_table_option
+%type <[]tree.KVOption> foreign_table_option_list opt_foreign_table_options
+%type <[]tree.KVOption> opt_with_publication_options
+%type <*tree.CreatePublication> opt_publication_for_tables
 %type <*tree.BackupOptions> opt_with_backup_options backup_options backup_options_list
 %type <*tree.RestoreOptions> opt_with_restore_options restore_options restore_options_list
 %type <*tree.TenantReplicationOptions> opt_with_replication_options replication_options replication_options_list
@@ -1377,6 +1478,10 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.SelectStatement> set_operation
 
 %type <tree.Expr> alter_column_default
+%type <tree.Expr> opt_domain_default
+%type <tree.DomainConstraint> domain_constraint domain_constraint_elem domain_check_constraint
+%type <[]tree.DomainConstraint> opt_domain_constraint_list
+%type <tree.AlterDomainCmd> alter_domain_cmd
 %type <tree.Expr> alter_column_on_update
 %type <tree.Expr> alter_column_visible
 %type <tree.Direction> opt_asc_desc
@@ -1460,7 +1565,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <[]tree.RangePartition> range_partitions
 %type <empty> opt_all_clause
 %type <empty> opt_privileges_clause
-%type <bool> distinct_clause opt_with_data
+%type <bool> distinct_clause opt_with_data opt_view_recursive
 %type <tree.DistinctOn> distinct_on_clause
 %type <tree.NameList> opt_column_list insert_column_list opt_stats_columns query_stats_cols
 // Note that "no index" variants exist to disable custom ORDER BY <index> syntax
@@ -1520,7 +1625,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <*tree.TenantSpec> virtual_cluster_spec virtual_cluster_spec_opt_all
 
 %type <bool> opt_unique opt_concurrently opt_cluster opt_without_index
-%type <bool> opt_index_access_method
+%type <str> opt_index_access_method
 
 %type <*tree.Limit> limit_clause offset_clause opt_limit_clause
 %type <tree.Expr> select_fetch_first_value
@@ -1564,6 +1669,10 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <bool> opt_ordinality opt_compact
 %type <*tree.Order> sortby sortby_index
 %type <tree.IndexElem> index_elem index_elem_options create_as_param
+%type <tree.ExcludeElemList> exclude_elems
+%type <tree.ExcludeElem> exclude_elem
+%type <str> opt_exclude_using
+%type <tree.Expr> opt_exclude_where
 %type <tree.TableExpr> table_ref numeric_table_ref func_table
 %type <tree.Exprs> rowsfrom_list
 %type <tree.Expr> rowsfrom_item
@@ -1628,6 +1737,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <tree.NamedColumnQualification> col_qualification create_as_col_qualification
 %type <tree.ColumnQualification> col_qualification_elem create_as_col_qualification_elem
 %type <tree.CompositeKeyMatchMethod> key_match
+%type <tree.ConstraintDeferrability> opt_deferrable deferrable constraint_deferrability
 %type <tree.ReferenceActions> reference_actions
 %type <tree.ReferenceAction> reference_action reference_on_delete reference_on_update
 
@@ -1690,13 +1800,15 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <privilege.TargetObjectType> target_object_type
 
 // User defined function relevant components.
-%type <bool> opt_or_replace opt_return_table opt_return_set opt_no
+%type <bool> opt_or_replace opt_return_set opt_no
 %type <str> param_name routine_as
-%type <tree.RoutineParams> opt_routine_param_with_default_list routine_param_with_default_list func_params func_params_list
-%type <tree.RoutineParam> routine_param_with_default routine_param
+%type <tree.RoutineParams> opt_routine_param_with_default_list routine_param_with_default_list func_params func_params_list table_func_column_list
+%type <tree.RoutineParam> routine_param_with_default routine_param table_func_column
 %type <tree.ResolvableTypeReference> routine_return_type routine_param_type
 %type <tree.RoutineOptions> opt_create_routine_opt_list create_routine_opt_list alter_func_opt_list
 %type <tree.RoutineOption> create_routine_opt_item common_routine_opt_item
+%type <tree.RoutineOptions> do_stmt_opt_list
+%type <tree.RoutineOption> do_stmt_opt_item
 %type <tree.RoutineParamClass> routine_param_class
 %type <*tree.UnresolvedObjectName> routine_create_name
 %type <tree.Statement> routine_return_stmt routine_body_stmt
@@ -1704,8 +1816,32 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 %type <*tree.RoutineBody> opt_routine_body
 %type <tree.RoutineObj> function_with_paramtypes
 %type <tree.RoutineObjs> function_with_paramtypes_list
+%type <tree.RoutineObj> aggregate_with_paramtypes
+%type <tree.RoutineObjs> aggregate_with_paramtypes_list
+%type <tree.RoutineParams> aggregate_params
+%type <tree.AggregateOption> aggregate_option
+%type <tree.AggregateOptions> aggregate_option_list
+%type <cast.Context> opt_cast_context
+%type <tree.OperatorName> any_operator
+%type <tree.OperatorOption> operator_option
+%type <tree.OperatorOptions> operator_option_list
+%type <tree.OperatorWithArgs> operator_with_argtypes
+%type <[]tree.OperatorWithArgs> operator_with_argtypes_list
 %type <empty> opt_link_sym
 
+// Trigger relevant components.
+%type <tree.TriggerActionTime> trigger_action_time
+%type <[]*tree.TriggerEvent> trigger_event_list
+%type <*tree.TriggerEvent> trigger_event
+%type <[]*tree.TriggerTransition> opt_trigger_transition_list trigger_transition_list
+%type <*tree.TriggerTransition> trigger_transition
+%type <bool> trigger_transition_type transition_is_table
+%type <tree.TriggerForEach> trigger_for_each trigger_for_type
+%type <tree.Expr> trigger_when
+%type <[]string> trigger_func_args
+%type <str> trigger_func_arg
+%type <empty> opt_as function_or_procedure opt_each
+
 %type <*tree.LabelSpec> label_spec
 
 %type <*tree.ShowRangesOptions> opt_show_ranges_options show_ranges_options
@@ -1750,7 +1886,7 @@ func (u *sqlSymUnion) showFingerprintOptions() *tree.ShowFingerprintOptions {
 // funny behavior of UNBOUNDED on the SQL standard, though.
 %nonassoc  UNBOUNDED         // ideally should have same precedence as IDENT
 %nonassoc  IDENT NULL PARTITION RANGE ROWS GROUPS PRECEDING FOLLOWING CUBE ROLLUP
-%left      CONCAT FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH REMOVE_PATH AT_AT  // multi-character ops
+%left      CONCAT FETCHVAL FETCHTEXT FETCHVAL_PATH FETCHTEXT_PATH REMOVE_PATH AT_AT AT_QUESTION ADJACENT  // multi-character ops
 %left      '|'
 %left      '#'
 %left      '&'
@@ -1804,6 +1940,7 @@ stmt_without_legacy_transaction:
 | execute_stmt               // EXTEND WITH HELP: EXECUTE
 | deallocate_stmt            // EXTEND WITH HELP: DEALLOCATE
 | discard_stmt               // EXTEND WITH HELP: DISCARD
+| do_stmt                    // EXTEND WITH HELP: DO
 | grant_stmt                 // EXTEND WITH HELP: GRANT
 | prepare_stmt               // EXTEND WITH HELP: PREPARE
 | revoke_stmt                // EXTEND WITH HELP: REVOKE
@@ -1819,7 +1956,9 @@ stmt_without_legacy_transaction:
 | fetch_cursor_stmt          // EXTEND WITH HELP: FETCH
 | move_cursor_stmt           // EXTEND WITH HELP: MOVE
 | reindex_stmt
-| unlisten_stmt
+| listen_stmt                // EXTEND WITH HELP: LISTEN
+| notify_stmt                // EXTEND WITH HELP: NOTIFY
+| unlisten_stmt              // EXTEND WITH HELP: UNLISTEN
 | show_commit_timestamp_stmt // EXTEND WITH HELP: SHOW COMMIT TIMESTAMP
 
 // %Help: ALTER
@@ -1829,7 +1968,6 @@ alter_stmt:
   alter_ddl_stmt      // help texts in sub-rule
 | alter_role_stmt     // EXTEND WITH HELP: ALTER ROLE
 | alter_virtual_cluster_stmt   /* SKIP DOC */
-| alter_unsupported_stmt
 | ALTER error         // SHOW HELP: ALTER
 
 alter_ddl_stmt:
@@ -1842,11 +1980,14 @@ alter_ddl_stmt:
 | alter_partition_stmt          // EXTEND WITH HELP: ALTER PARTITION
 | alter_schema_stmt             // EXTEND WITH HELP: ALTER SCHEMA
 | alter_type_stmt               // EXTEND WITH HELP: ALTER TYPE
+| alter_domain_stmt             // EXTEND WITH HELP: ALTER DOMAIN
 | alter_default_privileges_stmt // EXTEND WITH HELP: ALTER DEFAULT PRIVILEGES
 | alter_changefeed_stmt         // EXTEND WITH HELP: ALTER CHANGEFEED
 | alter_backup_stmt             // EXTEND WITH HELP: ALTER BACKUP
 | alter_func_stmt               // EXTEND WITH HELP: ALTER FUNCTION
 | alter_proc_stmt               // EXTEND WITH HELP: ALTER PROCEDURE
+| alter_aggregate_stmt          // EXTEND WITH HELP: ALTER AGGREGATE
+| alter_text_search_stmt        // EXTEND WITH HELP: ALTER TEXT SEARCH CONFIGURATION
 | alter_backup_schedule  // EXTEND WITH HELP: ALTER BACKUP SCHEDULE
 
 // %Help: ALTER TABLE - change the definition of a table
@@ -1872,6 +2013,7 @@ alter_ddl_stmt:
 //   ALTER TABLE ... RENAME TO <newname>
 //   ALTER TABLE ... RENAME [COLUMN] <colname> TO <newname>
 //   ALTER TABLE ... VALIDATE CONSTRAINT <constraintname>
+//   ALTER TABLE ... ALTER CONSTRAINT <constraintname> [NOT] DEFERRABLE [INITIALLY {DEFERRED | IMMEDIATE}]
 //   ALTER TABLE ... SET (storage_param = value, ...)
 //   ALTER TABLE ... SPLIT AT <selectclause> [WITH EXPIRATION <expr>]
 //   ALTER TABLE ... UNSPLIT AT <selectclause>
@@ -2062,6 +2204,102 @@ alter_proc_stmt:
 | alter_proc_set_schema_stmt
 | ALTER PROCEDURE error // SHOW HELP: ALTER PROCEDURE
 
+// %Help: ALTER AGGREGATE - change the definition of an aggregate function
+// %Category: DDL
+// %Text:
+// ALTER AGGREGATE name ( * | [ [ argmode ] [ argname ] argtype [, ...] ] )
+//    RENAME TO new_name
+// ALTER AGGREGATE name ( * | [ [ argmode ] [ argname ] argtype [, ...] ] )
+//    OWNER TO { new_owner | CURRENT_USER | SESSION_USER }
+// ALTER AGGREGATE name ( * | [ [ argmode ] [ argname ] argtype [, ...] ] )
+//    SET SCHEMA new_schema
+//
+// %SeeAlso: CREATE AGGREGATE, DROP AGGREGATE
+alter_aggregate_stmt:
+  ALTER AGGREGATE aggregate_with_paramtypes RENAME TO name
+  {
+    $$.val = &tree.AlterRoutineRename{
+      Function: $3.functionObj(),
+      NewName: tree.Name($6),
+      Aggregate: true,
+    }
+  }
+| ALTER AGGREGATE aggregate_with_paramtypes OWNER TO role_spec
+  {
+    $$.val = &tree.AlterRoutineSetOwner{
+      Function: $3.functionObj(),
+      NewOwner: $6.roleSpec(),
+      Aggregate: true,
+    }
+  }
+| ALTER AGGREGATE aggregate_with_paramtypes SET SCHEMA schema_name
+  {
+    $$.val = &tree.AlterRoutineSetSchema{
+      Function: $3.functionObj(),
+      NewSchemaName: tree.Name($6),
+      Aggregate: true,
+    }
+  }
+| ALTER AGGREGATE error // SHOW HELP: ALTER AGGREGATE
+
+// %Help: ALTER TEXT SEARCH CONFIGURATION - change the definition of a text search configuration
+// %Category: DDL
+// %Text:
+// ALTER TEXT SEARCH CONFIGURATION <name>
+//   ADD MAPPING FOR <token_type> [, ...] WITH <dictionary_name> [, ...]
+// ALTER TEXT SEARCH CONFIGURATION <name>
+//   ALTER MAPPING FOR <token_type> [, ...] WITH <dictionary_name> [, ...]
+// ALTER TEXT SEARCH CONFIGURATION <name>
+//   DROP MAPPING [ IF EXISTS ] FOR <token_type> [, ...]
+// ALTER TEXT SEARCH CONFIGURATION <name>
+//   SET WEIGHT { 'A' | 'B' | 'C' | 'D' } FOR <token_type> [, ...]
+//
+// Token types:
+//   asciiword, word, numword, uint
+//
+// SET WEIGHT assigns a weight to the lexemes produced from the given token
+// types, which is used when ranking documents with ts_rank.
+//
+// %SeeAlso: CREATE TEXT SEARCH, DROP TEXT SEARCH
+alter_text_search_stmt:
+  ALTER TEXT SEARCH CONFIGURATION db_object_name alter_text_search_cmd
+  {
+    $$.val = &tree.AlterTextSearchConfig{
+      Name: $5.unresolvedObjectName(),
+      Cmd: $6.alterTextSearchConfigCmd(),
+    }
+  }
+| ALTER TEXT SEARCH CONFIGURATION error // SHOW HELP: ALTER TEXT SEARCH CONFIGURATION
+
+alter_text_search_cmd:
+  ADD MAPPING FOR name_list WITH type_name_list
+  {
+    $$.val = &tree.AlterTextSearchConfigAddMapping{
+      TokenTypes: $4.nameList(),
+      Dictionaries: $6.unresolvedObjectNames(),
+    }
+  }
+| ALTER MAPPING FOR name_list WITH type_name_list
+  {
+    $$.val = &tree.AlterTextSearchConfigAddMapping{
+      Alter: true,
+      TokenTypes: $4.nameList(),
+      Dictionaries: $6.unresolvedObjectNames(),
+    }
+  }
+| DROP MAPPING FOR name_list
+  {
+    $$.val = &tree.AlterTextSearchConfigDropMapping{TokenTypes: $4.nameList()}
+  }
+| DROP MAPPING IF EXISTS FOR name_list
+  {
+    $$.val = &tree.AlterTextSearchConfigDropMapping{IfExists: true, TokenTypes: $6.nameList()}
+  }
+| SET WEIGHT SCONST FOR name_list
+  {
+    $$.val = &tree.AlterTextSearchConfigSetWeight{Weight: $3, TokenTypes: $5.nameList()}
+  }
+
 // ALTER DATABASE has its error help token here because the ALTER DATABASE
 // prefix is spread over multiple non-terminals.
 | ALTER DATABASE error // SHOW HELP: ALTER DATABASE
@@ -2876,7 +3114,13 @@ alter_table_cmd:
     }
   }
   // ALTER TABLE <name> ALTER CONSTRAINT ...
-| ALTER CONSTRAINT constraint_name error { return unimplementedWithIssueDetail(sqllex, 31632, "alter constraint") }
+| ALTER CONSTRAINT constraint_name constraint_deferrability
+  {
+    $$.val = &tree.AlterTableAlterConstraint{
+      Constraint: tree.Name($3),
+      Deferrability: $4.constraintDeferrability(),
+    }
+  }
   // ALTER TABLE <name> INHERITS ....
 | INHERITS error
   {
@@ -3074,6 +3318,72 @@ identity_option_list:
   identity_option_elem                       { $$.val = []tree.SequenceOption{$1.seqOpt()} }
 | identity_option_list identity_option_elem  { $$.val = append($1.seqOpts(), $2.seqOpt()) }
 
+// %Help: ALTER DOMAIN - change the definition of a domain
+// %Category: DDL
+// %Text: ALTER DOMAIN <type_name> <command>
+//
+// Commands:
+//   ALTER DOMAIN ... { SET DEFAULT <expr> | DROP DEFAULT }
+//   ALTER DOMAIN ... { SET | DROP } NOT NULL
+//   ALTER DOMAIN ... ADD [CONSTRAINT <name>] CHECK (<expr>)
+//   ALTER DOMAIN ... DROP CONSTRAINT [IF EXISTS] <name> [CASCADE | RESTRICT]
+//   ALTER DOMAIN ... RENAME CONSTRAINT <oldname> TO <newname>
+//
+// %SeeAlso: CREATE DOMAIN, DROP DOMAIN
+alter_domain_stmt:
+  ALTER DOMAIN type_name alter_domain_cmd
+  {
+    $$.val = &tree.AlterDomain{
+      Domain: $3.unresolvedObjectName(),
+      Cmd: $4.alterDomainCmd(),
+    }
+  }
+| ALTER DOMAIN type_name RENAME TO error { return unimplemented(sqllex, "alter domain rename") }
+| ALTER DOMAIN type_name OWNER TO error { return unimplemented(sqllex, "alter domain owner") }
+| ALTER DOMAIN type_name SET SCHEMA error { return unimplemented(sqllex, "alter domain set schema") }
+| ALTER DOMAIN type_name VALIDATE CONSTRAINT error { return unimplemented(sqllex, "alter domain validate constraint") }
+| ALTER DOMAIN error // SHOW HELP: ALTER DOMAIN
+
+alter_domain_cmd:
+  alter_column_default
+  {
+    $$.val = &tree.AlterDomainSetDefault{Default: $1.expr()}
+  }
+| SET NOT NULL
+  {
+    $$.val = &tree.AlterDomainSetNotNull{NotNull: true}
+  }
+| DROP NOT NULL
+  {
+    $$.val = &tree.AlterDomainSetNotNull{NotNull: false}
+  }
+| ADD domain_check_constraint
+  {
+    $$.val = &tree.AlterDomainAddConstraint{Constraint: $2.domainConstraint()}
+  }
+| DROP CONSTRAINT IF EXISTS constraint_name opt_drop_behavior
+  {
+    $$.val = &tree.AlterDomainDropConstraint{
+      Constraint: tree.Name($5),
+      IfExists: true,
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP CONSTRAINT constraint_name opt_drop_behavior
+  {
+    $$.val = &tree.AlterDomainDropConstraint{
+      Constraint: tree.Name($3),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| RENAME CONSTRAINT constraint_name TO constraint_name
+  {
+    $$.val = &tree.AlterDomainRenameConstraint{
+      Constraint: tree.Name($3),
+      NewName: tree.Name($5),
+    }
+  }
+
 // %Help: ALTER TYPE - change the definition of a type.
 // %Category: DDL
 // %Text: ALTER TYPE <typename> <command>
@@ -3983,16 +4293,6 @@ import_format:
     $$ = strings.ToUpper($1)
   }
 
-alter_unsupported_stmt:
-  ALTER DOMAIN error
-  {
-    return unimplemented(sqllex, "alter domain")
-  }
-| ALTER AGGREGATE error
-  {
-    return unimplementedWithIssueDetail(sqllex, 74775, "alter aggregate")
-  }
-
 // %Help: IMPORT - load data from file in a distributed manner
 // %Category: CCL
 // %Text:
@@ -4153,6 +4453,42 @@ call_stmt:
     $$.val = &tree.Call{Proc: p}
   }
 
+// %Help: DO - execute an anonymous code block
+// %Category: Misc
+// %Text: DO [ LANGUAGE <lang_name> ] <code>
+// %SeeAlso: CREATE FUNCTION, CALL
+do_stmt:
+  DO do_stmt_opt_list
+  {
+    doBlock, err := tree.MakeDoBlock($2.routineOptions())
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = doBlock
+  }
+| DO error // SHOW HELP: DO
+
+do_stmt_opt_list:
+  do_stmt_opt_item { $$.val = tree.RoutineOptions{$1.functionOption()} }
+| do_stmt_opt_list do_stmt_opt_item
+  {
+    $$.val = append($1.routineOptions(), $2.functionOption())
+  }
+
+do_stmt_opt_item:
+  SCONST
+  {
+    $$.val = tree.RoutineBodyStr($1)
+  }
+| LANGUAGE non_reserved_word_or_sconst
+  {
+    lang, err := tree.AsRoutineLanguage($2)
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = lang
+  }
+
 // The COPY grammar in postgres has 3 different versions, all of which are supported by postgres:
 // 1) The "really old" syntax from v7.2 and prior
 // 2) Pre 9.0 using hard-wired, space-separated options
@@ -4164,14 +4500,24 @@ copy_stmt:
   {
     /* FORCE DOC */
     name := $2.unresolvedObjectName().ToTableName()
-    if $7.expr() != nil {
-      return unimplementedWithIssue(sqllex, 54580)
-    }
     $$.val = &tree.CopyFrom{
        Table: name,
        Columns: $3.nameList(),
        Stdin: true,
        Options: *$6.copyOptions(),
+       Where: tree.NewWhere(tree.AstWhere, $7.expr()),
+    }
+  }
+| COPY table_name opt_column_list FROM SCONST opt_with_copy_options opt_where_clause
+  {
+    /* FORCE DOC */
+    name := $2.unresolvedObjectName().ToTableName()
+    $$.val = &tree.CopyFrom{
+       Table: name,
+       Columns: $3.nameList(),
+       File: tree.NewStrVal($5),
+       Options: *$6.copyOptions(),
+       Where: tree.NewWhere(tree.AstWhere, $7.expr()),
     }
   }
 | COPY table_name opt_column_list FROM error
@@ -4188,9 +4534,16 @@ copy_stmt:
        Options: *$6.copyOptions(),
     }
   }
-| COPY table_name opt_column_list TO error
+| COPY table_name opt_column_list TO SCONST opt_with_copy_options
   {
-    return unimplementedWithIssue(sqllex, 97181)
+    /* FORCE DOC */
+    name := $2.unresolvedObjectName().ToTableName()
+    $$.val = &tree.CopyTo{
+       Table: name,
+       Columns: $3.nameList(),
+       File: tree.NewStrVal($5),
+       Options: *$6.copyOptions(),
+    }
   }
 | COPY '(' copy_to_stmt ')' TO STDOUT opt_with_copy_options
    {
@@ -4200,9 +4553,14 @@ copy_stmt:
         Options: *$7.copyOptions(),
      }
    }
-| COPY '(' copy_to_stmt ')' TO error
+| COPY '(' copy_to_stmt ')' TO SCONST opt_with_copy_options
    {
-     return unimplementedWithIssue(sqllex, 96590)
+     /* FORCE DOC */
+     $$.val = &tree.CopyTo{
+        Statement: $3.stmt(),
+        File: tree.NewStrVal($6),
+        Options: *$7.copyOptions(),
+     }
    }
 
 opt_with_copy_options:
@@ -4284,17 +4642,21 @@ copy_options:
   {
     $$.val = &tree.CopyOptions{Escape: tree.NewStrVal($2)}
   }
-| FORCE QUOTE error
+| FORCE QUOTE name_list
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_quote")
+    $$.val = &tree.CopyOptions{ForceQuote: $3.nameList()}
   }
-| FORCE NOT NULL error
+| FORCE QUOTE '*'
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_not_null")
+    $$.val = &tree.CopyOptions{ForceQuoteAll: true}
   }
-| FORCE NULL error
+| FORCE NOT NULL name_list
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_null")
+    $$.val = &tree.CopyOptions{ForceNotNull: $4.nameList()}
+  }
+| FORCE NULL name_list
+  {
+    $$.val = &tree.CopyOptions{ForceNull: $3.nameList()}
   }
 | ENCODING SCONST
   {
@@ -4369,17 +4731,21 @@ copy_generic_options:
   {
     $$.val = &tree.CopyOptions{Escape: tree.NewStrVal($2)}
   }
-| FORCE_QUOTE error
+| FORCE_QUOTE '(' name_list ')'
+  {
+    $$.val = &tree.CopyOptions{ForceQuote: $3.nameList()}
+  }
+| FORCE_QUOTE '*'
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_quote")
+    $$.val = &tree.CopyOptions{ForceQuoteAll: true}
   }
-| FORCE_NOT_NULL error
+| FORCE_NOT_NULL '(' name_list ')'
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_not_null")
+    $$.val = &tree.CopyOptions{ForceNotNull: $3.nameList()}
   }
-| FORCE_NULL error
+| FORCE_NULL '(' name_list ')'
   {
-    return unimplementedWithIssueDetail(sqllex, 41608, "force_null")
+    $$.val = &tree.CopyOptions{ForceNull: $3.nameList()}
   }
 | ENCODING SCONST
   {
@@ -4720,17 +5086,20 @@ create_extension_stmt:
 // %Text:
 // CREATE [ OR REPLACE ] FUNCTION
 //    name ( [ [ argmode ] [ argname ] argtype [, ...] ] )
-//    [ RETURNS rettype ]
+//    [ RETURNS rettype
+//      | RETURNS TABLE ( column_name column_type [, ...] ) ]
 //  { LANGUAGE lang_name
 //    | { IMMUTABLE | STABLE | VOLATILE }
 //    | [ NOT ] LEAKPROOF
 //    | { CALLED ON NULL INPUT | RETURNS NULL ON NULL INPUT | STRICT }
+//    | [ EXTERNAL ] SECURITY { INVOKER | DEFINER }
+//    | SET configuration_parameter { TO | = } value
 //    | AS 'definition'
 //  } ...
 // %SeeAlso: WEBDOCS/create-function.html
 create_func_stmt:
   CREATE opt_or_replace FUNCTION routine_create_name '(' opt_routine_param_with_default_list ')'
-  RETURNS opt_return_table opt_return_set routine_return_type
+  RETURNS opt_return_set routine_return_type
   opt_create_routine_opt_list opt_routine_body
   {
     name := $4.unresolvedObjectName().ToRoutineName()
@@ -4740,11 +5109,36 @@ create_func_stmt:
       Name: name,
       Params: $6.routineParams(),
       ReturnType: &tree.RoutineReturnType{
-        Type: $11.typeReference(),
-        SetOf: $10.bool(),
+        Type: $10.typeReference(),
+        SetOf: $9.bool(),
+      },
+      Options: $11.routineOptions(),
+      RoutineBody: $12.routineBody(),
+    }
+  }
+| CREATE opt_or_replace FUNCTION routine_create_name '(' opt_routine_param_with_default_list ')'
+  RETURNS TABLE '(' table_func_column_list ')'
+  opt_create_routine_opt_list opt_routine_body
+  {
+    name := $4.unresolvedObjectName().ToRoutineName()
+    // The columns of the returned table are OUT parameters of the function,
+    // which returns a set of rows of their types.
+    cols := $11.routineParams()
+    var retType tree.ResolvableTypeReference = types.AnyTuple
+    if len(cols) == 1 {
+      retType = cols[0].Type
+    }
+    $$.val = &tree.CreateRoutine{
+      IsProcedure: false,
+      Replace: $2.bool(),
+      Name: name,
+      Params: append($6.routineParams(), cols...),
+      ReturnType: &tree.RoutineReturnType{
+        Type: retType,
+        SetOf: true,
       },
-      Options: $12.routineOptions(),
-      RoutineBody: $13.routineBody(),
+      Options: $13.routineOptions(),
+      RoutineBody: $14.routineBody(),
     }
   }
 | CREATE opt_or_replace FUNCTION routine_create_name '(' opt_routine_param_with_default_list ')'
@@ -4789,14 +5183,551 @@ create_proc_stmt:
   }
 | CREATE opt_or_replace PROCEDURE error // SHOW HELP: CREATE PROCEDURE
 
+// %Help: CREATE AGGREGATE - define a new aggregate function
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] AGGREGATE
+//    name ( * | [ argmode ] [ argname ] argtype [, ...] ) (
+//      SFUNC = sfunc,
+//      STYPE = state_data_type
+//      [ , FINALFUNC = ffunc ]
+//      [ , COMBINEFUNC = combinefunc ]
+//      [ , INITCOND = initial_condition ]
+//    )
+// %SeeAlso: DROP AGGREGATE, ALTER AGGREGATE, CREATE FUNCTION
+create_aggregate_stmt:
+  CREATE opt_or_replace AGGREGATE routine_create_name aggregate_params '(' aggregate_option_list ')'
+  {
+    $$.val = &tree.CreateAggregate{
+      Replace: $2.bool(),
+      Name: $4.unresolvedObjectName().ToRoutineName(),
+      Params: $5.routineParams(),
+      Options: $7.aggregateOptions(),
+    }
+  }
+| CREATE opt_or_replace AGGREGATE error // SHOW HELP: CREATE AGGREGATE
+
+aggregate_params:
+  '(' '*' ')'
+  {
+    $$.val = tree.RoutineParams{}
+  }
+| func_params
+
+aggregate_option_list:
+  aggregate_option
+  {
+    $$.val = tree.AggregateOptions{$1.aggregateOption()}
+  }
+| aggregate_option_list ',' aggregate_option
+  {
+    $$.val = append($1.aggregateOptions(), $3.aggregateOption())
+  }
+
+aggregate_option:
+  name '=' typename
+  {
+    $$.val = tree.AggregateOption{Name: $1, Type: $3.typeReference()}
+  }
+| name '=' SCONST
+  {
+    $$.val = tree.AggregateOption{Name: $1, Value: tree.NewStrVal($3)}
+  }
+| name '=' numeric_only
+  {
+    $$.val = tree.AggregateOption{Name: $1, Value: $3.expr()}
+  }
+
+// %Help: CREATE CAST - define a new cast
+// %Category: DDL
+// %Text:
+// CREATE CAST (source_type AS target_type)
+//   WITH FUNCTION function_name [ (argument_type [, ...]) ]
+//   [ AS ASSIGNMENT | AS IMPLICIT ]
+// %SeeAlso: DROP CAST, CREATE FUNCTION
+create_cast_stmt:
+  CREATE CAST '(' typename AS typename ')' WITH FUNCTION function_with_paramtypes opt_cast_context
+  {
+    $$.val = &tree.CreateCast{
+      Source: $4.typeReference(),
+      Target: $6.typeReference(),
+      Func: $10.functionObj(),
+      Context: $11.castContext(),
+    }
+  }
+| CREATE CAST '(' typename AS typename ')' WITHOUT FUNCTION opt_cast_context
+  {
+    return unimplemented(sqllex, "create cast without function")
+  }
+| CREATE CAST '(' typename AS typename ')' WITH INOUT opt_cast_context
+  {
+    return unimplemented(sqllex, "create cast with inout")
+  }
+| CREATE CAST error // SHOW HELP: CREATE CAST
+
+opt_cast_context:
+  AS ASSIGNMENT
+  {
+    $$.val = cast.ContextAssignment
+  }
+| AS IMPLICIT
+  {
+    $$.val = cast.ContextImplicit
+  }
+| /* EMPTY */
+  {
+    $$.val = cast.ContextExplicit
+  }
+
+// %Help: CREATE OPERATOR - define a new operator
+// %Category: DDL
+// %Text:
+// CREATE OPERATOR name (
+//    { FUNCTION | PROCEDURE } = function_name
+//    [ , LEFTARG = left_type ] , RIGHTARG = right_type
+//    [ , COMMUTATOR = com_op ] [ , NEGATOR = neg_op ]
+//    [ , RESTRICT = res_proc ] [ , JOIN = join_proc ]
+//    [ , HASHES ] [ , MERGES ]
+// )
+// %SeeAlso: DROP OPERATOR, CREATE FUNCTION
+create_operator_stmt:
+  CREATE OPERATOR any_operator '(' operator_option_list ')'
+  {
+    $$.val = &tree.CreateOperator{
+      Name: $3.operatorName(),
+      Options: $5.operatorOptions(),
+    }
+  }
+| CREATE OPERATOR error // SHOW HELP: CREATE OPERATOR
+
+operator_option_list:
+  operator_option
+  {
+    $$.val = tree.OperatorOptions{$1.operatorOption()}
+  }
+| operator_option_list ',' operator_option
+  {
+    $$.val = append($1.operatorOptions(), $3.operatorOption())
+  }
+
+operator_option:
+  name '=' typename
+  {
+    $$.val = tree.OperatorOption{Name: $1, Type: $3.typeReference()}
+  }
+| JOIN '=' typename
+  {
+    $$.val = tree.OperatorOption{Name: tree.OperatorOptionJoin, Type: $3.typeReference()}
+  }
+| name '=' all_op
+  {
+    $$.val = tree.OperatorOption{Name: $1, Operator: &tree.OperatorName{Symbol: $3.op().String()}}
+  }
+| name '=' OPERATOR '(' any_operator ')'
+  {
+    op := $5.operatorName()
+    $$.val = tree.OperatorOption{Name: $1, Operator: &op}
+  }
+| name
+  {
+    $$.val = tree.OperatorOption{Name: $1}
+  }
+
+any_operator:
+  all_op
+  {
+    $$.val = tree.OperatorName{Symbol: $1.op().String()}
+  }
+| name '.' all_op
+  {
+    $$.val = tree.OperatorName{Schema: tree.Name($1), Symbol: $3.op().String()}
+  }
+
+// %Help: CREATE FOREIGN TABLE - define a table over files in external storage
+// %Category: DDL
+// %Text:
+// CREATE FOREIGN TABLE [IF NOT EXISTS] <tablename> (
+//     <colname> <type> [NULL | NOT NULL] [, ...]
+//   )
+//   SERVER <connection_name>
+//   OPTIONS ( filename '<path>' [, format '<format>'] [, <option> '<value>' ...] )
+//
+// The server is the name of an external connection created with CREATE
+// EXTERNAL CONNECTION, and the filename is relative to it. The filename may
+// contain a glob pattern. The columns of parquet files are matched to the
+// columns of the table by name.
+//
+// Options:
+//   filename   the path of the file(s) to read
+//   format     the format of the files: 'csv' (default), 'avro' or 'parquet'
+//   delimiter  the field delimiter of CSV files
+//   header     whether CSV files start with a header row: 'true' or 'false'
+//   null       the string that represents NULL in CSV files
+//
+// %SeeAlso: CREATE EXTERNAL CONNECTION, DROP TABLE
+create_foreign_table_stmt:
+  CREATE FOREIGN TABLE table_name '(' opt_table_elem_list ')' SERVER name opt_foreign_table_options
+  {
+    $$.val = &tree.CreateForeignTable{
+      Table: $4.unresolvedObjectName().ToTableName(),
+      Defs: $6.tblDefs(),
+      Server: tree.Name($9),
+      Options: $10.kvOptions(),
+    }
+  }
+| CREATE FOREIGN TABLE IF NOT EXISTS table_name '(' opt_table_elem_list ')' SERVER name opt_foreign_table_options
+  {
+    $$.val = &tree.CreateForeignTable{
+      IfNotExists: true,
+      Table: $7.unresolvedObjectName().ToTableName(),
+      Defs: $9.tblDefs(),
+      Server: tree.Name($12),
+      Options: $13.kvOptions(),
+    }
+  }
+| CREATE FOREIGN TABLE error // SHOW HELP: CREATE FOREIGN TABLE
+
+opt_foreign_table_options:
+  OPTIONS '(' foreign_table_option_list ')'
+  {
+    $$.val = $3.kvOptions()
+  }
+| /* EMPTY */
+  {
+    $$.val = nil
+  }
+
+foreign_table_option_list:
+  foreign_table_option
+  {
+    $$.val = []tree.KVOption{$1.kvOption()}
+  }
+| foreign_table_option_list ',' foreign_table_option
+  {
+    $$.val = append($1.kvOptions(), $3.kvOption())
+  }
+
+foreign_table_option:
+  name SCONST
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: tree.NewStrVal($2)}
+  }
+
+// %Help: CREATE PUBLICATION - define a new publication
+// %Category: DDL
+// %Text:
+// CREATE PUBLICATION <name>
+//   [ FOR ALL TABLES | FOR TABLE <tablename> [, ...] ]
+//   [ WITH ( publish = '<operation> [, ...]' ) ]
+//
+// A publication is a set of tables whose changes can be streamed to a
+// logical replication client, such as a Postgres subscriber, with the
+// pgoutput plugin.
+//
+// Options:
+//   publish  the operations to publish: 'insert', 'update', 'delete' and
+//            'truncate' (default: all of them)
+//
+// %SeeAlso: DROP PUBLICATION
+create_publication_stmt:
+  CREATE PUBLICATION name opt_publication_for_tables opt_with_publication_options
+  {
+    n := $4.createPublication()
+    n.Name = tree.Name($3)
+    n.Options = $5.kvOptions()
+    $$.val = n
+  }
+| CREATE PUBLICATION error // SHOW HELP: CREATE PUBLICATION
+
+opt_publication_for_tables:
+  FOR ALL TABLES
+  {
+    $$.val = &tree.CreatePublication{AllTables: true}
+  }
+| FOR TABLE table_name_list
+  {
+    $$.val = &tree.CreatePublication{Tables: $3.tableNames()}
+  }
+| /* EMPTY */
+  {
+    $$.val = &tree.CreatePublication{}
+  }
+
+opt_with_publication_options:
+  WITH '(' kv_option_list ')'
+  {
+    $$.val = $3.kvOptions()
+  }
+| /* EMPTY */
+  {
+    $$.val = nil
+  }
+
+// %Help: CREATE TEXT SEARCH - define a text search configuration or dictionary
+// %Category: DDL
+// %Text:
+// CREATE TEXT SEARCH CONFIGURATION <name> ( PARSER = default | COPY = <source_config> )
+// CREATE TEXT SEARCH DICTIONARY <name> ( TEMPLATE = <template> [, <option> = <value> [, ...] ] )
+//
+// A configuration created with PARSER = default has no mappings. Mappings
+// from token types to dictionaries are added with ALTER TEXT SEARCH
+// CONFIGURATION.
+//
+// Dictionary templates and their options:
+//   simple    STOPWORDS, STOPWORD_LIST, ACCEPT
+//   snowball  LANGUAGE (required), STOPWORDS, STOPWORD_LIST
+//   synonym   SYNONYMS (required)
+//
+// Options:
+//   STOPWORDS      the name of a built-in stopword list, e.g. 'english'
+//   STOPWORD_LIST  a list of stopwords separated by commas or whitespace
+//   ACCEPT         whether words which are not stopwords are accepted
+//                  (default: true)
+//   LANGUAGE       the language of the snowball stemmer
+//   SYNONYMS       a list of 'word synonym' pairs separated by commas
+//
+// %SeeAlso: ALTER TEXT SEARCH CONFIGURATION, DROP TEXT SEARCH
+create_text_search_stmt:
+  CREATE TEXT SEARCH CONFIGURATION db_object_name '(' text_search_option_list ')'
+  {
+    $$.val = &tree.CreateTextSearchConfig{
+      Name: $5.unresolvedObjectName(),
+      Options: $7.textSearchOptions(),
+    }
+  }
+| CREATE TEXT SEARCH DICTIONARY db_object_name '(' text_search_option_list ')'
+  {
+    $$.val = &tree.CreateTextSearchDictionary{
+      Name: $5.unresolvedObjectName(),
+      Options: $7.textSearchOptions(),
+    }
+  }
+| CREATE TEXT SEARCH error // SHOW HELP: CREATE TEXT SEARCH
+
+text_search_option_list:
+  text_search_option
+  {
+    $$.val = tree.TextSearchOptions{$1.textSearchOption()}
+  }
+| text_search_option_list ',' text_search_option
+  {
+    $$.val = append($1.textSearchOptions(), $3.textSearchOption())
+  }
+
+text_search_option:
+  name '=' db_object_name
+  {
+    $$.val = tree.TextSearchOption{Name: $1, Value: $3.unresolvedObjectName().ToUnresolvedName()}
+  }
+| name '=' SCONST
+  {
+    $$.val = tree.TextSearchOption{Name: $1, Value: tree.NewStrVal($3)}
+  }
+| name '=' DEFAULT
+  {
+    $$.val = tree.TextSearchOption{Name: $1, Value: tree.DefaultVal{}}
+  }
+| name '=' TRUE
+  {
+    $$.val = tree.TextSearchOption{Name: $1, Value: tree.DBoolTrue}
+  }
+| name '=' FALSE
+  {
+    $$.val = tree.TextSearchOption{Name: $1, Value: tree.DBoolFalse}
+  }
+
+// %Help: CREATE TRIGGER - define a new trigger
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] TRIGGER name { BEFORE | AFTER | INSTEAD OF } { event [ OR ... ] }
+//    ON table_name
+//    [ REFERENCING { { OLD | NEW } TABLE [ AS ] transition_relation_name } [ ... ] ]
+//    [ FOR [ EACH ] { ROW | STATEMENT } ]
+//    [ WHEN ( condition ) ]
+//    EXECUTE { FUNCTION | PROCEDURE } function_name ( arguments )
+//
+// where event can be one of:
+//
+//    INSERT
+//    UPDATE [ OF column_name [, ... ] ]
+//    DELETE
+//    TRUNCATE
+// %SeeAlso: CREATE FUNCTION, DROP TRIGGER
+create_trigger_stmt:
+  CREATE opt_or_replace TRIGGER name trigger_action_time trigger_event_list
+  ON table_name opt_trigger_transition_list trigger_for_each trigger_when
+  EXECUTE function_or_procedure func_name '(' trigger_func_args ')'
+  {
+    $$.val = &tree.CreateTrigger{
+      Replace: $2.bool(),
+      Name: tree.Name($4),
+      ActionTime: $5.triggerActionTime(),
+      Events: $6.triggerEvents(),
+      TableName: $8.unresolvedObjectName(),
+      Transitions: $9.triggerTransitions(),
+      ForEach: $10.triggerForEach(),
+      When: $11.expr(),
+      FuncName: $14.unresolvedName(),
+      FuncArgs: $16.strs(),
+    }
+  }
+| CREATE opt_or_replace CONSTRAINT TRIGGER name AFTER trigger_event_list
+  ON table_name opt_deferrable FOR opt_each ROW trigger_when
+  EXECUTE function_or_procedure func_name '(' trigger_func_args ')'
+  {
+    if $10.constraintDeferrability() != tree.ConstraintNotDeferrable {
+      return unimplementedWithIssueDetail(sqllex, 28296, "deferrable constraint trigger")
+    }
+    $$.val = &tree.CreateTrigger{
+      Replace: $2.bool(),
+      Constraint: true,
+      Name: tree.Name($5),
+      ActionTime: tree.TriggerActionTimeAfter,
+      Events: $7.triggerEvents(),
+      TableName: $9.unresolvedObjectName(),
+      ForEach: tree.TriggerForEachRow,
+      When: $14.expr(),
+      FuncName: $17.unresolvedName(),
+      FuncArgs: $19.strs(),
+    }
+  }
+| CREATE opt_or_replace TRIGGER error // SHOW HELP: CREATE TRIGGER
+
+trigger_action_time:
+  BEFORE { $$.val = tree.TriggerActionTimeBefore }
+| AFTER { $$.val = tree.TriggerActionTimeAfter }
+| INSTEAD OF { $$.val = tree.TriggerActionTimeInsteadOf }
+
+trigger_event_list:
+  trigger_event
+  {
+    $$.val = []*tree.TriggerEvent{$1.triggerEvent()}
+  }
+| trigger_event_list OR trigger_event
+  {
+    $$.val = append($1.triggerEvents(), $3.triggerEvent())
+  }
+
+trigger_event:
+  INSERT
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventInsert}
+  }
+| DELETE
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventDelete}
+  }
+| UPDATE
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventUpdate}
+  }
+| UPDATE OF name_list
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventUpdate, Columns: $3.nameList()}
+  }
+| TRUNCATE
+  {
+    $$.val = &tree.TriggerEvent{EventType: tree.TriggerEventTruncate}
+  }
+
+opt_trigger_transition_list:
+  REFERENCING trigger_transition_list
+  {
+    $$.val = $2.triggerTransitions()
+  }
+| /* EMPTY */
+  {
+    $$.val = []*tree.TriggerTransition(nil)
+  }
+
+trigger_transition_list:
+  trigger_transition
+  {
+    $$.val = []*tree.TriggerTransition{$1.triggerTransition()}
+  }
+| trigger_transition_list trigger_transition
+  {
+    $$.val = append($1.triggerTransitions(), $2.triggerTransition())
+  }
+
+trigger_transition:
+  trigger_transition_type transition_is_table opt_as table_alias_name
+  {
+    $$.val = &tree.TriggerTransition{
+      Name: tree.Name($4),
+      IsNew: $1.bool(),
+      IsTable: $2.bool(),
+    }
+  }
+
+trigger_transition_type:
+  NEW { $$.val = true }
+| OLD { $$.val = false }
+
+transition_is_table:
+  TABLE { $$.val = true }
+| ROW { $$.val = false }
+
+opt_as:
+  AS {}
+| /* EMPTY */ {}
+
+trigger_for_each:
+  FOR opt_each trigger_for_type
+  {
+    $$.val = $3.triggerForEach()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.TriggerForEachStatement
+  }
+
+opt_each:
+  EACH {}
+| /* EMPTY */ {}
+
+trigger_for_type:
+  ROW { $$.val = tree.TriggerForEachRow }
+| STATEMENT { $$.val = tree.TriggerForEachStatement }
+
+trigger_when:
+  WHEN '(' a_expr ')' { $$.val = $3.expr() }
+| /* EMPTY */ { $$.val = tree.Expr(nil) }
+
+function_or_procedure:
+  FUNCTION {}
+| PROCEDURE {}
+
+trigger_func_args:
+  trigger_func_arg
+  {
+    $$.val = []string{$1}
+  }
+| trigger_func_args ',' trigger_func_arg
+  {
+    $$.val = append($1.strs(), $3)
+  }
+| /* EMPTY */
+  {
+    $$.val = []string(nil)
+  }
+
+trigger_func_arg:
+  ICONST
+  {
+    $$ = $1.numVal().String()
+  }
+| FCONST
+  {
+    $$ = $1.numVal().String()
+  }
+| SCONST
+| unrestricted_name
+
 opt_or_replace:
   OR REPLACE { $$.val = true }
 | /* EMPTY */ { $$.val = false }
 
-opt_return_table:
-  TABLE { return unimplementedWithIssueDetail(sqllex, 100226, "UDF returning TABLE") }
-| /* EMPTY */ { $$.val = false }
-
 opt_return_set:
   SETOF { $$.val = true}
 | /* EMPTY */ { $$.val = false }
@@ -4875,7 +5806,7 @@ routine_param_class:
 | OUT { $$.val = tree.RoutineParamOut }
 | INOUT { $$.val = tree.RoutineParamInOut }
 | IN OUT { $$.val = tree.RoutineParamInOut }
-| VARIADIC { return unimplementedWithIssueDetail(sqllex, 88947, "variadic user-defined functions") }
+| VARIADIC { $$.val = tree.RoutineParamVariadic }
 
 routine_param_type:
   typename
@@ -4883,6 +5814,23 @@ routine_param_type:
 routine_return_type:
   routine_param_type
 
+table_func_column_list:
+  table_func_column { $$.val = tree.RoutineParams{$1.routineParam()} }
+| table_func_column_list ',' table_func_column
+  {
+    $$.val = append($1.routineParams(), $3.routineParam())
+  }
+
+table_func_column:
+  param_name routine_param_type
+  {
+    $$.val = tree.RoutineParam{
+      Name: tree.Name($1),
+      Type: $2.typeReference(),
+      Class: tree.RoutineParamTable,
+    }
+  }
+
 opt_create_routine_opt_list:
   create_routine_opt_list { $$.val = $1.routineOptions() }
 | /* EMPTY */ { $$.val = tree.RoutineOptions{} }
@@ -4941,19 +5889,19 @@ common_routine_opt_item:
   }
 | EXTERNAL SECURITY DEFINER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.RoutineDefiner
   }
 | EXTERNAL SECURITY INVOKER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.RoutineInvoker
   }
 | SECURITY DEFINER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.RoutineDefiner
   }
 | SECURITY INVOKER
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.RoutineInvoker
   }
 | LEAKPROOF
   {
@@ -4975,11 +5923,19 @@ common_routine_opt_item:
   {
     return unimplemented(sqllex, "create function/procedure ... support")
   }
-
-// In theory we should parse the a whole set/reset statement here. But it's fine
-// to just return fast on SET/RESET keyword for now since it's not supported
-// yet.
-| SET { return unimplemented(sqllex, "create function/procedure ... set") }
+| SET generic_set
+  {
+    $$.val = $2.setVar()
+  }
+| SET var_name FROM CURRENT { return unimplemented(sqllex, "create function/procedure ... set from current") }
+| RESET_ALL ALL
+  {
+    $$.val = &tree.SetVar{ResetAll: true, Reset: true}
+  }
+| RESET session_var
+  {
+    $$.val = &tree.SetVar{Name: $2, Values: tree.Exprs{tree.DefaultVal{}}, Reset: true}
+  }
 | PARALLEL { return unimplemented(sqllex, "create function/procedure ... parallel") }
 
 routine_as:
@@ -5029,33 +5985,111 @@ opt_link_sym:
   ',' SCONST
   {
   }
-| /* Empty */
+| /* Empty */
+  {
+  }
+
+// %Help: DROP FUNCTION - remove a function
+// %Category: DDL
+// %Text:
+// DROP FUNCTION [ IF EXISTS ] name [ ( [ [ argmode ] [ argname ] argtype [, ...] ] ) ] [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: WEBDOCS/drop-function.html
+drop_func_stmt:
+  DROP FUNCTION function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      Routines: $3.routineObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP FUNCTION IF EXISTS function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      IfExists: true,
+      Routines: $5.routineObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP FUNCTION error // SHOW HELP: DROP FUNCTION
+
+// %Help: DROP TRIGGER - remove a trigger
+// %Category: DDL
+// %Text: DROP TRIGGER [ IF EXISTS ] name ON table_name [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE TRIGGER
+drop_trigger_stmt:
+  DROP TRIGGER name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      Trigger: tree.Name($3),
+      Table: $5.unresolvedObjectName(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP TRIGGER IF EXISTS name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      IfExists: true,
+      Trigger: tree.Name($5),
+      Table: $7.unresolvedObjectName(),
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP TRIGGER error // SHOW HELP: DROP TRIGGER
+
+// %Help: DROP PUBLICATION - remove a publication
+// %Category: DDL
+// %Text: DROP PUBLICATION [ IF EXISTS ] <name> [, ...] [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE PUBLICATION
+drop_publication_stmt:
+  DROP PUBLICATION name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropPublication{Names: $3.nameList(), DropBehavior: $4.dropBehavior()}
+  }
+| DROP PUBLICATION IF EXISTS name_list opt_drop_behavior
   {
+    $$.val = &tree.DropPublication{Names: $5.nameList(), IfExists: true, DropBehavior: $6.dropBehavior()}
   }
+| DROP PUBLICATION error // SHOW HELP: DROP PUBLICATION
 
-// %Help: DROP FUNCTION - remove a function
+// %Help: DROP TEXT SEARCH - remove a text search configuration or dictionary
 // %Category: DDL
 // %Text:
-// DROP FUNCTION [ IF EXISTS ] name [ ( [ [ argmode ] [ argname ] argtype [, ...] ] ) ] [, ...]
-//    [ CASCADE | RESTRICT ]
-// %SeeAlso: WEBDOCS/drop-function.html
-drop_func_stmt:
-  DROP FUNCTION function_with_paramtypes_list opt_drop_behavior
+// DROP TEXT SEARCH CONFIGURATION [ IF EXISTS ] <name> [, ...] [ CASCADE | RESTRICT ]
+// DROP TEXT SEARCH DICTIONARY [ IF EXISTS ] <name> [, ...] [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE TEXT SEARCH
+drop_text_search_stmt:
+  DROP TEXT SEARCH CONFIGURATION type_name_list opt_drop_behavior
   {
-    $$.val = &tree.DropRoutine{
-      Routines: $3.routineObjs(),
-      DropBehavior: $4.dropBehavior(),
+    $$.val = &tree.DropTextSearchConfig{
+      Names: $5.unresolvedObjectNames(),
+      DropBehavior: $6.dropBehavior(),
     }
   }
-| DROP FUNCTION IF EXISTS function_with_paramtypes_list opt_drop_behavior
+| DROP TEXT SEARCH CONFIGURATION IF EXISTS type_name_list opt_drop_behavior
   {
-    $$.val = &tree.DropRoutine{
+    $$.val = &tree.DropTextSearchConfig{
+      Names: $7.unresolvedObjectNames(),
       IfExists: true,
-      Routines: $5.routineObjs(),
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP TEXT SEARCH DICTIONARY type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTextSearchDictionary{
+      Names: $5.unresolvedObjectNames(),
       DropBehavior: $6.dropBehavior(),
     }
   }
-| DROP FUNCTION error // SHOW HELP: DROP FUNCTION
+| DROP TEXT SEARCH DICTIONARY IF EXISTS type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTextSearchDictionary{
+      Names: $7.unresolvedObjectNames(),
+      IfExists: true,
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP TEXT SEARCH error // SHOW HELP: DROP TEXT SEARCH
 
 // %Help: DROP PROCEDURE - remove a procedure
 // %Category: DDL
@@ -5083,6 +6117,129 @@ drop_proc_stmt:
   }
 | DROP PROCEDURE error // SHOW HELP: DROP PROCEDURE
 
+// %Help: DROP AGGREGATE - remove an aggregate function
+// %Category: DDL
+// %Text:
+// DROP AGGREGATE [ IF EXISTS ]
+//   name ( * | [ [ argmode ] [ argname ] argtype [, ...] ] ) [, ...]
+//   [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE AGGREGATE, ALTER AGGREGATE
+drop_aggregate_stmt:
+  DROP AGGREGATE aggregate_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      Aggregate: true,
+      Routines: $3.routineObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE IF EXISTS aggregate_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropRoutine{
+      IfExists: true,
+      Aggregate: true,
+      Routines: $5.routineObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE error // SHOW HELP: DROP AGGREGATE
+
+aggregate_with_paramtypes_list:
+  aggregate_with_paramtypes
+  {
+    $$.val = tree.RoutineObjs{$1.functionObj()}
+  }
+| aggregate_with_paramtypes_list ',' aggregate_with_paramtypes
+  {
+    $$.val = append($1.routineObjs(), $3.functionObj())
+  }
+
+aggregate_with_paramtypes:
+  db_object_name '(' '*' ')'
+  {
+    $$.val = tree.RoutineObj{
+      FuncName: $1.unresolvedObjectName().ToRoutineName(),
+      Params: tree.RoutineParams{},
+    }
+  }
+| function_with_paramtypes
+
+// %Help: DROP CAST - remove a cast
+// %Category: DDL
+// %Text:
+// DROP CAST [ IF EXISTS ] (source_type AS target_type) [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE CAST
+drop_cast_stmt:
+  DROP CAST '(' typename AS typename ')' opt_drop_behavior
+  {
+    $$.val = &tree.DropCast{
+      Source: $4.typeReference(),
+      Target: $6.typeReference(),
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP CAST IF EXISTS '(' typename AS typename ')' opt_drop_behavior
+  {
+    $$.val = &tree.DropCast{
+      Source: $6.typeReference(),
+      Target: $8.typeReference(),
+      IfExists: true,
+      DropBehavior: $10.dropBehavior(),
+    }
+  }
+| DROP CAST error // SHOW HELP: DROP CAST
+
+// %Help: DROP OPERATOR - remove an operator
+// %Category: DDL
+// %Text:
+// DROP OPERATOR [ IF EXISTS ]
+//   name ( { left_type | NONE } , right_type ) [, ...]
+//   [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE OPERATOR
+drop_operator_stmt:
+  DROP OPERATOR operator_with_argtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropOperator{
+      Operators: $3.operatorWithArgsList(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP OPERATOR IF EXISTS operator_with_argtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropOperator{
+      Operators: $5.operatorWithArgsList(),
+      IfExists: true,
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP OPERATOR error // SHOW HELP: DROP OPERATOR
+
+operator_with_argtypes_list:
+  operator_with_argtypes
+  {
+    $$.val = []tree.OperatorWithArgs{$1.operatorWithArgs()}
+  }
+| operator_with_argtypes_list ',' operator_with_argtypes
+  {
+    $$.val = append($1.operatorWithArgsList(), $3.operatorWithArgs())
+  }
+
+operator_with_argtypes:
+  any_operator '(' typename ',' typename ')'
+  {
+    left := $3.typeReference()
+    // NONE is also a valid type name, so it is recognized here as the
+    // missing left operand of a prefix operator.
+    if n, ok := left.(*tree.UnresolvedObjectName); ok && n.NumParts == 1 && n.Parts[0] == "none" {
+      left = nil
+    }
+    $$.val = tree.OperatorWithArgs{
+      Name: $1.operatorName(),
+      Left: left,
+      Right: $5.typeReference(),
+    }
+  }
+
 function_with_paramtypes_list:
   function_with_paramtypes
   {
@@ -5230,22 +6387,15 @@ opt_no:
 
 create_unsupported:
   CREATE ACCESS METHOD error { return unimplemented(sqllex, "create access method") }
-| CREATE AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "create aggregate") }
-| CREATE CAST error { return unimplemented(sqllex, "create cast") }
-| CREATE CONSTRAINT TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create constraint") }
 | CREATE CONVERSION error { return unimplemented(sqllex, "create conversion") }
 | CREATE DEFAULT CONVERSION error { return unimplemented(sqllex, "create def conv") }
-| CREATE FOREIGN TABLE error { return unimplemented(sqllex, "create foreign table") }
-| CREATE FOREIGN DATA error { return unimplemented(sqllex, "create fdw") }
+| CREATE FOREIGN DATA error { return purposelyUnimplemented(sqllex, "create fdw", "Foreign tables read files in external storage directly and do not use foreign-data wrappers.") }
 | CREATE opt_or_replace opt_trusted opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "create language " + $6) }
-| CREATE OPERATOR error { return unimplementedWithIssue(sqllex, 65017) }
-| CREATE PUBLICATION error { return unimplemented(sqllex, "create publication") }
 | CREATE opt_or_replace RULE error { return unimplemented(sqllex, "create rule") }
-| CREATE SERVER error { return unimplemented(sqllex, "create server") }
+| CREATE SERVER error { return purposelyUnimplemented(sqllex, "create server", "Foreign tables use external connections as servers; use CREATE EXTERNAL CONNECTION.") }
 | CREATE SUBSCRIPTION error { return unimplemented(sqllex, "create subscription") }
 | CREATE TABLESPACE error { return unimplementedWithIssueDetail(sqllex, 54113, "create tablespace") }
-| CREATE TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "create text") }
-| CREATE TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create trigger") }
+| CREATE TEXT SEARCH TEMPLATE error { return unimplementedWithIssueDetail(sqllex, 7821, "create text search template") }
 
 opt_trusted:
   TRUSTED {}
@@ -5257,23 +6407,16 @@ opt_procedural:
 
 drop_unsupported:
   DROP ACCESS METHOD error { return unimplemented(sqllex, "drop access method") }
-| DROP AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "drop aggregate") }
-| DROP CAST error { return unimplemented(sqllex, "drop cast") }
 | DROP COLLATION error { return unimplemented(sqllex, "drop collation") }
 | DROP CONVERSION error { return unimplemented(sqllex, "drop conversion") }
-| DROP DOMAIN error { return unimplementedWithIssueDetail(sqllex, 27796, "drop") }
 | DROP EXTENSION IF EXISTS name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension if exists") }
 | DROP EXTENSION name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension") }
-| DROP FOREIGN TABLE error { return unimplemented(sqllex, "drop foreign table") }
-| DROP FOREIGN DATA error { return unimplemented(sqllex, "drop fdw") }
+| DROP FOREIGN DATA error { return purposelyUnimplemented(sqllex, "drop fdw", "Foreign tables read files in external storage directly and do not use foreign-data wrappers.") }
 | DROP opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "drop language " + $4) }
-| DROP OPERATOR error { return unimplemented(sqllex, "drop operator") }
-| DROP PUBLICATION error { return unimplemented(sqllex, "drop publication") }
 | DROP RULE error { return unimplemented(sqllex, "drop rule") }
-| DROP SERVER error { return unimplemented(sqllex, "drop server") }
+| DROP SERVER error { return purposelyUnimplemented(sqllex, "drop server", "Foreign tables use external connections as servers; use CREATE EXTERNAL CONNECTION.") }
 | DROP SUBSCRIPTION error { return unimplemented(sqllex, "drop subscription") }
-| DROP TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "drop text") }
-| DROP TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "drop") }
+| DROP TEXT SEARCH TEMPLATE error { return unimplementedWithIssueDetail(sqllex, 7821, "drop text search template") }
 
 create_ddl_stmt:
   create_database_stmt // EXTEND WITH HELP: CREATE DATABASE
@@ -5284,10 +6427,18 @@ create_ddl_stmt:
 // Error case for both CREATE TABLE and CREATE TABLE ... AS in one
 | CREATE opt_persistence_temp_table TABLE error   // SHOW HELP: CREATE TABLE
 | create_type_stmt     // EXTEND WITH HELP: CREATE TYPE
+| create_domain_stmt   // EXTEND WITH HELP: CREATE DOMAIN
 | create_view_stmt     // EXTEND WITH HELP: CREATE VIEW
 | create_sequence_stmt // EXTEND WITH HELP: CREATE SEQUENCE
 | create_func_stmt     // EXTEND WITH HELP: CREATE FUNCTION
 | create_proc_stmt     // EXTEND WITH HELP: CREATE PROCEDURE
+| create_aggregate_stmt // EXTEND WITH HELP: CREATE AGGREGATE
+| create_cast_stmt     // EXTEND WITH HELP: CREATE CAST
+| create_operator_stmt // EXTEND WITH HELP: CREATE OPERATOR
+| create_foreign_table_stmt // EXTEND WITH HELP: CREATE FOREIGN TABLE
+| create_publication_stmt // EXTEND WITH HELP: CREATE PUBLICATION
+| create_text_search_stmt // EXTEND WITH HELP: CREATE TEXT SEARCH
+| create_trigger_stmt  // EXTEND WITH HELP: CREATE TRIGGER
 
 // %Help: CREATE STATISTICS - create a new table statistic
 // %Category: Misc
@@ -5672,8 +6823,15 @@ drop_ddl_stmt:
 | drop_sequence_stmt // EXTEND WITH HELP: DROP SEQUENCE
 | drop_schema_stmt   // EXTEND WITH HELP: DROP SCHEMA
 | drop_type_stmt     // EXTEND WITH HELP: DROP TYPE
+| drop_domain_stmt   // EXTEND WITH HELP: DROP DOMAIN
 | drop_func_stmt     // EXTEND WITH HELP: DROP FUNCTION
 | drop_proc_stmt     // EXTEND WITH HELP: DROP FUNCTION
+| drop_aggregate_stmt // EXTEND WITH HELP: DROP AGGREGATE
+| drop_cast_stmt     // EXTEND WITH HELP: DROP CAST
+| drop_operator_stmt // EXTEND WITH HELP: DROP OPERATOR
+| drop_trigger_stmt  // EXTEND WITH HELP: DROP TRIGGER
+| drop_publication_stmt // EXTEND WITH HELP: DROP PUBLICATION
+| drop_text_search_stmt // EXTEND WITH HELP: DROP TEXT SEARCH
 
 // %Help: DROP VIEW - remove a view
 // %Category: DDL
@@ -5725,7 +6883,7 @@ drop_sequence_stmt:
 
 // %Help: DROP TABLE - remove a table
 // %Category: DDL
-// %Text: DROP TABLE [IF EXISTS] <tablename> [, ...] [CASCADE | RESTRICT]
+// %Text: DROP [FOREIGN] TABLE [IF EXISTS] <tablename> [, ...] [CASCADE | RESTRICT]
 // %SeeAlso: WEBDOCS/drop-table.html
 drop_table_stmt:
   DROP TABLE table_name_list opt_drop_behavior
@@ -5736,7 +6894,16 @@ drop_table_stmt:
   {
     $$.val = &tree.DropTable{Names: $5.tableNames(), IfExists: true, DropBehavior: $6.dropBehavior()}
   }
+| DROP FOREIGN TABLE table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTable{Names: $4.tableNames(), IfExists: false, DropBehavior: $5.dropBehavior(), Foreign: true}
+  }
+| DROP FOREIGN TABLE IF EXISTS table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTable{Names: $6.tableNames(), IfExists: true, DropBehavior: $7.dropBehavior(), Foreign: true}
+  }
 | DROP TABLE error // SHOW HELP: DROP TABLE
+| DROP FOREIGN TABLE error // SHOW HELP: DROP TABLE
 
 // %Help: DROP INDEX - remove an index
 // %Category: DDL
@@ -5808,6 +6975,29 @@ drop_type_stmt:
   }
 | DROP TYPE error // SHOW HELP: DROP TYPE
 
+// %Help: DROP DOMAIN - remove a domain
+// %Category: DDL
+// %Text: DROP DOMAIN [IF EXISTS] <type_name> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE DOMAIN, ALTER DOMAIN
+drop_domain_stmt:
+  DROP DOMAIN type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropDomain{
+      Names: $3.unresolvedObjectNames(),
+      IfExists: false,
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP DOMAIN IF EXISTS type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropDomain{
+      Names: $5.unresolvedObjectNames(),
+      IfExists: true,
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP DOMAIN error // SHOW HELP: DROP DOMAIN
+
 // %Help: DROP VIRTUAL CLUSTER - remove a virtual cluster
 // %Category: Experimental
 // %Text: DROP VIRTUAL CLUSTER [IF EXISTS] <virtual_cluster_spec> [IMMEDIATE]
@@ -6755,7 +7945,7 @@ use_stmt:
 nonpreparable_set_stmt:
   set_transaction_stmt // EXTEND WITH HELP: SET TRANSACTION
 | set_exprs_internal   { /* SKIP DOC */ }
-| SET CONSTRAINTS error { return unimplemented(sqllex, "set constraints") }
+| set_constraints_stmt // EXTEND WITH HELP: SET CONSTRAINTS
 
 // SET SESSION / SET LOCAL / SET CLUSTER SETTING
 preparable_set_stmt:
@@ -7235,6 +8425,31 @@ set_transaction_stmt:
   }
 | SET SESSION TRANSACTION error // SHOW HELP: SET TRANSACTION
 
+// %Help: SET CONSTRAINTS - set when deferrable constraints are checked
+// %Category: Txn
+// %Text:
+// SET CONSTRAINTS { ALL | <constraintname> [, ...] } { DEFERRED | IMMEDIATE }
+//
+// %SeeAlso: SET TRANSACTION
+set_constraints_stmt:
+  SET CONSTRAINTS ALL DEFERRED
+  {
+    $$.val = &tree.SetConstraints{Deferred: true}
+  }
+| SET CONSTRAINTS ALL IMMEDIATE
+  {
+    $$.val = &tree.SetConstraints{}
+  }
+| SET CONSTRAINTS db_object_name_list DEFERRED
+  {
+    $$.val = &tree.SetConstraints{Names: $3.tableNames(), Deferred: true}
+  }
+| SET CONSTRAINTS db_object_name_list IMMEDIATE
+  {
+    $$.val = &tree.SetConstraints{Names: $3.tableNames()}
+  }
+| SET CONSTRAINTS error // SHOW HELP: SET CONSTRAINTS
+
 generic_set:
   var_name to_or_eq var_list
   {
@@ -10202,6 +11417,22 @@ col_qualification:
   {
     $$.val = tree.NamedColumnQualification{Qualification: &tree.ColumnFamilyConstraint{Family: tree.Name($6), Create: true, IfNotExists: true}}
   }
+| DEFERRABLE
+  {
+    $$.val = tree.NamedColumnQualification{Qualification: tree.ConstraintAttrDeferrable}
+  }
+| NOT DEFERRABLE
+  {
+    $$.val = tree.NamedColumnQualification{Qualification: tree.ConstraintAttrNotDeferrable}
+  }
+| INITIALLY DEFERRED
+  {
+    $$.val = tree.NamedColumnQualification{Qualification: tree.ConstraintAttrInitiallyDeferred}
+  }
+| INITIALLY IMMEDIATE
+  {
+    $$.val = tree.NamedColumnQualification{Qualification: tree.ConstraintAttrInitiallyImmediate}
+  }
 
 // DEFAULT NULL is already the default for Postgres. But define it here and
 // carry it forward into the system to make it explicit.
@@ -10424,6 +11655,9 @@ table_constraint:
 constraint_elem:
   CHECK '(' a_expr ')' opt_deferrable
   {
+    if $5.constraintDeferrability() != tree.ConstraintNotDeferrable {
+      return setErr(sqllex, pgerror.New(pgcode.FeatureNotSupported, "CHECK constraints cannot be marked DEFERRABLE"))
+    }
     $$.val = &tree.CheckConstraintTableDef{
       Expr: $3.expr(),
     }
@@ -10439,6 +11673,7 @@ constraint_elem:
         PartitionByIndex: $7.partitionByIndex(),
         Predicate: $9.expr(),
       },
+      Deferrability: $8.constraintDeferrability(),
     }
   }
 | PRIMARY KEY '(' index_params ')' opt_hash_sharded opt_with_storage_parameter_list
@@ -10462,11 +11697,58 @@ constraint_elem:
       ToCols: $8.nameList(),
       Match: $9.compositeKeyMatchMethod(),
       Actions: $10.referenceActions(),
+      Deferrability: $11.constraintDeferrability(),
+    }
+  }
+| EXCLUDE opt_exclude_using '(' exclude_elems ')' opt_deferrable opt_exclude_where
+  {
+    $$.val = &tree.ExcludeConstraintTableDef{
+      Using: tree.Name($2),
+      Elems: $4.excludeElems(),
+      Deferrability: $6.constraintDeferrability(),
+      Predicate: $7.expr(),
     }
   }
-| EXCLUDE USING error
+
+opt_exclude_using:
+  USING name
+  {
+    $$ = $2
+  }
+| /* EMPTY */
+  {
+    $$ = ""
+  }
+
+exclude_elems:
+  exclude_elem
+  {
+    $$.val = tree.ExcludeElemList{$1.excludeElem()}
+  }
+| exclude_elems ',' exclude_elem
+  {
+    $$.val = append($1.excludeElems(), $3.excludeElem())
+  }
+
+exclude_elem:
+  index_elem WITH all_op
+  {
+    op, ok := $3.op().(treecmp.ComparisonOperator)
+    if !ok {
+      return setErr(sqllex, pgerror.Newf(pgcode.WrongObjectType,
+        "operator %s is not a comparison operator", $3.op()))
+    }
+    $$.val = tree.ExcludeElem{IndexElem: $1.idxElem(), Operator: op}
+  }
+
+opt_exclude_where:
+  WHERE '(' a_expr ')'
+  {
+    $$.val = $3.expr()
+  }
+| /* EMPTY */
   {
-    return unimplementedWithIssueDetail(sqllex, 46657, "add constraint exclude using")
+    $$.val = tree.Expr(nil)
   }
 
 
@@ -10574,13 +11856,52 @@ create_as_col_qualification_elem:
     }
   }
 
+// NOT DEFERRABLE is not accepted by opt_deferrable, since it would conflict
+// with NOT VALID in ALTER TABLE ... ADD CONSTRAINT. Constraints are not
+// deferrable by default.
 opt_deferrable:
-  /* EMPTY */ { /* no error */ }
-| DEFERRABLE { return unimplementedWithIssueDetail(sqllex, 31632, "deferrable") }
-| DEFERRABLE INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "def initially deferred") }
-| DEFERRABLE INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "def initially immediate") }
-| INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "initially deferred") }
-| INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "initially immediate") }
+  /* EMPTY */
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
+| deferrable
+
+deferrable:
+  DEFERRABLE
+  {
+    $$.val = tree.ConstraintInitiallyImmediate
+  }
+| DEFERRABLE INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintInitiallyDeferred
+  }
+| DEFERRABLE INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintInitiallyImmediate
+  }
+| INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintInitiallyDeferred
+  }
+| INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
+
+constraint_deferrability:
+  deferrable
+| NOT DEFERRABLE
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
+| NOT DEFERRABLE INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
+| NOT DEFERRABLE INITIALLY DEFERRED
+  {
+    return setErr(sqllex, pgerror.New(pgcode.Syntax, "constraint declared INITIALLY DEFERRED must be DEFERRABLE"))
+  }
 
 storing:
   COVERING
@@ -10958,7 +12279,7 @@ role_or_group_or_user:
 // %Help: CREATE VIEW - create a new view
 // %Category: DDL
 // %Text:
-// CREATE [TEMPORARY | TEMP] VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] AS <source>
+// CREATE [TEMPORARY | TEMP] [RECURSIVE] VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] AS <source>
 // CREATE [TEMPORARY | TEMP] MATERIALIZED VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] AS <source> [WITH [NO] DATA]
 // %SeeAlso: CREATE TABLE, SHOW CREATE, WEBDOCS/create-view.html
 create_view_stmt:
@@ -10972,6 +12293,7 @@ create_view_stmt:
       Persistence: $2.persistence(),
       IfNotExists: false,
       Replace: false,
+      Recursive: $3.bool(),
     }
   }
 // We cannot use a rule like opt_or_replace here as that would cause a conflict
@@ -10986,6 +12308,7 @@ create_view_stmt:
       Persistence: $4.persistence(),
       IfNotExists: false,
       Replace: true,
+      Recursive: $5.bool(),
     }
   }
 | CREATE opt_temp opt_view_recursive VIEW IF NOT EXISTS view_name opt_column_list AS select_stmt
@@ -10998,6 +12321,7 @@ create_view_stmt:
       Persistence: $2.persistence(),
       IfNotExists: true,
       Replace: false,
+      Recursive: $3.bool(),
     }
   }
 | CREATE MATERIALIZED VIEW view_name opt_column_list AS select_stmt opt_with_data
@@ -11189,8 +12513,14 @@ subject_clause:
   }
 
 opt_view_recursive:
-  /* EMPTY */ { /* no error */ }
-| RECURSIVE { return unimplemented(sqllex, "create recursive view") }
+  RECURSIVE
+  {
+    $$.val = true
+  }
+| /* EMPTY */
+  {
+    $$.val = false
+  }
 
 
 // %Help: CREATE TYPE - create a type
@@ -11240,8 +12570,80 @@ create_type_stmt:
 | CREATE TYPE type_name '(' error         { return unimplementedWithIssueDetail(sqllex, 27793, "base") }
   // Shell types, gateway to define base types using the previous syntax.
 | CREATE TYPE type_name                   { return unimplementedWithIssueDetail(sqllex, 27793, "shell") }
-  // Domain types.
-| CREATE DOMAIN type_name error           { return unimplementedWithIssueDetail(sqllex, 27796, "create") }
+
+// %Help: CREATE DOMAIN - create a domain
+// %Category: DDL
+// %Text:
+// CREATE DOMAIN <type_name> [AS] <type> [DEFAULT <expr>] [<constraint> ...]
+//
+// Constraints:
+//   [CONSTRAINT <name>] { NOT NULL | NULL | CHECK (<expr>) }
+//
+// %SeeAlso: ALTER DOMAIN, DROP DOMAIN
+create_domain_stmt:
+  CREATE DOMAIN type_name opt_as typename opt_domain_default opt_domain_constraint_list
+  {
+    $$.val = &tree.CreateDomain{
+      TypeName: $3.unresolvedObjectName(),
+      Type: $5.typeReference(),
+      Default: $6.expr(),
+      Constraints: $7.domainConstraints(),
+    }
+  }
+| CREATE DOMAIN error // SHOW HELP: CREATE DOMAIN
+
+opt_domain_default:
+  DEFAULT b_expr
+  {
+    $$.val = $2.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = nil
+  }
+
+opt_domain_constraint_list:
+  opt_domain_constraint_list domain_constraint
+  {
+    $$.val = append($1.domainConstraints(), $2.domainConstraint())
+  }
+| /* EMPTY */
+  {
+    $$.val = []tree.DomainConstraint(nil)
+  }
+
+domain_constraint:
+  CONSTRAINT constraint_name domain_constraint_elem
+  {
+    c := $3.domainConstraint()
+    c.Name = tree.Name($2)
+    $$.val = c
+  }
+| domain_constraint_elem
+
+domain_constraint_elem:
+  NOT NULL
+  {
+    $$.val = tree.DomainConstraint{NotNull: true}
+  }
+| NULL
+  {
+    $$.val = tree.DomainConstraint{}
+  }
+| CHECK '(' a_expr ')'
+  {
+    $$.val = tree.DomainConstraint{Check: $3.expr()}
+  }
+
+domain_check_constraint:
+  CONSTRAINT constraint_name CHECK '(' a_expr ')'
+  {
+    $$.val = tree.DomainConstraint{Name: tree.Name($2), Check: $5.expr()}
+  }
+| CHECK '(' a_expr ')'
+  {
+    $$.val = tree.DomainConstraint{Check: $3.expr()}
+  }
 
 opt_enum_val_list:
   enum_val_list
@@ -11318,7 +12720,9 @@ create_index_stmt:
       PartitionByIndex: $14.partitionByIndex(),
       StorageParams:    $15.storageParams(),
       Predicate:        $16.expr(),
-      Inverted:         $8.bool(),
+      Inverted:         $8 == "inverted",
+      Hash:             $8 == "hash",
+      BRIN:             $8 == "brin",
       Concurrently:     $4.bool(),
       Invisibility:     $17.indexInvisibility(),
     }
@@ -11335,7 +12739,9 @@ create_index_stmt:
       Sharded:          $15.shardedIndexDef(),
       Storing:          $16.nameList(),
       PartitionByIndex: $17.partitionByIndex(),
-      Inverted:         $11.bool(),
+      Inverted:         $11 == "inverted",
+      Hash:             $11 == "hash",
+      BRIN:             $11 == "brin",
       StorageParams:    $18.storageParams(),
       Predicate:        $19.expr(),
       Concurrently:     $4.bool(),
@@ -11385,10 +12791,10 @@ opt_index_access_method:
     /* FORCE DOC */
     switch $2 {
       case "gin", "gist":
-        $$.val = true
-      case "btree":
-        $$.val = false
-      case "hash", "spgist", "brin":
+        $$ = "inverted"
+      case "btree", "hash", "brin":
+        $$ = $2
+      case "spgist":
         return unimplemented(sqllex, "index using " + $2)
       default:
         sqllex.Error("unrecognized access method: " + $2)
@@ -11397,7 +12803,7 @@ opt_index_access_method:
   }
 | /* EMPTY */
   {
-    $$.val = false
+    $$ = "btree"
   }
 
 opt_concurrently:
@@ -13399,9 +14805,18 @@ group_by_list:
 // rather than reducing the conflicting unreserved_keyword rule.
 group_by_item:
   a_expr { $$.val = $1.expr() }
-| ROLLUP '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "rollup") }
-| CUBE '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "cube") }
-| GROUPING SETS error { return unimplementedWithIssueDetail(sqllex, 46280, "grouping sets") }
+| ROLLUP '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.Rollup, Exprs: $3.exprs()}
+  }
+| CUBE '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.Cube, Exprs: $3.exprs()}
+  }
+| GROUPING SETS '(' group_by_list ')'
+  {
+    $$.val = &tree.GroupingSet{Type: tree.GroupingSets, Exprs: $4.exprs()}
+  }
 
 having_clause:
   HAVING a_expr
@@ -13980,16 +15395,46 @@ relation_expr_list:
     $$.val = append($1.tableNames(), name)
   }
 
-// UNLISTEN
+// %Help: LISTEN - listen for notifications on a channel
+// %Category: Misc
+// %Text: LISTEN <channel>
+// %SeeAlso: NOTIFY, UNLISTEN
+listen_stmt:
+  LISTEN name
+  {
+    $$.val = &tree.Listen{ChannelName: tree.Name($2)}
+  }
+| LISTEN error // SHOW HELP: LISTEN
+
+// %Help: NOTIFY - send a notification on a channel
+// %Category: Misc
+// %Text: NOTIFY <channel> [, <payload>]
+// %SeeAlso: LISTEN, UNLISTEN
+notify_stmt:
+  NOTIFY name
+  {
+    $$.val = &tree.Notify{ChannelName: tree.Name($2)}
+  }
+| NOTIFY name ',' SCONST
+  {
+    $$.val = &tree.Notify{ChannelName: tree.Name($2), Payload: $4}
+  }
+| NOTIFY error // SHOW HELP: NOTIFY
+
+// %Help: UNLISTEN - stop listening for notifications
+// %Category: Misc
+// %Text: UNLISTEN { <channel> | * }
+// %SeeAlso: LISTEN, NOTIFY
 unlisten_stmt:
-   UNLISTEN type_name
-    {
-        $$.val = &tree.Unlisten{ ChannelName: $2.unresolvedObjectName(), Star: false}
-    }
-|  UNLISTEN '*'
-      {
-          $$.val = &tree.Unlisten{ ChannelName:nil, Star: true}
-      }
+  UNLISTEN name
+  {
+    $$.val = &tree.Unlisten{ChannelName: tree.Name($2)}
+  }
+| UNLISTEN '*'
+  {
+    $$.val = &tree.Unlisten{Star: true}
+  }
+| UNLISTEN error // SHOW HELP: UNLISTEN
 
 
 // Given "UPDATE foo set set ...", we have to decide without looking any
@@ -14873,6 +16318,14 @@ a_expr:
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.TSMatches), Left: $1.expr(), Right: $3.expr()}
   }
+| a_expr AT_QUESTION a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.JSONPathExists), Left: $1.expr(), Right: $3.expr()}
+  }
+| a_expr ADJACENT a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.Adjacent), Left: $1.expr(), Right: $3.expr()}
+  }
 | a_expr INET_CONTAINS_OR_EQUALS a_expr
   {
     $$.val = &tree.FuncExpr{Func: tree.WrapFunction("inet_contains_or_equals"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
@@ -15427,7 +16880,10 @@ d_expr:
   {
     $$.val = $2.expr()
   }
-| GROUPING '(' expr_list ')' { return unimplemented(sqllex, "d_expr grouping") }
+| GROUPING '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingExpr{Exprs: $3.exprs()}
+  }
 
 func_application:
   func_application_name '(' ')'
@@ -16085,6 +17541,8 @@ all_op:
 | NOT_REGIMATCH { $$.val = treecmp.MakeComparisonOperator(treecmp.NotRegIMatch) }
 | AND_AND { $$.val = treecmp.MakeComparisonOperator(treecmp.Overlaps) }
 | AT_AT { $$.val = treecmp.MakeComparisonOperator(treecmp.TSMatches) }
+| AT_QUESTION { $$.val = treecmp.MakeComparisonOperator(treecmp.JSONPathExists) }
+| ADJACENT { $$.val = treecmp.MakeComparisonOperator(treecmp.Adjacent) }
 | '~' { $$.val = tree.MakeUnaryOperator(tree.UnaryComplement) }
 | SQRT { $$.val = tree.MakeUnaryOperator(tree.UnarySqrt) }
 | CBRT { $$.val = tree.MakeUnaryOperator(tree.UnaryCbrt) }
@@ -17031,6 +18489,7 @@ unreserved_keyword:
 | ALTER
 | ALWAYS
 | ASENSITIVE
+| ASSIGNMENT
 | AS_JSON
 | AT
 | ATOMIC
@@ -17108,10 +18567,12 @@ unreserved_keyword:
 | DESTINATION
 | DETACHED
 | DETAILS
+| DICTIONARY
 | DISCARD
 | DOMAIN
 | DOUBLE
 | DROP
+| EACH
 | ENCODING
 | ENCRYPTED
 | ENCRYPTION_PASSPHRASE
@@ -17174,6 +18635,7 @@ unreserved_keyword:
 | IMMEDIATE
 | IMMEDIATELY
 | IMMUTABLE
+| IMPLICIT
 | IMPORT
 | INCLUDE
 | INCLUDING
@@ -17187,6 +18649,7 @@ unreserved_keyword:
 | INHERITS
 | INJECT
 | INPUT
+| INSTEAD
 | INSERT
 | INTO_DB
 | INVERTED
@@ -17215,12 +18678,14 @@ unreserved_keyword:
 | LINESTRINGZ
 | LINESTRINGZM
 | LIST
+| LISTEN
 | LOCAL
 | LOCKED
 | LOGIN
 | LOCALITY
 | LOOKUP
 | LOW
+| MAPPING
 | MATCH
 | MATERIALIZED
 | MAXVALUE
@@ -17247,12 +18712,14 @@ unreserved_keyword:
 | NAMES
 | NAN
 | NEVER
+| NEW
 | NEW_DB_NAME
 | NEW_KMS
 | NEXT
 | NO
 | NORMAL
 | NOTHING
+| NOTIFY
 | NO_INDEX_JOIN
 | NO_ZIGZAG_JOIN
 | NO_FULL_SCAN
@@ -17278,6 +18745,7 @@ unreserved_keyword:
 | OF
 | OFF
 | OIDS
+| OLD
 | OLD_KMS
 | OPERATOR
 | OPT
@@ -17329,6 +18797,7 @@ unreserved_keyword:
 | RECURSIVE
 | REDACT
 | REF
+| REFERENCING
 | REFRESH
 | REGION
 | REGIONAL
@@ -17407,6 +18876,7 @@ unreserved_keyword:
 | STABLE
 | START
 | STATE
+| STATEMENT
 | STATEMENTS
 | STATISTICS
 | STDIN
@@ -17482,6 +18952,7 @@ unreserved_keyword:
 | VISIBILITY
 | VOLATILE
 | VOTERS
+| WEIGHT
 | WITHIN
 | WITHOUT
 | WRITE
@@ -17511,6 +18982,7 @@ bare_label_keywords:
 | ANY
 | ASC
 | ASENSITIVE
+| ASSIGNMENT
 | ASYMMETRIC
 | AS_JSON
 | AT
@@ -17617,6 +19089,7 @@ bare_label_keywords:
 | DESTINATION
 | DETACHED
 | DETAILS
+| DICTIONARY
 | DISCARD
 | DISTINCT
 | DO
@@ -17624,6 +19097,7 @@ bare_label_keywords:
 | DOUBLE
 | DROP
 | ELSE
+| EACH
 | ENCODING
 | ENCRYPTED
 | ENCRYPTION_INFO_DIR
@@ -17702,6 +19176,7 @@ bare_label_keywords:
 | IMMEDIATE
 | IMMEDIATELY
 | IMMUTABLE
+| IMPLICIT
 | IMPORT
 | IN
 | INCLUDE
@@ -17723,6 +19198,7 @@ bare_label_keywords:
 | INOUT
 | INPUT
 | INSENSITIVE
+| INSTEAD
 | INSERT
 | INT
 | INTEGER
@@ -17762,6 +19238,7 @@ bare_label_keywords:
 | LINESTRINGZ
 | LINESTRINGZM
 | LIST
+| LISTEN
 | LOCAL
 | LOCALITY
 | LOCALTIME
@@ -17770,6 +19247,7 @@ bare_label_keywords:
 | LOGIN
 | LOOKUP
 | LOW
+| MAPPING
 | MATCH
 | MATERIALIZED
 | MAXVALUE
@@ -17795,6 +19273,7 @@ bare_label_keywords:
 | NAN
 | NATURAL
 | NEVER
+| NEW
 | NEW_DB_NAME
 | NEW_KMS
 | NEXT
@@ -17816,6 +19295,7 @@ bare_label_keywords:
 | NOT
 | NOTHING
 | NOTHING_AFTER_RETURNING
+| NOTIFY
 | NOVIEWACTIVITY
 | NOVIEWACTIVITYREDACTED
 | NOVIEWCLUSTERSETTING
@@ -17830,6 +19310,7 @@ bare_label_keywords:
 | OF
 | OFF
 | OIDS
+| OLD
 | OLD_KMS
 | ONLY
 | OPERATOR
@@ -17892,6 +19373,7 @@ bare_label_keywords:
 | REDACT
 | REF
 | REFERENCES
+| REFERENCING
 | REFRESH
 | REGION
 | REGIONAL
@@ -17976,6 +19458,7 @@ bare_label_keywords:
 | STABLE
 | START
 | STATE
+| STATEMENT
 | STATEMENTS
 | STATISTICS
 | STATUS
@@ -18072,6 +19555,7 @@ bare_label_keywords:
 | VISIBILITY
 | VOLATILE
 | VOTERS
+| WEIGHT
 | WHEN
 | WORK
 | WRITE
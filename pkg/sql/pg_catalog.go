@@ -26,6 +26,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catformat"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
@@ -36,7 +37,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/oidext"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
@@ -59,6 +62,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
 )
@@ -74,6 +78,8 @@ var (
 const (
 	indexTypeForwardIndex  = "prefix"
 	indexTypeInvertedIndex = "inverted"
+	indexTypeHashIndex     = "hash"
+	indexTypeBRINIndex     = "brin"
 )
 
 // Bitmasks for pg_index.indoption. Each column in the index has a bitfield
@@ -118,6 +124,8 @@ const (
 
 var forwardIndexOid = stringOid(indexTypeForwardIndex)
 var invertedIndexOid = stringOid(indexTypeInvertedIndex)
+var hashIndexOid = stringOid(indexTypeHashIndex)
+var brinIndexOid = stringOid(indexTypeBRINIndex)
 
 // pgCatalog contains a set of system tables mirroring PostgreSQL's pg_catalog schema.
 // This code attempts to comply as closely as possible to the system catalogs documented
@@ -355,6 +363,84 @@ https://www.postgresql.org/docs/9.5/catalog-pg-am.html`,
 		); err != nil {
 			return err
 		}
+
+		// add row for hash indexes
+		if err := addRow(
+			hashIndexOid,                      // oid - all versions
+			tree.NewDName(indexTypeHashIndex), // amname - all versions
+			zeroVal,                           // amstrategies - < v9.6
+			zeroVal,                           // amsupport - < v9.6
+			tree.DBoolFalse,                   // amcanorder - < v9.6
+			tree.DBoolFalse,                   // amcanorderbyop - < v9.6
+			tree.DBoolFalse,                   // amcanbackward - < v9.6
+			tree.DBoolFalse,                   // amcanunique - < v9.6
+			tree.DBoolTrue,                    // amcanmulticol - < v9.6
+			tree.DBoolFalse,                   // amoptionalkey - < v9.6
+			tree.DBoolFalse,                   // amsearcharray - < v9.6
+			tree.DBoolFalse,                   // amsearchnulls - < v9.6
+			tree.DBoolFalse,                   // amstorage - < v9.6
+			tree.DBoolFalse,                   // amclusterable - < v9.6
+			tree.DBoolFalse,                   // ampredlocks - < v9.6
+			oidZero,                           // amkeytype - < v9.6
+			tree.DNull,                        // aminsert - < v9.6
+			tree.DNull,                        // ambeginscan - < v9.6
+			oidZero,                           // amgettuple - < v9.6
+			oidZero,                           // amgetbitmap - < v9.6
+			tree.DNull,                        // amrescan - < v9.6
+			tree.DNull,                        // amendscan - < v9.6
+			tree.DNull,                        // ammarkpos - < v9.6
+			tree.DNull,                        // amrestrpos - < v9.6
+			tree.DNull,                        // ambuild - < v9.6
+			tree.DNull,                        // ambuildempty - < v9.6
+			tree.DNull,                        // ambulkdelete - < v9.6
+			tree.DNull,                        // amvacuumcleanup - < v9.6
+			tree.DNull,                        // amcanreturn - < v9.6
+			tree.DNull,                        // amcostestimate - < v9.6
+			tree.DNull,                        // amoptions - < v9.6
+			tree.DNull,                        // amhandler - > v9.6
+			tree.NewDString("i"),              // amtype - > v9.6
+		); err != nil {
+			return err
+		}
+
+		// add row for BRIN indexes
+		if err := addRow(
+			brinIndexOid,                      // oid - all versions
+			tree.NewDName(indexTypeBRINIndex), // amname - all versions
+			zeroVal,                           // amstrategies - < v9.6
+			zeroVal,                           // amsupport - < v9.6
+			tree.DBoolFalse,                   // amcanorder - < v9.6
+			tree.DBoolFalse,                   // amcanorderbyop - < v9.6
+			tree.DBoolFalse,                   // amcanbackward - < v9.6
+			tree.DBoolFalse,                   // amcanunique - < v9.6
+			tree.DBoolTrue,                    // amcanmulticol - < v9.6
+			tree.DBoolTrue,                    // amoptionalkey - < v9.6
+			tree.DBoolFalse,                   // amsearcharray - < v9.6
+			tree.DBoolTrue,                    // amsearchnulls - < v9.6
+			tree.DBoolFalse,                   // amstorage - < v9.6
+			tree.DBoolFalse,                   // amclusterable - < v9.6
+			tree.DBoolFalse,                   // ampredlocks - < v9.6
+			oidZero,                           // amkeytype - < v9.6
+			tree.DNull,                        // aminsert - < v9.6
+			tree.DNull,                        // ambeginscan - < v9.6
+			oidZero,                           // amgettuple - < v9.6
+			oidZero,                           // amgetbitmap - < v9.6
+			tree.DNull,                        // amrescan - < v9.6
+			tree.DNull,                        // amendscan - < v9.6
+			tree.DNull,                        // ammarkpos - < v9.6
+			tree.DNull,                        // amrestrpos - < v9.6
+			tree.DNull,                        // ambuild - < v9.6
+			tree.DNull,                        // ambuildempty - < v9.6
+			tree.DNull,                        // ambulkdelete - < v9.6
+			tree.DNull,                        // amvacuumcleanup - < v9.6
+			tree.DNull,                        // amcanreturn - < v9.6
+			tree.DNull,                        // amcostestimate - < v9.6
+			tree.DNull,                        // amoptions - < v9.6
+			tree.DNull,                        // amhandler - > v9.6
+			tree.NewDString("i"),              // amtype - > v9.6
+		); err != nil {
+			return err
+		}
 		return nil
 	},
 }
@@ -575,7 +661,7 @@ var pgCatalogCastTable = virtualSchemaTable{
 	comment: `casts (empty - needs filling out)
 https://www.postgresql.org/docs/9.6/catalog-pg-cast.html`,
 	schema: vtable.PGCatalogCast,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+	populate: func(ctx context.Context, p *planner, db catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
 		h := makeOidHasher()
 		cast.ForEachCast(func(src, tgt oid.Oid, cCtx cast.Context, ctxOrigin cast.ContextOrigin, _ volatility.V) {
 			if ctxOrigin == cast.ContextOriginPgCast {
@@ -597,7 +683,24 @@ https://www.postgresql.org/docs/9.6/catalog-pg-cast.html`,
 				)
 			}
 		})
-		return nil
+		// Casts created with CREATE CAST are stored on their functions.
+		return forEachSchema(ctx, p, db, false /* requiresPrivileges */, func(ctx context.Context, sc catalog.SchemaDescriptor) error {
+			return sc.ForEachFunctionSignature(func(sig descpb.SchemaDescriptor_FunctionSignature) error {
+				if sig.CastContext == catpb.Function_NOT_A_CAST {
+					return nil
+				}
+				src, tgt := sig.ArgTypes[0].Oid(), sig.ReturnType.Oid()
+				castCtx := castContextFromProto(sig.CastContext).PGString()
+				return addRow(
+					h.CastOid(src, tgt),                     // oid
+					tree.NewDOid(src),                       // cast source
+					tree.NewDOid(tgt),                       // casttarget
+					tree.NewDOid(catid.FuncIDToOID(sig.ID)), // castfunc
+					tree.NewDString(castCtx),                // castcontext
+					tree.NewDString("f"),                    // castmethod
+				)
+			})
+		})
 	},
 }
 
@@ -713,6 +816,7 @@ var (
 	relKindView             = tree.NewDString("v")
 	relKindMaterializedView = tree.NewDString("m")
 	relKindSequence         = tree.NewDString("S")
+	relKindForeignTable     = tree.NewDString("f")
 
 	relPersistencePermanent = tree.NewDString("p")
 	relPersistenceTemporary = tree.NewDString("t")
@@ -742,6 +846,10 @@ https://www.postgresql.org/docs/9.5/catalog-pg-class.html`,
 			relKind = relKindSequence
 			relAm = oidZero
 			replIdent = "n"
+		} else if table.IsForeignTable() {
+			relKind = relKindForeignTable
+			relAm = oidZero
+			replIdent = "n"
 		}
 		relPersistence := relPersistencePermanent
 		if table.IsTemporary() {
@@ -808,8 +916,8 @@ https://www.postgresql.org/docs/9.5/catalog-pg-class.html`,
 
 		// Skip adding indexes for sequences (their table descriptors have a primary
 		// index to make them comprehensible to backup/restore, but PG doesn't include
-		// an index in pg_class).
-		if table.IsSequence() {
+		// an index in pg_class) and foreign tables (which have no indexes).
+		if table.IsSequence() || table.IsForeignTable() {
 			return nil
 		}
 
@@ -818,6 +926,10 @@ https://www.postgresql.org/docs/9.5/catalog-pg-class.html`,
 			indexType := forwardIndexOid
 			if index.GetType() == descpb.IndexDescriptor_INVERTED {
 				indexType = invertedIndexOid
+			} else if index.GetAccessMethod() == descpb.IndexDescriptor_HASH {
+				indexType = hashIndexOid
+			} else if index.GetAccessMethod() == descpb.IndexDescriptor_BRIN {
+				indexType = brinIndexOid
 			}
 			ownerOid, err := getOwnerOID(ctx, p, table)
 			if err != nil {
@@ -912,7 +1024,6 @@ var (
 
 	// Avoid unused warning for constants.
 	_ = conTypeTrigger
-	_ = conTypeExclusion
 
 	fkActionNone       = tree.NewDString("a")
 	fkActionRestrict   = tree.NewDString("r")
@@ -1057,13 +1168,26 @@ func populateTableConstraints(
 			conoid = h.UniqueWithoutIndexConstraintOid(
 				db.GetID(), sc.GetID(), table.GetID(), uwoi,
 			)
-			f.WriteString("UNIQUE WITHOUT INDEX (")
-			colNames, err := catalog.ColumnNamesForIDs(table, uwoi.UniqueWithoutIndexDesc().ColumnIDs)
-			if err != nil {
-				return err
+			if uc := uwoi.UniqueWithoutIndexDesc(); uc.IsExclusion() {
+				contype = conTypeExclusion
+				if err := showExclusionElems(&f.Buffer, table, uc); err != nil {
+					return err
+				}
+			} else {
+				f.WriteString("UNIQUE WITHOUT INDEX (")
+				colNames, err := catalog.ColumnNamesForIDs(table, uc.ColumnIDs)
+				if err != nil {
+					return err
+				}
+				f.WriteString(strings.Join(colNames, ", "))
+				f.WriteByte(')')
+			}
+			if uwoi.UniqueWithoutIndexDesc().Deferrable {
+				f.WriteString(" DEFERRABLE")
+				if uwoi.UniqueWithoutIndexDesc().InitiallyDeferred {
+					f.WriteString(" INITIALLY DEFERRED")
+				}
 			}
-			f.WriteString(strings.Join(colNames, ", "))
-			f.WriteByte(')')
 			if !uwoi.IsConstraintValidated() {
 				f.WriteString(" NOT VALID")
 			}
@@ -1094,13 +1218,14 @@ func populateTableConstraints(
 			condef = tree.NewDString(fmt.Sprintf("CHECK ((%s))%s", displayExpr, validity))
 		}
 
+		deferrable, initiallyDeferred := constraintDeferrability(c)
 		if err := addRow(
-			conoid,                   // oid
-			dNameOrNull(c.GetName()), // conname
-			namespaceOid,             // connamespace
-			contype,                  // contype
-			tree.DBoolFalse,          // condeferrable
-			tree.DBoolFalse,          // condeferred
+			conoid,                                 // oid
+			dNameOrNull(c.GetName()),               // conname
+			namespaceOid,                           // connamespace
+			contype,                                // contype
+			tree.MakeDBool(tree.DBool(deferrable)), // condeferrable
+			tree.MakeDBool(tree.DBool(initiallyDeferred)),            // condeferred
 			tree.MakeDBool(tree.DBool(!c.IsConstraintUnvalidated())), // convalidated
 			tblOid,         // conrelid
 			oidZero,        // contypid
@@ -1286,14 +1411,94 @@ func makeAllRelationsVirtualTableWithDescriptorIDIndex(
 	}
 }
 
-var pgCatalogConstraintTable = makeAllRelationsVirtualTableWithDescriptorIDIndex(
-	`table constraints (incomplete - see also information_schema.table_constraints)
+var pgCatalogConstraintTable = func() virtualSchemaTable {
+	t := makeAllRelationsVirtualTableWithDescriptorIDIndex(
+		`table constraints (incomplete - see also information_schema.table_constraints)
 https://www.postgresql.org/docs/9.5/catalog-pg-constraint.html`,
-	vtable.PGCatalogConstraint,
-	hideVirtual, /* Virtual tables have no constraints */
-	false,       /* includesIndexEntries */
-	populateTableConstraints,
-	nil)
+		vtable.PGCatalogConstraint,
+		hideVirtual, /* Virtual tables have no constraints */
+		false,       /* includesIndexEntries */
+		populateTableConstraints,
+		nil)
+	// The constraints of domains are not attached to any relation, so they
+	// are only listed by a full scan of the table.
+	populateRelations := t.populate
+	t.populate = func(
+		ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error,
+	) error {
+		if err := populateRelations(ctx, p, dbContext, addRow); err != nil {
+			return err
+		}
+		return populateDomainConstraints(ctx, p, dbContext, addRow)
+	}
+	return t
+}()
+
+// populateDomainConstraints adds a row to pg_constraint for every CHECK
+// constraint of a domain. Like in Postgres, these rows reference the domain
+// in contypid and have no conrelid. The NOT NULL constraint of a domain is
+// only reflected by pg_type.typnotnull.
+func populateDomainConstraints(
+	ctx context.Context,
+	p *planner,
+	dbContext catalog.DatabaseDescriptor,
+	addRow func(...tree.Datum) error,
+) error {
+	h := makeOidHasher()
+	return forEachTypeDesc(ctx, p, dbContext, func(
+		ctx context.Context, db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, typ catalog.TypeDescriptor,
+	) error {
+		dom := typ.AsDomainTypeDescriptor()
+		if dom == nil {
+			return nil
+		}
+		namespaceOid := schemaOid(sc.GetID())
+		typOid := tree.NewDOid(catid.TypeIDToOID(typ.GetID()))
+		for i := 0; i < dom.NumChecks(); i++ {
+			ck := dom.GetCheck(i)
+			validated := ck.Validity == descpb.ConstraintValidity_Validated
+			conoid := h.DomainCheckConstraintOid(db.GetID(), sc.GetID(), typ.GetID(), ck.ConstraintID)
+			consrc := tree.NewDString(fmt.Sprintf("(%s)", ck.Expr))
+			validity := ""
+			if !validated {
+				validity = " NOT VALID"
+			}
+			condef := tree.NewDString(fmt.Sprintf("CHECK ((%s))%s", ck.Expr, validity))
+			if err := addRow(
+				conoid,                                // oid
+				dNameOrNull(ck.Name),                  // conname
+				namespaceOid,                          // connamespace
+				conTypeCheck,                          // contype
+				tree.DBoolFalse,                       // condeferrable
+				tree.DBoolFalse,                       // condeferred
+				tree.MakeDBool(tree.DBool(validated)), // convalidated
+				oidZero,                               // conrelid
+				typOid,                                // contypid
+				oidZero,                               // conindid
+				oidZero,                               // confrelid
+				tree.DNull,                            // confupdtype
+				tree.DNull,                            // confdeltype
+				tree.DNull,                            // confmatchtype
+				tree.DBoolTrue,                        // conislocal
+				zeroVal,                               // coninhcount
+				tree.DBoolTrue,                        // connoinherit
+				tree.DNull,                            // conkey
+				tree.DNull,                            // confkey
+				tree.DNull,                            // conpfeqop
+				tree.DNull,                            // conppeqop
+				tree.DNull,                            // conffeqop
+				tree.DNull,                            // conexclop
+				consrc,                                // conbin
+				consrc,                                // consrc
+				condef,                                // condef
+				oidZero,                               // conparentid
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
 
 // colIDArrayToDatum returns an int[] containing the ColumnIDs, or NULL if there
 // are no ColumnIDs.
@@ -2418,7 +2623,44 @@ https://www.postgresql.org/docs/9.5/catalog-pg-operator.html`,
 				return err
 			}
 		}
-		return nil
+		// Operators created with CREATE OPERATOR are stored on their functions.
+		return forEachSchema(ctx, p, db, false /* requiresPrivileges */, func(ctx context.Context, sc catalog.SchemaDescriptor) error {
+			nspOid := schemaOid(sc.GetID())
+			return sc.ForEachFunctionSignature(func(sig descpb.SchemaDescriptor_FunctionSignature) error {
+				if len(sig.Operators) == 0 {
+					return nil
+				}
+				kind, leftType := prefixKind, oidZero
+				if len(sig.ArgTypes) == 2 {
+					kind, leftType = infixKind, tree.NewDOid(sig.ArgTypes[0].Oid())
+				}
+				rightType := tree.NewDOid(sig.ArgTypes[len(sig.ArgTypes)-1].Oid())
+				returnType := tree.NewDOid(sig.ReturnType.Oid())
+				for _, opName := range sig.Operators {
+					if err := addRow(
+						h.OperatorOid(opName, leftType, rightType, returnType), // oid
+
+						tree.NewDString(opName),                 // oprname
+						nspOid,                                  // oprnamespace
+						tree.DNull,                              // oprowner
+						kind,                                    // oprkind
+						tree.DBoolFalse,                         // oprcanmerge
+						tree.DBoolFalse,                         // oprcanhash
+						leftType,                                // oprleft
+						rightType,                               // oprright
+						returnType,                              // oprresult
+						tree.DNull,                              // oprcom
+						tree.DNull,                              // oprnegate
+						tree.NewDOid(catid.FuncIDToOID(sig.ID)), // oprcode
+						tree.DNull,                              // oprrest
+						tree.DNull,                              // oprjoin
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		})
 	},
 }
 
@@ -2669,8 +2911,12 @@ func addPgProcUDFRow(
 	}
 
 	kind := tree.NewDString("f")
+	prosrc := tree.NewDString(fnDesc.GetFunctionBody())
 	if fnDesc.IsProcedure() {
 		kind = tree.NewDString("p")
+	} else if fnDesc.IsAggregate() {
+		kind = tree.NewDString("a")
+		prosrc = tree.NewDString("aggregate_dummy")
 	}
 
 	lang := languageInternalOid
@@ -2679,19 +2925,37 @@ func addPgProcUDFRow(
 	} else if fnDesc.GetLanguage() == catpb.Function_SQL {
 		lang = languageSqlOid
 	}
+
+	variadic := oidZero
+	for _, param := range fnDesc.GetParams() {
+		if param.Class == catpb.Function_Param_VARIADIC {
+			variadic = tree.NewDOid(param.Type.ArrayContents().Oid())
+		}
+	}
+	secDef := tree.MakeDBool(tree.DBool(fnDesc.GetSecurity() == catpb.Function_DEFINER))
+	config := tree.DNull
+	if settings := fnDesc.GetSessionSettings(); len(settings) > 0 {
+		configArray := tree.NewDArray(types.String)
+		for _, setting := range settings {
+			if err := configArray.Append(tree.NewDString(setting.Name + "=" + setting.Value)); err != nil {
+				return err
+			}
+		}
+		config = configArray
+	}
 	return addRow(
 		tree.NewDOid(catid.FuncIDToOID(fnDesc.GetID())), // oid
 		tree.NewDName(fnDesc.GetName()),                 // proname
 		schemaOid(scDesc.GetID()),                       // pronamespace
 		h.UserOid(fnDesc.GetPrivileges().Owner()),       // proowner
-		lang,            // prolang
-		tree.DNull,      // procost
-		tree.DNull,      // prorows
-		oidZero,         // provariadic
-		tree.DNull,      // protransform
-		tree.DBoolFalse, // proisagg
+		lang,       // prolang
+		tree.DNull, // procost
+		tree.DNull, // prorows
+		variadic,   // provariadic
+		tree.DNull, // protransform
+		tree.MakeDBool(tree.DBool(fnDesc.IsAggregate())), // proisagg
 		tree.DBoolFalse, // proiswindow
-		tree.DBoolFalse, // prosecdef
+		secDef,          // prosecdef
 		tree.MakeDBool(tree.DBool(fnDesc.GetLeakProof())),            // proleakproof
 		tree.MakeDBool(tree.DBool(isStrict)),                         // proisstrict
 		tree.MakeDBool(tree.DBool(fnDesc.GetReturnType().ReturnSet)), // proretset
@@ -2706,9 +2970,9 @@ func addPgProcUDFRow(
 		argNames,                                         // proargnames
 		tree.DNull,                                       // proargdefaults
 		tree.DNull,                                       // protrftypes
-		tree.NewDString(fnDesc.GetFunctionBody()),        // prosrc
+		prosrc,                                           // prosrc
 		tree.DNull,                                       // probin
-		tree.DNull,                                       // proconfig
+		config,                                           // proconfig
 		tree.DNull,                                       // proacl
 		kind,                                             // prokind
 		// These columns were automatically created by pg_catalog_test's missing column generator.
@@ -3229,7 +3493,6 @@ var (
 	typTypeRange     = tree.NewDString("r")
 
 	// Avoid unused warning for constants.
-	_ = typTypeDomain
 	_ = typTypePseudo
 	_ = typTypeRange
 
@@ -3253,7 +3516,6 @@ var (
 	// Avoid unused warning for constants.
 	_ = typCategoryEnum
 	_ = typCategoryGeometric
-	_ = typCategoryRange
 	_ = typCategoryBitString
 
 	commaTypDelim = tree.NewDString(",")
@@ -3367,6 +3629,21 @@ func addPGTypeRow(
 	if cat == typCategoryPseudo {
 		typType = typTypePseudo
 	}
+	typNotNull := tree.DBoolFalse
+	typBaseType := oidZero
+	typDefault := tree.DNull
+	if typ.IsDomain() {
+		base := typ.DomainBaseType()
+		typType = typTypeDomain
+		builtinPrefix = builtins.PGIOBuiltinPrefix(base)
+		typBaseType = tree.NewDOid(base.Oid())
+		if dd := typ.TypeMeta.DomainData; dd != nil {
+			typNotNull = tree.MakeDBool(tree.DBool(dd.NotNull))
+			if dd.DefaultExpr != nil {
+				typDefault = tree.NewDString(*dd.DefaultExpr)
+			}
+		}
+	}
 	typname := typ.PGName()
 	typDelim := tree.NewDString(typ.Delimiter())
 	return addRow(
@@ -3396,13 +3673,13 @@ func addPGTypeRow(
 
 		tree.DNull,      // typalign
 		tree.DNull,      // typstorage
-		tree.DBoolFalse, // typnotnull
-		oidZero,         // typbasetype
+		typNotNull,      // typnotnull
+		typBaseType,     // typbasetype
 		negOneVal,       // typtypmod
 		zeroVal,         // typndims
 		typColl(typ, h), // typcollation
 		tree.DNull,      // typdefaultbin
-		tree.DNull,      // typdefault
+		typDefault,      // typdefault
 		tree.DNull,      // typacl
 	)
 }
@@ -4126,12 +4403,39 @@ var pgCatalogStatioSysTablesTable = virtualSchemaTable{
 }
 
 var pgCatalogTsConfigTable = virtualSchemaTable{
-	comment: "pg_ts_config was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogTsConfig,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `text search configurations
+https://www.postgresql.org/docs/15/catalog-pg-ts-config.html`,
+	schema: vtable.PgCatalogTsConfig,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		pgCatalogOid := tree.NewDOid(catconstants.PgCatalogID)
+		for _, name := range tsearch.BuiltinConfigNames() {
+			if err := addRow(
+				h.TextSearchConfigOid(catconstants.PgCatalogID, name), // oid
+				tree.NewDName(name), // cfgname
+				pgCatalogOid,        // cfgnamespace
+				tree.DNull,          // cfgowner
+				textSearchParserOid, // cfgparser
+			); err != nil {
+				return err
+			}
+		}
+		return forEachTextSearchSchema(ctx, p, dbContext, func(sc catalog.SchemaDescriptor) error {
+			for i := range sc.SchemaDesc().TextSearchConfigs {
+				config := &sc.SchemaDesc().TextSearchConfigs[i]
+				if err := addRow(
+					h.TextSearchConfigOid(sc.GetID(), config.Name), // oid
+					tree.NewDName(config.Name),                     // cfgname
+					schemaOid(sc.GetID()),                          // cfgnamespace
+					h.UserOid(config.OwnerProto.Decode()),          // cfgowner
+					textSearchParserOid,                            // cfgparser
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogStatsTable = virtualSchemaTable{
@@ -4189,12 +4493,32 @@ var pgCatalogStatUserFunctionsTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationTable = virtualSchemaTable{
-	comment: "pg_publication was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublication,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `publications
+https://www.postgresql.org/docs/15/catalog-pg-publication.html`,
+	schema: vtable.PgCatalogPublication,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachDatabaseDesc(ctx, p, dbContext, false, /* requiresPrivileges */
+			func(ctx context.Context, db catalog.DatabaseDescriptor) error {
+				for i := range db.DatabaseDesc().Publications {
+					pub := &db.DatabaseDesc().Publications[i]
+					if err := addRow(
+						h.PublicationOid(db.GetID(), pub.Name),          // oid
+						tree.NewDName(pub.Name),                         // pubname
+						h.UserOid(pub.OwnerProto.Decode()),              // pubowner
+						tree.MakeDBool(tree.DBool(pub.AllTables)),       // puballtables
+						tree.MakeDBool(tree.DBool(pub.PublishInsert)),   // pubinsert
+						tree.MakeDBool(tree.DBool(pub.PublishUpdate)),   // pubupdate
+						tree.MakeDBool(tree.DBool(pub.PublishDelete)),   // pubdelete
+						tree.MakeDBool(tree.DBool(pub.PublishTruncate)), // pubtruncate
+						tree.DBoolFalse, // pubviaroot
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogAmprocTable = virtualSchemaTable{
@@ -4313,12 +4637,57 @@ var pgCatalogStatAllIndexesTable = virtualSchemaTable{
 }
 
 var pgCatalogTsConfigMapTable = virtualSchemaTable{
-	comment: "pg_ts_config_map was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogTsConfigMap,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `text search configuration mappings
+https://www.postgresql.org/docs/15/catalog-pg-ts-config-map.html`,
+	schema: vtable.PgCatalogTsConfigMap,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		for _, name := range tsearch.BuiltinConfigNames() {
+			dict, err := tsearch.BuiltinConfigDictionary(name)
+			if err != nil {
+				return err
+			}
+			cfgOid := h.TextSearchConfigOid(catconstants.PgCatalogID, name)
+			dictOid := h.TextSearchDictionaryOid(catconstants.PgCatalogID, dict)
+			for _, typ := range tsearch.TokenTypes {
+				if err := addRow(
+					cfgOid,                       // mapcfg
+					tree.NewDInt(tree.DInt(typ)), // maptokentype
+					tree.NewDInt(1),              // mapseqno
+					dictOid,                      // mapdict
+				); err != nil {
+					return err
+				}
+			}
+		}
+		return forEachTextSearchSchema(ctx, p, dbContext, func(sc catalog.SchemaDescriptor) error {
+			for i := range sc.SchemaDesc().TextSearchConfigs {
+				config := &sc.SchemaDesc().TextSearchConfigs[i]
+				cfgOid := h.TextSearchConfigOid(sc.GetID(), config.Name)
+				for _, m := range config.Mappings {
+					typ, err := tsearch.ParseTokenType(m.TokenType)
+					if err != nil {
+						return err
+					}
+					for j, ref := range m.Dictionaries {
+						dictScID := sc.GetID()
+						if ref.Builtin {
+							dictScID = catconstants.PgCatalogID
+						}
+						if err := addRow(
+							cfgOid,                       // mapcfg
+							tree.NewDInt(tree.DInt(typ)), // maptokentype
+							tree.NewDInt(tree.DInt(j+1)), // mapseqno
+							h.TextSearchDictionaryOid(dictScID, ref.Name), // mapdict
+						); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			return nil
+		})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogStatBgwriterTable = virtualSchemaTable{
@@ -4349,12 +4718,23 @@ var pgCatalogStatXactUserTablesTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationTablesTable = virtualSchemaTable{
-	comment: "pg_publication_tables was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublicationTables,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `tables of publications
+https://www.postgresql.org/docs/15/view-pg-publication-tables.html`,
+	schema: vtable.PgCatalogPublicationTables,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachPublicationTable(ctx, p, dbContext,
+			func(db catalog.DatabaseDescriptor, pub *descpb.DatabaseDescriptor_Publication, tbl catalog.TableDescriptor) error {
+				sc, err := p.Descriptors().ByIDWithLeased(p.Txn()).WithoutNonPublic().Get().Schema(ctx, tbl.GetParentSchemaID())
+				if err != nil {
+					return err
+				}
+				return addRow(
+					tree.NewDName(pub.Name),      // pubname
+					tree.NewDName(sc.GetName()),  // schemaname
+					tree.NewDName(tbl.GetName()), // tablename
+				)
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogStatProgressClusterTable = virtualSchemaTable{
@@ -4385,12 +4765,56 @@ var pgCatalogLargeobjectMetadataTable = virtualSchemaTable{
 }
 
 var pgCatalogReplicationSlotsTable = virtualSchemaTable{
-	comment: "pg_replication_slots was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogReplicationSlots,
+	comment: `replication slots
+https://www.postgresql.org/docs/15/view-pg-replication-slots.html`,
+	schema: vtable.PgCatalogReplicationSlots,
 	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		dbNames := make(map[descpb.ID]string)
+		if err := forEachDatabaseDesc(ctx, p, nil /* all databases */, false, /* requiresPrivileges */
+			func(ctx context.Context, db catalog.DatabaseDescriptor) error {
+				dbNames[db.GetID()] = db.GetName()
+				return nil
+			}); err != nil {
+			return err
+		}
+		rows, err := p.InternalSQLTxn().QueryBufferedEx(
+			ctx,
+			"select-replication-slots",
+			p.Txn(),
+			sessiondata.NodeUserSessionDataOverride,
+			`SELECT slot_name, database_id, plugin, confirmed_flush_lsn FROM system.public.replication_slots`,
+		)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			dbID := descpb.ID(tree.MustBeDInt(row[1]))
+			database := tree.DNull
+			if name, ok := dbNames[dbID]; ok {
+				database = tree.NewDName(name)
+			}
+			confirmedFlushLSN := tree.NewDString(lsn.LSN(tree.MustBeDInt(row[3])).String())
+			if err := addRow(
+				tree.NewDName(string(tree.MustBeDString(row[0]))), // slot_name
+				tree.NewDName(string(tree.MustBeDString(row[2]))), // plugin
+				tree.NewDString("logical"),                        // slot_type
+				dbOid(dbID),                                       // datoid
+				database,                                          // database
+				tree.DBoolFalse,                                   // temporary
+				tree.DBoolFalse,                                   // active
+				tree.DNull,                                        // active_pid
+				tree.DNull,                                        // xmin
+				tree.DNull,                                        // catalog_xmin
+				confirmedFlushLSN,                                 // restart_lsn
+				confirmedFlushLSN,                                 // confirmed_flush_lsn
+				tree.NewDString("reserved"),                       // wal_status
+				tree.DNull,                                        // safe_wal_size
+			); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
-	unimplemented: true,
 }
 
 var pgCatalogSubscriptionRelTable = virtualSchemaTable{
@@ -4499,12 +4923,106 @@ func addRowForTimezoneNames(tz string, t time.Time, addRow func(...tree.Datum) e
 }
 
 var pgCatalogTsDictTable = virtualSchemaTable{
-	comment: "pg_ts_dict was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogTsDict,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `text search dictionaries
+https://www.postgresql.org/docs/15/catalog-pg-ts-dict.html`,
+	schema: vtable.PgCatalogTsDict,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		pgCatalogOid := tree.NewDOid(catconstants.PgCatalogID)
+		for _, name := range tsearch.BuiltinConfigNames() {
+			dict, err := tsearch.BuiltinConfigDictionary(name)
+			if err != nil {
+				return err
+			}
+			template := tsearch.DictTemplateSnowball
+			initOption := tree.DNull
+			if dict == "simple" {
+				template = tsearch.DictTemplateSimple
+			} else {
+				initOption = tree.NewDString(fmt.Sprintf("language = '%s', stopwords = '%s'", name, name))
+			}
+			if err := addRow(
+				h.TextSearchDictionaryOid(catconstants.PgCatalogID, dict), // oid
+				tree.NewDName(dict), // dictname
+				pgCatalogOid,        // dictnamespace
+				tree.DNull,          // dictowner
+				h.TextSearchTemplateOid(template.String()), // dicttemplate
+				initOption, // dictinitoption
+			); err != nil {
+				return err
+			}
+		}
+		return forEachTextSearchSchema(ctx, p, dbContext, func(sc catalog.SchemaDescriptor) error {
+			for i := range sc.SchemaDesc().TextSearchDictionaries {
+				dict := &sc.SchemaDesc().TextSearchDictionaries[i]
+				if err := addRow(
+					h.TextSearchDictionaryOid(sc.GetID(), dict.Name), // oid
+					tree.NewDName(dict.Name),                         // dictname
+					schemaOid(sc.GetID()),                            // dictnamespace
+					h.UserOid(dict.OwnerProto.Decode()),              // dictowner
+					h.TextSearchTemplateOid(dict.Template),           // dicttemplate
+					textSearchDictionaryInitOption(dict),             // dictinitoption
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
 	},
-	unimplemented: true,
+}
+
+// textSearchParserOid is the OID of the default text search parser in
+// Postgres, which is the only parser.
+var textSearchParserOid = tree.NewDOid(3722)
+
+// forEachTextSearchSchema calls fn with each schema which may contain text
+// search objects.
+func forEachTextSearchSchema(
+	ctx context.Context,
+	p *planner,
+	dbContext catalog.DatabaseDescriptor,
+	fn func(sc catalog.SchemaDescriptor) error,
+) error {
+	return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
+		func(ctx context.Context, db catalog.DatabaseDescriptor) error {
+			return forEachSchema(ctx, p, db, true /* requiresPrivileges */, func(ctx context.Context, sc catalog.SchemaDescriptor) error {
+				if sc.SchemaKind() != catalog.SchemaUserDefined && sc.SchemaKind() != catalog.SchemaPublic {
+					return nil
+				}
+				return fn(sc)
+			})
+		})
+}
+
+// textSearchDictionaryInitOption returns the options of a text search
+// dictionary other than its template, formatted as in Postgres.
+func textSearchDictionaryInitOption(dict *descpb.SchemaDescriptor_TextSearchDictionary) tree.Datum {
+	var opts []string
+	if dict.Language != "" {
+		opts = append(opts, fmt.Sprintf("%s = %s", tree.TextSearchDictOptionLanguage, lexbase.EscapeSQLString(dict.Language)))
+	}
+	if dict.StopWords != "" {
+		opts = append(opts, fmt.Sprintf("%s = %s", tree.TextSearchDictOptionStopWords, lexbase.EscapeSQLString(dict.StopWords)))
+	}
+	if len(dict.StopWordList) > 0 {
+		opts = append(opts, fmt.Sprintf("%s = %s",
+			tree.TextSearchDictOptionStopWordList, lexbase.EscapeSQLString(strings.Join(dict.StopWordList, ", "))))
+	}
+	if len(dict.Synonyms) > 0 {
+		synonyms := make([]string, len(dict.Synonyms))
+		for i, syn := range dict.Synonyms {
+			synonyms[i] = syn.Word + " " + syn.Synonym
+		}
+		opts = append(opts, fmt.Sprintf("%s = %s",
+			tree.TextSearchDictOptionSynonyms, lexbase.EscapeSQLString(strings.Join(synonyms, ", "))))
+	}
+	if dict.Reject {
+		opts = append(opts, fmt.Sprintf("%s = false", tree.TextSearchDictOptionAccept))
+	}
+	if len(opts) == 0 {
+		return tree.DNull
+	}
+	return tree.NewDString(strings.Join(opts, ", "))
 }
 
 var pgCatalogStatUserTablesTable = virtualSchemaTable{
@@ -4643,12 +5161,27 @@ var pgCatalogStatXactSysTablesTable = virtualSchemaTable{
 }
 
 var pgCatalogTsTemplateTable = virtualSchemaTable{
-	comment: "pg_ts_template was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogTsTemplate,
+	comment: `text search templates
+https://www.postgresql.org/docs/15/catalog-pg-ts-template.html`,
+	schema: vtable.PgCatalogTsTemplate,
 	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		pgCatalogOid := tree.NewDOid(catconstants.PgCatalogID)
+		for _, t := range []tsearch.DictTemplate{
+			tsearch.DictTemplateSimple, tsearch.DictTemplateSnowball, tsearch.DictTemplateSynonym,
+		} {
+			if err := addRow(
+				h.TextSearchTemplateOid(t.String()), // oid
+				tree.NewDName(t.String()),           // tmplname
+				pgCatalogOid,                        // tmplnamespace
+				tree.DNull,                          // tmplinit
+				tree.DNull,                          // tmpllexize
+			); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
-	unimplemented: true,
 }
 
 var pgCatalogStatReplicationTable = virtualSchemaTable{
@@ -4661,12 +5194,50 @@ var pgCatalogStatReplicationTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationRelTable = virtualSchemaTable{
-	comment: "pg_publication_rel was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublicationRel,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `tables which were explicitly added to publications
+https://www.postgresql.org/docs/15/catalog-pg-publication-rel.html`,
+	schema: vtable.PgCatalogPublicationRel,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachPublicationTable(ctx, p, dbContext,
+			func(db catalog.DatabaseDescriptor, pub *descpb.DatabaseDescriptor_Publication, tbl catalog.TableDescriptor) error {
+				if pub.AllTables {
+					return nil
+				}
+				pubOid := h.PublicationOid(db.GetID(), pub.Name)
+				return addRow(
+					h.PublicationRelOid(pubOid, tbl.GetID()), // oid
+					pubOid,                                   // prpubid
+					tableOid(tbl.GetID()),                    // prrelid
+				)
+			})
 	},
-	unimplemented: true,
+}
+
+// forEachPublicationTable calls fn for each table of each publication of the
+// databases in dbContext.
+func forEachPublicationTable(
+	ctx context.Context,
+	p *planner,
+	dbContext catalog.DatabaseDescriptor,
+	fn func(catalog.DatabaseDescriptor, *descpb.DatabaseDescriptor_Publication, catalog.TableDescriptor) error,
+) error {
+	return forEachDatabaseDesc(ctx, p, dbContext, false, /* requiresPrivileges */
+		func(ctx context.Context, db catalog.DatabaseDescriptor) error {
+			for i := range db.DatabaseDesc().Publications {
+				pub := &db.DatabaseDesc().Publications[i]
+				tables, err := publicationTables(ctx, p.Descriptors(), p.Txn(), db, pub)
+				if err != nil {
+					return err
+				}
+				for _, tbl := range tables {
+					if err := fn(db, pub, tbl); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
 }
 
 var pgCatalogAvailableExtensionVersionsTable = virtualSchemaTable{
@@ -4730,6 +5301,7 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.GeographyFamily:   typCategoryUserDefined,
 	types.GeometryFamily:    typCategoryUserDefined,
 	types.JsonFamily:        typCategoryUserDefined,
+	types.JSONPathFamily:    typCategoryUserDefined,
 	types.DecimalFamily:     typCategoryNumeric,
 	types.StringFamily:      typCategoryString,
 	types.TimestampFamily:   typCategoryDateTime,
@@ -4741,10 +5313,13 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.OidFamily:         typCategoryNumeric,
 	types.PGLSNFamily:       typCategoryUserDefined,
 	types.RefCursorFamily:   typCategoryUserDefined,
+	types.RangeFamily:       typCategoryRange,
+	types.MultirangeFamily:  typCategoryRange,
 	types.UuidFamily:        typCategoryUserDefined,
 	types.INetFamily:        typCategoryNetworkAddr,
 	types.UnknownFamily:     typCategoryUnknown,
 	types.VoidFamily:        typCategoryPseudo,
+	types.TriggerFamily:     typCategoryPseudo,
 }
 
 func typCategory(typ *types.T) tree.Datum {
@@ -4865,11 +5440,75 @@ https://www.postgresql.org/docs/9.6/catalog-pg-aggregate.html`,
 						}
 					}
 				}
-				return nil
+				return forEachSchema(ctx, p, db, true /* requiresPrivileges */, func(ctx context.Context, scDesc catalog.SchemaDescriptor) error {
+					return scDesc.ForEachFunctionSignature(func(sig descpb.SchemaDescriptor_FunctionSignature) error {
+						if !sig.IsAggregate {
+							return nil
+						}
+						fnDesc, err := p.Descriptors().ByID(p.Txn()).WithoutNonPublic().Get().Function(ctx, sig.ID)
+						if err != nil {
+							return err
+						}
+						return addPgAggregateUDFRow(ctx, p, fnDesc, addRow)
+					})
+				})
 			})
 	},
 }
 
+// addPgAggregateUDFRow adds a row to pg_aggregate for the given user-defined
+// aggregate.
+func addPgAggregateUDFRow(
+	ctx context.Context,
+	p *planner,
+	fnDesc catalog.FunctionDescriptor,
+	addRow func(...tree.Datum) error,
+) error {
+	agg := fnDesc.FuncDesc().Aggregate
+	supportFuncs := make([]tree.Datum, 3)
+	for i, fnOid := range []oid.Oid{agg.StateFunc, agg.FinalFunc, agg.CombineFunc} {
+		if fnOid == 0 {
+			supportFuncs[i] = tree.NewDOidWithName(0, types.RegProc, "-")
+			continue
+		}
+		name, _, err := p.ResolveFunctionByOID(ctx, fnOid)
+		if err != nil {
+			return err
+		}
+		supportFuncs[i] = tree.NewDOid(fnOid).AsRegProc(name.Object())
+	}
+	initVal := tree.DNull
+	if agg.InitCond != nil {
+		initVal = tree.NewDString(*agg.InitCond)
+	}
+	regprocForZeroOid := tree.NewDOidWithName(0, types.RegProc, "-")
+	return addRow(
+		tree.NewDOid(catid.FuncIDToOID(fnDesc.GetID())).AsRegProc(fnDesc.GetName()), // aggfnoid
+		tree.NewDString("n"),              // aggkind
+		zeroVal,                           // aggnumdirectargs
+		supportFuncs[0],                   // aggtransfn
+		supportFuncs[1],                   // aggfinalfn
+		supportFuncs[2],                   // aggcombinefn
+		regprocForZeroOid,                 // aggserialfn
+		regprocForZeroOid,                 // aggdeserialfn
+		regprocForZeroOid,                 // aggmtransfn
+		regprocForZeroOid,                 // aggminvtransfn
+		regprocForZeroOid,                 // aggmfinalfn
+		tree.DBoolFalse,                   // aggfinalextra
+		tree.DBoolFalse,                   // aggmfinalextra
+		oidZero,                           // aggsortop
+		tree.NewDOid(agg.StateType.Oid()), // aggtranstype
+		zeroVal,                           // aggtransspace
+		oidZero,                           // aggmtranstype
+		zeroVal,                           // aggmtransspace
+		initVal,                           // agginitval
+		tree.DNull,                        // aggminitval
+		// These columns were automatically created by pg_catalog_test's missing column generator.
+		tree.DNull, // aggfinalmodify
+		tree.DNull, // aggmfinalmodify
+	)
+}
+
 // oidHasher provides a consistent hashing mechanism for object identifiers in
 // pg_catalog tables, allowing for reliable joins across tables.
 //
@@ -4958,6 +5597,12 @@ const (
 	rewriteTypeTag
 	dbSchemaRoleTypeTag
 	castTypeTag
+	publicationTypeTag
+	publicationRelTypeTag
+	domainCheckConstraintTypeTag
+	textSearchConfigTypeTag
+	textSearchDictionaryTypeTag
+	textSearchTemplateTypeTag
 )
 
 func (h oidHasher) writeTypeTag(tag oidTypeTag) {
@@ -5030,6 +5675,17 @@ func (h oidHasher) CheckConstraintOid(
 	return h.getOid()
 }
 
+func (h oidHasher) DomainCheckConstraintOid(
+	dbID descpb.ID, scID descpb.ID, typeID descpb.ID, constraintID descpb.ConstraintID,
+) *tree.DOid {
+	h.writeTypeTag(domainCheckConstraintTypeTag)
+	h.writeDB(dbID)
+	h.writeSchema(scID)
+	h.writeUInt32(uint32(typeID))
+	h.writeUInt32(uint32(constraintID))
+	return h.getOid()
+}
+
 func (h oidHasher) PrimaryKeyConstraintOid(
 	dbID descpb.ID, scID descpb.ID, tableID descpb.ID, pkey catalog.UniqueWithIndexConstraint,
 ) *tree.DOid {
@@ -5157,6 +5813,48 @@ func (h oidHasher) CastOid(srcID oid.Oid, tgtID oid.Oid) *tree.DOid {
 	return h.getOid()
 }
 
+// PublicationOid creates an OID for the publication of a database.
+func (h oidHasher) PublicationOid(dbID descpb.ID, name string) *tree.DOid {
+	h.writeTypeTag(publicationTypeTag)
+	h.writeDB(dbID)
+	h.writeStr(name)
+	return h.getOid()
+}
+
+// PublicationRelOid creates an OID for the membership of a table in a
+// publication.
+func (h oidHasher) PublicationRelOid(pubOid *tree.DOid, tableID descpb.ID) *tree.DOid {
+	h.writeTypeTag(publicationRelTypeTag)
+	h.writeOID(pubOid)
+	h.writeTable(tableID)
+	return h.getOid()
+}
+
+// TextSearchConfigOid creates an OID for a text search configuration. The
+// built-in configurations belong to pg_catalog.
+func (h oidHasher) TextSearchConfigOid(scID descpb.ID, name string) *tree.DOid {
+	h.writeTypeTag(textSearchConfigTypeTag)
+	h.writeSchema(scID)
+	h.writeStr(name)
+	return h.getOid()
+}
+
+// TextSearchDictionaryOid creates an OID for a text search dictionary. The
+// built-in dictionaries belong to pg_catalog.
+func (h oidHasher) TextSearchDictionaryOid(scID descpb.ID, name string) *tree.DOid {
+	h.writeTypeTag(textSearchDictionaryTypeTag)
+	h.writeSchema(scID)
+	h.writeStr(name)
+	return h.getOid()
+}
+
+// TextSearchTemplateOid creates an OID for a text search template.
+func (h oidHasher) TextSearchTemplateOid(name string) *tree.DOid {
+	h.writeTypeTag(textSearchTemplateTypeTag)
+	h.writeStr(name)
+	return h.getOid()
+}
+
 func funcVolatility(v catpb.Function_Volatility) string {
 	switch v {
 	case catpb.Function_IMMUTABLE:
@@ -11,15 +11,16 @@
 package lsnutil
 
 import (
-	"time"
-
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 )
 
 // HLCToLSN converts a HLC to a LSN.
 // It is in a separate package to prevent the `lsn` package importing `log`.
+//
+// The LSN is the wall time of the timestamp in nanoseconds, so that LSNs
+// increase with time. The logical component is dropped, so distinct
+// timestamps may map to the same LSN.
 func HLCToLSN(h hlc.Timestamp) lsn.LSN {
-	// TODO(#105130): correctly populate this field.
-	return lsn.LSN(h.WallTime/int64(time.Millisecond)) << 32
+	return lsn.LSN(h.WallTime)
 }
@@ -0,0 +1,311 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package pgoutput encodes the logical replication messages of the pgoutput
+// output plugin of Postgres, and the walsender messages of the streaming
+// replication protocol which carry them.
+//
+// See https://www.postgresql.org/docs/current/protocol-logicalrep-message-formats.html
+// and https://www.postgresql.org/docs/current/protocol-replication.html.
+package pgoutput
+
+import (
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// ProtoVersion is the version of the logical replication protocol which is
+// supported, which is passed by clients in the proto_version option of
+// START_REPLICATION. Version 1 does not include streaming of in-progress
+// transactions, which is not needed as transactions are only sent once they
+// are committed.
+const ProtoVersion = 1
+
+// PluginName is the name of the output plugin, as given when creating a
+// replication slot.
+const PluginName = "pgoutput"
+
+// Writer is the buffer into which messages are encoded. It is implemented by
+// the pgwire connection, which owns the text encoding of datums.
+type Writer interface {
+	PutByte(b byte)
+	PutInt16(v int16)
+	PutInt32(v int32)
+	PutInt64(v int64)
+	// PutString writes a null-terminated string.
+	PutString(s string)
+	// PutTextDatum writes the text encoding of d prefixed by its length. d is
+	// not NULL.
+	PutTextDatum(d tree.Datum, t *types.T)
+}
+
+// Message is a message which can be sent to a replication client.
+type Message interface {
+	// Encode writes the message to w.
+	Encode(w Writer)
+}
+
+// Begin is sent at the start of each transaction.
+type Begin struct {
+	// FinalLSN is the LSN of the commit of the transaction.
+	FinalLSN   lsn.LSN
+	CommitTime time.Time
+	XID        uint32
+}
+
+// Commit is sent at the end of each transaction.
+type Commit struct {
+	// CommitLSN is the LSN of the commit of the transaction, and EndLSN is the
+	// LSN after it.
+	CommitLSN  lsn.LSN
+	EndLSN     lsn.LSN
+	CommitTime time.Time
+}
+
+// ReplicaIdentityDefault is the replica identity of tables whose rows are
+// identified by their primary key, which is the replica identity of every
+// table.
+const ReplicaIdentityDefault = 'd'
+
+// Relation describes a table. It is sent before the first change to the table
+// in a stream, and again whenever the table changes.
+type Relation struct {
+	RelationID      oid.Oid
+	Namespace       string
+	Name            string
+	ReplicaIdentity byte
+	Columns         []RelationColumn
+}
+
+// RelationColumn describes a column of a Relation.
+type RelationColumn struct {
+	// Key is set if the column is part of the replica identity of the table.
+	Key          bool
+	Name         string
+	TypeOID      oid.Oid
+	TypeModifier int32
+}
+
+// Tuple is the value of a row. Types has the type of every datum.
+type Tuple struct {
+	Datums tree.Datums
+	Types  []*types.T
+}
+
+// Insert is sent for each inserted row.
+type Insert struct {
+	RelationID oid.Oid
+	New        Tuple
+}
+
+// Update is sent for each updated row. Since the primary key of a row cannot
+// change without the row being deleted and inserted, the old row is never
+// sent.
+type Update struct {
+	RelationID oid.Oid
+	New        Tuple
+}
+
+// Delete is sent for each deleted row. Key has the values of the key columns
+// of the row; the other columns are NULL.
+type Delete struct {
+	RelationID oid.Oid
+	Key        Tuple
+}
+
+// XLogData carries a logical replication message.
+type XLogData struct {
+	// WALStart is the LSN of the message and WALEnd is the current end of the
+	// WAL on the server.
+	WALStart lsn.LSN
+	WALEnd   lsn.LSN
+	SendTime time.Time
+	Message  Message
+}
+
+// PrimaryKeepalive is sent periodically by the server. It lets the client
+// know that every change up to WALEnd has been sent, and may ask the client
+// to reply with a StandbyStatusUpdate.
+type PrimaryKeepalive struct {
+	WALEnd         lsn.LSN
+	SendTime       time.Time
+	ReplyRequested bool
+}
+
+var _ Message = Begin{}
+var _ Message = Commit{}
+var _ Message = Relation{}
+var _ Message = Insert{}
+var _ Message = Update{}
+var _ Message = Delete{}
+var _ Message = XLogData{}
+var _ Message = PrimaryKeepalive{}
+
+// Encode implements the Message interface.
+func (m Begin) Encode(w Writer) {
+	w.PutByte('B')
+	w.PutInt64(int64(m.FinalLSN))
+	w.PutInt64(toPGTime(m.CommitTime))
+	w.PutInt32(int32(m.XID))
+}
+
+// Encode implements the Message interface.
+func (m Commit) Encode(w Writer) {
+	w.PutByte('C')
+	// There are no flags.
+	w.PutByte(0)
+	w.PutInt64(int64(m.CommitLSN))
+	w.PutInt64(int64(m.EndLSN))
+	w.PutInt64(toPGTime(m.CommitTime))
+}
+
+// Encode implements the Message interface.
+func (m Relation) Encode(w Writer) {
+	w.PutByte('R')
+	w.PutInt32(int32(m.RelationID))
+	w.PutString(m.Namespace)
+	w.PutString(m.Name)
+	w.PutByte(m.ReplicaIdentity)
+	w.PutInt16(int16(len(m.Columns)))
+	for _, c := range m.Columns {
+		var flags byte
+		if c.Key {
+			flags = 1
+		}
+		w.PutByte(flags)
+		w.PutString(c.Name)
+		w.PutInt32(int32(c.TypeOID))
+		w.PutInt32(c.TypeModifier)
+	}
+}
+
+// Encode implements the Message interface.
+func (m Insert) Encode(w Writer) {
+	w.PutByte('I')
+	w.PutInt32(int32(m.RelationID))
+	w.PutByte('N')
+	m.New.encode(w)
+}
+
+// Encode implements the Message interface.
+func (m Update) Encode(w Writer) {
+	w.PutByte('U')
+	w.PutInt32(int32(m.RelationID))
+	w.PutByte('N')
+	m.New.encode(w)
+}
+
+// Encode implements the Message interface.
+func (m Delete) Encode(w Writer) {
+	w.PutByte('D')
+	w.PutInt32(int32(m.RelationID))
+	w.PutByte('K')
+	m.Key.encode(w)
+}
+
+func (t Tuple) encode(w Writer) {
+	w.PutInt16(int16(len(t.Datums)))
+	for i, d := range t.Datums {
+		if d == tree.DNull {
+			w.PutByte('n')
+			continue
+		}
+		w.PutByte('t')
+		w.PutTextDatum(d, t.Types[i])
+	}
+}
+
+// Encode implements the Message interface.
+func (m XLogData) Encode(w Writer) {
+	w.PutByte('w')
+	w.PutInt64(int64(m.WALStart))
+	w.PutInt64(int64(m.WALEnd))
+	w.PutInt64(toPGTime(m.SendTime))
+	m.Message.Encode(w)
+}
+
+// Encode implements the Message interface.
+func (m PrimaryKeepalive) Encode(w Writer) {
+	w.PutByte('k')
+	w.PutInt64(int64(m.WALEnd))
+	w.PutInt64(toPGTime(m.SendTime))
+	var reply byte
+	if m.ReplyRequested {
+		reply = 1
+	}
+	w.PutByte(reply)
+}
+
+// StandbyStatusUpdate is sent by the client to report the position up to which
+// it has received the stream.
+type StandbyStatusUpdate struct {
+	WALWritten lsn.LSN
+	// WALFlushed is the position up to which the client has durably received the
+	// stream. The changes up to it need not be sent again.
+	WALFlushed     lsn.LSN
+	WALApplied     lsn.LSN
+	ClientTime     time.Time
+	ReplyRequested bool
+}
+
+// standbyStatusUpdateLen is the length of a StandbyStatusUpdate message,
+// including its type byte.
+const standbyStatusUpdateLen = 1 + 8 + 8 + 8 + 8 + 1
+
+// Types of the messages sent by the client in CopyData messages.
+const (
+	// StandbyStatusUpdateType is the type of StandbyStatusUpdate messages.
+	StandbyStatusUpdateType = 'r'
+	// HotStandbyFeedbackType is the type of hot standby feedback messages,
+	// which are only meaningful for physical replication.
+	HotStandbyFeedbackType = 'h'
+)
+
+// ParseStandbyStatusUpdate parses the contents of a CopyData message of type
+// StandbyStatusUpdateType.
+func ParseStandbyStatusUpdate(data []byte) (StandbyStatusUpdate, error) {
+	if len(data) != standbyStatusUpdateLen || data[0] != StandbyStatusUpdateType {
+		return StandbyStatusUpdate{}, pgwirebase.NewProtocolViolationErrorf(
+			"invalid standby status update message")
+	}
+	buf := pgwirebase.ReadBuffer{Msg: data[1:]}
+	var vals [4]uint64
+	for i := range vals {
+		v, err := buf.GetUint64()
+		if err != nil {
+			return StandbyStatusUpdate{}, errors.Wrap(err, "invalid standby status update message")
+		}
+		vals[i] = v
+	}
+	return StandbyStatusUpdate{
+		WALWritten:     lsn.LSN(vals[0]),
+		WALFlushed:     lsn.LSN(vals[1]),
+		WALApplied:     lsn.LSN(vals[2]),
+		ClientTime:     fromPGTime(int64(vals[3])),
+		ReplyRequested: buf.Msg[0] == 1,
+	}, nil
+}
+
+// toPGTime returns the number of microseconds since the Postgres epoch,
+// which is how times are encoded in replication messages.
+func toPGTime(t time.Time) int64 {
+	return duration.DiffMicros(t, pgwirebase.PGEpochJDate)
+}
+
+func fromPGTime(micros int64) time.Time {
+	return duration.AddMicros(pgwirebase.PGEpochJDate, micros)
+}
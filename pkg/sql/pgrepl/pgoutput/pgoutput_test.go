@@ -0,0 +1,193 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgoutput
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/lib/pq/oid"
+	"github.com/stretchr/testify/require"
+)
+
+// testWriter encodes datums with their plain text representation.
+type testWriter struct {
+	buf []byte
+}
+
+func (w *testWriter) PutByte(b byte)     { w.buf = append(w.buf, b) }
+func (w *testWriter) PutInt16(v int16)   { w.buf = binary.BigEndian.AppendUint16(w.buf, uint16(v)) }
+func (w *testWriter) PutInt32(v int32)   { w.buf = binary.BigEndian.AppendUint32(w.buf, uint32(v)) }
+func (w *testWriter) PutInt64(v int64)   { w.buf = binary.BigEndian.AppendUint64(w.buf, uint64(v)) }
+func (w *testWriter) PutString(s string) { w.buf = append(append(w.buf, s...), 0) }
+func (w *testWriter) PutTextDatum(d tree.Datum, _ *types.T) {
+	s := tree.AsStringWithFlags(d, tree.FmtPgwireText)
+	w.PutInt32(int32(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+func TestEncode(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	commitTime := time.Date(2000, 1, 1, 0, 0, 1, 0, time.UTC)
+	for _, tc := range []struct {
+		name     string
+		msg      Message
+		expected []byte
+	}{
+		{
+			name: "begin",
+			msg:  Begin{FinalLSN: lsn.LSN(0x0102), CommitTime: commitTime, XID: 7},
+			expected: []byte{
+				'B',
+				0, 0, 0, 0, 0, 0, 0x01, 0x02,
+				0, 0, 0, 0, 0, 0x0f, 0x42, 0x40,
+				0, 0, 0, 7,
+			},
+		},
+		{
+			name: "commit",
+			msg:  Commit{CommitLSN: lsn.LSN(1), EndLSN: lsn.LSN(2), CommitTime: commitTime},
+			expected: []byte{
+				'C', 0,
+				0, 0, 0, 0, 0, 0, 0, 1,
+				0, 0, 0, 0, 0, 0, 0, 2,
+				0, 0, 0, 0, 0, 0x0f, 0x42, 0x40,
+			},
+		},
+		{
+			name: "relation",
+			msg: Relation{
+				RelationID:      oid.Oid(104),
+				Namespace:       "public",
+				Name:            "t",
+				ReplicaIdentity: ReplicaIdentityDefault,
+				Columns: []RelationColumn{
+					{Key: true, Name: "k", TypeOID: oid.T_int8, TypeModifier: -1},
+					{Name: "s", TypeOID: oid.T_text, TypeModifier: -1},
+				},
+			},
+			expected: []byte{
+				'R',
+				0, 0, 0, 104,
+				'p', 'u', 'b', 'l', 'i', 'c', 0,
+				't', 0,
+				'd',
+				0, 2,
+				1, 'k', 0, 0, 0, 0, 20, 0xff, 0xff, 0xff, 0xff,
+				0, 's', 0, 0, 0, 0, 25, 0xff, 0xff, 0xff, 0xff,
+			},
+		},
+		{
+			name: "insert",
+			msg: Insert{
+				RelationID: oid.Oid(104),
+				New: Tuple{
+					Datums: tree.Datums{tree.NewDInt(12), tree.DNull},
+					Types:  []*types.T{types.Int, types.String},
+				},
+			},
+			expected: []byte{
+				'I',
+				0, 0, 0, 104,
+				'N',
+				0, 2,
+				't', 0, 0, 0, 2, '1', '2',
+				'n',
+			},
+		},
+		{
+			name: "delete",
+			msg: Delete{
+				RelationID: oid.Oid(104),
+				Key: Tuple{
+					Datums: tree.Datums{tree.NewDString("a"), tree.DNull},
+					Types:  []*types.T{types.String, types.String},
+				},
+			},
+			expected: []byte{
+				'D',
+				0, 0, 0, 104,
+				'K',
+				0, 2,
+				't', 0, 0, 0, 1, 'a',
+				'n',
+			},
+		},
+		{
+			name: "xlogdata",
+			msg: XLogData{
+				WALStart: lsn.LSN(1),
+				WALEnd:   lsn.LSN(2),
+				SendTime: commitTime,
+				Message:  Commit{CommitLSN: lsn.LSN(1), EndLSN: lsn.LSN(2), CommitTime: commitTime},
+			},
+			expected: []byte{
+				'w',
+				0, 0, 0, 0, 0, 0, 0, 1,
+				0, 0, 0, 0, 0, 0, 0, 2,
+				0, 0, 0, 0, 0, 0x0f, 0x42, 0x40,
+				'C', 0,
+				0, 0, 0, 0, 0, 0, 0, 1,
+				0, 0, 0, 0, 0, 0, 0, 2,
+				0, 0, 0, 0, 0, 0x0f, 0x42, 0x40,
+			},
+		},
+		{
+			name: "keepalive",
+			msg:  PrimaryKeepalive{WALEnd: lsn.LSN(3), SendTime: commitTime, ReplyRequested: true},
+			expected: []byte{
+				'k',
+				0, 0, 0, 0, 0, 0, 0, 3,
+				0, 0, 0, 0, 0, 0x0f, 0x42, 0x40,
+				1,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var w testWriter
+			tc.msg.Encode(&w)
+			require.Equal(t, tc.expected, w.buf)
+		})
+	}
+}
+
+func TestParseStandbyStatusUpdate(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	data := []byte{
+		'r',
+		0, 0, 0, 0, 0, 0, 0, 3,
+		0, 0, 0, 0, 0, 0, 0, 2,
+		0, 0, 0, 0, 0, 0, 0, 1,
+		0, 0, 0, 0, 0, 0x0f, 0x42, 0x40,
+		1,
+	}
+	u, err := ParseStandbyStatusUpdate(data)
+	require.NoError(t, err)
+	require.Equal(t, StandbyStatusUpdate{
+		WALWritten:     lsn.LSN(3),
+		WALFlushed:     lsn.LSN(2),
+		WALApplied:     lsn.LSN(1),
+		ClientTime:     time.Date(2000, 1, 1, 0, 0, 1, 0, time.UTC),
+		ReplyRequested: true,
+	}, u)
+
+	_, err = ParseStandbyStatusUpdate(data[:len(data)-1])
+	require.Error(t, err)
+	_, err = ParseStandbyStatusUpdate(append([]byte{'h'}, data[1:]...))
+	require.Error(t, err)
+}
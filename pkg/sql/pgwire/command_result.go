@@ -20,6 +20,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/notify"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -41,6 +43,9 @@ const (
 	emptyQueryResponse
 	readyForQuery
 	flush
+	// notification is used for the results of DeliverNotifications commands,
+	// which flush the buffered notifications, if any.
+	notification
 	// Some commands, like Describe, don't need a completion message.
 	noCompletionMsg
 )
@@ -60,6 +65,7 @@ type commandResult struct {
 	buffer struct {
 		notices            []pgnotice.Notice
 		paramStatusUpdates []paramStatusUpdate
+		notifications      []notify.Notification
 	}
 
 	err error
@@ -148,6 +154,12 @@ func (r *commandResult) Close(ctx context.Context, t sql.TransactionStatusIndica
 		}
 	}
 
+	for _, n := range r.buffer.notifications {
+		if err := r.conn.bufferNotification(n); err != nil {
+			panic(errors.NewAssertionErrorWithWrappedErrf(err, "unexpected err when sending notification"))
+		}
+	}
+
 	// Send a completion message, specific to the type of result.
 	switch r.typ {
 	case commandComplete:
@@ -172,6 +184,12 @@ func (r *commandResult) Close(ctx context.Context, t sql.TransactionStatusIndica
 		// The error is saved on conn.err.
 		_ /* err */ = r.conn.Flush(r.pos)
 		r.conn.maybeReallocate()
+	case notification:
+		if len(r.buffer.notifications) > 0 || len(r.buffer.notices) > 0 {
+			// The error is saved on conn.err.
+			_ /* err */ = r.conn.Flush(r.pos)
+			r.conn.maybeReallocate()
+		}
 	case noCompletionMsg:
 		// nothing to do
 	default:
@@ -332,6 +350,11 @@ func (r *commandResult) BufferNotice(notice pgnotice.Notice) {
 	r.buffer.notices = append(r.buffer.notices, notice)
 }
 
+// BufferNotification is part of the sql.NotificationBuffer interface.
+func (r *commandResult) BufferNotification(n notify.Notification) {
+	r.buffer.notifications = append(r.buffer.notifications, n)
+}
+
 // SendNotice is part of the sql.RestrictedCommandResult interface.
 func (r *commandResult) SendNotice(ctx context.Context, notice pgnotice.Notice) error {
 	if err := r.conn.bufferNotice(ctx, notice); err != nil {
@@ -413,6 +436,21 @@ func (r *commandResult) SendCopyDone(ctx context.Context) error {
 	return r.conn.bufferCopyDone()
 }
 
+// SendCopyBothResponse is part of the sql.StartReplicationResult interface.
+func (r *commandResult) SendCopyBothResponse(ctx context.Context) error {
+	r.assertNotReleased()
+	r.conn.writerState.fi.registerCmd(r.pos)
+	return r.conn.bufferCopyBothResponse()
+}
+
+// SendReplicationMessage is part of the sql.StartReplicationResult interface.
+func (r *commandResult) SendReplicationMessage(ctx context.Context, msg pgoutput.Message) error {
+	if err := r.beforeAdd(); err != nil {
+		return err
+	}
+	return r.conn.bufferReplicationMessage(ctx, msg, r)
+}
+
 // SetRowsAffected is part of the sql.RestrictedCommandResult interface.
 func (r *commandResult) SetRowsAffected(ctx context.Context, n int) {
 	r.assertNotReleased()
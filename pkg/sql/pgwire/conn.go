@@ -28,8 +28,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
+	"github.com/cockroachdb/cockroach/pkg/sql/notify"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgreplparser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -264,6 +266,14 @@ func (c *conn) bufferNotice(ctx context.Context, noticeErr pgnotice.Notice) erro
 	return c.writeErrFields(ctx, noticeErr, &c.writerState.buf)
 }
 
+func (c *conn) bufferNotification(n notify.Notification) error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgNotificationResponse)
+	c.msgBuilder.putInt32(int32(n.SenderPID))
+	c.msgBuilder.writeTerminatedString(n.Channel)
+	c.msgBuilder.writeTerminatedString(n.Payload)
+	return c.msgBuilder.finishMsg(&c.writerState.buf)
+}
+
 func (c *conn) sendInitialConnData(
 	ctx context.Context,
 	sqlServer *sql.Server,
@@ -354,8 +364,32 @@ func (c *conn) handleSimpleQuery(
 			log.SqlExec.Infof(ctx, "could not parse simple query in replication protocol: %s", query)
 			return c.stmtBuf.Push(ctx, sql.SendError{Err: err})
 		}
-		switch stmt.AST.(type) {
-		case *pgrepltree.IdentifySystem:
+		switch ast := stmt.AST.(type) {
+		case *pgrepltree.IdentifySystem, *pgrepltree.CreateReplicationSlot,
+			*pgrepltree.DropReplicationSlot:
+		case *pgrepltree.StartReplication:
+			// START_REPLICATION is special, like COPY FROM: the client sends status
+			// updates while changes are streamed to it, so control of the
+			// connection is handed to the executor, and this network routine is
+			// blocked until control is passed back.
+			var wg sync.WaitGroup
+			var once sync.Once
+			wg.Add(1)
+			cmd := sql.StartReplication{
+				Conn:         c,
+				ParsedStmt:   stmt,
+				Stmt:         ast,
+				TimeReceived: timeReceived,
+				ParseStart:   startParse,
+				ParseEnd:     timeutil.Now(),
+			}
+			cmd.ReplicationDone.WaitGroup = &wg
+			cmd.ReplicationDone.Once = &once
+			if err := c.stmtBuf.Push(ctx, cmd); err != nil {
+				return err
+			}
+			wg.Wait()
+			return nil
 		default:
 			log.SqlExec.Infof(ctx, "unhandled replication protocol query: %s", query)
 			return c.stmtBuf.Push(ctx, sql.SendError{
@@ -862,7 +896,7 @@ func cookTag(
 			tag = strconv.AppendUint(tag, uint64(rowsAffected), 10)
 		}
 
-	case tree.Ack, tree.DDL:
+	case tree.Ack, tree.DDL, tree.Replication:
 		if tagStr == "SELECT" {
 			tag = append(tag, ' ')
 			tag = strconv.AppendInt(tag, int64(rowsAffected), 10)
@@ -1189,6 +1223,64 @@ func (c *conn) bufferCopyDone() error {
 	return c.msgBuilder.finishMsg(&c.writerState.buf)
 }
 
+func (c *conn) bufferCopyBothResponse() error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyBothResponse)
+	// The stream of replication messages has no columns.
+	c.msgBuilder.writeByte(byte(pgwirebase.FormatText))
+	c.msgBuilder.putInt16(0)
+	return c.msgBuilder.finishMsg(&c.writerState.buf)
+}
+
+// replicationMessageWriter implements the pgoutput.Writer interface, encoding
+// replication messages into the message being built.
+type replicationMessageWriter struct {
+	ctx context.Context
+	b   *writeBuffer
+}
+
+var _ pgoutput.Writer = replicationMessageWriter{}
+
+// PutByte is part of the pgoutput.Writer interface.
+func (w replicationMessageWriter) PutByte(b byte) { w.b.writeByte(b) }
+
+// PutInt16 is part of the pgoutput.Writer interface.
+func (w replicationMessageWriter) PutInt16(v int16) { w.b.putInt16(v) }
+
+// PutInt32 is part of the pgoutput.Writer interface.
+func (w replicationMessageWriter) PutInt32(v int32) { w.b.putInt32(v) }
+
+// PutInt64 is part of the pgoutput.Writer interface.
+func (w replicationMessageWriter) PutInt64(v int64) { w.b.putInt64(v) }
+
+// PutString is part of the pgoutput.Writer interface.
+func (w replicationMessageWriter) PutString(s string) { w.b.writeTerminatedString(s) }
+
+// PutTextDatum is part of the pgoutput.Writer interface.
+func (w replicationMessageWriter) PutTextDatum(d tree.Datum, t *types.T) {
+	// Like the output functions used by pgoutput in Postgres, values are
+	// encoded with the default conversion settings. Timestamps are in UTC.
+	var conv sessiondatapb.DataConversionConfig
+	w.b.writeTextDatum(w.ctx, d, conv, time.UTC, t)
+}
+
+// bufferReplicationMessage encodes a replication message in a CopyData
+// message and flushes it to the client, since the client needs to receive
+// replication messages as they are produced.
+func (c *conn) bufferReplicationMessage(
+	ctx context.Context, msg pgoutput.Message, res *commandResult,
+) error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyDataCommand)
+	msg.Encode(replicationMessageWriter{ctx: ctx, b: &c.msgBuilder})
+	if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+		return err
+	}
+	if err := c.Flush(res.pos); err != nil {
+		return err
+	}
+	c.maybeReallocate()
+	return nil
+}
+
 // writeRowDescription writes a row description to the given writer.
 //
 // formatCodes specifies the format for each column. It can be nil, in which
@@ -1366,6 +1458,11 @@ func (c *conn) CreateDrainResult(pos sql.CmdPos) sql.DrainResult {
 	return c.newMiscResult(pos, noCompletionMsg)
 }
 
+// CreateNotificationResult is part of the sql.ClientComm interface.
+func (c *conn) CreateNotificationResult(pos sql.CmdPos) sql.NotificationResult {
+	return c.newMiscResult(pos, notification)
+}
+
 // CreateBindResult is part of the sql.ClientComm interface.
 func (c *conn) CreateBindResult(pos sql.CmdPos) sql.BindResult {
 	return c.newMiscResult(pos, bindComplete)
@@ -1414,6 +1511,16 @@ func (c *conn) CreateCopyOutResult(cmd sql.CopyOut, pos sql.CmdPos) sql.CopyOutR
 	return res
 }
 
+// CreateStartReplicationResult is part of the sql.ClientComm interface.
+func (c *conn) CreateStartReplicationResult(
+	cmd sql.StartReplication, pos sql.CmdPos,
+) sql.StartReplicationResult {
+	res := c.newMiscResult(pos, commandComplete)
+	res.stmtType = cmd.Stmt.StatementReturnType()
+	res.cmdCompleteTag = cmd.Stmt.StatementTag()
+	return res
+}
+
 // pgwireReader is an io.Reader that wraps a conn, maintaining its metrics as
 // it is consumed.
 type pgwireReader struct {
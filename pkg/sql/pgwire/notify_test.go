@@ -0,0 +1,155 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgwire_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/jackc/pgconn"
+	pgx "github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// connectForNotifications opens a pgx connection to the given server, in the
+// given database.
+func connectForNotifications(
+	ctx context.Context, t *testing.T, s serverutils.ApplicationLayerInterface, dbName string,
+) *pgx.Conn {
+	pgURL, cleanup := s.PGUrl(t, serverutils.DBName(dbName))
+	defer cleanup()
+	conn, err := pgx.Connect(ctx, pgURL.String())
+	require.NoError(t, err)
+	return conn
+}
+
+// waitForNotification waits for the next NotificationResponse received by the
+// given connection.
+func waitForNotification(ctx context.Context, t *testing.T, conn *pgx.Conn) *pgconn.Notification {
+	ctx, cancel := context.WithTimeout(ctx, 45*time.Second)
+	defer cancel()
+	n, err := conn.WaitForNotification(ctx)
+	require.NoError(t, err)
+	return n
+}
+
+// TestNotificationResponse checks that the notifications sent with NOTIFY and
+// pg_notify are received over pgwire as NotificationResponse messages by the
+// sessions which listen on their channel, in the same database.
+func TestNotificationResponse(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	srv, db, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer srv.Stopper().Stop(ctx)
+	s := srv.ApplicationLayer()
+
+	_, err := db.Exec("CREATE DATABASE other")
+	require.NoError(t, err)
+
+	listener := connectForNotifications(ctx, t, s, "defaultdb")
+	defer func() { _ = listener.Close(ctx) }()
+	sender := connectForNotifications(ctx, t, s, "defaultdb")
+	defer func() { _ = sender.Close(ctx) }()
+	otherSender := connectForNotifications(ctx, t, s, "other")
+	defer func() { _ = otherSender.Close(ctx) }()
+
+	var senderPID uint32
+	require.NoError(t, sender.QueryRow(ctx, "SELECT pg_backend_pid()").Scan(&senderPID))
+
+	_, err = listener.Exec(ctx, "LISTEN foo")
+	require.NoError(t, err)
+
+	// Notifications sent by rolled back transactions and notifications sent on
+	// the channel of the same name in another database are not delivered. The
+	// notifications sent from a node are delivered in order, so they would be
+	// received before the one that follows.
+	_, err = sender.Exec(ctx, "BEGIN; NOTIFY foo, 'rolled back'; ROLLBACK")
+	require.NoError(t, err)
+	_, err = otherSender.Exec(ctx, "NOTIFY foo, 'other database'")
+	require.NoError(t, err)
+	_, err = sender.Exec(ctx, "NOTIFY bar, 'other channel'")
+	require.NoError(t, err)
+
+	_, err = sender.Exec(ctx, "NOTIFY foo, 'hello'")
+	require.NoError(t, err)
+	n := waitForNotification(ctx, t, listener)
+	require.Equal(t, "foo", n.Channel)
+	require.Equal(t, "hello", n.Payload)
+	require.Equal(t, senderPID, n.PID)
+
+	_, err = sender.Exec(ctx, "SELECT pg_notify('foo', 'world')")
+	require.NoError(t, err)
+	n = waitForNotification(ctx, t, listener)
+	require.Equal(t, "foo", n.Channel)
+	require.Equal(t, "world", n.Payload)
+
+	// Identical notifications sent in the same transaction are collapsed,
+	// unless the first one was rolled back to a savepoint.
+	_, err = sender.Exec(ctx, `BEGIN;
+NOTIFY foo, 'twice';
+NOTIFY foo, 'twice';
+SAVEPOINT s;
+NOTIFY foo, 'rolled back to savepoint';
+ROLLBACK TO SAVEPOINT s;
+NOTIFY foo, 'rolled back to savepoint';
+COMMIT`)
+	require.NoError(t, err)
+	_, err = sender.Exec(ctx, "NOTIFY foo, 'done'")
+	require.NoError(t, err)
+	for _, payload := range []string{"twice", "rolled back to savepoint", "done"} {
+		n = waitForNotification(ctx, t, listener)
+		require.Equal(t, payload, n.Payload)
+	}
+}
+
+// TestNotificationResponseMultiNode checks that notifications are delivered to
+// the sessions which listen on their channel on other nodes.
+func TestNotificationResponseMultiNode(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	tc := serverutils.StartCluster(t, 3, base.TestClusterArgs{})
+	defer tc.Stopper().Stop(ctx)
+
+	listeners := make([]*pgx.Conn, tc.NumServers())
+	for i := range listeners {
+		listeners[i] = connectForNotifications(ctx, t, tc.Server(i).ApplicationLayer(), "defaultdb")
+		defer func(conn *pgx.Conn) { _ = conn.Close(ctx) }(listeners[i])
+		_, err := listeners[i].Exec(ctx, "LISTEN foo")
+		require.NoError(t, err)
+	}
+
+	// Send a notification from each node, and check that every listener,
+	// including the one on the sending node, receives them.
+	for i := 0; i < tc.NumServers(); i++ {
+		sender := connectForNotifications(ctx, t, tc.Server(i).ApplicationLayer(), "defaultdb")
+		var senderPID uint32
+		require.NoError(t, sender.QueryRow(ctx, "SELECT pg_backend_pid()").Scan(&senderPID))
+		_, err := sender.Exec(ctx, "NOTIFY foo, 'hello'")
+		require.NoError(t, err)
+		require.NoError(t, sender.Close(ctx))
+
+		for j, listener := range listeners {
+			n := waitForNotification(ctx, t, listener)
+			require.Equal(t, "foo", n.Channel, "listener on node %d", j)
+			require.Equal(t, "hello", n.Payload, "listener on node %d", j)
+			require.Equal(t, senderPID, n.PID, "listener on node %d", j)
+		}
+	}
+}
@@ -454,6 +454,11 @@ func DecodeDatum(
 				return nil, err
 			}
 			return tree.ParseDJSON(bs)
+		case oidext.T_jsonpath:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDJSONPath(bs)
 		case oid.T_tsquery:
 			ret, err := tsearch.ParseTSQuery(bs)
 			if err != nil {
@@ -475,6 +480,24 @@ func DecodeDatum(
 				return nil, err
 			}
 			return tree.NewDString(bs), nil
+		case types.RangeFamily:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			d, _, err := tree.ParseDRangeFromString(evalCtx, bs, typ)
+			if err != nil {
+				return nil, err
+			}
+			return d, nil
+		case types.MultirangeFamily:
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			d, _, err := tree.ParseDMultirangeFromString(evalCtx, bs, typ)
+			if err != nil {
+				return nil, err
+			}
+			return d, nil
 		}
 	case FormatBinary:
 		switch id {
@@ -720,6 +743,19 @@ func DecodeDatum(
 				return nil, err
 			}
 			return tree.ParseDJSON(encoding.UnsafeConvertBytesToString(b))
+		case oidext.T_jsonpath:
+			if len(b) < 1 {
+				return nil, NewProtocolViolationErrorf("no data to decode")
+			}
+			if b[0] != 1 {
+				return nil, NewProtocolViolationErrorf("expected jsonpath version 1")
+			}
+			// Skip over the version number.
+			b = b[1:]
+			if err := validateStringBytes(b); err != nil {
+				return nil, err
+			}
+			return tree.ParseDJSONPath(string(b))
 		case oid.T_varbit, oid.T_bit:
 			if len(b) < 4 {
 				return nil, NewProtocolViolationErrorf("insufficient data: %d", len(b))
@@ -786,6 +822,16 @@ func DecodeDatum(
 			if typ.Family() == types.TupleFamily {
 				return decodeBinaryTuple(ctx, evalCtx, b)
 			}
+			if typ.Family() == types.RangeFamily {
+				d, err := decodeBinaryRange(ctx, evalCtx, typ, b)
+				if err != nil {
+					return nil, err
+				}
+				return d, nil
+			}
+			if typ.Family() == types.MultirangeFamily {
+				return decodeBinaryMultirange(ctx, evalCtx, typ, b)
+			}
 			if typ.Family() == types.OidFamily {
 				if len(b) < 4 {
 					return nil, pgerror.Newf(pgcode.ProtocolViolation, "oid requires 4 bytes for binary format")
@@ -1019,6 +1065,83 @@ func decodeBinaryArray(
 	return arr, nil
 }
 
+// decodeBinaryRange decodes the binary format of a range of type t, which
+// consists of a byte with the flags of the range followed by the
+// length-prefixed binary encodings of its finite bounds.
+func decodeBinaryRange(
+	ctx context.Context, evalCtx *eval.Context, t *types.T, b []byte,
+) (*tree.DRange, error) {
+	if len(b) < 1 {
+		return nil, NewProtocolViolationErrorf("no data to decode")
+	}
+	flags := tree.RangeFlags(b[0])
+	if flags&tree.RangeEmpty != 0 {
+		return tree.NewDEmptyRange(t), nil
+	}
+	r := bytes.NewBuffer(b[1:])
+	decodeBound := func(inf tree.RangeFlags) (tree.Datum, error) {
+		if flags&inf != 0 {
+			return nil, nil
+		}
+		var vlen int32
+		if err := binary.Read(r, binary.BigEndian, &vlen); err != nil {
+			return nil, err
+		}
+		if vlen < 0 || int(vlen) > r.Len() {
+			return nil, NewInvalidBinaryRepresentationErrorf("invalid range bound length %d", vlen)
+		}
+		return DecodeDatum(ctx, evalCtx, t.RangeSubtype(), FormatBinary, r.Next(int(vlen)))
+	}
+	lower, err := decodeBound(tree.RangeLowerInf)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := decodeBound(tree.RangeUpperInf)
+	if err != nil {
+		return nil, err
+	}
+	return tree.NewDRange(
+		evalCtx, t, lower, upper, flags&tree.RangeLowerInc != 0, flags&tree.RangeUpperInc != 0,
+	)
+}
+
+// decodeBinaryMultirange decodes the binary format of a multirange of type t,
+// which consists of the number of ranges followed by the length-prefixed
+// binary encoding of each range.
+func decodeBinaryMultirange(
+	ctx context.Context, evalCtx *eval.Context, t *types.T, b []byte,
+) (tree.Datum, error) {
+	r := bytes.NewBuffer(b)
+	var n int32
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		return nil, NewInvalidBinaryRepresentationErrorf("invalid multirange length %d", n)
+	}
+	rangeTyp := types.RangeOfMultirange(t)
+	var ranges []*tree.DRange
+	for i := int32(0); i < n; i++ {
+		var vlen int32
+		if err := binary.Read(r, binary.BigEndian, &vlen); err != nil {
+			return nil, err
+		}
+		if vlen < 0 || int(vlen) > r.Len() {
+			return nil, NewInvalidBinaryRepresentationErrorf("invalid range length %d", vlen)
+		}
+		rng, err := decodeBinaryRange(ctx, evalCtx, rangeTyp, r.Next(int(vlen)))
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, rng)
+	}
+	mr, err := tree.NewDMultirange(evalCtx, t, ranges)
+	if err != nil {
+		return nil, err
+	}
+	return mr, nil
+}
+
 const tupleHeaderSize, oidSize, elementSize = 4, 4, 4
 
 func decodeBinaryTuple(ctx context.Context, evalCtx *eval.Context, b []byte) (tree.Datum, error) {
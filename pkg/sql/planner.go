@@ -117,6 +117,14 @@ type extendedEvalContext struct {
 
 	// validateDbZoneConfig should the DB zone config on commit.
 	validateDbZoneConfig *bool
+
+	// deferredConstraints refers to the deferred constraint checks in
+	// extraTxnState. It is nil if the connExecutor runs in an outer txn.
+	deferredConstraints *deferredConstraintState
+
+	// notifications refers to the notification state in extraTxnState. It is
+	// nil if the connExecutor runs in an outer txn.
+	notifications *notificationState
 }
 
 // copyFromExecCfg copies relevant fields from an ExecutorConfig.
@@ -242,6 +250,10 @@ type planner struct {
 
 	storedProcTxnState storedProcTxnStateAccessor
 
+	// routineSessionOverrides is the number of routines declared with SECURITY
+	// DEFINER or SET clauses which are currently executing.
+	routineSessionOverrides int
+
 	createdSequences createdSequences
 
 	// autoCommit indicates whether the plan is allowed (but not required) to
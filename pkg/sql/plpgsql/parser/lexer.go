@@ -374,6 +374,87 @@ func (l *lexer) MakeFetchOrMoveStmt(isMove bool) (plpgsqltree.Statement, error)
 	}, nil
 }
 
+// ReadForLoopControl reads the control section of a FOR loop, which extends
+// from the IN keyword up to (but not including) the LOOP keyword. The loop
+// iterates over a range of integers if the control begins with REVERSE or
+// contains a top-level ".." token; otherwise, it iterates over the rows of a
+// query. A nil control is returned without an error if the loop iterates over
+// a cursor, which is not yet supported.
+func (l *lexer) ReadForLoopControl() (plpgsqltree.ForLoopControl, error) {
+	startPos, endPos, _, err := l.readSQLConstruct(true /* isExpr */, false /* allowEmpty */, LOOP)
+	if err != nil {
+		return nil, err
+	}
+	var reverse bool
+	if l.tokens[startPos].id == REVERSE {
+		reverse = true
+		startPos++
+	}
+	dotDotPos, byPos := -1, -1
+	parenLevel := 0
+	for pos := startPos; pos < endPos; pos++ {
+		switch l.tokens[pos].id {
+		case '(', '[':
+			parenLevel++
+		case ')', ']':
+			parenLevel--
+		case DOT_DOT:
+			if parenLevel == 0 && dotDotPos == -1 {
+				dotDotPos = pos
+			}
+		case BY:
+			if parenLevel == 0 && dotDotPos != -1 && byPos == -1 {
+				byPos = pos
+			}
+		}
+	}
+	if dotDotPos == -1 {
+		if reverse {
+			return nil, errors.New("missing \"..\" at end of SQL expression")
+		}
+		sqlStmt, err := parser.ParseOne(l.getStr(startPos, endPos))
+		if err != nil {
+			// A single identifier, optionally followed by a parenthesized argument
+			// list, refers to a cursor.
+			if l.tokens[startPos].id == IDENT &&
+				(endPos-startPos == 1 || l.tokens[startPos+1].id == '(') {
+				return nil, nil
+			}
+			return nil, err
+		}
+		if sqlStmt.AST.StatementReturnType() != tree.Rows {
+			return nil, errors.New("FOR loop query must return rows")
+		}
+		return &plpgsqltree.QueryForLoopControl{Query: sqlStmt.AST}, nil
+	}
+	upperEndPos := endPos
+	if byPos != -1 {
+		upperEndPos = byPos
+	}
+	ctrl := &plpgsqltree.IntForLoopControl{Reverse: reverse}
+	if ctrl.Lower, err = l.parseForLoopBound(startPos, dotDotPos); err != nil {
+		return nil, err
+	}
+	if ctrl.Upper, err = l.parseForLoopBound(dotDotPos+1, upperEndPos); err != nil {
+		return nil, err
+	}
+	if byPos != -1 {
+		if ctrl.Step, err = l.parseForLoopBound(byPos+1, endPos); err != nil {
+			return nil, err
+		}
+	}
+	return ctrl, nil
+}
+
+// parseForLoopBound parses the expression between the given token positions
+// for the lower bound, upper bound, or step of an integer FOR loop.
+func (l *lexer) parseForLoopBound(startPos, endPos int) (plpgsqltree.Expr, error) {
+	if endPos <= startPos {
+		return nil, errors.New("missing expression")
+	}
+	return l.ParseExpr(l.getStr(startPos, endPos))
+}
+
 func (l *lexer) ReadSqlExpr(
 	terminator1 int, terminators ...int,
 ) (sqlStr string, terminatorMet int, err error) {
@@ -119,6 +119,14 @@ func (u *plpgsqlSymUnion) elseIf() []plpgsqltree.ElseIf {
     return u.val.([]plpgsqltree.ElseIf)
 }
 
+func (u *plpgsqlSymUnion) forLoop() *plpgsqltree.ForLoop {
+    return u.val.(*plpgsqltree.ForLoop)
+}
+
+func (u *plpgsqlSymUnion) variables() []plpgsqltree.Variable {
+    return u.val.([]plpgsqltree.Variable)
+}
+
 func (u *plpgsqlSymUnion) open() *plpgsqltree.Open {
     return u.val.(*plpgsqltree.Open)
 }
@@ -321,11 +329,11 @@ func (u *plpgsqlSymUnion) sqlStatement() tree.Statement {
 %type <str>	expr_until_then expr_until_loop opt_expr_until_when
 %type <plpgsqltree.Expr>	opt_exitcond
 
-%type <forvariable>	for_variable
-%type <*tree.NumVal>	foreach_slice
-%type <plpgsqltree.Statement>	for_control
+%type <[]plpgsqltree.Variable>	for_variable
+%type <int32>	foreach_slice
+%type <*plpgsqltree.ForLoop>	for_control
 
-%type <str> any_identifier opt_block_label opt_loop_label opt_label query_options
+%type <str> any_identifier opt_block_label opt_loop_label opt_label
 %type <str> opt_error_level option_type
 
 %type <[]plpgsqltree.Statement> proc_sect
@@ -665,9 +673,13 @@ proc_stmt:pl_block ';'
     $$.val = $1.statement()
   }
 | stmt_for
-  { }
+  {
+    $$.val = $1.statement()
+  }
 | stmt_foreach_a
-  { }
+  {
+    $$.val = $1.statement()
+  }
 | stmt_exit
   {
     $$.val = $1.statement()
@@ -697,7 +709,9 @@ proc_stmt:pl_block ';'
     $$.val = $1.statement()
   }
 | stmt_perform
-  { }
+  {
+    $$.val = $1.statement()
+  }
 | stmt_call
   {
     $$.val = $1.statement()
@@ -740,7 +754,12 @@ proc_stmt:pl_block ';'
 
 stmt_perform: PERFORM stmt_until_semi ';'
   {
-    return unimplemented(plpgsqllex, "perform")
+    // PERFORM replaces the SELECT keyword of the query it executes.
+    sqlStmt, err := parser.ParseOne("SELECT " + $2)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.Perform{SqlStmt: sqlStmt.AST}
   }
 ;
 
@@ -762,9 +781,14 @@ stmt_call: CALL expr_until_semi ';'
   }
 ;
 
-stmt_do: DO expr_until_semi ';'
+stmt_do: DO stmt_until_semi ';'
   {
-    return unimplemented(plpgsqllex, "do")
+    // A nested DO block is executed as a SQL statement.
+    sqlStmt, err := parser.ParseOne("DO " + $2)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.Execute{SqlStmt: sqlStmt.AST}
   }
 ;
 
@@ -779,6 +803,18 @@ stmt_assign: IDENT assign_operator expr_until_semi ';'
       Value: expr,
     }
   }
+| IDENT '.' IDENT assign_operator expr_until_semi ';'
+  {
+    expr, err := plpgsqllex.(*lexer).ParseExpr($5)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.Assignment{
+      Var: plpgsqltree.Variable($1),
+      Indirection: tree.Name($3),
+      Value: expr,
+    }
+  }
 ;
 
 stmt_getdiag: GET getdiag_area_opt DIAGNOSTICS getdiag_list ';'
@@ -1001,54 +1037,93 @@ stmt_while: opt_loop_label WHILE expr_until_loop LOOP loop_body opt_label ';'
   }
 ;
 
-stmt_for: opt_loop_label FOR for_control loop_body
+stmt_for: opt_loop_label FOR for_control LOOP loop_body opt_label ';'
   {
-    return unimplemented(plpgsqllex, "for loop")
+    loopLabel, loopEndLabel := $1, $6
+    if err := checkLoopLabels(loopLabel, loopEndLabel); err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    forLoop := $3.forLoop()
+    forLoop.Label = $1
+    forLoop.Body = $5.statements()
+    $$.val = forLoop
   }
 ;
 
-for_control: for_variable IN
-  // TODO(drewk) need to parse the sql expression here.
+for_control: for_variable IN EXECUTE
   {
-    return unimplemented(plpgsqllex, "for loop")
+    return unimplemented(plpgsqllex, "for loop over dynamic query")
+  }
+| for_variable IN
+  {
+    ctrl, err := plpgsqllex.(*lexer).ReadForLoopControl()
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    if ctrl == nil {
+      return unimplemented(plpgsqllex, "cursor for loop")
+    }
+    target := $1.variables()
+    if _, ok := ctrl.(*plpgsqltree.IntForLoopControl); ok && len(target) != 1 {
+      return setErr(plpgsqllex, errors.New("integer FOR loop must have only one target variable"))
+    }
+    $$.val = &plpgsqltree.ForLoop{Target: target, Control: ctrl}
   }
 ;
 
 /*
  * Processing the for_variable is tricky because we don't yet know if the
- * FOR is an integer FOR loop or a loop over query results.  In the former
+ * FOR is an integer FOR loop or a loop over query results. In the former
  * case, the variable is just a name that we must instantiate as a loop
- * local variable, regardless of any other definition it might have.
- * Therefore, we always save the actual identifier into $$.name where it
- * can be used for that case.  We also save the outer-variable definition,
- * if any, because that's what we need for the loop-over-query case.  Note
- * that we must NOT apply check_assignable() or any other semantic check
- * until we know what's what.
- *
- * However, if we see a comma-separated list of names, we know that it
- * can't be an integer FOR loop and so it's OK to check the variables
- * immediately.  In particular, for T_WORD followed by comma, we should
- * complain that the name is not known rather than say it's a syntax error.
- * Note that the non-error result of this case sets *both* $$.scalar and
- * $$.row; see the for_control production.
+ * local variable, regardless of any other definition it might have. In the
+ * latter case, the names refer to existing variables which are assigned the
+ * columns of each row. A comma-separated list of names can only be used in
+ * the latter case.
  */
 for_variable: any_identifier
   {
-    return unimplemented(plpgsqllex, "for loop")
+    $$.val = []plpgsqltree.Variable{plpgsqltree.Variable($1)}
+  }
+| for_variable ',' any_identifier
+  {
+    $$.val = append($1.variables(), plpgsqltree.Variable($3))
   }
 ;
 
-stmt_foreach_a: opt_loop_label FOREACH for_variable foreach_slice IN ARRAY expr_until_loop loop_body
+stmt_foreach_a: opt_loop_label FOREACH any_identifier foreach_slice IN ARRAY expr_until_loop LOOP loop_body opt_label ';'
   {
-    return unimplemented(plpgsqllex, "for each loop")
+    loopLabel, loopEndLabel := $1, $10
+    if err := checkLoopLabels(loopLabel, loopEndLabel); err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    expr, err := plpgsqllex.(*lexer).ParseExpr($7)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.ForEachArray{
+      Label: $1,
+      Var: plpgsqltree.Variable($3),
+      Slice: int($4.int32()),
+      Expr: expr,
+      Body: $9.statements(),
+    }
   }
 ;
 
 foreach_slice:
   {
+    $$.val = int32(0)
   }
 | SLICE ICONST
   {
+    slice, err := $2.numVal().AsInt32()
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    if slice < 0 {
+      return setErr(plpgsqllex, errors.New("SLICE must be a non-negative integer"))
+    }
+    $$.val = slice
   }
 ;
 
@@ -1082,39 +1157,42 @@ stmt_return: RETURN return_expr ';'
     }
     $$.val = &plpgsqltree.Return{Expr: expr}
   }
-| RETURN_NEXT NEXT
+| RETURN_NEXT NEXT return_expr ';'
   {
-    return unimplemented(plpgsqllex, "return next")
+    var expr plpgsqltree.Expr
+    if $3 != "" {
+      var err error
+      expr, err = plpgsqllex.(*lexer).ParseExpr($3)
+      if err != nil {
+        return setErr(plpgsqllex, err)
+      }
+    }
+    $$.val = &plpgsqltree.ReturnNext{Expr: expr}
   }
-| RETURN_QUERY QUERY
- {
-   return unimplemented (plpgsqllex, "return query")
- }
-;
-
-return_expr:
+| RETURN_QUERY QUERY EXECUTE
   {
-    sqlStr, err := plpgsqllex.(*lexer).ReadReturnExpr()
+    return unimplemented(plpgsqllex, "return dynamic sql query")
+  }
+| RETURN_QUERY QUERY stmt_until_semi ';'
+  {
+    sqlStmt, err := parser.ParseOne($3)
     if err != nil {
       return setErr(plpgsqllex, err)
     }
-    $$ = sqlStr
+    if sqlStmt.AST.StatementReturnType() != tree.Rows {
+      return setErr(plpgsqllex, errors.New("RETURN QUERY query must return rows"))
+    }
+    $$.val = &plpgsqltree.ReturnQuery{SqlStmt: sqlStmt.AST}
   }
 ;
 
-query_options:
+return_expr:
   {
-    _, terminator, err := plpgsqllex.(*lexer).ReadSqlExpr(EXECUTE, ';')
-    if err != nil {
-      return setErr(plpgsqllex, err)
-    }
-    if terminator == EXECUTE {
-      return unimplemented (plpgsqllex, "return dynamic sql query")
-    }
-    _, _, err = plpgsqllex.(*lexer).ReadSqlExpr(';')
+    sqlStr, err := plpgsqllex.(*lexer).ReadReturnExpr()
     if err != nil {
       return setErr(plpgsqllex, err)
     }
+    $$ = sqlStr
   }
 ;
 
@@ -0,0 +1,310 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/errors"
+)
+
+type createPublicationNode struct {
+	n      *tree.CreatePublication
+	dbDesc *dbdesc.Mutable
+}
+
+// CreatePublication creates a publication in the current database.
+// Privileges: CREATE on the database, ownership of the tables of the
+// publication, or admin for FOR ALL TABLES.
+func (p *planner) CreatePublication(
+	ctx context.Context, n *tree.CreatePublication,
+) (planNode, error) {
+	dbDesc, err := p.publicationDatabase(ctx, "CREATE PUBLICATION")
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	if n.AllTables {
+		hasAdmin, err := p.HasAdminRole(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !hasAdmin {
+			return nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+				"must be admin to create FOR ALL TABLES publication")
+		}
+	}
+	return &createPublicationNode{n: n, dbDesc: dbDesc}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because CREATE PUBLICATION performs multiple KV operations on
+// descriptors and expects to see its own writes.
+func (n *createPublicationNode) ReadingOwnWrites() {}
+
+func (n *createPublicationNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("publication"))
+
+	name := string(n.n.Name)
+	if n.dbDesc.GetPublication(name) != nil {
+		return pgerror.Newf(pgcode.DuplicateObject, "publication %q already exists", name)
+	}
+	pub := descpb.DatabaseDescriptor_Publication{
+		Name:            name,
+		OwnerProto:      params.p.User().EncodeProto(),
+		AllTables:       n.n.AllTables,
+		PublishInsert:   true,
+		PublishUpdate:   true,
+		PublishDelete:   true,
+		PublishTruncate: true,
+	}
+	if err := params.p.applyPublicationOptions(params.ctx, &pub, n.n.Options); err != nil {
+		return err
+	}
+
+	for i := range n.n.Tables {
+		tn := &n.n.Tables[i]
+		tbl, err := params.p.ResolveUncachedTableDescriptorEx(
+			params.ctx, tn.ToUnresolvedObjectName(), true /* required */, tree.ResolveAnyTableKind,
+		)
+		if err != nil {
+			return err
+		}
+		if !isPublishableTable(tbl) || tbl.GetParentID() != n.dbDesc.GetID() {
+			return pgerror.Newf(pgcode.WrongObjectType,
+				"cannot add relation %q to publication", tbl.GetName())
+		}
+		hasOwnership, err := params.p.HasOwnership(params.ctx, tbl)
+		if err != nil {
+			return err
+		}
+		if !hasOwnership {
+			return pgerror.Newf(pgcode.InsufficientPrivilege,
+				"must be owner of table %s", tree.Name(tbl.GetName()))
+		}
+		for _, id := range pub.TableIDs {
+			if id == tbl.GetID() {
+				return pgerror.Newf(pgcode.DuplicateObject,
+					"relation %q is already member of publication %q", tbl.GetName(), name)
+			}
+		}
+		pub.TableIDs = append(pub.TableIDs, tbl.GetID())
+	}
+
+	n.dbDesc.AddPublication(pub)
+	return params.p.writeNonDropDatabaseChange(
+		params.ctx, n.dbDesc, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (*createPublicationNode) Next(runParams) (bool, error) { return false, nil }
+func (*createPublicationNode) Values() tree.Datums          { return tree.Datums{} }
+func (*createPublicationNode) Close(context.Context)        {}
+
+type dropPublicationNode struct {
+	n      *tree.DropPublication
+	dbDesc *dbdesc.Mutable
+}
+
+// DropPublication drops publications of the current database.
+// Privileges: ownership of the publications.
+func (p *planner) DropPublication(ctx context.Context, n *tree.DropPublication) (planNode, error) {
+	if n.DropBehavior == tree.DropCascade {
+		// Nothing depends on a publication, so CASCADE is the same as RESTRICT.
+		p.BufferClientNotice(ctx, pgnotice.Newf("CASCADE has no effect on publications"))
+	}
+	dbDesc, err := p.publicationDatabase(ctx, "DROP PUBLICATION")
+	if err != nil {
+		return nil, err
+	}
+	return &dropPublicationNode{n: n, dbDesc: dbDesc}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because DROP PUBLICATION performs multiple KV operations on
+// descriptors and expects to see its own writes.
+func (n *dropPublicationNode) ReadingOwnWrites() {}
+
+func (n *dropPublicationNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("publication"))
+
+	hasAdmin, err := params.p.HasAdminRole(params.ctx)
+	if err != nil {
+		return err
+	}
+	var dropped bool
+	for _, name := range n.n.Names {
+		pub := n.dbDesc.GetPublication(string(name))
+		if pub == nil {
+			if n.n.IfExists {
+				params.p.BufferClientNotice(params.ctx,
+					pgnotice.Newf("publication %q does not exist, skipping", string(name)))
+				continue
+			}
+			return pgerror.Newf(pgcode.UndefinedObject, "publication %q does not exist", string(name))
+		}
+		if !hasAdmin {
+			isOwner, err := params.p.isPublicationOwner(params.ctx, pub)
+			if err != nil {
+				return err
+			}
+			if !isOwner {
+				return pgerror.Newf(pgcode.InsufficientPrivilege,
+					"must be owner of publication %s", tree.Name(name))
+			}
+		}
+		n.dbDesc.RemovePublication(string(name))
+		dropped = true
+	}
+	if !dropped {
+		return nil
+	}
+	return params.p.writeNonDropDatabaseChange(
+		params.ctx, n.dbDesc, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (*dropPublicationNode) Next(runParams) (bool, error) { return false, nil }
+func (*dropPublicationNode) Values() tree.Datums          { return tree.Datums{} }
+func (*dropPublicationNode) Close(context.Context)        {}
+
+// publicationDatabase returns the current database, in which publications are
+// created and dropped, for modification.
+func (p *planner) publicationDatabase(ctx context.Context, op string) (*dbdesc.Mutable, error) {
+	if err := checkSchemaChangeEnabled(ctx, p.ExecCfg(), op); err != nil {
+		return nil, err
+	}
+	if !p.execCfg.Settings.Version.IsActive(ctx, clusterversion.V24_1) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use publications", clusterversion.V24_1)
+	}
+	if p.CurrentDatabase() == "" {
+		return nil, sqlerrors.ErrNoDatabase
+	}
+	return p.Descriptors().MutableByName(p.txn).Database(ctx, p.CurrentDatabase())
+}
+
+// isPublicationOwner returns whether the current user is, or is a member of,
+// the owner of the publication.
+func (p *planner) isPublicationOwner(
+	ctx context.Context, pub *descpb.DatabaseDescriptor_Publication,
+) (bool, error) {
+	owner := pub.OwnerProto.Decode()
+	if owner == p.User() {
+		return true, nil
+	}
+	memberOf, err := p.MemberOfWithAdminOption(ctx, p.User())
+	if err != nil {
+		return false, err
+	}
+	_, ok := memberOf[owner]
+	return ok, nil
+}
+
+// applyPublicationOptions sets the publication parameters given in the WITH
+// clause of CREATE PUBLICATION.
+func (p *planner) applyPublicationOptions(
+	ctx context.Context, pub *descpb.DatabaseDescriptor_Publication, opts tree.KVOptions,
+) error {
+	exprEval := p.ExprEvaluator("CREATE PUBLICATION")
+	for _, o := range opts {
+		if strings.ToLower(string(o.Key)) != "publish" {
+			return pgerror.Newf(pgcode.Syntax, "unrecognized publication parameter: %q", o.Key)
+		}
+		if o.Value == nil {
+			return pgerror.Newf(pgcode.InvalidParameterValue, "publish requires a parameter")
+		}
+		publish, err := exprEval.String(ctx, o.Value)
+		if err != nil {
+			return err
+		}
+		pub.PublishInsert, pub.PublishUpdate, pub.PublishDelete, pub.PublishTruncate =
+			false, false, false, false
+		for _, op := range strings.Split(publish, ",") {
+			switch strings.ToLower(strings.TrimSpace(op)) {
+			case "insert":
+				pub.PublishInsert = true
+			case "update":
+				pub.PublishUpdate = true
+			case "delete":
+				pub.PublishDelete = true
+			case "truncate":
+				pub.PublishTruncate = true
+			case "":
+				if strings.TrimSpace(publish) != "" {
+					return pgerror.Newf(pgcode.InvalidParameterValue,
+						"invalid list syntax for \"publish\" option")
+				}
+			default:
+				return pgerror.Newf(pgcode.InvalidParameterValue,
+					"unrecognized \"publish\" value: %q", strings.TrimSpace(op))
+			}
+		}
+	}
+	return nil
+}
+
+// isPublishableTable returns whether the changes to the table can be
+// published. Only regular tables with rows in KV can be published.
+func isPublishableTable(tbl catalog.TableDescriptor) bool {
+	return tbl.IsTable() && tbl.IsPhysicalTable() && !tbl.IsTemporary()
+}
+
+// publicationTables returns the tables of the publication of db, in ID order.
+// Tables which have been dropped are omitted.
+func publicationTables(
+	ctx context.Context,
+	col *descs.Collection,
+	txn *kv.Txn,
+	db catalog.DatabaseDescriptor,
+	pub *descpb.DatabaseDescriptor_Publication,
+) ([]catalog.TableDescriptor, error) {
+	inDB, err := col.GetAllTablesInDatabase(ctx, txn, db)
+	if err != nil {
+		return nil, err
+	}
+	var ids catalog.DescriptorIDSet
+	for _, id := range pub.TableIDs {
+		ids.Add(id)
+	}
+	var tables []catalog.TableDescriptor
+	if err := inDB.ForEachDescriptor(func(desc catalog.Descriptor) error {
+		tbl, err := catalog.AsTableDescriptor(desc)
+		if err != nil {
+			return err
+		}
+		if !isPublishableTable(tbl) || (!pub.AllTables && !ids.Contains(tbl.GetID())) {
+			return nil
+		}
+		tables = append(tables, tbl)
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "resolving tables of publication %q", pub.Name)
+	}
+	return tables, nil
+}
@@ -23,12 +23,14 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/geo/geogen"
 	"github.com/cockroachdb/cockroach/pkg/geo/geopb"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/bitarray"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil/pgdate"
@@ -294,6 +296,21 @@ func RandDatumWithNullChance(
 		return tree.NewDTSVector(tsearch.RandomTSVector(rng))
 	case types.TSQueryFamily:
 		return tree.NewDTSQuery(tsearch.RandomTSQuery(rng))
+	case types.JSONPathFamily:
+		return tree.NewDJSONPath(jsonpath.RandomPath(rng))
+	case types.RangeFamily:
+		return randRange(rng, typ)
+	case types.MultirangeFamily:
+		rangeTyp := types.RangeOfMultirange(typ)
+		ranges := make([]*tree.DRange, rng.Intn(4))
+		for i := range ranges {
+			ranges[i] = randRange(rng, rangeTyp)
+		}
+		mr, err := tree.NewDMultirange(&eval.Context{}, typ, ranges)
+		if err != nil {
+			panic(err)
+		}
+		return mr
 	default:
 		panic(errors.AssertionFailedf("invalid type %v", typ.DebugString()))
 	}
@@ -306,6 +323,31 @@ func RandArray(rng *rand.Rand, typ *types.T, nullChance int) tree.Datum {
 		false /* favorCommonData */, false /* targetColumnIsUnique */)
 }
 
+// randRange generates a random range of the given range type.
+func randRange(rng *rand.Rand, typ *types.T) *tree.DRange {
+	if rng.Intn(10) == 0 {
+		return tree.NewDEmptyRange(typ)
+	}
+	var lower, upper tree.Datum
+	if rng.Intn(5) != 0 {
+		lower = RandDatum(rng, typ.RangeSubtype(), false /* nullOk */)
+	}
+	if rng.Intn(5) != 0 {
+		upper = RandDatum(rng, typ.RangeSubtype(), false /* nullOk */)
+	}
+	evalCtx := &eval.Context{}
+	if lower != nil && upper != nil && lower.Compare(evalCtx, upper) > 0 {
+		lower, upper = upper, lower
+	}
+	r, err := tree.NewDRange(evalCtx, typ, lower, upper, rng.Intn(2) == 0, rng.Intn(2) == 0)
+	if err != nil {
+		// Canonicalizing a range over a discrete type can overflow its upper
+		// bound, in which case an empty range is used instead.
+		return tree.NewDEmptyRange(typ)
+	}
+	return r
+}
+
 // RandArrayWithCommonDataChance generates a random DArray where the contents
 // have a 1 in `nullChance` chance of being null, plus it favors generation of
 // non-random data if favorCommonData is true. If both favorCommonData and
@@ -486,6 +528,8 @@ func RandDatumSimple(rng *rand.Rand, typ *types.T) tree.Datum {
 		datum = tree.NewDTSQuery(tsearch.RandomTSQuery(rng))
 	case types.TSVectorFamily:
 		datum = tree.NewDTSVector(tsearch.RandomTSVector(rng))
+	case types.JSONPathFamily:
+		datum = tree.NewDJSONPath(*jsonpath.MustParse(fmt.Sprintf("$[%d]", rng.Intn(simpleRange))))
 	}
 	return datum
 }
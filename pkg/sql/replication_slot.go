@@ -0,0 +1,290 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsn"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/lsnutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgoutput"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl/pgrepltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+// maxReplicationSlotNameLen is the maximum length of the name of a
+// replication slot, which is the maximum length of a Postgres identifier.
+const maxReplicationSlotNameLen = 63
+
+// replicationSlot is a row of system.replication_slots.
+type replicationSlot struct {
+	name       string
+	databaseID descpb.ID
+	plugin     string
+	// confirmedFlushLSN and confirmedFlushTS are the position up to which the
+	// client has confirmed receiving the changes of the slot. The stream of the
+	// slot restarts from this position.
+	confirmedFlushLSN lsn.LSN
+	confirmedFlushTS  hlc.Timestamp
+}
+
+type createReplicationSlotNode struct {
+	optColumnsSlot
+	n    *pgrepltree.CreateReplicationSlot
+	row  tree.Datums
+	done bool
+}
+
+// CreateReplicationSlot creates a logical replication slot in the current
+// database, from which changes can be streamed with START_REPLICATION.
+func (p *planner) CreateReplicationSlot(
+	ctx context.Context, n *pgrepltree.CreateReplicationSlot,
+) (planNode, error) {
+	if err := p.checkReplicationSlotsEnabled(ctx); err != nil {
+		return nil, err
+	}
+	if n.Kind != pgrepltree.LogicalReplication {
+		return nil, pgerror.New(pgcode.FeatureNotSupported, "physical replication slots are not supported")
+	}
+	if n.Temporary {
+		return nil, pgerror.New(pgcode.FeatureNotSupported, "temporary replication slots are not supported")
+	}
+	if p.SessionData().ReplicationMode != sessiondatapb.ReplicationMode_REPLICATION_MODE_DATABASE {
+		return nil, pgerror.New(pgcode.ObjectNotInPrerequisiteState,
+			"logical decoding requires a database connection")
+	}
+	if err := checkReplicationSlotName(string(n.Slot)); err != nil {
+		return nil, err
+	}
+	if string(n.Plugin) != pgoutput.PluginName {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"output plugin %q is not supported", string(n.Plugin))
+	}
+	for _, o := range n.Options {
+		val := strings.ToLower(replicationOptionValue(o))
+		switch string(o.Key) {
+		case "snapshot":
+			// Snapshots are not exported, since the changes of a slot can be
+			// streamed from its creation time with AS OF SYSTEM TIME queries.
+			if val != "export" && val != "nothing" {
+				return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+					"snapshot %q is not supported", val)
+			}
+		case "two_phase":
+			if val != "false" && val != "off" && val != "0" {
+				return nil, pgerror.New(pgcode.FeatureNotSupported,
+					"two-phase decoding is not supported")
+			}
+		default:
+			return nil, pgerror.Newf(pgcode.Syntax,
+				"unrecognized option %q for CREATE_REPLICATION_SLOT", string(o.Key))
+		}
+	}
+	return &createReplicationSlotNode{n: n}, nil
+}
+
+func (n *createReplicationSlotNode) startExec(params runParams) error {
+	p := params.p
+	name := string(n.n.Slot)
+	if _, found, err := getReplicationSlot(params.ctx, p.InternalSQLTxn(), name); err != nil {
+		return err
+	} else if found {
+		return pgerror.Newf(pgcode.DuplicateObject, "replication slot %q already exists", name)
+	}
+	db, err := p.Descriptors().ByNameWithLeased(p.Txn()).Get().Database(params.ctx, p.CurrentDatabase())
+	if err != nil {
+		return err
+	}
+
+	// The consistent point of the slot is the timestamp of the transaction
+	// which creates it: every change committed after it is streamed.
+	ts := p.Txn().ReadTimestamp()
+	consistentPoint := lsnutil.HLCToLSN(ts)
+	if _, err := p.InternalSQLTxn().ExecEx(
+		params.ctx,
+		"insert-replication-slot",
+		p.Txn(),
+		sessiondata.NodeUserSessionDataOverride,
+		`INSERT INTO system.replication_slots
+  (slot_name, database_id, plugin, confirmed_flush_lsn, confirmed_flush_ts, owner, owner_id)
+VALUES ($1, $2, $3, $4, $5, $6, (SELECT user_id FROM system.users WHERE username = $6))`,
+		name,
+		int64(db.GetID()),
+		pgoutput.PluginName,
+		int64(consistentPoint),
+		eval.TimestampToDecimalDatum(ts),
+		p.User().Normalized(),
+	); err != nil {
+		return err
+	}
+	n.row = tree.Datums{
+		tree.NewDString(name),
+		tree.NewDString(consistentPoint.String()),
+		tree.DNull, // snapshot_name
+		tree.NewDString(pgoutput.PluginName),
+	}
+	return nil
+}
+
+func (n *createReplicationSlotNode) Next(runParams) (bool, error) {
+	if n.done {
+		return false, nil
+	}
+	n.done = true
+	return true, nil
+}
+
+func (n *createReplicationSlotNode) Values() tree.Datums { return n.row }
+func (*createReplicationSlotNode) Close(context.Context) {}
+
+type dropReplicationSlotNode struct {
+	n *pgrepltree.DropReplicationSlot
+}
+
+// DropReplicationSlot drops a replication slot.
+func (p *planner) DropReplicationSlot(
+	ctx context.Context, n *pgrepltree.DropReplicationSlot,
+) (planNode, error) {
+	if err := p.checkReplicationSlotsEnabled(ctx); err != nil {
+		return nil, err
+	}
+	return &dropReplicationSlotNode{n: n}, nil
+}
+
+func (n *dropReplicationSlotNode) startExec(params runParams) error {
+	name := string(n.n.Slot)
+	// Slots are never active across connections, so WAIT has no effect.
+	deleted, err := params.p.InternalSQLTxn().ExecEx(
+		params.ctx,
+		"delete-replication-slot",
+		params.p.Txn(),
+		sessiondata.NodeUserSessionDataOverride,
+		`DELETE FROM system.replication_slots WHERE slot_name = $1`,
+		name,
+	)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return pgerror.Newf(pgcode.UndefinedObject, "replication slot %q does not exist", name)
+	}
+	return nil
+}
+
+func (*dropReplicationSlotNode) Next(runParams) (bool, error) { return false, nil }
+func (*dropReplicationSlotNode) Values() tree.Datums          { return tree.Datums{} }
+func (*dropReplicationSlotNode) Close(context.Context)        {}
+
+func (p *planner) checkReplicationSlotsEnabled(ctx context.Context) error {
+	if !p.execCfg.Settings.Version.IsActive(ctx, clusterversion.V24_1_AddSystemReplicationSlotsTable) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use replication slots",
+			clusterversion.V24_1_AddSystemReplicationSlotsTable)
+	}
+	return nil
+}
+
+// checkReplicationSlotName returns an error if name is not a valid name for a
+// replication slot. As in Postgres, names are restricted so that they can be
+// used as file names.
+func checkReplicationSlotName(name string) error {
+	if name == "" {
+		return pgerror.Newf(pgcode.InvalidName, "replication slot name %q is too short", name)
+	}
+	if len(name) > maxReplicationSlotNameLen {
+		return pgerror.Newf(pgcode.NameTooLong, "replication slot name %q is too long", name)
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z') && !(c >= '0' && c <= '9') && c != '_' {
+			return errors.WithHint(
+				pgerror.Newf(pgcode.InvalidName,
+					"replication slot name %q contains invalid character", name),
+				"Replication slot names may only contain lower case letters, numbers, and the underscore character.",
+			)
+		}
+	}
+	return nil
+}
+
+// replicationOptionValue returns the value of an option of a replication
+// command, which is empty if the option has no value.
+func replicationOptionValue(o pgrepltree.Option) string {
+	switch v := o.Value.(type) {
+	case nil:
+		return ""
+	case *tree.StrVal:
+		return v.RawString()
+	default:
+		return tree.AsStringWithFlags(v, tree.FmtBareStrings)
+	}
+}
+
+// getReplicationSlot reads the replication slot with the given name.
+func getReplicationSlot(
+	ctx context.Context, txn isql.Txn, name string,
+) (_ replicationSlot, found bool, _ error) {
+	row, err := txn.QueryRowEx(
+		ctx,
+		"get-replication-slot",
+		txn.KV(),
+		sessiondata.NodeUserSessionDataOverride,
+		`SELECT database_id, plugin, confirmed_flush_lsn, confirmed_flush_ts
+FROM system.replication_slots WHERE slot_name = $1`,
+		name,
+	)
+	if err != nil || row == nil {
+		return replicationSlot{}, false, err
+	}
+	ts, err := hlc.DecimalToHLC(&tree.MustBeDDecimal(row[3]).Decimal)
+	if err != nil {
+		return replicationSlot{}, false, err
+	}
+	return replicationSlot{
+		name:              name,
+		databaseID:        descpb.ID(tree.MustBeDInt(row[0])),
+		plugin:            string(tree.MustBeDString(row[1])),
+		confirmedFlushLSN: lsn.LSN(tree.MustBeDInt(row[2])),
+		confirmedFlushTS:  ts,
+	}, true, nil
+}
+
+// confirmReplicationSlot records that the client of the replication slot has
+// received the changes up to the given position.
+func confirmReplicationSlot(
+	ctx context.Context, db isql.DB, name string, confirmedLSN lsn.LSN, ts hlc.Timestamp,
+) error {
+	return db.Txn(ctx, func(ctx context.Context, txn isql.Txn) error {
+		_, err := txn.ExecEx(
+			ctx,
+			"confirm-replication-slot",
+			txn.KV(),
+			sessiondata.NodeUserSessionDataOverride,
+			`UPDATE system.replication_slots
+SET confirmed_flush_lsn = $2, confirmed_flush_ts = $3
+WHERE slot_name = $1 AND confirmed_flush_lsn < $2`,
+			name,
+			int64(confirmedLSN),
+			eval.TimestampToDecimalDatum(ts),
+		)
+		return err
+	})
+}
@@ -254,6 +254,20 @@ func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err
 	}
 	g.rch.Init(ctx, retTypes, g.p.ExtendedEvalContext(), "routine" /* opName */)
 
+	// Apply the session changes of a SECURITY DEFINER routine or a routine with
+	// SET clauses for the duration of its execution.
+	if g.expr.SessionOverride != nil {
+		restore, err := g.p.pushRoutineSessionOverride(ctx, g.expr.SessionOverride)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if restoreErr := restore(); err == nil {
+				err = restoreErr
+			}
+		}()
+	}
+
 	// If this is the start of a PLpgSQL block with an exception handler, create a
 	// savepoint.
 	err = g.maybeInitBlockState(ctx)
@@ -281,6 +295,16 @@ func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err
 	stmtIdx := 0
 	ef := newExecFactory(ctx, g.p)
 	rrw := NewRowResultWriter(&g.rch)
+	if resultBuffer := g.expr.ResultBuffer; resultBuffer != nil {
+		// The RETURN NEXT and RETURN QUERY statements of a set-returning PLpgSQL
+		// routine add rows directly to its result. Restore the previous writer
+		// once execution finishes, since this may be a recursive invocation.
+		prevWriter := resultBuffer.Writer
+		resultBuffer.Writer = newRoutineResultAppender(rrw, g.expr.MultiColOutput, retTypes)
+		defer func() {
+			resultBuffer.Writer = prevWriter
+		}()
+	}
 	var cursorHelper *plpgsqlCursorHelper
 	err = g.expr.ForEachPlan(ctx, ef, g.args, func(plan tree.RoutinePlan, stmtForDistSQLDiagram string, isFinalPlan bool) error {
 		stmtIdx++
@@ -290,9 +314,22 @@ func (g *routineGenerator) startInternal(ctx context.Context, txn *kv.Txn) (err
 
 		var w rowResultWriter
 		openCursor := stmtIdx == 1 && g.expr.CursorDeclaration != nil
-		if isFinalPlan {
+		returnNext := stmtIdx == 1 && g.expr.ReturnNextBuffer != nil
+		if isFinalPlan && g.expr.ResultBuffer != nil {
+			// The output of a set-returning PLpgSQL routine is produced by its
+			// RETURN NEXT and RETURN QUERY statements, so the result of this
+			// statement is not needed.
+			w = &droppingResultWriter{}
+		} else if isFinalPlan {
 			// The result of this statement is the routine's output.
 			w = rrw
+		} else if returnNext {
+			// The result of the first statement is added to the output of the
+			// set-returning routine that is currently executing.
+			if g.expr.ReturnNextBuffer.Writer == nil {
+				return errors.AssertionFailedf("expected an executing set-returning routine")
+			}
+			w = &returnNextResultWriter{w: g.expr.ReturnNextBuffer.Writer}
 		} else if openCursor {
 			// The result of the first statement will be used to open a SQL cursor.
 			cursorHelper, err = g.newCursorHelper(plan.(*planComponents))
@@ -496,8 +533,21 @@ func (g *routineGenerator) CanOptimizeTailCall(nestedRoutine *tree.RoutineExpr)
 	//
 	// Note: cursors are opened after the first body statement, and there is
 	// always more than one body statement if a cursor is opened. This is enforced
-	// during exec-building. For this reason, we only have to check for an
-	// exception handler.
+	// during exec-building. The same is true for RETURN NEXT and RETURN QUERY
+	// statements. For this reason, we only have to check for an exception
+	// handler and a set-returning PLpgSQL routine.
+	if g.expr.SessionOverride != nil {
+		// The session changes of the current routine must remain in effect while
+		// the nested routine executes, so it cannot be deferred until the
+		// current routine finishes.
+		return false
+	}
+	if g.expr.ResultBuffer != nil {
+		// The output of a set-returning PLpgSQL routine is accumulated while its
+		// body statements execute, so execution cannot be deferred to a nested
+		// routine, which would discard the output.
+		return false
+	}
 	if g.expr.BlockState != nil {
 		// If the current routine has an exception handler (which is the case when
 		// BlockState is non-nil), the nested routine must either be part of the
@@ -541,6 +591,79 @@ func (d *droppingResultWriter) Err() error {
 	return d.err
 }
 
+// routineResultAppender adds the rows produced by the RETURN NEXT and RETURN
+// QUERY statements of a set-returning PLpgSQL routine to the routine's result.
+type routineResultAppender struct {
+	rrw *RowResultWriter
+
+	// multiColOutput is true if the routine returns multiple columns. In this
+	// case, each row is produced as a single tuple that must be expanded.
+	multiColOutput bool
+
+	// nullRow is added to the result in place of a NULL tuple when the routine
+	// returns multiple columns.
+	nullRow tree.Datums
+}
+
+var _ tree.RoutineResultWriter = &routineResultAppender{}
+
+func newRoutineResultAppender(
+	rrw *RowResultWriter, multiColOutput bool, retTypes []*types.T,
+) *routineResultAppender {
+	a := &routineResultAppender{rrw: rrw, multiColOutput: multiColOutput}
+	if multiColOutput {
+		a.nullRow = make(tree.Datums, len(retTypes))
+		for i := range a.nullRow {
+			a.nullRow[i] = tree.DNull
+		}
+	}
+	return a
+}
+
+// AddRow is part of the tree.RoutineResultWriter interface.
+func (a *routineResultAppender) AddRow(ctx context.Context, row tree.Datums) error {
+	if !a.multiColOutput {
+		return a.rrw.AddRow(ctx, row)
+	}
+	if len(row) != 1 {
+		return errors.AssertionFailedf("expected a single tuple column, found %d columns", len(row))
+	}
+	if row[0] == tree.DNull {
+		return a.rrw.AddRow(ctx, a.nullRow)
+	}
+	tup, ok := tree.AsDTuple(row[0])
+	if !ok {
+		return errors.AssertionFailedf("expected a tuple, found %T", row[0])
+	}
+	return a.rrw.AddRow(ctx, tup.D)
+}
+
+// returnNextResultWriter adds all rows added to it to the result of a
+// set-returning PLpgSQL routine. It only tracks errors with the SetError and
+// Err functions.
+type returnNextResultWriter struct {
+	w   tree.RoutineResultWriter
+	err error
+}
+
+// AddRow is part of the rowResultWriter interface.
+func (r *returnNextResultWriter) AddRow(ctx context.Context, row tree.Datums) error {
+	return r.w.AddRow(ctx, row)
+}
+
+// SetRowsAffected is part of the rowResultWriter interface.
+func (r *returnNextResultWriter) SetRowsAffected(ctx context.Context, n int) {}
+
+// SetError is part of the rowResultWriter interface.
+func (r *returnNextResultWriter) SetError(err error) {
+	r.err = err
+}
+
+// Err is part of the rowResultWriter interface.
+func (r *returnNextResultWriter) Err() error {
+	return r.err
+}
+
 func (g *routineGenerator) newCursorHelper(plan *planComponents) (*plpgsqlCursorHelper, error) {
 	open := g.expr.CursorDeclaration
 	if open.NameArgIdx < 0 || open.NameArgIdx >= len(g.args) {
@@ -730,6 +853,15 @@ func (p *planner) EvalTxnControlExpr(
 			"PL/pgSQL COMMIT/ROLLBACK is not allowed in an explicit transaction",
 		)
 	}
+	if p.routineSessionOverrides > 0 {
+		// Postgres also disallows transaction control in routines which change
+		// the session, since the changes could not be restored in the new
+		// transaction.
+		return nil, errors.WithDetail(
+			pgerror.Newf(pgcode.InvalidTransactionTermination, "invalid transaction termination"),
+			"PL/pgSQL COMMIT/ROLLBACK is not allowed in a routine with SECURITY DEFINER or SET clauses",
+		)
+	}
 	resumeProc, err := expr.Gen(ctx, args)
 	if err != nil {
 		return nil, err
@@ -737,3 +869,49 @@ func (p *planner) EvalTxnControlExpr(
 	p.storedProcTxnState.setStoredProcTxnState(expr.Op, &expr.Modes, resumeProc.(*memo.Memo))
 	return tree.DNull, nil
 }
+
+// pushRoutineSessionOverride pushes a copy of the session data with the
+// changes described by the given override applied, which remain in effect
+// while a routine executes. It returns a function which restores the previous
+// session data.
+func (p *planner) pushRoutineSessionOverride(
+	ctx context.Context, override *tree.RoutineSessionOverride,
+) (restore func() error, err error) {
+	sds := p.EvalContext().SessionDataStack
+	sds.PushTopClone()
+	oldSearchPath := p.semaCtx.SearchPath
+	p.routineSessionOverrides++
+	restore = func() error {
+		p.routineSessionOverrides--
+		p.semaCtx.SearchPath = oldSearchPath
+		return sds.Pop()
+	}
+	defer func() {
+		if err != nil {
+			_ = restore()
+		}
+	}()
+
+	sd := sds.Top()
+	if !override.User.Undefined() {
+		if sd.SessionUserProto == "" {
+			sd.SessionUserProto = sd.UserProto
+		}
+		sd.UserProto = override.User.EncodeProto()
+	}
+	m := p.sessionDataMutatorIterator.mutator(false /* applyCallbacks */, sd)
+	for _, setting := range override.Settings {
+		_, v, err := getSessionVar(setting.Name, false /* missingOk */)
+		if err != nil {
+			return nil, err
+		}
+		if v.Set == nil {
+			return nil, errors.AssertionFailedf("session variable %q cannot be set by a routine", setting.Name)
+		}
+		if err := v.Set(ctx, m, setting.Value); err != nil {
+			return nil, err
+		}
+	}
+	p.semaCtx.SearchPath = &sd.SearchPath
+	return restore, nil
+}
@@ -244,6 +244,10 @@ func hasKeyEncoding(typ *types.T) bool {
 		return false
 	case types.ArrayFamily:
 		return hasKeyEncoding(typ.ArrayContents())
+	case types.RangeFamily:
+		return hasKeyEncoding(typ.RangeSubtype())
+	case types.MultirangeFamily:
+		return hasKeyEncoding(types.RangeOfMultirange(typ).RangeSubtype())
 	}
 	return true
 }
@@ -0,0 +1,231 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package keyside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// The markers below are used by the ascending encoding of ranges, which is
+// wrapped in a bytes encoding in the given direction to produce the key.
+//
+// A range is encoded as rangeEmptyMarker if it is empty, and otherwise as
+// [rangeNonEmptyMarker, lower, upper]. An infinite lower bound is encoded as
+// rangeInfiniteLowerMarker, and a finite lower bound as [rangeFiniteMarker,
+// enc(val), inc], where inc is 0 for an inclusive bound and 1 for an exclusive
+// one. A finite upper bound is encoded as [rangeFiniteMarker, enc(val), inc],
+// where inc is 0 for an exclusive bound and 1 for an inclusive one, and an
+// infinite upper bound as rangeInfiniteUpperMarker. This matches the ordering
+// of ranges: the empty range sorts first, and other ranges are ordered by
+// their lower bounds and then by their upper bounds.
+//
+// A multirange is encoded as the sequence of its ranges, each prefixed with
+// multirangeElemMarker, followed by multirangeTerminator.
+const (
+	rangeEmptyMarker         = 0x00
+	rangeNonEmptyMarker      = 0x01
+	rangeInfiniteLowerMarker = 0x00
+	rangeFiniteMarker        = 0x01
+	rangeInfiniteUpperMarker = 0x02
+	multirangeTerminator     = 0x00
+	multirangeElemMarker     = 0x01
+)
+
+// encodeRangeKey generates an ordered key encoding of a range.
+func encodeRangeKey(b []byte, r *tree.DRange, dir encoding.Direction) ([]byte, error) {
+	inner, err := appendRangeKey(nil, r)
+	if err != nil {
+		return nil, err
+	}
+	if dir == encoding.Ascending {
+		return encoding.EncodeBytesAscending(b, inner), nil
+	}
+	return encoding.EncodeBytesDescending(b, inner), nil
+}
+
+// encodeMultirangeKey generates an ordered key encoding of a multirange.
+func encodeMultirangeKey(
+	b []byte, mr *tree.DMultirange, dir encoding.Direction,
+) ([]byte, error) {
+	var inner []byte
+	var err error
+	for _, r := range mr.Ranges {
+		inner = append(inner, multirangeElemMarker)
+		if inner, err = appendRangeKey(inner, r); err != nil {
+			return nil, err
+		}
+	}
+	inner = append(inner, multirangeTerminator)
+	if dir == encoding.Ascending {
+		return encoding.EncodeBytesAscending(b, inner), nil
+	}
+	return encoding.EncodeBytesDescending(b, inner), nil
+}
+
+// appendRangeKey appends the ascending encoding of a range to b.
+func appendRangeKey(b []byte, r *tree.DRange) ([]byte, error) {
+	if r.Empty {
+		return append(b, rangeEmptyMarker), nil
+	}
+	b = append(b, rangeNonEmptyMarker)
+	var err error
+	if r.Lower == nil {
+		b = append(b, rangeInfiniteLowerMarker)
+	} else {
+		b = append(b, rangeFiniteMarker)
+		if b, err = Encode(b, r.Lower, encoding.Ascending); err != nil {
+			return nil, err
+		}
+		if r.LowerInc {
+			b = append(b, 0)
+		} else {
+			b = append(b, 1)
+		}
+	}
+	if r.Upper == nil {
+		b = append(b, rangeInfiniteUpperMarker)
+	} else {
+		b = append(b, rangeFiniteMarker)
+		if b, err = Encode(b, r.Upper, encoding.Ascending); err != nil {
+			return nil, err
+		}
+		if r.UpperInc {
+			b = append(b, 1)
+		} else {
+			b = append(b, 0)
+		}
+	}
+	return b, nil
+}
+
+// decodeRangeKey decodes a range key generated by encodeRangeKey.
+func decodeRangeKey(
+	a *tree.DatumAlloc, t *types.T, key []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	rkey, inner, err := decodeRangeKeyBytes(key, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, inner, err := consumeRangeKey(a, t, inner)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(inner) != 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (trailing bytes)")
+	}
+	return r, rkey, nil
+}
+
+// decodeMultirangeKey decodes a multirange key generated by
+// encodeMultirangeKey.
+func decodeMultirangeKey(
+	a *tree.DatumAlloc, t *types.T, key []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	rkey, inner, err := decodeRangeKeyBytes(key, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	rangeTyp := types.RangeOfMultirange(t)
+	var ranges []*tree.DRange
+	for {
+		if len(inner) == 0 {
+			return nil, nil, errors.AssertionFailedf("invalid multirange encoding (unterminated)")
+		}
+		marker := inner[0]
+		inner = inner[1:]
+		if marker == multirangeTerminator {
+			break
+		}
+		var r *tree.DRange
+		if r, inner, err = consumeRangeKey(a, rangeTyp, inner); err != nil {
+			return nil, nil, err
+		}
+		ranges = append(ranges, r)
+	}
+	return tree.NewDMultirangeFromRanges(t, ranges), rkey, nil
+}
+
+// decodeRangeKeyBytes decodes the bytes wrapping the ascending encoding of a
+// range or multirange.
+func decodeRangeKeyBytes(key []byte, dir encoding.Direction) (rkey, inner []byte, err error) {
+	// The bounds are decoded from inner, so a deep copy is made to avoid
+	// referencing the key's memory.
+	if dir == encoding.Ascending {
+		return encoding.DecodeBytesAscending(key, nil)
+	}
+	return encoding.DecodeBytesDescending(key, nil)
+}
+
+// consumeRangeKey decodes the ascending encoding of a range generated by
+// appendRangeKey, returning the remainder of the buffer.
+func consumeRangeKey(
+	a *tree.DatumAlloc, t *types.T, b []byte,
+) (*tree.DRange, []byte, error) {
+	if len(b) == 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (empty)")
+	}
+	if b[0] == rangeEmptyMarker {
+		return tree.NewDEmptyRange(t), b[1:], nil
+	}
+	b = b[1:]
+	flags := tree.RangeFlags(0)
+	var lower, upper tree.Datum
+	var err error
+	// The flag of a finite lower bound is set if the bound is exclusive, while the
+	// flag of a finite upper bound is set if the bound is inclusive.
+	var flag bool
+	if lower, flag, b, err = consumeRangeBoundKey(a, t.RangeSubtype(), b, rangeInfiniteLowerMarker); err != nil {
+		return nil, nil, err
+	}
+	if lower == nil {
+		flags |= tree.RangeLowerInf
+	} else if !flag {
+		flags |= tree.RangeLowerInc
+	}
+	if upper, flag, b, err = consumeRangeBoundKey(a, t.RangeSubtype(), b, rangeInfiniteUpperMarker); err != nil {
+		return nil, nil, err
+	}
+	if upper == nil {
+		flags |= tree.RangeUpperInf
+	} else if flag {
+		flags |= tree.RangeUpperInc
+	}
+	return tree.NewDRangeFromFlags(t, flags, lower, upper), b, nil
+}
+
+// consumeRangeBoundKey decodes one bound of a range, returning a nil datum if
+// the bound is infinite. The returned flag is the raw inclusivity byte of a
+// finite bound, whose meaning depends on the side of the bound.
+func consumeRangeBoundKey(
+	a *tree.DatumAlloc, subtype *types.T, b []byte, infiniteMarker byte,
+) (_ tree.Datum, flag bool, _ []byte, _ error) {
+	if len(b) == 0 {
+		return nil, false, nil, errors.AssertionFailedf("invalid range encoding (missing bound)")
+	}
+	switch b[0] {
+	case infiniteMarker:
+		return nil, false, b[1:], nil
+	case rangeFiniteMarker:
+	default:
+		return nil, false, nil, errors.AssertionFailedf("invalid range bound marker %d", b[0])
+	}
+	d, b, err := Decode(a, subtype, b[1:], encoding.Ascending)
+	if err != nil {
+		return nil, false, nil, err
+	}
+	if len(b) == 0 {
+		return nil, false, nil, errors.AssertionFailedf("invalid range encoding (missing inclusivity)")
+	}
+	return d, b[0] != 0, b[1:], nil
+}
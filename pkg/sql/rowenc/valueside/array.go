@@ -238,6 +238,10 @@ func DatumTypeToArrayElementEncodingType(t *types.T) (encoding.Type, error) {
 		return encoding.JSON, nil
 	case types.TupleFamily:
 		return encoding.Tuple, nil
+	case types.RangeFamily:
+		return encoding.Range, nil
+	case types.MultirangeFamily:
+		return encoding.Multirange, nil
 	case types.ArrayFamily:
 		return 0, unimplemented.NewWithIssueDetail(32552, "", "nested arrays are not fully supported")
 	default:
@@ -329,6 +333,18 @@ func encodeArrayElement(b []byte, d tree.Datum) ([]byte, error) {
 			return nil, err
 		}
 		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
+	case *tree.DRange:
+		encoded, err := encodeRange(nil, t, nil)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
+	case *tree.DMultirange:
+		encoded, err := encodeMultirange(nil, t, nil)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeUntaggedBytesValue(b, encoded), nil
 	default:
 		return nil, errors.Errorf("don't know how to encode %s (%T)", d, d)
 	}
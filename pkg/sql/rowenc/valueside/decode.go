@@ -213,6 +213,13 @@ func DecodeUntaggedDatum(
 			return nil, b, err
 		}
 		return tree.NewDTSQuery(v), b, nil
+	case types.JSONPathFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		d, err := tree.ParseDJSONPath(string(data))
+		return d, b, err
 	case types.TSVectorFamily:
 		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
 		if err != nil {
@@ -223,6 +230,20 @@ func DecodeUntaggedDatum(
 			return nil, b, err
 		}
 		return tree.NewDTSVector(v), b, nil
+	case types.RangeFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		r, _, err := decodeRange(a, t, data)
+		return r, b, err
+	case types.MultirangeFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		mr, _, err := decodeMultirange(a, t, data)
+		return mr, b, err
 	case types.OidFamily:
 		// TODO: This possibly should decode to uint32 (with corresponding changes
 		// to encoding) to ensure that the value fits in a DOid without any loss of
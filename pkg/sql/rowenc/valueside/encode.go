@@ -90,12 +90,26 @@ func Encode(appendTo []byte, colID ColumnIDDelta, val tree.Datum, scratch []byte
 			return nil, err
 		}
 		return encoding.EncodeTSQueryValue(appendTo, uint32(colID), encoded), nil
+	case *tree.DJSONPath:
+		return encoding.EncodeJSONPathValue(appendTo, uint32(colID), []byte(t.Path.String())), nil
 	case *tree.DTSVector:
 		encoded, err := tsearch.EncodeTSVector(scratch, t.TSVector)
 		if err != nil {
 			return nil, err
 		}
 		return encoding.EncodeTSVectorValue(appendTo, uint32(colID), encoded), nil
+	case *tree.DRange:
+		encoded, err := encodeRange(scratch[:0], t, nil)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeRangeValue(appendTo, uint32(colID), encoded), nil
+	case *tree.DMultirange:
+		encoded, err := encodeMultirange(scratch[:0], t, nil)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeMultirangeValue(appendTo, uint32(colID), encoded), nil
 	case *tree.DArray:
 		a, err := encodeArray(t, scratch)
 		if err != nil {
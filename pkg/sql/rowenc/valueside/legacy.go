@@ -159,6 +159,11 @@ func MarshalLegacy(colType *types.T, val tree.Datum) (roachpb.Value, error) {
 			r.SetBytes(data)
 			return r, nil
 		}
+	case types.JSONPathFamily:
+		if v, ok := val.(*tree.DJSONPath); ok {
+			r.SetString(v.Path.String())
+			return r, nil
+		}
 	case types.TSVectorFamily:
 		if v, ok := val.(*tree.DTSVector); ok {
 			data, err := tsearch.EncodeTSVector(nil, v.TSVector)
@@ -168,6 +173,24 @@ func MarshalLegacy(colType *types.T, val tree.Datum) (roachpb.Value, error) {
 			r.SetBytes(data)
 			return r, nil
 		}
+	case types.RangeFamily:
+		if v, ok := val.(*tree.DRange); ok {
+			data, err := encodeRange(nil, v, nil)
+			if err != nil {
+				return r, err
+			}
+			r.SetBytes(data)
+			return r, nil
+		}
+	case types.MultirangeFamily:
+		if v, ok := val.(*tree.DMultirange); ok {
+			data, err := encodeMultirange(nil, v, nil)
+			if err != nil {
+				return r, err
+			}
+			r.SetBytes(data)
+			return r, nil
+		}
 	case types.ArrayFamily:
 		if v, ok := val.(*tree.DArray); ok {
 			if err := checkElementType(v.ParamTyp, colType.ArrayContents()); err != nil {
@@ -412,6 +435,12 @@ func UnmarshalLegacy(a *tree.DatumAlloc, typ *types.T, value roachpb.Value) (tre
 			return nil, err
 		}
 		return tree.NewDTSQuery(vec), nil
+	case types.JSONPathFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		return tree.ParseDJSONPath(string(v))
 	case types.TSVectorFamily:
 		v, err := value.GetBytes()
 		if err != nil {
@@ -422,6 +451,20 @@ func UnmarshalLegacy(a *tree.DatumAlloc, typ *types.T, value roachpb.Value) (tre
 			return nil, err
 		}
 		return tree.NewDTSVector(vec), nil
+	case types.RangeFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		r, _, err := decodeRange(a, typ, v)
+		return r, err
+	case types.MultirangeFamily:
+		v, err := value.GetBytes()
+		if err != nil {
+			return nil, err
+		}
+		mr, _, err := decodeMultirange(a, typ, v)
+		return mr, err
 	case types.EnumFamily:
 		v, err := value.GetBytes()
 		if err != nil {
@@ -0,0 +1,95 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package valueside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// encodeRange produces the untagged value encoding of a range: a byte holding
+// the tree.RangeFlags of the range, followed by the value encodings of its
+// finite bounds.
+func encodeRange(appendTo []byte, r *tree.DRange, scratch []byte) ([]byte, error) {
+	appendTo = append(appendTo, byte(r.Flags()))
+	var err error
+	if r.Lower != nil {
+		if appendTo, err = Encode(appendTo, NoColumnID, r.Lower, scratch); err != nil {
+			return nil, err
+		}
+	}
+	if r.Upper != nil {
+		if appendTo, err = Encode(appendTo, NoColumnID, r.Upper, scratch); err != nil {
+			return nil, err
+		}
+	}
+	return appendTo, nil
+}
+
+// decodeRange decodes a range from its untagged value encoding. It is the
+// counterpart of encodeRange().
+func decodeRange(a *tree.DatumAlloc, t *types.T, b []byte) (*tree.DRange, []byte, error) {
+	if len(b) == 0 {
+		return nil, b, errors.AssertionFailedf("missing flags of encoded range")
+	}
+	flags := tree.RangeFlags(b[0])
+	b = b[1:]
+	if flags&tree.RangeEmpty != 0 {
+		return tree.NewDEmptyRange(t), b, nil
+	}
+	var lower, upper tree.Datum
+	var err error
+	if flags&tree.RangeLowerInf == 0 {
+		if lower, b, err = Decode(a, t.RangeSubtype(), b); err != nil {
+			return nil, b, err
+		}
+	}
+	if flags&tree.RangeUpperInf == 0 {
+		if upper, b, err = Decode(a, t.RangeSubtype(), b); err != nil {
+			return nil, b, err
+		}
+	}
+	return tree.NewDRangeFromFlags(t, flags, lower, upper), b, nil
+}
+
+// encodeMultirange produces the untagged value encoding of a multirange: the
+// number of ranges, followed by the untagged value encoding of each range.
+func encodeMultirange(appendTo []byte, mr *tree.DMultirange, scratch []byte) ([]byte, error) {
+	appendTo = encoding.EncodeNonsortingUvarint(appendTo, uint64(len(mr.Ranges)))
+	var err error
+	for _, r := range mr.Ranges {
+		if appendTo, err = encodeRange(appendTo, r, scratch); err != nil {
+			return nil, err
+		}
+	}
+	return appendTo, nil
+}
+
+// decodeMultirange decodes a multirange from its untagged value encoding. It
+// is the counterpart of encodeMultirange().
+func decodeMultirange(
+	a *tree.DatumAlloc, t *types.T, b []byte,
+) (*tree.DMultirange, []byte, error) {
+	b, _, n, err := encoding.DecodeNonsortingUvarint(b)
+	if err != nil {
+		return nil, b, err
+	}
+	rangeTyp := types.RangeOfMultirange(t)
+	ranges := make([]*tree.DRange, n)
+	for i := range ranges {
+		if ranges[i], b, err = decodeRange(a, rangeTyp, b); err != nil {
+			return nil, b, err
+		}
+	}
+	return tree.NewDMultirangeFromRanges(t, ranges), b, nil
+}
@@ -138,7 +138,20 @@ func newWindower(
 		for i, argIdx := range windowFn.ArgsIdxs {
 			argTypes[i] = w.inputTypes[argIdx]
 		}
-		windowConstructor, outputType, err := execagg.GetWindowFunctionInfo(windowFn.Func, argTypes...)
+		var windowConstructor func(*eval.Context) eval.WindowFunc
+		var outputType *types.T
+		var err error
+		if windowFn.UserDefined != nil {
+			var aggConstructor execagg.AggregateConstructor
+			aggConstructor, outputType, err = execagg.GetUserDefinedAggregateInfo(
+				ctx, evalCtx, flowCtx.NewSemaContext(flowCtx.Txn), windowFn.UserDefined, argTypes,
+			)
+			if err == nil {
+				windowConstructor = builtins.NewAggregateWindowFunc(aggConstructor)
+			}
+		} else {
+			windowConstructor, outputType, err = execagg.GetWindowFunctionInfo(windowFn.Func, argTypes...)
+		}
 		if err != nil {
 			return nil, err
 		}
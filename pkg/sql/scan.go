@@ -60,6 +60,13 @@ type scanNode struct {
 	spans   []roachpb.Span
 	reverse bool
 
+	// brinConstraints are the constraints on single columns of the table which
+	// are implied by the filters applied to the output of a scan of the primary
+	// index. The spans are restricted with the BRIN indexes of the table
+	// according to them when the table readers are planned, see
+	// constrainSpansWithBRINIndexes.
+	brinConstraints []exec.ColumnConstraint
+
 	reqOrdering ReqOrdering
 
 	// if non-zero, hardLimit indicates that the scanNode only needs to provide
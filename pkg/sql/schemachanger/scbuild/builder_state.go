@@ -466,6 +466,60 @@ func (b *builderState) NextTableConstraintID(tableID catid.DescID) (ret catid.Co
 	return ret
 }
 
+// NextTableTriggerID implements the scbuildstmt.TableHelpers interface.
+func (b *builderState) NextTableTriggerID(tableID catid.DescID) (ret catid.TriggerID) {
+	{
+		b.ensureDescriptor(tableID)
+		desc := b.descCache[tableID].desc
+		tbl, ok := desc.(catalog.TableDescriptor)
+		if !ok {
+			panic(errors.AssertionFailedf("Expected table descriptor for ID %d, instead got %s",
+				desc.GetID(), desc.DescriptorType()))
+		}
+		ret = tbl.GetNextTriggerID()
+		if ret == 0 {
+			ret = 1
+		}
+	}
+	// Consult all present element in case they have a TriggerID field and it's larger.
+	b.QueryByID(tableID).ForEach(func(
+		_ scpb.Status, _ scpb.TargetStatus, e scpb.Element,
+	) {
+		v, _ := screl.Schema.GetAttribute(screl.TriggerID, e)
+		if id, ok := v.(catid.TriggerID); ok && id >= ret {
+			ret = id + 1
+		}
+	})
+	return ret
+}
+
+// NextDomainConstraintID implements the scbuildstmt.TypeHelpers interface.
+func (b *builderState) NextDomainConstraintID(typeID catid.DescID) (ret catid.ConstraintID) {
+	{
+		b.ensureDescriptor(typeID)
+		desc := b.descCache[typeID].desc
+		typ, ok := desc.(catalog.TypeDescriptor)
+		if !ok || typ.AsDomainTypeDescriptor() == nil {
+			panic(errors.AssertionFailedf("Expected domain type descriptor for ID %d, instead got %s",
+				desc.GetID(), desc.DescriptorType()))
+		}
+		ret = typ.TypeDesc().Domain.NextConstraintID
+		if ret == 0 {
+			ret = 1
+		}
+	}
+	// Consult all present element in case they have a ConstraintID field and it's larger.
+	b.QueryByID(typeID).ForEach(func(
+		_ scpb.Status, _ scpb.TargetStatus, e scpb.Element,
+	) {
+		v, _ := screl.Schema.GetAttribute(screl.ConstraintID, e)
+		if id, ok := v.(catid.ConstraintID); ok && id >= ret {
+			ret = id + 1
+		}
+	})
+	return ret
+}
+
 // NextTableTentativeIndexID implements the scbuildstmt.TableHelpers interface.
 func (b *builderState) NextTableTentativeIndexID(tableID catid.DescID) (ret catid.IndexID) {
 	ret = catid.IndexID(scbuildstmt.TableTentativeIdsStart)
@@ -985,6 +1039,9 @@ func (b *builderState) ResolveUserDefinedTypeType(
 	case descpb.TypeDescriptor_COMPOSITE:
 		b.ensureDescriptor(typ.GetID())
 		b.mustOwn(typ.GetID())
+	case descpb.TypeDescriptor_DOMAIN:
+		b.ensureDescriptor(typ.GetID())
+		b.mustOwn(typ.GetID())
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		// Implicit record types are not directly modifiable.
 		panic(pgerror.Newf(pgcode.DependentObjectsStillExist,
@@ -1323,7 +1380,14 @@ func (b *builderState) ResolveRoutine(
 	}
 
 	fnID := funcdesc.UserDefinedFunctionOIDToID(ol.Oid)
-	b.mustOwn(fnID)
+	// Routines which are only referenced, rather than modified, by the
+	// statement only require the specified privilege. Otherwise, ownership is
+	// required.
+	if p.RequiredPrivilege != 0 && !p.RequireOwnership {
+		b.requirePrivilege(fnID, p.RequiredPrivilege)
+	} else {
+		b.mustOwn(fnID)
+	}
 	b.ensureDescriptor(fnID)
 	return b.QueryByID(fnID)
 }
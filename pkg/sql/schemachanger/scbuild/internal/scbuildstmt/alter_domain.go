@@ -0,0 +1,200 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scbuildstmt
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// AlterDomain implements ALTER DOMAIN.
+func AlterDomain(b BuildCtx, n *tree.AlterDomain) {
+	elts := b.ResolveUserDefinedTypeType(n.Domain, ResolveParams{})
+	_, _, domain := scpb.FindDomainType(elts)
+	if domain == nil {
+		panic(pgerror.Newf(pgcode.WrongObjectType, "%q is not a domain", n.Domain.Object()))
+	}
+	// Mutate the AST to have the fully resolved name from above, which will be
+	// used for both event logging and errors.
+	tn := tree.MakeTypeNameWithPrefix(b.NamePrefix(domain), n.Domain.Object())
+	b.SetUnresolvedNameAnnotation(n.Domain, &tn)
+	b.IncrementSchemaChangeAlterCounter("domain", n.Cmd.TelemetryName())
+
+	switch t := n.Cmd.(type) {
+	case *tree.AlterDomainSetDefault:
+		alterDomainSetDefault(b, domain, t)
+	case *tree.AlterDomainSetNotNull:
+		alterDomainSetNotNull(b, domain, t.NotNull)
+	case *tree.AlterDomainAddConstraint:
+		alterDomainAddConstraint(b, tn.Object(), domain, t)
+	case *tree.AlterDomainDropConstraint:
+		alterDomainDropConstraint(b, tn.Object(), domain, t)
+	case *tree.AlterDomainRenameConstraint:
+		alterDomainRenameConstraint(b, tn.Object(), domain, t)
+	default:
+		panic(scerrors.NotImplementedErrorf(n, "unsupported ALTER DOMAIN command %T", t))
+	}
+}
+
+func alterDomainSetDefault(b BuildCtx, domain *scpb.DomainType, t *tree.AlterDomainSetDefault) {
+	b.QueryByID(domain.TypeID).FilterDomainTypeDefault().ForEachTarget(func(
+		target scpb.TargetStatus, e *scpb.DomainTypeDefault,
+	) {
+		if target == scpb.ToPublic {
+			b.Drop(e)
+		}
+	})
+	// For DROP DEFAULT, or if our desired default expression is NULL, there is
+	// nothing left to do.
+	if t.Default == nil || t.Default == tree.DNull {
+		return
+	}
+	expr, err := schemaexpr.ValidateDomainDefaultExpr(b, t.Default, domain.Type)
+	if err != nil {
+		panic(err)
+	}
+	b.Add(&scpb.DomainTypeDefault{
+		TypeID: domain.TypeID,
+		Expr:   catpb.Expression(expr),
+	})
+}
+
+func alterDomainSetNotNull(b BuildCtx, domain *scpb.DomainType, notNull bool) {
+	var existing *scpb.DomainTypeNotNull
+	b.QueryByID(domain.TypeID).FilterDomainTypeNotNull().ForEachTarget(func(
+		target scpb.TargetStatus, e *scpb.DomainTypeNotNull,
+	) {
+		if target == scpb.ToPublic {
+			existing = e
+		}
+	})
+	switch {
+	case notNull && existing == nil:
+		b.Add(&scpb.DomainTypeNotNull{TypeID: domain.TypeID})
+	case !notNull && existing != nil:
+		b.Drop(existing)
+	}
+}
+
+func alterDomainAddConstraint(
+	b BuildCtx, domainName string, domain *scpb.DomainType, t *tree.AlterDomainAddConstraint,
+) {
+	c := &t.Constraint
+	if c.NotNull {
+		alterDomainSetNotNull(b, domain, true /* notNull */)
+		return
+	}
+	if c.Check == nil {
+		panic(pgerror.New(pgcode.Syntax, "NULL constraints cannot be added to a domain"))
+	}
+	name := string(c.Name)
+	if name == "" {
+		name = newDomainConstraintName(b, domainName, domain)
+	} else if domainConstraintNameElem(b, domain, name) != nil {
+		panic(pgerror.Newf(pgcode.DuplicateObject,
+			"constraint %q for domain %q already exists", name, domainName))
+	}
+	expr, err := schemaexpr.ValidateDomainCheckExpr(b, c.Check, domain.Type)
+	if err != nil {
+		panic(err)
+	}
+	constraintID := b.NextDomainConstraintID(domain.TypeID)
+	b.Add(&scpb.DomainTypeCheckConstraint{
+		TypeID:       domain.TypeID,
+		ConstraintID: constraintID,
+		Expr:         catpb.Expression(expr),
+	})
+	b.Add(&scpb.DomainTypeConstraintName{
+		TypeID:       domain.TypeID,
+		ConstraintID: constraintID,
+		Name:         name,
+	})
+}
+
+func alterDomainDropConstraint(
+	b BuildCtx, domainName string, domain *scpb.DomainType, t *tree.AlterDomainDropConstraint,
+) {
+	nameElem := domainConstraintNameElem(b, domain, string(t.Constraint))
+	if nameElem == nil {
+		if t.IfExists {
+			b.EvalCtx().ClientNoticeSender.BufferClientNotice(b, pgnotice.Newf(
+				"constraint %q of domain %q does not exist, skipping", t.Constraint, domainName))
+			return
+		}
+		panic(pgerror.Newf(pgcode.UndefinedObject,
+			"constraint %q of domain %q does not exist", t.Constraint, domainName))
+	}
+	b.QueryByID(domain.TypeID).FilterDomainTypeCheckConstraint().ForEachTarget(func(
+		target scpb.TargetStatus, e *scpb.DomainTypeCheckConstraint,
+	) {
+		if target == scpb.ToPublic && e.ConstraintID == nameElem.ConstraintID {
+			b.Drop(e)
+		}
+	})
+	b.Drop(nameElem)
+}
+
+func alterDomainRenameConstraint(
+	b BuildCtx, domainName string, domain *scpb.DomainType, t *tree.AlterDomainRenameConstraint,
+) {
+	nameElem := domainConstraintNameElem(b, domain, string(t.Constraint))
+	if nameElem == nil {
+		panic(pgerror.Newf(pgcode.UndefinedObject,
+			"constraint %q of domain %q does not exist", t.Constraint, domainName))
+	}
+	if t.Constraint == t.NewName {
+		return
+	}
+	if domainConstraintNameElem(b, domain, string(t.NewName)) != nil {
+		panic(pgerror.Newf(pgcode.DuplicateObject,
+			"constraint %q for domain %q already exists", t.NewName, domainName))
+	}
+	b.Drop(nameElem)
+	b.Add(&scpb.DomainTypeConstraintName{
+		TypeID:       domain.TypeID,
+		ConstraintID: nameElem.ConstraintID,
+		Name:         string(t.NewName),
+	})
+}
+
+// domainConstraintNameElem returns the name element of the constraint of the
+// domain with the given name, or nil if there is no such constraint.
+func domainConstraintNameElem(
+	b BuildCtx, domain *scpb.DomainType, name string,
+) (ret *scpb.DomainTypeConstraintName) {
+	b.QueryByID(domain.TypeID).FilterDomainTypeConstraintName().ForEachTarget(func(
+		target scpb.TargetStatus, e *scpb.DomainTypeConstraintName,
+	) {
+		if target == scpb.ToPublic && e.Name == name {
+			ret = e
+		}
+	})
+	return ret
+}
+
+// newDomainConstraintName returns a name for a new CHECK constraint of the
+// domain which doesn't conflict with any existing constraint, following the
+// Postgres naming scheme of <domain>_check, <domain>_check1, etc.
+func newDomainConstraintName(b BuildCtx, domainName string, domain *scpb.DomainType) string {
+	name := domainName + "_check"
+	for i := 1; domainConstraintNameElem(b, domain, name) != nil; i++ {
+		name = fmt.Sprintf("%s_check%d", domainName, i)
+	}
+	return name
+}
@@ -91,6 +91,9 @@ func alterTableAddColumn(
 				"UNIQUE WITHOUT INDEX constraint on the column",
 		))
 	}
+	if d.Unique.Deferrability != tree.ConstraintNotDeferrable {
+		panic(sqlerrors.NewAddColumnDeferrableUniqueError())
+	}
 	if d.PrimaryKey.IsPrimaryKey {
 		publicTargets := b.QueryByID(tbl.TableID).Filter(
 			func(_ scpb.Status, target scpb.TargetStatus, _ scpb.Element) bool {
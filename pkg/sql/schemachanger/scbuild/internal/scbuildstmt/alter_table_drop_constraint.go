@@ -42,6 +42,9 @@ func alterTableDropConstraint(
 	// is not mature enough to deal with DDLs in transaction; we will fall back
 	// until it is.
 	fallBackIfDroppingPrimaryKey(constraintElems, t)
+	// Dropping a DEFERRABLE unique constraint declared with an index: fall back
+	// to legacy schema changer, which drops its index along with it.
+	fallBackIfDroppingDeferrableUniqueIndexConstraint(constraintElems, t)
 	// Dropping UNIQUE constraint: error out as not implemented.
 	droppingUniqueConstraintNotImplemented(constraintElems, t)
 
@@ -84,6 +87,15 @@ func fallBackIfDroppingPrimaryKey(
 	}
 }
 
+func fallBackIfDroppingDeferrableUniqueIndexConstraint(
+	constraintElems ElementResultSet, t *tree.AlterTableDropConstraint,
+) {
+	_, _, uwi := scpb.FindUniqueWithoutIndexConstraint(constraintElems)
+	if uwi != nil && uwi.IndexID != 0 {
+		panic(scerrors.NotImplementedErrorf(t, "dropping a deferrable unique constraint with an index"))
+	}
+}
+
 func droppingUniqueConstraintNotImplemented(
 	constraintElems ElementResultSet, t *tree.AlterTableDropConstraint,
 ) {
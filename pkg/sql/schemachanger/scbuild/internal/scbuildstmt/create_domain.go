@@ -0,0 +1,210 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scbuildstmt
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// CreateDomain implements CREATE DOMAIN.
+func CreateDomain(b BuildCtx, n *tree.CreateDomain) {
+	dbElts, scElts := b.ResolveTargetObject(n.TypeName, privilege.CREATE)
+	_, _, schemaElem := scpb.FindSchema(scElts)
+	_, _, dbElem := scpb.FindDatabase(dbElts)
+	_, _, scName := scpb.FindNamespace(scElts)
+	_, _, dbName := scpb.FindNamespace(dbElts)
+	if schemaElem.IsTemporary {
+		panic(pgerror.New(pgcode.FeatureNotSupported,
+			"cannot create a domain in a temporary schema"))
+	}
+	// Mutate the AST to have the fully resolved name, which will be used for
+	// both event logging and errors.
+	prefix := tree.ObjectNamePrefix{
+		CatalogName:     tree.Name(dbName.Name),
+		SchemaName:      tree.Name(scName.Name),
+		ExplicitCatalog: true,
+		ExplicitSchema:  true,
+	}
+	tn := tree.MakeTypeNameWithPrefix(prefix, n.TypeName.Object())
+	b.SetUnresolvedNameAnnotation(n.TypeName, &tn)
+	b.IncrementSchemaChangeCreateCounter("domain")
+
+	// Check we are not creating a type which conflicts with an alias available
+	// as a built-in type in CockroachDB but an extension type on the public
+	// schema for PostgreSQL.
+	if scName.Name == catconstants.PublicSchemaName {
+		if _, ok := types.PublicSchemaAliases[tn.Object()]; ok {
+			panic(sqlerrors.NewTypeAlreadyExistsError(tn.FQString()))
+		}
+	}
+	if objectNameExists(b, prefix, tn.Object()) {
+		panic(sqlerrors.NewTypeAlreadyExistsError(tn.FQString()))
+	}
+
+	baseType := b.ResolveTypeRef(n.Type)
+	if err := tree.CheckUnsupportedType(b, b.SemaCtx(), baseType.Type); err != nil {
+		panic(err)
+	}
+	if err := schemaexpr.ValidateDomainBaseType(baseType.Type); err != nil {
+		panic(err)
+	}
+
+	// Generate the domain elements.
+	domainID := b.GenerateUniqueDescID()
+	arrayID := b.GenerateUniqueDescID()
+	domainElem := &scpb.DomainType{
+		TypeID:      domainID,
+		ArrayTypeID: arrayID,
+		TypeT:       baseType,
+	}
+	b.Add(domainElem)
+	addTypeNameAndOwnership(b, dbElem, schemaElem, domainID, tn.Object())
+	if n.Default != nil && n.Default != tree.DNull {
+		expr, err := schemaexpr.ValidateDomainDefaultExpr(b, n.Default, baseType.Type)
+		if err != nil {
+			panic(err)
+		}
+		b.Add(&scpb.DomainTypeDefault{
+			TypeID: domainID,
+			Expr:   catpb.Expression(expr),
+		})
+	}
+	var sawNull, notNull bool
+	names := make(map[string]struct{})
+	constraintID := catid.ConstraintID(1)
+	for i := range n.Constraints {
+		c := &n.Constraints[i]
+		switch {
+		case c.NotNull:
+			if sawNull {
+				panic(pgerror.New(pgcode.Syntax, "conflicting NULL/NOT NULL constraints"))
+			}
+			if !notNull {
+				b.Add(&scpb.DomainTypeNotNull{TypeID: domainID})
+			}
+			notNull = true
+		case c.Check == nil:
+			if notNull {
+				panic(pgerror.New(pgcode.Syntax, "conflicting NULL/NOT NULL constraints"))
+			}
+			sawNull = true
+		default:
+			expr, err := schemaexpr.ValidateDomainCheckExpr(b, c.Check, baseType.Type)
+			if err != nil {
+				panic(err)
+			}
+			name := string(c.Name)
+			if name == "" {
+				// Follow the Postgres naming scheme of <domain>_check,
+				// <domain>_check1, etc.
+				name = tn.Object() + "_check"
+				for j := 1; ; j++ {
+					if _, ok := names[name]; !ok {
+						break
+					}
+					name = fmt.Sprintf("%s_check%d", tn.Object(), j)
+				}
+			} else if _, ok := names[name]; ok {
+				panic(pgerror.Newf(pgcode.DuplicateObject,
+					"constraint %q for domain %q already exists", name, tn.Object()))
+			}
+			names[name] = struct{}{}
+			b.Add(&scpb.DomainTypeCheckConstraint{
+				TypeID:       domainID,
+				ConstraintID: constraintID,
+				Expr:         catpb.Expression(expr),
+			})
+			b.Add(&scpb.DomainTypeConstraintName{
+				TypeID:       domainID,
+				ConstraintID: constraintID,
+				Name:         name,
+			})
+			constraintID++
+		}
+	}
+
+	// Generate the elements of the implicit array type of the domain.
+	arrayType := types.MakeArray(types.MakeDomain(
+		baseType.Type, catid.TypeIDToOID(domainID), catid.TypeIDToOID(arrayID),
+	))
+	b.Add(&scpb.AliasType{
+		TypeID: arrayID,
+		TypeT: scpb.TypeT{
+			Type:          arrayType,
+			ClosedTypeIDs: typedesc.GetTypeDescriptorClosure(arrayType).Ordered(),
+		},
+	})
+	addTypeNameAndOwnership(b, dbElem, schemaElem, arrayID, freeArrayTypeName(b, prefix, tn.Object()))
+
+	// Log the creation of this domain.
+	b.LogEventForExistingTarget(domainElem)
+}
+
+// addTypeNameAndOwnership adds the namespace, schema child, owner and
+// privilege elements of a new type.
+func addTypeNameAndOwnership(
+	b BuildCtx, dbElem *scpb.Database, schemaElem *scpb.Schema, typeID catid.DescID, name string,
+) {
+	b.Add(&scpb.Namespace{
+		DatabaseID:   dbElem.DatabaseID,
+		SchemaID:     schemaElem.SchemaID,
+		DescriptorID: typeID,
+		Name:         name,
+	})
+	b.Add(&scpb.SchemaChild{
+		ChildObjectID: typeID,
+		SchemaID:      schemaElem.SchemaID,
+	})
+	ownerElem, userPrivsElems := b.BuildUserPrivilegesFromDefaultPrivileges(
+		dbElem, schemaElem, typeID, privilege.Types, b.CurrentUser(),
+	)
+	b.Add(ownerElem)
+	for _, userPrivsElem := range userPrivsElems {
+		b.Add(userPrivsElem)
+	}
+}
+
+// objectNameExists returns true if there is a relation or a type with the
+// given name in the schema with the given prefix.
+func objectNameExists(b BuildCtx, prefix tree.ObjectNamePrefix, name string) bool {
+	un := tree.MakeTypeNameWithPrefix(prefix, name).ToUnresolvedObjectName()
+	elts := b.ResolveRelation(un, ResolveParams{
+		IsExistenceOptional: true,
+		RequiredPrivilege:   privilege.USAGE,
+		WithOffline:         true, // Offline objects still hold their names.
+		ResolveTypes:        true, // Check for collisions with type names.
+	})
+	return elts != nil && !elts.IsEmpty()
+}
+
+// freeArrayTypeName returns the name of the implicit array type of a new type
+// with the given name. Like in Postgres, this is the name of the type prefixed
+// with as many underscores as are needed to avoid a collision.
+func freeArrayTypeName(b BuildCtx, prefix tree.ObjectNamePrefix, name string) string {
+	arrayName := "_" + name
+	for objectNameExists(b, prefix, arrayName) {
+		arrayName = "_" + arrayName
+	}
+	return arrayName
+}
@@ -13,6 +13,7 @@ package scbuildstmt
 import (
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcinfo"
@@ -31,6 +32,27 @@ func CreateFunction(b BuildCtx, n *tree.CreateRoutine) {
 	if n.Replace {
 		panic(scerrors.NotImplementedError(n))
 	}
+	// The security and session settings of a function are not yet modeled as
+	// elements.
+	for _, option := range n.Options {
+		switch t := option.(type) {
+		case tree.RoutineSecurity:
+			if t == tree.RoutineDefiner {
+				panic(scerrors.NotImplementedErrorf(n, "SECURITY DEFINER is not supported"))
+			}
+		case *tree.SetVar:
+			panic(scerrors.NotImplementedErrorf(n, "SET clauses are not supported"))
+		}
+	}
+	// Let the legacy schema changer report the version gate error for
+	// parameters which older nodes don't know about.
+	if !b.EvalCtx().Settings.Version.IsActive(b, clusterversion.V24_1) {
+		for _, param := range n.Params {
+			if param.Class == tree.RoutineParamVariadic || param.Class == tree.RoutineParamTable {
+				panic(scerrors.NotImplementedErrorf(n, "VARIADIC and TABLE parameters are not supported"))
+			}
+		}
+	}
 	b.IncrementSchemaChangeCreateCounter("function")
 
 	dbElts, scElts := b.ResolveTargetObject(n.Name.ToUnresolvedObjectName(), privilege.CREATE)
@@ -48,6 +48,12 @@ import (
 
 // CreateIndex implements CREATE INDEX.
 func CreateIndex(b BuildCtx, n *tree.CreateIndex) {
+	if n.Hash {
+		panic(scerrors.NotImplementedErrorf(n, "hash indexes are not supported"))
+	}
+	if n.BRIN {
+		panic(scerrors.NotImplementedErrorf(n, "BRIN indexes are not supported"))
+	}
 	b.IncrementSchemaChangeCreateCounter("index")
 	// Resolve the table name and start building the new index element.
 	relationElements := b.ResolveRelation(n.Table.ToUnresolvedObjectName(), ResolveParams{
@@ -0,0 +1,244 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scbuildstmt
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// CreateTrigger implements CREATE TRIGGER.
+func CreateTrigger(b BuildCtx, n *tree.CreateTrigger) {
+	if n.Replace {
+		panic(unimplemented.NewWithIssue(28296, "CREATE OR REPLACE TRIGGER"))
+	}
+	if n.Constraint {
+		panic(unimplemented.NewWithIssue(28296, "CREATE CONSTRAINT TRIGGER"))
+	}
+	for _, transition := range n.Transitions {
+		if !transition.IsTable {
+			panic(pgerror.New(pgcode.FeatureNotSupported,
+				"ROW variable naming in the REFERENCING clause is not supported"))
+		}
+	}
+	if len(n.Transitions) > 0 {
+		panic(unimplemented.NewWithIssue(28296, "trigger transition tables"))
+	}
+	b.IncrementSchemaChangeCreateCounter("trigger")
+
+	// Resolve the table on which the trigger is defined.
+	relationElements := b.ResolveRelation(n.TableName, ResolveParams{
+		RequiredPrivilege: privilege.CREATE,
+	})
+	var tbl *scpb.Table
+	relationElements.ForEach(func(_ scpb.Status, target scpb.TargetStatus, e scpb.Element) {
+		switch t := e.(type) {
+		case *scpb.Table:
+			if target == scpb.ToPublic {
+				tbl = t
+			}
+		case *scpb.View:
+			panic(unimplemented.NewWithIssue(28296, "triggers on views"))
+		case *scpb.Sequence:
+			panic(pgerror.Newf(pgcode.WrongObjectType,
+				"%q is not a table or view", n.TableName.Object()))
+		}
+	})
+	if tbl == nil {
+		panic(pgerror.Newf(pgcode.UndefinedTable,
+			"relation %q does not exist", n.TableName.Object()))
+	}
+	if descpb.IsVirtualTable(tbl.TableID) {
+		panic(pgerror.Newf(pgcode.WrongObjectType,
+			"%q is a virtual table", n.TableName.Object()))
+	}
+	panicIfSchemaIsLocked(relationElements)
+
+	// Validate the trigger timing and events.
+	actionTime := triggerActionTimeToProto(n.ActionTime)
+	if actionTime == semenumpb.TriggerActionTime_INSTEAD_OF {
+		panic(errors.WithDetail(
+			pgerror.Newf(pgcode.WrongObjectType, "%q is a table", n.TableName.Object()),
+			"Tables cannot have INSTEAD OF triggers."))
+	}
+	forEachRow := n.ForEach == tree.TriggerForEachRow
+	events := make([]*scpb.TriggerEvent, 0, len(n.Events))
+	var seenEvents [semenumpb.TriggerEventType_TRUNCATE + 1]bool
+	for _, event := range n.Events {
+		eventType := triggerEventTypeToProto(event.EventType)
+		if seenEvents[eventType] {
+			panic(pgerror.New(pgcode.Syntax, "duplicate trigger events specified"))
+		}
+		seenEvents[eventType] = true
+		if eventType == semenumpb.TriggerEventType_TRUNCATE {
+			panic(unimplemented.NewWithIssue(28296, "TRUNCATE triggers"))
+		}
+		columnNames := make([]string, 0, len(event.Columns))
+		for _, col := range event.Columns {
+			getColumnIDFromColumnName(b, tbl.TableID, col, true /* required */)
+			columnNames = append(columnNames, string(col))
+		}
+		events = append(events, &scpb.TriggerEvent{
+			Type:        eventType,
+			ColumnNames: columnNames,
+		})
+	}
+
+	// Check that the trigger name is not already in use on the table.
+	scpb.ForEachTriggerName(b.QueryByID(tbl.TableID).Filter(publicTargetFilter), func(
+		_ scpb.Status, _ scpb.TargetStatus, e *scpb.TriggerName,
+	) {
+		if e.Name == string(n.Name) {
+			panic(pgerror.Newf(pgcode.DuplicateObject,
+				"trigger %q for relation %q already exists", n.Name, n.TableName.Object()))
+		}
+	})
+
+	// Resolve the trigger function, which must take no arguments and return
+	// TRIGGER.
+	funcName, err := n.FuncName.ToRoutineName()
+	if err != nil {
+		panic(err)
+	}
+	fnElements := b.ResolveRoutine(
+		&tree.RoutineObj{
+			FuncName: funcName,
+			Params:   tree.RoutineParams{},
+		},
+		ResolveParams{
+			RequiredPrivilege: privilege.EXECUTE,
+		},
+		tree.UDFRoutine,
+	)
+	_, _, fn := scpb.FindFunction(fnElements)
+	if fn == nil {
+		panic(errors.AssertionFailedf("failed to resolve function %s", funcName.String()))
+	}
+	if fn.ReturnType.Type.Family() != types.TriggerFamily {
+		panic(pgerror.Newf(pgcode.InvalidObjectDefinition,
+			"function %s must return type trigger", funcName.Object()))
+	}
+
+	// Validate the WHEN condition, and collect the objects that it references.
+	var whenExpr string
+	var usesTypeIDs, usesRoutineIDs []catid.DescID
+	if n.When != nil {
+		whenExpr, err = schemaexpr.ValidateTriggerWhenExpr(
+			b, n.When, b.SemaCtx(), forEachRow, n.Events,
+			func() colinfo.ResultColumns {
+				return getNonDropResultColumns(b, tbl.TableID)
+			},
+			func(columnName tree.Name) (exists bool, accessible bool, id catid.ColumnID, typ *types.T) {
+				return columnLookupFn(b, tbl.TableID, columnName)
+			},
+		)
+		if err != nil {
+			panic(err)
+		}
+		parsedWhenExpr, err := parser.ParseExpr(whenExpr)
+		if err != nil {
+			panic(err)
+		}
+		wrapped := b.WrapExpression(tbl.TableID, parsedWhenExpr)
+		if len(wrapped.UsesSequenceIDs) > 0 {
+			panic(unimplemented.NewWithIssue(28296, "sequence references in trigger WHEN conditions"))
+		}
+		usesTypeIDs = wrapped.UsesTypeIDs
+		usesRoutineIDs = wrapped.UsesFunctionIDs
+	}
+
+	// Add the trigger elements.
+	triggerID := b.NextTableTriggerID(tbl.TableID)
+	trigger := &scpb.Trigger{
+		TableID:   tbl.TableID,
+		TriggerID: triggerID,
+	}
+	b.Add(trigger)
+	b.Add(&scpb.TriggerName{
+		TableID:   tbl.TableID,
+		TriggerID: triggerID,
+		Name:      string(n.Name),
+	})
+	b.Add(&scpb.TriggerEnabled{
+		TableID:   tbl.TableID,
+		TriggerID: triggerID,
+		Enabled:   true,
+	})
+	b.Add(&scpb.TriggerTiming{
+		TableID:    tbl.TableID,
+		TriggerID:  triggerID,
+		ActionTime: actionTime,
+		ForEachRow: forEachRow,
+	})
+	b.Add(&scpb.TriggerEvents{
+		TableID:   tbl.TableID,
+		TriggerID: triggerID,
+		Events:    events,
+	})
+	if whenExpr != "" {
+		b.Add(&scpb.TriggerWhen{
+			TableID:   tbl.TableID,
+			TriggerID: triggerID,
+			WhenExpr:  whenExpr,
+		})
+	}
+	b.Add(&scpb.TriggerFunctionCall{
+		TableID:   tbl.TableID,
+		TriggerID: triggerID,
+		FuncID:    fn.FunctionID,
+		FuncArgs:  n.FuncArgs,
+	})
+	b.Add(&scpb.TriggerDeps{
+		TableID:        tbl.TableID,
+		TriggerID:      triggerID,
+		UsesTypeIDs:    usesTypeIDs,
+		UsesRoutineIDs: usesRoutineIDs,
+	})
+	b.LogEventForExistingTarget(trigger)
+}
+
+func triggerActionTimeToProto(t tree.TriggerActionTime) semenumpb.TriggerActionTime {
+	switch t {
+	case tree.TriggerActionTimeBefore:
+		return semenumpb.TriggerActionTime_BEFORE
+	case tree.TriggerActionTimeAfter:
+		return semenumpb.TriggerActionTime_AFTER
+	case tree.TriggerActionTimeInsteadOf:
+		return semenumpb.TriggerActionTime_INSTEAD_OF
+	}
+	panic(errors.AssertionFailedf("unknown trigger action time: %v", t))
+}
+
+func triggerEventTypeToProto(t tree.TriggerEventType) semenumpb.TriggerEventType {
+	switch t {
+	case tree.TriggerEventInsert:
+		return semenumpb.TriggerEventType_INSERT
+	case tree.TriggerEventUpdate:
+		return semenumpb.TriggerEventType_UPDATE
+	case tree.TriggerEventDelete:
+		return semenumpb.TriggerEventType_DELETE
+	case tree.TriggerEventTruncate:
+		return semenumpb.TriggerEventType_TRUNCATE
+	}
+	panic(errors.AssertionFailedf("unknown trigger event type: %v", t))
+}
@@ -79,6 +79,7 @@ type BuilderState interface {
 	NameResolver
 	PrivilegeChecker
 	TableHelpers
+	TypeHelpers
 	FunctionHelpers
 	SchemaHelpers
 
@@ -267,6 +268,10 @@ type TableHelpers interface {
 	// added to this table.
 	NextTableConstraintID(tableID catid.DescID) catid.ConstraintID
 
+	// NextTableTriggerID returns the ID that should be used for any new trigger
+	// added to this table.
+	NextTableTriggerID(tableID catid.DescID) catid.TriggerID
+
 	// NextTableTentativeIndexID returns the tentative ID, starting from
 	// scbuild.TABLE_TENTATIVE_IDS_START, that should be used for any new index added to
 	// this table.
@@ -304,6 +309,14 @@ type TableHelpers interface {
 	IsTableEmpty(tbl *scpb.Table) bool
 }
 
+// TypeHelpers has methods useful for creating new user-defined type elements.
+type TypeHelpers interface {
+
+	// NextDomainConstraintID returns the ID that should be used for any new
+	// constraint added to this domain.
+	NextDomainConstraintID(typeID catid.DescID) catid.ConstraintID
+}
+
 type FunctionHelpers interface {
 	BuildReferenceProvider(stmt tree.Statement) ReferenceProvider
 	WrapFunctionBody(fnID descpb.ID, bodyStr string, lang catpb.Function_Language, provider ReferenceProvider) *scpb.FunctionBody
@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 )
 
 func DropFunction(b BuildCtx, n *tree.DropRoutine) {
@@ -43,6 +44,10 @@ func DropFunction(b BuildCtx, n *tree.DropRoutine) {
 		if fn == nil {
 			continue
 		}
+		if fn.IsAggregate != n.Aggregate {
+			_, _, fnName := scpb.FindFunctionName(elts)
+			panic(sqlerrors.NewWrongRoutineKindError("DROP", fnName.Name, fn.IsAggregate))
+		}
 		f.FuncName.ObjectNamePrefix = b.NamePrefix(fn)
 		if dropRestrictDescriptor(b, fn.FunctionID) {
 			toCheckBackRefs = append(toCheckBackRefs, fn.FunctionID)
@@ -51,7 +56,11 @@ func DropFunction(b BuildCtx, n *tree.DropRoutine) {
 		}
 		b.LogEventForExistingTarget(fn)
 		b.IncrementSubWorkID()
-		b.IncrementSchemaChangeDropCounter("function")
+		if n.Aggregate {
+			b.IncrementSchemaChangeDropCounter("aggregate")
+		} else {
+			b.IncrementSchemaChangeDropCounter("function")
+		}
 	}
 
 	for i, fnID := range toCheckBackRefs {
@@ -0,0 +1,73 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scbuildstmt
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// DropTrigger implements DROP TRIGGER.
+func DropTrigger(b BuildCtx, n *tree.DropTrigger) {
+	relationElements := b.ResolveRelation(n.Table, ResolveParams{
+		IsExistenceOptional: n.IfExists,
+		RequiredPrivilege:   privilege.CREATE,
+	})
+	if relationElements == nil {
+		b.EvalCtx().ClientNoticeSender.BufferClientNotice(b,
+			pgnotice.Newf("relation %q does not exist, skipping", n.Table.Object()))
+		return
+	}
+	_, _, tbl := scpb.FindTable(relationElements)
+	if tbl == nil {
+		panic(pgerror.Newf(pgcode.WrongObjectType,
+			"%q is not a table", n.Table.Object()))
+	}
+	panicIfSchemaIsLocked(relationElements)
+
+	var trigger *scpb.Trigger
+	scpb.ForEachTriggerName(b.QueryByID(tbl.TableID).Filter(publicTargetFilter), func(
+		_ scpb.Status, _ scpb.TargetStatus, e *scpb.TriggerName,
+	) {
+		if e.Name == string(n.Trigger) {
+			trigger = &scpb.Trigger{TableID: e.TableID, TriggerID: e.TriggerID}
+		}
+	})
+	if trigger == nil {
+		if n.IfExists {
+			b.EvalCtx().ClientNoticeSender.BufferClientNotice(b,
+				pgnotice.Newf("trigger %q for relation %q does not exist, skipping",
+					n.Trigger, n.Table.Object()))
+			return
+		}
+		panic(pgerror.Newf(pgcode.UndefinedObject,
+			"trigger %q for table %q does not exist", n.Trigger, n.Table.Object()))
+	}
+	dropTrigger(b, trigger.TableID, trigger.TriggerID)
+	b.LogEventForExistingTarget(trigger)
+	b.IncrementSchemaChangeDropCounter("trigger")
+}
+
+// dropTrigger drops all the elements which make up a trigger.
+func dropTrigger(b BuildCtx, tableID catid.DescID, triggerID catid.TriggerID) {
+	triggerElements(b, tableID, triggerID).ForEach(func(
+		current scpb.Status, target scpb.TargetStatus, e scpb.Element,
+	) {
+		if target != scpb.ToAbsent {
+			b.Drop(e)
+		}
+	})
+}
@@ -27,15 +27,42 @@ func DropType(b BuildCtx, n *tree.DropType) {
 	if n.DropBehavior == tree.DropCascade {
 		panic(scerrors.NotImplementedErrorf(n, "DROP TYPE CASCADE is not yet supported"))
 	}
+	dropTypes(b, n.Names, n.IfExists, n.DropBehavior, false /* domainsOnly */)
+}
+
+// DropDomain implements DROP DOMAIN.
+func DropDomain(b BuildCtx, n *tree.DropDomain) {
+	if n.DropBehavior == tree.DropCascade {
+		panic(scerrors.NotImplementedErrorf(n, "DROP DOMAIN CASCADE is not yet supported"))
+	}
+	dropTypes(b, n.Names, n.IfExists, n.DropBehavior, true /* domainsOnly */)
+}
+
+// dropTypes contains the common logic for DROP TYPE and DROP DOMAIN. If
+// domainsOnly is set, all the types must be domains.
+func dropTypes(
+	b BuildCtx,
+	names []*tree.UnresolvedObjectName,
+	ifExists bool,
+	behavior tree.DropBehavior,
+	domainsOnly bool,
+) {
 	var toCheckBackrefs []catid.DescID
 	arrayTypesToAlsoCheck := make(map[catid.DescID]catid.DescID)
-	for _, name := range n.Names {
+	for _, name := range names {
 		elts := b.ResolveUserDefinedTypeType(name, ResolveParams{
-			IsExistenceOptional: n.IfExists,
+			IsExistenceOptional: ifExists,
 			RequiredPrivilege:   privilege.DROP,
 		})
+		if elts == nil {
+			continue
+		}
 		var typ scpb.Element
 		var typeID, arrayTypeID catid.DescID
+		_, _, domain := scpb.FindDomainType(elts)
+		if domainsOnly && domain == nil {
+			panic(pgerror.Newf(pgcode.WrongObjectType, "%q is not a domain", name.Object()))
+		}
 		if _, _, enum := scpb.FindEnumType(elts); enum != nil {
 			b.IncrementEnumCounter(sqltelemetry.EnumDrop)
 			typeID, arrayTypeID = enum.TypeID, enum.ArrayTypeID
@@ -43,6 +70,9 @@ func DropType(b BuildCtx, n *tree.DropType) {
 		} else if _, _, composite := scpb.FindCompositeType(elts); composite != nil {
 			typeID, arrayTypeID = composite.TypeID, composite.ArrayTypeID
 			typ = composite
+		} else if domain != nil {
+			typeID, arrayTypeID = domain.TypeID, domain.ArrayTypeID
+			typ = domain
 		} else {
 			continue
 		}
@@ -52,7 +82,7 @@ func DropType(b BuildCtx, n *tree.DropType) {
 		tn := tree.MakeTypeNameWithPrefix(prefix, name.Object())
 		b.SetUnresolvedNameAnnotation(name, &tn)
 		// Drop the type.
-		if n.DropBehavior == tree.DropCascade {
+		if behavior == tree.DropCascade {
 			dropCascadeDescriptor(b, typeID)
 		} else {
 			if dropRestrictDescriptor(b, typeID) {
@@ -217,6 +217,41 @@ func (w *walkCtx) walkType(typ catalog.TypeDescriptor) {
 				Name:            comp.GetElementLabel(i),
 			})
 		}
+	} else if dom := typ.AsDomainTypeDescriptor(); dom != nil {
+		typeT := newTypeT(dom.BaseType())
+		w.ev(descriptorStatus(typ), &scpb.DomainType{
+			TypeID:      dom.GetID(),
+			ArrayTypeID: dom.GetArrayTypeID(),
+			TypeT:       *typeT,
+		})
+		if expr := dom.GetDefaultExpr(); expr != nil {
+			w.ev(scpb.Status_PUBLIC, &scpb.DomainTypeDefault{
+				TypeID: dom.GetID(),
+				Expr:   catpb.Expression(*expr),
+			})
+		}
+		if dom.IsNotNull() {
+			w.ev(scpb.Status_PUBLIC, &scpb.DomainTypeNotNull{
+				TypeID: dom.GetID(),
+			})
+		}
+		for i := 0; i < dom.NumChecks(); i++ {
+			ck := dom.GetCheck(i)
+			status := scpb.Status_PUBLIC
+			if ck.Validity != descpb.ConstraintValidity_Validated {
+				status = scpb.Status_WRITE_ONLY
+			}
+			w.ev(status, &scpb.DomainTypeCheckConstraint{
+				TypeID:       dom.GetID(),
+				ConstraintID: ck.ConstraintID,
+				Expr:         catpb.Expression(ck.Expr),
+			})
+			w.ev(scpb.Status_PUBLIC, &scpb.DomainTypeConstraintName{
+				TypeID:       dom.GetID(),
+				ConstraintID: ck.ConstraintID,
+				Name:         ck.Name,
+			})
+		}
 	} else {
 		panic(errors.AssertionFailedf("unsupported type kind %q", typ.GetKind()))
 	}
@@ -277,6 +312,12 @@ func GetSequenceOptions(
 }
 
 func (w *walkCtx) walkRelation(tbl catalog.TableDescriptor) {
+	if tbl.IsForeignTable() {
+		// Foreign tables have no primary index, which the elements of a table
+		// require, so schema changes on them are left to the legacy schema
+		// changer.
+		panic(scerrors.NotImplementedErrorf(nil /* n */, "foreign tables are not supported"))
+	}
 	switch {
 	case tbl.IsSequence():
 		w.ev(descriptorStatus(tbl), &scpb.Sequence{
@@ -396,6 +437,9 @@ func (w *walkCtx) walkRelation(tbl catalog.TableDescriptor) {
 	for _, c := range tbl.OutboundForeignKeys() {
 		w.walkForeignKeyConstraint(tbl, c)
 	}
+	for i := range tbl.GetTriggers() {
+		w.walkTrigger(tbl, &tbl.GetTriggers()[i])
+	}
 
 	_ = tbl.ForeachDependedOnBy(func(dep *descpb.TableDescriptor_Reference) error {
 		w.backRefs.Add(dep.ID)
@@ -583,6 +627,8 @@ func (w *walkCtx) walkIndex(tbl catalog.TableDescriptor, idx catalog.Index) {
 			IndexID:             idx.GetID(),
 			IsUnique:            idx.IsUnique(),
 			IsInverted:          idx.GetType() == descpb.IndexDescriptor_INVERTED,
+			IsHash:              idx.GetAccessMethod() == descpb.IndexDescriptor_HASH,
+			IsBRIN:              idx.GetAccessMethod() == descpb.IndexDescriptor_BRIN,
 			IsCreatedExplicitly: idx.IsCreatedExplicitly(),
 			ConstraintID:        idx.GetConstraintID(),
 			IsNotVisible:        idx.GetInvisibility() != 0.0,
@@ -707,6 +753,7 @@ func (w *walkCtx) walkUniqueWithoutIndexConstraint(
 			ConstraintID: c.GetConstraintID(),
 			ColumnIDs:    c.CollectKeyColumnIDs().Ordered(),
 			Predicate:    expr,
+			IndexID:      c.UniqueWithoutIndexDesc().IndexID,
 		}
 		w.ev(scpb.Status_PUBLIC, uwi)
 	}
@@ -760,6 +807,68 @@ func (w *walkCtx) walkCheckConstraint(tbl catalog.TableDescriptor, c catalog.Che
 	}
 }
 
+func (w *walkCtx) walkTrigger(tbl catalog.TableDescriptor, t *descpb.TriggerDescriptor) {
+	w.ev(scpb.Status_PUBLIC, &scpb.Trigger{
+		TableID:   tbl.GetID(),
+		TriggerID: t.ID,
+	})
+	w.ev(scpb.Status_PUBLIC, &scpb.TriggerName{
+		TableID:   tbl.GetID(),
+		TriggerID: t.ID,
+		Name:      t.Name,
+	})
+	w.ev(scpb.Status_PUBLIC, &scpb.TriggerEnabled{
+		TableID:   tbl.GetID(),
+		TriggerID: t.ID,
+		Enabled:   t.Enabled,
+	})
+	w.ev(scpb.Status_PUBLIC, &scpb.TriggerTiming{
+		TableID:    tbl.GetID(),
+		TriggerID:  t.ID,
+		ActionTime: t.ActionTime,
+		ForEachRow: t.ForEachRow,
+	})
+	events := make([]*scpb.TriggerEvent, 0, len(t.Events))
+	for _, event := range t.Events {
+		events = append(events, &scpb.TriggerEvent{
+			Type:        event.Type,
+			ColumnNames: event.ColumnNames,
+		})
+	}
+	w.ev(scpb.Status_PUBLIC, &scpb.TriggerEvents{
+		TableID:   tbl.GetID(),
+		TriggerID: t.ID,
+		Events:    events,
+	})
+	if t.NewTransitionAlias != "" || t.OldTransitionAlias != "" {
+		w.ev(scpb.Status_PUBLIC, &scpb.TriggerTransition{
+			TableID:            tbl.GetID(),
+			TriggerID:          t.ID,
+			NewTransitionAlias: t.NewTransitionAlias,
+			OldTransitionAlias: t.OldTransitionAlias,
+		})
+	}
+	if t.WhenExpr != "" {
+		w.ev(scpb.Status_PUBLIC, &scpb.TriggerWhen{
+			TableID:   tbl.GetID(),
+			TriggerID: t.ID,
+			WhenExpr:  t.WhenExpr,
+		})
+	}
+	w.ev(scpb.Status_PUBLIC, &scpb.TriggerFunctionCall{
+		TableID:   tbl.GetID(),
+		TriggerID: t.ID,
+		FuncID:    t.FuncID,
+		FuncArgs:  t.FuncArgs,
+	})
+	w.ev(scpb.Status_PUBLIC, &scpb.TriggerDeps{
+		TableID:        tbl.GetID(),
+		TriggerID:      t.ID,
+		UsesTypeIDs:    t.DependsOnTypes,
+		UsesRoutineIDs: t.DependsOnRoutines,
+	})
+}
+
 func (w *walkCtx) walkForeignKeyConstraint(
 	tbl catalog.TableDescriptor, c catalog.ForeignKeyConstraint,
 ) {
@@ -803,10 +912,11 @@ func (w *walkCtx) walkForeignKeyConstraint(
 func (w *walkCtx) walkFunction(fnDesc catalog.FunctionDescriptor) {
 	typeT := newTypeT(fnDesc.GetReturnType().Type)
 	fn := &scpb.Function{
-		FunctionID: fnDesc.GetID(),
-		ReturnSet:  fnDesc.GetReturnType().ReturnSet,
-		ReturnType: *typeT,
-		Params:     make([]scpb.Function_Parameter, len(fnDesc.GetParams())),
+		FunctionID:  fnDesc.GetID(),
+		ReturnSet:   fnDesc.GetReturnType().ReturnSet,
+		ReturnType:  *typeT,
+		Params:      make([]scpb.Function_Parameter, len(fnDesc.GetParams())),
+		IsAggregate: fnDesc.IsAggregate(),
 	}
 	for i, param := range fnDesc.GetParams() {
 		typeT := newTypeT(param.Type)
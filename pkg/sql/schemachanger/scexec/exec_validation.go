@@ -15,9 +15,14 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/errors"
 )
 
@@ -105,6 +110,129 @@ func executeValidateColumnNotNull(
 	return nil
 }
 
+func executeValidateDomainCheckConstraint(
+	ctx context.Context, deps Dependencies, op *scop.ValidateDomainCheckConstraint,
+) error {
+	domain, err := mustReadDomain(ctx, deps, op.TypeID)
+	if err != nil {
+		return err
+	}
+	var ck *descpb.TypeDescriptor_Domain_CheckConstraint
+	for i := 0; i < domain.NumChecks(); i++ {
+		if c := domain.GetCheck(i); c.ConstraintID == op.ConstraintID {
+			ck = c
+		}
+	}
+	if ck == nil {
+		return errors.AssertionFailedf(
+			"cannot find constraint %d in domain %d", op.ConstraintID, op.TypeID)
+	}
+	expr, err := parser.ParseExpr(ck.Expr)
+	if err != nil {
+		return err
+	}
+	return validateDomainColumns(ctx, deps, domain, func(col catalog.Column) (
+		*descpb.TableDescriptor_CheckConstraint, error,
+	) {
+		colExpr, err := tree.ReplaceDomainValue(expr, &tree.ColumnItem{ColumnName: tree.Name(col.GetName())})
+		if err != nil {
+			return nil, err
+		}
+		return &descpb.TableDescriptor_CheckConstraint{
+			Expr: tree.Serialize(colExpr),
+			Name: ck.Name,
+		}, nil
+	})
+}
+
+func executeValidateDomainNotNull(
+	ctx context.Context, deps Dependencies, op *scop.ValidateDomainNotNull,
+) error {
+	domain, err := mustReadDomain(ctx, deps, op.TypeID)
+	if err != nil {
+		return err
+	}
+	return validateDomainColumns(ctx, deps, domain, func(col catalog.Column) (
+		*descpb.TableDescriptor_CheckConstraint, error,
+	) {
+		return &descpb.TableDescriptor_CheckConstraint{
+			Expr: tree.Serialize(&tree.IsNotNullExpr{
+				Expr: &tree.ColumnItem{ColumnName: tree.Name(col.GetName())},
+			}),
+			Name:                col.GetName() + "_auto_not_null",
+			IsNonNullConstraint: true,
+		}, nil
+	})
+}
+
+func mustReadDomain(
+	ctx context.Context, deps Dependencies, typeID descpb.ID,
+) (catalog.DomainTypeDescriptor, error) {
+	descs, err := deps.Catalog().MustReadImmutableDescriptors(ctx, typeID)
+	if err != nil {
+		return nil, err
+	}
+	typ, err := catalog.AsTypeDescriptor(descs[0])
+	if err != nil {
+		return nil, err
+	}
+	domain := typ.AsDomainTypeDescriptor()
+	if domain == nil {
+		return nil, errors.AssertionFailedf("type %d is not a domain", typeID)
+	}
+	return domain, nil
+}
+
+// validateDomainColumns validates a constraint of a domain against the
+// existing rows of every table column of that domain. The constraint is
+// validated on each table in the form of a synthetic check constraint on the
+// column, as built by makeCheck.
+func validateDomainColumns(
+	ctx context.Context,
+	deps Dependencies,
+	domain catalog.DomainTypeDescriptor,
+	makeCheck func(col catalog.Column) (*descpb.TableDescriptor_CheckConstraint, error),
+) error {
+	typOID := catid.TypeIDToOID(domain.GetID())
+	for i := 0; i < domain.NumReferencingDescriptors(); i++ {
+		descs, err := deps.Catalog().MustReadImmutableDescriptors(ctx, domain.GetReferencingDescriptorID(i))
+		if err != nil {
+			return err
+		}
+		table, ok := descs[0].(catalog.TableDescriptor)
+		if !ok || table.Dropped() || !table.IsPhysicalTable() {
+			continue
+		}
+		for _, col := range table.PublicColumns() {
+			if col.GetType().Oid() != typOID {
+				continue
+			}
+			ck, err := makeCheck(col)
+			if err != nil {
+				return err
+			}
+			desc := protoutil.Clone(table.TableDesc()).(*descpb.TableDescriptor)
+			ck.ConstraintID = desc.NextConstraintID
+			ck.ColumnIDs = []descpb.ColumnID{col.GetID()}
+			ck.Validity = descpb.ConstraintValidity_Validating
+			desc.NextConstraintID++
+			desc.Checks = append(desc.Checks, ck)
+			synthetic := tabledesc.NewBuilder(desc).BuildImmutableTable()
+			constraint, err := catalog.MustFindConstraintByID(synthetic, ck.ConstraintID)
+			if err != nil {
+				return err
+			}
+			// Execute the validation operation as a node user.
+			execOverride := sessiondata.NodeUserSessionDataOverride
+			err = deps.Validator().ValidateConstraint(ctx, synthetic, constraint, 0 /* indexIDForValidation */, execOverride)
+			if err != nil {
+				return scerrors.SchemaChangerUserError(err)
+			}
+		}
+	}
+	return nil
+}
+
 func executeValidationOps(ctx context.Context, deps Dependencies, ops []scop.Op) (err error) {
 	for _, op := range ops {
 		if err = executeValidationOp(ctx, deps, op); err != nil {
@@ -137,6 +265,20 @@ func executeValidationOp(ctx context.Context, deps Dependencies, op scop.Op) (er
 			}
 			return err
 		}
+	case *scop.ValidateDomainCheckConstraint:
+		if err = executeValidateDomainCheckConstraint(ctx, deps, op); err != nil {
+			if !scerrors.HasSchemaChangerUserError(err) {
+				return errors.Wrapf(err, "%T: %v", op, op)
+			}
+			return err
+		}
+	case *scop.ValidateDomainNotNull:
+		if err = executeValidateDomainNotNull(ctx, deps, op); err != nil {
+			if !scerrors.HasSchemaChangerUserError(err) {
+				return errors.Wrapf(err, "%T: %v", op, op)
+			}
+			return err
+		}
 
 	default:
 		panic("unimplemented")
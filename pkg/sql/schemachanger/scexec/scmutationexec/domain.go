@@ -0,0 +1,207 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package scmutationexec
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
+	"github.com/cockroachdb/errors"
+)
+
+func (i *immediateVisitor) CreateDomainTypeDescriptor(
+	_ context.Context, op scop.CreateDomainTypeDescriptor,
+) error {
+	mut := typedesc.NewBuilder(&descpb.TypeDescriptor{
+		ParentID:       catid.InvalidDescID, // Set by `Namespace` element
+		ParentSchemaID: catid.InvalidDescID, // Set by `Namespace` element
+		Name:           "",                  // Set by `Namespace` element
+		ID:             op.TypeID,
+		Kind:           descpb.TypeDescriptor_DOMAIN,
+		ArrayTypeID:    op.ArrayTypeID,
+		Domain: &descpb.TypeDescriptor_Domain{
+			BaseType:         op.BaseType.Type,
+			NextConstraintID: 1,
+		},
+		Privileges: &catpb.PrivilegeDescriptor{Version: catpb.Version23_2}, // Populated by `UserPrivileges` elements and `Owner` element
+		Version:    1,
+	}).BuildCreatedMutableType()
+	mut.State = descpb.DescriptorState_ADD
+	i.CreateDescriptor(mut)
+	return nil
+}
+
+func (i *immediateVisitor) CreateAliasTypeDescriptor(
+	_ context.Context, op scop.CreateAliasTypeDescriptor,
+) error {
+	mut := typedesc.NewBuilder(&descpb.TypeDescriptor{
+		ParentID:       catid.InvalidDescID, // Set by `Namespace` element
+		ParentSchemaID: catid.InvalidDescID, // Set by `Namespace` element
+		Name:           "",                  // Set by `Namespace` element
+		ID:             op.TypeID,
+		Kind:           descpb.TypeDescriptor_ALIAS,
+		Alias:          op.Type.Type,
+		Privileges:     &catpb.PrivilegeDescriptor{Version: catpb.Version23_2}, // Populated by `UserPrivileges` elements and `Owner` element
+		Version:        1,
+	}).BuildCreatedMutableType()
+	mut.State = descpb.DescriptorState_ADD
+	i.CreateDescriptor(mut)
+	return nil
+}
+
+func (i *immediateVisitor) SetDomainTypeDefault(
+	ctx context.Context, op scop.SetDomainTypeDefault,
+) error {
+	typ, err := i.checkOutDomain(ctx, op.TypeID)
+	if err != nil || typ == nil {
+		return err
+	}
+	expr := string(op.Expr)
+	typ.Domain.DefaultExpr = &expr
+	return nil
+}
+
+func (i *immediateVisitor) RemoveDomainTypeDefault(
+	ctx context.Context, op scop.RemoveDomainTypeDefault,
+) error {
+	typ, err := i.checkOutDomain(ctx, op.TypeID)
+	if err != nil || typ == nil {
+		return err
+	}
+	// The default may have already been replaced by a new one in the same
+	// stage, in which case it must be left as is.
+	if typ.Domain.DefaultExpr != nil && *typ.Domain.DefaultExpr == string(op.Expr) {
+		typ.Domain.DefaultExpr = nil
+	}
+	return nil
+}
+
+func (i *immediateVisitor) SetDomainTypeNotNull(
+	ctx context.Context, op scop.SetDomainTypeNotNull,
+) error {
+	typ, err := i.checkOutDomain(ctx, op.TypeID)
+	if err != nil || typ == nil {
+		return err
+	}
+	typ.Domain.NotNull = true
+	return nil
+}
+
+func (i *immediateVisitor) RemoveDomainTypeNotNull(
+	ctx context.Context, op scop.RemoveDomainTypeNotNull,
+) error {
+	typ, err := i.checkOutDomain(ctx, op.TypeID)
+	if err != nil || typ == nil {
+		return err
+	}
+	typ.Domain.NotNull = false
+	return nil
+}
+
+func (i *immediateVisitor) AddDomainTypeCheckConstraint(
+	ctx context.Context, op scop.AddDomainTypeCheckConstraint,
+) error {
+	typ, err := i.checkOutDomain(ctx, op.TypeID)
+	if err != nil || typ == nil {
+		return err
+	}
+	if findDomainCheck(typ, op.ConstraintID) != nil {
+		return errors.AssertionFailedf("constraint %d already exists on domain %q (%d)",
+			op.ConstraintID, typ.GetName(), typ.GetID())
+	}
+	if op.ConstraintID >= typ.Domain.NextConstraintID {
+		typ.Domain.NextConstraintID = op.ConstraintID + 1
+	}
+	typ.Domain.Checks = append(typ.Domain.Checks, descpb.TypeDescriptor_Domain_CheckConstraint{
+		ConstraintID: op.ConstraintID,
+		Expr:         string(op.Expr),
+		Validity:     descpb.ConstraintValidity_Validating,
+	})
+	return nil
+}
+
+func (i *immediateVisitor) MakeValidatedDomainTypeCheckConstraintPublic(
+	ctx context.Context, op scop.MakeValidatedDomainTypeCheckConstraintPublic,
+) error {
+	typ, err := i.checkOutDomain(ctx, op.TypeID)
+	if err != nil || typ == nil {
+		return err
+	}
+	ck := findDomainCheck(typ, op.ConstraintID)
+	if ck == nil {
+		return errors.AssertionFailedf("constraint %d not found on domain %q (%d)",
+			op.ConstraintID, typ.GetName(), typ.GetID())
+	}
+	ck.Validity = descpb.ConstraintValidity_Validated
+	return nil
+}
+
+func (i *immediateVisitor) RemoveDomainTypeCheckConstraint(
+	ctx context.Context, op scop.RemoveDomainTypeCheckConstraint,
+) error {
+	typ, err := i.checkOutDomain(ctx, op.TypeID)
+	if err != nil || typ == nil {
+		return err
+	}
+	for j := range typ.Domain.Checks {
+		if typ.Domain.Checks[j].ConstraintID == op.ConstraintID {
+			typ.Domain.Checks = append(typ.Domain.Checks[:j], typ.Domain.Checks[j+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (i *immediateVisitor) SetDomainTypeConstraintName(
+	ctx context.Context, op scop.SetDomainTypeConstraintName,
+) error {
+	typ, err := i.checkOutDomain(ctx, op.TypeID)
+	if err != nil || typ == nil {
+		return err
+	}
+	ck := findDomainCheck(typ, op.ConstraintID)
+	if ck == nil {
+		return errors.AssertionFailedf("constraint %d not found on domain %q (%d)",
+			op.ConstraintID, typ.GetName(), typ.GetID())
+	}
+	ck.Name = op.Name
+	return nil
+}
+
+// checkOutDomain checks out the type descriptor of a domain, or returns nil if
+// the domain is being dropped.
+func (i *immediateVisitor) checkOutDomain(
+	ctx context.Context, id descpb.ID,
+) (*typedesc.Mutable, error) {
+	typ, err := i.checkOutType(ctx, id)
+	if err != nil || typ.Dropped() {
+		return nil, err
+	}
+	if typ.Kind != descpb.TypeDescriptor_DOMAIN || typ.Domain == nil {
+		return nil, errors.AssertionFailedf("type %q (%d) is not a domain", typ.GetName(), typ.GetID())
+	}
+	return typ, nil
+}
+
+func findDomainCheck(
+	typ *typedesc.Mutable, id descpb.ConstraintID,
+) *descpb.TypeDescriptor_Domain_CheckConstraint {
+	for j := range typ.Domain.Checks {
+		if typ.Domain.Checks[j].ConstraintID == id {
+			return &typ.Domain.Checks[j]
+		}
+	}
+	return nil
+}
@@ -99,6 +99,11 @@ func addNewIndexMutation(
 	if opIndex.GeoConfig != nil {
 		idx.GeoConfig = *opIndex.GeoConfig
 	}
+	if opIndex.IsHash {
+		idx.AccessMethod = descpb.IndexDescriptor_HASH
+	} else if opIndex.IsBRIN {
+		idx.AccessMethod = descpb.IndexDescriptor_BRIN
+	}
 	return enqueueIndexMutation(tbl, idx, state, descpb.DescriptorMutation_ADD)
 }
 
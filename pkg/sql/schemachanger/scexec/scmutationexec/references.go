@@ -632,13 +632,17 @@ func (i *immediateVisitor) SetObjectParentID(ctx context.Context, op scop.SetObj
 			ReturnType:  t.GetReturnType().Type,
 			ReturnSet:   t.GetReturnType().ReturnSet,
 			IsProcedure: t.IsProcedure(),
+			IsAggregate: t.IsAggregate(),
+			Operators:   t.Operators,
+			CastContext: t.CastContext,
 		}
 		for pIdx, p := range t.Params {
 			class := funcdesc.ToTreeRoutineParamClass(p.Class)
 			if tree.IsInParamClass(class) {
 				ol.ArgTypes = append(ol.ArgTypes, p.Type)
+				ol.IsVariadic = class == tree.RoutineParamVariadic
 			}
-			if class == tree.RoutineParamOut {
+			if class == tree.RoutineParamOut || class == tree.RoutineParamTable {
 				ol.OutParamOrdinals = append(ol.OutParamOrdinals, int32(pIdx))
 				ol.OutParamTypes = append(ol.OutParamTypes, p.Type)
 			}
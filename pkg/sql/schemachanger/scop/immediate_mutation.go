@@ -859,3 +859,163 @@ type CreateDatabaseDescriptor struct {
 	immediateMutationOp
 	DatabaseID descpb.ID
 }
+
+// AddTrigger adds a trigger to a table.
+type AddTrigger struct {
+	immediateMutationOp
+	Trigger scpb.Trigger
+}
+
+// SetTriggerName sets the name of a trigger.
+type SetTriggerName struct {
+	immediateMutationOp
+	Name scpb.TriggerName
+}
+
+// SetTriggerEnabled sets whether a trigger is enabled.
+type SetTriggerEnabled struct {
+	immediateMutationOp
+	Enabled scpb.TriggerEnabled
+}
+
+// SetTriggerTiming sets the action time and granularity of a trigger.
+type SetTriggerTiming struct {
+	immediateMutationOp
+	Timing scpb.TriggerTiming
+}
+
+// SetTriggerEvents sets the events which fire a trigger.
+type SetTriggerEvents struct {
+	immediateMutationOp
+	Events scpb.TriggerEvents
+}
+
+// SetTriggerTransition sets the transition aliases of a trigger.
+type SetTriggerTransition struct {
+	immediateMutationOp
+	Transition scpb.TriggerTransition
+}
+
+// SetTriggerWhen sets the WHEN condition of a trigger.
+type SetTriggerWhen struct {
+	immediateMutationOp
+	When scpb.TriggerWhen
+}
+
+// SetTriggerFunctionCall sets the function invoked by a trigger, along with
+// its arguments.
+type SetTriggerFunctionCall struct {
+	immediateMutationOp
+	FunctionCall scpb.TriggerFunctionCall
+}
+
+// SetTriggerForwardReferences sets the forward references of a trigger to the
+// types and routines used by its WHEN condition.
+type SetTriggerForwardReferences struct {
+	immediateMutationOp
+	Deps scpb.TriggerDeps
+}
+
+// RemoveTrigger removes a trigger from a table.
+type RemoveTrigger struct {
+	immediateMutationOp
+	Trigger scpb.Trigger
+}
+
+// AddTriggerBackReferencesInRoutines adds back references to a trigger in the
+// specified routines.
+type AddTriggerBackReferencesInRoutines struct {
+	immediateMutationOp
+	BackReferencedTableID   descpb.ID
+	BackReferencedTriggerID catid.TriggerID
+	RoutineIDs              []descpb.ID
+}
+
+// RemoveTriggerBackReferencesInRoutines removes back references to a trigger
+// from the specified routines.
+type RemoveTriggerBackReferencesInRoutines struct {
+	immediateMutationOp
+	BackReferencedTableID   descpb.ID
+	BackReferencedTriggerID catid.TriggerID
+	RoutineIDs              []descpb.ID
+}
+
+// SetDomainTypeDefault sets the default expression of a domain.
+type SetDomainTypeDefault struct {
+	immediateMutationOp
+	TypeID descpb.ID
+	Expr   catpb.Expression
+}
+
+// RemoveDomainTypeDefault removes the default expression of a domain, unless
+// it has already been replaced with a different expression.
+type RemoveDomainTypeDefault struct {
+	immediateMutationOp
+	TypeID descpb.ID
+	Expr   catpb.Expression
+}
+
+// SetDomainTypeNotNull makes a domain reject NULL values. The constraint is
+// enforced as soon as it is set, but existing columns of the domain are only
+// validated afterwards.
+type SetDomainTypeNotNull struct {
+	immediateMutationOp
+	TypeID descpb.ID
+}
+
+// RemoveDomainTypeNotNull makes a domain accept NULL values.
+type RemoveDomainTypeNotNull struct {
+	immediateMutationOp
+	TypeID descpb.ID
+}
+
+// AddDomainTypeCheckConstraint adds a CHECK constraint to a domain in the
+// VALIDATING state. The constraint is enforced as soon as it is added, but
+// existing columns of the domain are only validated afterwards.
+type AddDomainTypeCheckConstraint struct {
+	immediateMutationOp
+	TypeID       descpb.ID
+	ConstraintID descpb.ConstraintID
+	Expr         catpb.Expression
+}
+
+// MakeValidatedDomainTypeCheckConstraintPublic marks a CHECK constraint of a
+// domain as validated.
+type MakeValidatedDomainTypeCheckConstraintPublic struct {
+	immediateMutationOp
+	TypeID       descpb.ID
+	ConstraintID descpb.ConstraintID
+}
+
+// RemoveDomainTypeCheckConstraint removes a CHECK constraint from a domain.
+type RemoveDomainTypeCheckConstraint struct {
+	immediateMutationOp
+	TypeID       descpb.ID
+	ConstraintID descpb.ConstraintID
+}
+
+// SetDomainTypeConstraintName sets the name of a CHECK constraint of a domain.
+type SetDomainTypeConstraintName struct {
+	immediateMutationOp
+	TypeID       descpb.ID
+	ConstraintID descpb.ConstraintID
+	Name         string
+}
+
+// CreateDomainTypeDescriptor creates the type descriptor of a new domain in
+// the ADD state. Its name, parents, privileges, default and constraints are
+// set by other operations.
+type CreateDomainTypeDescriptor struct {
+	immediateMutationOp
+	TypeID      descpb.ID
+	ArrayTypeID descpb.ID
+	BaseType    scpb.TypeT
+}
+
+// CreateAliasTypeDescriptor creates the type descriptor of a new alias type,
+// such as the implicit array type of a domain, in the ADD state.
+type CreateAliasTypeDescriptor struct {
+	immediateMutationOp
+	TypeID descpb.ID
+	Type   scpb.TypeT
+}
@@ -130,6 +130,28 @@ type ImmediateMutationVisitor interface {
 	SetSequenceOptions(context.Context, SetSequenceOptions) error
 	InitSequence(context.Context, InitSequence) error
 	CreateDatabaseDescriptor(context.Context, CreateDatabaseDescriptor) error
+	AddTrigger(context.Context, AddTrigger) error
+	SetTriggerName(context.Context, SetTriggerName) error
+	SetTriggerEnabled(context.Context, SetTriggerEnabled) error
+	SetTriggerTiming(context.Context, SetTriggerTiming) error
+	SetTriggerEvents(context.Context, SetTriggerEvents) error
+	SetTriggerTransition(context.Context, SetTriggerTransition) error
+	SetTriggerWhen(context.Context, SetTriggerWhen) error
+	SetTriggerFunctionCall(context.Context, SetTriggerFunctionCall) error
+	SetTriggerForwardReferences(context.Context, SetTriggerForwardReferences) error
+	RemoveTrigger(context.Context, RemoveTrigger) error
+	AddTriggerBackReferencesInRoutines(context.Context, AddTriggerBackReferencesInRoutines) error
+	RemoveTriggerBackReferencesInRoutines(context.Context, RemoveTriggerBackReferencesInRoutines) error
+	SetDomainTypeDefault(context.Context, SetDomainTypeDefault) error
+	RemoveDomainTypeDefault(context.Context, RemoveDomainTypeDefault) error
+	SetDomainTypeNotNull(context.Context, SetDomainTypeNotNull) error
+	RemoveDomainTypeNotNull(context.Context, RemoveDomainTypeNotNull) error
+	AddDomainTypeCheckConstraint(context.Context, AddDomainTypeCheckConstraint) error
+	MakeValidatedDomainTypeCheckConstraintPublic(context.Context, MakeValidatedDomainTypeCheckConstraintPublic) error
+	RemoveDomainTypeCheckConstraint(context.Context, RemoveDomainTypeCheckConstraint) error
+	SetDomainTypeConstraintName(context.Context, SetDomainTypeConstraintName) error
+	CreateDomainTypeDescriptor(context.Context, CreateDomainTypeDescriptor) error
+	CreateAliasTypeDescriptor(context.Context, CreateAliasTypeDescriptor) error
 }
 
 // Visit is part of the ImmediateMutationOp interface.
@@ -671,3 +693,113 @@ func (op InitSequence) Visit(ctx context.Context, v ImmediateMutationVisitor) er
 func (op CreateDatabaseDescriptor) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
 	return v.CreateDatabaseDescriptor(ctx, op)
 }
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op AddTrigger) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.AddTrigger(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetTriggerName) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetTriggerName(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetTriggerEnabled) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetTriggerEnabled(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetTriggerTiming) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetTriggerTiming(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetTriggerEvents) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetTriggerEvents(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetTriggerTransition) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetTriggerTransition(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetTriggerWhen) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetTriggerWhen(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetTriggerFunctionCall) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetTriggerFunctionCall(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetTriggerForwardReferences) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetTriggerForwardReferences(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op RemoveTrigger) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.RemoveTrigger(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op AddTriggerBackReferencesInRoutines) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.AddTriggerBackReferencesInRoutines(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op RemoveTriggerBackReferencesInRoutines) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.RemoveTriggerBackReferencesInRoutines(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetDomainTypeDefault) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetDomainTypeDefault(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op RemoveDomainTypeDefault) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.RemoveDomainTypeDefault(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetDomainTypeNotNull) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetDomainTypeNotNull(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op RemoveDomainTypeNotNull) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.RemoveDomainTypeNotNull(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op AddDomainTypeCheckConstraint) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.AddDomainTypeCheckConstraint(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op MakeValidatedDomainTypeCheckConstraintPublic) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.MakeValidatedDomainTypeCheckConstraintPublic(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op RemoveDomainTypeCheckConstraint) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.RemoveDomainTypeCheckConstraint(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op SetDomainTypeConstraintName) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.SetDomainTypeConstraintName(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op CreateDomainTypeDescriptor) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.CreateDomainTypeDescriptor(ctx, op)
+}
+
+// Visit is part of the ImmediateMutationOp interface.
+func (op CreateAliasTypeDescriptor) Visit(ctx context.Context, v ImmediateMutationVisitor) error {
+	return v.CreateAliasTypeDescriptor(ctx, op)
+}
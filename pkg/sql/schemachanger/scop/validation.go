@@ -44,5 +44,20 @@ type ValidateColumnNotNull struct {
 	IndexIDForValidation descpb.IndexID
 }
 
+// ValidateDomainCheckConstraint validates a CHECK constraint of a domain
+// against the values of all the table columns of the domain.
+type ValidateDomainCheckConstraint struct {
+	validationOp
+	TypeID       descpb.ID
+	ConstraintID descpb.ConstraintID
+}
+
+// ValidateDomainNotNull validates the NOT NULL constraint of a domain against
+// the values of all the table columns of the domain.
+type ValidateDomainNotNull struct {
+	validationOp
+	TypeID descpb.ID
+}
+
 // Make sure baseOp is used for linter.
 var _ = validationOp{baseOp: baseOp{}}
@@ -25,6 +25,8 @@ type ValidationVisitor interface {
 	ValidateIndex(context.Context, ValidateIndex) error
 	ValidateConstraint(context.Context, ValidateConstraint) error
 	ValidateColumnNotNull(context.Context, ValidateColumnNotNull) error
+	ValidateDomainCheckConstraint(context.Context, ValidateDomainCheckConstraint) error
+	ValidateDomainNotNull(context.Context, ValidateDomainNotNull) error
 }
 
 // Visit is part of the ValidationOp interface.
@@ -41,3 +43,13 @@ func (op ValidateConstraint) Visit(ctx context.Context, v ValidationVisitor) err
 func (op ValidateColumnNotNull) Visit(ctx context.Context, v ValidationVisitor) error {
 	return v.ValidateColumnNotNull(ctx, op)
 }
+
+// Visit is part of the ValidationOp interface.
+func (op ValidateDomainCheckConstraint) Visit(ctx context.Context, v ValidationVisitor) error {
+	return v.ValidateDomainCheckConstraint(ctx, op)
+}
+
+// Visit is part of the ValidationOp interface.
+func (op ValidateDomainNotNull) Visit(ctx context.Context, v ValidationVisitor) error {
+	return v.ValidateDomainNotNull(ctx, op)
+}
@@ -792,6 +792,191 @@ func (c *ElementCollection[E]) FilterDatabaseRoleSetting() *ElementCollection[*D
 	return (*ElementCollection[*DatabaseRoleSetting])(ret)
 }
 
+func (e DomainType) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_DomainType) Element() Element {
+	return e.DomainType
+}
+
+// ForEachDomainType iterates over elements of type DomainType.
+// Deprecated
+func ForEachDomainType(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *DomainType),
+) {
+  c.FilterDomainType().ForEach(fn)
+}
+
+// FindDomainType finds the first element of type DomainType.
+// Deprecated
+func FindDomainType(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *DomainType) {
+	if tc := c.FilterDomainType(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*DomainType)
+	}
+	return current, target, element
+}
+
+// DomainTypeElements filters elements of type DomainType.
+func (c *ElementCollection[E]) FilterDomainType() *ElementCollection[*DomainType] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*DomainType)
+		return ok
+	})
+	return (*ElementCollection[*DomainType])(ret)
+}
+
+func (e DomainTypeCheckConstraint) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_DomainTypeCheckConstraint) Element() Element {
+	return e.DomainTypeCheckConstraint
+}
+
+// ForEachDomainTypeCheckConstraint iterates over elements of type DomainTypeCheckConstraint.
+// Deprecated
+func ForEachDomainTypeCheckConstraint(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *DomainTypeCheckConstraint),
+) {
+  c.FilterDomainTypeCheckConstraint().ForEach(fn)
+}
+
+// FindDomainTypeCheckConstraint finds the first element of type DomainTypeCheckConstraint.
+// Deprecated
+func FindDomainTypeCheckConstraint(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *DomainTypeCheckConstraint) {
+	if tc := c.FilterDomainTypeCheckConstraint(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*DomainTypeCheckConstraint)
+	}
+	return current, target, element
+}
+
+// DomainTypeCheckConstraintElements filters elements of type DomainTypeCheckConstraint.
+func (c *ElementCollection[E]) FilterDomainTypeCheckConstraint() *ElementCollection[*DomainTypeCheckConstraint] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*DomainTypeCheckConstraint)
+		return ok
+	})
+	return (*ElementCollection[*DomainTypeCheckConstraint])(ret)
+}
+
+func (e DomainTypeConstraintName) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_DomainTypeConstraintName) Element() Element {
+	return e.DomainTypeConstraintName
+}
+
+// ForEachDomainTypeConstraintName iterates over elements of type DomainTypeConstraintName.
+// Deprecated
+func ForEachDomainTypeConstraintName(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *DomainTypeConstraintName),
+) {
+  c.FilterDomainTypeConstraintName().ForEach(fn)
+}
+
+// FindDomainTypeConstraintName finds the first element of type DomainTypeConstraintName.
+// Deprecated
+func FindDomainTypeConstraintName(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *DomainTypeConstraintName) {
+	if tc := c.FilterDomainTypeConstraintName(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*DomainTypeConstraintName)
+	}
+	return current, target, element
+}
+
+// DomainTypeConstraintNameElements filters elements of type DomainTypeConstraintName.
+func (c *ElementCollection[E]) FilterDomainTypeConstraintName() *ElementCollection[*DomainTypeConstraintName] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*DomainTypeConstraintName)
+		return ok
+	})
+	return (*ElementCollection[*DomainTypeConstraintName])(ret)
+}
+
+func (e DomainTypeDefault) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_DomainTypeDefault) Element() Element {
+	return e.DomainTypeDefault
+}
+
+// ForEachDomainTypeDefault iterates over elements of type DomainTypeDefault.
+// Deprecated
+func ForEachDomainTypeDefault(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *DomainTypeDefault),
+) {
+  c.FilterDomainTypeDefault().ForEach(fn)
+}
+
+// FindDomainTypeDefault finds the first element of type DomainTypeDefault.
+// Deprecated
+func FindDomainTypeDefault(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *DomainTypeDefault) {
+	if tc := c.FilterDomainTypeDefault(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*DomainTypeDefault)
+	}
+	return current, target, element
+}
+
+// DomainTypeDefaultElements filters elements of type DomainTypeDefault.
+func (c *ElementCollection[E]) FilterDomainTypeDefault() *ElementCollection[*DomainTypeDefault] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*DomainTypeDefault)
+		return ok
+	})
+	return (*ElementCollection[*DomainTypeDefault])(ret)
+}
+
+func (e DomainTypeNotNull) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_DomainTypeNotNull) Element() Element {
+	return e.DomainTypeNotNull
+}
+
+// ForEachDomainTypeNotNull iterates over elements of type DomainTypeNotNull.
+// Deprecated
+func ForEachDomainTypeNotNull(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *DomainTypeNotNull),
+) {
+  c.FilterDomainTypeNotNull().ForEach(fn)
+}
+
+// FindDomainTypeNotNull finds the first element of type DomainTypeNotNull.
+// Deprecated
+func FindDomainTypeNotNull(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *DomainTypeNotNull) {
+	if tc := c.FilterDomainTypeNotNull(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*DomainTypeNotNull)
+	}
+	return current, target, element
+}
+
+// DomainTypeNotNullElements filters elements of type DomainTypeNotNull.
+func (c *ElementCollection[E]) FilterDomainTypeNotNull() *ElementCollection[*DomainTypeNotNull] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*DomainTypeNotNull)
+		return ok
+	})
+	return (*ElementCollection[*DomainTypeNotNull])(ret)
+}
+
 func (e EnumType) element() {}
 
 // Element implements ElementGetter.
@@ -2272,6 +2457,339 @@ func (c *ElementCollection[E]) FilterTemporaryIndex() *ElementCollection[*Tempor
 	return (*ElementCollection[*TemporaryIndex])(ret)
 }
 
+func (e Trigger) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_Trigger) Element() Element {
+	return e.Trigger
+}
+
+// ForEachTrigger iterates over elements of type Trigger.
+// Deprecated
+func ForEachTrigger(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *Trigger),
+) {
+  c.FilterTrigger().ForEach(fn)
+}
+
+// FindTrigger finds the first element of type Trigger.
+// Deprecated
+func FindTrigger(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *Trigger) {
+	if tc := c.FilterTrigger(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*Trigger)
+	}
+	return current, target, element
+}
+
+// TriggerElements filters elements of type Trigger.
+func (c *ElementCollection[E]) FilterTrigger() *ElementCollection[*Trigger] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*Trigger)
+		return ok
+	})
+	return (*ElementCollection[*Trigger])(ret)
+}
+
+func (e TriggerDeps) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_TriggerDeps) Element() Element {
+	return e.TriggerDeps
+}
+
+// ForEachTriggerDeps iterates over elements of type TriggerDeps.
+// Deprecated
+func ForEachTriggerDeps(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *TriggerDeps),
+) {
+  c.FilterTriggerDeps().ForEach(fn)
+}
+
+// FindTriggerDeps finds the first element of type TriggerDeps.
+// Deprecated
+func FindTriggerDeps(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *TriggerDeps) {
+	if tc := c.FilterTriggerDeps(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*TriggerDeps)
+	}
+	return current, target, element
+}
+
+// TriggerDepsElements filters elements of type TriggerDeps.
+func (c *ElementCollection[E]) FilterTriggerDeps() *ElementCollection[*TriggerDeps] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*TriggerDeps)
+		return ok
+	})
+	return (*ElementCollection[*TriggerDeps])(ret)
+}
+
+func (e TriggerEnabled) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_TriggerEnabled) Element() Element {
+	return e.TriggerEnabled
+}
+
+// ForEachTriggerEnabled iterates over elements of type TriggerEnabled.
+// Deprecated
+func ForEachTriggerEnabled(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *TriggerEnabled),
+) {
+  c.FilterTriggerEnabled().ForEach(fn)
+}
+
+// FindTriggerEnabled finds the first element of type TriggerEnabled.
+// Deprecated
+func FindTriggerEnabled(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *TriggerEnabled) {
+	if tc := c.FilterTriggerEnabled(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*TriggerEnabled)
+	}
+	return current, target, element
+}
+
+// TriggerEnabledElements filters elements of type TriggerEnabled.
+func (c *ElementCollection[E]) FilterTriggerEnabled() *ElementCollection[*TriggerEnabled] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*TriggerEnabled)
+		return ok
+	})
+	return (*ElementCollection[*TriggerEnabled])(ret)
+}
+
+func (e TriggerEvents) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_TriggerEvents) Element() Element {
+	return e.TriggerEvents
+}
+
+// ForEachTriggerEvents iterates over elements of type TriggerEvents.
+// Deprecated
+func ForEachTriggerEvents(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *TriggerEvents),
+) {
+  c.FilterTriggerEvents().ForEach(fn)
+}
+
+// FindTriggerEvents finds the first element of type TriggerEvents.
+// Deprecated
+func FindTriggerEvents(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *TriggerEvents) {
+	if tc := c.FilterTriggerEvents(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*TriggerEvents)
+	}
+	return current, target, element
+}
+
+// TriggerEventsElements filters elements of type TriggerEvents.
+func (c *ElementCollection[E]) FilterTriggerEvents() *ElementCollection[*TriggerEvents] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*TriggerEvents)
+		return ok
+	})
+	return (*ElementCollection[*TriggerEvents])(ret)
+}
+
+func (e TriggerFunctionCall) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_TriggerFunctionCall) Element() Element {
+	return e.TriggerFunctionCall
+}
+
+// ForEachTriggerFunctionCall iterates over elements of type TriggerFunctionCall.
+// Deprecated
+func ForEachTriggerFunctionCall(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *TriggerFunctionCall),
+) {
+  c.FilterTriggerFunctionCall().ForEach(fn)
+}
+
+// FindTriggerFunctionCall finds the first element of type TriggerFunctionCall.
+// Deprecated
+func FindTriggerFunctionCall(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *TriggerFunctionCall) {
+	if tc := c.FilterTriggerFunctionCall(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*TriggerFunctionCall)
+	}
+	return current, target, element
+}
+
+// TriggerFunctionCallElements filters elements of type TriggerFunctionCall.
+func (c *ElementCollection[E]) FilterTriggerFunctionCall() *ElementCollection[*TriggerFunctionCall] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*TriggerFunctionCall)
+		return ok
+	})
+	return (*ElementCollection[*TriggerFunctionCall])(ret)
+}
+
+func (e TriggerName) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_TriggerName) Element() Element {
+	return e.TriggerName
+}
+
+// ForEachTriggerName iterates over elements of type TriggerName.
+// Deprecated
+func ForEachTriggerName(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *TriggerName),
+) {
+  c.FilterTriggerName().ForEach(fn)
+}
+
+// FindTriggerName finds the first element of type TriggerName.
+// Deprecated
+func FindTriggerName(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *TriggerName) {
+	if tc := c.FilterTriggerName(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*TriggerName)
+	}
+	return current, target, element
+}
+
+// TriggerNameElements filters elements of type TriggerName.
+func (c *ElementCollection[E]) FilterTriggerName() *ElementCollection[*TriggerName] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*TriggerName)
+		return ok
+	})
+	return (*ElementCollection[*TriggerName])(ret)
+}
+
+func (e TriggerTiming) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_TriggerTiming) Element() Element {
+	return e.TriggerTiming
+}
+
+// ForEachTriggerTiming iterates over elements of type TriggerTiming.
+// Deprecated
+func ForEachTriggerTiming(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *TriggerTiming),
+) {
+  c.FilterTriggerTiming().ForEach(fn)
+}
+
+// FindTriggerTiming finds the first element of type TriggerTiming.
+// Deprecated
+func FindTriggerTiming(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *TriggerTiming) {
+	if tc := c.FilterTriggerTiming(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*TriggerTiming)
+	}
+	return current, target, element
+}
+
+// TriggerTimingElements filters elements of type TriggerTiming.
+func (c *ElementCollection[E]) FilterTriggerTiming() *ElementCollection[*TriggerTiming] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*TriggerTiming)
+		return ok
+	})
+	return (*ElementCollection[*TriggerTiming])(ret)
+}
+
+func (e TriggerTransition) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_TriggerTransition) Element() Element {
+	return e.TriggerTransition
+}
+
+// ForEachTriggerTransition iterates over elements of type TriggerTransition.
+// Deprecated
+func ForEachTriggerTransition(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *TriggerTransition),
+) {
+  c.FilterTriggerTransition().ForEach(fn)
+}
+
+// FindTriggerTransition finds the first element of type TriggerTransition.
+// Deprecated
+func FindTriggerTransition(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *TriggerTransition) {
+	if tc := c.FilterTriggerTransition(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*TriggerTransition)
+	}
+	return current, target, element
+}
+
+// TriggerTransitionElements filters elements of type TriggerTransition.
+func (c *ElementCollection[E]) FilterTriggerTransition() *ElementCollection[*TriggerTransition] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*TriggerTransition)
+		return ok
+	})
+	return (*ElementCollection[*TriggerTransition])(ret)
+}
+
+func (e TriggerWhen) element() {}
+
+// Element implements ElementGetter.
+func (e * ElementProto_TriggerWhen) Element() Element {
+	return e.TriggerWhen
+}
+
+// ForEachTriggerWhen iterates over elements of type TriggerWhen.
+// Deprecated
+func ForEachTriggerWhen(
+	c *ElementCollection[Element], fn func(current Status, target TargetStatus, e *TriggerWhen),
+) {
+  c.FilterTriggerWhen().ForEach(fn)
+}
+
+// FindTriggerWhen finds the first element of type TriggerWhen.
+// Deprecated
+func FindTriggerWhen(
+	c *ElementCollection[Element],
+) (current Status, target TargetStatus, element *TriggerWhen) {
+	if tc := c.FilterTriggerWhen(); !tc.IsEmpty() {
+		var e Element
+		current, target, e = tc.Get(0)
+		element = e.(*TriggerWhen)
+	}
+	return current, target, element
+}
+
+// TriggerWhenElements filters elements of type TriggerWhen.
+func (c *ElementCollection[E]) FilterTriggerWhen() *ElementCollection[*TriggerWhen] {
+	ret := c.genericFilter(func(_ Status, _ TargetStatus, e Element) bool {
+		_, ok := e.(*TriggerWhen)
+		return ok
+	})
+	return (*ElementCollection[*TriggerWhen])(ret)
+}
+
 func (e UniqueWithoutIndexConstraint) element() {}
 
 // Element implements ElementGetter.
@@ -2467,6 +2985,16 @@ func (e* ElementProto) SetElement(element Element) {
 			e.ElementOneOf = &ElementProto_DatabaseRegionConfig{ DatabaseRegionConfig: t}
 		case *DatabaseRoleSetting:
 			e.ElementOneOf = &ElementProto_DatabaseRoleSetting{ DatabaseRoleSetting: t}
+		case *DomainType:
+			e.ElementOneOf = &ElementProto_DomainType{ DomainType: t}
+		case *DomainTypeCheckConstraint:
+			e.ElementOneOf = &ElementProto_DomainTypeCheckConstraint{ DomainTypeCheckConstraint: t}
+		case *DomainTypeConstraintName:
+			e.ElementOneOf = &ElementProto_DomainTypeConstraintName{ DomainTypeConstraintName: t}
+		case *DomainTypeDefault:
+			e.ElementOneOf = &ElementProto_DomainTypeDefault{ DomainTypeDefault: t}
+		case *DomainTypeNotNull:
+			e.ElementOneOf = &ElementProto_DomainTypeNotNull{ DomainTypeNotNull: t}
 		case *EnumType:
 			e.ElementOneOf = &ElementProto_EnumType{ EnumType: t}
 		case *EnumTypeValue:
@@ -2547,6 +3075,24 @@ func (e* ElementProto) SetElement(element Element) {
 			e.ElementOneOf = &ElementProto_TableZoneConfig{ TableZoneConfig: t}
 		case *TemporaryIndex:
 			e.ElementOneOf = &ElementProto_TemporaryIndex{ TemporaryIndex: t}
+		case *Trigger:
+			e.ElementOneOf = &ElementProto_Trigger{ Trigger: t}
+		case *TriggerDeps:
+			e.ElementOneOf = &ElementProto_TriggerDeps{ TriggerDeps: t}
+		case *TriggerEnabled:
+			e.ElementOneOf = &ElementProto_TriggerEnabled{ TriggerEnabled: t}
+		case *TriggerEvents:
+			e.ElementOneOf = &ElementProto_TriggerEvents{ TriggerEvents: t}
+		case *TriggerFunctionCall:
+			e.ElementOneOf = &ElementProto_TriggerFunctionCall{ TriggerFunctionCall: t}
+		case *TriggerName:
+			e.ElementOneOf = &ElementProto_TriggerName{ TriggerName: t}
+		case *TriggerTiming:
+			e.ElementOneOf = &ElementProto_TriggerTiming{ TriggerTiming: t}
+		case *TriggerTransition:
+			e.ElementOneOf = &ElementProto_TriggerTransition{ TriggerTransition: t}
+		case *TriggerWhen:
+			e.ElementOneOf = &ElementProto_TriggerWhen{ TriggerWhen: t}
 		case *UniqueWithoutIndexConstraint:
 			e.ElementOneOf = &ElementProto_UniqueWithoutIndexConstraint{ UniqueWithoutIndexConstraint: t}
 		case *UniqueWithoutIndexConstraintUnvalidated:
@@ -2582,6 +3128,11 @@ func GetElementOneOfProtos() []interface{} {
 	((*ElementProto_DatabaseData)(nil)),
 	((*ElementProto_DatabaseRegionConfig)(nil)),
 	((*ElementProto_DatabaseRoleSetting)(nil)),
+	((*ElementProto_DomainType)(nil)),
+	((*ElementProto_DomainTypeCheckConstraint)(nil)),
+	((*ElementProto_DomainTypeConstraintName)(nil)),
+	((*ElementProto_DomainTypeDefault)(nil)),
+	((*ElementProto_DomainTypeNotNull)(nil)),
 	((*ElementProto_EnumType)(nil)),
 	((*ElementProto_EnumTypeValue)(nil)),
 	((*ElementProto_ForeignKeyConstraint)(nil)),
@@ -2622,6 +3173,15 @@ func GetElementOneOfProtos() []interface{} {
 	((*ElementProto_TableSchemaLocked)(nil)),
 	((*ElementProto_TableZoneConfig)(nil)),
 	((*ElementProto_TemporaryIndex)(nil)),
+	((*ElementProto_Trigger)(nil)),
+	((*ElementProto_TriggerDeps)(nil)),
+	((*ElementProto_TriggerEnabled)(nil)),
+	((*ElementProto_TriggerEvents)(nil)),
+	((*ElementProto_TriggerFunctionCall)(nil)),
+	((*ElementProto_TriggerName)(nil)),
+	((*ElementProto_TriggerTiming)(nil)),
+	((*ElementProto_TriggerTransition)(nil)),
+	((*ElementProto_TriggerWhen)(nil)),
 	((*ElementProto_UniqueWithoutIndexConstraint)(nil)),
 	((*ElementProto_UniqueWithoutIndexConstraintUnvalidated)(nil)),
 	((*ElementProto_UserPrivileges)(nil)),
@@ -2654,6 +3214,11 @@ func GetElementTypes() []interface{} {
 	((*DatabaseData)(nil)),
 	((*DatabaseRegionConfig)(nil)),
 	((*DatabaseRoleSetting)(nil)),
+	((*DomainType)(nil)),
+	((*DomainTypeCheckConstraint)(nil)),
+	((*DomainTypeConstraintName)(nil)),
+	((*DomainTypeDefault)(nil)),
+	((*DomainTypeNotNull)(nil)),
 	((*EnumType)(nil)),
 	((*EnumTypeValue)(nil)),
 	((*ForeignKeyConstraint)(nil)),
@@ -2694,6 +3259,15 @@ func GetElementTypes() []interface{} {
 	((*TableSchemaLocked)(nil)),
 	((*TableZoneConfig)(nil)),
 	((*TemporaryIndex)(nil)),
+	((*Trigger)(nil)),
+	((*TriggerDeps)(nil)),
+	((*TriggerEnabled)(nil)),
+	((*TriggerEvents)(nil)),
+	((*TriggerFunctionCall)(nil)),
+	((*TriggerName)(nil)),
+	((*TriggerTiming)(nil)),
+	((*TriggerTransition)(nil)),
+	((*TriggerWhen)(nil)),
 	((*UniqueWithoutIndexConstraint)(nil)),
 	((*UniqueWithoutIndexConstraintUnvalidated)(nil)),
 	((*UserPrivileges)(nil)),
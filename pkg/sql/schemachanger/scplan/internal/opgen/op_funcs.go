@@ -197,3 +197,15 @@ func checkIfDescriptorIsWithoutData(id descpb.ID, md *opGenContext) bool {
 	}
 	return !doesDescriptorHaveData
 }
+
+// checkIfDomainIsBeingCreated returns true if the domain with the given type
+// ID is being created by this schema change, in which case no column can be
+// of that domain yet and its constraints do not need to be validated.
+func checkIfDomainIsBeingCreated(id descpb.ID, md *opGenContext) bool {
+	for idx, t := range md.Targets {
+		if e, ok := t.Element().(*scpb.DomainType); ok && e.TypeID == id {
+			return md.Initial[idx] == scpb.Status_ABSENT
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package opgen
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+)
+
+func init() {
+	opRegistry.register((*scpb.DomainTypeCheckConstraint)(nil),
+		toPublic(
+			scpb.Status_ABSENT,
+			to(scpb.Status_WRITE_ONLY,
+				emit(func(this *scpb.DomainTypeCheckConstraint) *scop.AddDomainTypeCheckConstraint {
+					return &scop.AddDomainTypeCheckConstraint{
+						TypeID:       this.TypeID,
+						ConstraintID: this.ConstraintID,
+						Expr:         this.Expr,
+					}
+				}),
+			),
+			to(scpb.Status_VALIDATED,
+				emit(func(this *scpb.DomainTypeCheckConstraint, md *opGenContext) *scop.ValidateDomainCheckConstraint {
+					if checkIfDomainIsBeingCreated(this.TypeID, md) {
+						return nil
+					}
+					return &scop.ValidateDomainCheckConstraint{
+						TypeID:       this.TypeID,
+						ConstraintID: this.ConstraintID,
+					}
+				}),
+			),
+			to(scpb.Status_PUBLIC,
+				emit(func(this *scpb.DomainTypeCheckConstraint) *scop.MakeValidatedDomainTypeCheckConstraintPublic {
+					return &scop.MakeValidatedDomainTypeCheckConstraintPublic{
+						TypeID:       this.TypeID,
+						ConstraintID: this.ConstraintID,
+					}
+				}),
+			),
+		),
+		toAbsent(
+			scpb.Status_PUBLIC,
+			equiv(scpb.Status_VALIDATED),
+			equiv(scpb.Status_WRITE_ONLY),
+			to(scpb.Status_ABSENT,
+				revertible(false),
+				emit(func(this *scpb.DomainTypeCheckConstraint) *scop.RemoveDomainTypeCheckConstraint {
+					return &scop.RemoveDomainTypeCheckConstraint{
+						TypeID:       this.TypeID,
+						ConstraintID: this.ConstraintID,
+					}
+				}),
+			),
+		),
+	)
+}
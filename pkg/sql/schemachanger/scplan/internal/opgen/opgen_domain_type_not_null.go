@@ -0,0 +1,52 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package opgen
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+)
+
+func init() {
+	opRegistry.register((*scpb.DomainTypeNotNull)(nil),
+		toPublic(
+			scpb.Status_ABSENT,
+			to(scpb.Status_WRITE_ONLY,
+				emit(func(this *scpb.DomainTypeNotNull) *scop.SetDomainTypeNotNull {
+					return &scop.SetDomainTypeNotNull{
+						TypeID: this.TypeID,
+					}
+				}),
+			),
+			to(scpb.Status_VALIDATED,
+				emit(func(this *scpb.DomainTypeNotNull) *scop.ValidateDomainNotNull {
+					return &scop.ValidateDomainNotNull{
+						TypeID: this.TypeID,
+					}
+				}),
+			),
+			to(scpb.Status_PUBLIC),
+		),
+		toAbsent(
+			scpb.Status_PUBLIC,
+			equiv(scpb.Status_VALIDATED),
+			equiv(scpb.Status_WRITE_ONLY),
+			to(scpb.Status_ABSENT,
+				revertible(false),
+				emit(func(this *scpb.DomainTypeNotNull) *scop.RemoveDomainTypeNotNull {
+					return &scop.RemoveDomainTypeNotNull{
+						TypeID: this.TypeID,
+					}
+				}),
+			),
+		),
+	)
+}
@@ -0,0 +1,75 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package opgen
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+)
+
+func init() {
+	opRegistry.register((*scpb.TriggerDeps)(nil),
+		toPublic(
+			scpb.Status_ABSENT,
+			to(scpb.Status_PUBLIC,
+				emit(func(this *scpb.TriggerDeps) *scop.SetTriggerForwardReferences {
+					return &scop.SetTriggerForwardReferences{
+						Deps: *protoutil.Clone(this).(*scpb.TriggerDeps),
+					}
+				}),
+				emit(func(this *scpb.TriggerDeps) *scop.UpdateTableBackReferencesInTypes {
+					if len(this.UsesTypeIDs) == 0 {
+						return nil
+					}
+					return &scop.UpdateTableBackReferencesInTypes{
+						TypeIDs:               this.UsesTypeIDs,
+						BackReferencedTableID: this.TableID,
+					}
+				}),
+				emit(func(this *scpb.TriggerDeps) *scop.AddTriggerBackReferencesInRoutines {
+					if len(this.UsesRoutineIDs) == 0 {
+						return nil
+					}
+					return &scop.AddTriggerBackReferencesInRoutines{
+						BackReferencedTableID:   this.TableID,
+						BackReferencedTriggerID: this.TriggerID,
+						RoutineIDs:              this.UsesRoutineIDs,
+					}
+				}),
+			),
+		),
+		toAbsent(
+			scpb.Status_PUBLIC,
+			to(scpb.Status_ABSENT,
+				emit(func(this *scpb.TriggerDeps) *scop.UpdateTableBackReferencesInTypes {
+					if len(this.UsesTypeIDs) == 0 {
+						return nil
+					}
+					return &scop.UpdateTableBackReferencesInTypes{
+						TypeIDs:               this.UsesTypeIDs,
+						BackReferencedTableID: this.TableID,
+					}
+				}),
+				emit(func(this *scpb.TriggerDeps) *scop.RemoveTriggerBackReferencesInRoutines {
+					if len(this.UsesRoutineIDs) == 0 {
+						return nil
+					}
+					return &scop.RemoveTriggerBackReferencesInRoutines{
+						BackReferencedTableID:   this.TableID,
+						BackReferencedTriggerID: this.TriggerID,
+						RoutineIDs:              this.UsesRoutineIDs,
+					}
+				}),
+			),
+		),
+	)
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package opgen
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
+)
+
+func init() {
+	opRegistry.register((*scpb.TriggerFunctionCall)(nil),
+		toPublic(
+			scpb.Status_ABSENT,
+			to(scpb.Status_PUBLIC,
+				emit(func(this *scpb.TriggerFunctionCall) *scop.SetTriggerFunctionCall {
+					return &scop.SetTriggerFunctionCall{
+						FunctionCall: *protoutil.Clone(this).(*scpb.TriggerFunctionCall),
+					}
+				}),
+				emit(func(this *scpb.TriggerFunctionCall) *scop.AddTriggerBackReferencesInRoutines {
+					return &scop.AddTriggerBackReferencesInRoutines{
+						BackReferencedTableID:   this.TableID,
+						BackReferencedTriggerID: this.TriggerID,
+						RoutineIDs:              []descpb.ID{this.FuncID},
+					}
+				}),
+			),
+		),
+		toAbsent(
+			scpb.Status_PUBLIC,
+			to(scpb.Status_ABSENT,
+				emit(func(this *scpb.TriggerFunctionCall) *scop.RemoveTriggerBackReferencesInRoutines {
+					return &scop.RemoveTriggerBackReferencesInRoutines{
+						BackReferencedTableID:   this.TableID,
+						BackReferencedTriggerID: this.TriggerID,
+						RoutineIDs:              []descpb.ID{this.FuncID},
+					}
+				}),
+			),
+		),
+	)
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package current
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/rel"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	. "github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scplan/internal/rules"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scplan/internal/scgraph"
+)
+
+// These rules ensure that the type descriptor of a new domain exists before
+// its CHECK constraints are added to it, that a CHECK constraint exists in the
+// type descriptor before it is named, and that it is named before it is validated
+// so that validation errors refer to the correct name.
+func init() {
+	registerDepRule(
+		"domain type descriptor added before its check constraints",
+		scgraph.Precedence,
+		"domain-type", "domain-constraint",
+		func(from, to NodeVars) rel.Clauses {
+			return rel.Clauses{
+				from.Type((*scpb.DomainType)(nil)),
+				to.TypeFilter(rulesVersionKey, isDomainTypeCheckConstraint),
+				JoinOnDescID(from, to, "type-id"),
+				StatusesToPublicOrTransient(from, scpb.Status_DESCRIPTOR_ADDED, to, scpb.Status_WRITE_ONLY),
+			}
+		},
+	)
+
+	registerDepRule(
+		"domain check constraint write-only before its name",
+		scgraph.Precedence,
+		"domain-constraint", "constraint-name",
+		func(from, to NodeVars) rel.Clauses {
+			return rel.Clauses{
+				from.TypeFilter(rulesVersionKey, isDomainTypeCheckConstraint),
+				to.TypeFilter(rulesVersionKey, isDomainTypeConstraintName),
+				JoinOnConstraintID(from, to, "type-id", "constraint-id"),
+				StatusesToPublicOrTransient(from, scpb.Status_WRITE_ONLY, to, scpb.Status_PUBLIC),
+			}
+		},
+	)
+
+	registerDepRule(
+		"domain constraint name public before its check constraint is validated",
+		scgraph.Precedence,
+		"constraint-name", "domain-constraint",
+		func(from, to NodeVars) rel.Clauses {
+			return rel.Clauses{
+				from.TypeFilter(rulesVersionKey, isDomainTypeConstraintName),
+				to.TypeFilter(rulesVersionKey, isDomainTypeCheckConstraint),
+				JoinOnConstraintID(from, to, "type-id", "constraint-id"),
+				StatusesToPublicOrTransient(from, scpb.Status_PUBLIC, to, scpb.Status_VALIDATED),
+			}
+		},
+	)
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package current
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/rel"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scpb"
+	. "github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scplan/internal/rules"
+	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scplan/internal/scgraph"
+)
+
+// These rules ensure that the trigger entry in the table descriptor exists
+// before its properties are set, and that it is removed before the
+// back-references owned by its dependents are cleaned up.
+func init() {
+	registerDepRule(
+		"trigger public right before its dependents",
+		scgraph.SameStagePrecedence,
+		"trigger", "dependent",
+		func(from, to NodeVars) rel.Clauses {
+			return rel.Clauses{
+				from.TypeFilter(rulesVersionKey, isTrigger),
+				to.TypeFilter(rulesVersionKey, isTriggerDependent),
+				JoinOnTriggerID(from, to, "table-id", "trigger-id"),
+				StatusesToPublicOrTransient(from, scpb.Status_PUBLIC, to, scpb.Status_PUBLIC),
+			}
+		},
+	)
+
+	registerDepRuleForDrop(
+		"trigger removed right before its dependents",
+		scgraph.SameStagePrecedence,
+		"trigger", "dependent",
+		scpb.Status_ABSENT, scpb.Status_ABSENT,
+		func(from, to NodeVars) rel.Clauses {
+			return rel.Clauses{
+				from.TypeFilter(rulesVersionKey, isTrigger),
+				to.TypeFilter(rulesVersionKey, isTriggerDependent),
+				JoinOnTriggerID(from, to, "table-id", "trigger-id"),
+			}
+		},
+	)
+}
@@ -73,7 +73,7 @@ var descriptorDataIsNotBeingAdded = screl.Schema.DefNotJoin1(
 func isDescriptor(e scpb.Element) bool {
 	switch e.(type) {
 	case *scpb.Database, *scpb.Schema, *scpb.Table, *scpb.View, *scpb.Sequence,
-		*scpb.AliasType, *scpb.EnumType, *scpb.CompositeType, *scpb.Function:
+		*scpb.AliasType, *scpb.EnumType, *scpb.CompositeType, *scpb.DomainType, *scpb.Function:
 		return true
 	}
 	return false
@@ -194,7 +194,7 @@ func isWithExpression(element scpb.Element) bool {
 
 func isTypeDescriptor(element scpb.Element) bool {
 	switch element.(type) {
-	case *scpb.EnumType, *scpb.AliasType, *scpb.CompositeType:
+	case *scpb.EnumType, *scpb.AliasType, *scpb.CompositeType, *scpb.DomainType:
 		return true
 	default:
 		return false
@@ -283,6 +283,21 @@ func isConstraintDependent(e scpb.Element) bool {
 	return false
 }
 
+func isTrigger(e scpb.Element) bool {
+	_, ok := e.(*scpb.Trigger)
+	return ok
+}
+
+func isTriggerDependent(e scpb.Element) bool {
+	switch e.(type) {
+	case *scpb.TriggerName, *scpb.TriggerEnabled, *scpb.TriggerTiming,
+		*scpb.TriggerEvents, *scpb.TriggerTransition, *scpb.TriggerWhen,
+		*scpb.TriggerFunctionCall, *scpb.TriggerDeps:
+		return true
+	}
+	return false
+}
+
 func isConstraintWithIndexName(e scpb.Element) bool {
 	switch e.(type) {
 	case *scpb.ConstraintWithoutIndexName:
@@ -318,3 +333,13 @@ func isOwner(e scpb.Element) bool {
 	}
 	return false
 }
+
+func isDomainTypeCheckConstraint(e scpb.Element) bool {
+	_, ok := e.(*scpb.DomainTypeCheckConstraint)
+	return ok
+}
+
+func isDomainTypeConstraintName(e scpb.Element) bool {
+	_, ok := e.(*scpb.DomainTypeConstraintName)
+	return ok
+}
@@ -538,6 +538,17 @@ func (p Plan) explainValidations(root treeprinter.Node, ops []scop.Op) error {
 				p.IndexName(op.TableID, op.IndexIDForValidation),
 				p.Name(op.TableID),
 			)))
+		case *scop.ValidateDomainCheckConstraint:
+			root.Child(accountFor(fmt.Sprintf(
+				"validate CHECK constraint %d on columns of domain %s",
+				op.ConstraintID,
+				p.Name(op.TypeID),
+			)))
+		case *scop.ValidateDomainNotNull:
+			root.Child(accountFor(fmt.Sprintf(
+				"validate NOT NULL constraint on columns of domain %s",
+				p.Name(op.TypeID),
+			)))
 		}
 	}
 	return p.Params.MemAcc.Grow(p.Params.Ctx, int64(estimatedMemAlloc))
@@ -95,6 +95,8 @@ const (
 	ReferencedColumnIDs
 	// Expr corresponds to the string representation of a SQL expression for an element.
 	Expr
+	// TriggerID is the ID of a trigger.
+	TriggerID
 
 	// AttrMax is the largest possible Attr value.
 	// Note: add any new enum values before TargetStatus, leave these at the end.
@@ -138,6 +140,26 @@ var elementSchemaOptions = []rel.SchemaOption{
 	rel.EntityMapping(t((*scpb.CompositeType)(nil)),
 		rel.EntityAttr(DescID, "TypeID"),
 	),
+	rel.EntityMapping(t((*scpb.DomainType)(nil)),
+		rel.EntityAttr(DescID, "TypeID"),
+	),
+	rel.EntityMapping(t((*scpb.DomainTypeDefault)(nil)),
+		rel.EntityAttr(DescID, "TypeID"),
+		rel.EntityAttr(Expr, "Expr"),
+	),
+	rel.EntityMapping(t((*scpb.DomainTypeNotNull)(nil)),
+		rel.EntityAttr(DescID, "TypeID"),
+	),
+	rel.EntityMapping(t((*scpb.DomainTypeCheckConstraint)(nil)),
+		rel.EntityAttr(DescID, "TypeID"),
+		rel.EntityAttr(ConstraintID, "ConstraintID"),
+		rel.EntityAttr(Expr, "Expr"),
+	),
+	rel.EntityMapping(t((*scpb.DomainTypeConstraintName)(nil)),
+		rel.EntityAttr(DescID, "TypeID"),
+		rel.EntityAttr(ConstraintID, "ConstraintID"),
+		rel.EntityAttr(Name, "Name"),
+	),
 	rel.EntityMapping(t((*scpb.CompositeTypeAttrName)(nil)),
 		rel.EntityAttr(DescID, "CompositeTypeID"),
 		rel.EntityAttr(Name, "Name"),
@@ -408,6 +430,47 @@ var elementSchemaOptions = []rel.SchemaOption{
 	rel.EntityMapping(t((*scpb.FunctionBody)(nil)),
 		rel.EntityAttr(DescID, "FunctionID"),
 	),
+	// Trigger elements.
+	rel.EntityMapping(t((*scpb.Trigger)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+	),
+	rel.EntityMapping(t((*scpb.TriggerName)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+		rel.EntityAttr(Name, "Name"),
+	),
+	rel.EntityMapping(t((*scpb.TriggerEnabled)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+	),
+	rel.EntityMapping(t((*scpb.TriggerTiming)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+	),
+	rel.EntityMapping(t((*scpb.TriggerEvents)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+	),
+	rel.EntityMapping(t((*scpb.TriggerTransition)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+	),
+	rel.EntityMapping(t((*scpb.TriggerWhen)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+	),
+	rel.EntityMapping(t((*scpb.TriggerFunctionCall)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+		rel.EntityAttr(ReferencedDescID, "FuncID"),
+	),
+	rel.EntityMapping(t((*scpb.TriggerDeps)(nil)),
+		rel.EntityAttr(DescID, "TableID"),
+		rel.EntityAttr(TriggerID, "TriggerID"),
+		rel.EntityAttr(ReferencedTypeIDs, "UsesTypeIDs"),
+		rel.EntityAttr(ReferencedFunctionIDs, "UsesRoutineIDs"),
+	),
 }
 
 // Schema is the schema exported by this package covering the elements of scpb.
@@ -28,7 +28,8 @@ func _() {
 	_ = x[ReferencedFunctionIDs-18]
 	_ = x[ReferencedColumnIDs-19]
 	_ = x[Expr-20]
-	_ = x[AttrMax-20]
+	_ = x[TriggerID-21]
+	_ = x[AttrMax-21]
 }
 
 func (i Attr) String() string {
@@ -73,6 +74,8 @@ func (i Attr) String() string {
 		return "ReferencedColumnIDs"
 	case Expr:
 		return "Expr"
+	case TriggerID:
+		return "TriggerID"
 	default:
 		return "Attr(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
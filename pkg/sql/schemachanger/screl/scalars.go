@@ -125,6 +125,12 @@ func VersionSupportsElementUse(el scpb.Element, version clusterversion.ClusterVe
 		return true
 	case *scpb.SequenceOption:
 		return version.IsActive(clusterversion.V23_2)
+	case *scpb.Trigger, *scpb.TriggerName, *scpb.TriggerEnabled, *scpb.TriggerTiming,
+		*scpb.TriggerEvents, *scpb.TriggerTransition, *scpb.TriggerWhen,
+		*scpb.TriggerFunctionCall, *scpb.TriggerDeps,
+		*scpb.DomainType, *scpb.DomainTypeDefault, *scpb.DomainTypeNotNull,
+		*scpb.DomainTypeCheckConstraint, *scpb.DomainTypeConstraintName:
+		return version.IsActive(clusterversion.V24_1)
 	default:
 		panic(errors.AssertionFailedf("unknown element %T", el))
 	}
@@ -2573,6 +2573,247 @@ var builtinOidsArray = []string{
 	2605: `merge_aggregated_stmt_metadata(arg1: jsonb) -> jsonb`,
 	2606: `crdb_internal.protect_mvcc_history(timestamp: decimal, expiration_window: interval, description: string) -> int`,
 	2607: `crdb_internal.extend_mvcc_history_protection(job_id: int) -> void`,
+	2608: `pg_notify(channel: string, payload: string) -> void`,
+	2609: `pg_listening_channels() -> string`,
+	2610: `jsonpathsend(jsonpath: jsonpath) -> bytes`,
+	2611: `jsonpathout(jsonpath: jsonpath) -> bytes`,
+	2612: `jsonpathrecv(input: anyelement) -> jsonpath`,
+	2613: `jsonpathin(input: anyelement) -> jsonpath`,
+	2614: `jsonpath(string: string) -> jsonpath`,
+	2615: `jsonpath(jsonpath: jsonpath) -> jsonpath`,
+	2616: `char(jsonpath: jsonpath) -> "char"`,
+	2617: `text(jsonpath: jsonpath) -> string`,
+	2618: `varchar(jsonpath: jsonpath) -> varchar`,
+	2619: `name(jsonpath: jsonpath) -> name`,
+	2620: `bpchar(jsonpath: jsonpath) -> char`,
+	2621: `jsonb_path_exists(target: jsonb, path: jsonpath) -> bool`,
+	2622: `jsonb_path_exists(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
+	2623: `jsonb_path_exists(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
+	2624: `jsonb_path_match(target: jsonb, path: jsonpath) -> bool`,
+	2625: `jsonb_path_match(target: jsonb, path: jsonpath, vars: jsonb) -> bool`,
+	2626: `jsonb_path_match(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> bool`,
+	2627: `jsonb_path_query(target: jsonb, path: jsonpath) -> jsonb`,
+	2628: `jsonb_path_query(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2629: `jsonb_path_query(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2630: `jsonb_path_query_array(target: jsonb, path: jsonpath) -> jsonb`,
+	2631: `jsonb_path_query_array(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2632: `jsonb_path_query_array(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2633: `jsonb_path_query_first(target: jsonb, path: jsonpath) -> jsonb`,
+	2634: `jsonb_path_query_first(target: jsonb, path: jsonpath, vars: jsonb) -> jsonb`,
+	2635: `jsonb_path_query_first(target: jsonb, path: jsonpath, vars: jsonb, silent: bool) -> jsonb`,
+	2636: `jsonb_path_exists_opr(target: jsonb, path: jsonpath) -> bool`,
+	2637: `jsonb_path_match_opr(target: jsonb, path: jsonpath) -> bool`,
+	2638: `int4rangesend(int4range: int4range) -> bytes`,
+	2639: `int4rangeout(int4range: int4range) -> bytes`,
+	2640: `int4rangerecv(input: anyelement) -> int4range`,
+	2641: `int4rangein(input: anyelement) -> int4range`,
+	2642: `char(int4range: int4range) -> "char"`,
+	2643: `text(int4range: int4range) -> string`,
+	2644: `varchar(int4range: int4range) -> varchar`,
+	2645: `name(int4range: int4range) -> name`,
+	2646: `bpchar(int4range: int4range) -> char`,
+	2647: `int4multirangesend(int4multirange: int4multirange) -> bytes`,
+	2648: `int4multirangeout(int4multirange: int4multirange) -> bytes`,
+	2649: `int4multirangerecv(input: anyelement) -> int4multirange`,
+	2650: `int4multirangein(input: anyelement) -> int4multirange`,
+	2651: `char(int4multirange: int4multirange) -> "char"`,
+	2652: `text(int4multirange: int4multirange) -> string`,
+	2653: `varchar(int4multirange: int4multirange) -> varchar`,
+	2654: `name(int4multirange: int4multirange) -> name`,
+	2655: `bpchar(int4multirange: int4multirange) -> char`,
+	2656: `int4range(lower: int4, upper: int4) -> int4range`,
+	2657: `int4range(lower: int4, upper: int4, bounds: string) -> int4range`,
+	2658: `int4multirange(int4range...) -> int4multirange`,
+	2659: `int8rangesend(int8range: int8range) -> bytes`,
+	2660: `int8rangeout(int8range: int8range) -> bytes`,
+	2661: `int8rangerecv(input: anyelement) -> int8range`,
+	2662: `int8rangein(input: anyelement) -> int8range`,
+	2663: `char(int8range: int8range) -> "char"`,
+	2664: `text(int8range: int8range) -> string`,
+	2665: `varchar(int8range: int8range) -> varchar`,
+	2666: `name(int8range: int8range) -> name`,
+	2667: `bpchar(int8range: int8range) -> char`,
+	2668: `int8multirangesend(int8multirange: int8multirange) -> bytes`,
+	2669: `int8multirangeout(int8multirange: int8multirange) -> bytes`,
+	2670: `int8multirangerecv(input: anyelement) -> int8multirange`,
+	2671: `int8multirangein(input: anyelement) -> int8multirange`,
+	2672: `char(int8multirange: int8multirange) -> "char"`,
+	2673: `text(int8multirange: int8multirange) -> string`,
+	2674: `varchar(int8multirange: int8multirange) -> varchar`,
+	2675: `name(int8multirange: int8multirange) -> name`,
+	2676: `bpchar(int8multirange: int8multirange) -> char`,
+	2677: `int8range(lower: int, upper: int) -> int8range`,
+	2678: `int8range(lower: int, upper: int, bounds: string) -> int8range`,
+	2679: `int8multirange(int8range...) -> int8multirange`,
+	2680: `numrangesend(numrange: numrange) -> bytes`,
+	2681: `numrangeout(numrange: numrange) -> bytes`,
+	2682: `numrangerecv(input: anyelement) -> numrange`,
+	2683: `numrangein(input: anyelement) -> numrange`,
+	2684: `char(numrange: numrange) -> "char"`,
+	2685: `text(numrange: numrange) -> string`,
+	2686: `varchar(numrange: numrange) -> varchar`,
+	2687: `name(numrange: numrange) -> name`,
+	2688: `bpchar(numrange: numrange) -> char`,
+	2689: `nummultirangesend(nummultirange: nummultirange) -> bytes`,
+	2690: `nummultirangeout(nummultirange: nummultirange) -> bytes`,
+	2691: `nummultirangerecv(input: anyelement) -> nummultirange`,
+	2692: `nummultirangein(input: anyelement) -> nummultirange`,
+	2693: `char(nummultirange: nummultirange) -> "char"`,
+	2694: `text(nummultirange: nummultirange) -> string`,
+	2695: `varchar(nummultirange: nummultirange) -> varchar`,
+	2696: `name(nummultirange: nummultirange) -> name`,
+	2697: `bpchar(nummultirange: nummultirange) -> char`,
+	2698: `numrange(lower: decimal, upper: decimal) -> numrange`,
+	2699: `numrange(lower: decimal, upper: decimal, bounds: string) -> numrange`,
+	2700: `nummultirange(numrange...) -> nummultirange`,
+	2701: `tsrangesend(tsrange: tsrange) -> bytes`,
+	2702: `tsrangeout(tsrange: tsrange) -> bytes`,
+	2703: `tsrangerecv(input: anyelement) -> tsrange`,
+	2704: `tsrangein(input: anyelement) -> tsrange`,
+	2705: `char(tsrange: tsrange) -> "char"`,
+	2706: `text(tsrange: tsrange) -> string`,
+	2707: `varchar(tsrange: tsrange) -> varchar`,
+	2708: `name(tsrange: tsrange) -> name`,
+	2709: `bpchar(tsrange: tsrange) -> char`,
+	2710: `tsmultirangesend(tsmultirange: tsmultirange) -> bytes`,
+	2711: `tsmultirangeout(tsmultirange: tsmultirange) -> bytes`,
+	2712: `tsmultirangerecv(input: anyelement) -> tsmultirange`,
+	2713: `tsmultirangein(input: anyelement) -> tsmultirange`,
+	2714: `char(tsmultirange: tsmultirange) -> "char"`,
+	2715: `text(tsmultirange: tsmultirange) -> string`,
+	2716: `varchar(tsmultirange: tsmultirange) -> varchar`,
+	2717: `name(tsmultirange: tsmultirange) -> name`,
+	2718: `bpchar(tsmultirange: tsmultirange) -> char`,
+	2719: `tsrange(lower: timestamp, upper: timestamp) -> tsrange`,
+	2720: `tsrange(lower: timestamp, upper: timestamp, bounds: string) -> tsrange`,
+	2721: `tsmultirange(tsrange...) -> tsmultirange`,
+	2722: `tstzrangesend(tstzrange: tstzrange) -> bytes`,
+	2723: `tstzrangeout(tstzrange: tstzrange) -> bytes`,
+	2724: `tstzrangerecv(input: anyelement) -> tstzrange`,
+	2725: `tstzrangein(input: anyelement) -> tstzrange`,
+	2726: `char(tstzrange: tstzrange) -> "char"`,
+	2727: `text(tstzrange: tstzrange) -> string`,
+	2728: `varchar(tstzrange: tstzrange) -> varchar`,
+	2729: `name(tstzrange: tstzrange) -> name`,
+	2730: `bpchar(tstzrange: tstzrange) -> char`,
+	2731: `tstzmultirangesend(tstzmultirange: tstzmultirange) -> bytes`,
+	2732: `tstzmultirangeout(tstzmultirange: tstzmultirange) -> bytes`,
+	2733: `tstzmultirangerecv(input: anyelement) -> tstzmultirange`,
+	2734: `tstzmultirangein(input: anyelement) -> tstzmultirange`,
+	2735: `char(tstzmultirange: tstzmultirange) -> "char"`,
+	2736: `text(tstzmultirange: tstzmultirange) -> string`,
+	2737: `varchar(tstzmultirange: tstzmultirange) -> varchar`,
+	2738: `name(tstzmultirange: tstzmultirange) -> name`,
+	2739: `bpchar(tstzmultirange: tstzmultirange) -> char`,
+	2740: `tstzrange(lower: timestamptz, upper: timestamptz) -> tstzrange`,
+	2741: `tstzrange(lower: timestamptz, upper: timestamptz, bounds: string) -> tstzrange`,
+	2742: `tstzmultirange(tstzrange...) -> tstzmultirange`,
+	2743: `daterangesend(daterange: daterange) -> bytes`,
+	2744: `daterangeout(daterange: daterange) -> bytes`,
+	2745: `daterangerecv(input: anyelement) -> daterange`,
+	2746: `daterangein(input: anyelement) -> daterange`,
+	2747: `char(daterange: daterange) -> "char"`,
+	2748: `text(daterange: daterange) -> string`,
+	2749: `varchar(daterange: daterange) -> varchar`,
+	2750: `name(daterange: daterange) -> name`,
+	2751: `bpchar(daterange: daterange) -> char`,
+	2752: `datemultirangesend(datemultirange: datemultirange) -> bytes`,
+	2753: `datemultirangeout(datemultirange: datemultirange) -> bytes`,
+	2754: `datemultirangerecv(input: anyelement) -> datemultirange`,
+	2755: `datemultirangein(input: anyelement) -> datemultirange`,
+	2756: `char(datemultirange: datemultirange) -> "char"`,
+	2757: `text(datemultirange: datemultirange) -> string`,
+	2758: `varchar(datemultirange: datemultirange) -> varchar`,
+	2759: `name(datemultirange: datemultirange) -> name`,
+	2760: `bpchar(datemultirange: datemultirange) -> char`,
+	2761: `daterange(lower: date, upper: date) -> daterange`,
+	2762: `daterange(lower: date, upper: date, bounds: string) -> daterange`,
+	2763: `datemultirange(daterange...) -> datemultirange`,
+	2764: `isempty(range: int4range) -> bool`,
+	2765: `isempty(range: int8range) -> bool`,
+	2766: `isempty(range: numrange) -> bool`,
+	2767: `isempty(range: tsrange) -> bool`,
+	2768: `isempty(range: tstzrange) -> bool`,
+	2769: `isempty(range: daterange) -> bool`,
+	2770: `isempty(multirange: int4multirange) -> bool`,
+	2771: `isempty(multirange: int8multirange) -> bool`,
+	2772: `isempty(multirange: nummultirange) -> bool`,
+	2773: `isempty(multirange: tsmultirange) -> bool`,
+	2774: `isempty(multirange: tstzmultirange) -> bool`,
+	2775: `isempty(multirange: datemultirange) -> bool`,
+	2776: `lower_inc(range: int4range) -> bool`,
+	2777: `lower_inc(range: int8range) -> bool`,
+	2778: `lower_inc(range: numrange) -> bool`,
+	2779: `lower_inc(range: tsrange) -> bool`,
+	2780: `lower_inc(range: tstzrange) -> bool`,
+	2781: `lower_inc(range: daterange) -> bool`,
+	2782: `lower_inc(multirange: int4multirange) -> bool`,
+	2783: `lower_inc(multirange: int8multirange) -> bool`,
+	2784: `lower_inc(multirange: nummultirange) -> bool`,
+	2785: `lower_inc(multirange: tsmultirange) -> bool`,
+	2786: `lower_inc(multirange: tstzmultirange) -> bool`,
+	2787: `lower_inc(multirange: datemultirange) -> bool`,
+	2788: `upper_inc(range: int4range) -> bool`,
+	2789: `upper_inc(range: int8range) -> bool`,
+	2790: `upper_inc(range: numrange) -> bool`,
+	2791: `upper_inc(range: tsrange) -> bool`,
+	2792: `upper_inc(range: tstzrange) -> bool`,
+	2793: `upper_inc(range: daterange) -> bool`,
+	2794: `upper_inc(multirange: int4multirange) -> bool`,
+	2795: `upper_inc(multirange: int8multirange) -> bool`,
+	2796: `upper_inc(multirange: nummultirange) -> bool`,
+	2797: `upper_inc(multirange: tsmultirange) -> bool`,
+	2798: `upper_inc(multirange: tstzmultirange) -> bool`,
+	2799: `upper_inc(multirange: datemultirange) -> bool`,
+	2800: `lower_inf(range: int4range) -> bool`,
+	2801: `lower_inf(range: int8range) -> bool`,
+	2802: `lower_inf(range: numrange) -> bool`,
+	2803: `lower_inf(range: tsrange) -> bool`,
+	2804: `lower_inf(range: tstzrange) -> bool`,
+	2805: `lower_inf(range: daterange) -> bool`,
+	2806: `lower_inf(multirange: int4multirange) -> bool`,
+	2807: `lower_inf(multirange: int8multirange) -> bool`,
+	2808: `lower_inf(multirange: nummultirange) -> bool`,
+	2809: `lower_inf(multirange: tsmultirange) -> bool`,
+	2810: `lower_inf(multirange: tstzmultirange) -> bool`,
+	2811: `lower_inf(multirange: datemultirange) -> bool`,
+	2812: `upper_inf(range: int4range) -> bool`,
+	2813: `upper_inf(range: int8range) -> bool`,
+	2814: `upper_inf(range: numrange) -> bool`,
+	2815: `upper_inf(range: tsrange) -> bool`,
+	2816: `upper_inf(range: tstzrange) -> bool`,
+	2817: `upper_inf(range: daterange) -> bool`,
+	2818: `upper_inf(multirange: int4multirange) -> bool`,
+	2819: `upper_inf(multirange: int8multirange) -> bool`,
+	2820: `upper_inf(multirange: nummultirange) -> bool`,
+	2821: `upper_inf(multirange: tsmultirange) -> bool`,
+	2822: `upper_inf(multirange: tstzmultirange) -> bool`,
+	2823: `upper_inf(multirange: datemultirange) -> bool`,
+	2824: `lower(range: int4range) -> int4`,
+	2825: `lower(range: int8range) -> int`,
+	2826: `lower(range: numrange) -> decimal`,
+	2827: `lower(range: tsrange) -> timestamp`,
+	2828: `lower(range: tstzrange) -> timestamptz`,
+	2829: `lower(range: daterange) -> date`,
+	2830: `lower(multirange: int4multirange) -> int4`,
+	2831: `lower(multirange: int8multirange) -> int`,
+	2832: `lower(multirange: nummultirange) -> decimal`,
+	2833: `lower(multirange: tsmultirange) -> timestamp`,
+	2834: `lower(multirange: tstzmultirange) -> timestamptz`,
+	2835: `lower(multirange: datemultirange) -> date`,
+	2836: `upper(range: int4range) -> int4`,
+	2837: `upper(range: int8range) -> int`,
+	2838: `upper(range: numrange) -> decimal`,
+	2839: `upper(range: tsrange) -> timestamp`,
+	2840: `upper(range: tstzrange) -> timestamptz`,
+	2841: `upper(range: daterange) -> date`,
+	2842: `upper(multirange: int4multirange) -> int4`,
+	2843: `upper(multirange: int8multirange) -> int`,
+	2844: `upper(multirange: nummultirange) -> decimal`,
+	2845: `upper(multirange: tsmultirange) -> timestamp`,
+	2846: `upper(multirange: tstzmultirange) -> timestamptz`,
+	2847: `upper(multirange: datemultirange) -> date`,
+	2848: `brin_summarize_new_values(index: string) -> int`,
 }
 
 var builtinOidsBySignature map[string]oid.Oid
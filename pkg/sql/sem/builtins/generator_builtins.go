@@ -258,6 +258,20 @@ var generators = map[string]builtinDefinition{
 			volatility.Immutable,
 		),
 	),
+	// See https://www.postgresql.org/docs/current/functions-info.html.
+	"pg_listening_channels": makeBuiltin(
+		tree.FunctionProperties{
+			Category:         builtinconstants.CategoryGenerator,
+			DistsqlBlocklist: true, // the channels are session state
+		},
+		makeGeneratorOverload(
+			tree.ParamTypes{},
+			types.String,
+			makeListeningChannelsGenerator,
+			"Returns the names of the channels the current session is listening on.",
+			volatility.Stable,
+		),
+	),
 	`pg_options_to_table`: makeBuiltin(
 		genProps(),
 		makeGeneratorOverload(
@@ -1233,6 +1247,20 @@ func makeWorkloadIndexRecsGeneratorFactory(hasTimestamp bool) eval.GeneratorOver
 	}
 }
 
+func makeListeningChannelsGenerator(
+	_ context.Context, evalCtx *eval.Context, _ tree.Datums,
+) (eval.ValueGenerator, error) {
+	arr := tree.NewDArray(types.String)
+	if evalCtx.Notifications != nil {
+		for _, channel := range evalCtx.Notifications.ListeningChannels(evalCtx.SessionData().Database) {
+			if err := arr.Append(tree.NewDString(channel)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &arrayValueGenerator{array: arr}, nil
+}
+
 func makeArrayGenerator(
 	_ context.Context, _ *eval.Context, args tree.Datums,
 ) (eval.ValueGenerator, error) {
@@ -0,0 +1,267 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
+)
+
+func init() {
+	for k, v := range jsonpathBuiltins {
+		v.props.Category = builtinconstants.CategoryJSON
+		// Most builtins in this file are of the Normal class, but
+		// jsonb_path_query is of the Generator class.
+		const enforceClass = false
+		registerBuiltin(k, v, tree.NormalClass, enforceClass)
+	}
+}
+
+// jsonPathParamTypes are the parameters of the jsonb_path_* builtins. The
+// trailing vars and silent parameters are optional.
+var jsonPathParamTypes = tree.ParamTypes{
+	{Name: "target", Typ: types.Jsonb},
+	{Name: "path", Typ: types.JSONPath},
+	{Name: "vars", Typ: types.Jsonb},
+	{Name: "silent", Typ: types.Bool},
+}
+
+// minJSONPathParams is the number of required parameters of the jsonb_path_*
+// builtins.
+const minJSONPathParams = 2
+
+const jsonPathArgsInfo = " If vars is specified, it must be an object whose fields provide " +
+	"the values of the named variables in the path. If silent is true, the function " +
+	"suppresses the same errors as the @? and @@ operators do."
+
+// jsonPathArgs extracts the arguments of a jsonb_path_* builtin, filling in
+// the defaults of the optional ones.
+func jsonPathArgs(
+	args tree.Datums,
+) (target json.JSON, path *tree.DJSONPath, vars json.JSON, silent bool) {
+	target = tree.MustBeDJSON(args[0]).JSON
+	path = tree.MustBeDJSONPath(args[1])
+	if len(args) > 2 {
+		vars = tree.MustBeDJSON(args[2]).JSON
+	}
+	if len(args) > 3 {
+		silent = bool(tree.MustBeDBool(args[3]))
+	}
+	return target, path, vars, silent
+}
+
+// evalJSONPath returns the items of the path evaluated against the target. If
+// silent is true, suppressible errors result in no items being returned.
+func evalJSONPath(
+	target json.JSON, path *tree.DJSONPath, vars json.JSON, silent bool,
+) ([]json.JSON, error) {
+	items, err := path.Eval(target, vars)
+	if err != nil {
+		if silent && jsonpath.IsSuppressibleError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return items, nil
+}
+
+// makeJSONPathOverloads returns an overload of a jsonb_path_* builtin for each
+// number of optional parameters.
+func makeJSONPathOverloads(
+	retType *types.T,
+	fn func(target json.JSON, path *tree.DJSONPath, vars json.JSON, silent bool) (tree.Datum, error),
+	info string,
+) []tree.Overload {
+	overloads := make([]tree.Overload, 0, len(jsonPathParamTypes)-minJSONPathParams+1)
+	for n := minJSONPathParams; n <= len(jsonPathParamTypes); n++ {
+		overloads = append(overloads, tree.Overload{
+			Types:      jsonPathParamTypes[:n],
+			ReturnType: tree.FixedReturnType(retType),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return fn(jsonPathArgs(args))
+			},
+			Info:       info + jsonPathArgsInfo,
+			Volatility: volatility.Immutable,
+		})
+	}
+	return overloads
+}
+
+var jsonpathBuiltins = map[string]builtinDefinition{
+	"jsonb_path_exists": makeBuiltin(tree.FunctionProperties{},
+		makeJSONPathOverloads(
+			types.Bool,
+			func(target json.JSON, path *tree.DJSONPath, vars json.JSON, silent bool) (tree.Datum, error) {
+				exists, ok, err := path.Exists(target, vars, silent)
+				if err != nil || !ok {
+					return tree.DNull, err
+				}
+				return tree.MakeDBool(tree.DBool(exists)), nil
+			},
+			"Checks whether the JSON path returns any item for the specified JSON value.",
+		)...,
+	),
+	"jsonb_path_exists_opr": makeBuiltin(tree.FunctionProperties{},
+		tree.Overload{
+			Types:      jsonPathParamTypes[:minJSONPathParams],
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return eval.BinaryOp(ctx, evalCtx, &tree.JSONPathExistsOp{}, args[0], args[1])
+			},
+			Info:       "Implementation of the @? operator.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"jsonb_path_match": makeBuiltin(tree.FunctionProperties{},
+		makeJSONPathOverloads(
+			types.Bool,
+			func(target json.JSON, path *tree.DJSONPath, vars json.JSON, silent bool) (tree.Datum, error) {
+				match, ok, err := path.Match(target, vars, silent)
+				if err != nil || !ok {
+					return tree.DNull, err
+				}
+				return tree.MakeDBool(tree.DBool(match)), nil
+			},
+			"Returns the result of a JSON path predicate check for the specified JSON value. "+
+				"Only the first item of the result is taken into account. If the result is not "+
+				"Boolean, then NULL is returned.",
+		)...,
+	),
+	"jsonb_path_match_opr": makeBuiltin(tree.FunctionProperties{},
+		tree.Overload{
+			Types:      jsonPathParamTypes[:minJSONPathParams],
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return eval.BinaryOp(ctx, evalCtx, &tree.JSONPathMatchOp{}, args[0], args[1])
+			},
+			Info:       "Implementation of the @@ operator for jsonpath.",
+			Volatility: volatility.Immutable,
+		},
+	),
+	"jsonb_path_query": makeBuiltin(tree.FunctionProperties{},
+		makeJSONPathQueryGeneratorOverloads()...,
+	),
+	"jsonb_path_query_array": makeBuiltin(tree.FunctionProperties{},
+		makeJSONPathOverloads(
+			types.Jsonb,
+			func(target json.JSON, path *tree.DJSONPath, vars json.JSON, silent bool) (tree.Datum, error) {
+				items, err := evalJSONPath(target, path, vars, silent)
+				if err != nil {
+					return nil, err
+				}
+				b := json.NewArrayBuilder(len(items))
+				for _, item := range items {
+					b.Add(item)
+				}
+				return tree.NewDJSON(b.Build()), nil
+			},
+			"Returns all JSON items returned by the JSON path for the specified JSON value, "+
+				"as a JSON array.",
+		)...,
+	),
+	"jsonb_path_query_first": makeBuiltin(tree.FunctionProperties{},
+		makeJSONPathOverloads(
+			types.Jsonb,
+			func(target json.JSON, path *tree.DJSONPath, vars json.JSON, silent bool) (tree.Datum, error) {
+				items, err := evalJSONPath(target, path, vars, silent)
+				if err != nil || len(items) == 0 {
+					return tree.DNull, err
+				}
+				return tree.NewDJSON(items[0]), nil
+			},
+			"Returns the first JSON item returned by the JSON path for the specified JSON value. "+
+				"Returns NULL if there are no results.",
+		)...,
+	),
+}
+
+func makeJSONPathQueryGeneratorOverloads() []tree.Overload {
+	overloads := make([]tree.Overload, 0, len(jsonPathParamTypes)-minJSONPathParams+1)
+	for n := minJSONPathParams; n <= len(jsonPathParamTypes); n++ {
+		overloads = append(overloads, makeGeneratorOverload(
+			jsonPathParamTypes[:n],
+			types.Jsonb,
+			makeJSONPathQueryGenerator,
+			"Returns all JSON items returned by the JSON path for the specified JSON value."+
+				jsonPathArgsInfo,
+			volatility.Immutable,
+		))
+	}
+	return overloads
+}
+
+// jsonPathQueryGenerator is a value generator returning the items of a JSON
+// path evaluated against a JSON value.
+type jsonPathQueryGenerator struct {
+	target json.JSON
+	path   *tree.DJSONPath
+	vars   json.JSON
+	silent bool
+
+	items []json.JSON
+	buf   [1]tree.Datum
+}
+
+var _ eval.ValueGenerator = &jsonPathQueryGenerator{}
+
+func makeJSONPathQueryGenerator(
+	_ context.Context, _ *eval.Context, args tree.Datums,
+) (eval.ValueGenerator, error) {
+	target, path, vars, silent := jsonPathArgs(args)
+	return &jsonPathQueryGenerator{
+		target: target,
+		path:   path,
+		vars:   vars,
+		silent: silent,
+	}, nil
+}
+
+// ResolvedType implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) ResolvedType() *types.T {
+	return types.Jsonb
+}
+
+// Start implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Start(_ context.Context, _ *kv.Txn) error {
+	items, err := evalJSONPath(g.target, g.path, g.vars, g.silent)
+	if err != nil {
+		return err
+	}
+	g.items = items
+	g.buf[0] = nil
+	return nil
+}
+
+// Next implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Next(_ context.Context) (bool, error) {
+	if len(g.items) == 0 {
+		return false, nil
+	}
+	g.buf[0] = tree.NewDJSON(g.items[0])
+	g.items = g.items[1:]
+	return true, nil
+}
+
+// Values implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Values() (tree.Datums, error) {
+	return g.buf[:], nil
+}
+
+// Close implements the eval.ValueGenerator interface.
+func (g *jsonPathQueryGenerator) Close(_ context.Context) {}
@@ -149,6 +149,11 @@ func init() {
 		if !ok {
 			return
 		}
+		if toType.Family() == types.RangeFamily || toType.Family() == types.MultirangeFamily {
+			// Range and multirange types have constructor functions with the same
+			// name as the type, which are defined in range_builtins.go.
+			return
+		}
 		distSQLBlockList := toType.Family() == types.OidFamily
 		if _, ok := castBuiltins[toOID]; !ok {
 			castBuiltins[toOID] = &builtinDefinition{
@@ -881,6 +886,45 @@ var pgBuiltins = map[string]builtinDefinition{
 		},
 	),
 
+	// pg_notify sends a notification on a channel, like NOTIFY.
+	// https://www.postgresql.org/docs/current/functions-info.html
+	"pg_notify": makeBuiltin(
+		tree.FunctionProperties{
+			DistsqlBlocklist: true, // the notification is sent by the session
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "channel", Typ: types.String},
+				{Name: "payload", Typ: types.String},
+			},
+			ReturnType:        tree.FixedReturnType(types.Void),
+			CalledOnNullInput: true,
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if evalCtx.Notifications == nil {
+					return nil, pgerror.New(pgcode.FeatureNotSupported,
+						"pg_notify is not supported in this context")
+				}
+				if args[0] == tree.DNull {
+					return nil, pgerror.New(pgcode.InvalidParameterValue,
+						"channel name cannot be empty")
+				}
+				var payload string
+				if args[1] != tree.DNull {
+					payload = string(tree.MustBeDString(args[1]))
+				}
+				if err := evalCtx.Notifications.QueueNotification(
+					evalCtx.SessionData().Database, string(tree.MustBeDString(args[0])), payload,
+				); err != nil {
+					return nil, err
+				}
+				return tree.DVoidDatum, nil
+			},
+			Info: "Sends a notification with the given payload on the given " +
+				"channel, like the NOTIFY statement.",
+			Volatility: volatility.Volatile,
+		},
+	),
+
 	// pg_my_temp_schema returns the OID of session's temporary schema, or 0 if
 	// none.
 	// https://www.postgresql.org/docs/11/functions-info.html
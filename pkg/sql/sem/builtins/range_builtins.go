@@ -0,0 +1,279 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+func init() {
+	const enforceClass = true
+	for k, v := range rangeBuiltins {
+		v.props.Category = builtinconstants.CategoryRange
+		registerBuiltin(k, v, tree.NormalClass, enforceClass)
+	}
+
+	// Each range and multirange type has a constructor function with the same
+	// name as the type.
+	for i, typ := range types.RangeTypes {
+		registerBuiltin(typ.Name(), makeBuiltin(
+			tree.FunctionProperties{Category: builtinconstants.CategoryRange},
+			makeRangeConstructorOverloads(typ)...,
+		), tree.NormalClass, enforceClass)
+		mrTyp := types.MultirangeTypes[i]
+		registerBuiltin(mrTyp.Name(), makeBuiltin(
+			tree.FunctionProperties{Category: builtinconstants.CategoryRange},
+			makeMultirangeConstructorOverload(mrTyp),
+		), tree.NormalClass, enforceClass)
+	}
+}
+
+var rangeBuiltins = map[string]builtinDefinition{
+	"isempty": makeBuiltin(tree.FunctionProperties{},
+		makeRangePropertyOverloads(
+			func(r *tree.DRange) bool { return r.Empty },
+			func(mr *tree.DMultirange) bool { return len(mr.Ranges) == 0 },
+			"Returns whether the %s is empty.",
+		)...,
+	),
+	"lower_inc": makeBuiltin(tree.FunctionProperties{},
+		makeRangePropertyOverloads(
+			func(r *tree.DRange) bool { return r.LowerInc },
+			func(mr *tree.DMultirange) bool { return len(mr.Ranges) > 0 && mr.Ranges[0].LowerInc },
+			"Returns whether the lower bound of the %s is inclusive.",
+		)...,
+	),
+	"upper_inc": makeBuiltin(tree.FunctionProperties{},
+		makeRangePropertyOverloads(
+			func(r *tree.DRange) bool { return r.UpperInc },
+			func(mr *tree.DMultirange) bool {
+				return len(mr.Ranges) > 0 && mr.Ranges[len(mr.Ranges)-1].UpperInc
+			},
+			"Returns whether the upper bound of the %s is inclusive.",
+		)...,
+	),
+	"lower_inf": makeBuiltin(tree.FunctionProperties{},
+		makeRangePropertyOverloads(
+			func(r *tree.DRange) bool { return !r.Empty && r.Lower == nil },
+			func(mr *tree.DMultirange) bool { return len(mr.Ranges) > 0 && mr.Ranges[0].Lower == nil },
+			"Returns whether the %s has no lower bound.",
+		)...,
+	),
+	"upper_inf": makeBuiltin(tree.FunctionProperties{},
+		makeRangePropertyOverloads(
+			func(r *tree.DRange) bool { return !r.Empty && r.Upper == nil },
+			func(mr *tree.DMultirange) bool {
+				return len(mr.Ranges) > 0 && mr.Ranges[len(mr.Ranges)-1].Upper == nil
+			},
+			"Returns whether the %s has no upper bound.",
+		)...,
+	),
+}
+
+// makeRangePropertyOverloads returns an overload for each range and multirange
+// type that returns a boolean property of its argument. The info string is
+// formatted with "range" or "multirange".
+func makeRangePropertyOverloads(
+	rangeFn func(*tree.DRange) bool, multirangeFn func(*tree.DMultirange) bool, info string,
+) []tree.Overload {
+	overloads := make([]tree.Overload, 0, len(types.RangeTypes)+len(types.MultirangeTypes))
+	for _, typ := range types.RangeTypes {
+		overloads = append(overloads, tree.Overload{
+			Types:      tree.ParamTypes{{Name: "range", Typ: typ}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.MakeDBool(tree.DBool(rangeFn(tree.MustBeDRange(args[0])))), nil
+			},
+			Info:       fmt.Sprintf(info, "range"),
+			Volatility: volatility.Immutable,
+		})
+	}
+	for _, typ := range types.MultirangeTypes {
+		overloads = append(overloads, tree.Overload{
+			Types:      tree.ParamTypes{{Name: "multirange", Typ: typ}},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.MakeDBool(tree.DBool(multirangeFn(tree.MustBeDMultirange(args[0])))), nil
+			},
+			Info:       fmt.Sprintf(info, "multirange"),
+			Volatility: volatility.Immutable,
+		})
+	}
+	return overloads
+}
+
+// withRangeBoundOverloads returns the given string overload of the lower or
+// upper builtin followed by overloads for each range and multirange type, which
+// return the lower or upper bound of their argument. The string overload is
+// preferred so that an argument of unknown type, such as a placeholder, still
+// resolves to it.
+func withRangeBoundOverloads(lower bool, stringOverload tree.Overload) []tree.Overload {
+	stringOverload.PreferredOverload = true
+	which := "upper"
+	if lower {
+		which = "lower"
+	}
+	boundOf := func(r *tree.DRange) tree.Datum {
+		b := r.Upper
+		if lower {
+			b = r.Lower
+		}
+		if b == nil {
+			// The empty range and unbounded ranges have no bound.
+			return tree.DNull
+		}
+		return b
+	}
+	overloads := make([]tree.Overload, 0, 1+len(types.RangeTypes)+len(types.MultirangeTypes))
+	overloads = append(overloads, stringOverload)
+	for _, typ := range types.RangeTypes {
+		overloads = append(overloads, tree.Overload{
+			Types:      tree.ParamTypes{{Name: "range", Typ: typ}},
+			ReturnType: tree.FixedReturnType(typ.RangeSubtype()),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return boundOf(tree.MustBeDRange(args[0])), nil
+			},
+			Info:       fmt.Sprintf("Returns the %s bound of the range.", which),
+			Volatility: volatility.Immutable,
+		})
+	}
+	for _, typ := range types.MultirangeTypes {
+		overloads = append(overloads, tree.Overload{
+			Types:      tree.ParamTypes{{Name: "multirange", Typ: typ}},
+			ReturnType: tree.FixedReturnType(types.RangeOfMultirange(typ).RangeSubtype()),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				mr := tree.MustBeDMultirange(args[0])
+				if len(mr.Ranges) == 0 {
+					return tree.DNull, nil
+				}
+				if lower {
+					return boundOf(mr.Ranges[0]), nil
+				}
+				return boundOf(mr.Ranges[len(mr.Ranges)-1]), nil
+			},
+			Info:       fmt.Sprintf("Returns the %s bound of the multirange.", which),
+			Volatility: volatility.Immutable,
+		})
+	}
+	return overloads
+}
+
+// makeRangeConstructorOverloads returns the overloads of the constructor
+// function of the given range type.
+func makeRangeConstructorOverloads(typ *types.T) []tree.Overload {
+	subtype := typ.RangeSubtype()
+	construct := func(evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+		lowerInc, upperInc := true, false
+		if len(args) > 2 {
+			if args[2] == tree.DNull {
+				return nil, pgerror.New(pgcode.DataException,
+					"range constructor flags argument must not be null")
+			}
+			var err error
+			lowerInc, upperInc, err = parseRangeBoundFlags(string(tree.MustBeDString(args[2])))
+			if err != nil {
+				return nil, err
+			}
+		}
+		// A NULL bound makes the range unbounded on that side.
+		var lower, upper tree.Datum
+		if args[0] != tree.DNull {
+			lower = args[0]
+		}
+		if args[1] != tree.DNull {
+			upper = args[1]
+		}
+		r, err := tree.NewDRange(evalCtx, typ, lower, upper, lowerInc, upperInc)
+		if err != nil {
+			return nil, err
+		}
+		return r, nil
+	}
+	return []tree.Overload{
+		{
+			Types:      tree.ParamTypes{{Name: "lower", Typ: subtype}, {Name: "upper", Typ: subtype}},
+			ReturnType: tree.FixedReturnType(typ),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return construct(evalCtx, args)
+			},
+			CalledOnNullInput: true,
+			Info: "Constructs a range with an inclusive lower bound and an exclusive upper " +
+				"bound. A NULL bound makes the range unbounded on that side.",
+			Volatility: volatility.Immutable,
+		},
+		{
+			Types: tree.ParamTypes{
+				{Name: "lower", Typ: subtype},
+				{Name: "upper", Typ: subtype},
+				{Name: "bounds", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(typ),
+			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return construct(evalCtx, args)
+			},
+			CalledOnNullInput: true,
+			Info: "Constructs a range with the given bounds. The bounds argument must be one " +
+				"of `[]`, `[)`, `(]` or `()`, where a bracket indicates an inclusive bound and a " +
+				"parenthesis an exclusive one. A NULL bound makes the range unbounded on that side.",
+			Volatility: volatility.Immutable,
+		},
+	}
+}
+
+// makeMultirangeConstructorOverload returns the overload of the constructor
+// function of the given multirange type, which takes any number of ranges.
+func makeMultirangeConstructorOverload(typ *types.T) tree.Overload {
+	return tree.Overload{
+		Types:      tree.VariadicType{VarType: types.RangeOfMultirange(typ)},
+		ReturnType: tree.FixedReturnType(typ),
+		Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+			ranges := make([]*tree.DRange, len(args))
+			for i, arg := range args {
+				if arg == tree.DNull {
+					return nil, pgerror.New(pgcode.NullValueNotAllowed,
+						"multirange values cannot contain null members")
+				}
+				ranges[i] = tree.MustBeDRange(arg)
+			}
+			mr, err := tree.NewDMultirange(evalCtx, typ, ranges)
+			if err != nil {
+				return nil, err
+			}
+			return mr, nil
+		},
+		CalledOnNullInput: true,
+		Info: "Constructs a multirange containing the given ranges. Overlapping and " +
+			"adjacent ranges are merged.",
+		Volatility: volatility.Immutable,
+	}
+}
+
+// parseRangeBoundFlags parses the bounds argument of a range constructor, such
+// as "[)", into whether the lower and upper bounds are inclusive.
+func parseRangeBoundFlags(s string) (lowerInc, upperInc bool, _ error) {
+	if len(s) == 2 && (s[0] == '[' || s[0] == '(') && (s[1] == ']' || s[1] == ')') {
+		return s[0] == '[', s[1] == ']', nil
+	}
+	return false, false, errors.WithHint(
+		pgerror.New(pgcode.Syntax, "invalid range bound flags"),
+		`Valid values are "[]", "[)", "(]", and "()".`,
+	)
+}
@@ -96,9 +96,12 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSVector),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
 				// Parse, stem, and stopword the input.
-				config := string(tree.MustBeDString(args[0]))
+				config, err := resolveTextSearchConfig(ctx, evalCtx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
 				document := string(tree.MustBeDString(args[1]))
 				vector, err := tsearch.DocumentToTSVector(config, document)
 				if err != nil {
@@ -114,7 +117,10 @@ var tsearchBuiltins = map[string]builtinDefinition{
 			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSVector),
 			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig)
+				config, err := tsearch.BuiltinConfig(tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig))
+				if err != nil {
+					return nil, err
+				}
 				document := string(tree.MustBeDString(args[0]))
 				vector, err := tsearch.DocumentToTSVector(config, document)
 				if err != nil {
@@ -132,8 +138,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := string(tree.MustBeDString(args[0]))
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := resolveTextSearchConfig(ctx, evalCtx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[1]))
 				query, err := tsearch.ToTSQuery(config, input)
 				if err != nil {
@@ -150,7 +159,10 @@ var tsearchBuiltins = map[string]builtinDefinition{
 			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
 			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig)
+				config, err := tsearch.BuiltinConfig(tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig))
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[0]))
 				query, err := tsearch.ToTSQuery(config, input)
 				if err != nil {
@@ -169,8 +181,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := string(tree.MustBeDString(args[0]))
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := resolveTextSearchConfig(ctx, evalCtx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[1]))
 				query, err := tsearch.PlainToTSQuery(config, input)
 				if err != nil {
@@ -186,7 +201,10 @@ var tsearchBuiltins = map[string]builtinDefinition{
 			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
 			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig)
+				config, err := tsearch.BuiltinConfig(tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig))
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[0]))
 				query, err := tsearch.PlainToTSQuery(config, input)
 				if err != nil {
@@ -204,8 +222,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := string(tree.MustBeDString(args[0]))
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := resolveTextSearchConfig(ctx, evalCtx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[1]))
 				query, err := tsearch.PhraseToTSQuery(config, input)
 				if err != nil {
@@ -221,7 +242,10 @@ var tsearchBuiltins = map[string]builtinDefinition{
 			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
 			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig)
+				config, err := tsearch.BuiltinConfig(tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig))
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[0]))
 				query, err := tsearch.PhraseToTSQuery(config, input)
 				if err != nil {
@@ -348,3 +372,17 @@ func getWeights(arr *tree.DArray) ([]float32, error) {
 	}
 	return ret, nil
 }
+
+// resolveTextSearchConfig returns the text search configuration with the given
+// name, which is either a built-in configuration or a user-defined one.
+func resolveTextSearchConfig(
+	ctx context.Context, evalCtx *eval.Context, name string,
+) (*tsearch.Config, error) {
+	config, err := tsearch.BuiltinConfig(tsearch.GetConfigKey(name))
+	if err == nil || evalCtx.CatalogBuiltins == nil {
+		return config, err
+	}
+	return evalCtx.CatalogBuiltins.ResolveTextSearchConfig(
+		ctx, name, evalCtx.SessionData().Database, evalCtx.SessionData().SearchPath,
+	)
+}
@@ -15,6 +15,8 @@
 package cast
 
 import (
+	"context"
+
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/lib/pq/oid"
@@ -101,6 +103,10 @@ const (
 	// Postgres, but are supported by CockroachDB and continue to be supported
 	// for backwards compatibility.
 	ContextOriginLegacyConversion
+	// ContextOriginUserDefined is used for casts created with CREATE CAST.
+	// They are not in castMap. Instead, they are stored in the catalog and
+	// looked up with a UserDefinedResolver.
+	ContextOriginUserDefined
 )
 
 // Cast includes details about a cast from one OID to another.
@@ -130,6 +136,40 @@ type Cast struct {
 	// set, it is used as an error hint suggesting a possible workaround when
 	// stable casts are not allowed.
 	VolatilityHint string
+	// Func is the OID of the function which performs a user-defined cast. It
+	// is unset for built-in casts.
+	Func oid.Oid
+}
+
+// MakeUserDefinedCast returns a cast created with CREATE CAST, which is
+// performed by calling the function with the given OID.
+func MakeUserDefinedCast(maxContext Context, v volatility.V, fn oid.Oid) Cast {
+	return Cast{
+		MaxContext: maxContext,
+		origin:     ContextOriginUserDefined,
+		Volatility: v,
+		Func:       fn,
+	}
+}
+
+// UserDefinedResolver looks up the casts created with CREATE CAST.
+type UserDefinedResolver interface {
+	// LookupUserDefinedCast returns the user-defined cast from src to tgt. ok
+	// is false if there is no such cast.
+	LookupUserDefinedCast(ctx context.Context, src, tgt *types.T) (_ Cast, ok bool, _ error)
+}
+
+// LookupUserDefinedCast returns the user-defined cast from src to tgt if it
+// exists. User-defined casts must convert to or from a user-defined type, so
+// the resolver is only consulted for those. The resolver may be nil, in which
+// case no user-defined cast is found.
+func LookupUserDefinedCast(
+	ctx context.Context, r UserDefinedResolver, src, tgt *types.T,
+) (_ Cast, ok bool, _ error) {
+	if r == nil || src.Oid() == tgt.Oid() || !(src.UserDefined() || tgt.UserDefined()) {
+		return Cast{}, false, nil
+	}
+	return r.LookupUserDefinedCast(ctx, src, tgt)
 }
 
 // ForEachCast calls fn for every valid cast from a source type to a target
@@ -213,6 +253,27 @@ func LookupCast(src, tgt *types.T) (Cast, bool) {
 		}, true
 	}
 
+	// Domains have dynamic OIDs, so they can't be populated in castMap. A cast
+	// from a domain is a cast from its base type, and a cast to a domain is a
+	// cast to its base type followed by a check of the domain's constraints.
+	// Like in Postgres, values are never implicitly cast to a domain.
+	if src.IsDomain() && src.Oid() == tgt.Oid() {
+		return Cast{
+			MaxContext: ContextImplicit,
+			Volatility: volatility.Immutable,
+		}, true
+	}
+	if src.IsDomain() {
+		return LookupCast(src.DomainBaseType(), tgt)
+	}
+	if tgt.IsDomain() {
+		c, ok := LookupCast(src, tgt.DomainBaseType())
+		if ok && c.MaxContext == ContextImplicit {
+			c.MaxContext = ContextAssignment
+		}
+		return c, ok
+	}
+
 	// Enums have dynamic OIDs, so they can't be populated in castMap. Instead,
 	// we dynamically create cast structs for valid enum casts.
 	if srcFamily == types.EnumFamily && tgtFamily == types.StringFamily {
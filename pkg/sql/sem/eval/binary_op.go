@@ -570,6 +570,30 @@ func (e *evaluator) EvalJSONAllExistsOp(
 	return tree.DBoolTrue, nil
 }
 
+func (e *evaluator) EvalJSONPathExistsOp(
+	ctx context.Context, _ *tree.JSONPathExistsOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	exists, ok, err := tree.MustBeDJSONPath(b).Exists(
+		tree.MustBeDJSON(a).JSON, nil /* vars */, true, /* silent */
+	)
+	if err != nil || !ok {
+		return tree.DNull, err
+	}
+	return tree.MakeDBool(tree.DBool(exists)), nil
+}
+
+func (e *evaluator) EvalJSONPathMatchOp(
+	ctx context.Context, _ *tree.JSONPathMatchOp, a, b tree.Datum,
+) (tree.Datum, error) {
+	match, ok, err := tree.MustBeDJSONPath(b).Match(
+		tree.MustBeDJSON(a).JSON, nil /* vars */, true, /* silent */
+	)
+	if err != nil || !ok {
+		return tree.DNull, err
+	}
+	return tree.MakeDBool(tree.DBool(match)), nil
+}
+
 func (e *evaluator) EvalJSONExistsOp(
 	ctx context.Context, _ *tree.JSONExistsOp, a, b tree.Datum,
 ) (tree.Datum, error) {
@@ -1211,6 +1235,34 @@ func (e *evaluator) EvalOverlapsINetOp(
 	return tree.MakeDBool(tree.DBool(ipAddr.ContainsOrContainedBy(&other))), nil
 }
 
+func (e *evaluator) EvalRangeContainsOp(
+	ctx context.Context, _ *tree.RangeContainsOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	ok, err := tree.RangeContains(e.ctx(), left, right)
+	return tree.MakeDBool(tree.DBool(ok)), err
+}
+
+func (e *evaluator) EvalRangeContainedByOp(
+	ctx context.Context, _ *tree.RangeContainedByOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	ok, err := tree.RangeContains(e.ctx(), right, left)
+	return tree.MakeDBool(tree.DBool(ok)), err
+}
+
+func (e *evaluator) EvalRangeOverlapsOp(
+	ctx context.Context, _ *tree.RangeOverlapsOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	ok, err := tree.RangeOverlaps(e.ctx(), left, right)
+	return tree.MakeDBool(tree.DBool(ok)), err
+}
+
+func (e *evaluator) EvalRangeAdjacentOp(
+	ctx context.Context, _ *tree.RangeAdjacentOp, left, right tree.Datum,
+) (tree.Datum, error) {
+	ok, err := tree.RangeAdjacent(e.ctx(), left, right)
+	return tree.MakeDBool(tree.DBool(ok)), err
+}
+
 func (e *evaluator) EvalTSMatchesQueryVectorOp(
 	ctx context.Context, _ *tree.TSMatchesQueryVectorOp, left, right tree.Datum,
 ) (tree.Datum, error) {
@@ -105,7 +105,15 @@ func performCast(
 	if err != nil {
 		return nil, err
 	}
-	return tree.AdjustValueToType(t, d)
+	if d, err = tree.AdjustValueToType(t, d); err != nil {
+		return nil, err
+	}
+	if t.IsDomain() {
+		if err := CheckDomainConstraints(ctx, evalCtx, d, t); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
 }
 
 var (
@@ -460,7 +468,7 @@ func performCastWithoutPrecisionTruncation(
 				tree.FmtDataConversionConfig(evalCtx.SessionData().DataConversionConfig),
 				tree.FmtLocation(evalCtx.GetLocation()),
 			)
-		case *tree.DArray:
+		case *tree.DArray, *tree.DRange, *tree.DMultirange:
 			s = tree.AsStringWithFlags(
 				d,
 				tree.FmtPgwireText,
@@ -500,6 +508,8 @@ func performCastWithoutPrecisionTruncation(
 			s = t.JSON.String()
 		case *tree.DTSQuery:
 			s = t.TSQuery.String()
+		case *tree.DJSONPath:
+			s = t.Path.String()
 		case *tree.DTSVector:
 			s = t.TSVector.String()
 		case *tree.DEnum:
@@ -920,6 +930,48 @@ func performCastWithoutPrecisionTruncation(
 			}
 			return &tree.DTSVector{TSVector: vec}, nil
 		}
+	case types.JSONPathFamily:
+		if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V24_1) {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to use jsonpath",
+				clusterversion.V24_1.Version())
+		}
+		switch v := d.(type) {
+		case *tree.DString:
+			return tree.ParseDJSONPath(string(*v))
+		}
+	case types.RangeFamily:
+		if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V24_1) {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to use range types",
+				clusterversion.V24_1.Version())
+		}
+		switch v := d.(type) {
+		case *tree.DString:
+			res, _, err := tree.ParseDRangeFromString(evalCtx, string(*v), t)
+			if err != nil {
+				return nil, err
+			}
+			return res, nil
+		case *tree.DRange:
+			return d, nil
+		}
+	case types.MultirangeFamily:
+		if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V24_1) {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to use multirange types",
+				clusterversion.V24_1.Version())
+		}
+		switch v := d.(type) {
+		case *tree.DString:
+			res, _, err := tree.ParseDMultirangeFromString(evalCtx, string(*v), t)
+			if err != nil {
+				return nil, err
+			}
+			return res, nil
+		case *tree.DMultirange:
+			return d, nil
+		}
 	case types.ArrayFamily:
 		switch v := d.(type) {
 		case *tree.DString:
@@ -287,6 +287,15 @@ type Context struct {
 	// during local execution. It may be unset.
 	RoutineSender DeferredRoutineSender
 
+	// DeferredConstraints tracks the deferred checks of deferrable constraints
+	// in the current transaction. It may be unset, in which case all
+	// constraints are checked immediately.
+	DeferredConstraints DeferredConstraints
+
+	// Notifications gives access to the notification channels of the session.
+	// It may be unset, in which case notifications are not supported.
+	Notifications SessionNotifications
+
 	// ULIDEntropy is the entropy source for ULID generation.
 	ULIDEntropy ulid.MonotonicReader
 
@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/rangedesc"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/lib/pq/oid"
 )
 
@@ -134,6 +135,13 @@ type CatalogBuiltins interface {
 		descIDMightExist func(id descpb.ID) bool,
 		nonTerminalJobIDMightExist func(id jobspb.JobID) bool,
 	) ([]byte, error)
+
+	// ResolveTextSearchConfig returns the user-defined text search
+	// configuration with the given, possibly qualified, name. Unqualified names
+	// are resolved in the current database using the search path.
+	ResolveTextSearchConfig(
+		ctx context.Context, name string, currentDatabase string, searchPath sessiondata.SearchPath,
+	) (*tsearch.Config, error)
 }
 
 // HasPrivilegeSpecifier specifies an object to lookup privilege for.
@@ -343,6 +351,11 @@ type Planner interface {
 	// constraint on the table.
 	RevalidateUniqueConstraint(ctx context.Context, tableID int, constraintName string) error
 
+	// SummarizeBRINIndex summarizes the rows of the table which are not yet
+	// summarized by the given BRIN index, and returns the number of block
+	// ranges it summarized.
+	SummarizeBRINIndex(ctx context.Context, indexName string) (int, error)
+
 	// IsConstraintActive returns if a given constraint is currently active,
 	// for the current transaction.
 	IsConstraintActive(ctx context.Context, tableID int, constraintName string) (bool, error)
@@ -564,6 +577,39 @@ type DeferredRoutineSender interface {
 	SendDeferredRoutine(nestedRoutine *tree.RoutineExpr, args tree.Datums)
 }
 
+// DeferredConstraints tracks the deferrable constraints whose checks have been
+// deferred until the end of the current transaction.
+type DeferredConstraints interface {
+	// IsDeferred returns true if checking of the deferrable constraint with the
+	// given name on the given table is currently deferred. initiallyDeferred is
+	// the default mode of the constraint, which may be overridden with SET
+	// CONSTRAINTS.
+	IsDeferred(tableID catid.DescID, name string, initiallyDeferred bool) bool
+
+	// AddPendingCheck records that a row violated the constraint with the given
+	// name on the given table, and must be checked again before the transaction
+	// commits. keyVals are the values of the constraint columns of the row.
+	// referenced is true if the row is a referenced row of a foreign key
+	// constraint, which was updated or deleted. An error is returned if the
+	// check cannot be recorded, for example because of the session's memory
+	// limit.
+	AddPendingCheck(
+		ctx context.Context, tableID catid.DescID, name string, keyVals tree.Datums, referenced bool,
+	) error
+}
+
+// SessionNotifications gives access to the notification channels of the
+// current session, which are used by LISTEN and NOTIFY.
+type SessionNotifications interface {
+	// QueueNotification queues a notification on the given channel of the
+	// given database, which is sent when the current transaction commits.
+	QueueNotification(database, channel, payload string) error
+
+	// ListeningChannels returns the names of the channels the session listens
+	// on in the given database.
+	ListeningChannels(database string) []string
+}
+
 // PrivilegedAccessor gives access to certain queries that would otherwise
 // require someone with RootUser access to query a given data source.
 // It is defined independently to prevent a circular dependency on sql, tree and sqlbase.
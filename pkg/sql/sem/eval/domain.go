@@ -0,0 +1,92 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package eval
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// CheckDomainConstraints returns an error if the given value, which must
+// already have been cast to the base type of the given domain type, violates
+// the NOT NULL or CHECK constraints of the domain.
+//
+// Like in Postgres, a NULL value satisfies all CHECK constraints.
+func CheckDomainConstraints(
+	ctx context.Context, evalCtx *Context, d tree.Datum, t *types.T,
+) error {
+	dd := t.TypeMeta.DomainData
+	if dd == nil {
+		return errors.AssertionFailedf("domain type %s is not hydrated", t.SQLStringForError())
+	}
+	if d == tree.DNull {
+		if dd.NotNull {
+			return pgerror.Newf(pgcode.NotNullViolation,
+				"domain %s does not allow null values", t.Name())
+		}
+		return nil
+	}
+	if len(dd.CheckExprs) == 0 {
+		return nil
+	}
+	evalCtx.PushIVarContainer(&domainValueContainer{value: d, typ: t.DomainBaseType()})
+	defer evalCtx.PopIVarContainer()
+	for i, exprStr := range dd.CheckExprs {
+		var typedExpr tree.TypedExpr
+		if dd.TypedCheckExprs != nil {
+			typedExpr = dd.TypedCheckExprs[i].(tree.TypedExpr)
+		} else {
+			// The expressions could not be type-checked when the type was
+			// hydrated, so do it now in order to surface the error.
+			expr, err := parser.ParseExpr(exprStr)
+			if err != nil {
+				return err
+			}
+			if typedExpr, err = tree.TypeCheckDomainCheckExpr(ctx, expr, t.DomainBaseType()); err != nil {
+				return err
+			}
+		}
+		res, err := Expr(ctx, evalCtx, typedExpr)
+		if err != nil {
+			return err
+		}
+		if res == tree.DBoolFalse {
+			return pgerror.Newf(pgcode.CheckViolation,
+				"value for domain %s violates check constraint %q", t.Name(), dd.CheckNames[i])
+		}
+	}
+	return nil
+}
+
+// domainValueContainer binds the value being checked to the indexed variable
+// that replaces VALUE in the CHECK constraints of a domain.
+type domainValueContainer struct {
+	value tree.Datum
+	typ   *types.T
+}
+
+var _ IndexedVarContainer = &domainValueContainer{}
+
+// IndexedVarEval implements the IndexedVarContainer interface.
+func (c *domainValueContainer) IndexedVarEval(int) (tree.Datum, error) {
+	return c.value, nil
+}
+
+// IndexedVarResolvedType implements the tree.IndexedVarContainer interface.
+func (c *domainValueContainer) IndexedVarResolvedType(int) *types.T {
+	return c.typ
+}
@@ -182,8 +182,14 @@ func (e *evaluator) EvalCastExpr(ctx context.Context, expr *tree.CastExpr) (tree
 		return nil, err
 	}
 
-	// NULL cast to anything is NULL.
+	// NULL cast to anything is NULL, unless it is cast to a domain that does
+	// not allow NULL values.
 	if d == tree.DNull {
+		if typ := expr.ResolvedType(); typ.IsDomain() {
+			if err := CheckDomainConstraints(ctx, e.ctx(), d, typ); err != nil {
+				return nil, err
+			}
+		}
 		return d, nil
 	}
 	d = UnwrapDatum(ctx, e.ctx(), d)
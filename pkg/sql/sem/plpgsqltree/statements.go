@@ -16,7 +16,6 @@ import (
 	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 )
 
 type Expr = tree.Expr
@@ -228,6 +227,11 @@ type Assignment struct {
 	StatementImpl
 	Var   Variable
 	Value Expr
+
+	// Indirection is the name of the field of a record variable that is
+	// assigned, as in "NEW.a := 1". It is empty if the whole variable is
+	// assigned.
+	Indirection tree.Name
 }
 
 func (s *Assignment) CopyNode() *Assignment {
@@ -241,6 +245,10 @@ func (s *Assignment) PlpgSQLStatementTag() string {
 
 func (s *Assignment) Format(ctx *tree.FmtCtx) {
 	ctx.FormatNode(&s.Var)
+	if s.Indirection != "" {
+		ctx.WriteByte('.')
+		ctx.FormatNode(&s.Indirection)
+	}
 	ctx.WriteString(" := ")
 	ctx.FormatNode(s.Value)
 	ctx.WriteString(";\n")
@@ -606,107 +614,169 @@ func (s *While) WalkStmt(visitor StatementVisitor) Statement {
 }
 
 // stmt_for
-type ForInt struct {
+type ForLoop struct {
 	StatementImpl
 	Label   string
-	Var     Variable
-	Lower   Expr
-	Upper   Expr
-	Step    Expr
-	Reverse int
+	Target  []Variable
+	Control ForLoopControl
 	Body    []Statement
 }
 
-func (s *ForInt) Format(ctx *tree.FmtCtx) {
-}
-
-func (s *ForInt) PlpgSQLStatementTag() string {
-	return "stmt_for_int_loop"
-}
-
-func (s *ForInt) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
-}
-
-type ForQuery struct {
-	StatementImpl
-	Label string
-	Var   Variable
-	Body  []Statement
-}
-
-func (s *ForQuery) Format(ctx *tree.FmtCtx) {
-}
-
-func (s *ForQuery) PlpgSQLStatementTag() string {
-	return "stmt_for_query_loop"
-}
-
-func (s *ForQuery) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+func (s *ForLoop) CopyNode() *ForLoop {
+	copyNode := *s
+	copyNode.Target = append([]Variable(nil), copyNode.Target...)
+	copyNode.Body = append([]Statement(nil), copyNode.Body...)
+	return &copyNode
 }
 
-type ForSelect struct {
-	ForQuery
-	Query Expr
+func (s *ForLoop) Format(ctx *tree.FmtCtx) {
+	if s.Label != "" {
+		ctx.WriteString("<<")
+		ctx.FormatNameP(&s.Label)
+		ctx.WriteString(">>\n")
+	}
+	ctx.WriteString("FOR ")
+	for i := range s.Target {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&s.Target[i])
+	}
+	ctx.WriteString(" IN ")
+	ctx.FormatNode(s.Control)
+	ctx.WriteString(" LOOP\n")
+	for _, stmt := range s.Body {
+		ctx.FormatNode(stmt)
+	}
+	ctx.WriteString("END LOOP")
+	if s.Label != "" {
+		ctx.WriteString(" ")
+		ctx.FormatNameP(&s.Label)
+	}
+	ctx.WriteString(";\n")
 }
 
-func (s *ForSelect) Format(ctx *tree.FmtCtx) {
+func (s *ForLoop) PlpgSQLStatementTag() string {
+	switch s.Control.(type) {
+	case *IntForLoopControl:
+		return "stmt_for_int_loop"
+	case *QueryForLoopControl:
+		return "stmt_for_query_loop"
+	}
+	return "stmt_for"
 }
 
-func (s *ForSelect) PlpgSQLStatementTag() string {
-	return "stmt_query_select_loop"
-}
+func (s *ForLoop) WalkStmt(visitor StatementVisitor) Statement {
+	newStmt, recurse := visitor.Visit(s)
 
-func (s *ForSelect) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	if recurse {
+		for i, bodyStmt := range s.Body {
+			newBodyStmt := bodyStmt.WalkStmt(visitor)
+			if newBodyStmt != bodyStmt {
+				if newStmt == s {
+					newStmt = s.CopyNode()
+				}
+				newStmt.(*ForLoop).Body[i] = newBodyStmt
+			}
+		}
+	}
+	return newStmt
 }
 
-type ForCursor struct {
-	ForQuery
-	CurVar   int
-	ArgQuery Expr
+// ForLoopControl is the part of a FOR loop between the IN and LOOP keywords,
+// which determines the values that are assigned to the loop target on each
+// iteration.
+type ForLoopControl interface {
+	tree.NodeFormatter
+	isForLoopControl()
 }
 
-func (s *ForCursor) Format(ctx *tree.FmtCtx) {
-}
+var (
+	_ ForLoopControl = &IntForLoopControl{}
+	_ ForLoopControl = &QueryForLoopControl{}
+)
 
-func (s *ForCursor) PlpgSQLStatementTag() string {
-	return "stmt_for_query_cursor_loop"
+// IntForLoopControl iterates over a range of integers, e.g.
+//
+//	FOR i IN REVERSE 10..1 BY 2 LOOP
+type IntForLoopControl struct {
+	Reverse bool
+	Lower   Expr
+	Upper   Expr
+	Step    Expr
 }
 
-func (s *ForCursor) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
-}
+func (c *IntForLoopControl) isForLoopControl() {}
 
-type ForDynamic struct {
-	ForQuery
-	Query  Expr
-	Params []Expr
+func (c *IntForLoopControl) Format(ctx *tree.FmtCtx) {
+	if c.Reverse {
+		ctx.WriteString("REVERSE ")
+	}
+	ctx.FormatNode(c.Lower)
+	ctx.WriteString("..")
+	ctx.FormatNode(c.Upper)
+	if c.Step != nil {
+		ctx.WriteString(" BY ")
+		ctx.FormatNode(c.Step)
+	}
 }
 
-func (s *ForDynamic) Format(ctx *tree.FmtCtx) {
+// QueryForLoopControl iterates over the rows returned by a query, e.g.
+//
+//	FOR a, b IN SELECT x, y FROM xy LOOP
+type QueryForLoopControl struct {
+	Query tree.Statement
 }
 
-func (s *ForDynamic) PlpgSQLStatementTag() string {
-	return "stmt_for_dyn_loop"
-}
+func (c *QueryForLoopControl) isForLoopControl() {}
 
-func (s *ForDynamic) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+func (c *QueryForLoopControl) Format(ctx *tree.FmtCtx) {
+	ctx.FormatNode(c.Query)
 }
 
 // stmt_foreach_a
 type ForEachArray struct {
 	StatementImpl
 	Label string
-	Var   *Variable
-	Slice int // TODO(drewk): not sure what this is
+	Var   Variable
+	// Slice is the number of array dimensions that are assigned to the loop
+	// variable on each iteration. It is zero if the array is iterated over
+	// element-by-element.
+	Slice int
 	Expr  Expr
 	Body  []Statement
 }
 
+func (s *ForEachArray) CopyNode() *ForEachArray {
+	copyNode := *s
+	copyNode.Body = append([]Statement(nil), copyNode.Body...)
+	return &copyNode
+}
+
 func (s *ForEachArray) Format(ctx *tree.FmtCtx) {
+	if s.Label != "" {
+		ctx.WriteString("<<")
+		ctx.FormatNameP(&s.Label)
+		ctx.WriteString(">>\n")
+	}
+	ctx.WriteString("FOREACH ")
+	ctx.FormatNode(&s.Var)
+	if s.Slice != 0 {
+		ctx.WriteString(" SLICE ")
+		ctx.WriteString(strconv.Itoa(s.Slice))
+	}
+	ctx.WriteString(" IN ARRAY ")
+	ctx.FormatNode(s.Expr)
+	ctx.WriteString(" LOOP\n")
+	for _, stmt := range s.Body {
+		ctx.FormatNode(stmt)
+	}
+	ctx.WriteString("END LOOP")
+	if s.Label != "" {
+		ctx.WriteString(" ")
+		ctx.FormatNameP(&s.Label)
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *ForEachArray) PlpgSQLStatementTag() string {
@@ -714,7 +784,20 @@ func (s *ForEachArray) PlpgSQLStatementTag() string {
 }
 
 func (s *ForEachArray) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, recurse := visitor.Visit(s)
+
+	if recurse {
+		for i, bodyStmt := range s.Body {
+			newBodyStmt := bodyStmt.WalkStmt(visitor)
+			if newBodyStmt != bodyStmt {
+				if newStmt == s {
+					newStmt = s.CopyNode()
+				}
+				newStmt.(*ForEachArray).Body[i] = newBodyStmt
+			}
+		}
+	}
+	return newStmt
 }
 
 // stmt_exit
@@ -815,13 +898,24 @@ func (s *Return) WalkStmt(visitor StatementVisitor) Statement {
 	return newStmt
 }
 
+// stmt_return_next
 type ReturnNext struct {
 	StatementImpl
-	Expr   Expr
-	RetVar Variable
+	Expr Expr
+}
+
+func (s *ReturnNext) CopyNode() *ReturnNext {
+	copyNode := *s
+	return &copyNode
 }
 
 func (s *ReturnNext) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RETURN NEXT")
+	if s.Expr != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(s.Expr)
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *ReturnNext) PlpgSQLStatementTag() string {
@@ -829,17 +923,25 @@ func (s *ReturnNext) PlpgSQLStatementTag() string {
 }
 
 func (s *ReturnNext) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, _ := visitor.Visit(s)
+	return newStmt
 }
 
+// stmt_return_query
 type ReturnQuery struct {
 	StatementImpl
-	Query        Expr
-	DynamicQuery Expr
-	Params       []Expr
+	SqlStmt tree.Statement
+}
+
+func (s *ReturnQuery) CopyNode() *ReturnQuery {
+	copyNode := *s
+	return &copyNode
 }
 
 func (s *ReturnQuery) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RETURN QUERY ")
+	ctx.FormatNode(s.SqlStmt)
+	ctx.WriteString(";\n")
 }
 
 func (s *ReturnQuery) PlpgSQLStatementTag() string {
@@ -847,7 +949,8 @@ func (s *ReturnQuery) PlpgSQLStatementTag() string {
 }
 
 func (s *ReturnQuery) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, _ := visitor.Visit(s)
+	return newStmt
 }
 
 // stmt_raise
@@ -1030,10 +1133,27 @@ func (s *DynamicExecute) WalkStmt(visitor StatementVisitor) Statement {
 // stmt_perform
 type Perform struct {
 	StatementImpl
-	Expr Expr
+	// SqlStmt is the SELECT statement that is executed with its result
+	// discarded. PERFORM replaces the SELECT keyword of the query, so it is
+	// omitted when formatting.
+	SqlStmt tree.Statement
+}
+
+func (s *Perform) CopyNode() *Perform {
+	copyNode := *s
+	return &copyNode
 }
 
 func (s *Perform) Format(ctx *tree.FmtCtx) {
+	const selectPrefix = "SELECT "
+	ctx.WriteString("PERFORM ")
+	start := ctx.Len()
+	ctx.FormatNode(s.SqlStmt)
+	if formatted := ctx.Bytes()[start:]; strings.HasPrefix(string(formatted), selectPrefix) {
+		n := copy(formatted, formatted[len(selectPrefix):])
+		ctx.Truncate(start + n)
+	}
+	ctx.WriteString(";\n")
 }
 
 func (s *Perform) PlpgSQLStatementTag() string {
@@ -1041,7 +1161,8 @@ func (s *Perform) PlpgSQLStatementTag() string {
 }
 
 func (s *Perform) WalkStmt(visitor StatementVisitor) Statement {
-	panic(unimplemented.New("plpgsql visitor", "Unimplemented PLpgSQL visitor pattern"))
+	newStmt, _ := visitor.Visit(s)
+	return newStmt
 }
 
 // stmt_call
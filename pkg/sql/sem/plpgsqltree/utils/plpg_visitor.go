@@ -20,7 +20,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
-	unimp "github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 )
 
@@ -321,10 +320,72 @@ func (v *SQLStmtVisitor) Visit(
 			newStmt = cpy
 		}
 
-	case *plpgsqltree.ForInt, *plpgsqltree.ForSelect, *plpgsqltree.ForCursor,
-		*plpgsqltree.ForDynamic, *plpgsqltree.ForEachArray, *plpgsqltree.ReturnNext,
-		*plpgsqltree.ReturnQuery, *plpgsqltree.Perform:
-		panic(unimp.New("plpgsql visitor", "Unimplemented PLpgSQL visitor"))
+	case *plpgsqltree.ForLoop:
+		switch c := t.Control.(type) {
+		case *plpgsqltree.IntForLoopControl:
+			newControl := *c
+			for _, expr := range []*tree.Expr{&newControl.Lower, &newControl.Upper, &newControl.Step} {
+				*expr, v.Err = simpleVisit(*expr, v.Fn)
+				if v.Err != nil {
+					return stmt, false
+				}
+			}
+			if newControl != *c {
+				cpy := t.CopyNode()
+				cpy.Control = &newControl
+				newStmt = cpy
+			}
+		case *plpgsqltree.QueryForLoopControl:
+			s, v.Err = simpleStmtVisit(c.Query, v.Fn)
+			if v.Err != nil {
+				return stmt, false
+			}
+			if c.Query != s {
+				cpy := t.CopyNode()
+				cpy.Control = &plpgsqltree.QueryForLoopControl{Query: s}
+				newStmt = cpy
+			}
+		}
+	case *plpgsqltree.ForEachArray:
+		e, v.Err = simpleVisit(t.Expr, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Expr != e {
+			cpy := t.CopyNode()
+			cpy.Expr = e
+			newStmt = cpy
+		}
+	case *plpgsqltree.ReturnNext:
+		e, v.Err = simpleVisit(t.Expr, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.Expr != e {
+			cpy := t.CopyNode()
+			cpy.Expr = e
+			newStmt = cpy
+		}
+	case *plpgsqltree.ReturnQuery:
+		s, v.Err = simpleStmtVisit(t.SqlStmt, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.SqlStmt != s {
+			cpy := t.CopyNode()
+			cpy.SqlStmt = s
+			newStmt = cpy
+		}
+	case *plpgsqltree.Perform:
+		s, v.Err = simpleStmtVisit(t.SqlStmt, v.Fn)
+		if v.Err != nil {
+			return stmt, false
+		}
+		if t.SqlStmt != s {
+			cpy := t.CopyNode()
+			cpy.SqlStmt = s
+			newStmt = cpy
+		}
 	}
 	if v.Err != nil {
 		return stmt, false
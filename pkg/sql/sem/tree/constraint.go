@@ -13,6 +13,8 @@ package tree
 import (
 	"strconv"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
 )
 
@@ -127,3 +129,82 @@ func (x CompositeKeyMatchMethod) String() string {
 		return strconv.Itoa(int(x))
 	}
 }
+
+// ConstraintDeferrability indicates whether the checks of a constraint can be
+// deferred until the end of the transaction, and whether they are deferred
+// unless SET CONSTRAINTS specifies otherwise.
+type ConstraintDeferrability int
+
+// The values for ConstraintDeferrability.
+const (
+	ConstraintNotDeferrable ConstraintDeferrability = iota
+	ConstraintInitiallyImmediate
+	ConstraintInitiallyDeferred
+)
+
+// MakeConstraintDeferrability returns the ConstraintDeferrability that
+// corresponds to the given [NOT] DEFERRABLE and INITIALLY DEFERRED|IMMEDIATE
+// clauses. A constraint that is INITIALLY DEFERRED is implicitly DEFERRABLE.
+func MakeConstraintDeferrability(
+	deferrable, notDeferrable, initiallyDeferred bool,
+) (ConstraintDeferrability, error) {
+	if initiallyDeferred {
+		if notDeferrable {
+			return 0, pgerror.New(pgcode.Syntax,
+				"constraint declared INITIALLY DEFERRED must be DEFERRABLE")
+		}
+		return ConstraintInitiallyDeferred, nil
+	}
+	if deferrable {
+		return ConstraintInitiallyImmediate, nil
+	}
+	return ConstraintNotDeferrable, nil
+}
+
+// Format implements the NodeFormatter interface.
+func (node ConstraintDeferrability) Format(ctx *FmtCtx) {
+	ctx.WriteString(node.String())
+}
+
+// String implements the fmt.Stringer interface.
+func (node ConstraintDeferrability) String() string {
+	switch node {
+	case ConstraintNotDeferrable:
+		return "NOT DEFERRABLE"
+	case ConstraintInitiallyImmediate:
+		return "DEFERRABLE"
+	case ConstraintInitiallyDeferred:
+		return "DEFERRABLE INITIALLY DEFERRED"
+	default:
+		return strconv.Itoa(int(node))
+	}
+}
+
+// ConstraintAttribute is a DEFERRABLE, NOT DEFERRABLE, INITIALLY DEFERRED or
+// INITIALLY IMMEDIATE clause in a column definition. It applies to the UNIQUE
+// or REFERENCES constraint that precedes it.
+type ConstraintAttribute int
+
+// The values for ConstraintAttribute.
+const (
+	ConstraintAttrDeferrable ConstraintAttribute = iota
+	ConstraintAttrNotDeferrable
+	ConstraintAttrInitiallyDeferred
+	ConstraintAttrInitiallyImmediate
+)
+
+// String implements the fmt.Stringer interface.
+func (node ConstraintAttribute) String() string {
+	switch node {
+	case ConstraintAttrDeferrable:
+		return "DEFERRABLE"
+	case ConstraintAttrNotDeferrable:
+		return "NOT DEFERRABLE"
+	case ConstraintAttrInitiallyDeferred:
+		return "INITIALLY DEFERRED"
+	case ConstraintAttrInitiallyImmediate:
+		return "INITIALLY IMMEDIATE"
+	default:
+		return strconv.Itoa(int(node))
+	}
+}
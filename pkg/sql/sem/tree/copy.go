@@ -20,7 +20,12 @@ type CopyFrom struct {
 	Table   TableName
 	Columns NameList
 	Stdin   bool
+	// File, if set, is the URI of the file in external storage from which the
+	// data is read, instead of STDIN.
+	File    Expr
 	Options CopyOptions
+	// Where, if set, filters the rows that are inserted into the table.
+	Where *Where
 }
 
 // CopyTo represents a COPY TO statement.
@@ -28,7 +33,10 @@ type CopyTo struct {
 	Table     TableName
 	Columns   NameList
 	Statement Statement
-	Options   CopyOptions
+	// File, if set, is the URI of the file in external storage to which the
+	// data is written, instead of STDOUT.
+	File    Expr
+	Options CopyOptions
 }
 
 // Format implements the NodeFormatter interface.
@@ -46,7 +54,12 @@ func (node *CopyTo) Format(ctx *FmtCtx) {
 			ctx.WriteString(")")
 		}
 	}
-	ctx.WriteString(" TO STDOUT")
+	ctx.WriteString(" TO ")
+	if node.File != nil {
+		ctx.FormatNode(node.File)
+	} else {
+		ctx.WriteString("STDOUT")
+	}
 	if !node.Options.IsDefault() {
 		ctx.WriteString(" WITH ")
 		ctx.FormatNode(&node.Options)
@@ -64,6 +77,17 @@ type CopyOptions struct {
 	Quote       *StrVal
 	Encoding    *StrVal
 
+	// ForceQuote lists the columns whose non-NULL values are always quoted by
+	// COPY TO in CSV format. ForceQuoteAll is set if all columns are quoted.
+	ForceQuote    NameList
+	ForceQuoteAll bool
+	// ForceNotNull lists the columns for which COPY FROM in CSV format does
+	// not match values against the null string.
+	ForceNotNull NameList
+	// ForceNull lists the columns for which COPY FROM in CSV format matches
+	// quoted values against the null string.
+	ForceNull NameList
+
 	// Additional flags are needed to keep track of whether explicit default
 	// values were already set.
 	HasFormat bool
@@ -84,11 +108,17 @@ func (node *CopyFrom) Format(ctx *FmtCtx) {
 	ctx.WriteString(" FROM ")
 	if node.Stdin {
 		ctx.WriteString("STDIN")
+	} else if node.File != nil {
+		ctx.FormatNode(node.File)
 	}
 	if !node.Options.IsDefault() {
 		ctx.WriteString(" WITH ")
 		ctx.FormatNode(&node.Options)
 	}
+	if node.Where != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.Where)
+	}
 }
 
 // Format implements the NodeFormatter interface
@@ -158,12 +188,36 @@ func (o *CopyOptions) Format(ctx *FmtCtx) {
 		ctx.WriteString("QUOTE ")
 		ctx.FormatNode(o.Quote)
 	}
+	if o.ForceQuoteAll {
+		maybeAddSep()
+		ctx.WriteString("FORCE_QUOTE *")
+	} else if len(o.ForceQuote) > 0 {
+		maybeAddSep()
+		ctx.WriteString("FORCE_QUOTE (")
+		ctx.FormatNode(&o.ForceQuote)
+		ctx.WriteString(")")
+	}
+	if len(o.ForceNotNull) > 0 {
+		maybeAddSep()
+		ctx.WriteString("FORCE_NOT_NULL (")
+		ctx.FormatNode(&o.ForceNotNull)
+		ctx.WriteString(")")
+	}
+	if len(o.ForceNull) > 0 {
+		maybeAddSep()
+		ctx.WriteString("FORCE_NULL (")
+		ctx.FormatNode(&o.ForceNull)
+		ctx.WriteString(")")
+	}
 	ctx.WriteString(")")
 }
 
 // IsDefault returns true if this struct has default value.
 func (o CopyOptions) IsDefault() bool {
-	return o == CopyOptions{}
+	return o.Destination == nil && o.CopyFormat == CopyFormatText && o.Delimiter == nil &&
+		o.Null == nil && o.Escape == nil && !o.Header && o.Quote == nil && o.Encoding == nil &&
+		o.ForceQuote == nil && !o.ForceQuoteAll && o.ForceNotNull == nil && o.ForceNull == nil &&
+		!o.HasFormat && !o.HasHeader
 }
 
 // CombineWith merges other options into this struct. An error is returned if
@@ -219,6 +273,25 @@ func (o *CopyOptions) CombineWith(other *CopyOptions) error {
 		}
 		o.Quote = other.Quote
 	}
+	if other.ForceQuote != nil || other.ForceQuoteAll {
+		if o.ForceQuote != nil || o.ForceQuoteAll {
+			return pgerror.Newf(pgcode.Syntax, "force_quote option specified multiple times")
+		}
+		o.ForceQuote = other.ForceQuote
+		o.ForceQuoteAll = other.ForceQuoteAll
+	}
+	if other.ForceNotNull != nil {
+		if o.ForceNotNull != nil {
+			return pgerror.Newf(pgcode.Syntax, "force_not_null option specified multiple times")
+		}
+		o.ForceNotNull = other.ForceNotNull
+	}
+	if other.ForceNull != nil {
+		if o.ForceNull != nil {
+			return pgerror.Newf(pgcode.Syntax, "force_null option specified multiple times")
+		}
+		o.ForceNull = other.ForceNull
+	}
 	return nil
 }
 
@@ -28,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/collatedstring"
 	"github.com/cockroachdb/cockroach/pkg/util/pretty"
@@ -239,6 +240,8 @@ type CreateIndex struct {
 	Table       TableName
 	Unique      bool
 	Inverted    bool
+	Hash        bool
+	BRIN        bool
 	IfNotExists bool
 	Columns     IndexElemList
 	Sharded     *ShardedIndexDef
@@ -277,6 +280,11 @@ func (node *CreateIndex) Format(ctx *FmtCtx) {
 	}
 	ctx.WriteString("ON ")
 	ctx.FormatNode(&node.Table)
+	if node.Hash {
+		ctx.WriteString(" USING hash")
+	} else if node.BRIN {
+		ctx.WriteString(" USING brin")
+	}
 
 	ctx.WriteString(" (")
 	ctx.FormatNode(&node.Columns)
@@ -426,6 +434,7 @@ func (*IndexTableDef) tableDef()                {}
 func (*FamilyTableDef) tableDef()               {}
 func (*ForeignKeyConstraintTableDef) tableDef() {}
 func (*CheckConstraintTableDef) tableDef()      {}
+func (*ExcludeConstraintTableDef) tableDef()    {}
 func (*LikeTableDef) tableDef()                 {}
 
 // TableDefs represents a list of table definitions.
@@ -502,6 +511,7 @@ type ColumnTableDef struct {
 		IsUnique       bool
 		WithoutIndex   bool
 		ConstraintName Name
+		Deferrability  ConstraintDeferrability
 	}
 	DefaultExpr struct {
 		Expr           Expr
@@ -518,6 +528,7 @@ type ColumnTableDef struct {
 		ConstraintName Name
 		Actions        ReferenceActions
 		Match          CompositeKeyMatchMethod
+		Deferrability  ConstraintDeferrability
 	}
 	Computed struct {
 		Computed bool
@@ -579,7 +590,11 @@ func NewColumnTableDef(
 		IsSerial: isSerial,
 	}
 	d.Nullable.Nullability = SilentNull
+	var attrs constraintAttrs
 	for _, c := range qualifications {
+		if _, ok := c.Qualification.(ConstraintAttribute); !ok {
+			attrs = constraintAttrs{}
+		}
 		switch t := c.Qualification.(type) {
 		case ColumnCollation:
 			locale := string(t)
@@ -686,6 +701,7 @@ func NewColumnTableDef(
 			d.Unique.IsUnique = true
 			d.Unique.WithoutIndex = t.WithoutIndex
 			d.Unique.ConstraintName = c.Name
+			attrs.target = &d.Unique.Deferrability
 		case *ColumnCheckConstraint:
 			d.CheckExprs = append(d.CheckExprs, ColumnTableDefCheckExpr{
 				Expr:           t.Expr,
@@ -701,6 +717,11 @@ func NewColumnTableDef(
 			d.References.ConstraintName = c.Name
 			d.References.Actions = t.Actions
 			d.References.Match = t.Match
+			attrs.target = &d.References.Deferrability
+		case ConstraintAttribute:
+			if err := attrs.apply(t); err != nil {
+				return nil, err
+			}
 		case *ColumnComputedDef:
 			if d.GeneratedIdentity.IsGeneratedAsIdentity {
 				return nil, pgerror.Newf(pgcode.Syntax,
@@ -725,6 +746,41 @@ func NewColumnTableDef(
 	return d, nil
 }
 
+// constraintAttrs accumulates the constraint attributes that follow a UNIQUE
+// or REFERENCES constraint in a column definition.
+type constraintAttrs struct {
+	// target is the deferrability of the constraint to which the attributes
+	// apply, or nil if the preceding qualification is not such a constraint.
+	target                                *ConstraintDeferrability
+	deferrable, notDeferrable             bool
+	initiallyDeferred, initiallyImmediate bool
+}
+
+func (a *constraintAttrs) apply(attr ConstraintAttribute) error {
+	if a.target == nil {
+		return pgerror.Newf(pgcode.Syntax, "misplaced %s clause", attr)
+	}
+	switch attr {
+	case ConstraintAttrDeferrable, ConstraintAttrNotDeferrable:
+		if a.deferrable || a.notDeferrable {
+			return pgerror.New(pgcode.Syntax,
+				"multiple DEFERRABLE/NOT DEFERRABLE clauses not allowed")
+		}
+		a.deferrable = attr == ConstraintAttrDeferrable
+		a.notDeferrable = attr == ConstraintAttrNotDeferrable
+	case ConstraintAttrInitiallyDeferred, ConstraintAttrInitiallyImmediate:
+		if a.initiallyDeferred || a.initiallyImmediate {
+			return pgerror.New(pgcode.Syntax,
+				"multiple INITIALLY IMMEDIATE/DEFERRED clauses not allowed")
+		}
+		a.initiallyDeferred = attr == ConstraintAttrInitiallyDeferred
+		a.initiallyImmediate = attr == ConstraintAttrInitiallyImmediate
+	}
+	var err error
+	*a.target, err = MakeConstraintDeferrability(a.deferrable, a.notDeferrable, a.initiallyDeferred)
+	return err
+}
+
 // HasDefaultExpr returns if the ColumnTableDef has a default expression.
 func (node *ColumnTableDef) HasDefaultExpr() bool {
 	return node.DefaultExpr.Expr != nil
@@ -812,6 +868,10 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 			if node.Unique.WithoutIndex {
 				ctx.WriteString(" WITHOUT INDEX")
 			}
+			if node.Unique.Deferrability != ConstraintNotDeferrable {
+				ctx.WriteByte(' ')
+				ctx.FormatNode(node.Unique.Deferrability)
+			}
 		}
 	}
 	if node.HasDefaultExpr() {
@@ -871,6 +931,10 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 			ctx.WriteString(node.References.Match.String())
 		}
 		ctx.FormatNode(&node.References.Actions)
+		if node.References.Deferrability != ConstraintNotDeferrable {
+			ctx.WriteByte(' ')
+			ctx.FormatNode(node.References.Deferrability)
+		}
 	}
 	if node.IsComputed() {
 		ctx.WriteString(" AS (")
@@ -951,6 +1015,7 @@ func (*ColumnFKConstraint) columnQualification()         {}
 func (*ColumnFamilyConstraint) columnQualification()     {}
 func (*GeneratedAlwaysAsIdentity) columnQualification()  {}
 func (*GeneratedByDefAsIdentity) columnQualification()   {}
+func (ConstraintAttribute) columnQualification()         {}
 
 // ColumnCollation represents a COLLATE clause for a column.
 type ColumnCollation string
@@ -1102,14 +1167,16 @@ type ConstraintTableDef interface {
 func (*UniqueConstraintTableDef) constraintTableDef()     {}
 func (*ForeignKeyConstraintTableDef) constraintTableDef() {}
 func (*CheckConstraintTableDef) constraintTableDef()      {}
+func (*ExcludeConstraintTableDef) constraintTableDef()    {}
 
 // UniqueConstraintTableDef represents a unique constraint within a CREATE
 // TABLE statement.
 type UniqueConstraintTableDef struct {
 	IndexTableDef
-	PrimaryKey   bool
-	WithoutIndex bool
-	IfNotExists  bool
+	PrimaryKey    bool
+	WithoutIndex  bool
+	IfNotExists   bool
+	Deferrability ConstraintDeferrability
 }
 
 // SetName implements the TableDef interface.
@@ -1154,6 +1221,10 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 	if node.PartitionByIndex != nil {
 		ctx.FormatNode(node.PartitionByIndex)
 	}
+	if node.Deferrability != ConstraintNotDeferrable {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.Deferrability)
+	}
 	if node.Predicate != nil {
 		ctx.WriteString(" WHERE ")
 		ctx.FormatNode(node.Predicate)
@@ -1173,13 +1244,14 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 
 // ForeignKeyConstraintTableDef represents a FOREIGN KEY constraint in the AST.
 type ForeignKeyConstraintTableDef struct {
-	Name        Name
-	Table       TableName
-	FromCols    NameList
-	ToCols      NameList
-	Actions     ReferenceActions
-	Match       CompositeKeyMatchMethod
-	IfNotExists bool
+	Name          Name
+	Table         TableName
+	FromCols      NameList
+	ToCols        NameList
+	Actions       ReferenceActions
+	Match         CompositeKeyMatchMethod
+	IfNotExists   bool
+	Deferrability ConstraintDeferrability
 }
 
 // Format implements the NodeFormatter interface.
@@ -1210,6 +1282,11 @@ func (node *ForeignKeyConstraintTableDef) Format(ctx *FmtCtx) {
 	}
 
 	ctx.FormatNode(&node.Actions)
+
+	if node.Deferrability != ConstraintNotDeferrable {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.Deferrability)
+	}
 }
 
 // SetName implements the ConstraintTableDef interface.
@@ -1256,6 +1333,86 @@ func (node *CheckConstraintTableDef) Format(ctx *FmtCtx) {
 	ctx.WriteByte(')')
 }
 
+// ExcludeConstraintTableDef represents an EXCLUDE constraint within a CREATE
+// TABLE statement.
+type ExcludeConstraintTableDef struct {
+	Name Name
+	// Using is the index access method named in the constraint, or empty if
+	// none was given.
+	Using         Name
+	Elems         ExcludeElemList
+	Predicate     Expr
+	IfNotExists   bool
+	Deferrability ConstraintDeferrability
+}
+
+// SetName implements the ConstraintTableDef interface.
+func (node *ExcludeConstraintTableDef) SetName(name Name) {
+	node.Name = name
+}
+
+// SetIfNotExists implements the ConstraintTableDef interface.
+func (node *ExcludeConstraintTableDef) SetIfNotExists() {
+	node.IfNotExists = true
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExcludeConstraintTableDef) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		if node.IfNotExists {
+			ctx.WriteString("IF NOT EXISTS ")
+		}
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteString("EXCLUDE ")
+	if node.Using != "" {
+		ctx.WriteString("USING ")
+		ctx.FormatNode(&node.Using)
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteByte('(')
+	ctx.FormatNode(&node.Elems)
+	ctx.WriteByte(')')
+	if node.Deferrability != ConstraintNotDeferrable {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(node.Deferrability)
+	}
+	if node.Predicate != nil {
+		ctx.WriteString(" WHERE (")
+		ctx.FormatNode(node.Predicate)
+		ctx.WriteByte(')')
+	}
+}
+
+// ExcludeElem is an element of an EXCLUDE constraint: a column or expression,
+// and the operator used to compare it between rows.
+type ExcludeElem struct {
+	IndexElem
+	Operator treecmp.ComparisonOperator
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExcludeElem) Format(ctx *FmtCtx) {
+	ctx.FormatNode(&node.IndexElem)
+	ctx.WriteString(" WITH ")
+	ctx.WriteString(node.Operator.String())
+}
+
+// ExcludeElemList is a list of EXCLUDE constraint elements.
+type ExcludeElemList []ExcludeElem
+
+// Format implements the NodeFormatter interface.
+func (l *ExcludeElemList) Format(ctx *FmtCtx) {
+	for i := range *l {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*l)[i])
+	}
+}
+
 // FamilyTableDef represents a family definition within a CREATE TABLE
 // statement.
 type FamilyTableDef struct {
@@ -1622,12 +1779,13 @@ func (node *CreateTable) HoistConstraints() {
 					targetCol = append(targetCol, col.References.Col)
 				}
 				node.Defs = append(node.Defs, &ForeignKeyConstraintTableDef{
-					Table:    *col.References.Table,
-					FromCols: NameList{col.Name},
-					ToCols:   targetCol,
-					Name:     col.References.ConstraintName,
-					Actions:  col.References.Actions,
-					Match:    col.References.Match,
+					Table:         *col.References.Table,
+					FromCols:      NameList{col.Name},
+					ToCols:        targetCol,
+					Name:          col.References.ConstraintName,
+					Actions:       col.References.Actions,
+					Match:         col.References.Match,
+					Deferrability: col.References.Deferrability,
 				})
 				col.References.Table = nil
 			}
@@ -1950,6 +2108,7 @@ type CreateView struct {
 	IfNotExists  bool
 	Persistence  Persistence
 	Replace      bool
+	Recursive    bool
 	Materialized bool
 	WithData     bool
 }
@@ -1966,6 +2125,10 @@ func (node *CreateView) Format(ctx *FmtCtx) {
 		ctx.WriteString("TEMPORARY ")
 	}
 
+	if node.Recursive {
+		ctx.WriteString("RECURSIVE ")
+	}
+
 	if node.Materialized {
 		ctx.WriteString("MATERIALIZED ")
 	}
@@ -0,0 +1,114 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/lib/pq/oid"
+)
+
+// The names of the options of a CREATE AGGREGATE statement.
+const (
+	AggregateOptionStateFunc   = "sfunc"
+	AggregateOptionStateType   = "stype"
+	AggregateOptionFinalFunc   = "finalfunc"
+	AggregateOptionCombineFunc = "combinefunc"
+	AggregateOptionInitCond    = "initcond"
+)
+
+// CreateAggregate represents a CREATE AGGREGATE statement.
+type CreateAggregate struct {
+	Name    RoutineName
+	Replace bool
+	// Params are the parameters of the aggregate. They are empty for an
+	// aggregate which takes no arguments, which is written as name(*).
+	Params  RoutineParams
+	Options AggregateOptions
+}
+
+var _ Statement = &CreateAggregate{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateAggregate) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("AGGREGATE ")
+	ctx.FormatNode(&node.Name)
+	if len(node.Params) == 0 {
+		ctx.WriteString("(*)")
+	} else {
+		ctx.WriteByte('(')
+		ctx.FormatNode(node.Params)
+		ctx.WriteByte(')')
+	}
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Options)
+	ctx.WriteByte(')')
+}
+
+// AggregateOptions is a list of options of a CREATE AGGREGATE statement.
+type AggregateOptions []AggregateOption
+
+// Format implements the NodeFormatter interface.
+func (node *AggregateOptions) Format(ctx *FmtCtx) {
+	for i := range *node {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*node)[i])
+	}
+}
+
+// AggregateOption is a single option of a CREATE AGGREGATE statement, such as
+// SFUNC = int8pl or INITCOND = '0'.
+type AggregateOption struct {
+	// Name is the name of the option.
+	Name string
+	// Type is set if the value of the option is a type or function name.
+	Type ResolvableTypeReference
+	// Value is set if the value of the option is a string or numeric constant.
+	Value Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AggregateOption) Format(ctx *FmtCtx) {
+	ctx.WriteString(strings.ToUpper(node.Name))
+	ctx.WriteString(" = ")
+	if node.Type != nil {
+		ctx.FormatTypeReference(node.Type)
+	} else {
+		ctx.FormatNode(node.Value)
+	}
+}
+
+// AggregateDefinition describes how a user-defined aggregate created with
+// CREATE AGGREGATE is computed.
+type AggregateDefinition struct {
+	// StateFunc is the OID of the state transition function, which is called
+	// with the current state followed by the arguments of each input row.
+	StateFunc oid.Oid
+	// StateType is the type of the state value.
+	StateType *types.T
+	// FinalFunc is the OID of the function which computes the result of the
+	// aggregate from the final state. It is zero if the result is the final
+	// state.
+	FinalFunc oid.Oid
+	// CombineFunc is the OID of the function which combines two partial
+	// states, or zero if there is none.
+	CombineFunc oid.Oid
+	// InitCond is the initial state value in its text form. It is nil if the
+	// initial state is NULL.
+	InitCond *string
+}
@@ -0,0 +1,70 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import "github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
+
+// CreateCast represents a CREATE CAST statement.
+type CreateCast struct {
+	Source ResolvableTypeReference
+	Target ResolvableTypeReference
+	// Func is the function which performs the cast.
+	Func RoutineObj
+	// Context is the most permissive context in which the cast can be
+	// performed. It is cast.ContextExplicit unless AS ASSIGNMENT or AS
+	// IMPLICIT is specified.
+	Context cast.Context
+}
+
+var _ Statement = &CreateCast{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateCast) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE CAST (")
+	ctx.FormatTypeReference(node.Source)
+	ctx.WriteString(" AS ")
+	ctx.FormatTypeReference(node.Target)
+	ctx.WriteString(") WITH FUNCTION ")
+	ctx.FormatNode(&node.Func)
+	switch node.Context {
+	case cast.ContextAssignment:
+		ctx.WriteString(" AS ASSIGNMENT")
+	case cast.ContextImplicit:
+		ctx.WriteString(" AS IMPLICIT")
+	}
+}
+
+// DropCast represents a DROP CAST statement.
+type DropCast struct {
+	Source       ResolvableTypeReference
+	Target       ResolvableTypeReference
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropCast{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropCast) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP CAST ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.WriteByte('(')
+	ctx.FormatTypeReference(node.Source)
+	ctx.WriteString(" AS ")
+	ctx.FormatTypeReference(node.Target)
+	ctx.WriteByte(')')
+	if node.DropBehavior != DropDefault {
+		ctx.WriteString(" ")
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// CreateForeignTable represents a CREATE FOREIGN TABLE statement.
+type CreateForeignTable struct {
+	IfNotExists bool
+	Table       TableName
+	Defs        TableDefs
+	// Server is the name of the external connection that the data of the
+	// table is read from.
+	Server Name
+	// Options are the options of the foreign table, such as the name of the
+	// file(s) to read and their format. The value of each option is a string
+	// literal.
+	Options KVOptions
+}
+
+var _ Statement = &CreateForeignTable{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateForeignTable) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE FOREIGN TABLE ")
+	if node.IfNotExists {
+		ctx.WriteString("IF NOT EXISTS ")
+	}
+	ctx.FormatNode(&node.Table)
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Defs)
+	ctx.WriteString(") SERVER ")
+	ctx.FormatNode(&node.Server)
+	if len(node.Options) > 0 {
+		ctx.WriteString(" OPTIONS (")
+		for i := range node.Options {
+			o := &node.Options[i]
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			// Option keys never contain PII.
+			ctx.WithFlags(ctx.flags&^FmtMarkRedactionNode, func() {
+				ctx.FormatNode(&o.Key)
+			})
+			ctx.WriteByte(' ')
+			ctx.FormatNode(o.Value)
+		}
+		ctx.WriteByte(')')
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import "strings"
+
+// The names of the options of a CREATE OPERATOR statement.
+const (
+	OperatorOptionFunction   = "function"
+	OperatorOptionProcedure  = "procedure"
+	OperatorOptionLeftArg    = "leftarg"
+	OperatorOptionRightArg   = "rightarg"
+	OperatorOptionCommutator = "commutator"
+	OperatorOptionNegator    = "negator"
+	OperatorOptionRestrict   = "restrict"
+	OperatorOptionJoin       = "join"
+	OperatorOptionHashes     = "hashes"
+	OperatorOptionMerges     = "merges"
+)
+
+// OperatorName is the name of an operator, such as + or myschema.+.
+type OperatorName struct {
+	// Schema is the schema qualifying the operator, or empty if the operator
+	// is not qualified.
+	Schema Name
+	// Symbol is the symbol of the operator.
+	Symbol string
+}
+
+// Format implements the NodeFormatter interface.
+func (node *OperatorName) Format(ctx *FmtCtx) {
+	if node.Schema != "" {
+		ctx.FormatNode(&node.Schema)
+		ctx.WriteByte('.')
+	}
+	ctx.WriteString(node.Symbol)
+}
+
+// CreateOperator represents a CREATE OPERATOR statement.
+type CreateOperator struct {
+	Name    OperatorName
+	Options OperatorOptions
+}
+
+var _ Statement = &CreateOperator{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateOperator) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE OPERATOR ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Options)
+	ctx.WriteByte(')')
+}
+
+// OperatorOptions is a list of options of a CREATE OPERATOR statement.
+type OperatorOptions []OperatorOption
+
+// Format implements the NodeFormatter interface.
+func (node *OperatorOptions) Format(ctx *FmtCtx) {
+	for i := range *node {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*node)[i])
+	}
+}
+
+// OperatorOption is a single option of a CREATE OPERATOR statement, such as
+// FUNCTION = f or LEFTARG = INT8.
+type OperatorOption struct {
+	// Name is the name of the option.
+	Name string
+	// Type is set if the value of the option is a type or function name.
+	Type ResolvableTypeReference
+	// Operator is set if the value of the option is an operator.
+	Operator *OperatorName
+}
+
+// Format implements the NodeFormatter interface.
+func (node *OperatorOption) Format(ctx *FmtCtx) {
+	ctx.WriteString(strings.ToUpper(node.Name))
+	if node.Type != nil {
+		ctx.WriteString(" = ")
+		ctx.FormatTypeReference(node.Type)
+	} else if node.Operator != nil {
+		ctx.WriteString(" = OPERATOR(")
+		ctx.FormatNode(node.Operator)
+		ctx.WriteByte(')')
+	}
+}
+
+// OperatorWithArgs identifies an operator by its name and operand types.
+type OperatorWithArgs struct {
+	Name OperatorName
+	// Left is the type of the left operand. It is nil for a prefix operator.
+	Left  ResolvableTypeReference
+	Right ResolvableTypeReference
+}
+
+// Format implements the NodeFormatter interface.
+func (node *OperatorWithArgs) Format(ctx *FmtCtx) {
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" (")
+	if node.Left == nil {
+		ctx.WriteString("NONE")
+	} else {
+		ctx.FormatTypeReference(node.Left)
+	}
+	ctx.WriteString(", ")
+	ctx.FormatTypeReference(node.Right)
+	ctx.WriteByte(')')
+}
+
+// DropOperator represents a DROP OPERATOR statement.
+type DropOperator struct {
+	Operators    []OperatorWithArgs
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropOperator{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropOperator) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP OPERATOR ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	for i := range node.Operators {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&node.Operators[i])
+	}
+	if node.DropBehavior != DropDefault {
+		ctx.WriteString(" ")
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
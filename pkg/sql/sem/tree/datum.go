@@ -39,6 +39,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/cockroach/pkg/util/ipaddr"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/cockroach/pkg/util/jsonpath"
 	"github.com/cockroachdb/cockroach/pkg/util/stringencoding"
 	"github.com/cockroachdb/cockroach/pkg/util/timeofday"
 	"github.com/cockroachdb/cockroach/pkg/util/timetz"
@@ -4038,7 +4039,7 @@ func AsJSON(
 		// This is RFC3339Nano, but without the TZ fields.
 		return json.FromString(formatTime(t.UTC(), "2006-01-02T15:04:05.999999999")), nil
 	case *DDate, *DUuid, *DOid, *DInterval, *DBytes, *DIPAddr, *DTime, *DTimeTZ, *DBitArray, *DBox2D,
-		*DTSVector, *DTSQuery, *DPGLSN:
+		*DTSVector, *DTSQuery, *DJSONPath, *DPGLSN, *DRange, *DMultirange:
 		return json.FromString(
 			AsStringWithFlags(t, FmtBareStrings, FmtDataConversionConfig(dcc), FmtLocation(loc)),
 		), nil
@@ -4290,6 +4291,402 @@ func ParseDTSQuery(s string) (Datum, error) {
 	return NewDTSQuery(v), nil
 }
 
+// DJSONPath is the jsonpath Datum.
+type DJSONPath struct {
+	jsonpath.Path
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DJSONPath) Format(ctx *FmtCtx) {
+	s := d.Path.String()
+	if ctx.HasFlags(fmtRawStrings) || ctx.HasFlags(fmtPgwireFormat) {
+		ctx.WriteString(s)
+	} else {
+		lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, s, ctx.flags.EncodeFlags())
+	}
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DJSONPath) ResolvedType() *types.T {
+	return types.JSONPath
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DJSONPath) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DJSONPath) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DJSONPath) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DJSONPath)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return strings.Compare(d.Path.String(), v.Path.String()), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DJSONPath) Prev(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DJSONPath) Next(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DJSONPath) IsMin(_ CompareContext) bool {
+	return false
+}
+
+// IsMax implements the Datum interface.
+func (d *DJSONPath) IsMax(_ CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DJSONPath) Max(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DJSONPath) Min(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Size implements the Datum interface.
+func (d *DJSONPath) Size() uintptr {
+	return unsafe.Sizeof(*d) + uintptr(len(d.Path.String()))
+}
+
+// AsDJSONPath attempts to retrieve a DJSONPath from an Expr, returning a
+// DJSONPath and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DJSONPath wrapped by a *DOidWrapper is possible.
+func AsDJSONPath(e Expr) (*DJSONPath, bool) {
+	switch t := e.(type) {
+	case *DJSONPath:
+		return t, true
+	case *DOidWrapper:
+		return AsDJSONPath(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDJSONPath attempts to retrieve a DJSONPath from an Expr, panicking if
+// the assertion fails.
+func MustBeDJSONPath(e Expr) *DJSONPath {
+	v, ok := AsDJSONPath(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DJSONPath, found %T", e))
+	}
+	return v
+}
+
+// NewDJSONPath is a helper routine to create a DJSONPath initialized from its
+// argument.
+func NewDJSONPath(p jsonpath.Path) *DJSONPath {
+	return &DJSONPath{Path: p}
+}
+
+// ParseDJSONPath takes a string of jsonpath and returns a DJSONPath value.
+func ParseDJSONPath(s string) (Datum, error) {
+	p, err := jsonpath.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return NewDJSONPath(*p), nil
+}
+
+// DRange is the Datum for range types, such as int4range and tstzrange. A
+// DRange is always canonical: ranges over discrete types use inclusive lower
+// and exclusive upper bounds, unbounded sides are never inclusive, and a range
+// that contains no values is represented by the empty range.
+type DRange struct {
+	typ *types.T
+	// Lower and Upper are the bounds of the range. A nil bound means that the
+	// range is unbounded on that side. Both bounds are nil for the empty range.
+	Lower, Upper Datum
+	// LowerInc and UpperInc indicate whether the corresponding bound is
+	// included in the range.
+	LowerInc, UpperInc bool
+	// Empty is true if the range contains no values.
+	Empty bool
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DRange) Format(ctx *FmtCtx) {
+	var buf bytes.Buffer
+	d.formatText(ctx, &buf)
+	if ctx.HasFlags(fmtRawStrings) || ctx.HasFlags(fmtPgwireFormat) || ctx.HasFlags(FmtBareStrings) {
+		ctx.Write(buf.Bytes())
+	} else {
+		lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, buf.String(), ctx.flags.EncodeFlags())
+	}
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DRange) ResolvedType() *types.T {
+	return d.typ
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DRange) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DRange) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DRange) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DRange)
+	if !ok || d.typ.Oid() != v.typ.Oid() {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return compareRanges(ctx, d, v)
+}
+
+// Prev implements the Datum interface.
+func (d *DRange) Prev(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DRange) Next(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DRange) IsMin(_ CompareContext) bool {
+	return d.Empty
+}
+
+// IsMax implements the Datum interface.
+func (d *DRange) IsMax(_ CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DRange) Max(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DRange) Min(_ CompareContext) (Datum, bool) {
+	// The empty range sorts before all other ranges.
+	return NewDEmptyRange(d.typ), true
+}
+
+// Size implements the Datum interface.
+func (d *DRange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	if d.Lower != nil {
+		sz += d.Lower.Size()
+	}
+	if d.Upper != nil {
+		sz += d.Upper.Size()
+	}
+	return sz
+}
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DRange) IsComposite() bool {
+	if cdLower, ok := d.Lower.(CompositeDatum); ok && cdLower.IsComposite() {
+		return true
+	}
+	if cdUpper, ok := d.Upper.(CompositeDatum); ok && cdUpper.IsComposite() {
+		return true
+	}
+	return false
+}
+
+// AsDRange attempts to retrieve a DRange from an Expr, returning a DRange and
+// a flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DRange wrapped by a
+// *DOidWrapper is possible.
+func AsDRange(e Expr) (*DRange, bool) {
+	switch t := e.(type) {
+	case *DRange:
+		return t, true
+	case *DOidWrapper:
+		return AsDRange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDRange attempts to retrieve a DRange from an Expr, panicking if the
+// assertion fails.
+func MustBeDRange(e Expr) *DRange {
+	v, ok := AsDRange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DRange, found %T", e))
+	}
+	return v
+}
+
+// DMultirange is the Datum for multirange types, such as int4multirange. The
+// ranges of a DMultirange are always non-empty, sorted, and neither overlap
+// nor are adjacent to each other.
+type DMultirange struct {
+	typ    *types.T
+	Ranges []*DRange
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DMultirange) Format(ctx *FmtCtx) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, r := range d.Ranges {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		r.formatText(ctx, &buf)
+	}
+	buf.WriteByte('}')
+	if ctx.HasFlags(fmtRawStrings) || ctx.HasFlags(fmtPgwireFormat) || ctx.HasFlags(FmtBareStrings) {
+		ctx.Write(buf.Bytes())
+	} else {
+		lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, buf.String(), ctx.flags.EncodeFlags())
+	}
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DMultirange) ResolvedType() *types.T {
+	return d.typ
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (d *DMultirange) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DMultirange) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DMultirange) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DMultirange)
+	if !ok || d.typ.Oid() != v.typ.Oid() {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	for i := 0; i < len(d.Ranges) && i < len(v.Ranges); i++ {
+		c, err := compareRanges(ctx, d.Ranges[i], v.Ranges[i])
+		if err != nil || c != 0 {
+			return c, err
+		}
+	}
+	switch {
+	case len(d.Ranges) < len(v.Ranges):
+		return -1, nil
+	case len(d.Ranges) > len(v.Ranges):
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Prev implements the Datum interface.
+func (d *DMultirange) Prev(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DMultirange) Next(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DMultirange) IsMin(_ CompareContext) bool {
+	return len(d.Ranges) == 0
+}
+
+// IsMax implements the Datum interface.
+func (d *DMultirange) IsMax(_ CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DMultirange) Max(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DMultirange) Min(_ CompareContext) (Datum, bool) {
+	// The empty multirange sorts before all other multiranges.
+	return NewDEmptyMultirange(d.typ), true
+}
+
+// Size implements the Datum interface.
+func (d *DMultirange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	for _, r := range d.Ranges {
+		sz += r.Size()
+	}
+	return sz
+}
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DMultirange) IsComposite() bool {
+	for _, r := range d.Ranges {
+		if r.IsComposite() {
+			return true
+		}
+	}
+	return false
+}
+
+// AsDMultirange attempts to retrieve a DMultirange from an Expr, returning a
+// DMultirange and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DMultirange wrapped by a *DOidWrapper is possible.
+func AsDMultirange(e Expr) (*DMultirange, bool) {
+	switch t := e.(type) {
+	case *DMultirange:
+		return t, true
+	case *DOidWrapper:
+		return AsDMultirange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDMultirange attempts to retrieve a DMultirange from an Expr, panicking
+// if the assertion fails.
+func MustBeDMultirange(e Expr) *DMultirange {
+	v, ok := AsDMultirange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DMultirange, found %T", e))
+	}
+	return v
+}
+
 // DTSVector is the tsvector Datum.
 type DTSVector struct {
 	tsearch.TSVector
@@ -6160,6 +6557,9 @@ var baseDatumTypeSizes = map[types.Family]struct {
 	types.TimestampFamily:      {unsafe.Sizeof(DTimestamp{}), fixedSize},
 	types.TimestampTZFamily:    {unsafe.Sizeof(DTimestampTZ{}), fixedSize},
 	types.TSQueryFamily:        {unsafe.Sizeof(DTSQuery{}), variableSize},
+	types.JSONPathFamily:       {unsafe.Sizeof(DJSONPath{}), variableSize},
+	types.RangeFamily:          {unsafe.Sizeof(DRange{}), variableSize},
+	types.MultirangeFamily:     {unsafe.Sizeof(DMultirange{}), variableSize},
 	types.TSVectorFamily:       {unsafe.Sizeof(DTSVector{}), variableSize},
 	types.IntervalFamily:       {unsafe.Sizeof(DInterval{}), fixedSize},
 	types.JsonFamily:           {unsafe.Sizeof(DJSON{}), variableSize},
@@ -0,0 +1,75 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
+
+// DoBlock represents a DO statement, which executes an anonymous code block.
+type DoBlock struct {
+	// Code is the body of the anonymous code block.
+	Code string
+	// Language is the language in which the code block is written. It is
+	// PL/pgSQL if no language was specified.
+	Language RoutineLanguage
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DoBlock) Format(ctx *FmtCtx) {
+	ctx.WriteString("DO ")
+	if node.Language != RoutineLangPLpgSQL {
+		ctx.FormatNode(node.Language)
+		ctx.WriteByte(' ')
+	}
+	// The code block is formatted as a string literal rather than with dollar
+	// quotes, since a DO statement may itself be nested in a dollar-quoted
+	// routine body.
+	if ctx.flags.HasFlags(FmtAnonymize) || ctx.flags.HasFlags(FmtHideConstants) {
+		ctx.WriteString("'_'")
+	} else {
+		lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, node.Code, ctx.flags.EncodeFlags())
+	}
+}
+
+var _ Statement = &DoBlock{}
+
+// MakeDoBlock constructs a DO statement from the given options, which must
+// include exactly one code block and at most one language.
+func MakeDoBlock(options RoutineOptions) (*DoBlock, error) {
+	var hasCode, hasLang bool
+	res := &DoBlock{Language: RoutineLangPLpgSQL}
+	for _, option := range options {
+		switch t := option.(type) {
+		case RoutineBodyStr:
+			if hasCode {
+				return nil, ErrConflictingRoutineOption
+			}
+			hasCode = true
+			res.Code = string(t)
+		case RoutineLanguage:
+			if hasLang {
+				return nil, ErrConflictingRoutineOption
+			}
+			hasLang = true
+			res.Language = t
+		default:
+			return nil, errors.AssertionFailedf("unexpected DO option: %s", AsString(option))
+		}
+	}
+	if !hasCode {
+		return nil, pgerror.New(pgcode.Syntax, "no inline code specified")
+	}
+	return res, nil
+}
@@ -1441,16 +1441,42 @@ func cmpOpFixups(
 		))
 	}
 
+	appendCmpOp := func(sym treecmp.ComparisonOperatorSymbol, cmpOp *CmpOp) {
+		s, ok := cmpOps[sym]
+		if !ok {
+			s = new(CmpOpOverloads)
+			cmpOps[sym] = s
+		}
+		s.overloads = append(s.overloads, cmpOp)
+	}
+
+	// Range and multirange comparisons. Each range type has its own overloads,
+	// rather than using the AnyRange wildcard, so that untyped constants are
+	// resolved to the type of the other operand.
+	for i, r := range types.RangeTypes {
+		mr := types.MultirangeTypes[i]
+		elem := r.RangeSubtype()
+		for _, t := range []*types.T{r, mr} {
+			appendCmpOp(treecmp.EQ, makeEqFn(t, t, volatility.Immutable))
+			appendCmpOp(treecmp.LT, makeLtFn(t, t, volatility.Immutable))
+			appendCmpOp(treecmp.LE, makeLeFn(t, t, volatility.Immutable))
+			appendCmpOp(treecmp.IsNotDistinctFrom, makeIsFn(t, t, volatility.Immutable))
+			appendCmpOp(treecmp.In, makeEvalTupleIn(t, volatility.Immutable))
+		}
+		for _, args := range [][2]*types.T{{r, r}, {r, mr}, {mr, r}, {mr, mr}} {
+			appendCmpOp(treecmp.Contains, makeRangeCmpOp(args[0], args[1], &RangeContainsOp{}))
+			appendCmpOp(treecmp.ContainedBy, makeRangeCmpOp(args[0], args[1], &RangeContainedByOp{}))
+			appendCmpOp(treecmp.Overlaps, makeRangeCmpOp(args[0], args[1], &RangeOverlapsOp{}))
+			appendCmpOp(treecmp.Adjacent, makeRangeCmpOp(args[0], args[1], &RangeAdjacentOp{}))
+		}
+		for _, t := range []*types.T{r, mr} {
+			appendCmpOp(treecmp.Contains, makeRangeCmpOp(t, elem, &RangeContainsOp{}))
+			appendCmpOp(treecmp.ContainedBy, makeRangeCmpOp(elem, t, &RangeContainedByOp{}))
+		}
+	}
+
 	// Array equality comparisons.
 	for _, t := range append(types.Scalar, types.AnyEnum) {
-		appendCmpOp := func(sym treecmp.ComparisonOperatorSymbol, cmpOp *CmpOp) {
-			s, ok := cmpOps[sym]
-			if !ok {
-				s = new(CmpOpOverloads)
-				cmpOps[sym] = s
-			}
-			s.overloads = append(s.overloads, cmpOp)
-		}
 		appendCmpOp(treecmp.EQ, &CmpOp{
 			LeftType:   types.MakeArray(t),
 			RightType:  types.MakeArray(t),
@@ -1986,6 +2012,20 @@ var CmpOps = cmpOpFixups(map[treecmp.ComparisonOperatorSymbol]*CmpOpOverloads{
 			EvalOp:     &TSMatchesVectorQueryOp{},
 			Volatility: volatility.Immutable,
 		},
+		{
+			LeftType:   types.Jsonb,
+			RightType:  types.JSONPath,
+			EvalOp:     &JSONPathMatchOp{},
+			Volatility: volatility.Immutable,
+		},
+	}},
+	treecmp.JSONPathExists: {overloads: []*CmpOp{
+		{
+			LeftType:   types.Jsonb,
+			RightType:  types.JSONPath,
+			EvalOp:     &JSONPathExistsOp{},
+			Volatility: volatility.Immutable,
+		},
 	}},
 })
 
@@ -2070,6 +2110,15 @@ func makeEvalTupleIn(typ *types.T, v volatility.V) *CmpOp {
 	}
 }
 
+func makeRangeCmpOp(a, b *types.T, op BinaryEvalOp) *CmpOp {
+	return &CmpOp{
+		LeftType:   a,
+		RightType:  b,
+		EvalOp:     op,
+		Volatility: volatility.Immutable,
+	}
+}
+
 // MultipleResultsError is returned by QueryRow when more than one result is
 // encountered.
 type MultipleResultsError struct {
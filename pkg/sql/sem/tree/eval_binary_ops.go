@@ -75,6 +75,18 @@ type OverlapsArrayOp struct{}
 // OverlapsINetOp is a BinaryEvalOp.
 type OverlapsINetOp struct{}
 
+// RangeContainsOp is a BinaryEvalOp.
+type RangeContainsOp struct{}
+
+// RangeContainedByOp is a BinaryEvalOp.
+type RangeContainedByOp struct{}
+
+// RangeOverlapsOp is a BinaryEvalOp.
+type RangeOverlapsOp struct{}
+
+// RangeAdjacentOp is a BinaryEvalOp.
+type RangeAdjacentOp struct{}
+
 // TSMatchesVectorQueryOp is a BinaryEvalOp.
 type TSMatchesVectorQueryOp struct{}
 
@@ -370,6 +382,12 @@ type JSONSomeExistsOp struct{}
 // JSONAllExistsOp is a BinaryEvalOp.
 type JSONAllExistsOp struct{}
 
+// JSONPathExistsOp is a BinaryEvalOp.
+type JSONPathExistsOp struct{}
+
+// JSONPathMatchOp is a BinaryEvalOp.
+type JSONPathMatchOp struct{}
+
 // JSONFetchValPathOp is a BinaryEvalOp.
 type JSONFetchValPathOp struct{}
 
@@ -319,8 +319,9 @@ func (fd *ResolvedFunctionDefinition) MatchOverload(
 		// Special handling of routines.
 		//
 		// First, apply regular postgres resolution approach of using only
-		// the input types.
-		if ol.params().MatchIdentical(paramTypes) {
+		// the input types. Note that the declared types are used, so the
+		// array type of a VARIADIC parameter must be specified.
+		if ol.Types.MatchIdentical(paramTypes) {
 			return true
 		}
 		if tryDefaultExprs && len(ol.defaultExprs()) > 0 {
@@ -295,10 +295,32 @@ type Overload struct {
 	// UDFContainsOnlySignature is false, then DEFAULT expressions are included
 	// into RoutineParams.
 	DefaultExprs Exprs
+	// Variadic is true if the last input parameter of the routine is VARIADIC,
+	// in which case any number of trailing arguments of the element type of the
+	// parameter can be provided for it. Types contains the array type of the
+	// parameter.
+	Variadic bool
+	// SessionOverride, if set, describes the changes made to the session while
+	// the routine executes. It is only set for UDFs declared with SECURITY
+	// DEFINER or SET clauses.
+	SessionOverride *RoutineSessionOverride
+	// AggregateDef, if set, describes how a user-defined aggregate is computed.
+	// It is only set for aggregates created with CREATE AGGREGATE when
+	// UDFContainsOnlySignature is false.
+	AggregateDef *AggregateDefinition
 }
 
 // params implements the overloadImpl interface.
-func (b Overload) params() TypeList { return b.Types }
+func (b Overload) params() TypeList {
+	if b.Variadic {
+		typs := b.Types.Types()
+		return VariadicType{
+			FixedTypes: typs[:len(typs)-1],
+			VarType:    typs[len(typs)-1].ArrayContents(),
+		}
+	}
+	return b.Types
+}
 
 // returnType implements the overloadImpl interface.
 func (b Overload) returnType() ReturnTyper { return b.ReturnType }
@@ -0,0 +1,221 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+var malformedRangeError = pgerror.Newf(pgcode.InvalidTextRepresentation, "malformed range literal")
+var malformedMultirangeError = pgerror.Newf(pgcode.InvalidTextRepresentation, "malformed multirange literal")
+
+type rangeParseState struct {
+	s                string
+	ctx              ParseContext
+	dependsOnContext bool
+	// t is the range type being parsed.
+	t *types.T
+}
+
+func (p *rangeParseState) advance() {
+	_, l := utf8.DecodeRuneInString(p.s)
+	p.s = p.s[l:]
+}
+
+func (p *rangeParseState) eatWhitespace() {
+	for unicode.IsSpace(p.peek()) {
+		p.advance()
+	}
+}
+
+func (p *rangeParseState) peek() rune {
+	r, _ := utf8.DecodeRuneInString(p.s)
+	return r
+}
+
+func (p *rangeParseState) eof() bool {
+	return len(p.s) == 0
+}
+
+// parseRange parses a single range, such as [1,10) or empty.
+func (p *rangeParseState) parseRange() (*DRange, error) {
+	p.eatWhitespace()
+	if len(p.s) >= len("empty") && strings.EqualFold(p.s[:len("empty")], "empty") {
+		p.s = p.s[len("empty"):]
+		return NewDEmptyRange(p.t), nil
+	}
+	var lowerInc, upperInc bool
+	switch p.peek() {
+	case '[':
+		lowerInc = true
+	case '(':
+	default:
+		return nil, errors.WithDetail(malformedRangeError, "Missing left parenthesis or bracket.")
+	}
+	p.advance()
+	lower, err := p.parseBound()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() != ',' {
+		return nil, errors.WithDetail(malformedRangeError, "Missing comma after lower bound.")
+	}
+	p.advance()
+	upper, err := p.parseBound()
+	if err != nil {
+		return nil, err
+	}
+	switch p.peek() {
+	case ']':
+		upperInc = true
+	case ')':
+	default:
+		return nil, errors.WithDetail(malformedRangeError, "Too many commas.")
+	}
+	p.advance()
+	return NewDRange(rangeParseCompareContext{}, p.t, lower, upper, lowerInc, upperInc)
+}
+
+// parseBound parses a range bound, stopping at the comma or closing
+// parenthesis or bracket that follows it. An empty unquoted bound is infinite,
+// in which case nil is returned. As in Postgres, bounds can be quoted with
+// double quotes, and a backslash escapes the character that follows it.
+func (p *rangeParseState) parseBound() (Datum, error) {
+	var sb strings.Builder
+	inQuote, quoted := false, false
+	for {
+		if p.eof() {
+			return nil, errors.WithDetail(malformedRangeError, "Unexpected end of input.")
+		}
+		ch := p.s[0]
+		if !inQuote && (ch == ',' || ch == ')' || ch == ']') {
+			break
+		}
+		p.s = p.s[1:]
+		switch ch {
+		case '\\':
+			if p.eof() {
+				return nil, errors.WithDetail(malformedRangeError, "Unexpected end of input.")
+			}
+			sb.WriteByte(p.s[0])
+			p.s = p.s[1:]
+		case '"':
+			if inQuote && !p.eof() && p.s[0] == '"' {
+				// Inside quotes, a doubled double quote is an escaped double quote.
+				sb.WriteByte('"')
+				p.s = p.s[1:]
+			} else {
+				inQuote = !inQuote
+				quoted = true
+			}
+		default:
+			sb.WriteByte(ch)
+		}
+	}
+	if sb.Len() == 0 && !quoted {
+		return nil, nil
+	}
+	d, dependsOnContext, err := ParseAndRequireString(p.t.RangeSubtype(), sb.String(), p.ctx)
+	if err != nil {
+		return nil, err
+	}
+	if dependsOnContext {
+		p.dependsOnContext = true
+	}
+	return d, nil
+}
+
+// ParseDRangeFromString parses the string-form of a range, such as '[1,10)'
+// or 'empty'. The input type t is the type of the range to parse.
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseContext (either for the time or the local timezone).
+func ParseDRangeFromString(
+	ctx ParseContext, s string, t *types.T,
+) (_ *DRange, dependsOnContext bool, _ error) {
+	p := rangeParseState{s: s, ctx: ctx, t: t}
+	r, err := p.parseRange()
+	if err == nil {
+		p.eatWhitespace()
+		if !p.eof() {
+			err = errors.WithDetail(malformedRangeError, "Junk after right parenthesis or bracket.")
+		}
+	}
+	if err != nil {
+		return nil, false, MakeParseError(s, t, err)
+	}
+	return r, p.dependsOnContext, nil
+}
+
+// ParseDMultirangeFromString parses the string-form of a multirange, such as
+// '{[1,3),[5,7)}'. The input type t is the type of the multirange to parse.
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseContext (either for the time or the local timezone).
+func ParseDMultirangeFromString(
+	ctx ParseContext, s string, t *types.T,
+) (_ *DMultirange, dependsOnContext bool, _ error) {
+	p := rangeParseState{s: s, ctx: ctx, t: types.RangeOfMultirange(t)}
+	ranges, err := p.parseMultirange()
+	if err != nil {
+		return nil, false, MakeParseError(s, t, err)
+	}
+	mr, err := NewDMultirange(rangeParseCompareContext{}, t, ranges)
+	if err != nil {
+		return nil, false, MakeParseError(s, t, err)
+	}
+	return mr, p.dependsOnContext, nil
+}
+
+// parseMultirange parses the ranges of a multirange, which are enclosed in
+// braces and separated by commas.
+func (p *rangeParseState) parseMultirange() ([]*DRange, error) {
+	p.eatWhitespace()
+	if p.peek() != '{' {
+		return nil, errors.WithDetail(malformedMultirangeError, "Missing left brace.")
+	}
+	p.advance()
+	p.eatWhitespace()
+	var ranges []*DRange
+	if p.peek() == '}' {
+		p.advance()
+	} else {
+		for {
+			r, err := p.parseRange()
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, r)
+			p.eatWhitespace()
+			if p.peek() == ',' {
+				p.advance()
+				continue
+			}
+			if p.peek() == '}' {
+				p.advance()
+				break
+			}
+			return nil, errors.WithDetail(malformedMultirangeError, "Expected comma or end of multirange.")
+		}
+	}
+	p.eatWhitespace()
+	if !p.eof() {
+		return nil, errors.WithDetail(malformedMultirangeError, "Junk after closing right brace.")
+	}
+	return ranges, nil
+}
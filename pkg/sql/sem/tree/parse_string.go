@@ -106,6 +106,12 @@ func ParseAndRequireString(
 		if err == nil {
 			d = NewDEnum(e)
 		}
+	case types.JSONPathFamily:
+		d, err = ParseDJSONPath(s)
+	case types.RangeFamily:
+		d, dependsOnContext, err = ParseDRangeFromString(ctx, s, t)
+	case types.MultirangeFamily:
+		d, dependsOnContext, err = ParseDMultirangeFromString(ctx, s, t)
 	case types.TSQueryFamily:
 		d, err = ParseDTSQuery(s)
 	case types.TSVectorFamily:
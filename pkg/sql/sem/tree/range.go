@@ -0,0 +1,673 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"bytes"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+// RangeFlags describe the bounds of a range. The values match the flags used
+// by Postgres in the binary representation of ranges.
+type RangeFlags byte
+
+const (
+	// RangeEmpty indicates that the range is empty.
+	RangeEmpty RangeFlags = 0x01
+	// RangeLowerInc indicates that the lower bound is inclusive.
+	RangeLowerInc RangeFlags = 0x02
+	// RangeUpperInc indicates that the upper bound is inclusive.
+	RangeUpperInc RangeFlags = 0x04
+	// RangeLowerInf indicates that the range has no lower bound.
+	RangeLowerInf RangeFlags = 0x08
+	// RangeUpperInf indicates that the range has no upper bound.
+	RangeUpperInf RangeFlags = 0x10
+)
+
+var errRangeBoundsOutOfOrder = pgerror.New(pgcode.DataException,
+	"range lower bound must be less than or equal to range upper bound")
+
+// NewDEmptyRange returns the empty range of the given range type.
+func NewDEmptyRange(typ *types.T) *DRange {
+	return &DRange{typ: typ, Empty: true}
+}
+
+// NewDRange returns the canonical range of the given range type with the given
+// bounds. A nil bound makes the range unbounded on that side. An error is
+// returned if the lower bound is greater than the upper bound.
+func NewDRange(
+	ctx CompareContext, typ *types.T, lower, upper Datum, lowerInc, upperInc bool,
+) (*DRange, error) {
+	d := &DRange{
+		typ:      typ,
+		Lower:    lower,
+		Upper:    upper,
+		LowerInc: lowerInc && lower != nil,
+		UpperInc: upperInc && upper != nil,
+	}
+	if lower != nil && upper != nil {
+		c, err := lower.CompareError(ctx, upper)
+		if err != nil {
+			return nil, err
+		}
+		if c > 0 {
+			return nil, errRangeBoundsOutOfOrder
+		}
+		if c == 0 && !(d.LowerInc && d.UpperInc) {
+			return NewDEmptyRange(typ), nil
+		}
+	}
+	if err := d.canonicalize(ctx); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// NewDRangeFromFlags returns a range of the given range type with the given
+// bounds and flags. Unlike NewDRange, the range is not canonicalized, so this
+// should only be used for bounds that are already canonical, such as when
+// decoding a range.
+func NewDRangeFromFlags(typ *types.T, flags RangeFlags, lower, upper Datum) *DRange {
+	if flags&RangeEmpty != 0 {
+		return NewDEmptyRange(typ)
+	}
+	d := &DRange{
+		typ:      typ,
+		LowerInc: flags&RangeLowerInc != 0,
+		UpperInc: flags&RangeUpperInc != 0,
+	}
+	if flags&RangeLowerInf == 0 {
+		d.Lower = lower
+	}
+	if flags&RangeUpperInf == 0 {
+		d.Upper = upper
+	}
+	return d
+}
+
+// Flags returns the flags describing the bounds of the range.
+func (d *DRange) Flags() RangeFlags {
+	if d.Empty {
+		return RangeEmpty
+	}
+	var flags RangeFlags
+	if d.LowerInc {
+		flags |= RangeLowerInc
+	}
+	if d.UpperInc {
+		flags |= RangeUpperInc
+	}
+	if d.Lower == nil {
+		flags |= RangeLowerInf
+	}
+	if d.Upper == nil {
+		flags |= RangeUpperInf
+	}
+	return flags
+}
+
+// canonicalize converts ranges over discrete types to use an inclusive lower
+// bound and an exclusive upper bound, so that equal ranges have equal
+// representations. For example, the int4range (1,5] becomes [2,6).
+func (d *DRange) canonicalize(ctx CompareContext) error {
+	subtype := d.typ.RangeSubtype()
+	switch subtype.Family() {
+	case types.IntFamily, types.DateFamily:
+	default:
+		return nil
+	}
+	var err error
+	if d.Lower != nil && !d.LowerInc {
+		if d.Lower, err = rangeBoundSucc(subtype, d.Lower); err != nil {
+			return err
+		}
+		d.LowerInc = true
+	}
+	if d.Upper != nil && d.UpperInc {
+		if d.Upper, err = rangeBoundSucc(subtype, d.Upper); err != nil {
+			return err
+		}
+		d.UpperInc = false
+	}
+	if d.Lower != nil && d.Upper != nil {
+		c, err := d.Lower.CompareError(ctx, d.Upper)
+		if err != nil {
+			return err
+		}
+		if c == 0 {
+			*d = DRange{typ: d.typ, Empty: true}
+		}
+	}
+	return nil
+}
+
+// rangeBoundSucc returns the value that immediately follows the given bound of
+// a range over a discrete type.
+func rangeBoundSucc(subtype *types.T, d Datum) (Datum, error) {
+	switch t := d.(type) {
+	case *DInt:
+		if *t == math.MaxInt64 || (subtype.Width() == 32 && *t >= math.MaxInt32) {
+			return nil, ErrIntOutOfRange
+		}
+		return NewDInt(*t + 1), nil
+	case *DDate:
+		if !t.IsFinite() {
+			return t, nil
+		}
+		next, err := t.AddDays(1)
+		if err != nil {
+			return nil, err
+		}
+		return NewDDate(next), nil
+	}
+	return nil, errors.AssertionFailedf("unexpected bound %T of discrete range", d)
+}
+
+// rangeBound is one of the bounds of a non-empty range. A nil val indicates
+// that the bound is infinite.
+type rangeBound struct {
+	val   Datum
+	inc   bool
+	lower bool
+}
+
+func (d *DRange) lowerBound() rangeBound {
+	return rangeBound{val: d.Lower, inc: d.LowerInc, lower: true}
+}
+
+func (d *DRange) upperBound() rangeBound {
+	return rangeBound{val: d.Upper, inc: d.UpperInc}
+}
+
+// compareRangeBounds compares two range bounds, which can each be either a
+// lower or an upper bound. An inclusive lower bound sorts before an exclusive
+// lower bound with the same value, while an inclusive upper bound sorts after
+// an exclusive upper bound with the same value.
+func compareRangeBounds(ctx CompareContext, b1, b2 rangeBound) (int, error) {
+	switch {
+	case b1.val == nil && b2.val == nil:
+		if b1.lower == b2.lower {
+			return 0, nil
+		}
+		return boundSide(b1.lower), nil
+	case b1.val == nil:
+		return boundSide(b1.lower), nil
+	case b2.val == nil:
+		return -boundSide(b2.lower), nil
+	}
+	c, err := b1.val.CompareError(ctx, b2.val)
+	if err != nil || c != 0 {
+		return c, err
+	}
+	switch {
+	case !b1.inc && !b2.inc:
+		if b1.lower == b2.lower {
+			return 0, nil
+		}
+		return -boundSide(b1.lower), nil
+	case !b1.inc:
+		return -boundSide(b1.lower), nil
+	case !b2.inc:
+		return boundSide(b2.lower), nil
+	}
+	return 0, nil
+}
+
+// boundSide returns -1 for lower bounds and 1 for upper bounds.
+func boundSide(lower bool) int {
+	if lower {
+		return -1
+	}
+	return 1
+}
+
+// compareRanges compares two ranges of the same type. The empty range sorts
+// before all other ranges, and other ranges are ordered by their lower bounds
+// and then by their upper bounds.
+func compareRanges(ctx CompareContext, a, b *DRange) (int, error) {
+	switch {
+	case a.Empty && b.Empty:
+		return 0, nil
+	case a.Empty:
+		return -1, nil
+	case b.Empty:
+		return 1, nil
+	}
+	c, err := compareRangeBounds(ctx, a.lowerBound(), b.lowerBound())
+	if err != nil || c != 0 {
+		return c, err
+	}
+	return compareRangeBounds(ctx, a.upperBound(), b.upperBound())
+}
+
+// ContainsElem returns whether the range contains the given value.
+func (d *DRange) ContainsElem(ctx CompareContext, elem Datum) (bool, error) {
+	if d.Empty {
+		return false, nil
+	}
+	if d.Lower != nil {
+		c, err := d.Lower.CompareError(ctx, elem)
+		if err != nil || c > 0 || (c == 0 && !d.LowerInc) {
+			return false, err
+		}
+	}
+	if d.Upper != nil {
+		c, err := d.Upper.CompareError(ctx, elem)
+		if err != nil || c < 0 || (c == 0 && !d.UpperInc) {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// ContainsRange returns whether the range contains all values of the other
+// range. All ranges contain the empty range.
+func (d *DRange) ContainsRange(ctx CompareContext, other *DRange) (bool, error) {
+	if other.Empty {
+		return true, nil
+	}
+	if d.Empty {
+		return false, nil
+	}
+	c, err := compareRangeBounds(ctx, d.lowerBound(), other.lowerBound())
+	if err != nil || c > 0 {
+		return false, err
+	}
+	c, err = compareRangeBounds(ctx, d.upperBound(), other.upperBound())
+	if err != nil || c < 0 {
+		return false, err
+	}
+	return true, nil
+}
+
+// ContainsMultirange returns whether the range contains all ranges of the
+// given multirange.
+func (d *DRange) ContainsMultirange(ctx CompareContext, mr *DMultirange) (bool, error) {
+	for _, r := range mr.Ranges {
+		if ok, err := d.ContainsRange(ctx, r); err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// Overlaps returns whether the two ranges have any values in common.
+func (d *DRange) Overlaps(ctx CompareContext, other *DRange) (bool, error) {
+	if d.Empty || other.Empty {
+		return false, nil
+	}
+	for _, r := range [2][2]*DRange{{d, other}, {other, d}} {
+		// Check whether the lower bound of r[0] falls within r[1].
+		c, err := compareRangeBounds(ctx, r[0].lowerBound(), r[1].lowerBound())
+		if err != nil {
+			return false, err
+		}
+		if c >= 0 {
+			c, err = compareRangeBounds(ctx, r[0].lowerBound(), r[1].upperBound())
+			if err != nil || c <= 0 {
+				return err == nil, err
+			}
+		}
+	}
+	return false, nil
+}
+
+// Adjacent returns whether the two ranges do not overlap, but there are no
+// values between them. For example, [1,3) and [3,5) are adjacent.
+func (d *DRange) Adjacent(ctx CompareContext, other *DRange) (bool, error) {
+	if d.Empty || other.Empty {
+		return false, nil
+	}
+	if ok, err := boundsAdjacent(ctx, d.upperBound(), other.lowerBound()); err != nil || ok {
+		return ok, err
+	}
+	return boundsAdjacent(ctx, other.upperBound(), d.lowerBound())
+}
+
+// boundsAdjacent returns whether the given upper bound is immediately followed
+// by the given lower bound. Ranges over discrete types are always canonical, so
+// this does not need to consider bounds such as 2] and [3, which would
+// otherwise be adjacent for integers.
+func boundsAdjacent(ctx CompareContext, upper, lower rangeBound) (bool, error) {
+	if upper.val == nil || lower.val == nil {
+		return false, nil
+	}
+	c, err := upper.val.CompareError(ctx, lower.val)
+	if err != nil || c != 0 {
+		return false, err
+	}
+	return upper.inc != lower.inc, nil
+}
+
+// union returns the smallest range that contains both ranges. It must only be
+// called on non-empty ranges that overlap or are adjacent.
+func (d *DRange) union(ctx CompareContext, other *DRange) (*DRange, error) {
+	res := *d
+	c, err := compareRangeBounds(ctx, d.lowerBound(), other.lowerBound())
+	if err != nil {
+		return nil, err
+	}
+	if c > 0 {
+		res.Lower, res.LowerInc = other.Lower, other.LowerInc
+	}
+	c, err = compareRangeBounds(ctx, d.upperBound(), other.upperBound())
+	if err != nil {
+		return nil, err
+	}
+	if c < 0 {
+		res.Upper, res.UpperInc = other.Upper, other.UpperInc
+	}
+	return &res, nil
+}
+
+// rangeBoundQuoteChars are the characters that require a range bound to be
+// quoted in the text representation of a range.
+const rangeBoundQuoteChars = " \t\v\f\r\n()[],\"\\"
+
+// formatText writes the Postgres text representation of the range, such as
+// [1,10) or empty, to buf.
+func (d *DRange) formatText(ctx *FmtCtx, buf *bytes.Buffer) {
+	if d.Empty {
+		buf.WriteString("empty")
+		return
+	}
+	if d.LowerInc {
+		buf.WriteByte('[')
+	} else {
+		buf.WriteByte('(')
+	}
+	if d.Lower != nil {
+		formatRangeBound(ctx, buf, d.Lower)
+	}
+	buf.WriteByte(',')
+	if d.Upper != nil {
+		formatRangeBound(ctx, buf, d.Upper)
+	}
+	if d.UpperInc {
+		buf.WriteByte(']')
+	} else {
+		buf.WriteByte(')')
+	}
+}
+
+// formatRangeBound writes the text representation of a range bound to buf,
+// quoting it if necessary.
+func formatRangeBound(ctx *FmtCtx, buf *bytes.Buffer, d Datum) {
+	f := NewFmtCtx(
+		FmtPgwireText,
+		FmtDataConversionConfig(ctx.dataConversionConfig),
+		FmtLocation(ctx.location),
+	)
+	f.FormatNode(d)
+	s := f.CloseAndGetString()
+	if s != "" && !strings.ContainsAny(s, rangeBoundQuoteChars) {
+		buf.WriteString(s)
+		return
+	}
+	buf.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\\' {
+			buf.WriteByte(s[i])
+		}
+		buf.WriteByte(s[i])
+	}
+	buf.WriteByte('"')
+}
+
+// NewDEmptyMultirange returns the empty multirange of the given multirange
+// type.
+func NewDEmptyMultirange(typ *types.T) *DMultirange {
+	return &DMultirange{typ: typ}
+}
+
+// NewDMultirange returns the canonical multirange of the given multirange type
+// that contains the given ranges. Empty ranges are dropped, and ranges that
+// overlap or are adjacent to each other are merged.
+func NewDMultirange(ctx CompareContext, typ *types.T, ranges []*DRange) (*DMultirange, error) {
+	sorted := make([]*DRange, 0, len(ranges))
+	for _, r := range ranges {
+		if !r.Empty {
+			sorted = append(sorted, r)
+		}
+	}
+	var err error
+	sort.SliceStable(sorted, func(i, j int) bool {
+		c, cmpErr := compareRanges(ctx, sorted[i], sorted[j])
+		if cmpErr != nil {
+			err = cmpErr
+		}
+		return c < 0
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := NewDEmptyMultirange(typ)
+	for _, r := range sorted {
+		if n := len(res.Ranges); n > 0 {
+			last := res.Ranges[n-1]
+			merge, err := last.Overlaps(ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			if !merge {
+				if merge, err = last.Adjacent(ctx, r); err != nil {
+					return nil, err
+				}
+			}
+			if merge {
+				if res.Ranges[n-1], err = last.union(ctx, r); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+		res.Ranges = append(res.Ranges, r)
+	}
+	return res, nil
+}
+
+// NewDMultirangeFromRanges returns a multirange of the given multirange type
+// with the given ranges. Unlike NewDMultirange, the ranges are not sorted or
+// merged, so this should only be used for ranges that are already canonical,
+// such as when decoding a multirange.
+func NewDMultirangeFromRanges(typ *types.T, ranges []*DRange) *DMultirange {
+	return &DMultirange{typ: typ, Ranges: ranges}
+}
+
+// ContainsElem returns whether any range of the multirange contains the given
+// value.
+func (d *DMultirange) ContainsElem(ctx CompareContext, elem Datum) (bool, error) {
+	for _, r := range d.Ranges {
+		if ok, err := r.ContainsElem(ctx, elem); err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// ContainsRange returns whether the multirange contains all values of the
+// given range. Since the ranges of a multirange are never adjacent, the range
+// must be contained by a single range of the multirange.
+func (d *DMultirange) ContainsRange(ctx CompareContext, r *DRange) (bool, error) {
+	if r.Empty {
+		return true, nil
+	}
+	for _, mr := range d.Ranges {
+		if ok, err := mr.ContainsRange(ctx, r); err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// ContainsMultirange returns whether the multirange contains all ranges of the
+// other multirange.
+func (d *DMultirange) ContainsMultirange(ctx CompareContext, other *DMultirange) (bool, error) {
+	for _, r := range other.Ranges {
+		if ok, err := d.ContainsRange(ctx, r); err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// OverlapsRange returns whether any range of the multirange overlaps the given
+// range.
+func (d *DMultirange) OverlapsRange(ctx CompareContext, r *DRange) (bool, error) {
+	for _, mr := range d.Ranges {
+		if ok, err := mr.Overlaps(ctx, r); err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// OverlapsMultirange returns whether any range of the multirange overlaps any
+// range of the other multirange.
+func (d *DMultirange) OverlapsMultirange(ctx CompareContext, other *DMultirange) (bool, error) {
+	for _, r := range other.Ranges {
+		if ok, err := d.OverlapsRange(ctx, r); err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// AdjacentRange returns whether the given range is adjacent to the multirange,
+// meaning that it is adjacent to the first or last range of the multirange.
+func (d *DMultirange) AdjacentRange(ctx CompareContext, r *DRange) (bool, error) {
+	if len(d.Ranges) == 0 || r.Empty {
+		return false, nil
+	}
+	first, last := d.Ranges[0], d.Ranges[len(d.Ranges)-1]
+	if ok, err := boundsAdjacent(ctx, r.upperBound(), first.lowerBound()); err != nil || ok {
+		return ok, err
+	}
+	return boundsAdjacent(ctx, last.upperBound(), r.lowerBound())
+}
+
+// AdjacentMultirange returns whether the two multiranges are adjacent, meaning
+// that the last range of one is adjacent to the first range of the other.
+func (d *DMultirange) AdjacentMultirange(ctx CompareContext, other *DMultirange) (bool, error) {
+	if len(d.Ranges) == 0 || len(other.Ranges) == 0 {
+		return false, nil
+	}
+	if ok, err := other.AdjacentRange(ctx, d.Ranges[len(d.Ranges)-1]); err != nil || ok {
+		return ok, err
+	}
+	return other.AdjacentRange(ctx, d.Ranges[0])
+}
+
+// RangeContains returns whether a, which is a range or multirange, contains b,
+// which is a range, multirange, or an element of a's subtype. It implements
+// the @> operator for ranges and the <@ operator with its arguments swapped.
+func RangeContains(ctx CompareContext, a, b Datum) (bool, error) {
+	a, b = ctx.UnwrapDatum(a), ctx.UnwrapDatum(b)
+	switch t := a.(type) {
+	case *DRange:
+		switch o := b.(type) {
+		case *DRange:
+			return t.ContainsRange(ctx, o)
+		case *DMultirange:
+			return t.ContainsMultirange(ctx, o)
+		default:
+			return t.ContainsElem(ctx, b)
+		}
+	case *DMultirange:
+		switch o := b.(type) {
+		case *DRange:
+			return t.ContainsRange(ctx, o)
+		case *DMultirange:
+			return t.ContainsMultirange(ctx, o)
+		default:
+			return t.ContainsElem(ctx, b)
+		}
+	}
+	return false, errors.AssertionFailedf("unexpected range operand %T", a)
+}
+
+// RangeOverlaps returns whether a and b, each of which is a range or a
+// multirange, have any values in common. It implements the && operator for
+// ranges.
+func RangeOverlaps(ctx CompareContext, a, b Datum) (bool, error) {
+	a, b = ctx.UnwrapDatum(a), ctx.UnwrapDatum(b)
+	switch t := a.(type) {
+	case *DRange:
+		switch o := b.(type) {
+		case *DRange:
+			return t.Overlaps(ctx, o)
+		case *DMultirange:
+			return o.OverlapsRange(ctx, t)
+		}
+	case *DMultirange:
+		switch o := b.(type) {
+		case *DRange:
+			return t.OverlapsRange(ctx, o)
+		case *DMultirange:
+			return t.OverlapsMultirange(ctx, o)
+		}
+	}
+	return false, errors.AssertionFailedf("unexpected range operands %T and %T", a, b)
+}
+
+// RangeAdjacent returns whether a and b, each of which is a range or a
+// multirange, are adjacent. It implements the -|- operator.
+func RangeAdjacent(ctx CompareContext, a, b Datum) (bool, error) {
+	a, b = ctx.UnwrapDatum(a), ctx.UnwrapDatum(b)
+	switch t := a.(type) {
+	case *DRange:
+		switch o := b.(type) {
+		case *DRange:
+			return t.Adjacent(ctx, o)
+		case *DMultirange:
+			return o.AdjacentRange(ctx, t)
+		}
+	case *DMultirange:
+		switch o := b.(type) {
+		case *DRange:
+			return t.AdjacentRange(ctx, o)
+		case *DMultirange:
+			return t.AdjacentMultirange(ctx, o)
+		}
+	}
+	return false, errors.AssertionFailedf("unexpected range operands %T and %T", a, b)
+}
+
+// rangeParseCompareContext is the CompareContext used to compare range bounds
+// while parsing ranges, when no evaluation context is available. Bounds are
+// never wrapped or placeholders, and they always have the same type, so the
+// comparison does not depend on the session.
+type rangeParseCompareContext struct{}
+
+var _ CompareContext = rangeParseCompareContext{}
+
+// UnwrapDatum is part of the CompareContext interface.
+func (rangeParseCompareContext) UnwrapDatum(d Datum) Datum { return d }
+
+// GetLocation is part of the CompareContext interface.
+func (rangeParseCompareContext) GetLocation() *time.Location { return time.UTC }
+
+// GetRelativeParseTime is part of the CompareContext interface.
+func (rangeParseCompareContext) GetRelativeParseTime() time.Time { return timeutil.Now() }
+
+// MustGetPlaceholderValue is part of the CompareContext interface.
+func (rangeParseCompareContext) MustGetPlaceholderValue(p *Placeholder) Datum {
+	panic(errors.AssertionFailedf("unexpected placeholder %s in range bound", p))
+}
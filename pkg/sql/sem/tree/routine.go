@@ -13,6 +13,7 @@ package tree
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/buildutil"
@@ -135,6 +136,39 @@ type RoutineExpr struct {
 	// CursorDeclaration contains the information needed to open a SQL cursor with
 	// the result of the *first* body statement. It may be unset.
 	CursorDeclaration *RoutineOpenCursor
+
+	// ResultBuffer is set for a set-returning PLpgSQL routine. The rows produced
+	// by RETURN NEXT and RETURN QUERY statements are added to the buffer, and
+	// form the result of the routine. The result of the final body statement is
+	// discarded.
+	ResultBuffer *RoutineResultBuffer
+
+	// ReturnNextBuffer is set for a sub-routine of a set-returning PLpgSQL
+	// routine that implements a RETURN NEXT or RETURN QUERY statement. The rows
+	// produced by the *first* body statement are added to the buffer.
+	ReturnNextBuffer *RoutineResultBuffer
+
+	// SessionOverride, if set, describes the changes made to the session while
+	// the routine executes. It is set for UDFs declared with SECURITY DEFINER
+	// or SET clauses.
+	SessionOverride *RoutineSessionOverride
+}
+
+// RoutineSessionOverride describes the changes made to the session for the
+// duration of a routine's execution.
+type RoutineSessionOverride struct {
+	// User, if set, is the user with whose privileges the routine executes.
+	// It is the owner of a SECURITY DEFINER routine.
+	User username.SQLUsername
+	// Settings are the session variables set by the SET clauses of the
+	// routine, in the order in which they are applied.
+	Settings []RoutineSessionSetting
+}
+
+// RoutineSessionSetting is a session variable set while a routine executes.
+type RoutineSessionSetting struct {
+	Name  string
+	Value string
 }
 
 // NewTypedRoutineExpr returns a new RoutineExpr that is well-typed.
@@ -220,6 +254,25 @@ type RoutineOpenCursor struct {
 	CursorSQL string
 }
 
+// RoutineResultBuffer is shared state between a set-returning PLpgSQL routine
+// and the sub-routines that implement its RETURN NEXT and RETURN QUERY
+// statements.
+type RoutineResultBuffer struct {
+	// Writer adds rows to the result of the set-returning routine. It is set
+	// while the routine is executing, and is restored to its previous value once
+	// execution finishes, so that recursive invocations each add rows to their
+	// own result.
+	Writer RoutineResultWriter
+}
+
+// RoutineResultWriter is used to add rows to the result of a set-returning
+// PLpgSQL routine.
+type RoutineResultWriter interface {
+	// AddRow adds a row to the result. Note that the caller owns the row slice
+	// and might reuse it.
+	AddRow(ctx context.Context, row Datums) error
+}
+
 // BlockState is shared state between all routines that make up a PLpgSQL block.
 // It allows for coordination between the routines for exception handling.
 type BlockState struct {
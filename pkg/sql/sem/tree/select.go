@@ -780,6 +780,50 @@ func (node *GroupBy) Format(ctx *FmtCtx) {
 	}
 }
 
+// GroupingSetType represents the kind of a grouping set in a GROUP BY clause.
+type GroupingSetType int
+
+const (
+	// GroupingSets represents an explicit list of grouping sets.
+	GroupingSets GroupingSetType = iota
+	// Rollup represents the grouping sets formed by each prefix of the given
+	// expressions, including the empty prefix.
+	Rollup
+	// Cube represents the grouping sets formed by each subset of the given
+	// expressions, including the empty set.
+	Cube
+)
+
+var groupingSetTypeName = [...]string{
+	GroupingSets: "GROUPING SETS",
+	Rollup:       "ROLLUP",
+	Cube:         "CUBE",
+}
+
+func (t GroupingSetType) String() string {
+	return groupingSetTypeName[t]
+}
+
+// GroupingSet represents a GROUPING SETS, ROLLUP or CUBE item in a GROUP BY
+// clause. A Tuple in Exprs represents a grouping set with several columns, or
+// the empty grouping set if it has no elements. GROUPING SETS items may be
+// nested, so Exprs can contain other GroupingSets.
+type GroupingSet struct {
+	Type  GroupingSetType
+	Exprs Exprs
+}
+
+// Format implements the NodeFormatter interface.
+func (node *GroupingSet) Format(ctx *FmtCtx) {
+	ctx.WriteString(node.Type.String())
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Exprs)
+	ctx.WriteByte(')')
+}
+
+// String implements the fmt.Stringer interface.
+func (node *GroupingSet) String() string { return AsString(node) }
+
 // DistinctOn represents a DISTINCT ON clause.
 type DistinctOn []Expr
 
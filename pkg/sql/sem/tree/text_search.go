@@ -0,0 +1,235 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import "strings"
+
+// The names of the options of a CREATE TEXT SEARCH CONFIGURATION statement.
+const (
+	TextSearchConfigOptionParser = "parser"
+	TextSearchConfigOptionCopy   = "copy"
+)
+
+// The names of the options of a CREATE TEXT SEARCH DICTIONARY statement.
+const (
+	TextSearchDictOptionTemplate     = "template"
+	TextSearchDictOptionLanguage     = "language"
+	TextSearchDictOptionStopWords    = "stopwords"
+	TextSearchDictOptionStopWordList = "stopword_list"
+	TextSearchDictOptionSynonyms     = "synonyms"
+	TextSearchDictOptionAccept       = "accept"
+)
+
+// CreateTextSearchConfig represents a CREATE TEXT SEARCH CONFIGURATION
+// statement.
+type CreateTextSearchConfig struct {
+	Name    *UnresolvedObjectName
+	Options TextSearchOptions
+}
+
+var _ Statement = &CreateTextSearchConfig{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTextSearchConfig) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE TEXT SEARCH CONFIGURATION ")
+	ctx.FormatNode(node.Name)
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Options)
+	ctx.WriteByte(')')
+}
+
+// CreateTextSearchDictionary represents a CREATE TEXT SEARCH DICTIONARY
+// statement.
+type CreateTextSearchDictionary struct {
+	Name    *UnresolvedObjectName
+	Options TextSearchOptions
+}
+
+var _ Statement = &CreateTextSearchDictionary{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTextSearchDictionary) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE TEXT SEARCH DICTIONARY ")
+	ctx.FormatNode(node.Name)
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Options)
+	ctx.WriteByte(')')
+}
+
+// TextSearchOptions is a list of options of a CREATE TEXT SEARCH statement.
+type TextSearchOptions []TextSearchOption
+
+// Format implements the NodeFormatter interface.
+func (node *TextSearchOptions) Format(ctx *FmtCtx) {
+	for i := range *node {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*node)[i])
+	}
+}
+
+// TextSearchOption is a single option of a CREATE TEXT SEARCH statement, such
+// as TEMPLATE = snowball or STOPWORDS = 'english'.
+type TextSearchOption struct {
+	// Name is the name of the option.
+	Name string
+	// Value is the value of the option. It is an *UnresolvedName if the value
+	// is an identifier, a *StrVal if it is a string, a DefaultVal if it is
+	// DEFAULT, and a *DBool if it is TRUE or FALSE.
+	Value Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *TextSearchOption) Format(ctx *FmtCtx) {
+	ctx.WriteString(strings.ToUpper(node.Name))
+	ctx.WriteString(" = ")
+	ctx.FormatNode(node.Value)
+}
+
+// AlterTextSearchConfig represents an ALTER TEXT SEARCH CONFIGURATION
+// statement.
+type AlterTextSearchConfig struct {
+	Name *UnresolvedObjectName
+	Cmd  AlterTextSearchConfigCmd
+}
+
+var _ Statement = &AlterTextSearchConfig{}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTextSearchConfig) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER TEXT SEARCH CONFIGURATION ")
+	ctx.FormatNode(node.Name)
+	ctx.FormatNode(node.Cmd)
+}
+
+// AlterTextSearchConfigCmd represents a command of an ALTER TEXT SEARCH
+// CONFIGURATION statement.
+type AlterTextSearchConfigCmd interface {
+	NodeFormatter
+	alterTextSearchConfigCmd()
+}
+
+func (*AlterTextSearchConfigAddMapping) alterTextSearchConfigCmd()  {}
+func (*AlterTextSearchConfigDropMapping) alterTextSearchConfigCmd() {}
+func (*AlterTextSearchConfigSetWeight) alterTextSearchConfigCmd()   {}
+
+var _ AlterTextSearchConfigCmd = &AlterTextSearchConfigAddMapping{}
+var _ AlterTextSearchConfigCmd = &AlterTextSearchConfigDropMapping{}
+var _ AlterTextSearchConfigCmd = &AlterTextSearchConfigSetWeight{}
+
+// AlterTextSearchConfigAddMapping represents an ADD MAPPING or an ALTER
+// MAPPING command, which sets the dictionaries used for the given token
+// types.
+type AlterTextSearchConfigAddMapping struct {
+	// Alter is set for an ALTER MAPPING command, which requires the token
+	// types to already be mapped.
+	Alter        bool
+	TokenTypes   NameList
+	Dictionaries []*UnresolvedObjectName
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTextSearchConfigAddMapping) Format(ctx *FmtCtx) {
+	if node.Alter {
+		ctx.WriteString(" ALTER MAPPING FOR ")
+	} else {
+		ctx.WriteString(" ADD MAPPING FOR ")
+	}
+	ctx.FormatNode(&node.TokenTypes)
+	ctx.WriteString(" WITH ")
+	for i := range node.Dictionaries {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(node.Dictionaries[i])
+	}
+}
+
+// AlterTextSearchConfigDropMapping represents a DROP MAPPING command.
+type AlterTextSearchConfigDropMapping struct {
+	IfExists   bool
+	TokenTypes NameList
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTextSearchConfigDropMapping) Format(ctx *FmtCtx) {
+	ctx.WriteString(" DROP MAPPING ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.WriteString("FOR ")
+	ctx.FormatNode(&node.TokenTypes)
+}
+
+// AlterTextSearchConfigSetWeight represents a SET WEIGHT command, which sets
+// the weight of the lexemes produced from the given token types.
+type AlterTextSearchConfigSetWeight struct {
+	Weight     string
+	TokenTypes NameList
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTextSearchConfigSetWeight) Format(ctx *FmtCtx) {
+	ctx.WriteString(" SET WEIGHT ")
+	ctx.FormatNode(NewStrVal(node.Weight))
+	ctx.WriteString(" FOR ")
+	ctx.FormatNode(&node.TokenTypes)
+}
+
+// DropTextSearchConfig represents a DROP TEXT SEARCH CONFIGURATION statement.
+type DropTextSearchConfig struct {
+	Names        []*UnresolvedObjectName
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropTextSearchConfig{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropTextSearchConfig) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP TEXT SEARCH CONFIGURATION ")
+	formatDropTextSearchObjects(ctx, node.Names, node.IfExists, node.DropBehavior)
+}
+
+// DropTextSearchDictionary represents a DROP TEXT SEARCH DICTIONARY
+// statement.
+type DropTextSearchDictionary struct {
+	Names        []*UnresolvedObjectName
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropTextSearchDictionary{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropTextSearchDictionary) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP TEXT SEARCH DICTIONARY ")
+	formatDropTextSearchObjects(ctx, node.Names, node.IfExists, node.DropBehavior)
+}
+
+func formatDropTextSearchObjects(
+	ctx *FmtCtx, names []*UnresolvedObjectName, ifExists bool, behavior DropBehavior,
+) {
+	if ifExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	for i := range names {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(names[i])
+	}
+	if behavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(behavior.String())
+	}
+}
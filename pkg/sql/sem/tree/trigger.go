@@ -0,0 +1,234 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import "github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+
+// CreateTrigger represents a CREATE TRIGGER statement.
+type CreateTrigger struct {
+	Replace bool
+	// Constraint is true for CREATE CONSTRAINT TRIGGER. Constraint triggers are
+	// always AFTER ... FOR EACH ROW triggers.
+	Constraint  bool
+	Name        Name
+	ActionTime  TriggerActionTime
+	Events      []*TriggerEvent
+	TableName   *UnresolvedObjectName
+	Transitions []*TriggerTransition
+	ForEach     TriggerForEach
+	When        Expr
+	FuncName    *UnresolvedName
+	FuncArgs    []string
+}
+
+var _ Statement = &CreateTrigger{}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	if node.Constraint {
+		ctx.WriteString("CONSTRAINT ")
+	}
+	ctx.WriteString("TRIGGER ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" ")
+	ctx.FormatNode(node.ActionTime)
+	ctx.WriteString(" ")
+	for i := range node.Events {
+		if i > 0 {
+			ctx.WriteString(" OR ")
+		}
+		ctx.FormatNode(node.Events[i])
+	}
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.TableName)
+	if len(node.Transitions) > 0 {
+		ctx.WriteString(" REFERENCING ")
+		for i := range node.Transitions {
+			if i > 0 {
+				ctx.WriteString(" ")
+			}
+			ctx.FormatNode(node.Transitions[i])
+		}
+	}
+	ctx.WriteString(" ")
+	ctx.FormatNode(node.ForEach)
+	if node.When != nil {
+		ctx.WriteString(" WHEN (")
+		ctx.FormatNode(node.When)
+		ctx.WriteString(")")
+	}
+	ctx.WriteString(" EXECUTE FUNCTION ")
+	ctx.FormatNode(node.FuncName)
+	ctx.WriteString("(")
+	for i := range node.FuncArgs {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		if ctx.flags.HasFlags(FmtHideConstants) {
+			ctx.WriteString("'_'")
+		} else {
+			lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, node.FuncArgs[i], ctx.flags.EncodeFlags())
+		}
+	}
+	ctx.WriteString(")")
+}
+
+// TriggerActionTime describes the timing of a trigger: before, after, or
+// instead of the event.
+type TriggerActionTime uint8
+
+const (
+	// TriggerActionTimeUnknown is the zero value, which is never produced by the
+	// parser.
+	TriggerActionTimeUnknown TriggerActionTime = iota
+	// TriggerActionTimeBefore indicates that the trigger fires before the event.
+	TriggerActionTimeBefore
+	// TriggerActionTimeAfter indicates that the trigger fires after the event.
+	TriggerActionTimeAfter
+	// TriggerActionTimeInsteadOf indicates that the trigger fires instead of the
+	// event. It is only valid for views.
+	TriggerActionTimeInsteadOf
+)
+
+var triggerActionTimeName = [...]string{
+	TriggerActionTimeUnknown:   "UNKNOWN",
+	TriggerActionTimeBefore:    "BEFORE",
+	TriggerActionTimeAfter:     "AFTER",
+	TriggerActionTimeInsteadOf: "INSTEAD OF",
+}
+
+func (t TriggerActionTime) String() string {
+	return triggerActionTimeName[t]
+}
+
+// Format implements the NodeFormatter interface.
+func (t TriggerActionTime) Format(ctx *FmtCtx) {
+	ctx.WriteString(t.String())
+}
+
+// TriggerEventType describes the type of mutation that fires a trigger.
+type TriggerEventType uint8
+
+const (
+	// TriggerEventTypeUnknown is the zero value, which is never produced by the
+	// parser.
+	TriggerEventTypeUnknown TriggerEventType = iota
+	// TriggerEventInsert indicates that the trigger fires for INSERT.
+	TriggerEventInsert
+	// TriggerEventUpdate indicates that the trigger fires for UPDATE.
+	TriggerEventUpdate
+	// TriggerEventDelete indicates that the trigger fires for DELETE.
+	TriggerEventDelete
+	// TriggerEventTruncate indicates that the trigger fires for TRUNCATE.
+	TriggerEventTruncate
+)
+
+var triggerEventTypeName = [...]string{
+	TriggerEventTypeUnknown: "UNKNOWN",
+	TriggerEventInsert:      "INSERT",
+	TriggerEventUpdate:      "UPDATE",
+	TriggerEventDelete:      "DELETE",
+	TriggerEventTruncate:    "TRUNCATE",
+}
+
+func (t TriggerEventType) String() string {
+	return triggerEventTypeName[t]
+}
+
+// TriggerEvent represents one of the events that fires a trigger. Columns is
+// only set for UPDATE OF <columns>.
+type TriggerEvent struct {
+	EventType TriggerEventType
+	Columns   NameList
+}
+
+// Format implements the NodeFormatter interface.
+func (node *TriggerEvent) Format(ctx *FmtCtx) {
+	ctx.WriteString(node.EventType.String())
+	if len(node.Columns) > 0 {
+		ctx.WriteString(" OF ")
+		ctx.FormatNode(&node.Columns)
+	}
+}
+
+// TriggerTransition represents a transition relation alias in the REFERENCING
+// clause of a CREATE TRIGGER statement.
+type TriggerTransition struct {
+	Name    Name
+	IsNew   bool
+	IsTable bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *TriggerTransition) Format(ctx *FmtCtx) {
+	if node.IsNew {
+		ctx.WriteString("NEW")
+	} else {
+		ctx.WriteString("OLD")
+	}
+	if node.IsTable {
+		ctx.WriteString(" TABLE")
+	} else {
+		ctx.WriteString(" ROW")
+	}
+	ctx.WriteString(" AS ")
+	ctx.FormatNode(&node.Name)
+}
+
+// TriggerForEach describes whether a trigger fires once for each row affected
+// by the event, or once for each statement.
+type TriggerForEach uint8
+
+const (
+	// TriggerForEachStatement indicates that the trigger fires once per
+	// statement. This is the default.
+	TriggerForEachStatement TriggerForEach = iota
+	// TriggerForEachRow indicates that the trigger fires once per row.
+	TriggerForEachRow
+)
+
+// Format implements the NodeFormatter interface.
+func (node TriggerForEach) Format(ctx *FmtCtx) {
+	if node == TriggerForEachRow {
+		ctx.WriteString("FOR EACH ROW")
+	} else {
+		ctx.WriteString("FOR EACH STATEMENT")
+	}
+}
+
+// DropTrigger represents a DROP TRIGGER statement.
+type DropTrigger struct {
+	IfExists     bool
+	Trigger      Name
+	Table        *UnresolvedObjectName
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropTrigger{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP TRIGGER ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Trigger)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(node.Table)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteString(" ")
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
@@ -392,6 +392,18 @@ func (expr *BinaryExpr) TypeCheck(
 	leftReturn := leftTyped.ResolvedType()
 	rightReturn := rightTyped.ResolvedType()
 
+	// A user-defined operator is used if no built-in operator matches, or if
+	// it matches the user-defined types of the operands.
+	if len(s.overloadIdxs) != 1 || leftReturn.UserDefined() || rightReturn.UserDefined() {
+		typed, ok, err := typeCheckUserDefinedOperator(
+			ctx, semaCtx, expr.Operator.Symbol.String(),
+			[]Expr{expr.Left, expr.Right}, []TypedExpr{leftTyped, rightTyped},
+		)
+		if err != nil || ok {
+			return typed, err
+		}
+	}
+
 	// Return NULL if at least one overload is possible, NULL is an argument,
 	// and none of the overloads accept NULL.
 	if leftReturn.Family() == types.UnknownFamily || rightReturn.Family() == types.UnknownFamily {
@@ -670,6 +682,26 @@ func (expr *CastExpr) TypeCheck(
 		return typedSubExpr, nil
 	}
 
+	// A cast created with CREATE CAST is performed by calling its function. The
+	// result of the function is cast further if it is not of the exact target
+	// type, e.g. if the target type has a type modifier.
+	if c, ok, err := cast.LookupUserDefinedCast(
+		ctx, semaCtx.UserDefinedCastResolver(), typedSubExpr.ResolvedType(), exprType,
+	); err != nil {
+		return nil, err
+	} else if ok {
+		fn := &FuncExpr{
+			Func:  ResolvableFunctionReference{FunctionReference: &FunctionOID{OID: c.Func}},
+			Exprs: Exprs{typedSubExpr},
+		}
+		if typedSubExpr, err = fn.TypeCheck(ctx, semaCtx, exprType); err != nil {
+			return nil, err
+		}
+		if typedSubExpr.ResolvedType().Identical(exprType) {
+			return typedSubExpr, nil
+		}
+	}
+
 	castFrom := typedSubExpr.ResolvedType()
 	allowStable := true
 	context := ""
@@ -961,6 +993,25 @@ func (expr *ComparisonExpr) TypeCheck(
 				expr.Operator.Symbol, leftTyped.ResolvedType(), rightTyped.ResolvedType(),
 			)
 		}
+		// A user-defined operator is used if no built-in operator matches, or if
+		// it matches the user-defined types of the operands.
+		if err != nil || leftTyped.ResolvedType().UserDefined() ||
+			rightTyped.ResolvedType().UserDefined() {
+			var typedOperands []TypedExpr
+			if err == nil {
+				typedOperands = []TypedExpr{leftTyped, rightTyped}
+			}
+			typed, ok, udoErr := typeCheckUserDefinedOperator(
+				ctx, semaCtx, expr.Operator.Symbol.String(),
+				[]Expr{expr.Left, expr.Right}, typedOperands,
+			)
+			if udoErr != nil {
+				return nil, udoErr
+			}
+			if ok {
+				return typed, nil
+			}
+		}
 	}
 	if err != nil {
 		return nil, err
@@ -1668,6 +1719,27 @@ func (expr *ColumnItem) TypeCheck(
 		"column %q does not exist", ErrString(expr))
 }
 
+// TypeCheck implements the Expr interface. GROUPING expressions are replaced
+// when the grouping columns of a query are built, so they can only be type
+// checked directly if they are used outside of a grouping context.
+func (expr *GroupingExpr) TypeCheck(
+	_ context.Context, semaCtx *SemaContext, desired *types.T,
+) (TypedExpr, error) {
+	if semaCtx != nil && semaCtx.Properties.IsSet(RejectAggregates) {
+		return nil, pgerror.Newf(pgcode.Grouping,
+			"grouping operations are not allowed in %s", semaCtx.Properties.Context())
+	}
+	return nil, pgerror.New(pgcode.Grouping, "GROUPING must be used with GROUP BY")
+}
+
+// TypeCheck implements the Expr interface. Grouping sets are expanded when the
+// grouping columns of a query are built, so they can never be type checked.
+func (expr *GroupingSet) TypeCheck(
+	_ context.Context, _ *SemaContext, desired *types.T,
+) (TypedExpr, error) {
+	return nil, pgerror.Newf(pgcode.Syntax, "%s is only allowed in GROUP BY", expr.Type)
+}
+
 // TypeCheck implements the Expr interface.
 func (expr UnqualifiedStar) TypeCheck(
 	_ context.Context, _ *SemaContext, desired *types.T,
@@ -1752,8 +1824,20 @@ func (expr *UnaryExpr) TypeCheck(
 	exprTyped := typedSubExprs[0]
 	exprReturn := exprTyped.ResolvedType()
 
-	// Return NULL if at least one overload is possible and NULL is an argument.
+	// A user-defined operator is used if no built-in operator matches, or if
+	// it matches the user-defined type of the operand.
 	numOps := len(s.overloadIdxs)
+	if numOps != 1 || exprReturn.UserDefined() {
+		typed, ok, err := typeCheckUserDefinedOperator(
+			ctx, semaCtx, expr.Operator.Symbol.String(),
+			[]Expr{expr.Expr}, []TypedExpr{exprTyped},
+		)
+		if err != nil || ok {
+			return typed, err
+		}
+	}
+
+	// Return NULL if at least one overload is possible and NULL is an argument.
 	if numOps > 0 {
 		if exprReturn.Family() == types.UnknownFamily {
 			return DNull, nil
@@ -2120,6 +2204,24 @@ func (d *DJSON) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedE
 	return d, nil
 }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DJSONPath) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DRange) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DMultirange) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DTSQuery) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
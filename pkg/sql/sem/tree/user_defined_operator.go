@@ -0,0 +1,157 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/lib/pq/oid"
+)
+
+// UserDefinedOperator is an operator created with CREATE OPERATOR. It is
+// evaluated by calling its function with the operands as arguments.
+type UserDefinedOperator struct {
+	// Symbol is the symbol of the operator, such as +.
+	Symbol string
+	// Left is the type of the left operand. It is nil for a prefix operator.
+	Left *types.T
+	// Right is the type of the right operand.
+	Right *types.T
+	// Func is the OID of the function which implements the operator.
+	Func oid.Oid
+}
+
+// UserDefinedOperatorResolver looks up the operators created with CREATE
+// OPERATOR.
+type UserDefinedOperatorResolver interface {
+	// ResolveOperator returns the user-defined operators with the given
+	// symbol in the schemas of the search path, in search path order.
+	ResolveOperator(ctx context.Context, symbol string, path SearchPath) ([]UserDefinedOperator, error)
+}
+
+// userDefinedOperatorResolver returns the resolver for user-defined
+// operators, or nil if there is none.
+func (sc *SemaContext) userDefinedOperatorResolver() UserDefinedOperatorResolver {
+	if sc == nil {
+		return nil
+	}
+	r, _ := sc.FunctionResolver.(UserDefinedOperatorResolver)
+	return r
+}
+
+// UserDefinedCastResolver returns the resolver for casts created with CREATE
+// CAST, or nil if there is none.
+func (sc *SemaContext) UserDefinedCastResolver() cast.UserDefinedResolver {
+	if sc == nil {
+		return nil
+	}
+	r, _ := sc.FunctionResolver.(cast.UserDefinedResolver)
+	return r
+}
+
+// typeCheckUserDefinedOperator type-checks an application of the operator
+// with the given symbol as a call to the function of a matching user-defined
+// operator. exprs holds the operands as written, and typed holds the operands
+// after they have been type-checked for the built-in operators. typed is nil
+// if that failed, in which case the operands are type-checked here. ok is
+// false if there is no matching user-defined operator.
+//
+// An operator matches if its operand types are the types of the operands.
+// Failing that, an operator matches if the operands which are constants can
+// become the operand types. The first matching operator in search path order
+// is used.
+func typeCheckUserDefinedOperator(
+	ctx context.Context, semaCtx *SemaContext, symbol string, exprs []Expr, typed []TypedExpr,
+) (_ TypedExpr, ok bool, _ error) {
+	r := semaCtx.userDefinedOperatorResolver()
+	if r == nil {
+		return nil, false, nil
+	}
+	for _, e := range exprs {
+		if semaCtx.isUnresolvedPlaceholder(e) {
+			return nil, false, nil
+		}
+	}
+	ops, err := r.ResolveOperator(ctx, symbol, semaCtx.SearchPath)
+	if err != nil || len(ops) == 0 {
+		return nil, false, err
+	}
+	if typed == nil {
+		typed = make([]TypedExpr, len(exprs))
+		for i, e := range exprs {
+			if typed[i], err = e.TypeCheck(ctx, semaCtx, types.Any); err != nil {
+				// The error of the built-in operators is reported instead.
+				return nil, false, nil //nolint:returnerrcheck
+			}
+		}
+	}
+	operandTypes := func(op *UserDefinedOperator) []*types.T {
+		if len(exprs) == 1 {
+			if op.Left != nil {
+				return nil
+			}
+			return []*types.T{op.Right}
+		}
+		if op.Left == nil {
+			return nil
+		}
+		return []*types.T{op.Left, op.Right}
+	}
+	matches := func(t, param *types.T) bool {
+		if t.Family() == types.UnknownFamily {
+			return true
+		}
+		if param.UserDefined() {
+			return t.Oid() == param.Oid()
+		}
+		return t.Equivalent(param)
+	}
+	for _, allowConstants := range []bool{false, true} {
+		for i := range ops {
+			params := operandTypes(&ops[i])
+			if params == nil {
+				continue
+			}
+			args := make(Exprs, len(exprs))
+			found := true
+			for j, param := range params {
+				if matches(typed[j].ResolvedType(), param) {
+					args[j] = typed[j]
+					continue
+				}
+				if allowConstants && isConstant(exprs[j]) {
+					if c, err := exprs[j].TypeCheck(ctx, semaCtx, param); err == nil &&
+						matches(c.ResolvedType(), param) {
+						args[j] = c
+						continue
+					}
+				}
+				found = false
+				break
+			}
+			if !found {
+				continue
+			}
+			fn := &FuncExpr{
+				Func:  ResolvableFunctionReference{FunctionReference: &FunctionOID{OID: ops[i].Func}},
+				Exprs: args,
+			}
+			typedFn, err := fn.TypeCheck(ctx, semaCtx, types.Any)
+			if err != nil {
+				return nil, false, err
+			}
+			return typedFn, true, nil
+		}
+	}
+	return nil, false, nil
+}
@@ -90,6 +90,9 @@ func ShowCreateTable(
 	if desc.IsTemporary() {
 		f.WriteString("TEMP ")
 	}
+	if desc.IsForeignTable() {
+		f.WriteString("FOREIGN ")
+	}
 	f.WriteString("TABLE ")
 	f.FormatNode(tn)
 	f.WriteString(" (")
@@ -144,9 +147,24 @@ func ShowCreateTable(
 			f.WriteString(fkCtx.String())
 		}
 	}
+	var hashIndexes []catalog.Index
 	for _, idx := range desc.PublicNonPrimaryIndexes() {
 		// Showing the primary index is handled above.
 
+		// Hash and BRIN indexes cannot be defined in a CREATE TABLE statement,
+		// so they are shown as separate CREATE INDEX statements below.
+		if idx.GetAccessMethod() == descpb.IndexDescriptor_HASH ||
+			idx.GetAccessMethod() == descpb.IndexDescriptor_BRIN {
+			hashIndexes = append(hashIndexes, idx)
+			continue
+		}
+
+		// The index of a DEFERRABLE unique constraint is implied by the
+		// constraint, which is shown below.
+		if isDeferrableUniqueIndex(desc, idx) {
+			continue
+		}
+
 		// Build the PARTITION BY clause.
 		var partitionBuf bytes.Buffer
 		if err := ShowCreatePartitioning(
@@ -193,10 +211,33 @@ func ShowCreateTable(
 		f.Buffer.WriteString(`)`)
 	}
 
-	if err := showCreateLocality(desc, f); err != nil {
+	if desc.IsForeignTable() {
+		// The locality of a foreign table cannot be specified, since its data is
+		// not stored in the cluster.
+		showForeignTableClause(desc, f)
+	} else if err := showCreateLocality(desc, f); err != nil {
 		return "", err
 	}
 
+	for _, idx := range hashIndexes {
+		idxStr, err := catformat.IndexForDisplay(
+			ctx,
+			desc,
+			tn,
+			idx,
+			"", /* partition */
+			fmtFlags,
+			p.RunParams(ctx).p.SemaCtx(),
+			p.RunParams(ctx).p.SessionData(),
+			catformat.IndexDisplayShowCreate,
+		)
+		if err != nil {
+			return "", err
+		}
+		f.WriteString(";\n")
+		f.WriteString(idxStr)
+	}
+
 	if !displayOptions.IgnoreComments {
 		if err := showComments(tn, desc, selectComment(ctx, p, desc.GetID()), &f.Buffer); err != nil {
 			return "", err
@@ -586,6 +586,12 @@ func showForeignKeyConstraint(
 		buf.WriteString(" ON UPDATE ")
 		buf.WriteString(tree.ForeignKeyReferenceActionType[fk.OnUpdate].String())
 	}
+	if fk.Deferrable {
+		buf.WriteString(" DEFERRABLE")
+		if fk.InitiallyDeferred {
+			buf.WriteString(" INITIALLY DEFERRED")
+		}
+	}
 	if fk.Validity != descpb.ConstraintValidity_Validated {
 		buf.WriteString(" NOT VALID")
 	}
@@ -621,6 +627,27 @@ func ShowCreateSequence(
 	return f.CloseAndGetString(), nil
 }
 
+// showForeignTableClause creates the SERVER and OPTIONS clauses of a CREATE
+// FOREIGN TABLE statement, writing them to tree.FmtCtx f.
+func showForeignTableClause(desc catalog.TableDescriptor, f *tree.FmtCtx) {
+	ft := desc.TableDesc().ForeignTable
+	f.WriteString(" SERVER ")
+	f.FormatName(ft.Server)
+	if len(ft.Options) == 0 {
+		return
+	}
+	f.WriteString(" OPTIONS (")
+	for i, o := range ft.Options {
+		if i > 0 {
+			f.WriteString(", ")
+		}
+		f.WriteString(o.Key)
+		f.WriteByte(' ')
+		f.FormatNode(tree.NewStrVal(o.Value))
+	}
+	f.WriteByte(')')
+}
+
 // showFamilyClause creates the FAMILY clauses for a CREATE statement, writing them
 // to tree.FmtCtx f
 func showFamilyClause(desc catalog.TableDescriptor, f *tree.FmtCtx) {
@@ -847,22 +874,44 @@ func showConstraintClause(
 			formatQuoteNames(&f.Buffer, c.GetName())
 			f.WriteString(" ")
 		}
-		f.WriteString("UNIQUE WITHOUT INDEX (")
-		colNames, err := catalog.ColumnNamesForIDs(desc, c.CollectKeyColumnIDs().Ordered())
-		if err != nil {
-			return err
+		uc := c.UniqueWithoutIndexDesc()
+		if uc.IsExclusion() {
+			if err := showExclusionElems(&f.Buffer, desc, uc); err != nil {
+				return err
+			}
+		} else {
+			if deferrableUniqueIndex(desc, c) != nil {
+				f.WriteString("UNIQUE (")
+			} else {
+				f.WriteString("UNIQUE WITHOUT INDEX (")
+			}
+			colNames, err := catalog.ColumnNamesForIDs(desc, c.CollectKeyColumnIDs().Ordered())
+			if err != nil {
+				return err
+			}
+			f.WriteString(strings.Join(colNames, ", "))
+			f.WriteString(")")
+		}
+		if uc.Deferrable {
+			f.WriteString(" DEFERRABLE")
+			if uc.InitiallyDeferred {
+				f.WriteString(" INITIALLY DEFERRED")
+			}
 		}
-		f.WriteString(strings.Join(colNames, ", "))
-		f.WriteString(")")
 		if c.IsPartial() {
-			f.WriteString(" WHERE ")
 			pred, err := schemaexpr.FormatExprForDisplay(
 				ctx, desc, c.GetPredicate(), semaCtx, sessionData, exprFmtFlags,
 			)
 			if err != nil {
 				return err
 			}
-			f.WriteString(pred)
+			if uc.IsExclusion() {
+				// The predicate of an exclusion constraint must be parenthesized.
+				f.WriteString(fmt.Sprintf(" WHERE (%s)", pred))
+			} else {
+				f.WriteString(" WHERE ")
+				f.WriteString(pred)
+			}
 		}
 		if !c.IsConstraintValidated() {
 			f.WriteString(" NOT VALID")
@@ -871,3 +920,31 @@ func showConstraintClause(
 	f.WriteString("\n)")
 	return nil
 }
+
+// showExclusionElems writes the EXCLUDE clause of the given exclusion
+// constraint, without its name, deferrability or predicate.
+func showExclusionElems(
+	buf *bytes.Buffer, desc catalog.TableDescriptor, uc *descpb.UniqueWithoutIndexConstraint,
+) error {
+	colNames, err := catalog.ColumnNamesForIDs(desc, uc.ColumnIDs)
+	if err != nil {
+		return err
+	}
+	buf.WriteString("EXCLUDE ")
+	if uc.ExclusionMethod != "" {
+		buf.WriteString("USING ")
+		buf.WriteString(uc.ExclusionMethod)
+		buf.WriteString(" ")
+	}
+	buf.WriteString("(")
+	for i, name := range colNames {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		formatQuoteNames(buf, name)
+		buf.WriteString(" WITH ")
+		buf.WriteString(uc.ExclusionOperators[i])
+	}
+	buf.WriteString(")")
+	return nil
+}
@@ -118,6 +118,24 @@ func NewIdentityColumnTypeError() error {
 		"identity column type must be INT, INT2, INT4 or INT8")
 }
 
+// NewDeferrablePrimaryKeyError creates an error for declaring a primary key
+// as DEFERRABLE.
+func NewDeferrablePrimaryKeyError() error {
+	return pgerror.New(pgcode.FeatureNotSupported,
+		"primary key constraints cannot be DEFERRABLE")
+}
+
+// NewAddColumnDeferrableUniqueError creates an error for adding a column with a
+// DEFERRABLE unique constraint to an existing table.
+func NewAddColumnDeferrableUniqueError() error {
+	return errors.WithHint(
+		pgerror.New(pgcode.FeatureNotSupported,
+			"adding a column with a DEFERRABLE unique constraint is unsupported"),
+		"add the column first, then run ALTER TABLE ... ADD CONSTRAINT to add a "+
+			"DEFERRABLE unique constraint on the column",
+	)
+}
+
 // NewInvalidSchemaDefinitionError creates an error for an invalid schema
 // definition such as a schema definition that doesn't parse.
 func NewInvalidSchemaDefinitionError(err error) error {
@@ -239,6 +257,19 @@ func NewWrongObjectTypeError(name tree.NodeFormatter, desiredObjType string) err
 		tree.ErrString(name), desiredObjType)
 }
 
+// NewWrongRoutineKindError creates an error for a routine statement, such as
+// DROP or ALTER, which names an aggregate when a function is expected, or vice
+// versa.
+func NewWrongRoutineKindError(op string, name string, isAggregate bool) error {
+	if isAggregate {
+		return errors.WithHintf(
+			pgerror.Newf(pgcode.WrongObjectType, "%s is an aggregate function", name),
+			"Use %s AGGREGATE to %s aggregate functions.", op, strings.ToLower(op),
+		)
+	}
+	return pgerror.Newf(pgcode.WrongObjectType, "function %s is not an aggregate", name)
+}
+
 // NewSyntaxErrorf creates a syntax error.
 func NewSyntaxErrorf(format string, args ...interface{}) error {
 	return pgerror.Newf(pgcode.Syntax, format, args...)
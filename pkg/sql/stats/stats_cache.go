@@ -300,6 +300,11 @@ func tableTypeCanHaveStats(table catalog.TableDescriptor) bool {
 		// Don't try to get statistics for views.
 		return false
 	}
+	if table.IsForeignTable() {
+		// Don't try to get statistics for foreign tables, whose data is not
+		// stored in the cluster.
+		return false
+	}
 	return true
 }
 
@@ -0,0 +1,782 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/evalcatalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+	"github.com/cockroachdb/errors"
+)
+
+// Text search dictionaries and configurations are stored in the descriptor of
+// the schema which contains them. A configuration maps token types to built-in
+// dictionaries, or to dictionaries of the same schema.
+
+type createTextSearchConfigNode struct {
+	n *tree.CreateTextSearchConfig
+}
+
+// CreateTextSearchConfig creates a text search configuration.
+// Privileges: CREATE on the schema.
+func (p *planner) CreateTextSearchConfig(
+	ctx context.Context, n *tree.CreateTextSearchConfig,
+) (planNode, error) {
+	if err := p.checkTextSearchEnabled(ctx, "CREATE TEXT SEARCH CONFIGURATION"); err != nil {
+		return nil, err
+	}
+	return &createTextSearchConfigNode{n: n}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because CREATE TEXT SEARCH CONFIGURATION performs multiple KV
+// operations on descriptors and expects to see its own writes.
+func (n *createTextSearchConfigNode) ReadingOwnWrites() {}
+
+func (n *createTextSearchConfigNode) startExec(params runParams) error {
+	scDesc, err := params.p.textSearchSchemaForCreate(params.ctx, n.n.Name)
+	if err != nil {
+		return err
+	}
+	name := n.n.Name.Object()
+	if scDesc.GetTextSearchConfig(name) != nil {
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"text search configuration %q already exists", name)
+	}
+
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("text_search_configuration"))
+
+	config := descpb.SchemaDescriptor_TextSearchConfig{
+		Name:       name,
+		OwnerProto: params.p.User().EncodeProto(),
+	}
+	var parserName, copyName string
+	for i := range n.n.Options {
+		o := &n.n.Options[i]
+		val, err := textSearchOptionString(o)
+		if err != nil {
+			return err
+		}
+		switch o.Name {
+		case tree.TextSearchConfigOptionParser:
+			parserName = val
+		case tree.TextSearchConfigOptionCopy:
+			copyName = val
+		default:
+			return pgerror.Newf(pgcode.Syntax,
+				"text search configuration parameter %q not recognized", o.Name)
+		}
+	}
+	switch {
+	case parserName != "" && copyName != "":
+		return pgerror.New(pgcode.Syntax, "cannot specify both PARSER and COPY options")
+	case parserName != "":
+		if parserName != "default" && parserName != "pg_catalog.default" {
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"text search parser %q does not exist", parserName)
+		}
+	case copyName != "":
+		if config.Mappings, err = params.p.copyTextSearchMappings(
+			params.ctx, scDesc, copyName,
+		); err != nil {
+			return err
+		}
+	default:
+		return pgerror.New(pgcode.InvalidObjectDefinition, "text search parser is required")
+	}
+
+	scDesc.AddTextSearchConfig(config)
+	return params.p.writeSchemaDescChange(
+		params.ctx, scDesc, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (*createTextSearchConfigNode) Next(runParams) (bool, error) { return false, nil }
+func (*createTextSearchConfigNode) Values() tree.Datums          { return tree.Datums{} }
+func (*createTextSearchConfigNode) Close(context.Context)        {}
+
+// copyTextSearchMappings returns the mappings of the configuration named by
+// the COPY option of CREATE TEXT SEARCH CONFIGURATION. The token types of a
+// built-in configuration are all mapped to its dictionary.
+func (p *planner) copyTextSearchMappings(
+	ctx context.Context, scDesc *schemadesc.Mutable, name string,
+) ([]descpb.SchemaDescriptor_TextSearchConfig_Mapping, error) {
+	var mappings []descpb.SchemaDescriptor_TextSearchConfig_Mapping
+	if dict, err := tsearch.BuiltinConfigDictionary(name); err == nil {
+		for _, typ := range tsearch.TokenTypes {
+			mappings = append(mappings, descpb.SchemaDescriptor_TextSearchConfig_Mapping{
+				TokenType: typ.String(),
+				Dictionaries: []descpb.SchemaDescriptor_TextSearchConfig_DictionaryRef{
+					{Name: dict, Builtin: true},
+				},
+			})
+		}
+		return mappings, nil
+	}
+	un, err := parser.ParseTableName(name)
+	if err != nil {
+		return nil, err
+	}
+	sc, err := p.lookupTextSearchObject(
+		ctx, p.Descriptors().ByName(p.txn).MaybeGet(), un, isTextSearchConfig,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if sc == nil {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"text search configuration %q does not exist", name)
+	}
+	if sc.GetID() != scDesc.GetID() {
+		return nil, unimplemented.NewWithIssue(7821,
+			"copying a text search configuration from another schema")
+	}
+	for _, m := range sc.GetTextSearchConfig(un.Object()).Mappings {
+		m.Dictionaries = append([]descpb.SchemaDescriptor_TextSearchConfig_DictionaryRef(nil), m.Dictionaries...)
+		mappings = append(mappings, m)
+	}
+	return mappings, nil
+}
+
+type createTextSearchDictionaryNode struct {
+	n *tree.CreateTextSearchDictionary
+}
+
+// CreateTextSearchDictionary creates a text search dictionary.
+// Privileges: CREATE on the schema.
+func (p *planner) CreateTextSearchDictionary(
+	ctx context.Context, n *tree.CreateTextSearchDictionary,
+) (planNode, error) {
+	if err := p.checkTextSearchEnabled(ctx, "CREATE TEXT SEARCH DICTIONARY"); err != nil {
+		return nil, err
+	}
+	return &createTextSearchDictionaryNode{n: n}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because CREATE TEXT SEARCH DICTIONARY performs multiple KV
+// operations on descriptors and expects to see its own writes.
+func (n *createTextSearchDictionaryNode) ReadingOwnWrites() {}
+
+func (n *createTextSearchDictionaryNode) startExec(params runParams) error {
+	scDesc, err := params.p.textSearchSchemaForCreate(params.ctx, n.n.Name)
+	if err != nil {
+		return err
+	}
+	name := n.n.Name.Object()
+	if scDesc.GetTextSearchDictionary(name) != nil {
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"text search dictionary %q already exists", name)
+	}
+
+	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("text_search_dictionary"))
+
+	dict := descpb.SchemaDescriptor_TextSearchDictionary{
+		Name:       name,
+		OwnerProto: params.p.User().EncodeProto(),
+	}
+	if err := applyTextSearchDictionaryOptions(&dict, n.n.Options); err != nil {
+		return err
+	}
+	// Check that the dictionary can be used.
+	if _, err := evalcatalog.MakeTextSearchDictionary(&dict); err != nil {
+		return err
+	}
+
+	scDesc.AddTextSearchDictionary(dict)
+	return params.p.writeSchemaDescChange(
+		params.ctx, scDesc, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (*createTextSearchDictionaryNode) Next(runParams) (bool, error) { return false, nil }
+func (*createTextSearchDictionaryNode) Values() tree.Datums          { return tree.Datums{} }
+func (*createTextSearchDictionaryNode) Close(context.Context)        {}
+
+// applyTextSearchDictionaryOptions sets the parameters of a dictionary given
+// in CREATE TEXT SEARCH DICTIONARY, and checks that they are allowed by its
+// template.
+func applyTextSearchDictionaryOptions(
+	dict *descpb.SchemaDescriptor_TextSearchDictionary, opts tree.TextSearchOptions,
+) error {
+	var template tsearch.DictTemplate
+	for i := range opts {
+		if opts[i].Name != tree.TextSearchDictOptionTemplate {
+			continue
+		}
+		val, err := textSearchOptionString(&opts[i])
+		if err != nil {
+			return err
+		}
+		if template, err = tsearch.ParseDictTemplate(strings.TrimPrefix(val, "pg_catalog.")); err != nil {
+			return err
+		}
+	}
+	if template == 0 {
+		return pgerror.New(pgcode.InvalidObjectDefinition, "text search template is required")
+	}
+	dict.Template = template.String()
+	dict.Reject = false
+
+	for i := range opts {
+		o := &opts[i]
+		if o.Name == tree.TextSearchDictOptionTemplate {
+			continue
+		}
+		val, err := textSearchOptionString(o)
+		if err != nil {
+			return err
+		}
+		var allowed bool
+		switch o.Name {
+		case tree.TextSearchDictOptionLanguage:
+			allowed = template == tsearch.DictTemplateSnowball
+			dict.Language = val
+		case tree.TextSearchDictOptionStopWords:
+			allowed = template != tsearch.DictTemplateSynonym
+			if _, err := tsearch.BuiltinStopWords(val); err != nil {
+				return err
+			}
+			dict.StopWords = val
+		case tree.TextSearchDictOptionStopWordList:
+			allowed = template != tsearch.DictTemplateSynonym
+			for w := range tsearch.ParseStopWordList(val) {
+				dict.StopWordList = append(dict.StopWordList, w)
+			}
+		case tree.TextSearchDictOptionSynonyms:
+			allowed = template == tsearch.DictTemplateSynonym
+			synonyms, err := tsearch.ParseSynonyms(val)
+			if err != nil {
+				return err
+			}
+			for w, s := range synonyms {
+				dict.Synonyms = append(dict.Synonyms,
+					descpb.SchemaDescriptor_TextSearchDictionary_Synonym{Word: w, Synonym: s})
+			}
+		case tree.TextSearchDictOptionAccept:
+			allowed = template == tsearch.DictTemplateSimple
+			accept, err := tree.ParseDBool(val)
+			if err != nil {
+				return err
+			}
+			dict.Reject = !bool(*accept)
+		}
+		if !allowed {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"unrecognized %s dictionary parameter: %q", template, o.Name)
+		}
+	}
+	// Keep the lists in a deterministic order.
+	sort.Strings(dict.StopWordList)
+	sort.Slice(dict.Synonyms, func(i, j int) bool {
+		return dict.Synonyms[i].Word < dict.Synonyms[j].Word
+	})
+
+	switch {
+	case template == tsearch.DictTemplateSnowball && dict.Language == "":
+		return pgerror.New(pgcode.InvalidParameterValue, "missing Language parameter")
+	case template == tsearch.DictTemplateSynonym && len(dict.Synonyms) == 0:
+		return pgerror.New(pgcode.InvalidParameterValue, "missing Synonyms parameter")
+	}
+	return nil
+}
+
+// textSearchOptionString returns the value of an option of CREATE TEXT
+// SEARCH as a string.
+func textSearchOptionString(o *tree.TextSearchOption) (string, error) {
+	switch v := o.Value.(type) {
+	case *tree.UnresolvedName:
+		return tree.AsStringWithFlags(v, tree.FmtBareIdentifiers), nil
+	case *tree.StrVal:
+		return v.RawString(), nil
+	case *tree.DBool:
+		return v.String(), nil
+	case tree.DefaultVal:
+		// DEFAULT is a reserved keyword, so it is not parsed as a name.
+		return "default", nil
+	}
+	return "", pgerror.Newf(pgcode.InvalidParameterValue, "%s requires a value", o.Name)
+}
+
+type alterTextSearchConfigNode struct {
+	n *tree.AlterTextSearchConfig
+}
+
+// AlterTextSearchConfig changes the mappings of a text search configuration.
+// Privileges: ownership of the configuration.
+func (p *planner) AlterTextSearchConfig(
+	ctx context.Context, n *tree.AlterTextSearchConfig,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER TEXT SEARCH CONFIGURATION",
+	); err != nil {
+		return nil, err
+	}
+	return &alterTextSearchConfigNode{n: n}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because ALTER TEXT SEARCH CONFIGURATION performs multiple KV
+// operations on descriptors and expects to see its own writes.
+func (n *alterTextSearchConfigNode) ReadingOwnWrites() {}
+
+func (n *alterTextSearchConfigNode) startExec(params runParams) error {
+	sc, err := params.p.lookupTextSearchObject(
+		params.ctx, params.p.Descriptors().ByName(params.p.txn).MaybeGet(), n.n.Name, isTextSearchConfig,
+	)
+	if err != nil {
+		return err
+	}
+	if sc == nil {
+		return pgerror.Newf(pgcode.UndefinedObject,
+			"text search configuration %q does not exist", n.n.Name.Object())
+	}
+	scDesc, err := params.p.Descriptors().MutableByID(params.p.txn).Schema(params.ctx, sc.GetID())
+	if err != nil {
+		return err
+	}
+	config := scDesc.GetTextSearchConfig(n.n.Name.Object())
+	if err := params.p.checkTextSearchOwnership(
+		params.ctx, "text search configuration", config.Name, config.OwnerProto,
+	); err != nil {
+		return err
+	}
+
+	telemetry.Inc(sqltelemetry.SchemaChangeAlterCounter("text_search_configuration"))
+
+	findMapping := func(tokenType tree.Name) (int, error) {
+		typ, err := tsearch.ParseTokenType(string(tokenType))
+		if err != nil {
+			return 0, err
+		}
+		for i := range config.Mappings {
+			if config.Mappings[i].TokenType == typ.String() {
+				return i, nil
+			}
+		}
+		return -1, nil
+	}
+	switch cmd := n.n.Cmd.(type) {
+	case *tree.AlterTextSearchConfigAddMapping:
+		dicts, err := params.p.resolveTextSearchDictionaries(params.ctx, scDesc, cmd.Dictionaries)
+		if err != nil {
+			return err
+		}
+		for _, tokenType := range cmd.TokenTypes {
+			idx, err := findMapping(tokenType)
+			if err != nil {
+				return err
+			}
+			switch {
+			case idx >= 0 && !cmd.Alter:
+				return pgerror.Newf(pgcode.DuplicateObject,
+					"mapping for token type %q already exists", tokenType)
+			case idx < 0 && cmd.Alter:
+				return pgerror.Newf(pgcode.UndefinedObject,
+					"mapping for token type %q does not exist", tokenType)
+			case idx >= 0:
+				config.Mappings[idx].Dictionaries = dicts
+			default:
+				config.Mappings = append(config.Mappings, descpb.SchemaDescriptor_TextSearchConfig_Mapping{
+					TokenType:    string(tokenType),
+					Dictionaries: dicts,
+				})
+			}
+		}
+
+	case *tree.AlterTextSearchConfigDropMapping:
+		for _, tokenType := range cmd.TokenTypes {
+			idx, err := findMapping(tokenType)
+			if err != nil {
+				return err
+			}
+			if idx < 0 {
+				if !cmd.IfExists {
+					return pgerror.Newf(pgcode.UndefinedObject,
+						"mapping for token type %q does not exist", tokenType)
+				}
+				params.p.BufferClientNotice(params.ctx, pgnotice.Newf(
+					"mapping for token type %q does not exist, skipping", tokenType))
+				continue
+			}
+			config.Mappings = append(config.Mappings[:idx], config.Mappings[idx+1:]...)
+		}
+
+	case *tree.AlterTextSearchConfigSetWeight:
+		if err := tsearch.ValidWeight(cmd.Weight); err != nil {
+			return err
+		}
+		weight := strings.ToUpper(cmd.Weight)
+		if weight == "D" {
+			weight = ""
+		}
+		for _, tokenType := range cmd.TokenTypes {
+			idx, err := findMapping(tokenType)
+			if err != nil {
+				return err
+			}
+			if idx < 0 {
+				return pgerror.Newf(pgcode.UndefinedObject,
+					"mapping for token type %q does not exist", tokenType)
+			}
+			config.Mappings[idx].Weight = weight
+		}
+
+	default:
+		return errors.AssertionFailedf("unknown ALTER TEXT SEARCH CONFIGURATION command %T", cmd)
+	}
+
+	return params.p.writeSchemaDescChange(
+		params.ctx, scDesc, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+func (*alterTextSearchConfigNode) Next(runParams) (bool, error) { return false, nil }
+func (*alterTextSearchConfigNode) Values() tree.Datums          { return tree.Datums{} }
+func (*alterTextSearchConfigNode) Close(context.Context)        {}
+
+// resolveTextSearchDictionaries resolves the dictionaries of a mapping of a
+// text search configuration in the given schema.
+func (p *planner) resolveTextSearchDictionaries(
+	ctx context.Context, scDesc catalog.SchemaDescriptor, names []*tree.UnresolvedObjectName,
+) ([]descpb.SchemaDescriptor_TextSearchConfig_DictionaryRef, error) {
+	refs := make([]descpb.SchemaDescriptor_TextSearchConfig_DictionaryRef, 0, len(names))
+	for _, un := range names {
+		if !un.HasExplicitSchema() || (un.NumParts == 2 && un.Schema() == "pg_catalog") {
+			if _, ok := tsearch.BuiltinDictionary(un.Object()); ok {
+				refs = append(refs, descpb.SchemaDescriptor_TextSearchConfig_DictionaryRef{
+					Name: un.Object(), Builtin: true,
+				})
+				continue
+			}
+		}
+		sc, err := p.lookupTextSearchObject(
+			ctx, p.Descriptors().ByName(p.txn).MaybeGet(), un, isTextSearchDictionary,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if sc == nil {
+			return nil, pgerror.Newf(pgcode.UndefinedObject,
+				"text search dictionary %q does not exist", un.Object())
+		}
+		if sc.GetID() != scDesc.GetID() {
+			return nil, unimplemented.NewWithIssuef(7821,
+				"text search dictionary %q is not in the schema of the configuration", un.Object())
+		}
+		refs = append(refs, descpb.SchemaDescriptor_TextSearchConfig_DictionaryRef{
+			Name: un.Object(),
+		})
+	}
+	return refs, nil
+}
+
+type dropTextSearchConfigNode struct {
+	n *tree.DropTextSearchConfig
+}
+
+// DropTextSearchConfig drops text search configurations.
+// Privileges: ownership of the configurations.
+func (p *planner) DropTextSearchConfig(
+	ctx context.Context, n *tree.DropTextSearchConfig,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP TEXT SEARCH CONFIGURATION",
+	); err != nil {
+		return nil, err
+	}
+	return &dropTextSearchConfigNode{n: n}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because DROP TEXT SEARCH CONFIGURATION performs multiple KV
+// operations on descriptors and expects to see its own writes.
+func (n *dropTextSearchConfigNode) ReadingOwnWrites() {}
+
+func (n *dropTextSearchConfigNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("text_search_configuration"))
+
+	for _, un := range n.n.Names {
+		sc, err := params.p.lookupTextSearchObject(
+			params.ctx, params.p.Descriptors().ByName(params.p.txn).MaybeGet(), un, isTextSearchConfig,
+		)
+		if err != nil {
+			return err
+		}
+		if sc == nil {
+			if n.n.IfExists {
+				params.p.BufferClientNotice(params.ctx, pgnotice.Newf(
+					"text search configuration %q does not exist, skipping", un.Object()))
+				continue
+			}
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"text search configuration %q does not exist", un.Object())
+		}
+		scDesc, err := params.p.Descriptors().MutableByID(params.p.txn).Schema(params.ctx, sc.GetID())
+		if err != nil {
+			return err
+		}
+		config := scDesc.GetTextSearchConfig(un.Object())
+		if err := params.p.checkTextSearchOwnership(
+			params.ctx, "text search configuration", config.Name, config.OwnerProto,
+		); err != nil {
+			return err
+		}
+		scDesc.RemoveTextSearchConfig(un.Object())
+		if err := params.p.writeSchemaDescChange(
+			params.ctx, scDesc, tree.AsStringWithFQNames(n.n, params.Ann()),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*dropTextSearchConfigNode) Next(runParams) (bool, error) { return false, nil }
+func (*dropTextSearchConfigNode) Values() tree.Datums          { return tree.Datums{} }
+func (*dropTextSearchConfigNode) Close(context.Context)        {}
+
+type dropTextSearchDictionaryNode struct {
+	n *tree.DropTextSearchDictionary
+}
+
+// DropTextSearchDictionary drops text search dictionaries. With CASCADE, the
+// configurations which use the dictionaries are dropped as well.
+// Privileges: ownership of the dictionaries and of the dependent
+// configurations.
+func (p *planner) DropTextSearchDictionary(
+	ctx context.Context, n *tree.DropTextSearchDictionary,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP TEXT SEARCH DICTIONARY",
+	); err != nil {
+		return nil, err
+	}
+	return &dropTextSearchDictionaryNode{n: n}, nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+// This is because DROP TEXT SEARCH DICTIONARY performs multiple KV operations
+// on descriptors and expects to see its own writes.
+func (n *dropTextSearchDictionaryNode) ReadingOwnWrites() {}
+
+func (n *dropTextSearchDictionaryNode) startExec(params runParams) error {
+	telemetry.Inc(sqltelemetry.SchemaChangeDropCounter("text_search_dictionary"))
+
+	for _, un := range n.n.Names {
+		sc, err := params.p.lookupTextSearchObject(
+			params.ctx, params.p.Descriptors().ByName(params.p.txn).MaybeGet(), un, isTextSearchDictionary,
+		)
+		if err != nil {
+			return err
+		}
+		if sc == nil {
+			if n.n.IfExists {
+				params.p.BufferClientNotice(params.ctx, pgnotice.Newf(
+					"text search dictionary %q does not exist, skipping", un.Object()))
+				continue
+			}
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"text search dictionary %q does not exist", un.Object())
+		}
+		scDesc, err := params.p.Descriptors().MutableByID(params.p.txn).Schema(params.ctx, sc.GetID())
+		if err != nil {
+			return err
+		}
+		dict := scDesc.GetTextSearchDictionary(un.Object())
+		if err := params.p.checkTextSearchOwnership(
+			params.ctx, "text search dictionary", dict.Name, dict.OwnerProto,
+		); err != nil {
+			return err
+		}
+
+		// Configurations can only use the dictionaries of their own schema.
+		var dependents []string
+		for _, config := range scDesc.TextSearchConfigs {
+			if textSearchConfigUsesDictionary(&config, dict.Name) {
+				dependents = append(dependents, config.Name)
+			}
+		}
+		if len(dependents) > 0 && n.n.DropBehavior != tree.DropCascade {
+			return errors.WithHint(
+				pgerror.Newf(pgcode.DependentObjectsStillExist,
+					"cannot drop text search dictionary %s because other objects depend on it",
+					tree.Name(dict.Name)),
+				"Use DROP ... CASCADE to drop the dependent objects too.")
+		}
+		for _, name := range dependents {
+			config := scDesc.GetTextSearchConfig(name)
+			if err := params.p.checkTextSearchOwnership(
+				params.ctx, "text search configuration", config.Name, config.OwnerProto,
+			); err != nil {
+				return err
+			}
+			params.p.BufferClientNotice(params.ctx, pgnotice.Newf(
+				"drop cascades to text search configuration %s", tree.Name(name)))
+			scDesc.RemoveTextSearchConfig(name)
+		}
+		scDesc.RemoveTextSearchDictionary(un.Object())
+		if err := params.p.writeSchemaDescChange(
+			params.ctx, scDesc, tree.AsStringWithFQNames(n.n, params.Ann()),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*dropTextSearchDictionaryNode) Next(runParams) (bool, error) { return false, nil }
+func (*dropTextSearchDictionaryNode) Values() tree.Datums          { return tree.Datums{} }
+func (*dropTextSearchDictionaryNode) Close(context.Context)        {}
+
+func textSearchConfigUsesDictionary(
+	config *descpb.SchemaDescriptor_TextSearchConfig, dictName string,
+) bool {
+	for _, m := range config.Mappings {
+		for _, d := range m.Dictionaries {
+			if !d.Builtin && d.Name == dictName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkTextSearchEnabled returns an error if text search objects cannot be
+// created.
+func (p *planner) checkTextSearchEnabled(ctx context.Context, op string) error {
+	if err := checkSchemaChangeEnabled(ctx, p.ExecCfg(), op); err != nil {
+		return err
+	}
+	if !p.execCfg.Settings.Version.IsActive(ctx, clusterversion.V24_1) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to create text search objects",
+			clusterversion.V24_1)
+	}
+	return nil
+}
+
+// textSearchSchemaForCreate returns the schema in which a text search object
+// with the given name is created, for modification.
+func (p *planner) textSearchSchemaForCreate(
+	ctx context.Context, name *tree.UnresolvedObjectName,
+) (*schemadesc.Mutable, error) {
+	db, sc, _, err := p.ResolveTargetObject(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	switch sc.SchemaKind() {
+	case catalog.SchemaPublic, catalog.SchemaUserDefined:
+	default:
+		return nil, pgerror.Newf(pgcode.InvalidSchemaName,
+			"cannot create text search objects in schema %q", sc.GetName())
+	}
+	if err := p.canCreateOnSchema(
+		ctx, sc.GetID(), db.GetID(), p.User(), skipCheckPublicSchema,
+	); err != nil {
+		return nil, err
+	}
+	return p.Descriptors().MutableByID(p.txn).Schema(ctx, sc.GetID())
+}
+
+func isTextSearchConfig(sc catalog.SchemaDescriptor, name string) bool {
+	return sc.GetTextSearchConfig(name) != nil
+}
+
+func isTextSearchDictionary(sc catalog.SchemaDescriptor, name string) bool {
+	return sc.GetTextSearchDictionary(name) != nil
+}
+
+// lookupTextSearchObject returns the schema which contains the text search
+// object with the given name, or nil if there is none. Unqualified names are
+// searched for in the schemas of the search path.
+func (p *planner) lookupTextSearchObject(
+	ctx context.Context,
+	g descs.ByNameGetter,
+	un *tree.UnresolvedObjectName,
+	exists func(catalog.SchemaDescriptor, string) bool,
+) (catalog.SchemaDescriptor, error) {
+	dbName := p.CurrentDatabase()
+	if un.HasExplicitCatalog() {
+		dbName = un.Catalog()
+	}
+	if dbName == "" {
+		return nil, nil
+	}
+	db, err := g.Database(ctx, dbName)
+	if err != nil || db == nil {
+		return nil, err
+	}
+	var scNames []string
+	if un.HasExplicitSchema() {
+		scNames = []string{un.Schema()}
+	} else {
+		iter := p.CurrentSearchPath().IterWithoutImplicitPGSchemas()
+		for scName, ok := iter.Next(); ok; scName, ok = iter.Next() {
+			scNames = append(scNames, scName)
+		}
+	}
+	for _, scName := range scNames {
+		sc, err := g.Schema(ctx, db, scName)
+		if err != nil {
+			return nil, err
+		}
+		if sc != nil && sc.SchemaKind() != catalog.SchemaVirtual && exists(sc, un.Object()) {
+			return sc, nil
+		}
+	}
+	return nil, nil
+}
+
+// checkTextSearchOwnership returns an error unless the current user is an
+// admin, or is a member of the owner of a text search object.
+func (p *planner) checkTextSearchOwnership(
+	ctx context.Context, kind, name string, owner username.SQLUsernameProto,
+) error {
+	if owner.Decode() == p.User() {
+		return nil
+	}
+	hasAdmin, err := p.HasAdminRole(ctx)
+	if err != nil || hasAdmin {
+		return err
+	}
+	memberOf, err := p.MemberOfWithAdminOption(ctx, p.User())
+	if err != nil {
+		return err
+	}
+	if _, ok := memberOf[owner.Decode()]; !ok {
+		return pgerror.Newf(pgcode.InsufficientPrivilege, "must be owner of %s %s", kind, tree.Name(name))
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// CreateTrigger creates a trigger. Triggers are only supported by the
+// declarative schema changer, so this is only reached when the declarative
+// schema changer is disabled or cannot be used for the statement.
+func (p *planner) CreateTrigger(_ context.Context, n *tree.CreateTrigger) (planNode, error) {
+	return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+		"%s is only implemented in the declarative schema changer", n.StatementTag())
+}
+
+// DropTrigger drops a trigger. See CreateTrigger.
+func (p *planner) DropTrigger(_ context.Context, n *tree.DropTrigger) (planNode, error) {
+	return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+		"%s is only implemented in the declarative schema changer", n.StatementTag())
+}
@@ -108,6 +108,20 @@ var OidToType = map[oid.Oid]*T{
 	oidext.T_geometry:  Geometry,
 	oidext.T_geography: Geography,
 	oidext.T_box2d:     Box2D,
+	oidext.T_jsonpath:  JSONPath,
+
+	oid.T_int4range:         Int4Range,
+	oid.T_int8range:         Int8Range,
+	oid.T_numrange:          NumRange,
+	oid.T_tsrange:           TSRange,
+	oid.T_tstzrange:         TSTZRange,
+	oid.T_daterange:         DateRange,
+	oidext.T_int4multirange: Int4Multirange,
+	oidext.T_int8multirange: Int8Multirange,
+	oidext.T_nummultirange:  NumMultirange,
+	oidext.T_tsmultirange:   TSMultirange,
+	oidext.T_tstzmultirange: TSTZMultirange,
+	oidext.T_datemultirange: DateMultirange,
 }
 
 // oidToArrayOid maps scalar type Oids to their corresponding array type Oid.
@@ -155,6 +169,20 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oidext.T_geometry:  oidext.T__geometry,
 	oidext.T_geography: oidext.T__geography,
 	oidext.T_box2d:     oidext.T__box2d,
+	oidext.T_jsonpath:  oidext.T__jsonpath,
+
+	oid.T_int4range:         oid.T__int4range,
+	oid.T_int8range:         oid.T__int8range,
+	oid.T_numrange:          oid.T__numrange,
+	oid.T_tsrange:           oid.T__tsrange,
+	oid.T_tstzrange:         oid.T__tstzrange,
+	oid.T_daterange:         oid.T__daterange,
+	oidext.T_int4multirange: oidext.T__int4multirange,
+	oidext.T_int8multirange: oidext.T__int8multirange,
+	oidext.T_nummultirange:  oidext.T__nummultirange,
+	oidext.T_tsmultirange:   oidext.T__tsmultirange,
+	oidext.T_tstzmultirange: oidext.T__tstzmultirange,
+	oidext.T_datemultirange: oidext.T__datemultirange,
 }
 
 // familyToOid maps each type family to a default OID value that is used when
@@ -175,6 +203,7 @@ var familyToOid = map[Family]oid.Oid{
 	OidFamily:            oid.T_oid,
 	PGLSNFamily:          oid.T_pg_lsn,
 	RefCursorFamily:      oid.T_refcursor,
+	TriggerFamily:        oid.T_trigger,
 	UnknownFamily:        oid.T_unknown,
 	UuidFamily:           oid.T_uuid,
 	ArrayFamily:          oid.T_anyarray,
@@ -187,10 +216,13 @@ var familyToOid = map[Family]oid.Oid{
 	TupleFamily:          oid.T_record,
 	BitFamily:            oid.T_bit,
 	AnyFamily:            oid.T_anyelement,
+	RangeFamily:          oid.T_int4range,
 
-	GeometryFamily:  oidext.T_geometry,
-	GeographyFamily: oidext.T_geography,
-	Box2DFamily:     oidext.T_box2d,
+	GeometryFamily:   oidext.T_geometry,
+	GeographyFamily:  oidext.T_geography,
+	Box2DFamily:      oidext.T_box2d,
+	JSONPathFamily:   oidext.T_jsonpath,
+	MultirangeFamily: oidext.T_int4multirange,
 }
 
 // ArrayOids is a set of all oids which correspond to an array type.
@@ -206,6 +238,9 @@ func init() {
 // CalcArrayOid returns the OID of the array type having elements of the given
 // type.
 func CalcArrayOid(elemTyp *T) oid.Oid {
+	if elemTyp.IsDomain() {
+		return elemTyp.UserDefinedArrayOID()
+	}
 	o := elemTyp.Oid()
 	switch elemTyp.Family() {
 	case ArrayFamily:
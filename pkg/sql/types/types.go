@@ -223,6 +223,29 @@ type UserDefinedTypeMetadata struct {
 	// for a table. Note: this can be deleted if we migrate implicit record types
 	// to ordinary persisted composite types.
 	ImplicitRecordType bool
+
+	// DomainData is non-nil iff the metadata is for a DOMAIN type.
+	DomainData *DomainMetadata
+}
+
+// DomainMetadata is metadata about a DOMAIN needed for evaluation.
+type DomainMetadata struct {
+	// NotNull is true if the domain does not allow NULL values.
+	NotNull bool
+	// DefaultExpr is the serialized default expression of the domain, if any.
+	DefaultExpr *string
+	// CheckNames holds the names of the CHECK constraints of the domain.
+	CheckNames []string
+	// CheckExprs holds the serialized expressions of the CHECK constraints of
+	// the domain, in which the value being checked is referenced as VALUE.
+	CheckExprs []string
+	// TypedCheckExprs caches the parsed and type-checked CHECK expressions of
+	// the domain, in the same order as CheckExprs. The elements are
+	// tree.TypedExpr values in which VALUE has been replaced with the indexed
+	// variable 0; they are stored as interface{} because the types package
+	// cannot depend on the tree package. It is nil if the expressions could not
+	// be type-checked when the type was hydrated.
+	TypedCheckExprs []interface{}
 }
 
 // EnumMetadata is metadata about an ENUM needed for evaluation.
@@ -435,6 +458,65 @@ var (
 	Json = &T{InternalType: InternalType{
 		Family: JsonFamily, Oid: oid.T_json, Locale: &emptyLocale}}
 
+	// JSONPath is the type of a SQL/JSON path expression, which is used to query
+	// JSON values.
+	JSONPath = &T{InternalType: InternalType{
+		Family: JSONPathFamily, Oid: oidext.T_jsonpath, Locale: &emptyLocale}}
+
+	// Int4Range is the type of a range of INT4 values, such as [1,10).
+	Int4Range = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_int4range, Locale: &emptyLocale}}
+
+	// Int8Range is the type of a range of INT8 values.
+	Int8Range = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_int8range, Locale: &emptyLocale}}
+
+	// NumRange is the type of a range of DECIMAL values.
+	NumRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_numrange, Locale: &emptyLocale}}
+
+	// TSRange is the type of a range of TIMESTAMP values.
+	TSRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_tsrange, Locale: &emptyLocale}}
+
+	// TSTZRange is the type of a range of TIMESTAMPTZ values.
+	TSTZRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_tstzrange, Locale: &emptyLocale}}
+
+	// DateRange is the type of a range of DATE values.
+	DateRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_daterange, Locale: &emptyLocale}}
+
+	// Int4Multirange is the type of a set of non-overlapping ranges of INT4
+	// values, such as {[1,3),[5,7)}.
+	Int4Multirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_int4multirange, Locale: &emptyLocale}}
+
+	// Int8Multirange is the type of a set of non-overlapping ranges of INT8
+	// values.
+	Int8Multirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_int8multirange, Locale: &emptyLocale}}
+
+	// NumMultirange is the type of a set of non-overlapping ranges of DECIMAL
+	// values.
+	NumMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_nummultirange, Locale: &emptyLocale}}
+
+	// TSMultirange is the type of a set of non-overlapping ranges of TIMESTAMP
+	// values.
+	TSMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_tsmultirange, Locale: &emptyLocale}}
+
+	// TSTZMultirange is the type of a set of non-overlapping ranges of
+	// TIMESTAMPTZ values.
+	TSTZMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_tstzmultirange, Locale: &emptyLocale}}
+
+	// DateMultirange is the type of a set of non-overlapping ranges of DATE
+	// values.
+	DateMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_datemultirange, Locale: &emptyLocale}}
+
 	// Uuid is the type of a universally unique identifier (UUID), which is a
 	// 128-bit quantity that is very unlikely to ever be generated again, and so
 	// can be relied on to be distinct from all other UUID values.
@@ -537,6 +619,16 @@ var (
 		},
 	}
 
+	// Trigger is the pseudo-type that is used as the return type of a trigger
+	// function.
+	Trigger = &T{
+		InternalType: InternalType{
+			Family: TriggerFamily,
+			Oid:    oid.T_trigger,
+			Locale: &emptyLocale,
+		},
+	}
+
 	// Scalar contains all types that meet this criteria:
 	//
 	//   1. Scalar type (no ArrayFamily or TupleFamily types).
@@ -588,6 +680,39 @@ var (
 	AnyEnum = &T{InternalType: InternalType{
 		Family: EnumFamily, Locale: &emptyLocale, Oid: oid.T_anyenum}}
 
+	// AnyRange is a special type only used during static analysis as a wildcard
+	// type that matches any range type. Execution-time values should never have
+	// this type.
+	AnyRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_anyrange, Locale: &emptyLocale}}
+
+	// AnyMultirange is a special type only used during static analysis as a
+	// wildcard type that matches any multirange type. Execution-time values
+	// should never have this type.
+	AnyMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_anymultirange, Locale: &emptyLocale}}
+
+	// RangeTypes contains all of the concrete range types.
+	RangeTypes = []*T{
+		Int4Range,
+		Int8Range,
+		NumRange,
+		TSRange,
+		TSTZRange,
+		DateRange,
+	}
+
+	// MultirangeTypes contains all of the concrete multirange types, in the same
+	// order as the corresponding range types in RangeTypes.
+	MultirangeTypes = []*T{
+		Int4Multirange,
+		Int8Multirange,
+		NumMultirange,
+		TSMultirange,
+		TSTZMultirange,
+		DateMultirange,
+	}
+
 	// AnyTuple is a special type used only during static analysis as a wildcard
 	// type that matches a tuple with any number of fields of any type (including
 	// tuple types). Execution-time values should never have this type.
@@ -1174,6 +1299,20 @@ func MakeEnum(typeOID, arrayTypeOID oid.Oid) *T {
 	}}
 }
 
+// MakeDomain constructs a new instance of a domain type over the given base
+// type, with the given stable type ID. The domain type has the same family and
+// attributes as the base type, so its values are represented in the same way.
+// Note that it does not hydrate cached fields on the type.
+func MakeDomain(base *T, typeOID, arrayTypeOID oid.Oid) *T {
+	t := &T{InternalType: base.InternalType}
+	t.InternalType.Oid = typeOID
+	t.InternalType.UDTMetadata = &PersistentUserDefinedTypeMetadata{
+		ArrayTypeOID:  arrayTypeOID,
+		DomainBaseOID: base.Oid(),
+	}
+	return t
+}
+
 // MakeArray constructs a new instance of an ArrayFamily type with the given
 // element type (which may itself be an ArrayFamily type).
 func MakeArray(typ *T) *T {
@@ -1451,6 +1590,66 @@ func (t *T) TupleLabels() []string {
 	return t.InternalType.TupleLabels
 }
 
+// RangeSubtype returns the type of the bounds of a range or multirange type.
+// For example, for int4range and int4multirange it returns INT4. It returns
+// Any for the anyrange and anymultirange wildcard types, and nil for types
+// not in the RangeFamily or MultirangeFamily.
+func (t *T) RangeSubtype() *T {
+	switch t.Family() {
+	case RangeFamily, MultirangeFamily:
+	default:
+		return nil
+	}
+	switch t.Oid() {
+	case oid.T_int4range, oidext.T_int4multirange:
+		return Int4
+	case oid.T_int8range, oidext.T_int8multirange:
+		return Int
+	case oid.T_numrange, oidext.T_nummultirange:
+		return Decimal
+	case oid.T_tsrange, oidext.T_tsmultirange:
+		return Timestamp
+	case oid.T_tstzrange, oidext.T_tstzmultirange:
+		return TimestampTZ
+	case oid.T_daterange, oidext.T_datemultirange:
+		return Date
+	}
+	return Any
+}
+
+// MultirangeOfRange returns the multirange type whose elements are ranges of
+// the given range type, or nil if there is no such type.
+func MultirangeOfRange(rangeTyp *T) *T {
+	for i := range RangeTypes {
+		if RangeTypes[i].Oid() == rangeTyp.Oid() {
+			return MultirangeTypes[i]
+		}
+	}
+	return nil
+}
+
+// RangeOfMultirange returns the range type of the elements of the given
+// multirange type, or nil if there is no such type.
+func RangeOfMultirange(multirangeTyp *T) *T {
+	for i := range MultirangeTypes {
+		if MultirangeTypes[i].Oid() == multirangeTyp.Oid() {
+			return RangeTypes[i]
+		}
+	}
+	return nil
+}
+
+// RangeOfSubtype returns the range type whose bounds have the given type, or
+// nil if there is no such type.
+func RangeOfSubtype(subtype *T) *T {
+	for _, typ := range RangeTypes {
+		if typ.RangeSubtype().Identical(subtype) {
+			return typ
+		}
+	}
+	return nil
+}
+
 // UserDefinedArrayOID returns the OID of the array type that corresponds to
 // this user defined type. This function only can only be called on user
 // defined types and returns non-zero data only for user defined types that
@@ -1462,6 +1661,23 @@ func (t *T) UserDefinedArrayOID() oid.Oid {
 	return t.InternalType.UDTMetadata.ArrayTypeOID
 }
 
+// IsDomain returns true if this is a user-defined domain type.
+func (t *T) IsDomain() bool {
+	return t.InternalType.UDTMetadata != nil && t.InternalType.UDTMetadata.DomainBaseOID != 0
+}
+
+// DomainBaseType returns the base type of a domain type. The base type has the
+// same family and attributes as the domain type.
+func (t *T) DomainBaseType() *T {
+	if !t.IsDomain() {
+		panic(errors.AssertionFailedf("%s is not a domain type", t.SQLStringForError()))
+	}
+	base := &T{InternalType: t.InternalType}
+	base.InternalType.Oid = t.InternalType.UDTMetadata.DomainBaseOID
+	base.InternalType.UDTMetadata = nil
+	return base
+}
+
 // RemapUserDefinedTypeOIDs is used to remap OIDs stored within a types.T
 // that is a user defined type. The newArrayOID argument is ignored if the
 // input type is an Array type. It mutates the input types.T and should only
@@ -1505,8 +1721,11 @@ var familyNames = map[Family]redact.SafeString{
 	IntFamily:            "int",
 	IntervalFamily:       "interval",
 	JsonFamily:           "jsonb",
+	JSONPathFamily:       "jsonpath",
+	MultirangeFamily:     "multirange",
 	OidFamily:            "oid",
 	PGLSNFamily:          "pg_lsn",
+	RangeFamily:          "range",
 	RefCursorFamily:      "refcursor",
 	StringFamily:         "string",
 	TimeFamily:           "time",
@@ -1515,6 +1734,7 @@ var familyNames = map[Family]redact.SafeString{
 	TimeTZFamily:         "timetz",
 	TSQueryFamily:        "tsquery",
 	TSVectorFamily:       "tsvector",
+	TriggerFamily:        "trigger",
 	TupleFamily:          "tuple",
 	UnknownFamily:        "unknown",
 	UuidFamily:           "uuid",
@@ -1541,6 +1761,13 @@ func (f Family) Name() redact.SafeString {
 //
 // TODO(andyk): Should these be changed to be the same as SQLStandardName?
 func (t *T) Name() string {
+	if t.IsDomain() {
+		// This can be nil during unit testing.
+		if t.TypeMeta.Name == nil {
+			return "unknown_domain"
+		}
+		return t.TypeMeta.Name.Basename()
+	}
 	switch fam := t.Family(); fam {
 	case AnyFamily:
 		return "anyelement"
@@ -1585,6 +1812,9 @@ func (t *T) Name() string {
 	case OidFamily:
 		return t.SQLStandardName()
 
+	case RangeFamily, MultirangeFamily:
+		return t.PGName()
+
 	case StringFamily, CollatedStringFamily:
 		switch t.Oid() {
 		case oid.T_text:
@@ -1678,6 +1908,9 @@ func (t *T) TelemetryName() string {
 // This function is full of special cases. See backend/utils/adt/format_type.c
 // in Postgres.
 func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
+	if t.IsDomain() {
+		return t.Name()
+	}
 	var buf strings.Builder
 	switch t.Family() {
 	case AnyFamily:
@@ -1763,6 +1996,10 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 	case JsonFamily:
 		// Only binary JSON is currently supported.
 		return "jsonb"
+	case JSONPathFamily:
+		return "jsonpath"
+	case RangeFamily, MultirangeFamily:
+		return t.PGName()
 	case OidFamily:
 		switch t.Oid() {
 		case oid.T_oid:
@@ -1847,6 +2084,8 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 		return "tsquery"
 	case TSVectorFamily:
 		return "tsvector"
+	case TriggerFamily:
+		return "trigger"
 	case TupleFamily:
 		if t.UserDefined() {
 			// If we have a user-defined tuple type, use its user-defined name.
@@ -1887,6 +2126,13 @@ func (t *T) InformationSchemaName() string {
 // reproduce the type via parsing the string as a type. It is used in error
 // messages and also to produce the output of SHOW CREATE.
 func (t *T) SQLString() string {
+	if t.IsDomain() {
+		// See the comment for EnumFamily below.
+		if t.TypeMeta.Name == nil {
+			return fmt.Sprintf("@%d", t.Oid())
+		}
+		return t.TypeMeta.Name.FQName()
+	}
 	switch t.Family() {
 	case BitFamily:
 		o := t.Oid()
@@ -2010,6 +2256,9 @@ func (t *T) SQLStringForError() redact.RedactableString {
 		case ArrayFamily:
 			prefix = "ARRAY"
 		}
+		if t.IsDomain() {
+			prefix = "DOMAIN"
+		}
 		return redact.Sprintf("USER DEFINED %s: %s", redact.Safe(prefix), t.SQLString())
 	}
 	switch t.Family() {
@@ -2022,7 +2271,8 @@ func (t *T) SQLStringForError() redact.RedactableString {
 		IntervalFamily, StringFamily, BytesFamily, TimestampTZFamily, CollatedStringFamily, OidFamily,
 		UnknownFamily, UuidFamily, INetFamily, TimeFamily, JsonFamily, TimeTZFamily, BitFamily,
 		GeometryFamily, GeographyFamily, Box2DFamily, VoidFamily, EncodedKeyFamily, TSQueryFamily,
-		TSVectorFamily, AnyFamily, PGLSNFamily, RefCursorFamily:
+		TSVectorFamily, AnyFamily, PGLSNFamily, RefCursorFamily, TriggerFamily, JSONPathFamily,
+		RangeFamily, MultirangeFamily:
 		// These types do not contain other types, and do not require redaction.
 		return redact.Sprint(redact.SafeString(t.SQLString()))
 	}
@@ -2097,6 +2347,17 @@ func (t *T) Equivalent(other *T) bool {
 		if t.Oid() != other.Oid() {
 			return false
 		}
+
+	case RangeFamily, MultirangeFamily:
+		// Similar to anyenum, anyrange and anymultirange match any range or
+		// multirange type, respectively.
+		if t.Oid() == oid.T_anyrange || other.Oid() == oid.T_anyrange ||
+			t.Oid() == oidext.T_anymultirange || other.Oid() == oidext.T_anymultirange {
+			return true
+		}
+		if t.Oid() != other.Oid() {
+			return false
+		}
 	}
 
 	return true
@@ -2161,6 +2422,7 @@ func (t *T) Equal(other *T) bool {
 func (t *T) IsWildcardType() bool {
 	for _, wildcard := range []*T{
 		Any, AnyArray, AnyCollatedString, AnyEnum, AnyEnumArray, AnyTuple, AnyTupleArray,
+		AnyRange, AnyMultirange,
 	} {
 		// Note that pointer comparison is insufficient since we might have
 		// deserialized t from disk.
@@ -2261,6 +2523,9 @@ func (t *InternalType) Identical(other *InternalType) bool {
 		if t.UDTMetadata.ArrayTypeOID != other.UDTMetadata.ArrayTypeOID {
 			return false
 		}
+		if t.UDTMetadata.DomainBaseOID != other.UDTMetadata.DomainBaseOID {
+			return false
+		}
 	} else if t.UDTMetadata != nil {
 		return false
 	} else if other.UDTMetadata != nil {
@@ -2694,6 +2959,8 @@ func (t *T) IsAmbiguous() bool {
 		return t.ArrayContents().IsAmbiguous()
 	case EnumFamily:
 		return t.Oid() == oid.T_anyenum
+	case RangeFamily, MultirangeFamily:
+		return t.Oid() == oid.T_anyrange || t.Oid() == oidext.T_anymultirange
 	}
 	return false
 }
@@ -2780,6 +3047,8 @@ func IsValidArrayElementType(t *T) (valid bool, issueNum int) {
 		return false, 90886
 	case TSVectorFamily:
 		return false, 90886
+	case JSONPathFamily:
+		return false, 22513
 	default:
 		return true, 0
 	}
@@ -2989,6 +3258,10 @@ var unreservedTypeTokens = map[string]*T{
 
 	"string": String,
 	"uuid":   Uuid,
+
+	// The trigger pseudo-type is only valid as the return type of a trigger
+	// function, so it is not included in OidToType.
+	"trigger": Trigger,
 }
 
 // The following map must include all types predefined in PostgreSQL
@@ -2999,7 +3272,6 @@ var postgresPredefinedTypeIssues = map[string]int{
 	"box":           21286,
 	"cidr":          18846,
 	"circle":        21286,
-	"jsonpath":      22513,
 	"line":          21286,
 	"lseg":          21286,
 	"macaddr":       45813,
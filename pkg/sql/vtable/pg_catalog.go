@@ -1127,7 +1127,8 @@ CREATE TABLE pg_catalog.pg_statio_all_tables (
 	tidx_blks_hit INT
 )`
 
-// PgCatalogTsTemplate is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogTsTemplate describes the schema of the pg_catalog.pg_ts_template table.
+// https://www.postgresql.org/docs/15/catalog-pg-ts-template.html
 const PgCatalogTsTemplate = `
 CREATE TABLE pg_catalog.pg_ts_template (
 	oid OID,
@@ -1137,7 +1138,8 @@ CREATE TABLE pg_catalog.pg_ts_template (
 	tmpllexize REGPROC
 )`
 
-// PgCatalogPublicationRel is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogPublicationRel describes the schema of the pg_catalog.pg_publication_rel table.
+// https://www.postgresql.org/docs/15/catalog-pg-publication-rel.html
 const PgCatalogPublicationRel = `
 CREATE TABLE pg_catalog.pg_publication_rel (
 	oid OID,
@@ -1396,7 +1398,8 @@ CREATE TABLE pg_catalog.pg_statio_all_indexes (
 	idx_blks_hit INT
 )`
 
-// PgCatalogTsConfig is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogTsConfig describes the schema of the pg_catalog.pg_ts_config table.
+// https://www.postgresql.org/docs/15/catalog-pg-ts-config.html
 const PgCatalogTsConfig = `
 CREATE TABLE pg_catalog.pg_ts_config (
 	oid OID,
@@ -1557,7 +1560,8 @@ CREATE TABLE pg_catalog.pg_hba_file_rules (
 	error STRING
 )`
 
-// PgCatalogPublication is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogPublication describes the schema of the pg_catalog.pg_publication table.
+// https://www.postgresql.org/docs/15/catalog-pg-publication.html
 const PgCatalogPublication = `
 CREATE TABLE pg_catalog.pg_publication (
 	oid OID,
@@ -1684,7 +1688,8 @@ CREATE TABLE pg_catalog.pg_stat_xact_user_tables (
 	n_tup_hot_upd INT
 )`
 
-// PgCatalogPublicationTables is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogPublicationTables describes the schema of the pg_catalog.pg_publication_tables table.
+// https://www.postgresql.org/docs/15/view-pg-publication-tables.html
 const PgCatalogPublicationTables = `
 CREATE TABLE pg_catalog.pg_publication_tables (
 	pubname NAME,
@@ -1730,7 +1735,8 @@ CREATE TABLE pg_catalog.pg_stat_all_indexes (
 	idx_tup_fetch INT
 )`
 
-// PgCatalogTsConfigMap is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogTsConfigMap describes the schema of the pg_catalog.pg_ts_config_map table.
+// https://www.postgresql.org/docs/15/catalog-pg-ts-config-map.html
 const PgCatalogTsConfigMap = `
 CREATE TABLE pg_catalog.pg_ts_config_map (
 	mapcfg OID,
@@ -1795,7 +1801,8 @@ CREATE TABLE pg_catalog.pg_largeobject_metadata (
 	lomacl STRING[]
 )`
 
-// PgCatalogReplicationSlots is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogReplicationSlots describes the schema of the pg_catalog.pg_replication_slots table.
+// https://www.postgresql.org/docs/15/view-pg-replication-slots.html
 const PgCatalogReplicationSlots = `
 CREATE TABLE pg_catalog.pg_replication_slots (
 	slot_name NAME,
@@ -1923,7 +1930,8 @@ CREATE TABLE pg_catalog.pg_subscription (
 	subpublications STRING[]
 )`
 
-// PgCatalogTsDict is an empty table in the pg_catalog that is not implemented yet
+// PgCatalogTsDict describes the schema of the pg_catalog.pg_ts_dict table.
+// https://www.postgresql.org/docs/15/catalog-pg-ts-dict.html
 const PgCatalogTsDict = `
 CREATE TABLE pg_catalog.pg_ts_dict (
 	oid OID,
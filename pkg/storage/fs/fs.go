@@ -95,9 +95,27 @@ func (e Envs) CloseAll() {
 // stickyRegistry may be nil iff the spec's StickyVFSID field is unset.
 func InitEnvFromStoreSpec(
 	ctx context.Context, spec base.StoreSpec, rw RWMode, stickyRegistry StickyRegistry,
+) (*Env, error) {
+	return initEnvFromStoreSpec(ctx, spec, spec.Path, rw, stickyRegistry)
+}
+
+// InitRaftLogEnvFromStoreSpec constructs a new Env for the separate raft log
+// engine of the store described by spec, see base.StoreSpec.RaftLogPath. For
+// an in-memory store, the Env lives in the same (sticky) in-memory filesystem
+// as the store's.
+func InitRaftLogEnvFromStoreSpec(
+	ctx context.Context, spec base.StoreSpec, rw RWMode, stickyRegistry StickyRegistry,
+) (*Env, error) {
+	if spec.RaftLogPath == "" {
+		return nil, errors.AssertionFailedf("store spec %s has no raft log path", spec)
+	}
+	return initEnvFromStoreSpec(ctx, spec, spec.RaftLogPath, rw, stickyRegistry)
+}
+
+func initEnvFromStoreSpec(
+	ctx context.Context, spec base.StoreSpec, dir string, rw RWMode, stickyRegistry StickyRegistry,
 ) (*Env, error) {
 	fs := vfs.Default
-	dir := spec.Path
 	if spec.InMemory {
 		if spec.StickyVFSID != "" {
 			if stickyRegistry == nil {
@@ -145,6 +145,21 @@ var upgrades = []upgradebase.Upgrade{
 		upgrade.RestoreActionNotRequired("cluster restore does not preserve the multiregion configuration of the system database"),
 	),
 
+	upgrade.NewTenantUpgrade(
+		"create system.replication_slots table",
+		clusterversion.V24_1_AddSystemReplicationSlotsTable.Version(),
+		upgrade.NoPrecondition,
+		createReplicationSlotsTable,
+		upgrade.RestoreActionNotRequired("replication slots track the position of clients of the cluster and are not restored"),
+	),
+
+	upgrade.NewSystemUpgrade(
+		"record the index of the last MVCC history mutation in the range applied state",
+		clusterversion.V24_1_MVCCHistoryMutationIndex.Version(),
+		mvccHistoryMutationIndexMigration,
+		upgrade.RestoreActionNotRequired("restore does not restore the range applied state"),
+	),
+
 	// Note: when starting a new release version, the first upgrade (for
 	// Vxy_zStart) must be a newFirstUpgrade. Keep this comment at the bottom.
 }
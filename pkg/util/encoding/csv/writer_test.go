@@ -76,6 +76,26 @@ func TestWrite(t *testing.T) {
 	}
 }
 
+func TestWriteQuotedField(t *testing.T) {
+	b := &bytes.Buffer{}
+	f := NewWriter(b)
+	for _, field := range []string{"abc", "", `a"b`} {
+		if err := f.WriteQuotedField(bytes.NewBufferString(field)); err != nil {
+			t.Fatalf("Unexpected error: %s\n", err)
+		}
+	}
+	if err := f.WriteField(bytes.NewBufferString("def")); err != nil {
+		t.Fatalf("Unexpected error: %s\n", err)
+	}
+	if err := f.FinishRecord(); err != nil {
+		t.Fatalf("Unexpected error: %s\n", err)
+	}
+	f.Flush()
+	if out, want := b.String(), `"abc","","a""b",def`+"\n"; out != want {
+		t.Errorf("out=%q want %q", out, want)
+	}
+}
+
 type errorWriter struct{}
 
 func (e errorWriter) Write(b []byte) (int, error) {
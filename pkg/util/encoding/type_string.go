@@ -53,6 +53,9 @@ func _() {
 	_ = x[JSONObjectDesc-41]
 	_ = x[JsonEmptyArray-42]
 	_ = x[JsonEmptyArrayDesc-43]
+	_ = x[JSONPath-44]
+	_ = x[Range-45]
+	_ = x[Multirange-46]
 }
 
 func (i Type) String() string {
@@ -145,6 +148,12 @@ func (i Type) String() string {
 		return "JsonEmptyArray"
 	case JsonEmptyArrayDesc:
 		return "JsonEmptyArrayDesc"
+	case JSONPath:
+		return "JSONPath"
+	case Range:
+		return "Range"
+	case Multirange:
+		return "Multirange"
 	default:
 		return "Type(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
@@ -0,0 +1,545 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package jsonpath implements the SQL/JSON path language, which is used to
+// query JSON documents. See
+// https://www.postgresql.org/docs/current/functions-json.html#FUNCTIONS-SQLJSON-PATH.
+package jsonpath
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/errors"
+)
+
+// Path is a parsed SQL/JSON path expression.
+type Path struct {
+	// Strict is true if the path is evaluated in strict mode, in which
+	// structural errors are raised rather than suppressed, and arrays are not
+	// automatically unwrapped.
+	Strict bool
+	// Expr is the root expression of the path.
+	Expr Expr
+}
+
+// String returns the canonical representation of the path.
+func (p *Path) String() string {
+	var buf bytes.Buffer
+	if p.Strict {
+		buf.WriteString("strict ")
+	}
+	formatExpr(&buf, p.Expr, true /* parens */)
+	return buf.String()
+}
+
+// Expr is a node of a SQL/JSON path expression.
+type Expr interface {
+	// priority returns the binding strength of the expression, which is used to
+	// decide where parentheses are needed when it is formatted.
+	priority() int
+}
+
+// Root is the $ variable, which refers to the JSON document being queried.
+type Root struct{}
+
+// Current is the @ variable, which refers to the item being filtered.
+type Current struct{}
+
+// Last is the last keyword, which refers to the last index of the array being
+// subscripted.
+type Last struct{}
+
+// Variable is a named variable, such as $x, whose value is passed to the path
+// evaluation.
+type Variable struct {
+	Name string
+}
+
+// Scalar is a literal JSON null, boolean, number, or string.
+type Scalar struct {
+	Val json.JSON
+}
+
+// Chain is a primary expression followed by a sequence of accessors, each of
+// which is applied to every item produced by the previous one.
+type Chain struct {
+	Base      Expr
+	Accessors []Accessor
+}
+
+// Accessor is an element of a Chain.
+type Accessor interface {
+	format(buf *bytes.Buffer)
+}
+
+// Key is the .key member accessor.
+type Key struct {
+	Name string
+}
+
+// AnyKey is the .* wildcard member accessor.
+type AnyKey struct{}
+
+// AnyIndex is the [*] wildcard array accessor.
+type AnyIndex struct{}
+
+// Subscript is an element of an Index accessor. To is nil if the subscript is
+// a single index rather than a range.
+type Subscript struct {
+	From, To Expr
+}
+
+// Index is the [...] array accessor, which contains a list of indexes and
+// index ranges.
+type Index struct {
+	Subscripts []Subscript
+}
+
+// AnyPath is the .** accessor, which returns the item and all of its
+// descendants whose nesting levels are between First and Last, inclusive. A
+// negative level refers to the last (deepest) level.
+type AnyPath struct {
+	First, Last int
+}
+
+// Filter is the ?(predicate) accessor, which returns the items for which the
+// predicate is true.
+type Filter struct {
+	Pred Expr
+}
+
+// Method is an item method, such as .type() or .size().
+type Method struct {
+	Name MethodName
+}
+
+// MethodName identifies an item method.
+type MethodName int
+
+const (
+	// MethodType is the .type() method.
+	MethodType MethodName = iota
+	// MethodSize is the .size() method.
+	MethodSize
+	// MethodDouble is the .double() method.
+	MethodDouble
+	// MethodCeiling is the .ceiling() method.
+	MethodCeiling
+	// MethodFloor is the .floor() method.
+	MethodFloor
+	// MethodAbs is the .abs() method.
+	MethodAbs
+	// MethodKeyValue is the .keyvalue() method.
+	MethodKeyValue
+)
+
+var methodNames = [...]string{
+	MethodType:     "type",
+	MethodSize:     "size",
+	MethodDouble:   "double",
+	MethodCeiling:  "ceiling",
+	MethodFloor:    "floor",
+	MethodAbs:      "abs",
+	MethodKeyValue: "keyvalue",
+}
+
+func (m MethodName) String() string {
+	return methodNames[m]
+}
+
+// BinaryOp identifies a binary operator.
+type BinaryOp int
+
+const (
+	// OpAnd is the && operator.
+	OpAnd BinaryOp = iota
+	// OpOr is the || operator.
+	OpOr
+	// OpEq is the == operator.
+	OpEq
+	// OpNe is the != (or <>) operator.
+	OpNe
+	// OpLt is the < operator.
+	OpLt
+	// OpLe is the <= operator.
+	OpLe
+	// OpGt is the > operator.
+	OpGt
+	// OpGe is the >= operator.
+	OpGe
+	// OpStartsWith is the starts with operator.
+	OpStartsWith
+	// OpAdd is the + operator.
+	OpAdd
+	// OpSub is the - operator.
+	OpSub
+	// OpMul is the * operator.
+	OpMul
+	// OpDiv is the / operator.
+	OpDiv
+	// OpMod is the % operator.
+	OpMod
+)
+
+var binaryOpNames = [...]string{
+	OpAnd:        "&&",
+	OpOr:         "||",
+	OpEq:         "==",
+	OpNe:         "!=",
+	OpLt:         "<",
+	OpLe:         "<=",
+	OpGt:         ">",
+	OpGe:         ">=",
+	OpStartsWith: "starts with",
+	OpAdd:        "+",
+	OpSub:        "-",
+	OpMul:        "*",
+	OpDiv:        "/",
+	OpMod:        "%",
+}
+
+func (o BinaryOp) String() string {
+	return binaryOpNames[o]
+}
+
+// isPredicate returns true if the operator produces a boolean.
+func (o BinaryOp) isPredicate() bool {
+	return o <= OpStartsWith
+}
+
+// Binary is a binary operation.
+type Binary struct {
+	Op          BinaryOp
+	Left, Right Expr
+}
+
+// UnaryOp identifies a unary operator.
+type UnaryOp int
+
+const (
+	// OpNot is the ! operator.
+	OpNot UnaryOp = iota
+	// OpPlus is the unary + operator.
+	OpPlus
+	// OpMinus is the unary - operator.
+	OpMinus
+	// OpExists is the exists (...) predicate.
+	OpExists
+	// OpIsUnknown is the (...) is unknown predicate.
+	OpIsUnknown
+)
+
+// Unary is a unary operation.
+type Unary struct {
+	Op      UnaryOp
+	Operand Expr
+}
+
+// LikeRegex is the like_regex predicate, which matches strings against a
+// regular expression.
+type LikeRegex struct {
+	Operand Expr
+	Pattern string
+	Flags   string
+	re      *regexp.Regexp
+}
+
+// isPredicate returns true if the expression produces a boolean.
+func isPredicate(e Expr) bool {
+	switch t := e.(type) {
+	case *Binary:
+		return t.Op.isPredicate()
+	case *Unary:
+		return t.Op == OpNot || t.Op == OpExists || t.Op == OpIsUnknown
+	case *LikeRegex:
+		return true
+	}
+	return false
+}
+
+// Priorities of the expressions, from the least to the most tightly binding.
+const (
+	priorityOr = iota
+	priorityAnd
+	priorityCmp
+	priorityAdd
+	priorityMul
+	priorityUnary
+	priorityPrimary
+)
+
+func (*Root) priority() int      { return priorityPrimary }
+func (*Current) priority() int   { return priorityPrimary }
+func (*Last) priority() int      { return priorityPrimary }
+func (*Variable) priority() int  { return priorityPrimary }
+func (*Scalar) priority() int    { return priorityPrimary }
+func (*Chain) priority() int     { return priorityPrimary }
+func (*LikeRegex) priority() int { return priorityCmp }
+
+func (e *Binary) priority() int {
+	switch e.Op {
+	case OpOr:
+		return priorityOr
+	case OpAnd:
+		return priorityAnd
+	case OpAdd, OpSub:
+		return priorityAdd
+	case OpMul, OpDiv, OpMod:
+		return priorityMul
+	default:
+		return priorityCmp
+	}
+}
+
+func (e *Unary) priority() int {
+	switch e.Op {
+	case OpPlus, OpMinus:
+		return priorityUnary
+	default:
+		return priorityPrimary
+	}
+}
+
+// formatExpr writes the canonical representation of e to buf, matching the
+// output of Postgres. If parens is true, operations are enclosed in
+// parentheses.
+func formatExpr(buf *bytes.Buffer, e Expr, parens bool) {
+	switch t := e.(type) {
+	case *Root:
+		buf.WriteByte('$')
+	case *Current:
+		buf.WriteByte('@')
+	case *Last:
+		buf.WriteString("last")
+	case *Variable:
+		buf.WriteByte('$')
+		formatString(buf, t.Name)
+	case *Scalar:
+		if t.Val.Type() == json.StringJSONType {
+			s, _ := t.Val.AsText()
+			formatString(buf, *s)
+		} else {
+			t.Val.Format(buf)
+		}
+	case *Chain:
+		formatExpr(buf, t.Base, true /* parens */)
+		for _, a := range t.Accessors {
+			a.format(buf)
+		}
+	case *Binary:
+		if parens {
+			buf.WriteByte('(')
+		}
+		formatExpr(buf, t.Left, t.Left.priority() <= t.priority())
+		buf.WriteByte(' ')
+		buf.WriteString(t.Op.String())
+		buf.WriteByte(' ')
+		formatExpr(buf, t.Right, t.Right.priority() <= t.priority())
+		if parens {
+			buf.WriteByte(')')
+		}
+	case *Unary:
+		switch t.Op {
+		case OpNot:
+			buf.WriteString("!(")
+			formatExpr(buf, t.Operand, false /* parens */)
+			buf.WriteByte(')')
+		case OpExists:
+			buf.WriteString("exists (")
+			formatExpr(buf, t.Operand, false /* parens */)
+			buf.WriteByte(')')
+		case OpIsUnknown:
+			buf.WriteByte('(')
+			formatExpr(buf, t.Operand, false /* parens */)
+			buf.WriteString(") is unknown")
+		case OpPlus, OpMinus:
+			if parens {
+				buf.WriteByte('(')
+			}
+			if t.Op == OpPlus {
+				buf.WriteByte('+')
+			} else {
+				buf.WriteByte('-')
+			}
+			formatExpr(buf, t.Operand, t.Operand.priority() <= t.priority())
+			if parens {
+				buf.WriteByte(')')
+			}
+		}
+	case *LikeRegex:
+		if parens {
+			buf.WriteByte('(')
+		}
+		formatExpr(buf, t.Operand, t.Operand.priority() <= t.priority())
+		buf.WriteString(" like_regex ")
+		formatString(buf, t.Pattern)
+		if t.Flags != "" {
+			buf.WriteString(" flag ")
+			formatString(buf, t.Flags)
+		}
+		if parens {
+			buf.WriteByte(')')
+		}
+	}
+}
+
+// formatString writes s to buf as a double-quoted string literal.
+func formatString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				buf.WriteString(`\u00`)
+				buf.WriteString(strconv.FormatInt(int64(r>>4), 16))
+				buf.WriteString(strconv.FormatInt(int64(r&0xf), 16))
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+}
+
+func (a *Key) format(buf *bytes.Buffer) {
+	buf.WriteByte('.')
+	formatString(buf, a.Name)
+}
+
+func (*AnyKey) format(buf *bytes.Buffer) {
+	buf.WriteString(".*")
+}
+
+func (*AnyIndex) format(buf *bytes.Buffer) {
+	buf.WriteString("[*]")
+}
+
+func (a *Index) format(buf *bytes.Buffer) {
+	buf.WriteByte('[')
+	for i, s := range a.Subscripts {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		formatExpr(buf, s.From, false /* parens */)
+		if s.To != nil {
+			buf.WriteString(" to ")
+			formatExpr(buf, s.To, false /* parens */)
+		}
+	}
+	buf.WriteByte(']')
+}
+
+func formatLevel(buf *bytes.Buffer, level int) {
+	if level < 0 {
+		buf.WriteString("last")
+	} else {
+		buf.WriteString(strconv.Itoa(level))
+	}
+}
+
+func (a *AnyPath) format(buf *bytes.Buffer) {
+	buf.WriteString(".**")
+	switch {
+	case a.First == 0 && a.Last < 0:
+	case a.First == a.Last:
+		buf.WriteByte('{')
+		formatLevel(buf, a.First)
+		buf.WriteByte('}')
+	default:
+		buf.WriteByte('{')
+		formatLevel(buf, a.First)
+		buf.WriteString(" to ")
+		formatLevel(buf, a.Last)
+		buf.WriteByte('}')
+	}
+}
+
+func (a *Filter) format(buf *bytes.Buffer) {
+	buf.WriteString("?(")
+	formatExpr(buf, a.Pred, false /* parens */)
+	buf.WriteByte(')')
+}
+
+func (a *Method) format(buf *bytes.Buffer) {
+	buf.WriteByte('.')
+	buf.WriteString(a.Name.String())
+	buf.WriteString("()")
+}
+
+// regexpFlags validates the flags of a like_regex predicate and returns the
+// equivalent flags of the regexp package.
+func regexpFlags(flags string) (string, error) {
+	var goFlags strings.Builder
+	for _, f := range flags {
+		switch f {
+		case 'i':
+			goFlags.WriteByte('i')
+		case 's':
+			goFlags.WriteByte('s')
+		case 'm':
+			goFlags.WriteByte('m')
+		case 'x', 'q':
+			// Extended syntax and literal patterns are handled separately.
+		default:
+			return "", errors.WithDetailf(
+				newSyntaxErrorf("invalid input syntax for type jsonpath"),
+				"Unrecognized flag character %q in LIKE_REGEX predicate.", f,
+			)
+		}
+	}
+	return goFlags.String(), nil
+}
+
+// compile compiles the regular expression of the predicate.
+func (e *LikeRegex) compile() error {
+	goFlags, err := regexpFlags(e.Flags)
+	if err != nil {
+		return err
+	}
+	pattern := e.Pattern
+	if strings.ContainsRune(e.Flags, 'q') {
+		pattern = regexp.QuoteMeta(pattern)
+	} else if strings.ContainsRune(e.Flags, 'x') {
+		// In extended mode, whitespace in the pattern is ignored.
+		pattern = strings.Map(func(r rune) rune {
+			switch r {
+			case ' ', '\t', '\n', '\r', '\f', '\v':
+				return -1
+			}
+			return r
+		}, pattern)
+	}
+	if goFlags != "" {
+		pattern = "(?" + goFlags + ")" + pattern
+	}
+	e.re, err = regexp.Compile(pattern)
+	if err != nil {
+		return newInvalidRegexpError(err)
+	}
+	return nil
+}
@@ -0,0 +1,832 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/cockroachdb/errors"
+)
+
+// errSuppressible marks the errors which are suppressed when a path is
+// evaluated with the silent option.
+var errSuppressible = errors.New("suppressible jsonpath error")
+
+// newEvalErrorf creates an error raised during the evaluation of a path
+// because of the structure or the contents of the queried document. These
+// errors are suppressed when the path is evaluated with the silent option.
+func newEvalErrorf(code pgcode.Code, format string, args ...interface{}) error {
+	return errors.Mark(pgerror.Newf(code, format, args...), errSuppressible)
+}
+
+// IsSuppressibleError returns true if the error is one which is suppressed
+// when the silent option is passed to the jsonb_path_* functions.
+func IsSuppressibleError(err error) bool {
+	return errors.Is(err, errSuppressible)
+}
+
+// decimalCtx is used for arithmetic on numbers. It matches the context used
+// for the DECIMAL type.
+var decimalCtx = &apd.Context{
+	Precision:   20,
+	Rounding:    apd.RoundHalfUp,
+	MaxExponent: 2000,
+	MinExponent: -2000,
+	Traps:       apd.DefaultTraps,
+}
+
+// exactCtx is used for the arithmetic operations which don't lose
+// precision.
+var exactCtx = decimalCtx.WithPrecision(0)
+
+// ternary is the result of a predicate.
+type ternary int
+
+const (
+	tFalse ternary = iota
+	tTrue
+	tUnknown
+)
+
+func makeTernary(b bool) ternary {
+	if b {
+		return tTrue
+	}
+	return tFalse
+}
+
+// toJSON converts the result of a predicate to a JSON boolean, or null if the
+// result is unknown.
+func (t ternary) toJSON() json.JSON {
+	switch t {
+	case tTrue:
+		return json.TrueJSONValue
+	case tFalse:
+		return json.FalseJSONValue
+	default:
+		return json.NullJSONValue
+	}
+}
+
+// evaluator holds the state of the evaluation of a path.
+type evaluator struct {
+	root   json.JSON
+	vars   json.JSON
+	strict bool
+	// current is the value of @, which is the item being filtered.
+	current json.JSON
+	// lastIndex is the value of last, which is the last index of the array
+	// being subscripted.
+	lastIndex int
+}
+
+// Eval evaluates the path against the target document and returns the
+// resulting sequence of items. vars is a JSON object containing the values of
+// the named variables referenced in the path, or nil if there are none.
+func (p *Path) Eval(target, vars json.JSON) ([]json.JSON, error) {
+	if vars != nil && vars.Type() != json.ObjectJSONType {
+		return nil, pgerror.New(pgcode.InvalidParameterValue,
+			`"vars" argument is not an object`)
+	}
+	e := evaluator{root: target, vars: vars, strict: p.Strict}
+	return e.eval(p.Expr)
+}
+
+// Exists returns whether the path returns any items for the target document.
+// ok is false if the result is unknown, which is the case when the evaluation
+// fails with a suppressible error and silent is true.
+func (p *Path) Exists(target, vars json.JSON, silent bool) (exists bool, ok bool, err error) {
+	items, err := p.Eval(target, vars)
+	if err != nil {
+		if silent && IsSuppressibleError(err) {
+			return false, false, nil
+		}
+		return false, false, err
+	}
+	return len(items) > 0, true, nil
+}
+
+// Match returns the result of a path predicate for the target document. The
+// path must return a single boolean or null item. ok is false if the result is
+// null, or if the evaluation fails with a suppressible error and silent is
+// true.
+func (p *Path) Match(target, vars json.JSON, silent bool) (match bool, ok bool, err error) {
+	items, err := p.Eval(target, vars)
+	if err == nil && len(items) == 1 {
+		switch items[0].Type() {
+		case json.TrueJSONType:
+			return true, true, nil
+		case json.FalseJSONType:
+			return false, true, nil
+		case json.NullJSONType:
+			return false, false, nil
+		}
+	}
+	if err == nil {
+		err = newEvalErrorf(pgcode.SingletonSQLJSONItemRequired,
+			"single boolean result is expected")
+	}
+	if silent && IsSuppressibleError(err) {
+		return false, false, nil
+	}
+	return false, false, err
+}
+
+// eval evaluates an expression and returns the resulting sequence of items.
+func (e *evaluator) eval(expr Expr) ([]json.JSON, error) {
+	switch t := expr.(type) {
+	case *Root:
+		return []json.JSON{e.root}, nil
+
+	case *Current:
+		return []json.JSON{e.current}, nil
+
+	case *Last:
+		return []json.JSON{json.FromInt(e.lastIndex)}, nil
+
+	case *Variable:
+		var val json.JSON
+		if e.vars != nil {
+			var err error
+			if val, err = e.vars.FetchValKey(t.Name); err != nil {
+				return nil, err
+			}
+		}
+		if val == nil {
+			return nil, pgerror.Newf(pgcode.UndefinedObject,
+				"could not find jsonpath variable %q", t.Name)
+		}
+		return []json.JSON{val}, nil
+
+	case *Scalar:
+		return []json.JSON{t.Val}, nil
+
+	case *Chain:
+		items, err := e.eval(t.Base)
+		if err != nil {
+			return nil, err
+		}
+		for _, acc := range t.Accessors {
+			var next []json.JSON
+			for _, item := range items {
+				if next, err = e.applyAccessor(acc, item, next); err != nil {
+					return nil, err
+				}
+			}
+			items = next
+		}
+		return items, nil
+
+	case *Binary:
+		if t.Op.isPredicate() {
+			return e.evalPredicateAsItem(t)
+		}
+		return e.evalArithmetic(t)
+
+	case *Unary:
+		if t.Op == OpPlus || t.Op == OpMinus {
+			return e.evalUnaryArithmetic(t)
+		}
+		return e.evalPredicateAsItem(t)
+
+	case *LikeRegex:
+		return e.evalPredicateAsItem(t)
+	}
+	return nil, errors.AssertionFailedf("unhandled jsonpath expression %T", expr)
+}
+
+// evalPredicateAsItem evaluates a predicate which is used as an expression,
+// which returns a single boolean, or null if the result is unknown.
+func (e *evaluator) evalPredicateAsItem(expr Expr) ([]json.JSON, error) {
+	res, err := e.evalPredicate(expr)
+	if err != nil {
+		return nil, err
+	}
+	return []json.JSON{res.toJSON()}, nil
+}
+
+// evalUnwrapped evaluates an expression and, in lax mode, replaces each array
+// in the result with its elements.
+func (e *evaluator) evalUnwrapped(expr Expr) ([]json.JSON, error) {
+	items, err := e.eval(expr)
+	if err != nil || e.strict {
+		return items, err
+	}
+	var res []json.JSON
+	for _, item := range items {
+		res = e.appendUnwrapped(res, item)
+	}
+	return res, nil
+}
+
+// appendUnwrapped appends the item to res if it is not an array, and the
+// elements of the item otherwise.
+func (e *evaluator) appendUnwrapped(res []json.JSON, item json.JSON) []json.JSON {
+	if elems, ok := item.AsArray(); ok && item.Type() == json.ArrayJSONType {
+		return append(res, elems...)
+	}
+	return append(res, item)
+}
+
+// applyAccessor applies the accessor to the item and appends the resulting
+// items to res.
+func (e *evaluator) applyAccessor(acc Accessor, item json.JSON, res []json.JSON) ([]json.JSON, error) {
+	isArray := item.Type() == json.ArrayJSONType
+	switch t := acc.(type) {
+	case *Key:
+		if item.Type() == json.ObjectJSONType {
+			val, err := item.FetchValKey(t.Name)
+			if err != nil {
+				return nil, err
+			}
+			if val != nil {
+				return append(res, val), nil
+			}
+			if e.strict {
+				return nil, newEvalErrorf(pgcode.SQLJSONMemberNotFound,
+					"JSON object does not contain key %q", t.Name)
+			}
+			return res, nil
+		}
+		if isArray && !e.strict {
+			return e.applyToElements(acc, item, res)
+		}
+		if e.strict {
+			return nil, newEvalErrorf(pgcode.SQLJSONMemberNotFound,
+				"jsonpath member accessor can only be applied to an object")
+		}
+		return res, nil
+
+	case *AnyKey:
+		if item.Type() == json.ObjectJSONType {
+			iter, err := item.ObjectIter()
+			if err != nil {
+				return nil, err
+			}
+			for iter.Next() {
+				res = append(res, iter.Value())
+			}
+			return res, nil
+		}
+		if isArray && !e.strict {
+			return e.applyToElements(acc, item, res)
+		}
+		if e.strict {
+			return nil, newEvalErrorf(pgcode.SQLJSONObjectNotFound,
+				"jsonpath wildcard member accessor can only be applied to an object")
+		}
+		return res, nil
+
+	case *AnyIndex:
+		if isArray {
+			elems, _ := item.AsArray()
+			return append(res, elems...), nil
+		}
+		if e.strict {
+			return nil, newEvalErrorf(pgcode.SQLJSONArrayNotFound,
+				"jsonpath wildcard array accessor can only be applied to an array")
+		}
+		return append(res, item), nil
+
+	case *Index:
+		return e.applyIndex(t, item, res)
+
+	case *AnyPath:
+		return appendDescendants(t, item, 0 /* level */, res), nil
+
+	case *Filter:
+		if isArray && !e.strict {
+			return e.applyToElements(acc, item, res)
+		}
+		prev := e.current
+		e.current = item
+		match, err := e.evalPredicate(t.Pred)
+		e.current = prev
+		if err != nil {
+			return nil, err
+		}
+		if match == tTrue {
+			res = append(res, item)
+		}
+		return res, nil
+
+	case *Method:
+		return e.applyMethod(t.Name, item, res)
+	}
+	return nil, errors.AssertionFailedf("unhandled jsonpath accessor %T", acc)
+}
+
+// applyToElements applies the accessor to each element of the array. It is
+// used to automatically unwrap arrays in lax mode.
+func (e *evaluator) applyToElements(acc Accessor, array json.JSON, res []json.JSON) ([]json.JSON, error) {
+	elems, _ := array.AsArray()
+	for _, elem := range elems {
+		// Only a single level of arrays is unwrapped.
+		if elem.Type() == json.ArrayJSONType {
+			if _, ok := acc.(*Filter); !ok {
+				continue
+			}
+		}
+		var err error
+		if res, err = e.applyAccessor(acc, elem, res); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (e *evaluator) applyIndex(acc *Index, item json.JSON, res []json.JSON) ([]json.JSON, error) {
+	var elems []json.JSON
+	if item.Type() == json.ArrayJSONType {
+		elems, _ = item.AsArray()
+	} else if e.strict {
+		return nil, newEvalErrorf(pgcode.SQLJSONArrayNotFound,
+			"jsonpath array accessor can only be applied to an array")
+	} else {
+		// In lax mode, a non-array item is treated as an array containing only
+		// the item.
+		elems = []json.JSON{item}
+	}
+	prevLast := e.lastIndex
+	e.lastIndex = len(elems) - 1
+	defer func() { e.lastIndex = prevLast }()
+	for _, sub := range acc.Subscripts {
+		from, err := e.evalSubscript(sub.From)
+		if err != nil {
+			return nil, err
+		}
+		to := from
+		if sub.To != nil {
+			if to, err = e.evalSubscript(sub.To); err != nil {
+				return nil, err
+			}
+		}
+		if from < 0 || from > to || to >= len(elems) {
+			if e.strict {
+				return nil, newEvalErrorf(pgcode.InvalidSQLJSONSubscript,
+					"jsonpath array subscript is out of bounds")
+			}
+			if from < 0 {
+				from = 0
+			}
+			if to >= len(elems) {
+				to = len(elems) - 1
+			}
+		}
+		for i := from; i <= to; i++ {
+			res = append(res, elems[i])
+		}
+	}
+	return res, nil
+}
+
+// evalSubscript evaluates an array subscript, which must be a single number.
+// The number is truncated to an integer.
+func (e *evaluator) evalSubscript(expr Expr) (int, error) {
+	items, err := e.eval(expr)
+	if err != nil {
+		return 0, err
+	}
+	if len(items) != 1 || items[0].Type() != json.NumberJSONType {
+		return 0, newEvalErrorf(pgcode.InvalidSQLJSONSubscript,
+			"jsonpath array subscript is not a single numeric value")
+	}
+	d, _ := items[0].AsDecimal()
+	i, err := truncateToInt64(d)
+	if err != nil || i < math.MinInt32 || i > math.MaxInt32 {
+		return 0, newEvalErrorf(pgcode.InvalidSQLJSONSubscript,
+			"jsonpath array subscript is out of integer range")
+	}
+	return int(i), nil
+}
+
+// truncateToInt64 truncates the decimal toward zero and converts it to an
+// int64.
+func truncateToInt64(d *apd.Decimal) (int64, error) {
+	var truncated apd.Decimal
+	ctx := exactCtx.WithPrecision(0)
+	ctx.Rounding = apd.RoundDown
+	if _, err := ctx.RoundToIntegralValue(&truncated, d); err != nil {
+		return 0, err
+	}
+	return truncated.Int64()
+}
+
+// appendDescendants appends the item and its descendants whose nesting levels
+// are in the range of the .** accessor to res. The level of the item is given.
+func appendDescendants(acc *AnyPath, item json.JSON, level int, res []json.JSON) []json.JSON {
+	if level >= acc.First && (acc.Last < 0 || level <= acc.Last) {
+		res = append(res, item)
+	}
+	if acc.Last >= 0 && level >= acc.Last {
+		return res
+	}
+	switch item.Type() {
+	case json.ObjectJSONType:
+		iter, _ := item.ObjectIter()
+		for iter != nil && iter.Next() {
+			res = appendDescendants(acc, iter.Value(), level+1, res)
+		}
+	case json.ArrayJSONType:
+		elems, _ := item.AsArray()
+		for _, elem := range elems {
+			res = appendDescendants(acc, elem, level+1, res)
+		}
+	}
+	return res
+}
+
+// typeName returns the name of the type of the item, as returned by the
+// .type() method.
+func typeName(item json.JSON) string {
+	switch item.Type() {
+	case json.ObjectJSONType:
+		return "object"
+	case json.ArrayJSONType:
+		return "array"
+	case json.StringJSONType:
+		return "string"
+	case json.NumberJSONType:
+		return "number"
+	case json.TrueJSONType, json.FalseJSONType:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
+
+func (e *evaluator) applyMethod(m MethodName, item json.JSON, res []json.JSON) ([]json.JSON, error) {
+	switch m {
+	case MethodType:
+		return append(res, json.FromString(typeName(item))), nil
+
+	case MethodSize:
+		if item.Type() == json.ArrayJSONType {
+			return append(res, json.FromInt(item.Len())), nil
+		}
+		if e.strict {
+			return nil, newEvalErrorf(pgcode.SQLJSONArrayNotFound,
+				"jsonpath item method .size() can only be applied to an array")
+		}
+		return append(res, json.FromInt(1)), nil
+	}
+
+	// The remaining methods are applied to the elements of arrays in lax mode.
+	if item.Type() == json.ArrayJSONType && !e.strict {
+		return e.applyToElements(&Method{Name: m}, item, res)
+	}
+	switch m {
+	case MethodDouble:
+		var f float64
+		switch item.Type() {
+		case json.NumberJSONType:
+			d, _ := item.AsDecimal()
+			var err error
+			if f, err = d.Float64(); err != nil {
+				return nil, newEvalErrorf(pgcode.NonNumericSQLJSONItem,
+					"numeric argument of jsonpath item method .double() is out of range for type double precision")
+			}
+		case json.StringJSONType:
+			s, _ := item.AsText()
+			var err error
+			if f, err = strconv.ParseFloat(strings.TrimSpace(*s), 64); err != nil {
+				return nil, newEvalErrorf(pgcode.NonNumericSQLJSONItem,
+					"string argument of jsonpath item method .double() is not a valid representation of a double precision number")
+			}
+		default:
+			return nil, newEvalErrorf(pgcode.NonNumericSQLJSONItem,
+				"jsonpath item method .double() can only be applied to a string or numeric value")
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, newEvalErrorf(pgcode.NonNumericSQLJSONItem,
+				"NaN or Infinity is not allowed for jsonpath item method .double()")
+		}
+		j, err := json.FromFloat64(f)
+		if err != nil {
+			return nil, err
+		}
+		return append(res, j), nil
+
+	case MethodCeiling, MethodFloor, MethodAbs:
+		d, ok := item.AsDecimal()
+		if !ok {
+			return nil, newEvalErrorf(pgcode.NonNumericSQLJSONItem,
+				"jsonpath item method .%s() can only be applied to a numeric value", m)
+		}
+		var r apd.Decimal
+		var err error
+		switch m {
+		case MethodCeiling:
+			_, err = exactCtx.Ceil(&r, d)
+		case MethodFloor:
+			_, err = exactCtx.Floor(&r, d)
+		default:
+			r.Abs(d)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return append(res, json.FromDecimal(r)), nil
+
+	case MethodKeyValue:
+		if item.Type() != json.ObjectJSONType {
+			return nil, newEvalErrorf(pgcode.SQLJSONObjectNotFound,
+				"jsonpath item method .keyvalue() can only be applied to an object")
+		}
+		iter, err := item.ObjectIter()
+		if err != nil {
+			return nil, err
+		}
+		for iter.Next() {
+			b := json.NewObjectBuilder(3)
+			b.Add("key", json.FromString(iter.Key()))
+			b.Add("value", iter.Value())
+			b.Add("id", json.FromInt(0))
+			res = append(res, b.Build())
+		}
+		return res, nil
+	}
+	return nil, errors.AssertionFailedf("unhandled jsonpath method %s", m)
+}
+
+// evalSingleNumber evaluates an operand of an arithmetic operator, which must
+// be a single number.
+func (e *evaluator) evalSingleNumber(expr Expr, op BinaryOp, side string) (*apd.Decimal, error) {
+	items, err := e.evalUnwrapped(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 1 {
+		if d, ok := items[0].AsDecimal(); ok {
+			return d, nil
+		}
+	}
+	return nil, newEvalErrorf(pgcode.SingletonSQLJSONItemRequired,
+		"%s operand of jsonpath operator %s is not a single numeric value", side, op)
+}
+
+func (e *evaluator) evalArithmetic(expr *Binary) ([]json.JSON, error) {
+	left, err := e.evalSingleNumber(expr.Left, expr.Op, "left")
+	if err != nil {
+		return nil, err
+	}
+	right, err := e.evalSingleNumber(expr.Right, expr.Op, "right")
+	if err != nil {
+		return nil, err
+	}
+	var r apd.Decimal
+	switch expr.Op {
+	case OpAdd:
+		_, err = exactCtx.Add(&r, left, right)
+	case OpSub:
+		_, err = exactCtx.Sub(&r, left, right)
+	case OpMul:
+		_, err = exactCtx.Mul(&r, left, right)
+	case OpDiv, OpMod:
+		if right.IsZero() {
+			return nil, newEvalErrorf(pgcode.DivisionByZero, "division by zero")
+		}
+		if expr.Op == OpDiv {
+			if _, err = decimalCtx.Quo(&r, left, right); err == nil {
+				r.Reduce(&r)
+			}
+		} else {
+			_, err = decimalCtx.Rem(&r, left, right)
+		}
+	}
+	if err != nil {
+		return nil, errors.Mark(pgerror.Wrap(err, pgcode.NumericValueOutOfRange, ""), errSuppressible)
+	}
+	return []json.JSON{json.FromDecimal(r)}, nil
+}
+
+func (e *evaluator) evalUnaryArithmetic(expr *Unary) ([]json.JSON, error) {
+	items, err := e.evalUnwrapped(expr.Operand)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]json.JSON, len(items))
+	for i, item := range items {
+		d, ok := item.AsDecimal()
+		if !ok {
+			op := "+"
+			if expr.Op == OpMinus {
+				op = "-"
+			}
+			return nil, newEvalErrorf(pgcode.NonNumericSQLJSONItem,
+				"operand of unary jsonpath operator %s is not a numeric value", op)
+		}
+		if expr.Op == OpPlus {
+			res[i] = item
+			continue
+		}
+		var neg apd.Decimal
+		neg.Neg(d)
+		res[i] = json.FromDecimal(neg)
+	}
+	return res, nil
+}
+
+// evalPredicate evaluates a predicate. Errors raised while evaluating the
+// operands of the predicate make its result unknown.
+func (e *evaluator) evalPredicate(expr Expr) (ternary, error) {
+	res, err := e.evalPredicateInternal(expr)
+	if err != nil {
+		if IsSuppressibleError(err) {
+			return tUnknown, nil
+		}
+		return tUnknown, err
+	}
+	return res, nil
+}
+
+func (e *evaluator) evalPredicateInternal(expr Expr) (ternary, error) {
+	switch t := expr.(type) {
+	case *Binary:
+		switch t.Op {
+		case OpAnd, OpOr:
+			left, err := e.evalPredicate(t.Left)
+			if err != nil {
+				return tUnknown, err
+			}
+			// A short-circuit value of false for && and true for ||.
+			short := makeTernary(t.Op == OpOr)
+			if left == short {
+				return short, nil
+			}
+			right, err := e.evalPredicate(t.Right)
+			if err != nil {
+				return tUnknown, err
+			}
+			if right == short {
+				return short, nil
+			}
+			if left == tUnknown || right == tUnknown {
+				return tUnknown, nil
+			}
+			return left, nil
+
+		case OpStartsWith:
+			return e.evalItemPredicate(t.Left, t.Right, func(item, prefix json.JSON) ternary {
+				s, p := asString(item), asString(prefix)
+				if s == nil || p == nil {
+					return tUnknown
+				}
+				return makeTernary(strings.HasPrefix(*s, *p))
+			})
+
+		default:
+			return e.evalItemPredicate(t.Left, t.Right, func(left, right json.JSON) ternary {
+				return compareItems(t.Op, left, right)
+			})
+		}
+
+	case *Unary:
+		switch t.Op {
+		case OpNot:
+			res, err := e.evalPredicate(t.Operand)
+			if err != nil || res == tUnknown {
+				return tUnknown, err
+			}
+			return makeTernary(res == tFalse), nil
+
+		case OpIsUnknown:
+			res, err := e.evalPredicate(t.Operand)
+			if err != nil {
+				return tUnknown, err
+			}
+			return makeTernary(res == tUnknown), nil
+
+		case OpExists:
+			items, err := e.eval(t.Operand)
+			if err != nil {
+				return tUnknown, err
+			}
+			return makeTernary(len(items) > 0), nil
+		}
+
+	case *LikeRegex:
+		return e.evalItemPredicate(t.Operand, nil /* right */, func(item, _ json.JSON) ternary {
+			s := asString(item)
+			if s == nil {
+				return tUnknown
+			}
+			return makeTernary(t.re.MatchString(*s))
+		})
+	}
+	return tUnknown, errors.AssertionFailedf("unhandled jsonpath predicate %T", expr)
+}
+
+// evalItemPredicate evaluates a predicate which compares the items returned
+// by the left and right expressions. The predicate is true if it holds for
+// any pair of items. If it is unknown for some pair, the result is unknown in
+// strict mode, and unknown in lax mode only if it does not hold for any other
+// pair. If right is nil, the predicate is applied to the left items only.
+func (e *evaluator) evalItemPredicate(
+	left, right Expr, pred func(left, right json.JSON) ternary,
+) (ternary, error) {
+	leftItems, err := e.evalUnwrapped(left)
+	if err != nil {
+		return tUnknown, err
+	}
+	rightItems := []json.JSON{nil}
+	if right != nil {
+		if rightItems, err = e.evalUnwrapped(right); err != nil {
+			return tUnknown, err
+		}
+	}
+	found, unknown := false, false
+	for _, l := range leftItems {
+		for _, r := range rightItems {
+			switch pred(l, r) {
+			case tUnknown:
+				if e.strict {
+					return tUnknown, nil
+				}
+				unknown = true
+			case tTrue:
+				if !e.strict {
+					return tTrue, nil
+				}
+				found = true
+			}
+		}
+	}
+	if found {
+		return tTrue, nil
+	}
+	if unknown {
+		return tUnknown, nil
+	}
+	return tFalse, nil
+}
+
+// asString returns the value of a JSON string, or nil if the item is not a
+// string.
+func asString(item json.JSON) *string {
+	if item == nil || item.Type() != json.StringJSONType {
+		return nil
+	}
+	s, _ := item.AsText()
+	return s
+}
+
+// isBool returns true if the item is a JSON boolean.
+func isBool(item json.JSON) bool {
+	return item.Type() == json.TrueJSONType || item.Type() == json.FalseJSONType
+}
+
+// compareItems applies a comparison operator to two items. Only scalars of the
+// same type can be compared; any other comparison is unknown, except for
+// comparisons with null, which are false (or true for !=).
+func compareItems(op BinaryOp, left, right json.JSON) ternary {
+	lt, rt := left.Type(), right.Type()
+	if lt != rt && !(isBool(left) && isBool(right)) {
+		if lt == json.NullJSONType || rt == json.NullJSONType {
+			return makeTernary(op == OpNe)
+		}
+		return tUnknown
+	}
+	var cmp int
+	switch lt {
+	case json.NullJSONType:
+		cmp = 0
+	case json.TrueJSONType, json.FalseJSONType, json.NumberJSONType, json.StringJSONType:
+		var err error
+		if cmp, err = left.Compare(right); err != nil {
+			return tUnknown
+		}
+	default:
+		// Arrays and objects can't be compared.
+		return tUnknown
+	}
+	switch op {
+	case OpEq:
+		return makeTernary(cmp == 0)
+	case OpNe:
+		return makeTernary(cmp != 0)
+	case OpLt:
+		return makeTernary(cmp < 0)
+	case OpLe:
+		return makeTernary(cmp <= 0)
+	case OpGt:
+		return makeTernary(cmp > 0)
+	case OpGe:
+		return makeTernary(cmp >= 0)
+	}
+	return tUnknown
+}
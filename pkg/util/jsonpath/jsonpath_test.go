@@ -0,0 +1,276 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected string
+	}{
+		{`$`, `$`},
+		{`  $  `, `$`},
+		{`strict $`, `strict $`},
+		{`lax $`, `$`},
+		{`$.a`, `$."a"`},
+		{`$."a b"`, `$."a b"`},
+		{`$.a.b[*]`, `$."a"."b"[*]`},
+		{`$.*`, `$.*`},
+		{`$[0]`, `$[0]`},
+		{`$[1, 2 to 3, last]`, `$[1,2 to 3,last]`},
+		{`$[last - 1]`, `$[last - 1]`},
+		{`$.**`, `$.**`},
+		{`$.**{2}`, `$.**{2}`},
+		{`$.**{1 to last}`, `$.**{1 to last}`},
+		{`$.a.type()`, `$."a".type()`},
+		{`$.size()`, `$.size()`},
+		{`$.a.double().ceiling().floor().abs()`, `$."a".double().ceiling().floor().abs()`},
+		{`$.keyvalue()`, `$.keyvalue()`},
+		{`$ ? (@ > 1)`, `$?(@ > 1)`},
+		{`$.a ? (@.b == "x" && @.c != null)`, `$."a"?(@."b" == "x" && @."c" != null)`},
+		{`$ ? (@ < 1 || @ >= 2 && @ <> 3)`, `$?(@ < 1 || @ >= 2 && @ != 3)`},
+		{`$ ? ((@ < 1 || @ >= 2) && @ <= 3)`, `$?((@ < 1 || @ >= 2) && @ <= 3)`},
+		{`$ ? (!(@ == true))`, `$?(!(@ == true))`},
+		{`$ ? (exists (@.a))`, `$?(exists (@."a"))`},
+		{`$ ? ((@ == 1) is unknown)`, `$?((@ == 1) is unknown)`},
+		{`$ ? (@ starts with "ab")`, `$?(@ starts with "ab")`},
+		{`$ ? (@ starts with $x)`, `$?(@ starts with $"x")`},
+		{`$ ? (@ like_regex "^a.*")`, `$?(@ like_regex "^a.*")`},
+		{`$ ? (@ like_regex "^a" flag "i")`, `$?(@ like_regex "^a" flag "i")`},
+		{`$.a + 1`, `($."a" + 1)`},
+		{`1 + 2 * 3`, `(1 + 2 * 3)`},
+		{`(1 + 2) * 3`, `((1 + 2) * 3)`},
+		{`-$.a`, `(-$."a")`},
+		{`-1`, `-1`},
+		{`$.a == 1`, `($."a" == 1)`},
+		{`$var`, `$"var"`},
+		{`$"a b"`, `$"a b"`},
+		{`"a\nbA"`, `"a\nbA"`},
+		{`1.5e2`, `150`},
+		{`0.5e-1`, `0.05`},
+		{`null`, `null`},
+		{`TRUE`, `true`},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			p, err := Parse(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, p.String())
+			// The formatted path must parse to the same path.
+			p2, err := Parse(p.String())
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, p2.String())
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected string
+	}{
+		{``, `syntax error`},
+		{`$.`, `syntax error`},
+		{`$[`, `syntax error`},
+		{`$ ? (@)`, `syntax error`},
+		{`@`, `@ is not allowed in root expressions`},
+		{`last`, `LAST is allowed only in array subscripts`},
+		{`$.a.foo()`, `syntax error`},
+		{`$.datetime()`, `.datetime() method is not supported`},
+		{`$ ? (@ like_regex "(")`, `invalid regular expression`},
+		{`$ ? (@ like_regex "a" flag "z")`, `invalid input syntax for type jsonpath`},
+		{`1a`, `trailing junk`},
+		{`01`, `trailing junk`},
+		{`"abc`, `unexpected end of jsonpath input`},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := Parse(tc.input)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestEval(t *testing.T) {
+	const doc = `{
+		"a": 1,
+		"b": [1, 2, 3, {"c": "x"}],
+		"d": {"e": {"f": true}, "g": null},
+		"s": "foobar",
+		"n": [[1, 2], [3]]
+	}`
+	for _, tc := range []struct {
+		path     string
+		target   string
+		vars     string
+		expected string
+		err      string
+	}{
+		{path: `$`, target: `1`, expected: `[1]`},
+		{path: `$.a`, expected: `[1]`},
+		{path: `$.missing`, expected: `[]`},
+		{path: `strict $.missing`, err: `JSON object does not contain key "missing"`},
+		{path: `$.b[*]`, expected: `[1, 2, 3, {"c": "x"}]`},
+		{path: `$.b[1 to 2]`, expected: `[2, 3]`},
+		{path: `$.b[last]`, expected: `[{"c": "x"}]`},
+		{path: `$.b[last - 1, 0]`, expected: `[3, 1]`},
+		{path: `$.b[1.7]`, expected: `[2]`},
+		{path: `$.b[10]`, expected: `[]`},
+		{path: `strict $.b[10]`, err: `jsonpath array subscript is out of bounds`},
+		{path: `$.a[0]`, expected: `[1]`},
+		{path: `strict $.a[0]`, err: `jsonpath array accessor can only be applied to an array`},
+		{path: `$.a[*]`, expected: `[1]`},
+		{path: `strict $.a[*]`, err: `jsonpath wildcard array accessor can only be applied to an array`},
+		// Lax mode unwraps a single level of arrays for member accessors.
+		{path: `$.b.c`, expected: `["x"]`},
+		{path: `strict $.b.c`, err: `jsonpath member accessor can only be applied to an object`},
+		{path: `$.n.c`, expected: `[]`},
+		{path: `$.d.*`, expected: `[{"f": true}, null]`},
+		{path: `$.d.**`, expected: `[{"e": {"f": true}, "g": null}, {"f": true}, true, null]`},
+		{path: `$.d.**{2}`, expected: `[true]`},
+		{path: `$.d.**{1 to last}`, expected: `[{"f": true}, true, null]`},
+		{path: `$.b[*] ? (@ > 1)`, expected: `[2, 3]`},
+		{path: `$.b ? (@ > 1)`, expected: `[2, 3]`},
+		{path: `$.b[*] ? (@.c == "x")`, expected: `[{"c": "x"}]`},
+		{path: `$ ? (@.a == 1).s`, expected: `["foobar"]`},
+		{path: `$ ? (@.a == 2).s`, expected: `[]`},
+		{path: `$.b[*] ? (@ > $min && @ < $max)`, vars: `{"min": 1, "max": 3}`, expected: `[2]`},
+		{path: `$.b[*] ? (@ == $x)`, vars: `{}`, err: `could not find jsonpath variable "x"`},
+		{path: `$.b[*] ? (@ == $x)`, vars: `[]`, err: `"vars" argument is not an object`},
+		{path: `$.s ? (@ starts with "foo")`, expected: `["foobar"]`},
+		{path: `$.s ? (@ like_regex "^F" flag "i")`, expected: `["foobar"]`},
+		{path: `$.s ? (@ like_regex "^F")`, expected: `[]`},
+		{path: `$.d ? (exists (@.e.f))`, expected: `[{"e": {"f": true}, "g": null}]`},
+		{path: `$.d ? (!exists (@.x))`, expected: `[{"e": {"f": true}, "g": null}]`},
+		{path: `$.d.g ? (@ != 1)`, expected: `[null]`},
+		{path: `$.d.g ? (@ == 1)`, expected: `[]`},
+		{path: `$.b[*] ? ((@ > "a") is unknown)`, expected: `[1, 2, 3, {"c": "x"}]`},
+		{path: `$.a == 1`, expected: `[true]`},
+		{path: `$.a == "1"`, expected: `[null]`},
+		{path: `$.b[*] > 2`, expected: `[true]`},
+		{path: `strict $.b[*] > 2`, expected: `[null]`},
+		{path: `$.a + 2`, expected: `[3]`},
+		{path: `$.a * 2.5 - 1`, expected: `[1.5]`},
+		{path: `10 / 4`, expected: `[2.5]`},
+		{path: `10 % 4`, expected: `[2]`},
+		{path: `$.a / 0`, err: `division by zero`},
+		{path: `$.b + 1`, err: `left operand of jsonpath operator + is not a single numeric value`},
+		{path: `-$.b[0 to 2]`, expected: `[-1, -2, -3]`},
+		{path: `-$.s`, err: `operand of unary jsonpath operator - is not a numeric value`},
+		{path: `$.b.type()`, expected: `["array"]`},
+		{path: `$.b[*].type()`, expected: `["number", "number", "number", "object"]`},
+		{path: `$.b.size()`, expected: `[4]`},
+		{path: `$.a.size()`, expected: `[1]`},
+		{path: `strict $.a.size()`, err: `jsonpath item method .size() can only be applied to an array`},
+		{path: `$.double()`, target: `"1.5"`, expected: `[1.5]`},
+		{path: `$.double()`, target: `"abc"`, err: `not a valid representation of a double precision number`},
+		{path: `$[*].ceiling()`, target: `[1.2, -1.2]`, expected: `[2, -1]`},
+		{path: `$[*].floor()`, target: `[1.2, -1.2]`, expected: `[1, -2]`},
+		{path: `$.abs()`, target: `[-1, 2]`, expected: `[1, 2]`},
+		{path: `$.s.abs()`, err: `jsonpath item method .abs() can only be applied to a numeric value`},
+		{path: `$.d.e.keyvalue()`, expected: `[{"id": 0, "key": "f", "value": true}]`},
+		{path: `$.a.keyvalue()`, err: `jsonpath item method .keyvalue() can only be applied to an object`},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			p, err := Parse(tc.path)
+			require.NoError(t, err)
+			targetStr := tc.target
+			if targetStr == "" {
+				targetStr = doc
+			}
+			target, err := json.ParseJSON(targetStr)
+			require.NoError(t, err)
+			var vars json.JSON
+			if tc.vars != "" {
+				vars, err = json.ParseJSON(tc.vars)
+				require.NoError(t, err)
+			}
+			res, err := p.Eval(target, vars)
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			strs := make([]string, len(res))
+			for i := range res {
+				strs[i] = res[i].String()
+			}
+			require.Equal(t, tc.expected, "["+strings.Join(strs, ", ")+"]")
+		})
+	}
+}
+
+func TestIsSuppressibleError(t *testing.T) {
+	target, err := json.ParseJSON(`{"a": [1]}`)
+	require.NoError(t, err)
+	for _, tc := range []struct {
+		path         string
+		suppressible bool
+	}{
+		{`strict $.b`, true},
+		{`strict $.a[5]`, true},
+		{`$.a[*] / 0`, true},
+		{`$.a[*] ? (@ == $x)`, false},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			_, err := MustParse(tc.path).Eval(target, nil /* vars */)
+			require.Error(t, err)
+			require.Equal(t, tc.suppressible, IsSuppressibleError(err))
+		})
+	}
+}
+
+func TestExistsAndMatch(t *testing.T) {
+	target, err := json.ParseJSON(`{"a": [1, 2], "b": "x"}`)
+	require.NoError(t, err)
+	for _, tc := range []struct {
+		path   string
+		match  bool
+		silent bool
+		res    bool
+		ok     bool
+		err    string
+	}{
+		{path: `$.a[*] ? (@ > 1)`, res: true, ok: true},
+		{path: `$.a[*] ? (@ > 2)`, res: false, ok: true},
+		{path: `strict $.c`, err: `JSON object does not contain key "c"`},
+		{path: `strict $.c`, silent: true, ok: false},
+		{path: `$.a[*] > 1`, match: true, res: true, ok: true},
+		{path: `$.a[*] > 2`, match: true, res: false, ok: true},
+		{path: `$.b > 1`, match: true, ok: false},
+		{path: `$.a`, match: true, err: `single boolean result is expected`},
+		{path: `$.a`, match: true, silent: true, ok: false},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			p := MustParse(tc.path)
+			eval := p.Exists
+			if tc.match {
+				eval = p.Match
+			}
+			res, ok, err := eval(target, nil /* vars */, tc.silent)
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.res, res)
+		})
+	}
+}
@@ -0,0 +1,851 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/apd/v3"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/util/json"
+)
+
+func newSyntaxErrorf(format string, args ...interface{}) error {
+	return pgerror.Newf(pgcode.Syntax, format, args...)
+}
+
+func newInvalidRegexpError(err error) error {
+	return pgerror.Wrap(err, pgcode.InvalidRegularExpression, "invalid regular expression")
+}
+
+// tokenKind is the kind of a lexical token of a path expression.
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	// tokIdent is an unquoted word, which is either a keyword or a key name.
+	tokIdent
+	// tokString is a double-quoted string.
+	tokString
+	// tokNumber is a numeric literal.
+	tokNumber
+	// tokVariable is a named variable, such as $x or $"x".
+	tokVariable
+	// tokOp is an operator or punctuation, such as "(" or "<=".
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	// val is the text of the token. For strings and variables, escapes have
+	// been processed and quotes removed.
+	val string
+}
+
+// scanner splits a path expression into tokens.
+type scanner struct {
+	input string
+	pos   int
+}
+
+// multiCharOps contains the operators which are longer than one character,
+// ordered so that longer operators are matched first.
+var multiCharOps = []string{"**", "==", "!=", "<>", "<=", ">=", "&&", "||"}
+
+const singleCharOps = "$@()[]{}.,?!<>+-*/%"
+
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isIdentPart(r rune) bool {
+	return isIdentStart(r) || unicode.IsDigit(r)
+}
+
+func (s *scanner) next() (token, error) {
+	for s.pos < len(s.input) {
+		r, size := utf8.DecodeRuneInString(s.input[s.pos:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		s.pos += size
+	}
+	if s.pos >= len(s.input) {
+		return token{kind: tokEOF}, nil
+	}
+	rest := s.input[s.pos:]
+	r, size := utf8.DecodeRuneInString(rest)
+	switch {
+	case r == '"':
+		s.pos++
+		str, err := s.scanString()
+		return token{kind: tokString, val: str}, err
+	case r >= '0' && r <= '9':
+		return s.scanNumber()
+	case r == '$':
+		// $ followed by a name is a variable; $ alone is the root.
+		s.pos++
+		if s.pos < len(s.input) && s.input[s.pos] == '"' {
+			s.pos++
+			str, err := s.scanString()
+			return token{kind: tokVariable, val: str}, err
+		}
+		if name := s.scanIdent(); name != "" {
+			return token{kind: tokVariable, val: name}, nil
+		}
+		return token{kind: tokOp, val: "$"}, nil
+	case isIdentStart(r):
+		return token{kind: tokIdent, val: s.scanIdent()}, nil
+	}
+	for _, op := range multiCharOps {
+		if strings.HasPrefix(rest, op) {
+			s.pos += len(op)
+			return token{kind: tokOp, val: op}, nil
+		}
+	}
+	if strings.ContainsRune(singleCharOps, r) {
+		s.pos += size
+		return token{kind: tokOp, val: string(r)}, nil
+	}
+	return token{}, newSyntaxErrorf("syntax error at or near %q of jsonpath input", string(r))
+}
+
+func (s *scanner) scanIdent() string {
+	start := s.pos
+	for s.pos < len(s.input) {
+		r, size := utf8.DecodeRuneInString(s.input[s.pos:])
+		if !isIdentPart(r) {
+			break
+		}
+		s.pos += size
+	}
+	return s.input[start:s.pos]
+}
+
+// scanString scans a double-quoted string whose opening quote has already
+// been consumed.
+func (s *scanner) scanString() (string, error) {
+	var b strings.Builder
+	for s.pos < len(s.input) {
+		c := s.input[s.pos]
+		s.pos++
+		switch c {
+		case '"':
+			return b.String(), nil
+		case '\\':
+			if s.pos >= len(s.input) {
+				return "", newSyntaxErrorf("unexpected end of jsonpath input")
+			}
+			esc := s.input[s.pos]
+			s.pos++
+			switch esc {
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'v':
+				b.WriteByte('\v')
+			case 'x':
+				if s.pos+2 > len(s.input) {
+					return "", newSyntaxErrorf("invalid hexadecimal character sequence in jsonpath input")
+				}
+				v, err := strconv.ParseUint(s.input[s.pos:s.pos+2], 16, 8)
+				if err != nil {
+					return "", newSyntaxErrorf("invalid hexadecimal character sequence in jsonpath input")
+				}
+				s.pos += 2
+				b.WriteRune(rune(v))
+			case 'u':
+				r, err := s.scanUnicodeEscape()
+				if err != nil {
+					return "", err
+				}
+				b.WriteRune(r)
+			default:
+				b.WriteByte(esc)
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", newSyntaxErrorf("unexpected end of jsonpath input")
+}
+
+// scanUnicodeEscape scans the code point of a \uXXXX or \u{X...} escape.
+func (s *scanner) scanUnicodeEscape() (rune, error) {
+	var hex string
+	if s.pos < len(s.input) && s.input[s.pos] == '{' {
+		end := strings.IndexByte(s.input[s.pos:], '}')
+		if end < 0 {
+			return 0, newSyntaxErrorf("invalid Unicode escape sequence in jsonpath input")
+		}
+		hex = s.input[s.pos+1 : s.pos+end]
+		s.pos += end + 1
+	} else {
+		if s.pos+4 > len(s.input) {
+			return 0, newSyntaxErrorf("invalid Unicode escape sequence in jsonpath input")
+		}
+		hex = s.input[s.pos : s.pos+4]
+		s.pos += 4
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || hex == "" || !utf8.ValidRune(rune(v)) {
+		return 0, newSyntaxErrorf("invalid Unicode escape sequence in jsonpath input")
+	}
+	return rune(v), nil
+}
+
+func (s *scanner) scanNumber() (token, error) {
+	start := s.pos
+	digits := func() {
+		for s.pos < len(s.input) && s.input[s.pos] >= '0' && s.input[s.pos] <= '9' {
+			s.pos++
+		}
+	}
+	digits()
+	// Integers can't have leading zeros.
+	if s.pos-start > 1 && s.input[start] == '0' {
+		return token{}, newSyntaxErrorf("trailing junk after numeric literal at or near %q of jsonpath input", s.input[start:start+2])
+	}
+	// A period is only part of the number if it is followed by a digit, so
+	// that accessors can be applied to integers, as in 1.type().
+	if s.pos+1 < len(s.input) && s.input[s.pos] == '.' &&
+		s.input[s.pos+1] >= '0' && s.input[s.pos+1] <= '9' {
+		s.pos++
+		digits()
+	}
+	if s.pos < len(s.input) && (s.input[s.pos] == 'e' || s.input[s.pos] == 'E') {
+		s.pos++
+		if s.pos < len(s.input) && (s.input[s.pos] == '+' || s.input[s.pos] == '-') {
+			s.pos++
+		}
+		expStart := s.pos
+		digits()
+		if s.pos == expStart {
+			return token{}, newSyntaxErrorf("trailing junk after numeric literal at or near %q of jsonpath input", s.input[start:s.pos])
+		}
+	}
+	if s.pos < len(s.input) {
+		if r, _ := utf8.DecodeRuneInString(s.input[s.pos:]); isIdentStart(r) {
+			return token{}, newSyntaxErrorf("trailing junk after numeric literal at or near %q of jsonpath input", s.input[start:s.pos+1])
+		}
+	}
+	return token{kind: tokNumber, val: s.input[start:s.pos]}, nil
+}
+
+// parser is a recursive descent parser for path expressions.
+type parser struct {
+	scanner
+	tok token
+	// filterDepth is the number of filters enclosing the current position,
+	// which determines whether @ may be used.
+	filterDepth int
+	// subscriptDepth is the number of array subscripts enclosing the current
+	// position, which determines whether last may be used.
+	subscriptDepth int
+}
+
+// Parse parses the given SQL/JSON path expression.
+func Parse(input string) (*Path, error) {
+	p := parser{scanner: scanner{input: input}}
+	return p.parse()
+}
+
+// MustParse parses the given SQL/JSON path expression and panics if it is
+// invalid.
+func MustParse(input string) *Path {
+	path, err := Parse(input)
+	if err != nil {
+		panic(err)
+	}
+	return path
+}
+
+func (p *parser) advance() error {
+	tok, err := p.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+// isOp returns true if the current token is the given operator.
+func (p *parser) isOp(op string) bool {
+	return p.tok.kind == tokOp && p.tok.val == op
+}
+
+// isKeyword returns true if the current token is the given keyword.
+func (p *parser) isKeyword(kw string) bool {
+	return p.tok.kind == tokIdent && strings.EqualFold(p.tok.val, kw)
+}
+
+func (p *parser) errorAtToken() error {
+	if p.tok.kind == tokEOF {
+		return newSyntaxErrorf("syntax error at end of jsonpath input")
+	}
+	return newSyntaxErrorf("syntax error at or near %q of jsonpath input", p.tok.val)
+}
+
+// expectOp consumes the given operator.
+func (p *parser) expectOp(op string) error {
+	if !p.isOp(op) {
+		return p.errorAtToken()
+	}
+	return p.advance()
+}
+
+// expectKeyword consumes the given keyword.
+func (p *parser) expectKeyword(kw string) error {
+	if !p.isKeyword(kw) {
+		return p.errorAtToken()
+	}
+	return p.advance()
+}
+
+func (p *parser) parse() (*Path, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	path := &Path{}
+	if p.isKeyword("strict") {
+		path.Strict = true
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	} else if p.isKeyword("lax") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, p.errorAtToken()
+	}
+	path.Expr = expr
+	return path, nil
+}
+
+// parsePredicate parses an expression which must be a predicate.
+func (p *parser) parsePredicate() (Expr, error) {
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !isPredicate(e) {
+		return nil, p.errorAtToken()
+	}
+	return e, nil
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("||") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if !isPredicate(left) || !isPredicate(right) {
+			return nil, p.errorAtToken()
+		}
+		left = &Binary{Op: OpOr, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("&&") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		if !isPredicate(left) || !isPredicate(right) {
+			return nil, p.errorAtToken()
+		}
+		left = &Binary{Op: OpAnd, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (Expr, error) {
+	if !p.isOp("!") {
+		return p.parseComparison()
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	operand, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	if !isPredicate(operand) {
+		return nil, p.errorAtToken()
+	}
+	return &Unary{Op: OpNot, Operand: operand}, nil
+}
+
+var comparisonOps = map[string]BinaryOp{
+	"==": OpEq,
+	"!=": OpNe,
+	"<>": OpNe,
+	"<":  OpLt,
+	"<=": OpLe,
+	">":  OpGt,
+	">=": OpGe,
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokOp {
+		if op, ok := comparisonOps[p.tok.val]; ok {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			right, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			return &Binary{Op: op, Left: left, Right: right}, nil
+		}
+	}
+	switch {
+	case p.isKeyword("starts"):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if err := p.expectKeyword("with"); err != nil {
+			return nil, err
+		}
+		// The prefix must be a string or a variable.
+		var right Expr
+		switch p.tok.kind {
+		case tokString:
+			right = &Scalar{Val: json.FromString(p.tok.val)}
+		case tokVariable:
+			right = &Variable{Name: p.tok.val}
+		default:
+			return nil, p.errorAtToken()
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return &Binary{Op: OpStartsWith, Left: left, Right: right}, nil
+
+	case p.isKeyword("like_regex"):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokString {
+			return nil, p.errorAtToken()
+		}
+		e := &LikeRegex{Operand: left, Pattern: p.tok.val}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.isKeyword("flag") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if p.tok.kind != tokString {
+				return nil, p.errorAtToken()
+			}
+			e.Flags = p.tok.val
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+		if err := e.compile(); err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+	return left, nil
+}
+
+func (p *parser) parseAdditive() (Expr, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("+") || p.isOp("-") {
+		op := OpAdd
+		if p.tok.val == "-" {
+			op = OpSub
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: op, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseMultiplicative() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("*") || p.isOp("/") || p.isOp("%") {
+		var op BinaryOp
+		switch p.tok.val {
+		case "*":
+			op = OpMul
+		case "/":
+			op = OpDiv
+		default:
+			op = OpMod
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: op, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	if !p.isOp("+") && !p.isOp("-") {
+		return p.parseAccessorExpr()
+	}
+	minus := p.tok.val == "-"
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	// Fold signs into numeric literals.
+	if s, ok := operand.(*Scalar); ok && s.Val.Type() == json.NumberJSONType {
+		if !minus {
+			return s, nil
+		}
+		d, _ := s.Val.AsDecimal()
+		var neg apd.Decimal
+		neg.Neg(d)
+		return &Scalar{Val: json.FromDecimal(neg)}, nil
+	}
+	op := OpPlus
+	if minus {
+		op = OpMinus
+	}
+	return &Unary{Op: op, Operand: operand}, nil
+}
+
+func (p *parser) parseAccessorExpr() (Expr, error) {
+	base, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	var accessors []Accessor
+	for {
+		var acc Accessor
+		switch {
+		case p.isOp("."):
+			acc, err = p.parseDotAccessor()
+		case p.isOp("["):
+			acc, err = p.parseArrayAccessor()
+		case p.isOp("?"):
+			acc, err = p.parseFilter()
+		default:
+			if len(accessors) == 0 {
+				return base, nil
+			}
+			return &Chain{Base: base, Accessors: accessors}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		accessors = append(accessors, acc)
+	}
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	var e Expr
+	switch p.tok.kind {
+	case tokVariable:
+		e = &Variable{Name: p.tok.val}
+	case tokString:
+		e = &Scalar{Val: json.FromString(p.tok.val)}
+	case tokNumber:
+		d, _, err := apd.NewFromString(p.tok.val)
+		if err != nil {
+			return nil, newSyntaxErrorf("invalid numeric literal %q", p.tok.val)
+		}
+		if d.Exponent > 0 {
+			// Numbers with a positive exponent, like 1e2, are formatted without
+			// the exponent, as in Postgres.
+			var scale apd.BigInt
+			scale.Exp(apd.NewBigInt(10), apd.NewBigInt(int64(d.Exponent)), nil /* m */)
+			d.Coeff.Mul(&d.Coeff, &scale)
+			d.Exponent = 0
+		}
+		e = &Scalar{Val: json.FromDecimal(*d)}
+	case tokOp:
+		switch p.tok.val {
+		case "$":
+			e = &Root{}
+		case "@":
+			if p.filterDepth == 0 {
+				return nil, newSyntaxErrorf("@ is not allowed in root expressions")
+			}
+			e = &Current{}
+		case "(":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			if isPredicate(inner) && p.isKeyword("is") {
+				if err := p.advance(); err != nil {
+					return nil, err
+				}
+				if err := p.expectKeyword("unknown"); err != nil {
+					return nil, err
+				}
+				return &Unary{Op: OpIsUnknown, Operand: inner}, nil
+			}
+			return inner, nil
+		default:
+			return nil, p.errorAtToken()
+		}
+	case tokIdent:
+		switch strings.ToLower(p.tok.val) {
+		case "null":
+			e = &Scalar{Val: json.NullJSONValue}
+		case "true":
+			e = &Scalar{Val: json.TrueJSONValue}
+		case "false":
+			e = &Scalar{Val: json.FalseJSONValue}
+		case "last":
+			if p.subscriptDepth == 0 {
+				return nil, newSyntaxErrorf("LAST is allowed only in array subscripts")
+			}
+			e = &Last{}
+		case "exists":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if err := p.expectOp("("); err != nil {
+				return nil, err
+			}
+			operand, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			return &Unary{Op: OpExists, Operand: operand}, nil
+		default:
+			return nil, p.errorAtToken()
+		}
+	default:
+		return nil, p.errorAtToken()
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+var methods = map[string]MethodName{
+	"type":     MethodType,
+	"size":     MethodSize,
+	"double":   MethodDouble,
+	"ceiling":  MethodCeiling,
+	"floor":    MethodFloor,
+	"abs":      MethodAbs,
+	"keyvalue": MethodKeyValue,
+}
+
+// parseDotAccessor parses an accessor which starts with a period: a member
+// accessor, a wildcard, a recursive wildcard, or an item method.
+func (p *parser) parseDotAccessor() (Accessor, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	switch {
+	case p.isOp("*"):
+		return &AnyKey{}, p.advance()
+	case p.isOp("**"):
+		return p.parseAnyPath()
+	case p.tok.kind == tokString:
+		name := p.tok.val
+		return &Key{Name: name}, p.advance()
+	case p.tok.kind != tokIdent:
+		return nil, p.errorAtToken()
+	}
+	name := p.tok.val
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if !p.isOp("(") {
+		return &Key{Name: name}, nil
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	m, ok := methods[name]
+	if !ok {
+		if name == "datetime" {
+			return nil, pgerror.New(pgcode.FeatureNotSupported,
+				"the jsonpath .datetime() method is not supported")
+		}
+		return nil, newSyntaxErrorf("syntax error at or near %q of jsonpath input", name)
+	}
+	if err := p.expectOp(")"); err != nil {
+		return nil, err
+	}
+	return &Method{Name: m}, nil
+}
+
+// parseAnyPath parses the optional levels of a .** accessor.
+func (p *parser) parseAnyPath() (Accessor, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	acc := &AnyPath{First: 0, Last: -1}
+	if !p.isOp("{") {
+		return acc, nil
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	first, err := p.parseLevel()
+	if err != nil {
+		return nil, err
+	}
+	acc.First, acc.Last = first, first
+	if p.isKeyword("to") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if acc.Last, err = p.parseLevel(); err != nil {
+			return nil, err
+		}
+	}
+	return acc, p.expectOp("}")
+}
+
+// parseLevel parses a nesting level of a .** accessor, which is a
+// non-negative integer or last. Last is returned as -1.
+func (p *parser) parseLevel() (int, error) {
+	if p.isKeyword("last") {
+		return -1, p.advance()
+	}
+	if p.tok.kind != tokNumber {
+		return 0, p.errorAtToken()
+	}
+	level, err := strconv.Atoi(p.tok.val)
+	if err != nil || level < 0 {
+		return 0, p.errorAtToken()
+	}
+	return level, p.advance()
+}
+
+// parseArrayAccessor parses a [*] or [subscripts] accessor.
+func (p *parser) parseArrayAccessor() (Accessor, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.isOp("*") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return &AnyIndex{}, p.expectOp("]")
+	}
+	p.subscriptDepth++
+	defer func() { p.subscriptDepth-- }()
+	acc := &Index{}
+	for {
+		from, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		sub := Subscript{From: from}
+		if p.isKeyword("to") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			if sub.To, err = p.parseAdditive(); err != nil {
+				return nil, err
+			}
+		}
+		acc.Subscripts = append(acc.Subscripts, sub)
+		if !p.isOp(",") {
+			break
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	return acc, p.expectOp("]")
+}
+
+// parseFilter parses a ?(predicate) accessor.
+func (p *parser) parseFilter() (Accessor, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if err := p.expectOp("("); err != nil {
+		return nil, err
+	}
+	p.filterDepth++
+	defer func() { p.filterDepth-- }()
+	pred, err := p.parsePredicate()
+	if err != nil {
+		return nil, err
+	}
+	return &Filter{Pred: pred}, p.expectOp(")")
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package jsonpath
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+var alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// RandomPath returns a random Path for testing.
+func RandomPath(rng *rand.Rand) Path {
+	for {
+		var sb strings.Builder
+		if rng.Intn(2) == 0 {
+			sb.WriteString("strict ")
+		}
+		sb.WriteString("$")
+		nAccessors := rng.Intn(5)
+		for i := 0; i < nAccessors; i++ {
+			switch rng.Intn(5) {
+			case 0:
+				sb.WriteString(".*")
+			case 1:
+				sb.WriteString("[*]")
+			case 2:
+				sb.WriteString("[")
+				sb.WriteString(strconv.Itoa(rng.Intn(10)))
+				sb.WriteString("]")
+			case 3:
+				sb.WriteString(" ? (@ > ")
+				sb.WriteString(strconv.Itoa(rng.Intn(100)))
+				sb.WriteString(")")
+			default:
+				l := make([]byte, 1+rng.Intn(10))
+				for i := range l {
+					l[i] = alphabet[rng.Intn(len(alphabet))]
+				}
+				sb.WriteString(".")
+				sb.Write(l)
+			}
+		}
+		path, err := Parse(sb.String())
+		if err != nil {
+			continue
+		}
+		return *path
+	}
+}
@@ -10,7 +10,17 @@
 
 package tsearch
 
-import "strings"
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/blevesearch/snowballstem"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/errors"
+)
 
 // ValidConfig returns an error if the input string is not a supported and valid
 // text search config.
@@ -24,8 +34,373 @@ func ValidConfig(input string) error {
 // and stopwords from an input config value. This is simulating the more
 // advanced customizable dictionaries and configs that Postgres has, which
 // allows user-defined text search configurations: because of this, configs can
-// have schema prefixes. Because we don't (yet?) allow this, we just have to
-// trim off any `pg_catalog.` prefix if it exists.
+// have schema prefixes. The built-in configs live in pg_catalog, so we just
+// have to trim off any `pg_catalog.` prefix if it exists.
 func GetConfigKey(config string) string {
 	return strings.TrimPrefix(config, "pg_catalog.")
 }
+
+// TokenType is the type of a token produced by TSParse. A text search
+// configuration maps each token type to the dictionaries which normalize the
+// tokens of that type. The values match the token IDs of the Postgres default
+// parser.
+type TokenType int
+
+const (
+	// TokenASCIIWord is a word of ASCII letters.
+	TokenASCIIWord TokenType = 1
+	// TokenWord is a word of letters, some of which are not ASCII.
+	TokenWord TokenType = 2
+	// TokenNumWord is a word of letters and digits.
+	TokenNumWord TokenType = 3
+	// TokenUint is an unsigned integer.
+	TokenUint TokenType = 19
+)
+
+// TokenTypes are the token types produced by TSParse.
+var TokenTypes = []TokenType{TokenASCIIWord, TokenWord, TokenNumWord, TokenUint}
+
+// String implements the fmt.Stringer interface.
+func (t TokenType) String() string {
+	switch t {
+	case TokenASCIIWord:
+		return "asciiword"
+	case TokenWord:
+		return "word"
+	case TokenNumWord:
+		return "numword"
+	case TokenUint:
+		return "uint"
+	}
+	return "unknown"
+}
+
+// ParseTokenType returns the token type with the given name.
+func ParseTokenType(name string) (TokenType, error) {
+	for _, t := range TokenTypes {
+		if t.String() == name {
+			return t, nil
+		}
+	}
+	return 0, pgerror.Newf(pgcode.InvalidParameterValue,
+		"token type %q does not exist", name)
+}
+
+// tokenTypeOf returns the type of a token produced by TSParse.
+func tokenTypeOf(token string) TokenType {
+	hasDigit, hasLetter, ascii := false, false, true
+	for _, r := range token {
+		if unicode.IsDigit(r) {
+			hasDigit = true
+		} else {
+			hasLetter = true
+		}
+		if r > unicode.MaxASCII {
+			ascii = false
+		}
+	}
+	switch {
+	case !hasLetter:
+		return TokenUint
+	case hasDigit:
+		return TokenNumWord
+	case ascii:
+		return TokenASCIIWord
+	}
+	return TokenWord
+}
+
+// DictTemplate is the template of a text search dictionary, which determines
+// how the dictionary normalizes tokens.
+type DictTemplate int
+
+const (
+	// DictTemplateSimple lowercases tokens and discards stopwords.
+	DictTemplateSimple DictTemplate = iota + 1
+	// DictTemplateSnowball discards stopwords and stems the remaining tokens
+	// with a snowball stemmer.
+	DictTemplateSnowball
+	// DictTemplateSynonym replaces tokens with their synonyms.
+	DictTemplateSynonym
+)
+
+// String implements the fmt.Stringer interface.
+func (t DictTemplate) String() string {
+	switch t {
+	case DictTemplateSimple:
+		return "simple"
+	case DictTemplateSnowball:
+		return "snowball"
+	case DictTemplateSynonym:
+		return "synonym"
+	}
+	return "unknown"
+}
+
+// ParseDictTemplate returns the dictionary template with the given name.
+func ParseDictTemplate(name string) (DictTemplate, error) {
+	for _, t := range []DictTemplate{DictTemplateSimple, DictTemplateSnowball, DictTemplateSynonym} {
+		if t.String() == name {
+			return t, nil
+		}
+	}
+	return 0, pgerror.Newf(pgcode.UndefinedObject,
+		"text search template %q does not exist", name)
+}
+
+// Dictionary is a text search dictionary, which normalizes tokens into
+// lexemes.
+type Dictionary struct {
+	Template DictTemplate
+	// Language is the language of the stemmer of a snowball dictionary.
+	Language string
+	// StopWords are the lowercase words which are discarded by a simple or
+	// snowball dictionary.
+	StopWords map[string]struct{}
+	// Synonyms maps the lowercase words recognized by a synonym dictionary to
+	// their synonyms.
+	Synonyms map[string]string
+	// Reject is set for a simple dictionary which does not accept the words
+	// which are not stopwords, so that they are passed on to the next
+	// dictionary of the mapping.
+	Reject bool
+}
+
+// ValidLanguage returns an error if there is no snowball stemmer for the
+// given language.
+func ValidLanguage(language string) error {
+	if language != "simple" {
+		if _, err := getStemmer(language); err == nil {
+			return nil
+		}
+	}
+	return pgerror.Newf(pgcode.InvalidParameterValue,
+		"no snowball stemmer for language %q", language)
+}
+
+// BuiltinStopWords returns the built-in stopword list with the given name,
+// such as "english".
+func BuiltinStopWords(name string) (map[string]struct{}, error) {
+	stopwords, ok := stopwordsMap[name]
+	if !ok || name == "simple" {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"text search stopword list %q does not exist", name)
+	}
+	return stopwords, nil
+}
+
+// ParseStopWordList parses a list of stopwords separated by commas or
+// whitespace.
+func ParseStopWordList(list string) map[string]struct{} {
+	words := strings.FieldsFunc(list, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	stopwords := make(map[string]struct{}, len(words))
+	for _, w := range words {
+		stopwords[strings.ToLower(w)] = struct{}{}
+	}
+	return stopwords
+}
+
+// ParseSynonyms parses a list of synonyms separated by commas or newlines.
+// Each entry is a word followed by its synonym, separated by whitespace.
+func ParseSynonyms(list string) (map[string]string, error) {
+	entries := strings.FieldsFunc(list, func(r rune) bool {
+		return r == ',' || r == '\n'
+	})
+	synonyms := make(map[string]string, len(entries))
+	for _, e := range entries {
+		fields := strings.Fields(e)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid synonym entry %q: expected a word and its synonym", strings.TrimSpace(e))
+		}
+		synonyms[strings.ToLower(fields[0])] = strings.ToLower(fields[1])
+	}
+	return synonyms, nil
+}
+
+// BuiltinDictionary returns the built-in dictionary with the given name. The
+// built-in dictionaries are "simple", which lowercases tokens, and the
+// snowball dictionaries named after their language, such as "english_stem".
+func BuiltinDictionary(name string) (*Dictionary, bool) {
+	if name == "simple" {
+		return &Dictionary{Template: DictTemplateSimple}, true
+	}
+	language := strings.TrimSuffix(name, "_stem")
+	if language == name || ValidLanguage(language) != nil {
+		return nil, false
+	}
+	return &Dictionary{
+		Template:  DictTemplateSnowball,
+		Language:  language,
+		StopWords: stopwordsMap[language],
+	}, true
+}
+
+// BuiltinConfigDictionary returns the name of the built-in dictionary which
+// all the token types of the given built-in configuration are mapped to.
+func BuiltinConfigDictionary(config string) (string, error) {
+	if err := ValidConfig(config); err != nil {
+		return "", err
+	}
+	config = GetConfigKey(config)
+	if config == "simple" {
+		return config, nil
+	}
+	return config + "_stem", nil
+}
+
+// lexize normalizes a token. It returns false if the dictionary does not
+// recognize the token, which is then passed on to the next dictionary of
+// the mapping. An empty lexeme is returned for a stopword.
+func (d *Dictionary) lexize(token string) (lexeme string, ok bool, _ error) {
+	lower := strings.ToLower(token)
+	switch d.Template {
+	case DictTemplateSimple:
+		if _, ok := d.StopWords[lower]; ok {
+			return "", true, nil
+		}
+		if d.Reject {
+			return "", false, nil
+		}
+		return lower, true, nil
+	case DictTemplateSnowball:
+		if _, ok := d.StopWords[lower]; ok {
+			return "", true, nil
+		}
+		stemmer, err := getStemmer(d.Language)
+		if err != nil {
+			return "", false, err
+		}
+		env := snowballstem.NewEnv(lower)
+		stemmer(env)
+		return env.Current(), true, nil
+	case DictTemplateSynonym:
+		synonym, ok := d.Synonyms[lower]
+		return synonym, ok, nil
+	}
+	return "", false, errors.AssertionFailedf("unknown dictionary template %d", d.Template)
+}
+
+// Config is a text search configuration, which determines how a document or
+// query is normalized into lexemes. Each token of the input is normalized by
+// the first dictionary mapped to its type which recognizes it. Tokens which
+// are not recognized are discarded.
+type Config struct {
+	mappings map[TokenType][]*Dictionary
+	weights  map[TokenType]tsWeight
+}
+
+// NewConfig returns a text search configuration without any mappings.
+func NewConfig() *Config {
+	return &Config{mappings: make(map[TokenType][]*Dictionary)}
+}
+
+// AddMapping maps a token type to the given dictionaries, which are consulted
+// in order.
+func (c *Config) AddMapping(t TokenType, dicts ...*Dictionary) {
+	c.mappings[t] = dicts
+}
+
+// SetWeight sets the weight of the lexemes produced from tokens of the given
+// type, which is one of A, B, C or D. The default weight is D.
+func (c *Config) SetWeight(t TokenType, weight string) error {
+	w, err := parseWeight(weight)
+	if err != nil {
+		return err
+	}
+	if w == weightD {
+		// Positions without a weight have the default weight.
+		delete(c.weights, t)
+		return nil
+	}
+	if c.weights == nil {
+		c.weights = make(map[TokenType]tsWeight)
+	}
+	c.weights[t] = w
+	return nil
+}
+
+// ValidWeight returns an error if the input string is not a valid lexeme
+// weight.
+func ValidWeight(weight string) error {
+	_, err := parseWeight(weight)
+	return err
+}
+
+func parseWeight(weight string) (tsWeight, error) {
+	switch strings.ToUpper(weight) {
+	case "A":
+		return weightA, nil
+	case "B":
+		return weightB, nil
+	case "C":
+		return weightC, nil
+	case "D":
+		return weightD, nil
+	}
+	return 0, pgerror.Newf(pgcode.InvalidParameterValue, "unrecognized weight: %q", weight)
+}
+
+// lexize normalizes a token with the dictionaries mapped to its type. It
+// returns false if none of them recognize the token. An empty lexeme is
+// returned for a stopword.
+func (c *Config) lexize(token string) (lexeme string, weight tsWeight, ok bool, _ error) {
+	t := tokenTypeOf(token)
+	for _, d := range c.mappings[t] {
+		lexeme, ok, err := d.lexize(token)
+		if err != nil || ok {
+			return lexeme, c.weights[t], ok, err
+		}
+	}
+	return "", 0, false, nil
+}
+
+var builtinConfigs struct {
+	once    sync.Once
+	configs map[string]*Config
+}
+
+// BuiltinConfig returns the built-in text search configuration with the
+// given name, such as "english", which maps every token type to the built-in
+// dictionary of the same language.
+func BuiltinConfig(name string) (*Config, error) {
+	builtinConfigs.once.Do(func() {
+		builtinConfigs.configs = make(map[string]*Config, len(stopwordsMap))
+		for config := range stopwordsMap {
+			dictName, err := BuiltinConfigDictionary(config)
+			if err != nil {
+				// There are stopwords for some languages without a stemmer.
+				continue
+			}
+			d, _ := BuiltinDictionary(dictName)
+			c := NewConfig()
+			for _, t := range TokenTypes {
+				c.AddMapping(t, d)
+			}
+			builtinConfigs.configs[config] = c
+		}
+	})
+	c, ok := builtinConfigs.configs[name]
+	if !ok {
+		return nil, pgerror.Newf(pgcode.UndefinedObject, "text search configuration %q does not exist", name)
+	}
+	return c, nil
+}
+
+// BuiltinConfigNames returns the names of the built-in text search
+// configurations in sorted order.
+func BuiltinConfigNames() []string {
+	// Looking up a configuration initializes the built-in configurations.
+	_, _ = BuiltinConfig("simple")
+	names := make([]string, 0, len(builtinConfigs.configs))
+	for name := range builtinConfigs.configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig(t *testing.T) {
+	synonyms, err := ParseSynonyms("postgres pgsql, crdb cockroach")
+	require.NoError(t, err)
+	english, ok := BuiltinDictionary("english_stem")
+	require.True(t, ok)
+	c := NewConfig()
+	c.AddMapping(TokenASCIIWord, &Dictionary{Template: DictTemplateSynonym, Synonyms: synonyms}, english)
+	c.AddMapping(TokenNumWord, &Dictionary{
+		Template:  DictTemplateSimple,
+		StopWords: ParseStopWordList("v1 v2"),
+	})
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		// Stopwords and tokens of unmapped types are discarded.
+		{input: "The Postgres databases 42", expected: "'databas':3 'pgsql':2"},
+		{input: "CRDB v1 v3", expected: "'cockroach':1 'v3':3"},
+	}
+	for _, tt := range tests {
+		v, err := DocumentToTSVector(c, tt.input)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, v.String())
+	}
+
+	require.NoError(t, c.SetWeight(TokenASCIIWord, "a"))
+	v, err := DocumentToTSVector(c, "CRDB v1 v3")
+	require.NoError(t, err)
+	assert.Equal(t, "'cockroach':1A 'v3':3", v.String())
+
+	q, err := PlainToTSQuery(c, "the postgres")
+	require.NoError(t, err)
+	assert.Equal(t, "'pgsql'", q.String())
+
+	assert.Error(t, c.SetWeight(TokenASCIIWord, "e"))
+}
+
+func TestConfigWeightsAffectRank(t *testing.T) {
+	english, err := BuiltinConfig("english")
+	require.NoError(t, err)
+	weighted := NewConfig()
+	for _, typ := range TokenTypes {
+		weighted.AddMapping(typ, english.mappings[typ]...)
+		require.NoError(t, weighted.SetWeight(typ, "A"))
+	}
+	q, err := ToTSQuery(english, "rat")
+	require.NoError(t, err)
+
+	var ranks []float32
+	for _, c := range []*Config{english, weighted} {
+		v, err := DocumentToTSVector(c, "a fat rat")
+		require.NoError(t, err)
+		rank, err := Rank(nil /* weights */, v, q, 0 /* method */)
+		require.NoError(t, err)
+		ranks = append(ranks, rank)
+	}
+	assert.Less(t, ranks[0], ranks[1])
+}
+
+func TestParseTokenType(t *testing.T) {
+	for _, typ := range TokenTypes {
+		actual, err := ParseTokenType(typ.String())
+		require.NoError(t, err)
+		assert.Equal(t, typ, actual)
+	}
+	_, err := ParseTokenType("email")
+	assert.Error(t, err)
+}
@@ -18,7 +18,6 @@ import (
 	"unicode"
 	"unicode/utf8"
 
-	"github.com/blevesearch/snowballstem"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/errors"
@@ -402,40 +401,35 @@ func TSParse(input string) []string {
 }
 
 // TSLexize implements the "dictionary" construct that's exposed via ts_lexize.
-// It gets invoked once per input token to produce an output lexeme during
-// routines like to_tsvector and to_tsquery.
+// It normalizes a token with the built-in text search configuration of the
+// given name.
 // It can return true in the second parameter to indicate a stopword was found.
 func TSLexize(config string, token string) (lexeme string, stopWord bool, err error) {
-	stopwords, ok := stopwordsMap[config]
-	if !ok {
-		return "", false, pgerror.Newf(pgcode.UndefinedObject, "text search configuration %q does not exist", config)
-	}
-
-	lower := strings.ToLower(token)
-	if _, ok := stopwords[lower]; ok {
-		return "", true, nil
+	c, err := BuiltinConfig(config)
+	if err != nil {
+		return "", false, err
 	}
-	stemmer, err := getStemmer(config)
+	lexeme, _, ok, err := c.lexize(token)
 	if err != nil {
 		return "", false, err
 	}
-	env := snowballstem.NewEnv(lower)
-	stemmer(env)
-	return env.Current(), false, nil
+	return lexeme, !ok || lexeme == "", nil
 }
 
 // DocumentToTSVector parses an input document into lexemes, removes stop words,
 // stems and normalizes the lexemes, and returns a TSVector annotated with
-// lexeme positions according to a text search configuration passed by name.
-func DocumentToTSVector(config string, input string) (TSVector, error) {
+// lexeme positions and weights according to a text search configuration.
+func DocumentToTSVector(config *Config, input string) (TSVector, error) {
 	tokens := TSParse(input)
 	vector := make(TSVector, 0, len(tokens))
 	for i := range tokens {
-		lexeme, stopWord, err := TSLexize(config, tokens[i])
+		lexeme, weight, ok, err := config.lexize(tokens[i])
 		if err != nil {
 			return nil, err
 		}
-		if stopWord {
+		if !ok || lexeme == "" {
+			// Tokens which are not recognized by any dictionary are discarded
+			// like stopwords.
 			continue
 		}
 
@@ -445,7 +439,7 @@ func DocumentToTSVector(config string, input string) (TSVector, error) {
 			// Postgres silently truncates positions larger than 16383 to 16383.
 			pos = maxTSVectorPosition
 		}
-		term.positions = []tsPosition{{position: uint16(pos)}}
+		term.positions = []tsPosition{{position: uint16(pos), weight: weight}}
 		vector = append(vector, term)
 	}
 	return normalizeTSVector(vector)
@@ -435,6 +435,12 @@ func DatumToGoSQL(d tree.Datum) (interface{}, error) {
 		return d.String(), nil
 	case *tree.DTSVector:
 		return d.String(), nil
+	case *tree.DJSONPath:
+		return d.Path.String(), nil
+	case *tree.DRange:
+		return d.String(), nil
+	case *tree.DMultirange:
+		return d.String(), nil
 	}
 	return nil, errors.Errorf("unhandled datum type: %s", reflect.TypeOf(d))
 }